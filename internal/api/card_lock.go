@@ -0,0 +1,193 @@
+package api
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// cardLockTTL is how long a card lock lasts. Clients holding a lock renew it
+// by acquiring it again before it runs out.
+const cardLockTTL = 30 * time.Second
+
+// Session identification for card locks. The header wins over the cookie.
+const (
+	sessionHeader = "X-Session-ID"
+	sessionCookie = "kan_session"
+)
+
+// LockEntry records which session holds a card lock and until when.
+type LockEntry struct {
+	SessionID       string
+	ExpiresAtMillis int64
+}
+
+// LockRegistry holds short-lived, in-memory card locks so that two browser
+// tabs editing the same card don't overwrite each other. Locks are advisory:
+// only UpdateCard checks them, and they are lost when the server restarts.
+type LockRegistry struct {
+	locks sync.Map // board + "/" + card ID -> LockEntry
+	now   func() time.Time
+}
+
+// NewLockRegistry creates an empty lock registry.
+func NewLockRegistry() *LockRegistry {
+	return &LockRegistry{now: time.Now}
+}
+
+func lockKey(board, cardID string) string {
+	return board + "/" + cardID
+}
+
+// Acquire takes or renews the lock on a card for the session. If another
+// session holds an unexpired lock, Acquire returns that lock and false.
+func (r *LockRegistry) Acquire(board, cardID, sessionID string) (LockEntry, bool) {
+	key := lockKey(board, cardID)
+	now := r.now()
+	entry := LockEntry{SessionID: sessionID, ExpiresAtMillis: now.Add(cardLockTTL).UnixMilli()}
+	for {
+		existing, loaded := r.locks.LoadOrStore(key, entry)
+		if !loaded {
+			return entry, true
+		}
+		held := existing.(LockEntry)
+		if held.SessionID != sessionID && !r.expired(held, now) {
+			return held, false
+		}
+		// Renewing our own lock or replacing an expired one. Retry if the
+		// entry changed underneath us.
+		if r.locks.CompareAndSwap(key, held, entry) {
+			return entry, true
+		}
+	}
+}
+
+// Release drops the session's lock on a card. Releasing a card that isn't
+// locked is a no-op. If another session holds an unexpired lock, Release
+// leaves it in place and returns that lock and false.
+func (r *LockRegistry) Release(board, cardID, sessionID string) (LockEntry, bool) {
+	key := lockKey(board, cardID)
+	existing, ok := r.locks.Load(key)
+	if !ok {
+		return LockEntry{}, true
+	}
+	held := existing.(LockEntry)
+	if held.SessionID != sessionID && !r.expired(held, r.now()) {
+		return held, false
+	}
+	r.locks.CompareAndDelete(key, held)
+	return LockEntry{}, true
+}
+
+// HeldByOther returns the lock on a card if a session other than sessionID
+// holds it and it hasn't expired.
+func (r *LockRegistry) HeldByOther(board, cardID, sessionID string) (LockEntry, bool) {
+	existing, ok := r.locks.Load(lockKey(board, cardID))
+	if !ok {
+		return LockEntry{}, false
+	}
+	held := existing.(LockEntry)
+	if held.SessionID == sessionID || r.expired(held, r.now()) {
+		return LockEntry{}, false
+	}
+	return held, true
+}
+
+// EvictExpired removes every expired lock.
+func (r *LockRegistry) EvictExpired() {
+	now := r.now()
+	r.locks.Range(func(key, value any) bool {
+		if r.expired(value.(LockEntry), now) {
+			r.locks.CompareAndDelete(key, value)
+		}
+		return true
+	})
+}
+
+// RunEviction evicts expired locks every cardLockTTL until stop is closed.
+// Expired locks are already ignored, so this only bounds memory.
+func (r *LockRegistry) RunEviction(stop <-chan struct{}) {
+	ticker := time.NewTicker(cardLockTTL)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			r.EvictExpired()
+		case <-stop:
+			return
+		}
+	}
+}
+
+func (r *LockRegistry) expired(entry LockEntry, now time.Time) bool {
+	return now.UnixMilli() >= entry.ExpiresAtMillis
+}
+
+// requestSessionID returns the session a request comes from, or "" if it
+// doesn't identify one.
+func requestSessionID(r *http.Request) string {
+	if id := r.Header.Get(sessionHeader); id != "" {
+		return id
+	}
+	if cookie, err := r.Cookie(sessionCookie); err == nil {
+		return cookie.Value
+	}
+	return ""
+}
+
+// CardLockResponse describes a card lock.
+type CardLockResponse struct {
+	LockedBy        string `json:"locked_by"`
+	ExpiresAtMillis int64  `json:"expires_at_millis"`
+}
+
+// writeCardLocked writes a 423 Locked response naming the lock holder.
+func writeCardLocked(w http.ResponseWriter, lock LockEntry) {
+	JSON(w, http.StatusLocked, map[string]any{
+		"error":             "card is locked by another session",
+		"locked_by":         lock.SessionID,
+		"expires_at_millis": lock.ExpiresAtMillis,
+	})
+}
+
+// LockCard acquires or renews a lock on a card for the requesting session.
+func (h *Handler) LockCard(w http.ResponseWriter, r *http.Request) {
+	boardName := r.PathValue("board")
+
+	sessionID := requestSessionID(r)
+	if sessionID == "" {
+		BadRequest(w, "missing session: set the "+sessionHeader+" header or the "+sessionCookie+" cookie")
+		return
+	}
+
+	card, err := h.ctx().CardService.FindByIDOrAlias(boardName, r.PathValue("id"))
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	lock, ok := h.locks.Acquire(boardName, card.ID, sessionID)
+	if !ok {
+		writeCardLocked(w, lock)
+		return
+	}
+	JSON(w, http.StatusOK, CardLockResponse{LockedBy: lock.SessionID, ExpiresAtMillis: lock.ExpiresAtMillis})
+}
+
+// UnlockCard releases the requesting session's lock on a card.
+func (h *Handler) UnlockCard(w http.ResponseWriter, r *http.Request) {
+	boardName := r.PathValue("board")
+
+	card, err := h.ctx().CardService.FindByIDOrAlias(boardName, r.PathValue("id"))
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	if lock, ok := h.locks.Release(boardName, card.ID, requestSessionID(r)); !ok {
+		writeCardLocked(w, lock)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,133 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+// newTestLockRegistry returns a registry whose clock is advanced by the
+// returned function.
+func newTestLockRegistry() (*LockRegistry, func(time.Duration)) {
+	now := time.UnixMilli(1_700_000_000_000)
+	r := NewLockRegistry()
+	r.now = func() time.Time { return now }
+	return r, func(d time.Duration) { now = now.Add(d) }
+}
+
+func TestLockRegistry_Acquire(t *testing.T) {
+	r, advance := newTestLockRegistry()
+
+	lock, ok := r.Acquire("main", "c1", "tab-a")
+	if !ok || lock.SessionID != "tab-a" {
+		t.Fatalf("Acquire = %+v, %v; want lock for tab-a", lock, ok)
+	}
+	if want := r.now().Add(cardLockTTL).UnixMilli(); lock.ExpiresAtMillis != want {
+		t.Errorf("ExpiresAtMillis = %d, want %d", lock.ExpiresAtMillis, want)
+	}
+
+	// Re-acquiring renews the lock.
+	advance(10 * time.Second)
+	renewed, ok := r.Acquire("main", "c1", "tab-a")
+	if !ok || renewed.ExpiresAtMillis != lock.ExpiresAtMillis+10_000 {
+		t.Errorf("renewed Acquire = %+v, %v; want expiry pushed back 10s", renewed, ok)
+	}
+
+	// The same card ID on another board is a separate lock.
+	if _, ok := r.Acquire("other", "c1", "tab-b"); !ok {
+		t.Error("Expected lock on another board to be independent")
+	}
+}
+
+func TestLockRegistry_Conflict(t *testing.T) {
+	r, _ := newTestLockRegistry()
+	r.Acquire("main", "c1", "tab-a")
+
+	held, ok := r.Acquire("main", "c1", "tab-b")
+	if ok || held.SessionID != "tab-a" {
+		t.Errorf("Acquire by tab-b = %+v, %v; want conflict with tab-a", held, ok)
+	}
+	if _, locked := r.HeldByOther("main", "c1", "tab-b"); !locked {
+		t.Error("Expected card to be locked for tab-b")
+	}
+	if _, locked := r.HeldByOther("main", "c1", "tab-a"); locked {
+		t.Error("Expected the holder not to be locked out")
+	}
+	if _, ok := r.Release("main", "c1", "tab-b"); ok {
+		t.Error("Expected tab-b not to release tab-a's lock")
+	}
+}
+
+func TestLockRegistry_Expiry(t *testing.T) {
+	r, advance := newTestLockRegistry()
+	r.Acquire("main", "c1", "tab-a")
+
+	advance(cardLockTTL)
+	if _, locked := r.HeldByOther("main", "c1", "tab-b"); locked {
+		t.Error("Expected expired lock to be ignored")
+	}
+	if lock, ok := r.Acquire("main", "c1", "tab-b"); !ok || lock.SessionID != "tab-b" {
+		t.Errorf("Acquire after expiry = %+v, %v; want lock for tab-b", lock, ok)
+	}
+
+	advance(cardLockTTL)
+	r.EvictExpired()
+	if _, ok := r.locks.Load(lockKey("main", "c1")); ok {
+		t.Error("Expected EvictExpired to remove the expired lock")
+	}
+}
+
+func TestLockRegistry_Release(t *testing.T) {
+	r, _ := newTestLockRegistry()
+	r.Acquire("main", "c1", "tab-a")
+
+	if _, ok := r.Release("main", "c1", "tab-a"); !ok {
+		t.Fatal("Expected holder to release its lock")
+	}
+	if _, ok := r.Acquire("main", "c1", "tab-b"); !ok {
+		t.Error("Expected card to be free after release")
+	}
+	if _, ok := r.Release("main", "missing", "tab-a"); !ok {
+		t.Error("Expected releasing an unlocked card to succeed")
+	}
+}
+
+func TestHandler_CardLock(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+	card := createCardFromResponse(t, api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "Shared"}))
+	path := "/api/v1/boards/main/cards/" + card.ID
+	tabA := map[string]string{sessionHeader: "tab-a"}
+	tabB := map[string]string{sessionHeader: "tab-b"}
+
+	if w := api.request("POST", path+"/lock", nil); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 without a session, got %d", w.Code)
+	}
+
+	w := api.requestWithHeaders("POST", path+"/lock", nil, tabA)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200 acquiring lock, got %d: %s", w.Code, w.Body.String())
+	}
+	var lock CardLockResponse
+	decodeJSON(t, w, &lock)
+	if lock.LockedBy != "tab-a" || lock.ExpiresAtMillis == 0 {
+		t.Errorf("Expected lock held by tab-a, got %+v", lock)
+	}
+
+	if w := api.requestWithHeaders("POST", path+"/lock", nil, tabB); w.Code != http.StatusLocked {
+		t.Errorf("Expected 423 for a second session, got %d", w.Code)
+	}
+	if w := api.requestWithHeaders("PUT", path, map[string]any{"title": "From B"}, tabB); w.Code != http.StatusLocked {
+		t.Errorf("Expected 423 updating a card locked by another session, got %d", w.Code)
+	}
+	if w := api.requestWithHeaders("PUT", path, map[string]any{"title": "From A"}, tabA); w.Code != http.StatusOK {
+		t.Errorf("Expected holder's update to succeed, got %d: %s", w.Code, w.Body.String())
+	}
+
+	if w := api.requestWithHeaders("DELETE", path+"/lock", nil, tabA); w.Code != http.StatusNoContent {
+		t.Fatalf("Expected 204 releasing lock, got %d", w.Code)
+	}
+	if w := api.requestWithHeaders("PUT", path, map[string]any{"title": "From B"}, tabB); w.Code != http.StatusOK {
+		t.Errorf("Expected update to succeed after release, got %d: %s", w.Code, w.Body.String())
+	}
+}
@@ -14,14 +14,18 @@ import (
 // ProjectContext bundles all per-project dependencies needed by the HTTP handlers.
 // The Handler holds one of these and can swap it out on project switch.
 type ProjectContext struct {
-	Paths        *config.Paths
-	BoardStore   store.BoardStore
-	CardStore    store.CardStore
-	ProjectStore store.ProjectStore
-	CardService  *service.CardService
-	BoardService *service.BoardService
-	Creator      string
-	ProjectRoot  string
+	Paths            *config.Paths
+	BoardStore       store.BoardStore
+	CardStore        store.CardStore
+	ProjectStore     store.ProjectStore
+	AuditStore       store.AuditStore
+	HookHistoryStore store.HookHistoryStore
+	CardService      *service.CardService
+	BoardService     *service.BoardService
+	SearchService    *service.SearchService
+	HookService      *service.HookService
+	Creator          string
+	ProjectRoot      string
 }
 
 // BuildProjectContext creates a fully-wired ProjectContext from a project root path
@@ -47,9 +51,12 @@ func BuildProjectContext(projectRoot, dataLocation, creator string) (*ProjectCon
 		return nil, err
 	}
 
-	boardStore := store.NewBoardStore(paths)
+	// The server reads board configs on nearly every request; cache them.
+	boardStore := store.NewCachingBoardStore(store.NewBoardStore(paths), paths)
 	cardStore := store.NewCardStore(paths)
 	projectStore := store.NewProjectStore(paths)
+	auditStore := store.NewAuditStore(paths)
+	hookHistoryStore := store.NewHookHistoryStore(paths)
 
 	// Ensure project config exists with ID and current schema.
 	// Uses raw file I/O, so it's safe to call before store reads.
@@ -58,26 +65,48 @@ func BuildProjectContext(projectRoot, dataLocation, creator string) (*ProjectCon
 		log.Printf("Warning: failed to initialize project config for %s: %v", projectRoot, err)
 	}
 
-	aliasService := service.NewAliasService(cardStore)
+	aliasService := service.NewAliasService(cardStore, boardStore)
 	boardService := service.NewBoardService(boardStore, cardStore)
 	cardService := service.NewCardService(cardStore, boardStore, aliasService)
+	boardService.SetCardService(cardService)
+	searchService := service.NewSearchService(cardStore, boardStore)
 
 	// Set up hook service for pattern hooks
 	hookService := service.NewHookService(projectRoot)
+	hookService.SetHistoryStore(hookHistoryStore)
 	cardService.SetHookService(hookService)
+	cardService.SetAuditStore(auditStore, func() string { return creator })
 
 	return &ProjectContext{
-		Paths:        paths,
-		BoardStore:   boardStore,
-		CardStore:    cardStore,
-		ProjectStore: projectStore,
-		CardService:  cardService,
-		BoardService: boardService,
-		Creator:      creator,
-		ProjectRoot:  projectRoot,
+		Paths:            paths,
+		BoardStore:       boardStore,
+		CardStore:        cardStore,
+		ProjectStore:     projectStore,
+		AuditStore:       auditStore,
+		HookHistoryStore: hookHistoryStore,
+		CardService:      cardService,
+		BoardService:     boardService,
+		SearchService:    searchService,
+		HookService:      hookService,
+		Creator:          creator,
+		ProjectRoot:      projectRoot,
 	}, nil
 }
 
+// Close releases the context's resources (the board config watcher and the
+// hook task janitor). It's called when the server switches away from the
+// project.
+func (c *ProjectContext) Close() {
+	if c.HookService != nil {
+		c.HookService.Close()
+	}
+	if closer, ok := c.BoardStore.(interface{ Close() error }); ok {
+		if err := closer.Close(); err != nil {
+			log.Printf("Warning: failed to close board store for %s: %v", c.ProjectRoot, err)
+		}
+	}
+}
+
 // autoMigrateProject checks a project's boards and cards for outdated schemas
 // and migrates transparently. Returns an error for future versions (user needs
 // a newer Kan) or if migration fails.
@@ -96,10 +125,11 @@ func autoMigrateProject(paths *config.Paths, projectRoot string) error {
 		return err
 	}
 
-	if err := svc.Execute(plan, false); err != nil {
+	result, err := svc.Execute(plan, false)
+	if err != nil {
 		return fmt.Errorf("auto-migration failed for %s: %w", projectRoot, err)
 	}
 
-	log.Printf("Auto-migrated project at %s to current schema", projectRoot)
+	log.Printf("Auto-migrated project at %s to current schema (snapshot %s)", projectRoot, result.SnapshotID)
 	return nil
 }
@@ -0,0 +1,176 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/amterp/kan/internal/model"
+)
+
+// Board event types published to SSE subscribers.
+const (
+	EventCardCreated = "card_created"
+	EventCardUpdated = "card_updated"
+	EventCardMoved   = "card_moved"
+	EventCardDeleted = "card_deleted"
+
+	// EventCommentCreated is published when a comment is added to a card.
+	EventCommentCreated = "comment_created"
+
+	// EventBoardConfigUpdated is published when the board's config.toml
+	// changes on disk, e.g. edited by hand while the server is running.
+	EventBoardConfigUpdated = "board_config_updated"
+)
+
+// BoardEvent is a single change notification streamed to subscribers. Card
+// fields are empty for board-level events.
+type BoardEvent struct {
+	EventType string `json:"event_type"`
+	CardID    string `json:"card_id"`
+	Column    string `json:"column"`
+}
+
+// BoardEventBus fans card change events out to per-board subscribers.
+// Unlike WebSocketHub (which relays raw file changes for the whole project),
+// events here are published by Handler after each successful mutation and
+// scoped to the board they happened on.
+type BoardEventBus struct {
+	boards sync.Map // board name -> *sync.Map of chan BoardEvent -> struct{}
+}
+
+// NewBoardEventBus creates an empty event bus.
+func NewBoardEventBus() *BoardEventBus {
+	return &BoardEventBus{}
+}
+
+// Subscribe registers a new subscriber for the board. The returned function
+// unregisters it and drains any undelivered events; callers must call it
+// once they stop reading.
+func (b *BoardEventBus) Subscribe(board string) (<-chan BoardEvent, func()) {
+	ch := make(chan BoardEvent, 64)
+	subs, _ := b.boards.LoadOrStore(board, &sync.Map{})
+	subs.(*sync.Map).Store(ch, struct{}{})
+
+	unsubscribe := func() {
+		subs.(*sync.Map).Delete(ch)
+		// The channel is never closed: a concurrent Publish may still hold it.
+		// Draining releases buffered events so nothing is left pinned.
+		for {
+			select {
+			case <-ch:
+			default:
+				return
+			}
+		}
+	}
+	return ch, unsubscribe
+}
+
+// Publish delivers an event to every subscriber of the board. Slow subscribers
+// whose buffer is full miss the event rather than blocking the request.
+func (b *BoardEventBus) Publish(board string, event BoardEvent) {
+	subs, ok := b.boards.Load(board)
+	if !ok {
+		return
+	}
+	subs.(*sync.Map).Range(func(key, _ any) bool {
+		select {
+		case key.(chan BoardEvent) <- event:
+		default:
+		}
+		return true
+	})
+}
+
+// SubscriberCount returns the number of active subscribers for a board.
+func (b *BoardEventBus) SubscriberCount(board string) int {
+	subs, ok := b.boards.Load(board)
+	if !ok {
+		return 0
+	}
+	n := 0
+	subs.(*sync.Map).Range(func(_, _ any) bool {
+		n++
+		return true
+	})
+	return n
+}
+
+// publish sends an event to the board's SSE subscribers and WebSocket clients.
+func (h *Handler) publish(boardName string, event BoardEvent) {
+	h.events.Publish(boardName, event)
+	h.sockets.Broadcast(boardName, WebSocketMessage{Type: event.EventType, Data: event})
+}
+
+// publishCardEvent notifies board subscribers about a change to a card.
+func (h *Handler) publishCardEvent(boardName, eventType string, card *model.Card) {
+	h.publish(boardName, BoardEvent{
+		EventType: eventType,
+		CardID:    card.ID,
+		Column:    card.Column,
+	})
+}
+
+// OnFileChange implements FileWatcherSubscriber. When a board's config.toml
+// changes on disk, its cached config is dropped and subscribers are told to
+// refetch the board.
+func (h *Handler) OnFileChange(change FileChange) {
+	if change.Kind != FileChangeKindBoard {
+		return
+	}
+	if reloader, ok := h.ctx().BoardStore.(interface{ Reload(string) }); ok {
+		reloader.Reload(change.BoardName)
+	}
+	h.publish(change.BoardName, BoardEvent{EventType: EventBoardConfigUpdated})
+}
+
+// StreamBoardEvents streams card change events for a board as server-sent events.
+// The stream stays open until the client disconnects.
+func (h *Handler) StreamBoardEvents(w http.ResponseWriter, r *http.Request) {
+	boardName := r.PathValue("board")
+
+	if _, err := h.ctx().BoardStore.Get(boardName); err != nil {
+		Error(w, err)
+		return
+	}
+
+	rc := http.NewResponseController(w)
+	// The stream is long-lived, so lift the server's WriteTimeout for it.
+	// Not every writer supports deadlines (e.g. test recorders); that's fine.
+	_ = rc.SetWriteDeadline(time.Time{})
+
+	events, unsubscribe := h.events.Subscribe(boardName)
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	if err := rc.Flush(); err != nil {
+		log.Printf("SSE flush not supported: %v", err)
+		return
+	}
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case event := <-events:
+			data, err := json.Marshal(event)
+			if err != nil {
+				log.Printf("Failed to marshal board event: %v", err)
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.EventType, data); err != nil {
+				return
+			}
+			if err := rc.Flush(); err != nil {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,574 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/amterp/kan/internal/model"
+)
+
+// openEventStream connects to the board's SSE endpoint and returns a channel
+// of decoded events. The stream is closed when the test ends.
+func openEventStream(t *testing.T, server *httptest.Server, api *testAPI, board string) <-chan BoardEvent {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	req, _ := http.NewRequestWithContext(ctx, "GET", server.URL+"/api/v1/boards/"+board+"/events", nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to open event stream: %v", err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", resp.StatusCode)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("Expected text/event-stream, got %q", ct)
+	}
+
+	// Headers are flushed after subscribing, so by now the subscriber is registered.
+	if n := api.handler.events.SubscriberCount(board); n != 1 {
+		t.Fatalf("Expected 1 subscriber, got %d", n)
+	}
+
+	events := make(chan BoardEvent, 16)
+	go func() {
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			line := scanner.Text()
+			if !strings.HasPrefix(line, "data: ") {
+				continue
+			}
+			var event BoardEvent
+			if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &event); err == nil {
+				events <- event
+			}
+		}
+	}()
+	return events
+}
+
+func nextEvent(t *testing.T, events <-chan BoardEvent) BoardEvent {
+	t.Helper()
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timed out waiting for event")
+		return BoardEvent{}
+	}
+}
+
+// subscribeEvents subscribes to the board's events directly on the bus, for
+// tests that only need to see what a handler published.
+func subscribeEvents(t *testing.T, api *testAPI, board string) <-chan BoardEvent {
+	t.Helper()
+	events, unsubscribe := api.handler.events.Subscribe(board)
+	t.Cleanup(unsubscribe)
+	return events
+}
+
+// publishedEvents returns the events already delivered to a subscription.
+// Handlers publish before responding, so after a request returns its events
+// are buffered.
+func publishedEvents(events <-chan BoardEvent) []BoardEvent {
+	var got []BoardEvent
+	for {
+		select {
+		case event := <-events:
+			got = append(got, event)
+		default:
+			return got
+		}
+	}
+}
+
+func postJSON(t *testing.T, method, url string, body any) *http.Response {
+	t.Helper()
+	data, _ := json.Marshal(body)
+	req, _ := http.NewRequest(method, url, bytes.NewReader(data))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("%s %s failed: %v", method, url, err)
+	}
+	resp.Body.Close()
+	return resp
+}
+
+func TestHandler_BoardEvents_CreateAndMove(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+	server := httptest.NewServer(api.mux)
+	t.Cleanup(server.Close) // Runs after stream cleanups, so Close doesn't wait on open streams
+
+	events := openEventStream(t, server, api, "main")
+
+	resp := postJSON(t, "POST", server.URL+"/api/v1/boards/main/cards", map[string]any{"title": "Streamed"})
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("Create failed with status %d", resp.StatusCode)
+	}
+
+	created := nextEvent(t, events)
+	if created.EventType != EventCardCreated {
+		t.Errorf("Expected %s, got %s", EventCardCreated, created.EventType)
+	}
+	if created.CardID == "" || created.Column != "backlog" {
+		t.Errorf("Unexpected created event: %+v", created)
+	}
+
+	resp = postJSON(t, "PATCH", server.URL+"/api/v1/boards/main/cards/"+created.CardID+"/move", map[string]any{"column": "done"})
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Move failed with status %d", resp.StatusCode)
+	}
+
+	moved := nextEvent(t, events)
+	if moved.EventType != EventCardMoved {
+		t.Errorf("Expected %s, got %s", EventCardMoved, moved.EventType)
+	}
+	if moved.CardID != created.CardID || moved.Column != "done" {
+		t.Errorf("Unexpected moved event: %+v", moved)
+	}
+}
+
+func TestHandler_BoardEvents_ScopedToBoard(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+	api.createBoard(t, "other")
+	server := httptest.NewServer(api.mux)
+	t.Cleanup(server.Close)
+
+	events := openEventStream(t, server, api, "other")
+
+	postJSON(t, "POST", server.URL+"/api/v1/boards/main/cards", map[string]any{"title": "Elsewhere"})
+	postJSON(t, "POST", server.URL+"/api/v1/boards/other/cards", map[string]any{"title": "Here"})
+
+	// The first event received must be from "other"; the "main" create is not delivered.
+	event := nextEvent(t, events)
+	card, err := api.cardStore.Get("other", event.CardID)
+	if err != nil || card.Title != "Here" {
+		t.Errorf("Expected event for card on 'other', got %+v", event)
+	}
+}
+
+func TestHandler_BoardEvents_UnsubscribesOnDisconnect(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+	server := httptest.NewServer(api.mux)
+	t.Cleanup(server.Close)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req, _ := http.NewRequestWithContext(ctx, "GET", server.URL+"/api/v1/boards/main/events", nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to open event stream: %v", err)
+	}
+	if api.handler.events.SubscriberCount("main") != 1 {
+		t.Fatal("Expected subscriber to be registered")
+	}
+
+	cancel()
+	resp.Body.Close()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for api.handler.events.SubscriberCount("main") != 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("Subscriber was not removed after disconnect")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestHandler_BoardEvents_BoardNotFound(t *testing.T) {
+	api := setupTestAPI(t)
+
+	w := api.request("GET", "/api/v1/boards/missing/events", nil)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404, got %d", w.Code)
+	}
+}
+
+func TestHandler_BoardEvents_ConfigEditedOnDisk(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+	server := httptest.NewServer(api.mux)
+	t.Cleanup(server.Close)
+
+	paths := api.handler.ctx().Paths
+	watcher, err := NewFileWatcher(paths.KanRoot())
+	if err != nil {
+		t.Fatalf("NewFileWatcher failed: %v", err)
+	}
+	watcher.Subscribe(api.handler)
+	if err := watcher.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	t.Cleanup(func() { watcher.Stop() })
+
+	events := openEventStream(t, server, api, "main")
+
+	// Edit the config the way a user would in their editor.
+	configPath := paths.BoardConfigPath("main")
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	edited := strings.Replace(string(data), `name = "backlog"`, `name = "inbox"`, 1)
+	if edited == string(data) {
+		t.Fatal("expected the config to contain the backlog column")
+	}
+	if err := os.WriteFile(configPath, []byte(edited), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	event := nextEvent(t, events)
+	if event.EventType != EventBoardConfigUpdated {
+		t.Fatalf("Expected %s, got %+v", EventBoardConfigUpdated, event)
+	}
+
+	board, err := api.boardStore.Get("main")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if board.Columns[0].Name != "inbox" {
+		t.Errorf("Expected edited column name, got %q", board.Columns[0].Name)
+	}
+}
+
+func TestHandler_BoardEvents_ImportCSV(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+	events := subscribeEvents(t, api, "main")
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	part, _ := mw.CreateFormFile("file", "cards.csv")
+	part.Write([]byte("title,column\nFirst,done\nSecond,\nLost,nowhere\n"))
+	mw.Close()
+	req := httptest.NewRequest("POST", "/api/v1/boards/main/cards/import-csv", &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	w := httptest.NewRecorder()
+	api.mux.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+	}
+
+	got := publishedEvents(events)
+	if len(got) != 2 {
+		t.Fatalf("Expected one event per imported card, got %+v", got)
+	}
+	for i, column := range []string{"done", "backlog"} {
+		if got[i].EventType != EventCardCreated || got[i].CardID == "" || got[i].Column != column {
+			t.Errorf("Expected %s in %s, got %+v", EventCardCreated, column, got[i])
+		}
+	}
+}
+
+func TestHandler_BoardEvents_ImportJSONL(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+	api.createBoard(t, "copy")
+	for _, title := range []string{"First", "Second"} {
+		api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": title})
+	}
+	export := api.request("GET", "/api/v1/boards/main/cards.jsonl", nil).Body.Bytes()
+	events := subscribeEvents(t, api, "copy")
+
+	// The bad line fails the request, but the cards before it are imported.
+	req := httptest.NewRequest("POST", "/api/v1/boards/copy/cards/import-jsonl", bytes.NewReader(append(export, "{bad\n"...)))
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	w := httptest.NewRecorder()
+	api.mux.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("Expected status 400, got %d. Body: %s", w.Code, w.Body.String())
+	}
+
+	got := publishedEvents(events)
+	if len(got) != 2 {
+		t.Fatalf("Expected one event per imported card, got %+v", got)
+	}
+	for _, event := range got {
+		if event.EventType != EventCardCreated {
+			t.Errorf("Expected %s, got %+v", EventCardCreated, event)
+		}
+		if _, err := api.cardStore.Get("copy", event.CardID); err != nil {
+			t.Errorf("Expected event for an imported card, got %+v", event)
+		}
+	}
+
+	// Importing again updates the same cards.
+	req = httptest.NewRequest("POST", "/api/v1/boards/copy/cards/import-jsonl", bytes.NewReader(export))
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	w = httptest.NewRecorder()
+	api.mux.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	got = publishedEvents(events)
+	if len(got) != 2 || got[0].EventType != EventCardUpdated || got[1].EventType != EventCardUpdated {
+		t.Errorf("Expected %s for each re-imported card, got %+v", EventCardUpdated, got)
+	}
+}
+
+func TestHandler_BoardEvents_Checklist(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+	card := createCardFromResponse(t, api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "Listed"}))
+	events := subscribeEvents(t, api, "main")
+
+	w := api.request("POST", "/api/v1/boards/main/cards/"+card.ID+"/checklist", map[string]any{"text": "Step one"})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Add failed with status %d: %s", w.Code, w.Body.String())
+	}
+	var item struct {
+		ID string `json:"id"`
+	}
+	decodeJSON(t, w, &item)
+	itemPath := "/api/v1/boards/main/cards/" + card.ID + "/checklist/" + item.ID
+	if w := api.request("PATCH", itemPath, map[string]any{"done": true}); w.Code != http.StatusOK {
+		t.Fatalf("Toggle failed with status %d: %s", w.Code, w.Body.String())
+	}
+	if w := api.request("DELETE", itemPath, nil); w.Code != http.StatusNoContent {
+		t.Fatalf("Delete failed with status %d: %s", w.Code, w.Body.String())
+	}
+
+	got := publishedEvents(events)
+	if len(got) != 3 {
+		t.Fatalf("Expected an event per checklist change, got %+v", got)
+	}
+	for _, event := range got {
+		if event.EventType != EventCardUpdated || event.CardID != card.ID || event.Column != "backlog" {
+			t.Errorf("Expected %s for %s, got %+v", EventCardUpdated, card.ID, event)
+		}
+	}
+
+	// A failed change publishes nothing.
+	api.request("DELETE", itemPath, nil)
+	if got := publishedEvents(events); len(got) != 0 {
+		t.Errorf("Expected no event for a failed delete, got %+v", got)
+	}
+}
+
+func TestHandler_BoardEvents_Comments(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+	card := createCardFromResponse(t, api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "Discussed"}))
+	w := api.request("POST", "/api/v1/boards/main/cards/"+card.ID+"/comments", map[string]any{"body": "First"})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Create comment failed with status %d: %s", w.Code, w.Body.String())
+	}
+	var comment CommentResponse
+	decodeJSON(t, w, &comment)
+	events := subscribeEvents(t, api, "main")
+
+	commentPath := "/api/v1/boards/main/cards/" + card.ID + "/comments/" + comment.ID
+	if w := api.request("PATCH", commentPath, map[string]any{"body": "Edited"}); w.Code != http.StatusOK {
+		t.Fatalf("Edit comment failed with status %d: %s", w.Code, w.Body.String())
+	}
+	if w := api.request("DELETE", commentPath, nil); w.Code != http.StatusNoContent {
+		t.Fatalf("Delete comment failed with status %d: %s", w.Code, w.Body.String())
+	}
+
+	got := publishedEvents(events)
+	if len(got) != 2 {
+		t.Fatalf("Expected an event per comment change, got %+v", got)
+	}
+	for _, event := range got {
+		if event.EventType != EventCardUpdated || event.CardID != card.ID || event.Column != "backlog" {
+			t.Errorf("Expected %s for %s, got %+v", EventCardUpdated, card.ID, event)
+		}
+	}
+
+	// A failed change publishes nothing.
+	api.request("DELETE", commentPath, nil)
+	if got := publishedEvents(events); len(got) != 0 {
+		t.Errorf("Expected no event for a failed delete, got %+v", got)
+	}
+}
+
+func TestHandler_BoardEvents_DeleteColumn(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+	first := createCardFromResponse(t, api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "One", "column": "in-progress"}))
+	second := createCardFromResponse(t, api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "Two", "column": "in-progress"}))
+	api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "Stays"})
+	events := subscribeEvents(t, api, "main")
+
+	if w := api.request("DELETE", "/api/v1/boards/main/columns/in-progress", nil); w.Code != http.StatusOK {
+		t.Fatalf("Delete column failed with status %d: %s", w.Code, w.Body.String())
+	}
+
+	deleted := make(map[string]bool)
+	for _, event := range publishedEvents(events) {
+		if event.EventType != EventCardDeleted || event.Column != "in-progress" {
+			t.Errorf("Expected %s in in-progress, got %+v", EventCardDeleted, event)
+		}
+		deleted[event.CardID] = true
+	}
+	if len(deleted) != 2 || !deleted[first.ID] || !deleted[second.ID] {
+		t.Errorf("Expected events for both cards in the column, got %v", deleted)
+	}
+}
+
+func TestHandler_BoardEvents_DeleteCardChildren(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+	parent := createCardFromResponse(t, api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "Epic"}))
+	child := createCardFromResponse(t, api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "Task", "parent": parent.ID}))
+	events := subscribeEvents(t, api, "main")
+
+	if w := api.request("DELETE", "/api/v1/boards/main/cards/"+parent.ID, nil); w.Code != http.StatusNoContent {
+		t.Fatalf("Delete failed with status %d: %s", w.Code, w.Body.String())
+	}
+
+	got := publishedEvents(events)
+	if len(got) != 2 {
+		t.Fatalf("Expected 2 events, got %+v", got)
+	}
+	if got[0].EventType != EventCardDeleted || got[0].CardID != parent.ID {
+		t.Errorf("Expected %s for the parent, got %+v", EventCardDeleted, got[0])
+	}
+	if got[1].EventType != EventCardUpdated || got[1].CardID != child.ID {
+		t.Errorf("Expected %s for the child, got %+v", EventCardUpdated, got[1])
+	}
+}
+
+func TestHandler_BoardEvents_DeleteCardsChildren(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+	parent := createCardFromResponse(t, api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "Epic"}))
+	kept := createCardFromResponse(t, api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "Kept", "parent": parent.ID}))
+	gone := createCardFromResponse(t, api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "Gone", "parent": parent.ID}))
+	events := subscribeEvents(t, api, "main")
+
+	body := map[string]any{"card_ids": []string{parent.ID, gone.ID}}
+	if w := api.request("DELETE", "/api/v1/boards/main/cards", body); w.Code != http.StatusOK {
+		t.Fatalf("Delete failed with status %d: %s", w.Code, w.Body.String())
+	}
+
+	updated := make(map[string]bool)
+	for _, event := range publishedEvents(events) {
+		if event.EventType == EventCardUpdated {
+			updated[event.CardID] = true
+		}
+	}
+	if len(updated) != 1 || !updated[kept.ID] {
+		t.Errorf("Expected card_updated for %s only, got %v", kept.ID, updated)
+	}
+}
+
+func TestHandler_BoardEvents_AutoArchive(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+	cfg, err := api.boardStore.Get("main")
+	if err != nil {
+		t.Fatalf("Failed to get board: %v", err)
+	}
+	done := cfg.GetColumn("done")
+	done.Done = true
+	done.AutoArchiveAfterDays = 7
+	if err := api.boardStore.Update(cfg); err != nil {
+		t.Fatalf("Failed to update board: %v", err)
+	}
+	stale := func(id string) {
+		card := &model.Card{ID: id, Title: id, Column: "done", DoneAtMillis: time.Now().AddDate(0, 0, -8).UnixMilli()}
+		if err := api.cardStore.Create("main", card); err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+	}
+	events := subscribeEvents(t, api, "main")
+
+	// Archived through the endpoint...
+	stale("c_endpoint")
+	if w := api.request("POST", "/api/v1/boards/main/auto-archive", nil); w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	// ...and by the server's periodic run.
+	stale("c_ticker")
+	(&Server{handler: api.handler}).autoArchiveAll()
+
+	got := publishedEvents(events)
+	want := []BoardEvent{
+		{EventType: EventCardUpdated, CardID: "c_endpoint"},
+		{EventType: EventCardUpdated, CardID: "c_ticker"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %+v, got %+v", want, got)
+	}
+}
+
+func TestHandler_BoardEvents_MergeBoards(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+	api.createBoard(t, "other")
+	card := createCardFromResponse(t, api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "Merged"}))
+	source := subscribeEvents(t, api, "main")
+	target := subscribeEvents(t, api, "other")
+
+	body := map[string]any{"target": "other", "column_mapping": map[string]string{"backlog": "done"}}
+	if w := api.request("POST", "/api/v1/boards/main/merge", body); w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+	}
+
+	if got, want := publishedEvents(source), []BoardEvent{{EventType: EventCardDeleted, CardID: card.ID}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected source events %+v, got %+v", want, got)
+	}
+	if got, want := publishedEvents(target), []BoardEvent{{EventType: EventCardCreated, CardID: card.ID, Column: "done"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected target events %+v, got %+v", want, got)
+	}
+}
+
+func TestHandler_BoardEvents_TransferUnlinks(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+	api.createBoard(t, "other")
+	parent := createCardFromResponse(t, api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "Epic"}))
+	child := createCardFromResponse(t, api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "Task", "parent": parent.ID}))
+	events := subscribeEvents(t, api, "main")
+
+	body := map[string]any{"target_board": "other"}
+	if w := api.request("POST", "/api/v1/boards/main/cards/"+parent.ID+"/transfer", body); w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+	}
+
+	got := publishedEvents(events)
+	if len(got) != 2 || got[1].EventType != EventCardUpdated || got[1].CardID != child.ID {
+		t.Errorf("Expected %s for the unlinked child after the delete, got %+v", EventCardUpdated, got)
+	}
+}
+
+func TestHandler_BoardEvents_MergeUnlinks(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+	api.createBoard(t, "other")
+	api.request("POST", "/api/v1/boards/other/cards", map[string]any{"title": "Same title"})
+	parent := createCardFromResponse(t, api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "Epic"}))
+	skipped := createCardFromResponse(t, api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "Same title", "parent": parent.ID}))
+	events := subscribeEvents(t, api, "main")
+
+	body := map[string]any{"target": "other", "conflict_alias": "skip"}
+	if w := api.request("POST", "/api/v1/boards/main/merge", body); w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+	}
+
+	want := []BoardEvent{
+		{EventType: EventCardDeleted, CardID: parent.ID},
+		{EventType: EventCardUpdated, CardID: skipped.ID, Column: skipped.Column},
+	}
+	if got := publishedEvents(events); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected source events %+v, got %+v", want, got)
+	}
+}
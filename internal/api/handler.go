@@ -1,17 +1,27 @@
 package api
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log"
+	"maps"
 	"net/http"
+	"regexp"
 	"sort"
+	"strconv"
+	"strings"
 	"sync"
 
 	"github.com/amterp/kan/internal/config"
+	kanerr "github.com/amterp/kan/internal/errors"
+	"github.com/amterp/kan/internal/metrics"
 	"github.com/amterp/kan/internal/model"
 	"github.com/amterp/kan/internal/service"
 	"github.com/amterp/kan/internal/store"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 // CardResponse wraps a Card for JSON API responses.
@@ -30,12 +40,28 @@ type CardResponse struct {
 	UpdatedAtMillis     int64                    `json:"updated_at_millis"`
 	Comments            []model.Comment          `json:"comments,omitempty"`
 	History             []model.HistoryEntry     `json:"history,omitempty"`
+	DueAtMillis         int64                    `json:"due_at_millis,omitempty"`
+	Blocks              []string                 `json:"blocks,omitempty"`
+	BlockedBy           []string                 `json:"blocked_by,omitempty"`
+	Checklist           []model.ChecklistItem    `json:"checklist,omitempty"`
+	Archived            bool                     `json:"archived,omitempty"`
+	ArchivedAtMillis    int64                    `json:"archived_at_millis,omitempty"`
+	LastColumn          string                   `json:"last_column,omitempty"`
+	DoneAtMillis        int64                    `json:"done_at_millis,omitempty"`
 	CustomFields        map[string]any           `json:"-"` // Flattened into top level by MarshalJSON
 	MissingWantedFields []MissingWantedFieldInfo `json:"missing_wanted_fields,omitempty"`
+
+	fieldOrder []string // the board's custom field names, in display order
 }
 
 // MarshalJSON flattens custom fields into the top level of the JSON output.
 func (c CardResponse) MarshalJSON() ([]byte, error) {
+	return c.marshalJSON(nil)
+}
+
+// marshalJSON is MarshalJSON with the built-in keys in overrides replaced or
+// added, for responses that extend a card.
+func (c CardResponse) marshalJSON(overrides map[string]any) ([]byte, error) {
 	// Build base map with known fields
 	m := map[string]any{
 		"id":                c.ID,
@@ -62,42 +88,125 @@ func (c CardResponse) MarshalJSON() ([]byte, error) {
 	if len(c.History) > 0 {
 		m["history"] = c.History
 	}
+	if c.DueAtMillis != 0 {
+		m["due_at_millis"] = c.DueAtMillis
+	}
+	if len(c.Blocks) > 0 {
+		m["blocks"] = c.Blocks
+	}
+	if len(c.BlockedBy) > 0 {
+		m["blocked_by"] = c.BlockedBy
+	}
+	if len(c.Checklist) > 0 {
+		m["checklist"] = c.Checklist
+	}
+	if c.Archived {
+		m["archived"] = true
+		m["archived_at_millis"] = c.ArchivedAtMillis
+	}
+	if c.LastColumn != "" {
+		m["last_column"] = c.LastColumn
+	}
+	if c.DoneAtMillis != 0 {
+		m["done_at_millis"] = c.DoneAtMillis
+	}
 	if len(c.MissingWantedFields) > 0 {
 		m["missing_wanted_fields"] = c.MissingWantedFields
 	}
+	maps.Copy(m, overrides)
 
-	// Flatten custom fields into top level
-	for k, v := range c.CustomFields {
-		m[k] = v
+	// Flatten custom fields into the top level, after the built-in keys, in
+	// the board's field order. Fields the board doesn't define come last.
+	for k := range c.CustomFields {
+		delete(m, k)
+	}
+	base, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	buf.Write(base[:len(base)-1])
+	for _, k := range c.customFieldKeys() {
+		key, err := json.Marshal(k)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(c.CustomFields[k])
+		if err != nil {
+			return nil, err
+		}
+		buf.WriteByte(',')
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
 	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
 
-	return json.Marshal(m)
+// customFieldKeys returns the card's custom field names: those in fieldOrder
+// first, in that order, then the rest sorted by name.
+func (c CardResponse) customFieldKeys() []string {
+	keys := make([]string, 0, len(c.CustomFields))
+	seen := make(map[string]bool, len(c.CustomFields))
+	for _, name := range c.fieldOrder {
+		if _, ok := c.CustomFields[name]; ok {
+			keys = append(keys, name)
+			seen[name] = true
+		}
+	}
+	rest := make([]string, 0, len(c.CustomFields)-len(keys))
+	for name := range c.CustomFields {
+		if !seen[name] {
+			rest = append(rest, name)
+		}
+	}
+	sort.Strings(rest)
+	return append(keys, rest...)
 }
 
 // toCardResponse converts a model.Card to a CardResponse for API output.
 func toCardResponse(card *model.Card) CardResponse {
 	return CardResponse{
-		ID:              card.ID,
-		Alias:           card.Alias,
-		AliasExplicit:   card.AliasExplicit,
-		Title:           card.Title,
-		Description:     card.Description,
-		Column:          card.Column,
-		Position:        card.Position,
-		Parent:          card.Parent,
-		Creator:         card.Creator,
-		CreatedAtMillis: card.CreatedAtMillis,
-		UpdatedAtMillis: card.UpdatedAtMillis,
-		Comments:        card.Comments,
-		History:         card.History,
-		CustomFields:    card.CustomFields,
+		ID:               card.ID,
+		Alias:            card.Alias,
+		AliasExplicit:    card.AliasExplicit,
+		Title:            card.Title,
+		Description:      card.Description,
+		Column:           card.Column,
+		Position:         card.Position,
+		Parent:           card.Parent,
+		Creator:          card.Creator,
+		CreatedAtMillis:  card.CreatedAtMillis,
+		UpdatedAtMillis:  card.UpdatedAtMillis,
+		Comments:         card.Comments,
+		History:          card.History,
+		DueAtMillis:      card.DueAtMillis,
+		Blocks:           card.Blocks,
+		BlockedBy:        card.BlockedBy,
+		Checklist:        card.Checklist,
+		Archived:         card.Archived,
+		ArchivedAtMillis: card.ArchivedAtMillis,
+		LastColumn:       card.LastColumn,
+		DoneAtMillis:     card.DoneAtMillis,
+		CustomFields:     card.CustomFields,
 	}
 }
 
+// NewCardResponse converts a card to the JSON shape served by the API,
+// including missing wanted fields. The CLI uses it so `kan card show --json`
+// prints exactly what GET /cards/{id} would return.
+func NewCardResponse(card *model.Card, boardCfg *model.BoardConfig) CardResponse {
+	return toCardResponseWithWanted(card, boardCfg)
+}
+
 // toCardResponseWithWanted converts a model.Card to a CardResponse including wanted fields check.
 func toCardResponseWithWanted(card *model.Card, boardCfg *model.BoardConfig) CardResponse {
 	resp := toCardResponse(card)
 	if boardCfg != nil {
+		for _, field := range boardCfg.OrderedCustomFields() {
+			resp.fieldOrder = append(resp.fieldOrder, field.Name)
+		}
 		for _, mf := range service.CheckWantedFields(card, boardCfg) {
 			info := MissingWantedFieldInfo{
 				Name:        mf.FieldName,
@@ -138,14 +247,24 @@ type Handler struct {
 	globalStore     store.GlobalStore
 	mu              sync.RWMutex
 	current         *ProjectContext
+	events          *BoardEventBus
+	sockets         *BoardWebSocketHub
+	locks           *LockRegistry
+	columnMu        sync.Mutex              // Serializes column changes so If-Revision checks hold until the write
 	onProjectSwitch func(newKanRoot string) // Called when project is switched
+	routes          []string                // Patterns registered by RegisterRoutes, for the OpenAPI spec
+	maxBodyBytes    int64                   // Request body cap enforced by RequestValidationMiddleware
 }
 
 // NewHandler creates a new handler with the given dependencies.
 func NewHandler(globalStore store.GlobalStore, ctx *ProjectContext) *Handler {
 	return &Handler{
-		globalStore: globalStore,
-		current:     ctx,
+		globalStore:  globalStore,
+		current:      ctx,
+		events:       NewBoardEventBus(),
+		sockets:      NewBoardWebSocketHub(),
+		locks:        NewLockRegistry(),
+		maxBodyBytes: DefaultMaxRequestBodyBytes,
 	}
 }
 
@@ -155,6 +274,17 @@ func (h *Handler) SetOnProjectSwitch(fn func(newKanRoot string)) {
 	h.onProjectSwitch = fn
 }
 
+// SetMaxRequestBodyBytes sets the largest request body the API accepts.
+// It must be called before RegisterRoutes.
+func (h *Handler) SetMaxRequestBodyBytes(n int64) {
+	h.maxBodyBytes = n
+}
+
+// maxUploadBytes returns the request body cap for the import routes.
+func (h *Handler) maxUploadBytes() int64 {
+	return max(h.maxBodyBytes, MaxUploadBodyBytes)
+}
+
 // ctx returns the current ProjectContext under read lock.
 // The returned context is immutable; callers should not modify it.
 func (h *Handler) ctx() *ProjectContext {
@@ -165,42 +295,105 @@ func (h *Handler) ctx() *ProjectContext {
 }
 
 // RegisterRoutes sets up all API routes on the given mux.
-func (h *Handler) RegisterRoutes(mux *http.ServeMux) {
+// The registered patterns are recorded to build the OpenAPI spec.
+func (h *Handler) RegisterRoutes(serveMux *http.ServeMux) {
+	mux := &routeRecorder{
+		mux:        serveMux,
+		wrap:       RequestValidationMiddleware(h.maxBodyBytes),
+		wrapUpload: UploadValidationMiddleware(h.maxUploadBytes()),
+	}
+
 	// Project routes
+	mux.HandleFunc("GET /api/v1/openapi.json", h.GetOpenAPISpec)
 	mux.HandleFunc("GET /api/v1/project", h.GetProject)
+	mux.HandleFunc("PATCH /api/v1/project", h.UpdateProject)
 	mux.HandleFunc("GET /favicon.svg", h.GetFavicon)
+	mux.Handle("GET /metrics", promhttp.Handler())
+	mux.HandleFunc("GET /api/v1/migrate/snapshots", h.ListMigrationSnapshots)
+	mux.HandleFunc("GET /api/v1/hook-tasks/{task_id}", h.GetHookTask)
 
 	// Cross-project routes
 	mux.HandleFunc("GET /api/v1/all-boards", h.ListAllBoards)
+	mux.HandleFunc("GET /api/v1/all-boards/search", h.SearchAllBoards)
+	mux.HandleFunc("GET /api/v1/search", h.SearchAll)
 	mux.HandleFunc("POST /api/v1/switch", h.SwitchProject)
 
 	// Board routes
 	mux.HandleFunc("GET /api/v1/boards", h.ListBoards)
 	mux.HandleFunc("GET /api/v1/boards/{name}", h.GetBoard)
 	mux.HandleFunc("DELETE /api/v1/boards/{name}", h.DeleteBoard)
+	mux.HandleFunc("POST /api/v1/boards/{board}/archive", h.ArchiveBoard)
+	mux.HandleFunc("POST /api/v1/boards/{board}/unarchive", h.UnarchiveBoard)
+	mux.HandleFunc("GET /api/v1/boards/{board}/export", h.ExportBoard)
+	mux.HandleUploadFunc("POST /api/v1/boards/import", h.ImportBoard)
+	mux.HandleUploadFunc("POST /api/v1/boards/import-trello", h.ImportTrelloBoard)
+	mux.HandleFunc("POST /api/v1/boards/{board}/duplicate", h.DuplicateBoard)
+	mux.HandleFunc("POST /api/v1/boards/{board}/merge", h.MergeBoards)
+	mux.HandleFunc("PATCH /api/v1/boards/{board}/collaborators", h.UpdateCollaborators)
+	mux.HandleFunc("GET /api/v1/boards/{board}/audit", h.GetBoardAudit)
+	mux.HandleFunc("GET /api/v1/boards/{board}/hooks/history", h.GetHookHistory)
+	mux.HandleFunc("POST /api/v1/boards/{board}/link-rules/preview", h.PreviewLinkRules)
+	mux.HandleFunc("GET /api/v1/boards/{board}/stats", h.GetBoardStats)
+	mux.HandleFunc("GET /api/v1/boards/{board}/completion", h.GetBoardCompletion)
+	mux.HandleFunc("GET /api/v1/boards/{board}/velocity", h.GetBoardVelocity)
+	mux.HandleFunc("POST /api/v1/boards/{board}/auto-archive", h.AutoArchive)
+	mux.HandleFunc("GET /api/v1/boards/{board}/events", h.StreamBoardEvents)
+	mux.HandleFunc("GET /api/v1/boards/{board}/ws", h.ServeBoardSocket)
 
 	// Column routes
+	mux.HandleFunc("GET /api/v1/boards/{board}/columns", h.ListColumns)
 	mux.HandleFunc("POST /api/v1/boards/{board}/columns", h.CreateColumn)
+	mux.HandleFunc("GET /api/v1/boards/{board}/columns/{name}/cards", h.ListColumnCards)
 	mux.HandleFunc("DELETE /api/v1/boards/{board}/columns/{name}", h.DeleteColumn)
 	mux.HandleFunc("PATCH /api/v1/boards/{board}/columns/{name}", h.UpdateColumn)
 	mux.HandleFunc("PUT /api/v1/boards/{board}/columns/order", h.ReorderColumns)
+	mux.HandleFunc("PUT /api/v1/boards/{board}/columns/{column}/order", h.ReorderColumnCards)
+
+	// Sprint routes
+	mux.HandleFunc("GET /api/v1/boards/{board}/sprint/active", h.GetActiveSprint)
+	mux.HandleFunc("POST /api/v1/boards/{board}/sprint/start", h.StartSprint)
 
 	// Card routes
 	mux.HandleFunc("GET /api/v1/boards/{board}/cards", h.ListCards)
 	mux.HandleFunc("POST /api/v1/boards/{board}/cards", h.CreateCard)
-	mux.HandleFunc("GET /api/v1/boards/{board}/cards/{id}", h.GetCard)
-	mux.HandleFunc("PUT /api/v1/boards/{board}/cards/{id}", h.UpdateCard)
+	mux.HandleFunc("GET /api/v1/boards/{board}/cards/{id}", h.ETagMiddleware(h.GetCard))
+	mux.HandleFunc("PUT /api/v1/boards/{board}/cards/{id}", h.ETagMiddleware(h.UpdateCard))
 	mux.HandleFunc("DELETE /api/v1/boards/{board}/cards/{id}", h.DeleteCard)
-	mux.HandleFunc("PATCH /api/v1/boards/{board}/cards/{id}/move", h.MoveCard)
+	mux.HandleFunc("DELETE /api/v1/boards/{board}/cards", h.DeleteCards)
+	mux.HandleFunc("PATCH /api/v1/boards/{board}/cards/{id}/move", h.ETagMiddleware(h.MoveCard))
+	mux.HandleFunc("PATCH /api/v1/boards/{board}/cards/bulk-move", h.BulkMoveCards)
+	mux.HandleFunc("POST /api/v1/boards/{board}/cards/fetch", h.FetchCards)
 	mux.HandleFunc("POST /api/v1/boards/{board}/cards/restore", h.RestoreCard)
+	mux.HandleUploadFunc("POST /api/v1/boards/{board}/cards/import-csv", h.ImportCardsCSV)
+	mux.HandleFunc("GET /api/v1/boards/{board}/cards.jsonl", h.ExportCardsJSONL)
+	mux.HandleUploadFunc("POST /api/v1/boards/{board}/cards/import-jsonl", h.ImportCardsJSONL)
+	mux.HandleFunc("POST /api/v1/boards/{board}/cards/from-template", h.CreateCardFromTemplate)
+	mux.HandleFunc("POST /api/v1/boards/{board}/cards/{id}/archive", h.ArchiveCard)
+	mux.HandleFunc("POST /api/v1/boards/{board}/cards/{id}/unarchive", h.UnarchiveCard)
+	mux.HandleFunc("POST /api/v1/boards/{board}/cards/{id}/copy-fields", h.CopyCardFields)
+	mux.HandleFunc("POST /api/v1/boards/{board}/cards/{id}/transfer", h.TransferCard)
+	mux.HandleFunc("POST /api/v1/boards/{board}/cards/{id}/clone", h.CloneCard)
+	mux.HandleFunc("GET /api/v1/boards/{board}/cards/{id}/blocks", h.GetCardBlocks)
+	mux.HandleFunc("GET /api/v1/boards/{board}/cards/{id}/blocked-by", h.GetCardBlockedBy)
+	mux.HandleFunc("GET /api/v1/boards/{board}/cards/{id}/suggest-column", h.SuggestColumn)
+	mux.HandleFunc("GET /api/v1/boards/{board}/cards/suggest-tags", h.SuggestTags)
+	mux.HandleFunc("POST /api/v1/boards/{board}/cards/{id}/lock", h.LockCard)
+	mux.HandleFunc("DELETE /api/v1/boards/{board}/cards/{id}/lock", h.UnlockCard)
+	mux.HandleFunc("POST /api/v1/boards/{board}/search", h.SearchCards)
 
 	// Comment routes
+	mux.HandleFunc("GET /api/v1/boards/{board}/cards/{id}/comments", h.ListComments)
 	mux.HandleFunc("POST /api/v1/boards/{board}/cards/{id}/comments", h.CreateComment)
 	mux.HandleFunc("PATCH /api/v1/boards/{board}/cards/{id}/comments/{cid}", h.EditComment)
 	mux.HandleFunc("DELETE /api/v1/boards/{board}/cards/{id}/comments/{cid}", h.DeleteComment)
+	mux.HandleFunc("POST /api/v1/boards/{board}/cards/{id}/checklist", h.AddChecklistItem)
+	mux.HandleFunc("PATCH /api/v1/boards/{board}/cards/{id}/checklist/{item_id}", h.ToggleChecklistItem)
+	mux.HandleFunc("DELETE /api/v1/boards/{board}/cards/{id}/checklist/{item_id}", h.DeleteChecklistItem)
 
 	// Static files (frontend)
 	mux.Handle("/", h.StaticHandler())
+
+	h.routes = mux.patterns
 }
 
 // --- Project Handlers ---
@@ -225,6 +418,12 @@ func (h *Handler) GetProject(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	JSON(w, http.StatusOK, h.projectResponse(cfg))
+}
+
+// projectResponse builds a ProjectResponse, filling in defaults for an unset
+// name or favicon.
+func (h *Handler) projectResponse(cfg *model.ProjectConfig) ProjectResponse {
 	// If no name set, use "Kan"
 	name := cfg.Name
 	if name == "" {
@@ -237,18 +436,130 @@ func (h *Handler) GetProject(w http.ResponseWriter, r *http.Request) {
 		favicon = model.DefaultFaviconConfig(cfg.ID, name)
 	}
 
-	JSON(w, http.StatusOK, ProjectResponse{
+	return ProjectResponse{
 		Name:        name,
 		Favicon:     favicon,
 		ProjectPath: h.ctx().ProjectRoot,
-	})
+	}
+}
+
+// UpdateProjectRequest is the JSON body for updating project metadata.
+// Omitted fields, and empty fields within favicon, are left unchanged.
+type UpdateProjectRequest struct {
+	Name    *string              `json:"name,omitempty"`
+	Favicon *model.FaviconConfig `json:"favicon,omitempty"`
+}
+
+var hexColorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// validateFavicon checks the favicon fields of a project update.
+func validateFavicon(f model.FaviconConfig) string {
+	if f.Background != "" && !hexColorPattern.MatchString(f.Background) {
+		return "favicon.background must be a 6-digit hex color like #3b82f6"
+	}
+	if f.IconType != "" && f.IconType != model.IconTypeLetter && f.IconType != model.IconTypeEmoji {
+		return fmt.Sprintf("favicon.icon_type must be %q or %q", model.IconTypeLetter, model.IconTypeEmoji)
+	}
+	if f.Letter != "" && (len(f.Letter) != 1 || f.Letter[0] < 'A' || f.Letter[0] > 'Z') {
+		return "favicon.letter must be a single uppercase letter"
+	}
+	return ""
+}
+
+// UpdateProject changes the project name and/or favicon.
+func (h *Handler) UpdateProject(w http.ResponseWriter, r *http.Request) {
+	var req UpdateProjectRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		BadRequest(w, "invalid JSON body")
+		return
+	}
+
+	if req.Name != nil && strings.TrimSpace(*req.Name) == "" {
+		BadRequest(w, "name cannot be empty")
+		return
+	}
+	if req.Favicon != nil {
+		if msg := validateFavicon(*req.Favicon); msg != "" {
+			BadRequest(w, msg)
+			return
+		}
+	}
+
+	projectStore := h.ctx().ProjectStore
+	cfg, err := projectStore.Load()
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	if req.Name != nil {
+		cfg.Name = strings.TrimSpace(*req.Name)
+	}
+	if f := req.Favicon; f != nil {
+		if f.Background != "" {
+			cfg.Favicon.Background = f.Background
+		}
+		if f.IconType != "" {
+			cfg.Favicon.IconType = f.IconType
+		}
+		if f.Letter != "" {
+			cfg.Favicon.Letter = f.Letter
+		}
+		if f.Emoji != "" {
+			cfg.Favicon.Emoji = f.Emoji
+		}
+	}
+
+	if err := projectStore.Save(cfg); err != nil {
+		Error(w, err)
+		return
+	}
+
+	JSON(w, http.StatusOK, h.projectResponse(cfg))
+}
+
+// SnapshotResponse describes a pre-migration snapshot.
+type SnapshotResponse struct {
+	ID              string            `json:"id"`
+	CreatedAtMillis int64             `json:"created_at_millis"`
+	BoardSchemas    map[string]string `json:"board_schemas"`
+}
+
+// SnapshotListResponse is the JSON response for listing migration snapshots.
+type SnapshotListResponse struct {
+	Snapshots []SnapshotResponse `json:"snapshots"`
+}
+
+// ListMigrationSnapshots returns the project's pre-migration snapshots, oldest
+// first, with the board schema versions each one holds.
+func (h *Handler) ListMigrationSnapshots(w http.ResponseWriter, r *http.Request) {
+	snapshots, err := service.NewQuietMigrateService(h.ctx().Paths).Snapshots()
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	resp := SnapshotListResponse{Snapshots: make([]SnapshotResponse, len(snapshots))}
+	for i, snap := range snapshots {
+		resp.Snapshots[i] = SnapshotResponse{
+			ID:              snap.ID,
+			CreatedAtMillis: snap.CreatedAtMillis,
+			BoardSchemas:    snap.BoardSchemas,
+		}
+	}
+	JSON(w, http.StatusOK, resp)
 }
 
 // --- Board Handlers ---
 
-// ListBoards returns all board names.
+// ListBoards returns the names of active boards, or of all boards with
+// ?include_archived=true.
 func (h *Handler) ListBoards(w http.ResponseWriter, r *http.Request) {
-	boards, err := h.ctx().BoardStore.List()
+	list := h.ctx().BoardStore.List
+	if r.URL.Query().Get("include_archived") == "true" {
+		list = h.ctx().BoardStore.ListAll
+	}
+	boards, err := list()
 	if err != nil {
 		Error(w, err)
 		return
@@ -285,224 +596,1164 @@ func (h *Handler) DeleteBoard(w http.ResponseWriter, r *http.Request) {
 	JSON(w, http.StatusOK, DeleteBoardResponse{DeletedCards: deletedCards})
 }
 
-// --- Card Handlers ---
-
-// ListCards returns all cards for a board, optionally filtered by column.
-func (h *Handler) ListCards(w http.ResponseWriter, r *http.Request) {
+// ArchiveBoard archives a board, hiding it from board lists while keeping its
+// data. Returns the updated board config.
+func (h *Handler) ArchiveBoard(w http.ResponseWriter, r *http.Request) {
 	boardName := r.PathValue("board")
-	columnFilter := r.URL.Query().Get("column")
 
-	// Verify board exists first
-	if !h.ctx().BoardStore.Exists(boardName) {
-		NotFound(w, "board", boardName)
+	if err := h.ctx().BoardService.ArchiveBoard(boardName); err != nil {
+		Error(w, err)
 		return
 	}
+	h.writeBoard(w, boardName)
+}
 
-	cards, err := h.ctx().CardService.List(boardName, columnFilter)
-	if err != nil {
+// UnarchiveBoard restores an archived board. Returns the updated board config.
+func (h *Handler) UnarchiveBoard(w http.ResponseWriter, r *http.Request) {
+	boardName := r.PathValue("board")
+
+	if err := h.ctx().BoardService.UnarchiveBoard(boardName); err != nil {
 		Error(w, err)
 		return
 	}
-
-	// Get board config for wanted fields check
-	boardCfg, _ := h.ctx().BoardStore.Get(boardName)
-	JSON(w, http.StatusOK, map[string]any{"cards": toCardResponses(cards, boardCfg)})
+	h.writeBoard(w, boardName)
 }
 
-// CreateCardRequest is the JSON body for creating a card.
-type CreateCardRequest struct {
-	Title        string         `json:"title"`
-	Description  string         `json:"description,omitempty"`
-	Column       string         `json:"column,omitempty"`
-	Parent       string         `json:"parent,omitempty"`
-	CustomFields map[string]any `json:"custom_fields,omitempty"`
+// writeBoard responds with a board's current config.
+func (h *Handler) writeBoard(w http.ResponseWriter, boardName string) {
+	board, err := h.ctx().BoardService.Get(boardName)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+	JSON(w, http.StatusOK, board)
 }
 
-// MissingWantedOptionInfo describes a valid option for a missing wanted field.
-type MissingWantedOptionInfo struct {
-	Value       string `json:"value"`
-	Description string `json:"description,omitempty"`
-}
+// ExportBoard returns a self-contained JSON export of a board and its cards.
+func (h *Handler) ExportBoard(w http.ResponseWriter, r *http.Request) {
+	boardName := r.PathValue("board")
 
-// MissingWantedFieldInfo describes a wanted field that is missing from a card.
-type MissingWantedFieldInfo struct {
-	Name        string                    `json:"name"`
-	Type        string                    `json:"type"`
-	Description string                    `json:"description,omitempty"`
-	Options     []MissingWantedOptionInfo `json:"options,omitempty"`
+	export, err := h.ctx().BoardService.Export(boardName)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", boardName+".json"))
+	JSON(w, http.StatusOK, export)
 }
 
-// CreateCardResponse is the JSON response for creating a card.
-type CreateCardResponse struct {
-	Card                CardResponse             `json:"card"`
-	HookResults         []HookInfo               `json:"hook_results,omitempty"`
-	MissingWantedFields []MissingWantedFieldInfo `json:"missing_wanted_fields,omitempty"`
-}
+// defaultAuditLimit is how many audit entries are returned when ?limit is unset.
+const defaultAuditLimit = 50
 
-// HookInfo contains information about a hook execution for API response.
-type HookInfo struct {
-	Name    string `json:"name"`
-	Success bool   `json:"success"`
-	Output  string `json:"output,omitempty"`
-	Error   string `json:"error,omitempty"`
+// AuditLogResponse is the JSON response for a board's audit log.
+type AuditLogResponse struct {
+	Entries []model.AuditEntry `json:"entries"`
 }
 
-// CreateCard creates a new card.
-func (h *Handler) CreateCard(w http.ResponseWriter, r *http.Request) {
+// GetBoardAudit returns a board's most recent audit entries, newest first.
+// ?limit caps the count (default 50).
+func (h *Handler) GetBoardAudit(w http.ResponseWriter, r *http.Request) {
 	boardName := r.PathValue("board")
 
-	var req CreateCardRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		BadRequest(w, "invalid JSON body")
+	limit, err := intQueryParam(r.URL.Query().Get("limit"), defaultAuditLimit)
+	if err != nil || limit < 1 {
+		BadRequest(w, "limit must be a positive integer")
 		return
 	}
 
-	if req.Title == "" {
-		BadRequest(w, "title is required")
+	if !h.ctx().BoardStore.Exists(boardName) {
+		NotFound(w, "board", boardName)
 		return
 	}
 
-	input := service.AddCardInput{
-		BoardName:    boardName,
-		Title:        req.Title,
-		Description:  req.Description,
-		Column:       req.Column,
-		Parent:       req.Parent,
-		Creator:      h.ctx().Creator,
-		CustomFields: stringifyCustomFields(req.CustomFields),
-	}
-
-	card, hookResults, err := h.ctx().CardService.Add(input)
+	entries, err := h.ctx().AuditStore.Recent(boardName, limit)
 	if err != nil {
 		Error(w, err)
 		return
 	}
+	JSON(w, http.StatusOK, AuditLogResponse{Entries: entries})
+}
 
-	// Get board config for wanted fields check
-	boardCfg, _ := h.ctx().BoardStore.Get(boardName)
-
-	// Build hook info response
-	var hookInfos []HookInfo
-	for _, result := range hookResults {
-		info := HookInfo{
-			Name:    result.HookName,
-			Success: result.Success,
-			Output:  result.Stdout,
-		}
-		if result.Error != nil {
-			info.Error = result.Error.Error()
-		}
-		hookInfos = append(hookInfos, info)
-	}
-
-	// Build card response with wanted fields check
-	cardResp := toCardResponseWithWanted(card, boardCfg)
+// defaultHookHistoryLimit is how many hook history entries are returned when
+// ?limit is unset.
+const defaultHookHistoryLimit = 100
 
-	JSON(w, http.StatusCreated, CreateCardResponse{
-		Card:                cardResp,
-		HookResults:         hookInfos,
-		MissingWantedFields: cardResp.MissingWantedFields, // Same data at both levels for compatibility
-	})
+// HookHistoryResponse is the JSON response for a board's hook history.
+type HookHistoryResponse struct {
+	Entries []model.HookHistoryEntry `json:"entries"`
 }
 
-// GetCard returns a single card by ID.
-func (h *Handler) GetCard(w http.ResponseWriter, r *http.Request) {
+// GetHookHistory returns a board's most recent hook executions, newest first.
+func (h *Handler) GetHookHistory(w http.ResponseWriter, r *http.Request) {
 	boardName := r.PathValue("board")
-	cardID := r.PathValue("id")
 
-	card, err := h.ctx().CardService.FindByIDOrAlias(boardName, cardID)
+	limit, err := intQueryParam(r.URL.Query().Get("limit"), defaultHookHistoryLimit)
+	if err != nil || limit < 1 {
+		BadRequest(w, "limit must be a positive integer")
+		return
+	}
+
+	if !h.ctx().BoardStore.Exists(boardName) {
+		NotFound(w, "board", boardName)
+		return
+	}
+
+	entries, err := h.ctx().HookHistoryStore.List(boardName, limit)
 	if err != nil {
 		Error(w, err)
 		return
 	}
+	JSON(w, http.StatusOK, HookHistoryResponse{Entries: entries})
+}
 
-	// Get board config for wanted fields check
-	boardCfg, _ := h.ctx().BoardStore.Get(boardName)
-	JSON(w, http.StatusOK, toCardResponseWithWanted(card, boardCfg))
+// LinkRulePreviewRequest is the JSON body for previewing a board's link rules.
+type LinkRulePreviewRequest struct {
+	Text string `json:"text"`
 }
 
-// UpdateCardRequest is the JSON body for updating a card.
-type UpdateCardRequest struct {
-	Title        *string        `json:"title,omitempty"`
-	Description  *string        `json:"description,omitempty"`
-	Column       *string        `json:"column,omitempty"`
-	CustomFields map[string]any `json:"custom_fields,omitempty"`
+// LinkRulePreviewResponse is the link the first matching rule makes of the text.
+type LinkRulePreviewResponse struct {
+	Matched bool   `json:"matched"`
+	Rule    string `json:"rule,omitempty"`
+	URL     string `json:"url,omitempty"`
 }
 
-// UpdateCard updates an existing card.
-func (h *Handler) UpdateCard(w http.ResponseWriter, r *http.Request) {
+// PreviewLinkRules tries the board's link rules, in order, against some text
+// and returns the URL the first matching rule builds from it.
+func (h *Handler) PreviewLinkRules(w http.ResponseWriter, r *http.Request) {
 	boardName := r.PathValue("board")
-	cardID := r.PathValue("id")
 
-	card, err := h.ctx().CardService.FindByIDOrAlias(boardName, cardID)
-	if err != nil {
-		Error(w, err)
-		return
-	}
-
-	var req UpdateCardRequest
+	var req LinkRulePreviewRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		BadRequest(w, "invalid JSON body")
 		return
 	}
 
-	// Handle column change separately (uses MoveCard to update card file)
-	if req.Column != nil && *req.Column != card.Column {
-		if err := h.ctx().CardService.MoveCard(boardName, card.ID, *req.Column); err != nil {
-			Error(w, err)
-			return
-		}
-		// Re-fetch so the response reflects the move (column, position, history)
-		// instead of only patching the column on the stale in-memory card.
-		card, err = h.ctx().CardService.Get(boardName, card.ID)
-		if err != nil {
-			Error(w, err)
-			return
-		}
-	}
-
-	// Apply other updates via Edit
-	input := service.EditCardInput{
-		BoardName:     boardName,
-		CardIDOrAlias: card.ID,
-		Title:         req.Title,
-		Description:   req.Description,
-		CustomFields:  stringifyCustomFields(req.CustomFields),
+	cfg, err := h.ctx().BoardService.Get(boardName)
+	if err != nil {
+		Error(w, err)
+		return
 	}
-
-	// Only call Edit if there are changes to apply
-	if req.Title != nil || req.Description != nil || len(req.CustomFields) > 0 {
-		updated, err := h.ctx().CardService.Edit(input)
-		if err != nil {
-			Error(w, err)
+	for _, rule := range cfg.LinkRules {
+		if url, ok := rule.Preview(req.Text); ok {
+			JSON(w, http.StatusOK, LinkRulePreviewResponse{Matched: true, Rule: rule.Name, URL: url})
 			return
 		}
-		card = updated
 	}
+	JSON(w, http.StatusOK, LinkRulePreviewResponse{Matched: false})
+}
 
-	// Get board config for wanted fields check
-	boardCfg, _ := h.ctx().BoardStore.Get(boardName)
-	JSON(w, http.StatusOK, toCardResponseWithWanted(card, boardCfg))
+// ColumnStatsResponse is one column's entry in BoardStatsResponse.
+type ColumnStatsResponse struct {
+	Name         string `json:"name"`
+	Count        int    `json:"count"`
+	AvgAgeMillis int64  `json:"avg_age_millis"`
 }
 
-// DeleteCard deletes a card.
-func (h *Handler) DeleteCard(w http.ResponseWriter, r *http.Request) {
+// BoardStatsResponse is the JSON response for board statistics.
+type BoardStatsResponse struct {
+	Columns            []ColumnStatsResponse `json:"columns"`
+	TotalCards         int                   `json:"total_cards"`
+	DoneCount          int                   `json:"done_count"`
+	SinceMillis        int64                 `json:"since_millis"`
+	UntilMillis        int64                 `json:"until_millis"`
+	CreatedInWindow    int                   `json:"created_in_window"`
+	CompletedInWindow  int                   `json:"completed_in_window"`
+	AvgCycleTimeMillis int64                 `json:"avg_cycle_time_millis"`
+	ThroughputPerDay   float64               `json:"throughput_per_day"`
+}
+
+// GetBoardStats returns card counts, cycle time and throughput for a board.
+// ?since and ?until (epoch millis) bound the window; by default it covers the
+// last 30 days.
+func (h *Handler) GetBoardStats(w http.ResponseWriter, r *http.Request) {
 	boardName := r.PathValue("board")
-	cardID := r.PathValue("id")
+	query := r.URL.Query()
 
-	// First resolve the card ID (might be an alias)
-	card, err := h.ctx().CardService.FindByIDOrAlias(boardName, cardID)
+	since, err := intQueryParam(query.Get("since"), 0)
 	if err != nil {
-		Error(w, err)
+		BadRequest(w, "since must be an integer")
+		return
+	}
+	until, err := intQueryParam(query.Get("until"), 0)
+	if err != nil {
+		BadRequest(w, "until must be an integer")
 		return
 	}
 
-	if err := h.ctx().CardService.Delete(boardName, card.ID); err != nil {
+	stats, err := h.ctx().BoardService.Statistics(boardName, int64(since), int64(until))
+	if err != nil {
 		Error(w, err)
 		return
 	}
 
-	w.WriteHeader(http.StatusNoContent)
+	resp := BoardStatsResponse{
+		Columns:            make([]ColumnStatsResponse, len(stats.Columns)),
+		TotalCards:         stats.TotalCards,
+		DoneCount:          stats.DoneCount,
+		SinceMillis:        stats.SinceMillis,
+		UntilMillis:        stats.UntilMillis,
+		CreatedInWindow:    stats.CreatedInWindow,
+		CompletedInWindow:  stats.CompletedInWindow,
+		AvgCycleTimeMillis: stats.AvgCycleTimeMillis,
+		ThroughputPerDay:   stats.ThroughputPerDay,
+	}
+	for i, col := range stats.Columns {
+		resp.Columns[i] = ColumnStatsResponse{Name: col.Name, Count: col.Count, AvgAgeMillis: col.AvgAgeMillis}
+	}
+	JSON(w, http.StatusOK, resp)
+}
+
+// ColumnCompletionResponse is one column's entry in CompletionResponse.
+type ColumnCompletionResponse struct {
+	Name          string  `json:"name"`
+	CardCount     int     `json:"card_count"`
+	DoneCount     int     `json:"done_count"`
+	CompletionPct float64 `json:"completion_pct"`
+}
+
+// CompletionResponse is the JSON response for a board's completion stats.
+type CompletionResponse struct {
+	Columns       []ColumnCompletionResponse `json:"columns"`
+	TotalCards    int                        `json:"total_cards"`
+	TotalDone     int                        `json:"total_done"`
+	CompletionPct float64                    `json:"completion_pct"`
+}
+
+// GetBoardCompletion returns how many of a board's cards are in done columns,
+// per column and overall.
+func (h *Handler) GetBoardCompletion(w http.ResponseWriter, r *http.Request) {
+	stats, err := h.ctx().BoardService.CompletionStats(r.PathValue("board"))
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	resp := CompletionResponse{
+		Columns:       make([]ColumnCompletionResponse, len(stats.ColumnStats)),
+		TotalCards:    stats.TotalCards,
+		TotalDone:     stats.TotalDone,
+		CompletionPct: stats.CompletionPct,
+	}
+	for i, col := range stats.ColumnStats {
+		resp.Columns[i] = ColumnCompletionResponse{
+			Name:          col.Name,
+			CardCount:     col.CardCount,
+			DoneCount:     col.DoneCount,
+			CompletionPct: col.CompletionPct,
+		}
+	}
+	JSON(w, http.StatusOK, resp)
+}
+
+// defaultVelocityWindowDays is the velocity window when ?window_days is unset.
+const defaultVelocityWindowDays = 14
+
+// VelocityResponse is the JSON response for a board's sprint velocity.
+type VelocityResponse struct {
+	DoneColumn string  `json:"done_column"`
+	WindowDays int     `json:"window_days"`
+	Velocity   float64 `json:"velocity"`
+}
+
+// GetBoardVelocity returns the story points finished within ?window_days
+// (default 14) in ?done_column (default: the board's first done column).
+func (h *Handler) GetBoardVelocity(w http.ResponseWriter, r *http.Request) {
+	boardName := r.PathValue("board")
+	query := r.URL.Query()
+
+	windowDays, err := intQueryParam(query.Get("window_days"), defaultVelocityWindowDays)
+	if err != nil {
+		BadRequest(w, "window_days must be an integer")
+		return
+	}
+
+	doneColumn := query.Get("done_column")
+	if doneColumn == "" {
+		cfg, err := h.ctx().BoardStore.Get(boardName)
+		if err != nil {
+			Error(w, err)
+			return
+		}
+		for _, col := range cfg.Columns {
+			if cfg.IsDoneColumn(col.Name) {
+				doneColumn = col.Name
+				break
+			}
+		}
+		if doneColumn == "" {
+			BadRequest(w, "board has no done column; pass done_column")
+			return
+		}
+	}
+
+	velocity, err := h.ctx().BoardService.SprintVelocity(boardName, doneColumn, windowDays)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+	JSON(w, http.StatusOK, VelocityResponse{DoneColumn: doneColumn, WindowDays: windowDays, Velocity: velocity})
+}
+
+// AutoArchiveResponse reports the result of an auto-archive run.
+type AutoArchiveResponse struct {
+	Archived int `json:"archived"`
+}
+
+// AutoArchive archives cards that have outstayed their done column's
+// auto_archive_after_days. The server also runs this hourly.
+func (h *Handler) AutoArchive(w http.ResponseWriter, r *http.Request) {
+	boardName := r.PathValue("board")
+
+	archived, err := h.autoArchive(boardName)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	JSON(w, http.StatusOK, AutoArchiveResponse{Archived: archived})
+}
+
+// autoArchive runs the board's auto-archive policies and publishes an update
+// for each archived card, as ArchiveCard does. Cards archived before an error
+// are still published. Returns how many were archived.
+func (h *Handler) autoArchive(boardName string) (int, error) {
+	archived, err := h.ctx().BoardService.RunAutoArchiveWithIDs(boardName)
+	for _, id := range archived {
+		// Archiving clears the column, so the event carries none.
+		h.publish(boardName, BoardEvent{EventType: EventCardUpdated, CardID: id})
+	}
+	return len(archived), err
+}
+
+// ImportBoardResponse is returned when a board is imported.
+type ImportBoardResponse struct {
+	Board string `json:"board"`
+}
+
+// ImportBoard creates a board from an export document (the request body).
+// ?name= imports under a different name; by default the exported name is used.
+func (h *Handler) ImportBoard(w http.ResponseWriter, r *http.Request) {
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		bodyReadError(w, err, h.maxUploadBytes())
+		return
+	}
+
+	name := r.URL.Query().Get("name")
+	if name == "" {
+		name = service.ExportedBoardName(data)
+	}
+
+	if err := h.ctx().BoardService.Import(data, name); err != nil {
+		Error(w, err)
+		return
+	}
+	JSON(w, http.StatusCreated, ImportBoardResponse{Board: name})
+}
+
+// ImportTrelloBoard creates a board from a Trello board export in the request
+// body. ?name= names the board; it defaults to the slugified Trello board name.
+func (h *Handler) ImportTrelloBoard(w http.ResponseWriter, r *http.Request) {
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		bodyReadError(w, err, h.maxUploadBytes())
+		return
+	}
+
+	name := r.URL.Query().Get("name")
+	if name == "" {
+		name = service.TrelloBoardName(data)
+	}
+
+	if err := h.ctx().BoardService.ImportFromTrello(data, name); err != nil {
+		Error(w, err)
+		return
+	}
+	JSON(w, http.StatusCreated, ImportBoardResponse{Board: name})
+}
+
+// DuplicateBoardRequest is the JSON body for duplicating a board.
+type DuplicateBoardRequest struct {
+	Name string `json:"name"`
+}
+
+// DuplicateBoard copies a board, with fresh card IDs, under a new name.
+func (h *Handler) DuplicateBoard(w http.ResponseWriter, r *http.Request) {
+	boardName := r.PathValue("board")
+
+	var req DuplicateBoardRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		BadRequest(w, "invalid JSON body")
+		return
+	}
+	if req.Name == "" {
+		BadRequest(w, "name is required")
+		return
+	}
+
+	if err := h.ctx().BoardService.Duplicate(boardName, req.Name); err != nil {
+		Error(w, err)
+		return
+	}
+	JSON(w, http.StatusCreated, ImportBoardResponse{Board: req.Name})
+}
+
+// MergeBoardsRequest is the JSON body for merging a board into another.
+type MergeBoardsRequest struct {
+	Target        string            `json:"target"`
+	ColumnMapping map[string]string `json:"column_mapping,omitempty"` // Source column -> target column; unmapped columns keep their name
+	ConflictAlias string            `json:"conflict_alias,omitempty"` // "regenerate" (default) or "skip"
+	DeleteSource  bool              `json:"delete_source,omitempty"`
+}
+
+// MergeBoardsResponse describes a merge.
+type MergeBoardsResponse struct {
+	Moved            int      `json:"moved"`
+	Skipped          int      `json:"skipped"`
+	AliasRegenerated int      `json:"alias_regenerated"`
+	DroppedFields    []string `json:"dropped_fields,omitempty"` // Custom fields the target board doesn't accept
+	SourceDeleted    bool     `json:"source_deleted"`
+}
+
+// MergeBoards moves every card of a board, keeping their IDs, into another
+// board, optionally deleting the emptied source board.
+func (h *Handler) MergeBoards(w http.ResponseWriter, r *http.Request) {
+	boardName := r.PathValue("board")
+
+	var req MergeBoardsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		BadRequest(w, "invalid JSON body")
+		return
+	}
+	if req.Target == "" {
+		BadRequest(w, "target is required")
+		return
+	}
+
+	result, err := h.ctx().BoardService.MergeBoards(boardName, req.Target, service.MergeOptions{
+		ColumnMapping: req.ColumnMapping,
+		ConflictAlias: req.ConflictAlias,
+		DeleteSource:  req.DeleteSource,
+	})
+	if err != nil {
+		Error(w, err)
+		return
+	}
+	for _, card := range result.MovedCards {
+		h.publish(boardName, BoardEvent{EventType: EventCardDeleted, CardID: card.ID})
+		h.publishCardEvent(req.Target, EventCardCreated, card)
+	}
+	for _, card := range result.Unlinked {
+		h.publishCardEvent(boardName, EventCardUpdated, card)
+	}
+	JSON(w, http.StatusOK, MergeBoardsResponse{
+		Moved:            result.Moved,
+		Skipped:          result.Skipped,
+		AliasRegenerated: result.AliasRegenerated,
+		DroppedFields:    result.DroppedFields,
+		SourceDeleted:    result.SourceDeleted,
+	})
+}
+
+// UpdateCollaboratorsRequest is the JSON body for changing a board's collaborators.
+type UpdateCollaboratorsRequest struct {
+	Add    []string `json:"add,omitempty"`
+	Remove []string `json:"remove,omitempty"`
+}
+
+// UpdateCollaboratorsResponse is the response for changing a board's collaborators.
+type UpdateCollaboratorsResponse struct {
+	Collaborators []string `json:"collaborators"`
+	Warnings      []string `json:"warnings,omitempty"`
+}
+
+// UpdateCollaborators adds and removes the collaborators user fields accept.
+// Removing a collaborator still named on cards succeeds with a warning.
+func (h *Handler) UpdateCollaborators(w http.ResponseWriter, r *http.Request) {
+	boardName := r.PathValue("board")
+
+	var req UpdateCollaboratorsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		BadRequest(w, "invalid JSON body")
+		return
+	}
+
+	stillAssigned, err := h.ctx().BoardService.UpdateCollaborators(boardName, req.Add, req.Remove)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+	board, err := h.ctx().BoardStore.Get(boardName)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	resp := UpdateCollaboratorsResponse{Collaborators: board.Collaborators}
+	if resp.Collaborators == nil {
+		resp.Collaborators = []string{}
+	}
+	for _, name := range req.Remove {
+		if n := stillAssigned[name]; n > 0 {
+			resp.Warnings = append(resp.Warnings, fmt.Sprintf("%s is still assigned on %d card(s)", name, n))
+		}
+	}
+	JSON(w, http.StatusOK, resp)
+}
+
+// --- Card Handlers ---
+
+// PaginatedCardList is the JSON response for listing cards. Total counts every
+// card matching the filters, not just those on this page.
+type PaginatedCardList struct {
+	Cards      []CardResponse `json:"cards"`
+	Total      int            `json:"total"`
+	Page       int            `json:"page"`
+	PerPage    int            `json:"per_page"`
+	TotalPages int            `json:"total_pages"`
+}
+
+// ListCards returns the cards for a board, optionally filtered by column.
+// Archived cards are omitted unless ?include_archived=true, ?overdue=true
+// keeps only cards whose due date has passed, and ?has_incomplete_checklist=true
+// keeps only cards with an unchecked checklist item, ?stale=true keeps only
+// cards not updated within the board's stale_days, and ?has_field=NAME and
+// ?missing_field=NAME keep only cards with or without a value for a custom
+// field, and ?mentioned=USER keeps only cards with a comment mentioning USER.
+// ?page and ?per_page select a page (defaults 1 and 50); with neither set,
+// every card is returned on a single page.
+func (h *Handler) ListCards(w http.ResponseWriter, r *http.Request) {
+	boardName := r.PathValue("board")
+	query := r.URL.Query()
+	columnFilter := query.Get("column")
+	includeArchived := query.Get("include_archived") == "true"
+	overdueOnly := query.Get("overdue") == "true"
+	incompleteChecklistOnly := query.Get("has_incomplete_checklist") == "true"
+	staleOnly := query.Get("stale") == "true"
+	hasField := query.Get("has_field")
+	missingField := query.Get("missing_field")
+	mentioned := query.Get("mentioned")
+	filtered := overdueOnly || incompleteChecklistOnly || staleOnly || hasField != "" || missingField != "" || mentioned != ""
+
+	paginate := query.Has("page") || query.Has("per_page")
+	page, err := intQueryParam(query.Get("page"), 1)
+	if err != nil {
+		BadRequest(w, "page must be an integer")
+		return
+	}
+	perPage, err := intQueryParam(query.Get("per_page"), defaultPerPage)
+	if err != nil {
+		BadRequest(w, "per_page must be an integer")
+		return
+	}
+	sortBy, err := model.ParseSortFields(query.Get("sort"))
+	if err != nil {
+		BadRequest(w, err.Error())
+		return
+	}
+
+	// Verify board exists first
+	if !h.ctx().BoardStore.Exists(boardName) {
+		NotFound(w, "board", boardName)
+		return
+	}
+
+	var cards []*model.Card
+	total := 0
+	switch {
+	case len(sortBy) > 0:
+		cards, err = h.ctx().CardService.ListWithOptions(boardName, columnFilter, service.ListOptions{
+			SortBy:          sortBy,
+			IncludeArchived: includeArchived,
+			Streaming:       columnFilter != "",
+		})
+	case paginate && !includeArchived && !filtered:
+		cards, total, err = h.ctx().CardService.ListPaginated(boardName, columnFilter, page, perPage)
+	case includeArchived:
+		cards, err = h.ctx().CardService.ListIncludingArchived(boardName, columnFilter)
+	default:
+		cards, err = h.ctx().CardService.List(boardName, columnFilter)
+	}
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	// Get board config for wanted fields check
+	boardCfg, _ := h.ctx().BoardStore.Get(boardName)
+	h.updateColumnGauge(boardName, boardCfg, cards, columnFilter == "" && !paginate)
+
+	for _, name := range []string{hasField, missingField} {
+		if name == "" || boardCfg == nil {
+			continue
+		}
+		if _, ok := boardCfg.CustomFields[name]; !ok {
+			BadRequest(w, fmt.Sprintf("unknown field %q", name))
+			return
+		}
+	}
+
+	if overdueOnly {
+		cards = service.CheckOverdueCards(cards)
+	}
+	if incompleteChecklistOnly {
+		cards = service.FilterIncompleteChecklist(cards)
+	}
+	if staleOnly {
+		cards = service.FilterStaleCards(cards, boardCfg)
+	}
+	if hasField != "" {
+		cards = service.FilterByField(cards, hasField, true)
+	}
+	if missingField != "" {
+		cards = service.FilterByField(cards, missingField, false)
+	}
+	if mentioned != "" {
+		cards = service.FilterByMention(cards, mentioned)
+	}
+	if paginate && (len(sortBy) > 0 || includeArchived || filtered) {
+		// Sorts and filters that the service doesn't page must run before
+		// slicing, otherwise pages would come back short and total would be
+		// wrong.
+		cards, total, err = service.PaginateCards(cards, page, perPage)
+		if err != nil {
+			Error(w, err)
+			return
+		}
+	}
+	if !paginate {
+		total = len(cards)
+		perPage = max(total, 1)
+	}
+
+	JSON(w, http.StatusOK, PaginatedCardList{
+		Cards:      toCardResponses(cards, boardCfg),
+		Total:      total,
+		Page:       page,
+		PerPage:    perPage,
+		TotalPages: (total + perPage - 1) / perPage,
+	})
+}
+
+// updateColumnGauge refreshes kan_cards_total for the board. complete reports
+// whether cards covers the whole board; if not (a column filter or a page was
+// requested), the gauge is left as is rather than paying for a second full
+// listing, so it's only as fresh as the last unfiltered list.
+func (h *Handler) updateColumnGauge(boardName string, boardCfg *model.BoardConfig, cards []*model.Card, complete bool) {
+	if !complete {
+		return
+	}
+
+	counts := make(map[string]int)
+	if boardCfg != nil {
+		for _, col := range boardCfg.Columns {
+			counts[col.Name] = 0
+		}
+	}
+	for _, card := range cards {
+		if !card.Archived {
+			counts[card.Column]++
+		}
+	}
+	metrics.SetColumnCounts(boardName, counts)
+}
+
+// defaultPerPage is the page size used when only ?page is given.
+const defaultPerPage = 50
+
+// intQueryParam parses an integer query parameter, returning def if it's empty.
+func intQueryParam(raw string, def int) (int, error) {
+	if raw == "" {
+		return def, nil
+	}
+	return strconv.Atoi(raw)
+}
+
+// CreateCardRequest is the JSON body for creating a card.
+type CreateCardRequest struct {
+	Title        string         `json:"title"`
+	Description  string         `json:"description,omitempty"`
+	Column       string         `json:"column,omitempty"`
+	Parent       string         `json:"parent,omitempty"`
+	DueAtMillis  int64          `json:"due_at_millis,omitempty"`
+	BlockedBy    []string       `json:"blocked_by,omitempty"`
+	CustomFields map[string]any `json:"custom_fields,omitempty"`
+}
+
+// MissingWantedOptionInfo describes a valid option for a missing wanted field.
+type MissingWantedOptionInfo struct {
+	Value       string `json:"value"`
+	Description string `json:"description,omitempty"`
+}
+
+// MissingWantedFieldInfo describes a wanted field that is missing from a card.
+type MissingWantedFieldInfo struct {
+	Name        string                    `json:"name"`
+	Type        string                    `json:"type"`
+	Description string                    `json:"description,omitempty"`
+	Options     []MissingWantedOptionInfo `json:"options,omitempty"`
+}
+
+// CreateCardResponse is the JSON response for creating a card.
+type CreateCardResponse struct {
+	Card                CardResponse             `json:"card"`
+	HookResults         []HookInfo               `json:"hook_results,omitempty"`
+	HookTaskID          string                   `json:"hook_task_id,omitempty"`
+	MissingWantedFields []MissingWantedFieldInfo `json:"missing_wanted_fields,omitempty"`
+}
+
+// HookInfo contains information about a hook execution for API response.
+type HookInfo struct {
+	Name      string            `json:"name"`
+	Success   bool              `json:"success"`
+	Output    string            `json:"output,omitempty"`
+	Error     string            `json:"error,omitempty"`
+	FieldsSet map[string]string `json:"fields_set,omitempty"`
+}
+
+// CreateCard creates a new card.
+func (h *Handler) CreateCard(w http.ResponseWriter, r *http.Request) {
+	boardName := r.PathValue("board")
+
+	var req CreateCardRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		BadRequest(w, "invalid JSON body")
+		return
+	}
+
+	if req.Title == "" {
+		BadRequest(w, "title is required")
+		return
+	}
+
+	input := service.AddCardInput{
+		BoardName:    boardName,
+		Title:        req.Title,
+		Description:  req.Description,
+		Column:       req.Column,
+		Parent:       req.Parent,
+		Creator:      h.ctx().Creator,
+		DueAtMillis:  req.DueAtMillis,
+		CustomFields: stringifyCustomFields(req.CustomFields),
+	}
+	if req.BlockedBy != nil {
+		input.BlockedBy = &req.BlockedBy
+	}
+
+	// With ?async_hooks=true, pattern hooks run in the background and the
+	// client polls GET /api/v1/hook-tasks/{task_id} for their results.
+	var (
+		card        *model.Card
+		hookResults []*service.HookResult
+		hookTaskID  string
+		err         error
+	)
+	if r.URL.Query().Get("async_hooks") == "true" {
+		card, hookTaskID, err = h.ctx().CardService.AddWithAsyncHooks(input, func(updated *model.Card) {
+			h.publishCardEvent(boardName, EventCardUpdated, updated)
+		})
+	} else {
+		card, hookResults, err = h.ctx().CardService.Add(input)
+	}
+	if err != nil {
+		Error(w, err)
+		return
+	}
+	h.publishCardEvent(boardName, EventCardCreated, card)
+
+	// Get board config for wanted fields check
+	boardCfg, _ := h.ctx().BoardStore.Get(boardName)
+
+	// Build card response with wanted fields check
+	cardResp := toCardResponseWithWanted(card, boardCfg)
+
+	JSON(w, http.StatusCreated, CreateCardResponse{
+		Card:                cardResp,
+		HookResults:         toHookInfos(hookResults),
+		HookTaskID:          hookTaskID,
+		MissingWantedFields: cardResp.MissingWantedFields, // Same data at both levels for compatibility
+	})
+}
+
+// CreateCardFromTemplateRequest is the JSON body for creating a card from a
+// board's card template.
+type CreateCardFromTemplateRequest struct {
+	Template string            `json:"template"`
+	Vars     map[string]string `json:"vars,omitempty"`
+}
+
+// CreateCardFromTemplate creates a card by expanding one of the board's card
+// templates with the given variables.
+func (h *Handler) CreateCardFromTemplate(w http.ResponseWriter, r *http.Request) {
+	boardName := r.PathValue("board")
+
+	var req CreateCardFromTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		BadRequest(w, "invalid JSON body")
+		return
+	}
+
+	if req.Template == "" {
+		BadRequest(w, "template is required")
+		return
+	}
+
+	card, hookResults, err := h.ctx().CardService.ApplyTemplateAs(boardName, req.Template, h.ctx().Creator, req.Vars)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+	h.publishCardEvent(boardName, EventCardCreated, card)
+
+	boardCfg, _ := h.ctx().BoardStore.Get(boardName)
+	cardResp := toCardResponseWithWanted(card, boardCfg)
+
+	JSON(w, http.StatusCreated, CreateCardResponse{
+		Card:                cardResp,
+		HookResults:         toHookInfos(hookResults),
+		MissingWantedFields: cardResp.MissingWantedFields,
+	})
+}
+
+// toHookInfos converts hook results for API output.
+func toHookInfos(results []*service.HookResult) []HookInfo {
+	var infos []HookInfo
+	for _, result := range results {
+		info := HookInfo{
+			Name:      result.HookName,
+			Success:   result.Success,
+			Output:    result.Stdout,
+			FieldsSet: result.FieldsSet,
+		}
+		if result.Error != nil {
+			info.Error = result.Error.Error()
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+// HookTaskResponse is the JSON response for polling an async hook task.
+type HookTaskResponse struct {
+	TaskID      string     `json:"task_id"`
+	Done        bool       `json:"done"`
+	HookResults []HookInfo `json:"hook_results,omitempty"`
+}
+
+// GetHookTask reports the status of hooks started by an async card create.
+func (h *Handler) GetHookTask(w http.ResponseWriter, r *http.Request) {
+	taskID := r.PathValue("task_id")
+
+	done, results, ok := h.ctx().HookService.PollTask(taskID)
+	if !ok {
+		NotFound(w, "hook task", taskID)
+		return
+	}
+
+	JSON(w, http.StatusOK, HookTaskResponse{
+		TaskID:      taskID,
+		Done:        done,
+		HookResults: toHookInfos(results),
+	})
+}
+
+// GetCard returns a single card by ID.
+func (h *Handler) GetCard(w http.ResponseWriter, r *http.Request) {
+	boardName := r.PathValue("board")
+	cardID := r.PathValue("id")
+
+	card, err := h.ctx().CardService.FindByIDOrAlias(boardName, cardID)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	// Get board config for wanted fields check
+	boardCfg, _ := h.ctx().BoardStore.Get(boardName)
+	setCardETag(w, card)
+	JSON(w, http.StatusOK, toCardResponseWithWanted(card, boardCfg))
+}
+
+// FetchCardsRequest is the JSON body for looking up several cards at once.
+type FetchCardsRequest struct {
+	IDs []string `json:"ids"` // Card IDs or aliases
+}
+
+// FetchCardsResponse holds the cards found and the IDs or aliases that
+// matched no card.
+type FetchCardsResponse struct {
+	Cards    []CardResponse `json:"cards"`
+	NotFound []string       `json:"not_found"`
+}
+
+// FetchCards looks up several cards by ID or alias. Missing cards are listed
+// in not_found instead of failing the request, so clients can check which
+// cards they remember still exist.
+func (h *Handler) FetchCards(w http.ResponseWriter, r *http.Request) {
+	boardName := r.PathValue("board")
+
+	var req FetchCardsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		BadRequest(w, "invalid JSON body")
+		return
+	}
+
+	cards, notFound, err := h.ctx().CardService.FetchByIDsOrAliases(boardName, req.IDs)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	boardCfg, _ := h.ctx().BoardStore.Get(boardName)
+	JSON(w, http.StatusOK, FetchCardsResponse{Cards: toCardResponses(cards, boardCfg), NotFound: notFound})
+}
+
+// GetCardBlocks returns the cards that a card directly blocks.
+func (h *Handler) GetCardBlocks(w http.ResponseWriter, r *http.Request) {
+	boardName := r.PathValue("board")
+
+	cards, err := h.ctx().CardService.GetBlockedCards(boardName, r.PathValue("id"))
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	boardCfg, _ := h.ctx().BoardStore.Get(boardName)
+	JSON(w, http.StatusOK, map[string]any{"cards": toCardResponses(cards, boardCfg)})
+}
+
+// GetCardBlockedBy returns every card that transitively blocks a card,
+// nearest blockers first.
+func (h *Handler) GetCardBlockedBy(w http.ResponseWriter, r *http.Request) {
+	boardName := r.PathValue("board")
+
+	cards, err := h.ctx().CardService.GetBlockingChain(boardName, r.PathValue("id"))
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	boardCfg, _ := h.ctx().BoardStore.Get(boardName)
+	JSON(w, http.StatusOK, map[string]any{"cards": toCardResponses(cards, boardCfg)})
+}
+
+// SuggestColumnResponse is where a card should go next. Column is empty when
+// there's no suggestion.
+type SuggestColumnResponse struct {
+	Column string `json:"column"`
+	Reason string `json:"reason"`
+}
+
+// SuggestColumn suggests a column for a card from its checklist, wanted
+// fields and time in its column. The card isn't moved.
+func (h *Handler) SuggestColumn(w http.ResponseWriter, r *http.Request) {
+	boardName := r.PathValue("board")
+
+	card, err := h.ctx().CardService.FindByIDOrAlias(boardName, r.PathValue("id"))
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	column, reason, err := h.ctx().CardService.SuggestColumn(boardName, card.ID)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+	JSON(w, http.StatusOK, SuggestColumnResponse{Column: column, Reason: reason})
+}
+
+// SuggestTagsResponse lists labels or tags suggested for a card title, best
+// first.
+type SuggestTagsResponse struct {
+	Suggestions []string `json:"suggestions"`
+}
+
+// SuggestTags suggests labels or tags for a new card from the title query
+// parameter.
+func (h *Handler) SuggestTags(w http.ResponseWriter, r *http.Request) {
+	suggestions, err := h.ctx().CardService.SuggestTags(r.PathValue("board"), r.URL.Query().Get("title"))
+	if err != nil {
+		Error(w, err)
+		return
+	}
+	JSON(w, http.StatusOK, SuggestTagsResponse{Suggestions: suggestions})
+}
+
+// UpdateCardRequest is the JSON body for updating a card.
+type UpdateCardRequest struct {
+	Title        *string        `json:"title,omitempty"`
+	Description  *string        `json:"description,omitempty"`
+	Column       *string        `json:"column,omitempty"`
+	DueAtMillis  *int64         `json:"due_at_millis,omitempty"` // 0 clears the due date
+	BlockedBy    *[]string      `json:"blocked_by,omitempty"`    // [] clears dependencies
+	CustomFields map[string]any `json:"custom_fields,omitempty"`
+}
+
+// UpdateCard updates an existing card.
+func (h *Handler) UpdateCard(w http.ResponseWriter, r *http.Request) {
+	boardName := r.PathValue("board")
+	cardID := r.PathValue("id")
+
+	card, err := h.ctx().CardService.FindByIDOrAlias(boardName, cardID)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	if lock, locked := h.locks.HeldByOther(boardName, card.ID, requestSessionID(r)); locked {
+		writeCardLocked(w, lock)
+		return
+	}
+
+	var req UpdateCardRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		BadRequest(w, "invalid JSON body")
+		return
+	}
+
+	// Handle column change separately (uses MoveCard to update card file)
+	if req.Column != nil && *req.Column != card.Column {
+		if err := h.ctx().CardService.MoveCard(boardName, card.ID, *req.Column); err != nil {
+			Error(w, err)
+			return
+		}
+		// Re-fetch so the response reflects the move (column, position, history)
+		// instead of only patching the column on the stale in-memory card.
+		card, err = h.ctx().CardService.Get(boardName, card.ID)
+		if err != nil {
+			Error(w, err)
+			return
+		}
+		h.publishCardEvent(boardName, EventCardMoved, card)
+	}
+
+	// Apply other updates via Edit
+	input := service.EditCardInput{
+		BoardName:     boardName,
+		CardIDOrAlias: card.ID,
+		Title:         req.Title,
+		Description:   req.Description,
+		DueAtMillis:   req.DueAtMillis,
+		BlockedBy:     req.BlockedBy,
+		CustomFields:  stringifyCustomFields(req.CustomFields),
+	}
+
+	// Only call Edit if there are changes to apply
+	if req.Title != nil || req.Description != nil || req.DueAtMillis != nil || req.BlockedBy != nil || len(req.CustomFields) > 0 {
+		updated, err := h.ctx().CardService.Edit(input)
+		if err != nil {
+			Error(w, err)
+			return
+		}
+		card = updated
+		h.publishCardEvent(boardName, EventCardUpdated, card)
+	}
+
+	// Get board config for wanted fields check
+	boardCfg, _ := h.ctx().BoardStore.Get(boardName)
+	setCardETag(w, card)
+	JSON(w, http.StatusOK, toCardResponseWithWanted(card, boardCfg))
+}
+
+// DeleteCard deletes a card. ?parent_behavior says what happens to its child
+// cards (see service.DeleteOptions).
+func (h *Handler) DeleteCard(w http.ResponseWriter, r *http.Request) {
+	boardName := r.PathValue("board")
+	cardID := r.PathValue("id")
+
+	// First resolve the card ID (might be an alias)
+	card, err := h.ctx().CardService.FindByIDOrAlias(boardName, cardID)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	opts := service.DeleteOptions{ParentBehavior: r.URL.Query().Get("parent_behavior")}
+	result, err := h.ctx().CardService.DeleteWithResult(boardName, card.ID, opts)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+	h.publishCardEvent(boardName, EventCardDeleted, card)
+	for _, id := range result.DeletedIDs[1:] {
+		h.publishCardEvent(boardName, EventCardDeleted, &model.Card{ID: id})
+	}
+	for _, child := range result.Updated {
+		h.publishCardEvent(boardName, EventCardUpdated, child)
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DeleteCardsRequest is the JSON body for deleting several cards at once.
+type DeleteCardsRequest struct {
+	CardIDs []string `json:"card_ids"`          // Card IDs or aliases
+	DryRun  bool     `json:"dry_run,omitempty"` // Report what would be deleted without deleting
+}
+
+// FailedDeleteResponse is a card DeleteCards found but couldn't delete.
+type FailedDeleteResponse struct {
+	CardID string `json:"card_id"`
+	Error  string `json:"error"`
+}
+
+// DeleteCardsResponse summarizes a bulk delete.
+type DeleteCardsResponse struct {
+	Deleted  []string               `json:"deleted"`
+	NotFound []string               `json:"not_found"`
+	Failed   []FailedDeleteResponse `json:"failed"`
+	DryRun   bool                   `json:"dry_run"`
+}
+
+// DeleteCards deletes several cards. Unknown IDs are listed in not_found and
+// don't stop the rest from being deleted. With dry_run, deleted lists the
+// cards that would be deleted.
+func (h *Handler) DeleteCards(w http.ResponseWriter, r *http.Request) {
+	boardName := r.PathValue("board")
+
+	var req DeleteCardsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		BadRequest(w, "invalid JSON body")
+		return
+	}
+	if len(req.CardIDs) == 0 {
+		BadRequest(w, "card_ids is required")
+		return
+	}
+
+	result, err := h.ctx().CardService.DeleteMany(boardName, req.CardIDs, req.DryRun)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	if !req.DryRun {
+		for _, id := range result.Deleted {
+			h.publish(boardName, BoardEvent{EventType: EventCardDeleted, CardID: id})
+		}
+		for _, child := range result.Updated {
+			h.publishCardEvent(boardName, EventCardUpdated, child)
+		}
+	}
+
+	JSON(w, http.StatusOK, NewDeleteCardsResponse(result, req.DryRun))
+}
+
+// NewDeleteCardsResponse converts a bulk delete result to its JSON shape. The
+// CLI uses it so `kan card delete --many --json` matches the API.
+func NewDeleteCardsResponse(result *service.BulkDeleteResult, dryRun bool) DeleteCardsResponse {
+	resp := DeleteCardsResponse{
+		Deleted:  append([]string{}, result.Deleted...),
+		NotFound: append([]string{}, result.NotFound...),
+		Failed:   []FailedDeleteResponse{},
+		DryRun:   dryRun,
+	}
+	for _, f := range result.Failed {
+		resp.Failed = append(resp.Failed, FailedDeleteResponse{CardID: f.CardID, Error: f.Error})
+	}
+	return resp
 }
 
 // RestoreCardRequest is the JSON body for restoring a deleted card.
@@ -512,100 +1763,577 @@ type RestoreCardRequest struct {
 	Position int             `json:"position"` // Position within the column
 }
 
-// RestoreCard re-creates a previously deleted card from a full snapshot.
-func (h *Handler) RestoreCard(w http.ResponseWriter, r *http.Request) {
+// RestoreCard re-creates a previously deleted card from a full snapshot.
+func (h *Handler) RestoreCard(w http.ResponseWriter, r *http.Request) {
+	boardName := r.PathValue("board")
+
+	var req RestoreCardRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		BadRequest(w, "invalid JSON body")
+		return
+	}
+
+	if req.Column == "" {
+		BadRequest(w, "column is required")
+		return
+	}
+
+	// Unmarshal the card from the raw JSON
+	var card model.Card
+	if err := json.Unmarshal(req.Card, &card); err != nil {
+		BadRequest(w, "invalid card JSON: "+err.Error())
+		return
+	}
+
+	if card.ID == "" {
+		BadRequest(w, "card must have an id")
+		return
+	}
+
+	if err := h.ctx().CardService.Restore(boardName, &card, req.Column, req.Position); err != nil {
+		Error(w, err)
+		return
+	}
+
+	// Populate column for response
+	card.Column = req.Column
+	h.publishCardEvent(boardName, EventCardCreated, &card)
+
+	boardCfg, _ := h.ctx().BoardStore.Get(boardName)
+	JSON(w, http.StatusCreated, toCardResponseWithWanted(&card, boardCfg))
+}
+
+// MoveCardRequest is the JSON body for moving a card.
+type MoveCardRequest struct {
+	Column   string `json:"column"`
+	Position *int   `json:"position,omitempty"` // Optional: position in target column (-1 or omit for end)
+}
+
+// MoveCard moves a card to a different column.
+func (h *Handler) MoveCard(w http.ResponseWriter, r *http.Request) {
+	boardName := r.PathValue("board")
+	cardID := r.PathValue("id")
+
+	// First resolve the card ID (might be an alias)
+	card, err := h.ctx().CardService.FindByIDOrAlias(boardName, cardID)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	var req MoveCardRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		BadRequest(w, "invalid JSON body")
+		return
+	}
+
+	if req.Column == "" {
+		BadRequest(w, "column is required")
+		return
+	}
+
+	// Determine position (-1 means append to end)
+	position := -1
+	if req.Position != nil {
+		position = *req.Position
+	}
+
+	// Use the service's MoveCardAt which updates the card's column and position
+	if err := h.ctx().CardService.MoveCardAt(boardName, card.ID, req.Column, position); err != nil {
+		Error(w, err)
+		return
+	}
+
+	// Re-fetch so the response reflects the move (new column, position, and the
+	// appended history entry) rather than the stale pre-move card.
+	card, err = h.ctx().CardService.Get(boardName, card.ID)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+	h.publishCardEvent(boardName, EventCardMoved, card)
+
+	// Get board config for wanted fields check
+	boardCfg, _ := h.ctx().BoardStore.Get(boardName)
+	setCardETag(w, card)
+	JSON(w, http.StatusOK, toCardResponseWithWanted(card, boardCfg))
+}
+
+// ArchiveCard soft-deletes a card, removing it from its column.
+func (h *Handler) ArchiveCard(w http.ResponseWriter, r *http.Request) {
 	boardName := r.PathValue("board")
+	cardID := r.PathValue("id")
 
-	var req RestoreCardRequest
+	card, err := h.ctx().CardService.FindByIDOrAlias(boardName, cardID)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	if err := h.ctx().CardService.Archive(boardName, card.ID); err != nil {
+		Error(w, err)
+		return
+	}
+
+	card, err = h.ctx().CardService.Get(boardName, card.ID)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+	h.publishCardEvent(boardName, EventCardUpdated, card)
+
+	JSON(w, http.StatusOK, toCardResponse(card))
+}
+
+// UnarchiveCard returns an archived card to the column it was archived from.
+func (h *Handler) UnarchiveCard(w http.ResponseWriter, r *http.Request) {
+	boardName := r.PathValue("board")
+	cardID := r.PathValue("id")
+
+	card, err := h.ctx().CardService.FindByIDOrAlias(boardName, cardID)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	card, err = h.ctx().CardService.Unarchive(boardName, card.ID)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+	h.publishCardEvent(boardName, EventCardMoved, card)
+
+	boardCfg, _ := h.ctx().BoardStore.Get(boardName)
+	JSON(w, http.StatusOK, toCardResponseWithWanted(card, boardCfg))
+}
+
+// BulkMoveCardsRequest is the JSON body for moving several cards at once.
+type BulkMoveCardsRequest struct {
+	CardIDs  []string `json:"card_ids"`           // Card IDs or aliases, in landing order
+	Column   string   `json:"column"`             // Target column
+	Position *int     `json:"position,omitempty"` // Optional: index of the first card (-1 or omit for end)
+}
+
+// BulkMoveCards moves several cards to a column in one all-or-nothing operation.
+// If any card can't be resolved, nothing is moved and a 422 lists the failures.
+func (h *Handler) BulkMoveCards(w http.ResponseWriter, r *http.Request) {
+	boardName := r.PathValue("board")
+
+	var req BulkMoveCardsRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		BadRequest(w, "invalid JSON body")
 		return
 	}
 
+	if len(req.CardIDs) == 0 {
+		BadRequest(w, "card_ids is required")
+		return
+	}
 	if req.Column == "" {
 		BadRequest(w, "column is required")
 		return
 	}
 
-	// Unmarshal the card from the raw JSON
-	var card model.Card
-	if err := json.Unmarshal(req.Card, &card); err != nil {
-		BadRequest(w, "invalid card JSON: "+err.Error())
+	cards, err := h.ctx().CardService.BulkMove(service.BulkMoveCardInput{
+		BoardName: boardName,
+		CardIDs:   req.CardIDs,
+		Column:    req.Column,
+		Position:  req.Position,
+	})
+	if err != nil {
+		Error(w, err)
 		return
 	}
+	for _, card := range cards {
+		h.publishCardEvent(boardName, EventCardMoved, card)
+	}
 
-	if card.ID == "" {
-		BadRequest(w, "card must have an id")
+	boardCfg, _ := h.ctx().BoardStore.Get(boardName)
+	JSON(w, http.StatusOK, map[string]any{"cards": toCardResponses(cards, boardCfg)})
+}
+
+// CloneCardRequest is the JSON body for cloning a card.
+type CloneCardRequest struct {
+	TargetColumn string   `json:"target_column,omitempty"` // Defaults to the source card's column
+	Title        string   `json:"title,omitempty"`         // Defaults to the source card's title
+	TitlePrefix  string   `json:"title_prefix,omitempty"`  // e.g. "[COPY] "
+	Labels       []string `json:"labels,omitempty"`        // Added to the labels copied from the source
+}
+
+// CloneCard copies a card into a new card with a fresh ID and alias.
+func (h *Handler) CloneCard(w http.ResponseWriter, r *http.Request) {
+	boardName := r.PathValue("board")
+	cardID := r.PathValue("id")
+
+	// Body is optional: an empty request clones into the source's column.
+	var req CloneCardRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		BadRequest(w, "invalid JSON body")
 		return
 	}
 
-	if err := h.ctx().CardService.Restore(boardName, &card, req.Column, req.Position); err != nil {
+	card, err := h.ctx().CardService.Clone(boardName, cardID, service.CloneOptions{
+		TargetColumn: req.TargetColumn,
+		Title:        req.Title,
+		TitlePrefix:  req.TitlePrefix,
+		Creator:      h.ctx().Creator,
+		Labels:       req.Labels,
+	})
+	if err != nil {
 		Error(w, err)
 		return
 	}
+	h.publishCardEvent(boardName, EventCardCreated, card)
 
-	// Populate column for response
-	card.Column = req.Column
+	boardCfg, _ := h.ctx().BoardStore.Get(boardName)
+	JSON(w, http.StatusCreated, toCardResponseWithWanted(card, boardCfg))
+}
+
+// CopyCardFieldsRequest is the JSON body for copying custom fields between cards.
+type CopyCardFieldsRequest struct {
+	SourceCard  string   `json:"source_card"`            // Source card ID or alias
+	SourceBoard string   `json:"source_board,omitempty"` // Defaults to the target card's board
+	Fields      []string `json:"fields,omitempty"`       // Empty = all fields shared by both schemas
+}
+
+// CopyCardFields copies custom field values from a source card onto a card.
+func (h *Handler) CopyCardFields(w http.ResponseWriter, r *http.Request) {
+	boardName := r.PathValue("board")
+	cardID := r.PathValue("id")
+
+	var req CopyCardFieldsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		BadRequest(w, "invalid JSON body")
+		return
+	}
+
+	if req.SourceCard == "" {
+		BadRequest(w, "source_card is required")
+		return
+	}
+
+	sourceBoard := req.SourceBoard
+	if sourceBoard == "" {
+		sourceBoard = boardName
+	}
+
+	// Resolve both cards (either might be an alias)
+	target, err := h.ctx().CardService.FindByIDOrAlias(boardName, cardID)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+	source, err := h.ctx().CardService.FindByIDOrAlias(sourceBoard, req.SourceCard)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	card, err := h.ctx().CardService.CopyCustomFieldsAcrossBoards(boardName, target.ID, sourceBoard, source.ID, req.Fields)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+	h.publishCardEvent(boardName, EventCardUpdated, card)
 
 	boardCfg, _ := h.ctx().BoardStore.Get(boardName)
-	JSON(w, http.StatusCreated, toCardResponseWithWanted(&card, boardCfg))
+	JSON(w, http.StatusOK, toCardResponseWithWanted(card, boardCfg))
 }
 
-// MoveCardRequest is the JSON body for moving a card.
-type MoveCardRequest struct {
-	Column   string `json:"column"`
-	Position *int   `json:"position,omitempty"` // Optional: position in target column (-1 or omit for end)
+// TransferCardRequest is the JSON body for moving a card to another board.
+type TransferCardRequest struct {
+	TargetBoard  string `json:"target_board"`
+	TargetColumn string `json:"target_column,omitempty"` // Defaults to the target board's default column
 }
 
-// MoveCard moves a card to a different column.
-func (h *Handler) MoveCard(w http.ResponseWriter, r *http.Request) {
+// TransferCardResponse is the moved card, as it now is on the target board.
+type TransferCardResponse struct {
+	Card          CardResponse `json:"card"`
+	Board         string       `json:"board"`
+	DroppedFields []string     `json:"dropped_fields,omitempty"` // Custom fields the target board doesn't accept
+	AliasChanged  bool         `json:"alias_changed,omitempty"`  // The alias was taken on the target board
+}
+
+// TransferCard moves a card, keeping its ID, to another board.
+func (h *Handler) TransferCard(w http.ResponseWriter, r *http.Request) {
 	boardName := r.PathValue("board")
 	cardID := r.PathValue("id")
 
-	// First resolve the card ID (might be an alias)
+	var req TransferCardRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		BadRequest(w, "invalid JSON body")
+		return
+	}
+	if req.TargetBoard == "" {
+		BadRequest(w, "target_board is required")
+		return
+	}
+
 	card, err := h.ctx().CardService.FindByIDOrAlias(boardName, cardID)
 	if err != nil {
 		Error(w, err)
 		return
 	}
 
-	var req MoveCardRequest
+	result, err := h.ctx().BoardService.MoveCardAcrossBoards(boardName, req.TargetBoard, card.ID, req.TargetColumn)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+	h.publishCardEvent(boardName, EventCardDeleted, card)
+	h.publishCardEvent(req.TargetBoard, EventCardCreated, result.Card)
+	for _, unlinked := range result.Unlinked {
+		h.publishCardEvent(boardName, EventCardUpdated, unlinked)
+	}
+
+	boardCfg, _ := h.ctx().BoardStore.Get(req.TargetBoard)
+	JSON(w, http.StatusOK, TransferCardResponse{
+		Card:          toCardResponseWithWanted(result.Card, boardCfg),
+		Board:         req.TargetBoard,
+		DroppedFields: result.DroppedFields,
+		AliasChanged:  result.AliasChanged,
+	})
+}
+
+// SearchRequest is the JSON body for searching a board's cards.
+type SearchRequest struct {
+	Query         string   `json:"query"`
+	Fields        []string `json:"fields,omitempty"` // title, description, comments (default: title + description)
+	CaseSensitive bool     `json:"case_sensitive,omitempty"`
+	UseRegex      bool     `json:"use_regex,omitempty"`
+}
+
+// SearchResponse is the JSON response for a board search.
+type SearchResponse struct {
+	Results []CardResponse `json:"results"`
+	Count   int            `json:"count"`
+}
+
+// SearchCards finds cards on a board whose text matches a query.
+func (h *Handler) SearchCards(w http.ResponseWriter, r *http.Request) {
+	boardName := r.PathValue("board")
+
+	var req SearchRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		BadRequest(w, "invalid JSON body")
 		return
 	}
 
-	if req.Column == "" {
-		BadRequest(w, "column is required")
+	if req.Query == "" {
+		BadRequest(w, "query is required")
 		return
 	}
 
-	// Determine position (-1 means append to end)
-	position := -1
-	if req.Position != nil {
-		position = *req.Position
+	cards, err := h.ctx().SearchService.Search(boardName, req.Query, service.SearchOptions{
+		Fields:        req.Fields,
+		CaseSensitive: req.CaseSensitive,
+		UseRegex:      req.UseRegex,
+	})
+	if err != nil {
+		Error(w, err)
+		return
 	}
 
-	// Use the service's MoveCardAt which updates the card's column and position
-	if err := h.ctx().CardService.MoveCardAt(boardName, card.ID, req.Column, position); err != nil {
+	boardCfg, _ := h.ctx().BoardStore.Get(boardName)
+	JSON(w, http.StatusOK, SearchResponse{
+		Results: toCardResponses(cards, boardCfg),
+		Count:   len(cards),
+	})
+}
+
+// --- Column Handlers ---
+
+// ColumnSummaryResponse describes how full a column is.
+type ColumnSummaryResponse struct {
+	Name        string  `json:"name"`
+	CardCount   int     `json:"card_count"`
+	Limit       int     `json:"limit"`
+	PercentFull float64 `json:"percent_full"`
+	AtLimit     bool    `json:"at_limit"`
+}
+
+// ColumnSummariesResponse is the JSON response for listing columns.
+type ColumnSummariesResponse struct {
+	Columns []ColumnSummaryResponse `json:"columns"`
+}
+
+// columnSummaries returns the column summaries for a board.
+func (h *Handler) columnSummaries(boardName string) ([]ColumnSummaryResponse, error) {
+	summaries, err := h.ctx().BoardService.GetColumnSummary(boardName)
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]ColumnSummaryResponse, len(summaries))
+	for i, s := range summaries {
+		resp[i] = ColumnSummaryResponse{
+			Name:        s.Name,
+			CardCount:   s.CardCount,
+			Limit:       s.Limit,
+			PercentFull: s.PercentFull,
+			AtLimit:     s.AtLimit,
+		}
+	}
+	return resp, nil
+}
+
+// ListColumns returns each column's card count and how close it is to its
+// limit.
+func (h *Handler) ListColumns(w http.ResponseWriter, r *http.Request) {
+	columns, err := h.columnSummaries(r.PathValue("board"))
+	if err != nil {
 		Error(w, err)
 		return
 	}
+	JSON(w, http.StatusOK, ColumnSummariesResponse{Columns: columns})
+}
 
-	// Re-fetch so the response reflects the move (new column, position, and the
-	// appended history entry) rather than the stale pre-move card.
-	card, err = h.ctx().CardService.Get(boardName, card.ID)
+// ColumnCardResponse is a card in a column listing. Its position is the
+// card's 0-based place in the column rather than its fractional sort key.
+type ColumnCardResponse struct {
+	CardResponse
+	Position int `json:"position"`
+}
+
+// MarshalJSON writes the card with position as its index, which
+// CardResponse.MarshalJSON would otherwise write as the sort key.
+func (c ColumnCardResponse) MarshalJSON() ([]byte, error) {
+	return c.CardResponse.marshalJSON(map[string]any{"position": c.Position})
+}
+
+// ColumnCardsResponse is the JSON response for one column's cards.
+type ColumnCardsResponse struct {
+	Column string               `json:"column"`
+	Cards  []ColumnCardResponse `json:"cards"`
+	Total  int                  `json:"total"`
+}
+
+// ListColumnCards returns the unarchived cards in one column, in board order.
+func (h *Handler) ListColumnCards(w http.ResponseWriter, r *http.Request) {
+	boardName := r.PathValue("board")
+	columnName := r.PathValue("name")
+
+	boardCfg, err := h.ctx().BoardService.Get(boardName)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+	if !boardCfg.HasColumn(columnName) {
+		Error(w, kanerr.ColumnNotFound(columnName, boardName))
+		return
+	}
+
+	cards, err := h.ctx().CardService.List(boardName, columnName)
 	if err != nil {
 		Error(w, err)
 		return
 	}
 
-	// Get board config for wanted fields check
-	boardCfg, _ := h.ctx().BoardStore.Get(boardName)
-	JSON(w, http.StatusOK, toCardResponseWithWanted(card, boardCfg))
+	resp := ColumnCardsResponse{Column: columnName, Cards: make([]ColumnCardResponse, len(cards)), Total: len(cards)}
+	for i, card := range cards {
+		resp.Cards[i] = ColumnCardResponse{CardResponse: toCardResponseWithWanted(card, boardCfg), Position: i}
+	}
+	JSON(w, http.StatusOK, resp)
 }
 
-// --- Column Handlers ---
+// SprintResponse is the column running the active sprint and its cards.
+type SprintResponse struct {
+	Column model.Column   `json:"column"`
+	Cards  []CardResponse `json:"cards"`
+}
+
+// GetActiveSprint returns the column whose sprint covers now, with its
+// unarchived cards in board order.
+func (h *Handler) GetActiveSprint(w http.ResponseWriter, r *http.Request) {
+	boardName := r.PathValue("board")
+
+	col, err := h.ctx().BoardService.GetActiveSprint(boardName)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+	boardCfg, err := h.ctx().BoardService.Get(boardName)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+	cards, err := h.ctx().CardService.List(boardName, col.Name)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	resp := SprintResponse{Column: *col, Cards: make([]CardResponse, len(cards))}
+	for i, card := range cards {
+		resp.Cards[i] = toCardResponseWithWanted(card, boardCfg)
+	}
+	JSON(w, http.StatusOK, resp)
+}
+
+// StartSprintRequest is the JSON body for starting a sprint.
+type StartSprintRequest struct {
+	Column       string `json:"column"`
+	DurationDays int    `json:"duration_days,omitempty"` // 0 = 14 days
+}
+
+// StartSprint starts a sprint in a column now, replacing any sprint the
+// column had, and returns the updated column.
+func (h *Handler) StartSprint(w http.ResponseWriter, r *http.Request) {
+	boardName := r.PathValue("board")
+
+	var req StartSprintRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		BadRequest(w, "invalid JSON body")
+		return
+	}
+	if req.Column == "" {
+		BadRequest(w, "column is required")
+		return
+	}
+
+	h.columnMu.Lock()
+	defer h.columnMu.Unlock()
+	col, err := h.ctx().BoardService.StartSprint(boardName, req.Column, req.DurationDays)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+	JSON(w, http.StatusOK, col)
+}
+
+// revisionHeader lets column changes name the board revision they were based
+// on, so a change made against a stale config is refused instead of
+// clobbering a concurrent one.
+const revisionHeader = "If-Revision"
+
+// checkRevision compares the request's If-Revision header, if present, with
+// the board's current revision. On a mismatch it writes a 409 with the current
+// revision and returns false. Callers must hold h.columnMu until their write
+// completes, so the revision can't move between the check and the change.
+func (h *Handler) checkRevision(w http.ResponseWriter, r *http.Request, boardName string) bool {
+	header := r.Header.Get(revisionHeader)
+	if header == "" {
+		return true
+	}
+	expected, err := strconv.Atoi(header)
+	if err != nil {
+		BadRequest(w, "invalid "+revisionHeader+" header: must be an integer")
+		return false
+	}
+
+	board, err := h.ctx().BoardStore.Get(boardName)
+	if err != nil {
+		Error(w, err)
+		return false
+	}
+	if board.Revision != expected {
+		JSON(w, http.StatusConflict, map[string]any{
+			"error":            "board config modified",
+			"current_revision": board.Revision,
+		})
+		return false
+	}
+	return true
+}
 
 // CreateColumnRequest is the JSON body for creating a column.
 type CreateColumnRequest struct {
@@ -616,6 +2344,13 @@ type CreateColumnRequest struct {
 	Position    *int   `json:"position,omitempty"` // Optional: insert position (-1 or omit for end)
 }
 
+// CreateColumnResponse is the new column, plus the board's updated column
+// summaries.
+type CreateColumnResponse struct {
+	model.Column
+	Columns []ColumnSummaryResponse `json:"columns"`
+}
+
 // CreateColumn creates a new column on a board.
 func (h *Handler) CreateColumn(w http.ResponseWriter, r *http.Request) {
 	boardName := r.PathValue("board")
@@ -637,6 +2372,12 @@ func (h *Handler) CreateColumn(w http.ResponseWriter, r *http.Request) {
 		position = *req.Position
 	}
 
+	h.columnMu.Lock()
+	defer h.columnMu.Unlock()
+	if !h.checkRevision(w, r, boardName) {
+		return
+	}
+
 	if err := h.ctx().BoardService.AddColumn(boardName, req.Name, req.Color, req.Description, position); err != nil {
 		Error(w, err)
 		return
@@ -650,15 +2391,21 @@ func (h *Handler) CreateColumn(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	// Get the updated board to return the new column
-	board, err := h.ctx().BoardStore.Get(boardName)
+	// Get the updated board to return the new column
+	board, err := h.ctx().BoardStore.Get(boardName)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	columns, err := h.columnSummaries(boardName)
 	if err != nil {
 		Error(w, err)
 		return
 	}
 
 	col := board.GetColumn(req.Name)
-	JSON(w, http.StatusCreated, col)
+	JSON(w, http.StatusCreated, CreateColumnResponse{Column: *col, Columns: columns})
 }
 
 // DeleteColumnResponse is returned when a column is deleted.
@@ -671,13 +2418,22 @@ func (h *Handler) DeleteColumn(w http.ResponseWriter, r *http.Request) {
 	boardName := r.PathValue("board")
 	columnName := r.PathValue("name")
 
-	deletedCards, err := h.ctx().BoardService.DeleteColumn(boardName, columnName)
+	h.columnMu.Lock()
+	defer h.columnMu.Unlock()
+	if !h.checkRevision(w, r, boardName) {
+		return
+	}
+
+	deleted, err := h.ctx().BoardService.DeleteColumn(boardName, columnName)
+	for _, card := range deleted {
+		h.publishCardEvent(boardName, EventCardDeleted, card)
+	}
 	if err != nil {
 		Error(w, err)
 		return
 	}
 
-	JSON(w, http.StatusOK, DeleteColumnResponse{DeletedCards: deletedCards})
+	JSON(w, http.StatusOK, DeleteColumnResponse{DeletedCards: len(deleted)})
 }
 
 // UpdateColumnRequest is the JSON body for updating a column.
@@ -699,6 +2455,12 @@ func (h *Handler) UpdateColumn(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	h.columnMu.Lock()
+	defer h.columnMu.Unlock()
+	if !h.checkRevision(w, r, boardName) {
+		return
+	}
+
 	// Handle rename
 	if req.Name != nil && *req.Name != columnName {
 		if err := h.ctx().BoardService.RenameColumn(boardName, columnName, *req.Name); err != nil {
@@ -768,6 +2530,12 @@ func (h *Handler) ReorderColumns(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	h.columnMu.Lock()
+	defer h.columnMu.Unlock()
+	if !h.checkRevision(w, r, boardName) {
+		return
+	}
+
 	if err := h.ctx().BoardService.ReorderColumns(boardName, req.Columns); err != nil {
 		Error(w, err)
 		return
@@ -783,6 +2551,174 @@ func (h *Handler) ReorderColumns(w http.ResponseWriter, r *http.Request) {
 	JSON(w, http.StatusOK, board)
 }
 
+// ReorderColumnCardsRequest is the JSON body for reordering a column's cards.
+type ReorderColumnCardsRequest struct {
+	CardIDs []string `json:"card_ids"`
+}
+
+// ReorderColumnCards sets the order of the cards within a column and returns
+// the column's cards in their new order.
+func (h *Handler) ReorderColumnCards(w http.ResponseWriter, r *http.Request) {
+	boardName := r.PathValue("board")
+	columnName := r.PathValue("column")
+
+	var req ReorderColumnCardsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		BadRequest(w, "invalid JSON body")
+		return
+	}
+
+	if err := h.ctx().CardService.ReorderCards(boardName, columnName, req.CardIDs); err != nil {
+		Error(w, err)
+		return
+	}
+
+	cards, err := h.ctx().CardService.List(boardName, columnName)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+	for _, card := range cards {
+		h.publishCardEvent(boardName, EventCardMoved, card)
+	}
+
+	boardCfg, _ := h.ctx().BoardStore.Get(boardName)
+	JSON(w, http.StatusOK, map[string]any{"cards": toCardResponses(cards, boardCfg)})
+}
+
+// maxCSVUploadMemory caps how much of a CSV upload is buffered in memory;
+// larger files spill to temporary files.
+const maxCSVUploadMemory = 32 << 20
+
+// CSVRowErrorResponse describes a CSV row that failed to import.
+type CSVRowErrorResponse struct {
+	Row   int    `json:"row"`
+	Error string `json:"error"`
+}
+
+// ImportCSVResponse summarizes a CSV import.
+type ImportCSVResponse struct {
+	Imported int                   `json:"imported"`
+	Skipped  int                   `json:"skipped"`
+	Errors   []CSVRowErrorResponse `json:"errors"`
+}
+
+// ImportCardsCSV creates cards from an uploaded CSV file. The request is
+// multipart/form-data with a "file" part and an optional "mapping" field
+// holding a JSON object of CSV header -> card field. Rows that fail are
+// listed in the response while the rest are still imported.
+func (h *Handler) ImportCardsCSV(w http.ResponseWriter, r *http.Request) {
+	boardName := r.PathValue("board")
+
+	if err := r.ParseMultipartForm(maxCSVUploadMemory); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			Error(w, kanerr.RequestTooLarge(h.maxUploadBytes()))
+			return
+		}
+		BadRequest(w, "expected multipart/form-data body")
+		return
+	}
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		BadRequest(w, "file is required")
+		return
+	}
+	defer file.Close()
+
+	var mapping service.CSVMapping
+	if raw := r.FormValue("mapping"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &mapping); err != nil {
+			BadRequest(w, "mapping must be a JSON object of CSV header to card field")
+			return
+		}
+	}
+
+	imported, skipped, err := h.ctx().CardService.ImportCSV(boardName, file, mapping, h.ctx().Creator)
+	for _, card := range imported {
+		h.publishCardEvent(boardName, EventCardCreated, card)
+	}
+	resp := ImportCSVResponse{Imported: len(imported), Skipped: skipped, Errors: []CSVRowErrorResponse{}}
+	var rowErrs *service.CSVImportError
+	if errors.As(err, &rowErrs) {
+		for _, row := range rowErrs.Rows {
+			resp.Errors = append(resp.Errors, CSVRowErrorResponse{Row: row.Row, Error: row.Err.Error()})
+		}
+	} else if err != nil {
+		Error(w, err)
+		return
+	}
+	JSON(w, http.StatusOK, resp)
+}
+
+// jsonlCardStore is implemented by card stores that can export a board's
+// cards as newline-delimited JSON.
+type jsonlCardStore interface {
+	ExportJSONL(boardName string, w io.Writer) error
+}
+
+// jsonlStore returns the context's card store if it supports JSONL, writing
+// a 501 otherwise.
+func (h *Handler) jsonlStore(w http.ResponseWriter) (jsonlCardStore, bool) {
+	s, ok := h.ctx().CardStore.(jsonlCardStore)
+	if !ok {
+		JSON(w, http.StatusNotImplemented, map[string]string{"error": "card store doesn't support JSONL"})
+	}
+	return s, ok
+}
+
+// ExportCardsJSONL streams every card on a board, archived ones included, as
+// newline-delimited JSON, one card per line in column order.
+func (h *Handler) ExportCardsJSONL(w http.ResponseWriter, r *http.Request) {
+	boardName := r.PathValue("board")
+
+	cardStore, ok := h.jsonlStore(w)
+	if !ok {
+		return
+	}
+	if _, err := h.ctx().BoardStore.Get(boardName); err != nil {
+		Error(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	if err := cardStore.ExportJSONL(boardName, w); err != nil {
+		// The status line has gone out, so all that's left is to log it.
+		log.Printf("JSONL export of board %q failed: %v", boardName, err)
+	}
+}
+
+// ImportJSONLResponse summarizes a JSONL import.
+type ImportJSONLResponse struct {
+	Imported int `json:"imported"`
+}
+
+// ImportCardsJSONL writes the newline-delimited JSON cards in the request
+// body to a board, keeping their IDs: cards already on the board are
+// updated, others created. On a bad line, the cards before it stay imported.
+func (h *Handler) ImportCardsJSONL(w http.ResponseWriter, r *http.Request) {
+	boardName := r.PathValue("board")
+
+	// Cards before a bad line stay imported, so they're published either way.
+	created, updated, err := h.ctx().CardService.ImportJSONL(boardName, r.Body)
+	for _, card := range created {
+		h.publishCardEvent(boardName, EventCardCreated, card)
+	}
+	for _, card := range updated {
+		h.publishCardEvent(boardName, EventCardUpdated, card)
+	}
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		Error(w, kanerr.RequestTooLarge(h.maxUploadBytes()))
+		return
+	}
+	if err != nil {
+		Error(w, err)
+		return
+	}
+	JSON(w, http.StatusOK, ImportJSONLResponse{Imported: len(created) + len(updated)})
+}
+
 // --- Comment Handlers ---
 
 // CreateCommentRequest is the JSON body for creating a comment.
@@ -792,11 +2728,13 @@ type CreateCommentRequest struct {
 
 // CommentResponse is the JSON response for a comment.
 type CommentResponse struct {
-	ID              string `json:"id"`
-	Body            string `json:"body"`
-	Author          string `json:"author"`
-	CreatedAtMillis int64  `json:"created_at_millis"`
-	UpdatedAtMillis int64  `json:"updated_at_millis,omitempty"`
+	ID              string   `json:"id"`
+	Body            string   `json:"body"`
+	Author          string   `json:"author"`
+	CreatedAtMillis int64    `json:"created_at_millis"`
+	UpdatedAtMillis int64    `json:"updated_at_millis,omitempty"`
+	MentionedIn     []string `json:"mentions,omitempty"` // users @mentioned in the body
+	Warnings        []string `json:"warnings,omitempty"`
 }
 
 // stringifyCustomFields converts API custom field values to strings for the
@@ -821,7 +2759,45 @@ func toCommentResponse(c *model.Comment) CommentResponse {
 		Author:          c.Author,
 		CreatedAtMillis: c.CreatedAtMillis,
 		UpdatedAtMillis: c.UpdatedAtMillis,
+		MentionedIn:     c.Mentions,
+	}
+}
+
+// mentionWarnings returns a warning for each of the comment's mentions that
+// isn't a collaborator on the board. Unknown mentions are still stored.
+func (h *Handler) mentionWarnings(boardName string, c *model.Comment) ([]string, error) {
+	unknown, err := h.ctx().CardService.UnknownMentions(boardName, c.Mentions)
+	if err != nil {
+		return nil, err
+	}
+	var warnings []string
+	for _, name := range unknown {
+		warnings = append(warnings, fmt.Sprintf("@%s is not a collaborator on this board", name))
+	}
+	return warnings, nil
+}
+
+// CommentsResponse is the JSON response for listing a card's comments.
+type CommentsResponse struct {
+	Comments []CommentResponse `json:"comments"`
+}
+
+// ListComments returns a card's comments, oldest first.
+func (h *Handler) ListComments(w http.ResponseWriter, r *http.Request) {
+	boardName := r.PathValue("board")
+	cardID := r.PathValue("id")
+
+	comments, err := h.ctx().CardService.ListComments(boardName, cardID)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	resp := CommentsResponse{Comments: make([]CommentResponse, len(comments))}
+	for i := range comments {
+		resp.Comments[i] = toCommentResponse(&comments[i])
 	}
+	JSON(w, http.StatusOK, resp)
 }
 
 // CreateComment creates a new comment on a card.
@@ -846,7 +2822,14 @@ func (h *Handler) CreateComment(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	JSON(w, http.StatusCreated, toCommentResponse(comment))
+	h.publish(boardName, BoardEvent{EventType: EventCommentCreated, CardID: cardID})
+
+	resp := toCommentResponse(comment)
+	if resp.Warnings, err = h.mentionWarnings(boardName, comment); err != nil {
+		Error(w, err)
+		return
+	}
+	JSON(w, http.StatusCreated, resp)
 }
 
 // EditCommentRequest is the JSON body for editing a comment.
@@ -875,8 +2858,16 @@ func (h *Handler) EditComment(w http.ResponseWriter, r *http.Request) {
 		Error(w, err)
 		return
 	}
+	if card, err := h.ctx().CardService.FindCommentCard(boardName, commentID); err == nil {
+		h.publishCardEvent(boardName, EventCardUpdated, card)
+	}
 
-	JSON(w, http.StatusOK, toCommentResponse(comment))
+	resp := toCommentResponse(comment)
+	if resp.Warnings, err = h.mentionWarnings(boardName, comment); err != nil {
+		Error(w, err)
+		return
+	}
+	JSON(w, http.StatusOK, resp)
 }
 
 // DeleteComment removes a comment from a card.
@@ -884,14 +2875,108 @@ func (h *Handler) DeleteComment(w http.ResponseWriter, r *http.Request) {
 	boardName := r.PathValue("board")
 	commentID := r.PathValue("cid")
 
+	// The comment is gone afterwards, so find its card first for the event.
+	card, err := h.ctx().CardService.FindCommentCard(boardName, commentID)
+	if err != nil {
+		Error(w, err)
+		return
+	}
 	if err := h.ctx().CardService.DeleteComment(boardName, commentID); err != nil {
 		Error(w, err)
 		return
 	}
+	h.publishCardEvent(boardName, EventCardUpdated, card)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// AddChecklistItemRequest is the JSON body for adding a checklist item.
+type AddChecklistItemRequest struct {
+	Text string `json:"text"`
+}
+
+// AddChecklistItem appends an item to a card's checklist.
+func (h *Handler) AddChecklistItem(w http.ResponseWriter, r *http.Request) {
+	boardName := r.PathValue("board")
+	cardID := r.PathValue("id")
+
+	var req AddChecklistItemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		BadRequest(w, "invalid JSON body")
+		return
+	}
+
+	item, err := h.ctx().CardService.AddChecklistItem(boardName, cardID, req.Text)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+	h.publishCardUpdated(boardName, cardID)
+
+	JSON(w, http.StatusCreated, item)
+}
+
+// ToggleChecklistItemRequest is the JSON body for checking or unchecking a
+// checklist item.
+type ToggleChecklistItemRequest struct {
+	Done *bool `json:"done"`
+}
+
+// ToggleChecklistItem marks a checklist item done or not done.
+func (h *Handler) ToggleChecklistItem(w http.ResponseWriter, r *http.Request) {
+	boardName := r.PathValue("board")
+	cardID := r.PathValue("id")
+	itemID := r.PathValue("item_id")
+
+	var req ToggleChecklistItemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		BadRequest(w, "invalid JSON body")
+		return
+	}
+	if req.Done == nil {
+		BadRequest(w, "done is required")
+		return
+	}
+
+	if err := h.ctx().CardService.ToggleChecklistItem(boardName, cardID, itemID, *req.Done); err != nil {
+		Error(w, err)
+		return
+	}
+
+	card, err := h.ctx().CardService.FindByIDOrAlias(boardName, cardID)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+	h.publishCardEvent(boardName, EventCardUpdated, card)
+	boardCfg, _ := h.ctx().BoardStore.Get(boardName)
+	JSON(w, http.StatusOK, toCardResponseWithWanted(card, boardCfg))
+}
+
+// DeleteChecklistItem removes an item from a card's checklist.
+func (h *Handler) DeleteChecklistItem(w http.ResponseWriter, r *http.Request) {
+	boardName := r.PathValue("board")
+	cardID := r.PathValue("id")
+	itemID := r.PathValue("item_id")
+
+	if err := h.ctx().CardService.DeleteChecklistItem(boardName, cardID, itemID); err != nil {
+		Error(w, err)
+		return
+	}
+	h.publishCardUpdated(boardName, cardID)
 
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// publishCardUpdated publishes EventCardUpdated for a card the handler
+// changed without getting it back. The write already succeeded, so a failed
+// lookup only skips the event.
+func (h *Handler) publishCardUpdated(boardName, cardIDOrAlias string) {
+	if card, err := h.ctx().CardService.FindByIDOrAlias(boardName, cardIDOrAlias); err == nil {
+		h.publishCardEvent(boardName, EventCardUpdated, card)
+	}
+}
+
 // --- Cross-Project Handlers ---
 
 // BoardEntry represents a single board across all registered projects.
@@ -1007,6 +3092,159 @@ func (h *Handler) ListAllBoards(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// AllBoardsSearchResult is one matching card from a cross-project search.
+type AllBoardsSearchResult struct {
+	ProjectName string       `json:"project_name"`
+	ProjectPath string       `json:"project_path"`
+	BoardName   string       `json:"board_name"`
+	Card        CardResponse `json:"card"`
+}
+
+// AllBoardsSearchResponse is the JSON response for searching across all projects.
+type AllBoardsSearchResponse struct {
+	Results []AllBoardsSearchResult `json:"results"`
+	Count   int                     `json:"count"`
+	Skipped []SkippedProject        `json:"skipped,omitempty"`
+}
+
+// SearchAllBoards searches the title and description of cards on every board in
+// every registered project (?q=...). Projects or boards that can't be read are
+// reported in skipped rather than failing the whole search.
+func (h *Handler) SearchAllBoards(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query().Get("q")
+	if query == "" {
+		BadRequest(w, "q is required")
+		return
+	}
+
+	globalCfg, err := h.globalStore.Load()
+	if err != nil {
+		Error(w, fmt.Errorf("failed to load global config: %w", err))
+		return
+	}
+
+	results := []AllBoardsSearchResult{}
+	var skipped []SkippedProject
+
+	for projectName, projectPath := range globalCfg.Projects {
+		dataLocation := ""
+		if repoCfg := globalCfg.GetRepoConfig(projectPath); repoCfg != nil {
+			dataLocation = repoCfg.DataLocation
+		}
+
+		paths := config.NewPaths(projectPath, dataLocation)
+		boardStore := store.NewBoardStore(paths)
+		searchService := service.NewSearchService(store.NewCardStore(paths), boardStore)
+
+		boardNames, err := boardStore.List()
+		if err != nil {
+			log.Printf("Skipping project %q (%s): %v", projectName, projectPath, err)
+			skipped = append(skipped, SkippedProject{
+				Name:   projectName,
+				Path:   projectPath,
+				Reason: fmt.Sprintf("failed to list boards: %v", err),
+			})
+			continue
+		}
+
+		displayName := projectName
+		if projCfg, err := store.NewProjectStore(paths).Load(); err == nil && projCfg.Name != "" {
+			displayName = projCfg.Name
+		}
+
+		for _, bn := range boardNames {
+			cards, err := searchService.Search(bn, query, service.SearchOptions{})
+			if err != nil {
+				// An invalid query fails the same way for every board; report it once.
+				if kanerr.IsValidationError(err) {
+					Error(w, err)
+					return
+				}
+				log.Printf("Skipping board %q in project %q: %v", bn, projectName, err)
+				skipped = append(skipped, SkippedProject{
+					Name:   projectName,
+					Path:   projectPath,
+					Reason: fmt.Sprintf("failed to search board %s: %v", bn, err),
+				})
+				continue
+			}
+
+			boardCfg, _ := boardStore.Get(bn)
+			for _, card := range cards {
+				results = append(results, AllBoardsSearchResult{
+					ProjectName: displayName,
+					ProjectPath: projectPath,
+					BoardName:   bn,
+					Card:        toCardResponseWithWanted(card, boardCfg),
+				})
+			}
+		}
+	}
+
+	// Map iteration is randomized; sort for a stable response. Within a board,
+	// Search already returns cards in board order.
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].ProjectName != results[j].ProjectName {
+			return results[i].ProjectName < results[j].ProjectName
+		}
+		return results[i].BoardName < results[j].BoardName
+	})
+
+	JSON(w, http.StatusOK, AllBoardsSearchResponse{
+		Results: results,
+		Count:   len(results),
+		Skipped: skipped,
+	})
+}
+
+// GlobalSearchResponse is the JSON response for GET /api/v1/search.
+type GlobalSearchResponse struct {
+	Results []service.SearchHit `json:"results"`
+	Skipped []SkippedProject    `json:"skipped"`
+}
+
+// SearchAll searches every registered project (?q=...&fields=title,description),
+// several projects at a time. Unlike SearchAllBoards it returns search hits
+// with match snippets rather than full cards. Projects that can't be read are
+// reported in skipped rather than failing the whole search.
+func (h *Handler) SearchAll(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query().Get("q")
+	if query == "" {
+		BadRequest(w, "q is required")
+		return
+	}
+
+	var opts service.SearchOptions
+	if fields := r.URL.Query().Get("fields"); fields != "" {
+		for _, f := range strings.Split(fields, ",") {
+			opts.Fields = append(opts.Fields, strings.TrimSpace(f))
+		}
+	}
+
+	globalCfg, err := h.globalStore.Load()
+	if err != nil {
+		Error(w, fmt.Errorf("failed to load global config: %w", err))
+		return
+	}
+
+	hits, skippedProjects, err := service.SearchProjects(globalCfg, query, opts)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	skipped := []SkippedProject{}
+	for _, sp := range skippedProjects {
+		log.Printf("Skipping project %q (%s): %v", sp.Name, sp.Path, sp.Err)
+		skipped = append(skipped, SkippedProject{Name: sp.Name, Path: sp.Path, Reason: sp.Err.Error()})
+	}
+
+	JSON(w, http.StatusOK, GlobalSearchResponse{
+		Results: hits,
+		Skipped: skipped,
+	})
+}
+
 // SwitchProjectRequest is the JSON body for switching projects.
 type SwitchProjectRequest struct {
 	ProjectPath string `json:"project_path"`
@@ -1065,6 +3303,7 @@ func (h *Handler) SwitchProject(w http.ResponseWriter, r *http.Request) {
 	// Verify the project has at least one board
 	boardNames, err := newCtx.BoardStore.List()
 	if err != nil || len(boardNames) == 0 {
+		newCtx.Close()
 		BadRequest(w, "project has no boards")
 		return
 	}
@@ -1086,8 +3325,12 @@ func (h *Handler) SwitchProject(w http.ResponseWriter, r *http.Request) {
 
 	// Swap context
 	h.mu.Lock()
+	oldCtx := h.current
 	h.current = newCtx
 	h.mu.Unlock()
+	if oldCtx != nil {
+		oldCtx.Close()
+	}
 
 	// Notify server to update file watcher
 	if h.onProjectSwitch != nil {
@@ -3,11 +3,18 @@ package api
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/amterp/kan/internal/config"
 	"github.com/amterp/kan/internal/model"
@@ -37,25 +44,38 @@ func setupTestAPI(t *testing.T) *testAPI {
 	cardStore := store.NewCardStore(paths)
 	boardStore := store.NewBoardStore(paths)
 	projectStore := store.NewProjectStore(paths)
-	aliasService := service.NewAliasService(cardStore)
+	auditStore := store.NewAuditStore(paths)
+	aliasService := service.NewAliasService(cardStore, boardStore)
 	cardService := service.NewCardService(cardStore, boardStore, aliasService)
+	cardService.SetAuditStore(auditStore, func() string { return "test-user" })
 	boardService := service.NewBoardService(boardStore, cardStore)
+	boardService.SetCardService(cardService)
+	searchService := service.NewSearchService(cardStore, boardStore)
+	hookHistoryStore := store.NewHookHistoryStore(paths)
+	hookService := service.NewHookService(tempDir)
+	hookService.SetHistoryStore(hookHistoryStore)
+	cardService.SetHookService(hookService)
 
 	ctx := &ProjectContext{
-		Paths:        paths,
-		BoardStore:   boardStore,
-		CardStore:    cardStore,
-		ProjectStore: projectStore,
-		CardService:  cardService,
-		BoardService: boardService,
-		Creator:      "test-user",
-		ProjectRoot:  tempDir,
+		Paths:            paths,
+		BoardStore:       boardStore,
+		CardStore:        cardStore,
+		ProjectStore:     projectStore,
+		AuditStore:       auditStore,
+		HookHistoryStore: hookHistoryStore,
+		CardService:      cardService,
+		BoardService:     boardService,
+		SearchService:    searchService,
+		HookService:      hookService,
+		Creator:          "test-user",
+		ProjectRoot:      tempDir,
 	}
 	handler := NewHandler(nil, ctx)
 	mux := http.NewServeMux()
 	handler.RegisterRoutes(mux)
 
 	t.Cleanup(func() {
+		hookService.Close()
 		os.RemoveAll(tempDir)
 	})
 
@@ -105,6 +125,11 @@ func (api *testAPI) createBoard(t *testing.T, name string) {
 
 // request makes an HTTP request and returns the response.
 func (api *testAPI) request(method, path string, body any) *httptest.ResponseRecorder {
+	return api.requestWithHeaders(method, path, body, nil)
+}
+
+// requestWithHeaders is request with extra request headers (e.g. If-Match).
+func (api *testAPI) requestWithHeaders(method, path string, body any, headers map[string]string) *httptest.ResponseRecorder {
 	var bodyReader *bytes.Reader
 	if body != nil {
 		data, _ := json.Marshal(body)
@@ -117,6 +142,9 @@ func (api *testAPI) request(method, path string, body any) *httptest.ResponseRec
 	if body != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
 
 	w := httptest.NewRecorder()
 	api.mux.ServeHTTP(w, req)
@@ -158,6 +186,40 @@ func createCardFromResponse(t *testing.T, w *httptest.ResponseRecorder) model.Ca
 // Board Endpoint Tests
 // ============================================================================
 
+func TestCardResponse_CustomFieldOrder(t *testing.T) {
+	boardCfg := &model.BoardConfig{CustomFields: map[string]model.CustomFieldSchema{
+		"priority": {Type: model.FieldTypeString, Order: 2},
+		"type":     {Type: model.FieldTypeString, Order: 1},
+		"notes":    {Type: model.FieldTypeString},
+	}}
+	card := &model.Card{ID: "c1", Title: "Card", Column: "backlog", CustomFields: map[string]any{
+		"priority": "high",
+		"type":     "bug",
+		"notes":    "n",
+		"legacy":   "x", // not defined on the board
+	}}
+
+	data, err := json.Marshal(toCardResponseWithWanted(card, boardCfg))
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var keys []string
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.Token() // {
+	for dec.More() {
+		key, _ := dec.Token()
+		keys = append(keys, key.(string))
+		var skip json.RawMessage
+		if err := dec.Decode(&skip); err != nil {
+			t.Fatalf("Decode failed: %v", err)
+		}
+	}
+	want := []string{"notes", "type", "priority", "legacy"}
+	if got := keys[len(keys)-len(want):]; !reflect.DeepEqual(got, want) {
+		t.Errorf("Custom field keys = %v, want %v (all keys: %v)", got, want, keys)
+	}
+}
+
 func TestHandler_ListBoards_Empty(t *testing.T) {
 	api := setupTestAPI(t)
 
@@ -192,6 +254,130 @@ func TestHandler_ListBoards_WithBoards(t *testing.T) {
 	}
 }
 
+func TestHandler_UpdateProject(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        map[string]any
+		wantStatus  int
+		wantName    string
+		wantFavicon model.FaviconConfig
+	}{
+		{
+			name:        "name only",
+			body:        map[string]any{"name": "Renamed"},
+			wantStatus:  http.StatusOK,
+			wantName:    "Renamed",
+			wantFavicon: model.FaviconConfig{Background: "#10b981", IconType: "letter", Letter: "O"},
+		},
+		{
+			name:        "favicon only",
+			body:        map[string]any{"favicon": map[string]any{"background": "#3b82f6", "letter": "M"}},
+			wantStatus:  http.StatusOK,
+			wantName:    "Original",
+			wantFavicon: model.FaviconConfig{Background: "#3b82f6", IconType: "letter", Letter: "M"},
+		},
+		{
+			name: "name and favicon",
+			body: map[string]any{
+				"name":    "My Project",
+				"favicon": map[string]any{"background": "#3B82F6", "icon_type": "letter", "letter": "M"},
+			},
+			wantStatus:  http.StatusOK,
+			wantName:    "My Project",
+			wantFavicon: model.FaviconConfig{Background: "#3B82F6", IconType: "letter", Letter: "M"},
+		},
+		{name: "invalid hex color", body: map[string]any{"favicon": map[string]any{"background": "#3b82f"}}, wantStatus: http.StatusBadRequest},
+		{name: "hex color without hash", body: map[string]any{"favicon": map[string]any{"background": "3b82f6"}}, wantStatus: http.StatusBadRequest},
+		{name: "lowercase letter", body: map[string]any{"favicon": map[string]any{"letter": "m"}}, wantStatus: http.StatusBadRequest},
+		{name: "two letters", body: map[string]any{"favicon": map[string]any{"letter": "MP"}}, wantStatus: http.StatusBadRequest},
+		{name: "unknown icon type", body: map[string]any{"favicon": map[string]any{"icon_type": "image"}}, wantStatus: http.StatusBadRequest},
+		{name: "empty name", body: map[string]any{"name": "  "}, wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := setupTestAPI(t)
+			api.createBoard(t, "main")
+			projectStore := api.handler.ctx().ProjectStore
+			original := &model.ProjectConfig{
+				ID:      "project-id",
+				Name:    "Original",
+				Favicon: model.FaviconConfig{Background: "#10b981", IconType: "letter", Letter: "O"},
+			}
+			if err := projectStore.Save(original); err != nil {
+				t.Fatalf("Save failed: %v", err)
+			}
+
+			w := api.request("PATCH", "/api/v1/project", tt.body)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d. Body: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+
+			saved, err := projectStore.Load()
+			if err != nil {
+				t.Fatalf("Load failed: %v", err)
+			}
+			if tt.wantStatus != http.StatusOK {
+				if saved.Name != "Original" || saved.Favicon != original.Favicon {
+					t.Errorf("Expected project unchanged after a rejected update, got %+v", saved)
+				}
+				return
+			}
+
+			var resp ProjectResponse
+			decodeJSON(t, w, &resp)
+			if resp.Name != tt.wantName || resp.Favicon != tt.wantFavicon {
+				t.Errorf("Expected response %q %+v, got %q %+v", tt.wantName, tt.wantFavicon, resp.Name, resp.Favicon)
+			}
+			if saved.Name != tt.wantName || saved.Favicon != tt.wantFavicon {
+				t.Errorf("Expected saved project %q %+v, got %q %+v", tt.wantName, tt.wantFavicon, saved.Name, saved.Favicon)
+			}
+		})
+	}
+}
+
+func TestHandler_ArchiveBoard(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+	api.createBoard(t, "old")
+
+	w := api.request("POST", "/api/v1/boards/old/archive", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	var board model.BoardConfig
+	decodeJSON(t, w, &board)
+	if !board.IsArchived() {
+		t.Errorf("Expected archived_at_millis to be set, got %+v", board)
+	}
+
+	var resp map[string][]string
+	decodeJSON(t, api.request("GET", "/api/v1/boards", nil), &resp)
+	if !reflect.DeepEqual(resp["boards"], []string{"main"}) {
+		t.Errorf("Expected only the active board, got %v", resp["boards"])
+	}
+	decodeJSON(t, api.request("GET", "/api/v1/boards?include_archived=true", nil), &resp)
+	if len(resp["boards"]) != 2 {
+		t.Errorf("Expected both boards with include_archived, got %v", resp["boards"])
+	}
+
+	if w := api.request("POST", "/api/v1/boards/main/archive", nil); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 archiving the last active board, got %d", w.Code)
+	}
+
+	w = api.request("POST", "/api/v1/boards/old/unarchive", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	decodeJSON(t, api.request("GET", "/api/v1/boards", nil), &resp)
+	if len(resp["boards"]) != 2 {
+		t.Errorf("Expected the restored board to be listed, got %v", resp["boards"])
+	}
+	if w := api.request("POST", "/api/v1/boards/missing/archive", nil); w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 for a missing board, got %d", w.Code)
+	}
+}
+
 func TestHandler_GetBoard_Found(t *testing.T) {
 	api := setupTestAPI(t)
 	api.createBoard(t, "main")
@@ -228,510 +414,2216 @@ func TestHandler_GetBoard_NotFound(t *testing.T) {
 	}
 }
 
-// ============================================================================
-// Card Endpoint Tests
-// ============================================================================
-
-func TestHandler_ListCards_Empty(t *testing.T) {
+func TestHandler_ExportImportBoard(t *testing.T) {
 	api := setupTestAPI(t)
 	api.createBoard(t, "main")
+	api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "First", "column": "backlog"})
+	api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "Second", "column": "done"})
 
-	w := api.request("GET", "/api/v1/boards/main/cards", nil)
-
+	w := api.request("GET", "/api/v1/boards/main/export", nil)
 	if w.Code != http.StatusOK {
-		t.Errorf("Expected status 200, got %d", w.Code)
+		t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
 	}
+	export := json.RawMessage(w.Body.Bytes())
 
-	// Verify Content-Type header
-	if ct := w.Header().Get("Content-Type"); ct != "application/json" {
-		t.Errorf("Expected Content-Type 'application/json', got %q", ct)
+	w = api.request("POST", "/api/v1/boards/import?name=copy", export)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d. Body: %s", w.Code, w.Body.String())
 	}
-
-	var resp map[string][]*model.Card
+	var resp ImportBoardResponse
 	decodeJSON(t, w, &resp)
-	if len(resp["cards"]) != 0 {
-		t.Errorf("Expected empty cards list, got %d cards", len(resp["cards"]))
+	if resp.Board != "copy" {
+		t.Errorf("Expected board 'copy', got %q", resp.Board)
 	}
-}
 
-func TestHandler_ListCards_NonexistentBoard(t *testing.T) {
-	api := setupTestAPI(t)
-	// Don't create any board
-
-	w := api.request("GET", "/api/v1/boards/nonexistent/cards", nil)
-
-	if w.Code != http.StatusNotFound {
-		t.Errorf("Expected status 404, got %d", w.Code)
+	cards, err := api.cardStore.List("copy", true)
+	if err != nil || len(cards) != 2 {
+		t.Errorf("Expected 2 imported cards, got %d (err=%v)", len(cards), err)
 	}
 
-	var resp map[string]string
-	decodeJSON(t, w, &resp)
-	if resp["error"] == "" {
-		t.Error("Expected error message in response")
+	// Importing under the exported name collides with the original board.
+	if w := api.request("POST", "/api/v1/boards/import", export); w.Code != http.StatusConflict {
+		t.Errorf("Expected status 409 for name collision, got %d", w.Code)
+	}
+	if w := api.request("GET", "/api/v1/boards/missing/export", nil); w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404 for unknown board, got %d", w.Code)
 	}
 }
 
-func TestHandler_CreateCard_Basic(t *testing.T) {
+func TestHandler_ImportTrelloBoard(t *testing.T) {
 	api := setupTestAPI(t)
 	api.createBoard(t, "main")
 
-	body := map[string]any{
-		"title":  "Test card",
-		"column": "backlog",
-	}
-	w := api.request("POST", "/api/v1/boards/main/cards", body)
+	trello := json.RawMessage(`{
+		"name": "Side Project",
+		"lists": [{"id": "l1", "name": "To Do", "pos": 1}, {"id": "l2", "name": "Done", "pos": 2}],
+		"cards": [
+			{"id": "c1", "name": "Open card", "idList": "l1", "pos": 1, "labels": [{"name": "ux"}]},
+			{"id": "c2", "name": "Finished card", "idList": "l2", "pos": 1, "closed": true}
+		]
+	}`)
 
+	w := api.request("POST", "/api/v1/boards/import-trello", trello)
 	if w.Code != http.StatusCreated {
-		t.Errorf("Expected status 201, got %d. Body: %s", w.Code, w.Body.String())
+		t.Fatalf("Expected status 201, got %d. Body: %s", w.Code, w.Body.String())
 	}
-
-	var resp CreateCardResponse
+	var resp ImportBoardResponse
 	decodeJSON(t, w, &resp)
-	if resp.Card.Title != "Test card" {
-		t.Errorf("Expected title 'Test card', got %q", resp.Card.Title)
+	if resp.Board != "side-project" {
+		t.Errorf("Expected board named from the Trello board, got %q", resp.Board)
 	}
-	if resp.Card.Column != "backlog" {
-		t.Errorf("Expected column 'backlog', got %q", resp.Card.Column)
+
+	cards, err := api.cardStore.List("side-project", true)
+	if err != nil || len(cards) != 2 {
+		t.Errorf("Expected 2 imported cards, got %d (err=%v)", len(cards), err)
 	}
-	if resp.Card.ID == "" {
-		t.Error("Expected card ID to be set")
+
+	if w := api.request("POST", "/api/v1/boards/import-trello", trello); w.Code != http.StatusConflict {
+		t.Errorf("Expected status 409 for name collision, got %d", w.Code)
 	}
-	if resp.Card.Alias == "" {
-		t.Error("Expected alias to be generated")
+	if w := api.request("POST", "/api/v1/boards/import-trello?name=other", json.RawMessage(`{"lists": []}`)); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for an export without lists, got %d", w.Code)
 	}
 }
 
-func TestHandler_CreateCard_WithCustomFields(t *testing.T) {
+func TestHandler_MergeBoards(t *testing.T) {
 	api := setupTestAPI(t)
 	api.createBoard(t, "main")
+	api.createBoard(t, "other")
+	card := createCardFromResponse(t, api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "Merged", "column": "backlog"}))
 
-	body := map[string]any{
-		"title":         "Bug fix",
-		"column":        "backlog",
-		"custom_fields": map[string]any{"type": "bug"},
+	body := map[string]any{"target": "other", "column_mapping": map[string]string{"backlog": "done"}, "delete_source": true}
+	w := api.request("POST", "/api/v1/boards/main/merge", body)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	var resp MergeBoardsResponse
+	decodeJSON(t, w, &resp)
+	if resp.Moved != 1 || !resp.SourceDeleted {
+		t.Errorf("Unexpected merge response: %+v", resp)
 	}
-	w := api.request("POST", "/api/v1/boards/main/cards", body)
 
-	if w.Code != http.StatusCreated {
-		t.Errorf("Expected status 201, got %d. Body: %s", w.Code, w.Body.String())
+	w = api.request("GET", "/api/v1/boards/other/cards/"+card.ID, nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected card on target board, got %d", w.Code)
+	}
+	var moved CardResponse
+	decodeJSON(t, w, &moved)
+	if moved.Column != "done" {
+		t.Errorf("Expected card mapped to done, got %q", moved.Column)
 	}
 
-	// Custom fields should be flattened to top level in the card JSON response
-	var response map[string]any
-	decodeJSON(t, w, &response)
-	card := response["card"].(map[string]any)
-	if card["type"] != "bug" {
-		t.Errorf("Expected 'type' field at top level of card to be 'bug', got %v", card["type"])
+	if w := api.request("POST", "/api/v1/boards/other/merge", map[string]any{}); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 without target, got %d", w.Code)
 	}
-	// Should NOT be nested under custom_fields within the card
-	if card["custom_fields"] != nil {
-		t.Errorf("Expected custom_fields to be flattened, but found nested: %v", card["custom_fields"])
+	if w := api.request("POST", "/api/v1/boards/other/merge", map[string]any{"target": "nope"}); w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 for missing target board, got %d", w.Code)
 	}
 }
 
-func TestHandler_CreateCard_DefaultColumn(t *testing.T) {
+func TestHandler_DuplicateBoard(t *testing.T) {
 	api := setupTestAPI(t)
 	api.createBoard(t, "main")
+	first := createCardFromResponse(t, api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "First", "column": "backlog"}))
+	second := createCardFromResponse(t, api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "Second", "column": "done"}))
 
-	body := map[string]any{
-		"title": "No column specified",
+	w := api.request("POST", "/api/v1/boards/main/duplicate", map[string]any{"name": "copy-of-main"})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d. Body: %s", w.Code, w.Body.String())
 	}
-	w := api.request("POST", "/api/v1/boards/main/cards", body)
 
-	if w.Code != http.StatusCreated {
-		t.Errorf("Expected status 201, got %d. Body: %s", w.Code, w.Body.String())
+	w = api.request("GET", "/api/v1/boards/copy-of-main/cards", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	var list PaginatedCardList
+	decodeJSON(t, w, &list)
+	if len(list.Cards) != 2 {
+		t.Fatalf("Expected 2 cards on the copy, got %d", len(list.Cards))
+	}
+	titles := make(map[string]string)
+	for _, c := range list.Cards {
+		titles[c.Title] = c.ID
+	}
+	for _, orig := range []model.Card{first, second} {
+		id, ok := titles[orig.Title]
+		if !ok {
+			t.Errorf("Expected a copy of %q", orig.Title)
+		} else if id == orig.ID {
+			t.Errorf("Copy of %q should have a new ID", orig.Title)
+		}
 	}
 
-	var resp CreateCardResponse
-	decodeJSON(t, w, &resp)
-	if resp.Card.Column != "backlog" {
-		t.Errorf("Expected default column 'backlog', got %q", resp.Card.Column)
+	if w := api.request("POST", "/api/v1/boards/main/duplicate", map[string]any{"name": "copy-of-main"}); w.Code != http.StatusConflict {
+		t.Errorf("Expected status 409 for existing target, got %d", w.Code)
+	}
+	if w := api.request("POST", "/api/v1/boards/main/duplicate", map[string]any{}); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for missing name, got %d", w.Code)
+	}
+	if w := api.request("POST", "/api/v1/boards/missing/duplicate", map[string]any{"name": "x"}); w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404 for unknown board, got %d", w.Code)
 	}
 }
 
-func TestHandler_CreateCard_MissingTitle(t *testing.T) {
+func TestHandler_GetHookHistory(t *testing.T) {
 	api := setupTestAPI(t)
 	api.createBoard(t, "main")
 
-	body := map[string]any{
-		"column": "backlog",
+	for _, ts := range []int64{100, 300, 200} {
+		entry := model.HookHistoryEntry{Timestamp: ts, HookName: "notify", CardID: "c1", Success: true}
+		if err := api.handler.ctx().HookHistoryStore.Append("main", entry); err != nil {
+			t.Fatalf("Append failed: %v", err)
+		}
 	}
-	w := api.request("POST", "/api/v1/boards/main/cards", body)
 
-	if w.Code != http.StatusBadRequest {
-		t.Errorf("Expected status 400, got %d", w.Code)
+	w := api.request("GET", "/api/v1/boards/main/hooks/history?limit=2", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
 	}
-
-	var resp map[string]string
+	var resp HookHistoryResponse
 	decodeJSON(t, w, &resp)
-	if resp["error"] != "title is required" {
-		t.Errorf("Expected 'title is required' error, got %q", resp["error"])
+	if len(resp.Entries) != 2 || resp.Entries[0].Timestamp != 300 || resp.Entries[1].Timestamp != 200 {
+		t.Errorf("Expected the 2 newest entries, got %+v", resp.Entries)
+	}
+
+	if w := api.request("GET", "/api/v1/boards/main/hooks/history?limit=0", nil); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for limit=0, got %d", w.Code)
+	}
+	if w := api.request("GET", "/api/v1/boards/missing/hooks/history", nil); w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404 for unknown board, got %d", w.Code)
 	}
 }
 
-func TestHandler_CreateCard_InvalidColumn(t *testing.T) {
+func TestHandler_PreviewLinkRules(t *testing.T) {
 	api := setupTestAPI(t)
 	api.createBoard(t, "main")
 
-	body := map[string]any{
-		"title":  "Bad column",
-		"column": "NonExistent",
+	cfg, err := api.handler.ctx().BoardService.Get("main")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	cfg.LinkRules = []model.LinkRule{
+		{Name: "Jira", Pattern: `JIRA-\d+`, URL: "https://jira.example.com/browse/{0}"},
+		{Name: "GitHub", Pattern: `#(\d+)`, URL: "https://github.com/o/r/issues/{1}"},
+	}
+	if err := api.handler.ctx().BoardStore.Update(cfg); err != nil {
+		t.Fatalf("Update failed: %v", err)
 	}
-	w := api.request("POST", "/api/v1/boards/main/cards", body)
 
-	if w.Code != http.StatusNotFound {
-		t.Errorf("Expected status 404, got %d", w.Code)
+	tests := []struct {
+		text string
+		want LinkRulePreviewResponse
+	}{
+		{"JIRA-123", LinkRulePreviewResponse{Matched: true, Rule: "Jira", URL: "https://jira.example.com/browse/JIRA-123"}},
+		{"fixes #7", LinkRulePreviewResponse{Matched: true, Rule: "GitHub", URL: "https://github.com/o/r/issues/7"}},
+		{"nothing here", LinkRulePreviewResponse{}},
+	}
+	for _, tt := range tests {
+		w := api.request("POST", "/api/v1/boards/main/link-rules/preview", LinkRulePreviewRequest{Text: tt.text})
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+		var resp LinkRulePreviewResponse
+		decodeJSON(t, w, &resp)
+		if resp != tt.want {
+			t.Errorf("Preview of %q = %+v, want %+v", tt.text, resp, tt.want)
+		}
+	}
+
+	if w := api.request("POST", "/api/v1/boards/missing/link-rules/preview", LinkRulePreviewRequest{Text: "JIRA-1"}); w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404 for unknown board, got %d", w.Code)
 	}
 }
 
-func TestHandler_CreateCard_InvalidCustomField(t *testing.T) {
+func TestHandler_GetBoardAudit(t *testing.T) {
 	api := setupTestAPI(t)
 	api.createBoard(t, "main")
+	api.createBoard(t, "other")
 
-	body := map[string]any{
-		"title":         "Bad type",
-		"column":        "backlog",
-		"custom_fields": map[string]any{"type": "nonexistent"},
+	card := createCardFromResponse(t, api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "Audited"}))
+	api.request("PATCH", "/api/v1/boards/main/cards/"+card.ID+"/move", map[string]any{"column": "done"})
+	api.request("PUT", "/api/v1/boards/main/cards/"+card.ID, map[string]any{"title": "Renamed"})
+	other := createCardFromResponse(t, api.request("POST", "/api/v1/boards/other/cards", map[string]any{"title": "Elsewhere"}))
+	api.request("DELETE", "/api/v1/boards/other/cards/"+other.ID, nil)
+
+	w := api.request("GET", "/api/v1/boards/main/audit", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
 	}
-	w := api.request("POST", "/api/v1/boards/main/cards", body)
+	var resp AuditLogResponse
+	decodeJSON(t, w, &resp)
 
-	if w.Code != http.StatusBadRequest {
-		t.Errorf("Expected status 400, got %d. Body: %s", w.Code, w.Body.String())
+	var actions []string
+	for _, e := range resp.Entries {
+		actions = append(actions, e.Action)
+		if e.EntityID != card.ID || e.Actor != "test-user" {
+			t.Errorf("Unexpected entry: %+v", e)
+		}
+	}
+	if fmt.Sprint(actions) != "[updated moved created]" {
+		t.Errorf("Expected newest-first updated,moved,created; got %v", actions)
+	}
+	if resp.Entries[0].Delta["title"] != "Renamed" {
+		t.Errorf("Expected title delta, got %v", resp.Entries[0].Delta)
+	}
+	if resp.Entries[1].Delta["from"] != "backlog" || resp.Entries[1].Delta["to"] != "done" {
+		t.Errorf("Expected backlog -> done move delta, got %v", resp.Entries[1].Delta)
 	}
-}
 
-func TestHandler_CreateCard_InvalidJSON(t *testing.T) {
-	api := setupTestAPI(t)
-	api.createBoard(t, "main")
+	decodeJSON(t, api.request("GET", "/api/v1/boards/other/audit", nil), &resp)
+	if len(resp.Entries) != 2 || resp.Entries[0].Action != "deleted" || resp.Entries[1].Action != "created" {
+		t.Errorf("Expected deleted,created on 'other', got %v", resp.Entries)
+	}
 
-	req := httptest.NewRequest("POST", "/api/v1/boards/main/cards", bytes.NewReader([]byte("not json")))
-	req.Header.Set("Content-Type", "application/json")
-	w := httptest.NewRecorder()
-	api.mux.ServeHTTP(w, req)
+	decodeJSON(t, api.request("GET", "/api/v1/boards/main/audit?limit=1", nil), &resp)
+	if len(resp.Entries) != 1 || resp.Entries[0].Action != "updated" {
+		t.Errorf("Expected only the latest entry with limit=1, got %v", resp.Entries)
+	}
 
-	if w.Code != http.StatusBadRequest {
-		t.Errorf("Expected status 400, got %d", w.Code)
+	if w := api.request("GET", "/api/v1/boards/main/audit?limit=0", nil); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for limit=0, got %d", w.Code)
+	}
+	if w := api.request("GET", "/api/v1/boards/missing/audit", nil); w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404 for unknown board, got %d", w.Code)
 	}
 }
 
-func TestHandler_GetCard_ByID(t *testing.T) {
+func TestHandler_GetBoardCompletion(t *testing.T) {
 	api := setupTestAPI(t)
 	api.createBoard(t, "main")
 
-	// Create a card first
-	body := map[string]any{"title": "Test card", "column": "backlog"}
-	createResp := api.request("POST", "/api/v1/boards/main/cards", body)
-	created := createCardFromResponse(t, createResp)
+	cfg, err := api.boardStore.Get("main")
+	if err != nil {
+		t.Fatalf("Get board failed: %v", err)
+	}
+	cfg.DoneColumns = []string{"done"}
+	if err := api.boardStore.Update(cfg); err != nil {
+		t.Fatalf("Update board failed: %v", err)
+	}
 
-	// Get by ID
-	w := api.request("GET", "/api/v1/boards/main/cards/"+created.ID, nil)
+	for i := 0; i < 4; i++ {
+		card := createCardFromResponse(t, api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": fmt.Sprintf("Card %d", i)}))
+		if i%2 == 0 {
+			api.request("PATCH", "/api/v1/boards/main/cards/"+card.ID+"/move", map[string]any{"column": "done"})
+		}
+	}
 
+	w := api.request("GET", "/api/v1/boards/main/completion", nil)
 	if w.Code != http.StatusOK {
-		t.Errorf("Expected status 200, got %d", w.Code)
+		t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	var resp CompletionResponse
+	decodeJSON(t, w, &resp)
+	if resp.TotalCards != 4 || resp.TotalDone != 2 || resp.CompletionPct != 50 {
+		t.Errorf("Expected 2/4 done (50%%), got %d/%d (%v%%)", resp.TotalDone, resp.TotalCards, resp.CompletionPct)
+	}
+	for _, col := range resp.Columns {
+		if col.Name == "done" && (col.DoneCount != 2 || col.CompletionPct != 100) {
+			t.Errorf("Expected done column fully complete, got %+v", col)
+		}
 	}
 
-	var card model.Card
-	decodeJSON(t, w, &card)
-	if card.ID != created.ID {
-		t.Errorf("Expected card ID %q, got %q", created.ID, card.ID)
+	if w := api.request("GET", "/api/v1/boards/missing/completion", nil); w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404 for unknown board, got %d", w.Code)
 	}
 }
 
-func TestHandler_GetCard_ByAlias(t *testing.T) {
+func TestHandler_UpdateCollaborators(t *testing.T) {
 	api := setupTestAPI(t)
 	api.createBoard(t, "main")
 
-	// Create a card
-	body := map[string]any{"title": "Fix login bug", "column": "backlog"}
-	createResp := api.request("POST", "/api/v1/boards/main/cards", body)
-	created := createCardFromResponse(t, createResp)
-
-	// Get by alias
-	w := api.request("GET", "/api/v1/boards/main/cards/fix-login-bug", nil)
+	cfg, err := api.boardStore.Get("main")
+	if err != nil {
+		t.Fatalf("Get board failed: %v", err)
+	}
+	cfg.CustomFields = map[string]model.CustomFieldSchema{"owner": {Type: model.FieldTypeUser}}
+	if err := api.boardStore.Update(cfg); err != nil {
+		t.Fatalf("Update board failed: %v", err)
+	}
 
+	w := api.request("PATCH", "/api/v1/boards/main/collaborators", map[string]any{"add": []string{"alice", "bob"}})
 	if w.Code != http.StatusOK {
-		t.Errorf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
 	}
 
-	var card model.Card
-	decodeJSON(t, w, &card)
-	if card.ID != created.ID {
-		t.Errorf("Expected card ID %q, got %q", created.ID, card.ID)
+	w = api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "Owned", "custom_fields": map[string]any{"owner": "bob"}})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	w = api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "Stranger", "custom_fields": map[string]any{"owner": "mallory"}})
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for a non-collaborator, got %d", w.Code)
 	}
-}
-
-func TestHandler_GetCard_NotFound(t *testing.T) {
-	api := setupTestAPI(t)
-	api.createBoard(t, "main")
-
-	w := api.request("GET", "/api/v1/boards/main/cards/nonexistent", nil)
 
-	if w.Code != http.StatusNotFound {
-		t.Errorf("Expected status 404, got %d", w.Code)
+	w = api.request("PATCH", "/api/v1/boards/main/collaborators", map[string]any{"remove": []string{"bob"}})
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	var resp UpdateCollaboratorsResponse
+	decodeJSON(t, w, &resp)
+	if !reflect.DeepEqual(resp.Collaborators, []string{"alice"}) {
+		t.Errorf("Collaborators = %v, want [alice]", resp.Collaborators)
+	}
+	if len(resp.Warnings) != 1 || !strings.Contains(resp.Warnings[0], "bob") {
+		t.Errorf("Expected a warning about bob, got %v", resp.Warnings)
 	}
 }
 
-func TestHandler_UpdateCard_Title(t *testing.T) {
+func TestHandler_GetBoardStats(t *testing.T) {
 	api := setupTestAPI(t)
 	api.createBoard(t, "main")
 
-	// Create a card
-	body := map[string]any{"title": "Original", "column": "backlog"}
-	createResp := api.request("POST", "/api/v1/boards/main/cards", body)
-	created := createCardFromResponse(t, createResp)
+	cfg, err := api.boardStore.Get("main")
+	if err != nil {
+		t.Fatalf("Get board failed: %v", err)
+	}
+	cfg.DoneColumns = []string{"done"}
+	if err := api.boardStore.Update(cfg); err != nil {
+		t.Fatalf("Update board failed: %v", err)
+	}
 
-	// Update title
+	for i := 0; i < 10; i++ {
+		card := createCardFromResponse(t, api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": fmt.Sprintf("Card %d", i)}))
+		if i%2 == 0 {
+			api.request("PATCH", "/api/v1/boards/main/cards/"+card.ID+"/move", map[string]any{"column": "done"})
+		}
+	}
+
+	w := api.request("GET", "/api/v1/boards/main/stats", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	var resp BoardStatsResponse
+	decodeJSON(t, w, &resp)
+	if resp.TotalCards != 10 || resp.DoneCount != 5 {
+		t.Errorf("Expected total_cards=10, done_count=5; got %d, %d", resp.TotalCards, resp.DoneCount)
+	}
+	if resp.CreatedInWindow != 10 || resp.CompletedInWindow != 5 {
+		t.Errorf("Expected 10 created, 5 completed in the default window; got %d, %d", resp.CreatedInWindow, resp.CompletedInWindow)
+	}
+	counts := make(map[string]int)
+	for _, col := range resp.Columns {
+		counts[col.Name] = col.Count
+	}
+	if counts["backlog"] != 5 || counts["done"] != 5 {
+		t.Errorf("Expected 5 backlog and 5 done, got %v", counts)
+	}
+
+	if w := api.request("GET", "/api/v1/boards/main/stats?since=abc", nil); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for non-integer since, got %d", w.Code)
+	}
+	if w := api.request("GET", "/api/v1/boards/main/stats?since=2000&until=1000", nil); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for since after until, got %d", w.Code)
+	}
+	if w := api.request("GET", "/api/v1/boards/missing/stats", nil); w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404 for unknown board, got %d", w.Code)
+	}
+}
+
+func TestHandler_ListMigrationSnapshots(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+
+	var resp SnapshotListResponse
+	decodeJSON(t, api.request("GET", "/api/v1/migrate/snapshots", nil), &resp)
+	if resp.Snapshots == nil || len(resp.Snapshots) != 0 {
+		t.Fatalf("Expected empty snapshot list, got %v", resp.Snapshots)
+	}
+
+	id, err := service.NewBackupService().Snapshot(config.NewPaths(api.tempDir, ""))
+	if err != nil {
+		t.Fatalf("Snapshot failed: %v", err)
+	}
+
+	w := api.request("GET", "/api/v1/migrate/snapshots", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+	decodeJSON(t, w, &resp)
+	if len(resp.Snapshots) != 1 || resp.Snapshots[0].ID != id {
+		t.Fatalf("Expected snapshot %s, got %v", id, resp.Snapshots)
+	}
+	if resp.Snapshots[0].BoardSchemas["main"] == "" {
+		t.Errorf("Expected board schema for 'main', got %v", resp.Snapshots[0].BoardSchemas)
+	}
+}
+
+func TestHandler_Metrics(t *testing.T) {
+	api := setupTestAPI(t)
+	// Collectors are process-global, so use a board name no other test touches.
+	api.createBoard(t, "metered")
+
+	card := createCardFromResponse(t, api.request("POST", "/api/v1/boards/metered/cards", map[string]any{"title": "Counted"}))
+	api.request("POST", "/api/v1/boards/metered/cards", map[string]any{"title": "Also counted"})
+	api.request("PATCH", "/api/v1/boards/metered/cards/"+card.ID+"/move", map[string]any{"column": "done"})
+	api.request("GET", "/api/v1/boards/metered/cards", nil)
+	// A partial listing must not overwrite the gauge with partial counts.
+	api.request("POST", "/api/v1/boards/metered/cards", map[string]any{"title": "Not yet counted"})
+	api.request("GET", "/api/v1/boards/metered/cards?column=done", nil)
+
+	w := api.request("GET", "/metrics", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+
+	body := w.Body.Bytes()
+	for _, want := range []string{
+		`kan_cards_created_total{board="metered"} 3`,
+		`kan_cards_moved_total{board="metered"} 1`,
+		`kan_cards_total{board="metered",column="backlog"} 1`,
+		`kan_cards_total{board="metered",column="done"} 1`,
+		`kan_cards_total{board="metered",column="in-progress"} 0`,
+	} {
+		if !bytes.Contains(body, []byte(want)) {
+			t.Errorf("Expected metrics to contain %q", want)
+		}
+	}
+}
+
+// ============================================================================
+// Card Endpoint Tests
+// ============================================================================
+
+func TestHandler_ListCards_Empty(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+
+	w := api.request("GET", "/api/v1/boards/main/cards", nil)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", w.Code)
+	}
+
+	// Verify Content-Type header
+	if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Expected Content-Type 'application/json', got %q", ct)
+	}
+
+	var resp PaginatedCardList
+	decodeJSON(t, w, &resp)
+	if len(resp.Cards) != 0 {
+		t.Errorf("Expected empty cards list, got %d cards", len(resp.Cards))
+	}
+}
+
+func TestHandler_ListCards_NonexistentBoard(t *testing.T) {
+	api := setupTestAPI(t)
+	// Don't create any board
+
+	w := api.request("GET", "/api/v1/boards/nonexistent/cards", nil)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404, got %d", w.Code)
+	}
+
+	var resp map[string]string
+	decodeJSON(t, w, &resp)
+	if resp["error"] == "" {
+		t.Error("Expected error message in response")
+	}
+}
+
+func TestHandler_CreateCard_Basic(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+
+	body := map[string]any{
+		"title":  "Test card",
+		"column": "backlog",
+	}
+	w := api.request("POST", "/api/v1/boards/main/cards", body)
+
+	if w.Code != http.StatusCreated {
+		t.Errorf("Expected status 201, got %d. Body: %s", w.Code, w.Body.String())
+	}
+
+	var resp CreateCardResponse
+	decodeJSON(t, w, &resp)
+	if resp.Card.Title != "Test card" {
+		t.Errorf("Expected title 'Test card', got %q", resp.Card.Title)
+	}
+	if resp.Card.Column != "backlog" {
+		t.Errorf("Expected column 'backlog', got %q", resp.Card.Column)
+	}
+	if resp.Card.ID == "" {
+		t.Error("Expected card ID to be set")
+	}
+	if resp.Card.Alias == "" {
+		t.Error("Expected alias to be generated")
+	}
+}
+
+func TestHandler_CreateCard_AsyncHooks(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.Write([]byte("type=bug"))
+	}))
+	defer server.Close()
+
+	cfg, err := api.boardStore.Get("main")
+	if err != nil {
+		t.Fatalf("Failed to get board: %v", err)
+	}
+	cfg.PatternHooks = []model.PatternHook{{Name: "notify", PatternTitle: ".*", Webhook: server.URL, Timeout: 5}}
+	if err := api.boardStore.Update(cfg); err != nil {
+		t.Fatalf("Failed to update board: %v", err)
+	}
+
+	events := subscribeEvents(t, api, "main")
+	w := api.request("POST", "/api/v1/boards/main/cards?async_hooks=true", map[string]any{"title": "Slow hook"})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	var created CreateCardResponse
+	decodeJSON(t, w, &created)
+	if created.HookTaskID == "" || created.Card.ID == "" {
+		t.Fatalf("Expected card and hook task ID, got %+v", created)
+	}
+	if len(created.HookResults) != 0 {
+		t.Errorf("Expected no inline hook results in async mode, got %+v", created.HookResults)
+	}
+
+	close(release)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		w = api.request("GET", "/api/v1/hook-tasks/"+created.HookTaskID, nil)
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+		var task HookTaskResponse
+		decodeJSON(t, w, &task)
+		if task.Done {
+			if len(task.HookResults) != 1 || !task.HookResults[0].Success || task.HookResults[0].Output != "type=bug" {
+				t.Errorf("Unexpected hook results: %+v", task.HookResults)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Timed out waiting for async hooks")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// The hook's field is applied in the background and published once saved.
+	if first := nextEvent(t, events); first.EventType != EventCardCreated {
+		t.Errorf("Expected %s first, got %+v", EventCardCreated, first)
+	}
+	if updated := nextEvent(t, events); updated.EventType != EventCardUpdated || updated.CardID != created.Card.ID {
+		t.Errorf("Expected %s for the hook's field, got %+v", EventCardUpdated, updated)
+	}
+	if card, _ := api.cardStore.Get("main", created.Card.ID); card.CustomFields["type"] != "bug" {
+		t.Errorf("Expected type=bug from the hook, got %v", card.CustomFields)
+	}
+
+	w = api.request("GET", "/api/v1/hook-tasks/e_missing", nil)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404 for unknown task, got %d", w.Code)
+	}
+}
+
+func TestHandler_CreateCard_WithCustomFields(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+
+	body := map[string]any{
+		"title":         "Bug fix",
+		"column":        "backlog",
+		"custom_fields": map[string]any{"type": "bug"},
+	}
+	w := api.request("POST", "/api/v1/boards/main/cards", body)
+
+	if w.Code != http.StatusCreated {
+		t.Errorf("Expected status 201, got %d. Body: %s", w.Code, w.Body.String())
+	}
+
+	// Custom fields should be flattened to top level in the card JSON response
+	var response map[string]any
+	decodeJSON(t, w, &response)
+	card := response["card"].(map[string]any)
+	if card["type"] != "bug" {
+		t.Errorf("Expected 'type' field at top level of card to be 'bug', got %v", card["type"])
+	}
+	// Should NOT be nested under custom_fields within the card
+	if card["custom_fields"] != nil {
+		t.Errorf("Expected custom_fields to be flattened, but found nested: %v", card["custom_fields"])
+	}
+}
+
+func TestHandler_CreateCard_DefaultColumn(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+
+	body := map[string]any{
+		"title": "No column specified",
+	}
+	w := api.request("POST", "/api/v1/boards/main/cards", body)
+
+	if w.Code != http.StatusCreated {
+		t.Errorf("Expected status 201, got %d. Body: %s", w.Code, w.Body.String())
+	}
+
+	var resp CreateCardResponse
+	decodeJSON(t, w, &resp)
+	if resp.Card.Column != "backlog" {
+		t.Errorf("Expected default column 'backlog', got %q", resp.Card.Column)
+	}
+}
+
+func TestHandler_CreateCard_MissingTitle(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+
+	body := map[string]any{
+		"column": "backlog",
+	}
+	w := api.request("POST", "/api/v1/boards/main/cards", body)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", w.Code)
+	}
+
+	var resp map[string]string
+	decodeJSON(t, w, &resp)
+	if resp["error"] != "title is required" {
+		t.Errorf("Expected 'title is required' error, got %q", resp["error"])
+	}
+}
+
+func TestHandler_CreateCard_InvalidColumn(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+
+	body := map[string]any{
+		"title":  "Bad column",
+		"column": "NonExistent",
+	}
+	w := api.request("POST", "/api/v1/boards/main/cards", body)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404, got %d", w.Code)
+	}
+}
+
+func TestHandler_CreateCard_InvalidCustomField(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+
+	body := map[string]any{
+		"title":         "Bad type",
+		"column":        "backlog",
+		"custom_fields": map[string]any{"type": "nonexistent"},
+	}
+	w := api.request("POST", "/api/v1/boards/main/cards", body)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d. Body: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestHandler_CreateCard_InvalidJSON(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+
+	req := httptest.NewRequest("POST", "/api/v1/boards/main/cards", bytes.NewReader([]byte("not json")))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	api.mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", w.Code)
+	}
+}
+
+func TestHandler_CreateCardFromTemplate(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+
+	ctx := api.handler.ctx()
+	cfg, err := ctx.BoardStore.Get("main")
+	if err != nil {
+		t.Fatalf("Get board failed: %v", err)
+	}
+	cfg.Templates = []model.CardTemplate{{
+		Name:               "bug-report",
+		TitlePattern:       "[{{.component}}] {{.severity}} bug",
+		DefaultDescription: "Found in {{.component}}",
+	}}
+	if err := ctx.BoardStore.Update(cfg); err != nil {
+		t.Fatalf("Update board failed: %v", err)
+	}
+
+	path := "/api/v1/boards/main/cards/from-template"
+	w := api.request("POST", path, map[string]any{
+		"template": "bug-report",
+		"vars":     map[string]string{"component": "auth", "severity": "high"},
+	})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	card := createCardFromResponse(t, w)
+	if card.Title != "[auth] high bug" || card.Description != "Found in auth" {
+		t.Errorf("Expected expanded title and description, got %q / %q", card.Title, card.Description)
+	}
+
+	tests := []struct {
+		name string
+		body map[string]any
+		want int
+	}{
+		{"missing var", map[string]any{"template": "bug-report", "vars": map[string]string{"component": "auth"}}, http.StatusBadRequest},
+		{"missing template", map[string]any{"vars": map[string]string{}}, http.StatusBadRequest},
+		{"unknown template", map[string]any{"template": "feature-request"}, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if w := api.request("POST", path, tt.body); w.Code != tt.want {
+				t.Errorf("Expected status %d, got %d. Body: %s", tt.want, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandler_CardsJSONL(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+	api.createBoard(t, "copy")
+	for _, title := range []string{"First", "Second", "Third"} {
+		api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": title, "custom_fields": map[string]any{"type": "bug"}})
+	}
+
+	w := api.request("GET", "/api/v1/boards/main/cards.jsonl", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/x-ndjson" {
+		t.Errorf("Expected NDJSON content type, got %q", ct)
+	}
+	export := w.Body.Bytes()
+	if n := bytes.Count(export, []byte("\n")); n != 3 {
+		t.Errorf("Expected 3 lines, got %d", n)
+	}
+
+	req := httptest.NewRequest("POST", "/api/v1/boards/copy/cards/import-jsonl", bytes.NewReader(export))
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	w = httptest.NewRecorder()
+	api.mux.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	var resp ImportJSONLResponse
+	decodeJSON(t, w, &resp)
+	if resp.Imported != 3 {
+		t.Errorf("Expected 3 imported, got %d", resp.Imported)
+	}
+	cards, _ := api.cardStore.List("copy", true)
+	if len(cards) != 3 || cards[0].CustomFields["type"] != "bug" {
+		t.Errorf("Expected 3 imported bug cards, got %d", len(cards))
+	}
+
+	if w := api.request("GET", "/api/v1/boards/nope/cards.jsonl", nil); w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 for missing board, got %d", w.Code)
+	}
+	req = httptest.NewRequest("POST", "/api/v1/boards/copy/cards/import-jsonl", strings.NewReader("{bad\n"))
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	w = httptest.NewRecorder()
+	api.mux.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for malformed JSONL, got %d", w.Code)
+	}
+}
+
+func TestHandler_ImportCardsCSV(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+
+	upload := func(csvData, mapping string) *httptest.ResponseRecorder {
+		var body bytes.Buffer
+		mw := multipart.NewWriter(&body)
+		part, _ := mw.CreateFormFile("file", "cards.csv")
+		part.Write([]byte(csvData))
+		if mapping != "" {
+			mw.WriteField("mapping", mapping)
+		}
+		mw.Close()
+
+		req := httptest.NewRequest("POST", "/api/v1/boards/main/cards/import-csv", &body)
+		req.Header.Set("Content-Type", mw.FormDataContentType())
+		w := httptest.NewRecorder()
+		api.mux.ServeHTTP(w, req)
+		return w
+	}
+
+	w := upload("Name,Kind,Stage\nFirst,bug,done\nSecond,,\n,feature,\nThird,,nowhere\n", `{"Name":"title","Kind":"type","Stage":"column"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	var resp ImportCSVResponse
+	decodeJSON(t, w, &resp)
+	if resp.Imported != 2 || resp.Skipped != 2 || len(resp.Errors) != 1 || resp.Errors[0].Row != 5 {
+		t.Errorf("Expected 2 imported, 2 skipped, an error on row 5; got %+v", resp)
+	}
+
+	cards, _ := api.cardStore.List("main", false)
+	if len(cards) != 2 {
+		t.Errorf("Expected 2 cards, got %d", len(cards))
+	}
+	for _, c := range cards {
+		if c.Title == "First" && (c.Column != "done" || c.CustomFields["type"] != "bug") {
+			t.Errorf("Expected First in done with type=bug, got column=%q type=%v", c.Column, c.CustomFields["type"])
+		}
+	}
+
+	if w := upload("Name\nx\n", `{"Name":"nope"}`); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for bad mapping, got %d", w.Code)
+	}
+	if w := upload("title\nx\n", `[not json`); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for malformed mapping, got %d", w.Code)
+	}
+	if w := api.request("POST", "/api/v1/boards/main/cards/import-csv", map[string]any{}); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for non-multipart body, got %d", w.Code)
+	}
+}
+
+func TestHandler_GetCard_ByID(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+
+	// Create a card first
+	body := map[string]any{"title": "Test card", "column": "backlog"}
+	createResp := api.request("POST", "/api/v1/boards/main/cards", body)
+	created := createCardFromResponse(t, createResp)
+
+	// Get by ID
+	w := api.request("GET", "/api/v1/boards/main/cards/"+created.ID, nil)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", w.Code)
+	}
+
+	var card model.Card
+	decodeJSON(t, w, &card)
+	if card.ID != created.ID {
+		t.Errorf("Expected card ID %q, got %q", created.ID, card.ID)
+	}
+}
+
+func TestHandler_GetCard_ByAlias(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+
+	// Create a card
+	body := map[string]any{"title": "Fix login bug", "column": "backlog"}
+	createResp := api.request("POST", "/api/v1/boards/main/cards", body)
+	created := createCardFromResponse(t, createResp)
+
+	// Get by alias
+	w := api.request("GET", "/api/v1/boards/main/cards/fix-login-bug", nil)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+	}
+
+	var card model.Card
+	decodeJSON(t, w, &card)
+	if card.ID != created.ID {
+		t.Errorf("Expected card ID %q, got %q", created.ID, card.ID)
+	}
+}
+
+func TestHandler_GetCard_NotFound(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+
+	w := api.request("GET", "/api/v1/boards/main/cards/nonexistent", nil)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404, got %d", w.Code)
+	}
+}
+
+func TestHandler_UpdateCard_Title(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+
+	// Create a card
+	body := map[string]any{"title": "Original", "column": "backlog"}
+	createResp := api.request("POST", "/api/v1/boards/main/cards", body)
+	created := createCardFromResponse(t, createResp)
+
+	// Update title
 	updateBody := map[string]any{"title": "Updated title"}
 	w := api.request("PUT", "/api/v1/boards/main/cards/"+created.ID, updateBody)
 
 	if w.Code != http.StatusOK {
-		t.Errorf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		t.Errorf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+	}
+
+	var updated model.Card
+	decodeJSON(t, w, &updated)
+	if updated.Title != "Updated title" {
+		t.Errorf("Expected title 'Updated title', got %q", updated.Title)
+	}
+	// Alias should be regenerated
+	if updated.Alias != "updated-title" {
+		t.Errorf("Expected alias 'updated-title', got %q", updated.Alias)
+	}
+}
+
+func TestHandler_UpdateCard_Column(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+
+	// Create a card
+	body := map[string]any{"title": "Test", "column": "backlog"}
+	createResp := api.request("POST", "/api/v1/boards/main/cards", body)
+	created := createCardFromResponse(t, createResp)
+
+	// Move to different column via update
+	updateBody := map[string]any{"column": "in-progress"}
+	w := api.request("PUT", "/api/v1/boards/main/cards/"+created.ID, updateBody)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+	}
+
+	var updated CardResponse
+	decodeJSON(t, w, &updated)
+	if updated.Column != "in-progress" {
+		t.Errorf("Expected column 'in-progress', got %q", updated.Column)
+	}
+}
+
+func TestHandler_UpdateCard_Description(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+
+	// Create a card
+	body := map[string]any{"title": "Test", "column": "backlog"}
+	createResp := api.request("POST", "/api/v1/boards/main/cards", body)
+	created := createCardFromResponse(t, createResp)
+
+	// Update description
+	updateBody := map[string]any{"description": "New description"}
+	w := api.request("PUT", "/api/v1/boards/main/cards/"+created.ID, updateBody)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", w.Code)
+	}
+
+	var updated model.Card
+	decodeJSON(t, w, &updated)
+	if updated.Description != "New description" {
+		t.Errorf("Expected description 'New description', got %q", updated.Description)
+	}
+}
+
+func TestHandler_UpdateCard_NotFound(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+
+	updateBody := map[string]any{"title": "Updated"}
+	w := api.request("PUT", "/api/v1/boards/main/cards/nonexistent", updateBody)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404, got %d", w.Code)
+	}
+}
+
+func TestHandler_DeleteCard(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+
+	// Create a card
+	body := map[string]any{"title": "To delete", "column": "backlog"}
+	createResp := api.request("POST", "/api/v1/boards/main/cards", body)
+	created := createCardFromResponse(t, createResp)
+
+	// Delete it
+	w := api.request("DELETE", "/api/v1/boards/main/cards/"+created.ID, nil)
+
+	if w.Code != http.StatusNoContent {
+		t.Errorf("Expected status 204, got %d", w.Code)
+	}
+
+	// Verify it's gone
+	getResp := api.request("GET", "/api/v1/boards/main/cards/"+created.ID, nil)
+	if getResp.Code != http.StatusNotFound {
+		t.Errorf("Expected card to be deleted, got status %d", getResp.Code)
+	}
+}
+
+func TestHandler_DeleteCard_ByAlias(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+
+	// Create a card
+	body := map[string]any{"title": "Delete me", "column": "backlog"}
+	api.request("POST", "/api/v1/boards/main/cards", body)
+
+	// Delete by alias
+	w := api.request("DELETE", "/api/v1/boards/main/cards/delete-me", nil)
+
+	if w.Code != http.StatusNoContent {
+		t.Errorf("Expected status 204, got %d. Body: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestHandler_DeleteCard_ParentBehavior(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+
+	parent := createCardFromResponse(t, api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "Epic"}))
+	child := createCardFromResponse(t, api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "Task", "parent": parent.ID}))
+
+	if w := api.request("DELETE", "/api/v1/boards/main/cards/"+parent.ID+"?parent_behavior=orphan", nil); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for an unknown behavior, got %d", w.Code)
+	}
+
+	if w := api.request("DELETE", "/api/v1/boards/main/cards/"+parent.ID+"?parent_behavior=delete", nil); w.Code != http.StatusNoContent {
+		t.Fatalf("Expected 204, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	if w := api.request("GET", "/api/v1/boards/main/cards/"+child.ID, nil); w.Code != http.StatusNotFound {
+		t.Errorf("Expected child to be deleted with its parent, got status %d", w.Code)
+	}
+}
+
+func TestHandler_DeleteCard_NotFound(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+
+	w := api.request("DELETE", "/api/v1/boards/main/cards/nonexistent", nil)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404, got %d", w.Code)
+	}
+}
+
+func TestHandler_MoveCard_ToColumn(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+
+	// Create a card
+	body := map[string]any{"title": "Movable", "column": "backlog"}
+	createResp := api.request("POST", "/api/v1/boards/main/cards", body)
+	created := createCardFromResponse(t, createResp)
+
+	// Move it
+	moveBody := map[string]any{"column": "done"}
+	w := api.request("PATCH", "/api/v1/boards/main/cards/"+created.ID+"/move", moveBody)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+	}
+
+	var moved CardResponse
+	decodeJSON(t, w, &moved)
+	if moved.Column != "done" {
+		t.Errorf("Expected column 'done', got %q", moved.Column)
+	}
+}
+
+func TestHandler_MoveCard_WithPosition(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+
+	// Create two cards in done
+	firstResp := api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "First", "column": "done"})
+	first := createCardFromResponse(t, firstResp)
+	secondResp := api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "Second", "column": "done"})
+	second := createCardFromResponse(t, secondResp)
+
+	// Create a card in backlog
+	createResp := api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "Third", "column": "backlog"})
+	third := createCardFromResponse(t, createResp)
+
+	// Move to position 0 in done
+	position := 0
+	moveBody := map[string]any{"column": "done", "position": position}
+	w := api.request("PATCH", "/api/v1/boards/main/cards/"+third.ID+"/move", moveBody)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+	}
+
+	var moved CardResponse
+	decodeJSON(t, w, &moved)
+	if moved.Column != "done" {
+		t.Errorf("Expected column 'done', got %q", moved.Column)
+	}
+
+	// Verify order in done column: Third, First, Second
+	listResp := api.request("GET", "/api/v1/boards/main/cards?column=done", nil)
+	var listResult PaginatedCardList
+	decodeJSON(t, listResp, &listResult)
+	cards := listResult.Cards
+	if len(cards) != 3 {
+		t.Fatalf("Expected 3 cards in done, got %d", len(cards))
+	}
+	if cards[0].ID != third.ID {
+		t.Errorf("Expected Third at position 0, got %s", cards[0].Title)
+	}
+	if cards[1].ID != first.ID {
+		t.Errorf("Expected First at position 1, got %s", cards[1].Title)
+	}
+	if cards[2].ID != second.ID {
+		t.Errorf("Expected Second at position 2, got %s", cards[2].Title)
+	}
+}
+
+func TestHandler_MoveCard_InvalidColumn(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+
+	// Create a card
+	body := map[string]any{"title": "Test", "column": "backlog"}
+	createResp := api.request("POST", "/api/v1/boards/main/cards", body)
+	created := createCardFromResponse(t, createResp)
+
+	// Try to move to invalid column
+	moveBody := map[string]any{"column": "NonExistent"}
+	w := api.request("PATCH", "/api/v1/boards/main/cards/"+created.ID+"/move", moveBody)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404, got %d", w.Code)
+	}
+}
+
+func TestHandler_MoveCard_MissingColumn(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+
+	// Create a card
+	body := map[string]any{"title": "Test", "column": "backlog"}
+	createResp := api.request("POST", "/api/v1/boards/main/cards", body)
+	created := createCardFromResponse(t, createResp)
+
+	// Try to move without specifying column
+	moveBody := map[string]any{}
+	w := api.request("PATCH", "/api/v1/boards/main/cards/"+created.ID+"/move", moveBody)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", w.Code)
+	}
+
+	var resp map[string]string
+	decodeJSON(t, w, &resp)
+	if resp["error"] != "column is required" {
+		t.Errorf("Expected 'column is required' error, got %q", resp["error"])
+	}
+}
+
+func TestHandler_CreateCard_ColumnAtLimit(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+
+	api.request("PATCH", "/api/v1/boards/main/columns/in-progress", map[string]any{"limit": 1})
+	api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "First", "column": "in-progress"})
+
+	w := api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "Second", "column": "in-progress"})
+	if w.Code != http.StatusConflict {
+		t.Fatalf("Expected status 409, got %d. Body: %s", w.Code, w.Body.String())
+	}
+
+	var resp map[string]string
+	decodeJSON(t, w, &resp)
+	if resp["error"] != "column 'in-progress' is at its WIP limit of 1" {
+		t.Errorf("Unexpected error message: %q", resp["error"])
+	}
+}
+
+func TestHandler_ListColumns(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+
+	api.request("PATCH", "/api/v1/boards/main/columns/in-progress", map[string]any{"limit": 1})
+	api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "First", "column": "in-progress"})
+
+	w := api.request("GET", "/api/v1/boards/main/columns", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	var resp ColumnSummariesResponse
+	decodeJSON(t, w, &resp)
+	var inProgress *ColumnSummaryResponse
+	for i := range resp.Columns {
+		if resp.Columns[i].Name == "in-progress" {
+			inProgress = &resp.Columns[i]
+		}
+	}
+	if inProgress == nil {
+		t.Fatalf("in-progress missing from %+v", resp.Columns)
+	}
+	if inProgress.CardCount != 1 || !inProgress.AtLimit || inProgress.PercentFull != 100 {
+		t.Errorf("Unexpected in-progress summary: %+v", *inProgress)
+	}
+
+	w = api.request("POST", "/api/v1/boards/main/columns", map[string]any{"name": "review", "limit": 3})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	var created CreateColumnResponse
+	decodeJSON(t, w, &created)
+	if created.Name != "review" || created.Limit != 3 {
+		t.Errorf("Unexpected column: %+v", created.Column)
+	}
+	if len(created.Columns) != len(resp.Columns)+1 {
+		t.Errorf("Expected %d summaries, got %+v", len(resp.Columns)+1, created.Columns)
+	}
+
+	if w := api.request("GET", "/api/v1/boards/missing/columns", nil); w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404 for missing board, got %d", w.Code)
+	}
+}
+
+func TestHandler_Sprint(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+
+	if w := api.request("GET", "/api/v1/boards/main/sprint/active", nil); w.Code != http.StatusNotFound {
+		t.Fatalf("Expected status 404 with no sprint, got %d. Body: %s", w.Code, w.Body.String())
+	}
+
+	// A sprint that hasn't started yet isn't active.
+	cfg, _ := api.boardStore.Get("main")
+	start := time.Now().Add(24 * time.Hour).UnixMilli()
+	cfg.SetColumnSprint("backlog", start, start+int64(7*24*time.Hour/time.Millisecond))
+	api.boardStore.Update(cfg)
+	if w := api.request("GET", "/api/v1/boards/main/sprint/active", nil); w.Code != http.StatusNotFound {
+		t.Fatalf("Expected status 404 for a future sprint, got %d. Body: %s", w.Code, w.Body.String())
+	}
+
+	w := api.request("POST", "/api/v1/boards/main/sprint/start", map[string]any{"column": "in-progress", "duration_days": 7})
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	var started model.Column
+	decodeJSON(t, w, &started)
+	if started.Name != "in-progress" || !started.HasSprint() {
+		t.Errorf("Unexpected column: %+v", started)
+	}
+
+	api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "Sprint work", "column": "in-progress"})
+	api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "Later", "column": "backlog"})
+
+	w = api.request("GET", "/api/v1/boards/main/sprint/active", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	var resp SprintResponse
+	decodeJSON(t, w, &resp)
+	if resp.Column.Name != "in-progress" {
+		t.Errorf("Expected the in-progress sprint, got %q", resp.Column.Name)
+	}
+	if len(resp.Cards) != 1 || resp.Cards[0].Title != "Sprint work" {
+		t.Errorf("Expected only the sprint column's card, got %+v", resp.Cards)
+	}
+
+	w = api.request("POST", "/api/v1/boards/main/sprint/start", map[string]any{"column": "review"})
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404 for missing column, got %d", w.Code)
+	}
+}
+
+func TestHandler_CreateColumn_IfRevision(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+
+	boardRevision := func() int {
+		t.Helper()
+		w := api.request("GET", "/api/v1/boards/main", nil)
+		var board model.BoardConfig
+		decodeJSON(t, w, &board)
+		return board.Revision
+	}
+	start := boardRevision()
+
+	// Without If-Revision, concurrent creates both go through.
+	var wg sync.WaitGroup
+	codes := make([]int, 2)
+	for i, name := range []string{"review", "qa"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			codes[i] = api.request("POST", "/api/v1/boards/main/columns", map[string]any{"name": name}).Code
+		}()
+	}
+	wg.Wait()
+	for i, code := range codes {
+		if code != http.StatusCreated {
+			t.Errorf("Create %d without If-Revision: expected 201, got %d", i, code)
+		}
+	}
+	current := boardRevision()
+	if current <= start {
+		t.Fatalf("Expected revision to advance past %d, got %d", start, current)
+	}
+
+	// A stale revision is refused with the current one.
+	headers := map[string]string{"If-Revision": strconv.Itoa(start)}
+	w := api.requestWithHeaders("POST", "/api/v1/boards/main/columns", map[string]any{"name": "stale"}, headers)
+	if w.Code != http.StatusConflict {
+		t.Fatalf("Expected 409 for stale If-Revision, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	var conflict struct {
+		Error           string `json:"error"`
+		CurrentRevision int    `json:"current_revision"`
+	}
+	decodeJSON(t, w, &conflict)
+	if conflict.Error != "board config modified" || conflict.CurrentRevision != current {
+		t.Errorf("Unexpected conflict body: %+v, want current_revision %d", conflict, current)
+	}
+
+	// The current revision is accepted.
+	headers["If-Revision"] = strconv.Itoa(current)
+	w = api.requestWithHeaders("POST", "/api/v1/boards/main/columns", map[string]any{"name": "fresh"}, headers)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected 201 for current If-Revision, got %d. Body: %s", w.Code, w.Body.String())
+	}
+
+	headers["If-Revision"] = "abc"
+	if w := api.requestWithHeaders("DELETE", "/api/v1/boards/main/columns/fresh", nil, headers); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for malformed If-Revision, got %d", w.Code)
+	}
+}
+
+func TestHandler_MoveCard_NotFound(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+
+	moveBody := map[string]any{"column": "done"}
+	w := api.request("PATCH", "/api/v1/boards/main/cards/nonexistent/move", moveBody)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404, got %d", w.Code)
+	}
+}
+
+func TestHandler_CloneCard(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+
+	source := createCardFromResponse(t, api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "Original", "column": "backlog"}))
+
+	body := map[string]any{"target_column": "done", "title_prefix": "[COPY] "}
+	w := api.request("POST", "/api/v1/boards/main/cards/"+source.ID+"/clone", body)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d. Body: %s", w.Code, w.Body.String())
+	}
+
+	var clone CardResponse
+	decodeJSON(t, w, &clone)
+	if clone.ID == source.ID || clone.Alias == source.Alias {
+		t.Errorf("Expected fresh ID and alias, got %s / %s", clone.ID, clone.Alias)
+	}
+	if clone.Title != "[COPY] Original" || clone.Column != "done" {
+		t.Errorf("Expected '[COPY] Original' in done, got %q in %q", clone.Title, clone.Column)
+	}
+}
+
+func TestHandler_CloneCard_CustomFields(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+
+	source := createCardFromResponse(t, api.request("POST", "/api/v1/boards/main/cards", map[string]any{
+		"title":         "Original",
+		"custom_fields": map[string]any{"type": "bug", "labels": "blocked"},
+	}))
+
+	body := map[string]any{"title": "Second try", "labels": []string{"needs-review"}}
+	w := api.request("POST", "/api/v1/boards/main/cards/"+source.ID+"/clone", body)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d. Body: %s", w.Code, w.Body.String())
+	}
+
+	var clone CardResponse
+	decodeJSON(t, w, &clone)
+	if clone.ID == source.ID || clone.Alias == source.Alias {
+		t.Errorf("Expected fresh ID and alias, got %s / %s", clone.ID, clone.Alias)
+	}
+	if clone.Title != "Second try" {
+		t.Errorf("Expected title 'Second try', got %q", clone.Title)
+	}
+
+	stored, err := api.cardStore.Get("main", clone.ID)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if stored.CustomFields["type"] != "bug" {
+		t.Errorf("Expected type 'bug' copied, got %v", stored.CustomFields["type"])
+	}
+	if got := stored.GetLabels(); !reflect.DeepEqual(got, []string{"blocked", "needs-review"}) {
+		t.Errorf("Expected copied and added labels, got %v", got)
+	}
+}
+
+func TestHandler_CardETag(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+	card := createCardFromResponse(t, api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "Shared"}))
+	path := "/api/v1/boards/main/cards/" + card.ID
+
+	w := api.request("GET", path, nil)
+	etag := w.Header().Get("ETag")
+	if etag != fmt.Sprintf("%q", fmt.Sprint(card.UpdatedAtMillis)) {
+		t.Fatalf("Expected ETag of UpdatedAtMillis, got %q", etag)
+	}
+
+	// Conditional GET with a matching ETag
+	w = api.requestWithHeaders("GET", path, nil, map[string]string{"If-None-Match": etag})
+	if w.Code != http.StatusNotModified {
+		t.Errorf("Expected 304 for matching If-None-Match, got %d", w.Code)
+	}
+	if w.Body.Len() != 0 {
+		t.Errorf("Expected empty 304 body, got %q", w.Body.String())
+	}
+
+	// Fresh edit: If-Match matches the current version
+	time.Sleep(2 * time.Millisecond) // ensure the edit gets a new UpdatedAtMillis
+	w = api.requestWithHeaders("PUT", path, map[string]any{"title": "First edit"}, map[string]string{"If-Match": etag})
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200 for fresh edit, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	newETag := w.Header().Get("ETag")
+	if newETag == "" || newETag == etag {
+		t.Errorf("Expected a new ETag after the edit, got %q", newETag)
 	}
 
-	var updated model.Card
-	decodeJSON(t, w, &updated)
-	if updated.Title != "Updated title" {
-		t.Errorf("Expected title 'Updated title', got %q", updated.Title)
+	// Concurrent edit: a second client still holds the old ETag
+	w = api.requestWithHeaders("PUT", path, map[string]any{"title": "Lost update"}, map[string]string{"If-Match": etag})
+	if w.Code != http.StatusPreconditionFailed {
+		t.Fatalf("Expected 412 for stale If-Match, got %d", w.Code)
 	}
-	// Alias should be regenerated
-	if updated.Alias != "updated-title" {
-		t.Errorf("Expected alias 'updated-title', got %q", updated.Alias)
+	var errResp map[string]string
+	decodeJSON(t, w, &errResp)
+	if errResp["error"] != "card has been modified" {
+		t.Errorf("Unexpected error body: %v", errResp)
+	}
+	w = api.requestWithHeaders("PATCH", path+"/move", map[string]any{"column": "done"}, map[string]string{"If-Match": etag})
+	if w.Code != http.StatusPreconditionFailed {
+		t.Errorf("Expected 412 for stale If-Match on move, got %d", w.Code)
+	}
+
+	// A stale If-None-Match gets the full card
+	w = api.requestWithHeaders("GET", path, nil, map[string]string{"If-None-Match": etag})
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected 200 for stale If-None-Match, got %d", w.Code)
+	}
+	var got CardResponse
+	decodeJSON(t, w, &got)
+	if got.Title != "First edit" {
+		t.Errorf("Expected the first edit to stick, got title %q", got.Title)
+	}
+
+	// Move with the current ETag succeeds
+	w = api.requestWithHeaders("PATCH", path+"/move", map[string]any{"column": "done"}, map[string]string{"If-Match": newETag})
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected 200 for move with current ETag, got %d. Body: %s", w.Code, w.Body.String())
 	}
 }
 
-func TestHandler_UpdateCard_Column(t *testing.T) {
+func TestHandler_ReorderColumnCards(t *testing.T) {
 	api := setupTestAPI(t)
 	api.createBoard(t, "main")
+	first := createCardFromResponse(t, api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "First", "column": "backlog"}))
+	second := createCardFromResponse(t, api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "Second", "column": "backlog"}))
 
-	// Create a card
-	body := map[string]any{"title": "Test", "column": "backlog"}
-	createResp := api.request("POST", "/api/v1/boards/main/cards", body)
-	created := createCardFromResponse(t, createResp)
+	w := api.request("PUT", "/api/v1/boards/main/columns/backlog/order", map[string]any{"card_ids": []string{second.ID, first.ID}})
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Cards []CardResponse `json:"cards"`
+	}
+	decodeJSON(t, w, &resp)
+	if len(resp.Cards) != 2 || resp.Cards[0].ID != second.ID || resp.Cards[1].ID != first.ID {
+		t.Errorf("Expected [second, first], got %+v", resp.Cards)
+	}
 
-	// Move to different column via update
-	updateBody := map[string]any{"column": "in-progress"}
-	w := api.request("PUT", "/api/v1/boards/main/cards/"+created.ID, updateBody)
+	if w := api.request("PUT", "/api/v1/boards/main/columns/backlog/order", map[string]any{"card_ids": []string{first.ID}}); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for a partial list, got %d", w.Code)
+	}
+	if w := api.request("PUT", "/api/v1/boards/main/columns/nope/order", map[string]any{"card_ids": []string{}}); w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404 for unknown column, got %d", w.Code)
+	}
+}
+
+func TestHandler_ListColumnCards(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+
+	var ids []string
+	for _, title := range []string{"First", "Second", "Third"} {
+		card := createCardFromResponse(t, api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": title, "column": "backlog"}))
+		ids = append(ids, card.ID)
+	}
+	api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "Elsewhere", "column": "done"})
+	order := []string{ids[2], ids[0], ids[1]}
+	if w := api.request("PUT", "/api/v1/boards/main/columns/backlog/order", map[string]any{"card_ids": order}); w.Code != http.StatusOK {
+		t.Fatalf("Reorder failed: %d %s", w.Code, w.Body.String())
+	}
 
+	w := api.request("GET", "/api/v1/boards/main/columns/backlog/cards", nil)
 	if w.Code != http.StatusOK {
-		t.Errorf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	var resp ColumnCardsResponse
+	decodeJSON(t, w, &resp)
+	if resp.Column != "backlog" || resp.Total != 3 || len(resp.Cards) != 3 {
+		t.Fatalf("Expected 3 backlog cards, got %+v", resp)
+	}
+	for i, card := range resp.Cards {
+		if card.ID != order[i] || card.Position != i || card.Column != "backlog" {
+			t.Errorf("Card %d = %s at position %d in %q, want %s at %d", i, card.ID, card.Position, card.Column, order[i], i)
+		}
+	}
+
+	w = api.request("GET", "/api/v1/boards/main/columns/in-progress/cards", nil)
+	if body := w.Body.String(); w.Code != http.StatusOK || !strings.Contains(body, `"cards":[]`) || !strings.Contains(body, `"total":0`) {
+		t.Errorf("Expected an empty column listing, got %d: %s", w.Code, body)
+	}
+
+	if w := api.request("GET", "/api/v1/boards/main/columns/nope/cards", nil); w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404 for unknown column, got %d", w.Code)
+	}
+	if w := api.request("GET", "/api/v1/boards/missing/columns/backlog/cards", nil); w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404 for unknown board, got %d", w.Code)
+	}
+}
+
+func TestHandler_Checklist(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+	card := createCardFromResponse(t, api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "Release"}))
+	api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "No checklist"})
+	base := "/api/v1/boards/main/cards/" + card.ID + "/checklist"
+
+	w := api.request("POST", base, map[string]any{"text": "Write changelog"})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	var item model.ChecklistItem
+	decodeJSON(t, w, &item)
+	if item.ID == "" || item.Text != "Write changelog" || item.Done {
+		t.Errorf("Unexpected item: %+v", item)
+	}
+	if w := api.request("POST", base, map[string]any{"text": ""}); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for empty text, got %d", w.Code)
 	}
 
+	// The filter keeps only cards with an unchecked item
+	w = api.request("GET", "/api/v1/boards/main/cards?has_incomplete_checklist=true", nil)
+	var listResult PaginatedCardList
+	decodeJSON(t, w, &listResult)
+	if len(listResult.Cards) != 1 || listResult.Cards[0].ID != card.ID {
+		t.Errorf("Expected only the card with an open item, got %v", listResult.Cards)
+	}
+
+	w = api.request("PATCH", base+"/"+item.ID, map[string]any{"done": true})
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+	}
 	var updated CardResponse
 	decodeJSON(t, w, &updated)
-	if updated.Column != "in-progress" {
-		t.Errorf("Expected column 'in-progress', got %q", updated.Column)
+	if len(updated.Checklist) != 1 || !updated.Checklist[0].Done {
+		t.Errorf("Expected the item to be done, got %+v", updated.Checklist)
+	}
+	if w := api.request("PATCH", base+"/"+item.ID, map[string]any{}); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 without done, got %d", w.Code)
+	}
+
+	w = api.request("GET", "/api/v1/boards/main/cards?has_incomplete_checklist=true", nil)
+	decodeJSON(t, w, &listResult)
+	if len(listResult.Cards) != 0 {
+		t.Errorf("Expected no cards once every item is done, got %v", listResult.Cards)
+	}
+
+	if w := api.request("DELETE", base+"/"+item.ID, nil); w.Code != http.StatusNoContent {
+		t.Errorf("Expected status 204, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	if w := api.request("DELETE", base+"/"+item.ID, nil); w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 deleting a missing item, got %d", w.Code)
 	}
 }
 
-func TestHandler_UpdateCard_Description(t *testing.T) {
+func TestHandler_ListCards_OverdueFilter(t *testing.T) {
 	api := setupTestAPI(t)
 	api.createBoard(t, "main")
 
-	// Create a card
-	body := map[string]any{"title": "Test", "column": "backlog"}
-	createResp := api.request("POST", "/api/v1/boards/main/cards", body)
-	created := createCardFromResponse(t, createResp)
+	past := createCardFromResponse(t, api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "Late", "due_at_millis": 1000}))
+	api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "No deadline"})
 
-	// Update description
-	updateBody := map[string]any{"description": "New description"}
-	w := api.request("PUT", "/api/v1/boards/main/cards/"+created.ID, updateBody)
+	w := api.request("GET", "/api/v1/boards/main/cards?overdue=true", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	var listResult PaginatedCardList
+	decodeJSON(t, w, &listResult)
+	cards := listResult.Cards
+	if len(cards) != 1 || cards[0].ID != past.ID {
+		t.Errorf("Expected only the overdue card, got %v", cards)
+	}
+	if cards[0].DueAtMillis != 1000 {
+		t.Errorf("Expected due_at_millis 1000, got %d", cards[0].DueAtMillis)
+	}
+}
+
+func TestHandler_ListCards_StaleFilter(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+
+	old := createCardFromResponse(t, api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "Forgotten"}))
+	api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "Fresh"})
+
+	ctx := api.handler.ctx()
+	card, err := ctx.CardStore.Get("main", old.ID)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	card.UpdatedAtMillis -= 31 * 24 * 60 * 60 * 1000
+	if err := ctx.CardStore.Update("main", card); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	// Stale detection is off until the board sets stale_days.
+	w := api.request("GET", "/api/v1/boards/main/cards?stale=true", nil)
+	var listResult PaginatedCardList
+	decodeJSON(t, w, &listResult)
+	if len(listResult.Cards) != 0 {
+		t.Errorf("Expected no stale cards without stale_days, got %v", listResult.Cards)
+	}
+
+	cfg, err := ctx.BoardStore.Get("main")
+	if err != nil {
+		t.Fatalf("Get board failed: %v", err)
+	}
+	cfg.Stale.StaleDays = 30
+	if err := ctx.BoardStore.Update(cfg); err != nil {
+		t.Fatalf("Update board failed: %v", err)
+	}
 
+	w = api.request("GET", "/api/v1/boards/main/cards?stale=true", nil)
 	if w.Code != http.StatusOK {
-		t.Errorf("Expected status 200, got %d", w.Code)
+		t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
 	}
+	decodeJSON(t, w, &listResult)
+	if len(listResult.Cards) != 1 || listResult.Cards[0].ID != old.ID {
+		t.Errorf("Expected only the stale card, got %v", listResult.Cards)
+	}
+}
 
-	var updated model.Card
+func TestHandler_ListCards_FieldFilters(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+
+	cfg, err := api.boardStore.Get("main")
+	if err != nil {
+		t.Fatalf("Get board failed: %v", err)
+	}
+	cfg.CustomFields = map[string]model.CustomFieldSchema{"story_points": {Type: model.FieldTypeInteger}}
+	if err := api.boardStore.Update(cfg); err != nil {
+		t.Fatalf("Update board failed: %v", err)
+	}
+
+	pointed := createCardFromResponse(t, api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "Estimated", "custom_fields": map[string]any{"story_points": "3"}}))
+	unpointed := createCardFromResponse(t, api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "Unestimated"}))
+
+	for query, want := range map[string]string{"has_field=story_points": pointed.ID, "missing_field=story_points": unpointed.ID} {
+		w := api.request("GET", "/api/v1/boards/main/cards?"+query, nil)
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s: expected status 200, got %d. Body: %s", query, w.Code, w.Body.String())
+		}
+		var listResult PaginatedCardList
+		decodeJSON(t, w, &listResult)
+		if len(listResult.Cards) != 1 || listResult.Cards[0].ID != want {
+			t.Errorf("%s: expected only %s, got %v", query, want, listResult.Cards)
+		}
+	}
+
+	if w := api.request("GET", "/api/v1/boards/main/cards?has_field=nope", nil); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for an unknown field, got %d", w.Code)
+	}
+}
+
+func TestHandler_GetBoardVelocity(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+
+	cfg, err := api.boardStore.Get("main")
+	if err != nil {
+		t.Fatalf("Get board failed: %v", err)
+	}
+	cfg.CustomFields = map[string]model.CustomFieldSchema{"story_points": {Type: model.FieldTypeInteger}}
+	if err := api.boardStore.Update(cfg); err != nil {
+		t.Fatalf("Update board failed: %v", err)
+	}
+
+	for _, points := range []string{"3", "5"} {
+		card := createCardFromResponse(t, api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "Card", "custom_fields": map[string]any{"story_points": points}}))
+		api.request("PATCH", "/api/v1/boards/main/cards/"+card.ID+"/move", map[string]any{"column": "done"})
+	}
+
+	w := api.request("GET", "/api/v1/boards/main/velocity?window_days=7&done_column=done", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	var resp VelocityResponse
+	decodeJSON(t, w, &resp)
+	if resp.Velocity != 8 || resp.WindowDays != 7 || resp.DoneColumn != "done" {
+		t.Errorf("Unexpected velocity response: %+v", resp)
+	}
+
+	if w := api.request("GET", "/api/v1/boards/main/velocity?window_days=soon", nil); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for a bad window, got %d", w.Code)
+	}
+}
+
+func TestHandler_ListCards_Pagination(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+
+	for _, title := range []string{"A", "B", "C", "D", "E"} {
+		api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": title, "column": "backlog"})
+	}
+	api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "Done", "column": "done"})
+
+	w := api.request("GET", "/api/v1/boards/main/cards?column=backlog&page=2&per_page=2", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	var resp PaginatedCardList
+	decodeJSON(t, w, &resp)
+	if resp.Total != 5 || resp.Page != 2 || resp.PerPage != 2 || resp.TotalPages != 3 {
+		t.Errorf("Unexpected envelope: total=%d page=%d per_page=%d total_pages=%d",
+			resp.Total, resp.Page, resp.PerPage, resp.TotalPages)
+	}
+	if len(resp.Cards) != 2 || resp.Cards[0].Title != "C" || resp.Cards[1].Title != "D" {
+		t.Errorf("Expected cards C, D on page 2, got %v", resp.Cards)
+	}
+
+	// Without pagination params every card comes back on one page.
+	decodeJSON(t, api.request("GET", "/api/v1/boards/main/cards", nil), &resp)
+	if len(resp.Cards) != 6 || resp.Total != 6 || resp.TotalPages != 1 {
+		t.Errorf("Expected all 6 cards on a single page, got %d cards (total=%d, pages=%d)",
+			len(resp.Cards), resp.Total, resp.TotalPages)
+	}
+
+	if w := api.request("GET", "/api/v1/boards/main/cards?page=0", nil); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for page=0, got %d", w.Code)
+	}
+	if w := api.request("GET", "/api/v1/boards/main/cards?per_page=abc", nil); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for non-integer per_page, got %d", w.Code)
+	}
+}
+
+func TestHandler_ListCards_Sort(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+
+	for _, c := range []struct {
+		title string
+		due   int64
+	}{{"B", 2000}, {"C", 1000}, {"A", 2000}, {"D", 0}} {
+		api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": c.title, "due_at_millis": c.due})
+	}
+
+	w := api.request("GET", "/api/v1/boards/main/cards?sort=due_at:asc,title:desc&per_page=3", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	var resp PaginatedCardList
+	decodeJSON(t, w, &resp)
+	var got []string
+	for _, c := range resp.Cards {
+		got = append(got, c.Title)
+	}
+	if fmt.Sprint(got) != "[C B A]" || resp.Total != 4 {
+		t.Errorf("Expected first page C,B,A of 4, got %v (total=%d)", got, resp.Total)
+	}
+
+	for _, bad := range []string{"due_at:sideways", "nope", "title,"} {
+		if w := api.request("GET", "/api/v1/boards/main/cards?sort="+bad, nil); w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400 for sort=%s, got %d", bad, w.Code)
+		}
+	}
+}
+
+func TestHandler_MoveCard_TransitionViolation(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+	cfg, _ := api.boardStore.Get("main")
+	cfg.Columns[2].TransitionRules = []model.TransitionRule{{RequiredFields: []string{"type"}}}
+	if err := api.boardStore.Update(cfg); err != nil {
+		t.Fatalf("Update board failed: %v", err)
+	}
+
+	card := createCardFromResponse(t, api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "Untyped"}))
+	w := api.request("PATCH", "/api/v1/boards/main/cards/"+card.ID+"/move", map[string]any{"column": "done"})
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("Expected status 422, got %d. Body: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestHandler_CardDependencies(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+
+	root := createCardFromResponse(t, api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "Root"}))
+	mid := createCardFromResponse(t, api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "Mid", "blocked_by": []string{root.Alias}}))
+	leaf := createCardFromResponse(t, api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "Leaf"}))
+
+	w := api.request("PUT", "/api/v1/boards/main/cards/"+leaf.ID, map[string]any{"blocked_by": []string{mid.ID}})
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	var updated CardResponse
 	decodeJSON(t, w, &updated)
-	if updated.Description != "New description" {
-		t.Errorf("Expected description 'New description', got %q", updated.Description)
+	if len(updated.BlockedBy) != 1 || updated.BlockedBy[0] != mid.ID {
+		t.Errorf("Expected blocked_by [%s], got %v", mid.ID, updated.BlockedBy)
+	}
+
+	var resp struct {
+		Cards []CardResponse `json:"cards"`
+	}
+	decodeJSON(t, api.request("GET", "/api/v1/boards/main/cards/"+leaf.ID+"/blocked-by", nil), &resp)
+	if len(resp.Cards) != 2 || resp.Cards[0].ID != mid.ID || resp.Cards[1].ID != root.ID {
+		t.Errorf("Expected transitive blockers [Mid Root], got %v", resp.Cards)
+	}
+
+	decodeJSON(t, api.request("GET", "/api/v1/boards/main/cards/"+root.ID+"/blocks", nil), &resp)
+	if len(resp.Cards) != 1 || resp.Cards[0].ID != mid.ID {
+		t.Errorf("Expected Root to block only Mid, got %v", resp.Cards)
+	}
+
+	if w := api.request("PUT", "/api/v1/boards/main/cards/"+leaf.ID, map[string]any{"blocked_by": []string{"missing"}}); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for unknown blocker, got %d", w.Code)
+	}
+	if w := api.request("GET", "/api/v1/boards/main/cards/missing/blocked-by", nil); w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404 for unknown card, got %d", w.Code)
 	}
 }
 
-func TestHandler_UpdateCard_NotFound(t *testing.T) {
+func TestHandler_SearchCards(t *testing.T) {
 	api := setupTestAPI(t)
 	api.createBoard(t, "main")
 
-	updateBody := map[string]any{"title": "Updated"}
-	w := api.request("PUT", "/api/v1/boards/main/cards/nonexistent", updateBody)
+	api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "Fix login redirect"})
+	api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "Refactor auth", "description": "Touches the login handler"})
+	api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "Unrelated"})
 
-	if w.Code != http.StatusNotFound {
-		t.Errorf("Expected status 404, got %d", w.Code)
+	w := api.request("POST", "/api/v1/boards/main/search", map[string]any{"query": "LOGIN"})
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	var resp SearchResponse
+	decodeJSON(t, w, &resp)
+	if resp.Count != 2 || len(resp.Results) != 2 {
+		t.Errorf("Expected 2 results, got count=%d results=%d", resp.Count, len(resp.Results))
+	}
+
+	w = api.request("POST", "/api/v1/boards/main/search", map[string]any{"query": "login", "fields": []string{"title"}})
+	decodeJSON(t, w, &resp)
+	if resp.Count != 1 || resp.Results[0].Title != "Fix login redirect" {
+		t.Errorf("Expected only the title match, got %v", resp.Results)
+	}
+
+	if w := api.request("POST", "/api/v1/boards/main/search", map[string]any{"query": "(", "use_regex": true}); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for invalid regex, got %d", w.Code)
+	}
+	if w := api.request("POST", "/api/v1/boards/main/search", map[string]any{}); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for missing query, got %d", w.Code)
+	}
+}
+
+func TestHandler_ArchiveCard(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+
+	card := createCardFromResponse(t, api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "Old", "column": "backlog"}))
+
+	w := api.request("POST", "/api/v1/boards/main/cards/"+card.ID+"/archive", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	var archived map[string]any
+	decodeJSON(t, w, &archived)
+	if archived["archived"] != true || archived["last_column"] != "backlog" {
+		t.Errorf("Expected archived card with last_column backlog, got %v", archived)
+	}
+
+	var listResult PaginatedCardList
+	decodeJSON(t, api.request("GET", "/api/v1/boards/main/cards", nil), &listResult)
+	if len(listResult.Cards) != 0 {
+		t.Errorf("Expected archived card hidden by default, got %d cards", len(listResult.Cards))
+	}
+
+	decodeJSON(t, api.request("GET", "/api/v1/boards/main/cards?include_archived=true", nil), &listResult)
+	if len(listResult.Cards) != 1 {
+		t.Errorf("Expected archived card with include_archived=true, got %d cards", len(listResult.Cards))
+	}
+
+	w = api.request("POST", "/api/v1/boards/main/cards/"+card.ID+"/unarchive", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	var restored CardResponse
+	decodeJSON(t, w, &restored)
+	if restored.Column != "backlog" {
+		t.Errorf("Expected unarchived card in backlog, got %q", restored.Column)
+	}
+}
+
+func TestHandler_BulkMoveCards(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+
+	first := createCardFromResponse(t, api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "First", "column": "backlog"}))
+	second := createCardFromResponse(t, api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "Second", "column": "backlog"}))
+
+	body := map[string]any{"card_ids": []string{second.ID, first.Alias}, "column": "done", "position": 0}
+	w := api.request("PATCH", "/api/v1/boards/main/cards/bulk-move", body)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+	}
+
+	listResp := api.request("GET", "/api/v1/boards/main/cards?column=done", nil)
+	var listResult PaginatedCardList
+	decodeJSON(t, listResp, &listResult)
+	cards := listResult.Cards
+	if len(cards) != 2 {
+		t.Fatalf("Expected 2 cards in done, got %d", len(cards))
+	}
+	if cards[0].ID != second.ID || cards[1].ID != first.ID {
+		t.Errorf("Expected order Second, First; got %s, %s", cards[0].Title, cards[1].Title)
 	}
 }
 
-func TestHandler_DeleteCard(t *testing.T) {
+func TestHandler_BulkMoveCards_InvalidIDRollsBack(t *testing.T) {
 	api := setupTestAPI(t)
 	api.createBoard(t, "main")
 
-	// Create a card
-	body := map[string]any{"title": "To delete", "column": "backlog"}
-	createResp := api.request("POST", "/api/v1/boards/main/cards", body)
-	created := createCardFromResponse(t, createResp)
+	card := createCardFromResponse(t, api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "Stay", "column": "backlog"}))
 
-	// Delete it
-	w := api.request("DELETE", "/api/v1/boards/main/cards/"+created.ID, nil)
+	body := map[string]any{"card_ids": []string{card.ID, "nonexistent"}, "column": "done"}
+	w := api.request("PATCH", "/api/v1/boards/main/cards/bulk-move", body)
 
-	if w.Code != http.StatusNoContent {
-		t.Errorf("Expected status 204, got %d", w.Code)
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("Expected status 422, got %d. Body: %s", w.Code, w.Body.String())
 	}
 
-	// Verify it's gone
-	getResp := api.request("GET", "/api/v1/boards/main/cards/"+created.ID, nil)
-	if getResp.Code != http.StatusNotFound {
-		t.Errorf("Expected card to be deleted, got status %d", getResp.Code)
+	var resp struct {
+		FailedIDs []string `json:"failed_ids"`
+	}
+	decodeJSON(t, w, &resp)
+	if len(resp.FailedIDs) != 1 || resp.FailedIDs[0] != "nonexistent" {
+		t.Errorf("Expected failed_ids [nonexistent], got %v", resp.FailedIDs)
+	}
+
+	getResp := api.request("GET", "/api/v1/boards/main/cards/"+card.ID, nil)
+	var got CardResponse
+	decodeJSON(t, getResp, &got)
+	if got.Column != "backlog" {
+		t.Errorf("Expected card to stay in backlog, got %q", got.Column)
 	}
 }
 
-func TestHandler_DeleteCard_ByAlias(t *testing.T) {
+func TestHandler_DeleteCards(t *testing.T) {
 	api := setupTestAPI(t)
 	api.createBoard(t, "main")
 
-	// Create a card
-	body := map[string]any{"title": "Delete me", "column": "backlog"}
-	api.request("POST", "/api/v1/boards/main/cards", body)
+	first := createCardFromResponse(t, api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "First"}))
+	second := createCardFromResponse(t, api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "Second"}))
 
-	// Delete by alias
-	w := api.request("DELETE", "/api/v1/boards/main/cards/delete-me", nil)
+	// A dry run reports what would go without deleting anything.
+	body := map[string]any{"card_ids": []string{first.ID, second.Alias, "nonexistent"}, "dry_run": true}
+	w := api.request("DELETE", "/api/v1/boards/main/cards", body)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	var dry DeleteCardsResponse
+	decodeJSON(t, w, &dry)
+	if !dry.DryRun || len(dry.Deleted) != 2 || len(dry.NotFound) != 1 || dry.NotFound[0] != "nonexistent" {
+		t.Errorf("Unexpected dry run response: %+v", dry)
+	}
+	if resp := api.request("GET", "/api/v1/boards/main/cards/"+first.ID, nil); resp.Code != http.StatusOK {
+		t.Fatalf("Dry run deleted the card: %d", resp.Code)
+	}
 
-	if w.Code != http.StatusNoContent {
-		t.Errorf("Expected status 204, got %d. Body: %s", w.Code, w.Body.String())
+	body["dry_run"] = false
+	w = api.request("DELETE", "/api/v1/boards/main/cards", body)
+	var result DeleteCardsResponse
+	decodeJSON(t, w, &result)
+	if result.DryRun || len(result.Deleted) != 2 || len(result.Failed) != 0 {
+		t.Errorf("Unexpected delete response: %+v", result)
+	}
+	for _, id := range []string{first.ID, second.ID} {
+		if resp := api.request("GET", "/api/v1/boards/main/cards/"+id, nil); resp.Code != http.StatusNotFound {
+			t.Errorf("Expected card %s to be deleted, got %d", id, resp.Code)
+		}
+	}
+
+	if w := api.request("DELETE", "/api/v1/boards/main/cards", map[string]any{"card_ids": []string{}}); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for empty card_ids, got %d", w.Code)
 	}
 }
 
-func TestHandler_DeleteCard_NotFound(t *testing.T) {
+func TestHandler_CopyCardFields(t *testing.T) {
 	api := setupTestAPI(t)
 	api.createBoard(t, "main")
 
-	w := api.request("DELETE", "/api/v1/boards/main/cards/nonexistent", nil)
+	sourceBody := map[string]any{
+		"title":         "Source",
+		"custom_fields": map[string]any{"type": "bug", "labels": "blocked"},
+	}
+	source := createCardFromResponse(t, api.request("POST", "/api/v1/boards/main/cards", sourceBody))
+	target := createCardFromResponse(t, api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "Target"}))
 
-	if w.Code != http.StatusNotFound {
-		t.Errorf("Expected status 404, got %d", w.Code)
+	body := map[string]any{"source_card": source.Alias, "fields": []string{"type"}}
+	w := api.request("POST", "/api/v1/boards/main/cards/"+target.ID+"/copy-fields", body)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+	}
+
+	var card map[string]any
+	decodeJSON(t, w, &card)
+	if card["type"] != "bug" {
+		t.Errorf("Expected type 'bug', got %v", card["type"])
+	}
+	if card["labels"] != nil {
+		t.Errorf("Expected labels not to be copied, got %v", card["labels"])
 	}
 }
 
-func TestHandler_MoveCard_ToColumn(t *testing.T) {
+func TestHandler_CopyCardFields_MissingSource(t *testing.T) {
 	api := setupTestAPI(t)
 	api.createBoard(t, "main")
 
-	// Create a card
-	body := map[string]any{"title": "Movable", "column": "backlog"}
-	createResp := api.request("POST", "/api/v1/boards/main/cards", body)
-	created := createCardFromResponse(t, createResp)
-
-	// Move it
-	moveBody := map[string]any{"column": "done"}
-	w := api.request("PATCH", "/api/v1/boards/main/cards/"+created.ID+"/move", moveBody)
+	target := createCardFromResponse(t, api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "Target"}))
 
-	if w.Code != http.StatusOK {
-		t.Errorf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+	w := api.request("POST", "/api/v1/boards/main/cards/"+target.ID+"/copy-fields", map[string]any{})
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", w.Code)
 	}
 
-	var moved CardResponse
-	decodeJSON(t, w, &moved)
-	if moved.Column != "done" {
-		t.Errorf("Expected column 'done', got %q", moved.Column)
+	w = api.request("POST", "/api/v1/boards/main/cards/"+target.ID+"/copy-fields", map[string]any{"source_card": "nope"})
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404, got %d", w.Code)
 	}
 }
 
-func TestHandler_MoveCard_WithPosition(t *testing.T) {
+func TestHandler_TransferCard(t *testing.T) {
 	api := setupTestAPI(t)
 	api.createBoard(t, "main")
+	api.createBoard(t, "other")
 
-	// Create two cards in done
-	firstResp := api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "First", "column": "done"})
-	first := createCardFromResponse(t, firstResp)
-	secondResp := api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "Second", "column": "done"})
-	second := createCardFromResponse(t, secondResp)
-
-	// Create a card in backlog
-	createResp := api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "Third", "column": "backlog"})
-	third := createCardFromResponse(t, createResp)
-
-	// Move to position 0 in done
-	position := 0
-	moveBody := map[string]any{"column": "done", "position": position}
-	w := api.request("PATCH", "/api/v1/boards/main/cards/"+third.ID+"/move", moveBody)
+	card := createCardFromResponse(t, api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "Moving on"}))
 
+	body := map[string]any{"target_board": "other", "target_column": "done"}
+	w := api.request("POST", "/api/v1/boards/main/cards/"+card.Alias+"/transfer", body)
 	if w.Code != http.StatusOK {
-		t.Errorf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
 	}
-
-	var moved CardResponse
-	decodeJSON(t, w, &moved)
-	if moved.Column != "done" {
-		t.Errorf("Expected column 'done', got %q", moved.Column)
+	var resp TransferCardResponse
+	decodeJSON(t, w, &resp)
+	if resp.Card.ID != card.ID || resp.Board != "other" || resp.Card.Column != "done" {
+		t.Errorf("Unexpected transfer response: %+v", resp)
 	}
 
-	// Verify order in done column: Third, First, Second
-	listResp := api.request("GET", "/api/v1/boards/main/cards?column=done", nil)
-	var listResult map[string][]CardResponse
-	decodeJSON(t, listResp, &listResult)
-	cards := listResult["cards"]
-	if len(cards) != 3 {
-		t.Fatalf("Expected 3 cards in done, got %d", len(cards))
+	if w := api.request("GET", "/api/v1/boards/main/cards/"+card.ID, nil); w.Code != http.StatusNotFound {
+		t.Errorf("Expected card gone from source (404), got %d", w.Code)
 	}
-	if cards[0].ID != third.ID {
-		t.Errorf("Expected Third at position 0, got %s", cards[0].Title)
+	if w := api.request("GET", "/api/v1/boards/other/cards/"+card.ID, nil); w.Code != http.StatusOK {
+		t.Errorf("Expected card on target board, got %d", w.Code)
 	}
-	if cards[1].ID != first.ID {
-		t.Errorf("Expected First at position 1, got %s", cards[1].Title)
+
+	if w := api.request("POST", "/api/v1/boards/other/cards/"+card.ID+"/transfer", map[string]any{}); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 without target_board, got %d", w.Code)
 	}
-	if cards[2].ID != second.ID {
-		t.Errorf("Expected Second at position 2, got %s", cards[2].Title)
+	body = map[string]any{"target_board": "main", "target_column": "nope"}
+	if w := api.request("POST", "/api/v1/boards/other/cards/"+card.ID+"/transfer", body); w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 for missing column, got %d", w.Code)
 	}
 }
 
-func TestHandler_MoveCard_InvalidColumn(t *testing.T) {
+func TestHandler_FetchCards(t *testing.T) {
 	api := setupTestAPI(t)
 	api.createBoard(t, "main")
 
-	// Create a card
-	body := map[string]any{"title": "Test", "column": "backlog"}
-	createResp := api.request("POST", "/api/v1/boards/main/cards", body)
-	created := createCardFromResponse(t, createResp)
+	login := createCardFromResponse(t, api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "Fix login bug"}))
+	docs := createCardFromResponse(t, api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "Write docs"}))
 
-	// Try to move to invalid column
-	moveBody := map[string]any{"column": "NonExistent"}
-	w := api.request("PATCH", "/api/v1/boards/main/cards/"+created.ID+"/move", moveBody)
+	w := api.request("POST", "/api/v1/boards/main/cards/fetch", FetchCardsRequest{IDs: []string{docs.ID, "fix-login-bug", "card-xyz"}})
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	var resp FetchCardsResponse
+	decodeJSON(t, w, &resp)
+	if len(resp.Cards) != 2 || resp.Cards[0].ID != docs.ID || resp.Cards[1].ID != login.ID {
+		t.Errorf("Expected docs then login, got %+v", resp.Cards)
+	}
+	if !reflect.DeepEqual(resp.NotFound, []string{"card-xyz"}) {
+		t.Errorf("Expected card-xyz not found, got %v", resp.NotFound)
+	}
 
-	if w.Code != http.StatusNotFound {
-		t.Errorf("Expected status 404, got %d", w.Code)
+	// An empty request is an empty result, not null.
+	w = api.request("POST", "/api/v1/boards/main/cards/fetch", FetchCardsRequest{})
+	if body := w.Body.String(); w.Code != http.StatusOK || !strings.Contains(body, `"cards":[]`) || !strings.Contains(body, `"not_found":[]`) {
+		t.Errorf("Expected empty lists, got %d: %s", w.Code, body)
+	}
+
+	if w := api.request("POST", "/api/v1/boards/missing/cards/fetch", FetchCardsRequest{IDs: []string{login.ID}}); w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 for unknown board, got %d", w.Code)
 	}
 }
 
-func TestHandler_MoveCard_MissingColumn(t *testing.T) {
+func TestHandler_SuggestColumn(t *testing.T) {
 	api := setupTestAPI(t)
 	api.createBoard(t, "main")
 
-	// Create a card
-	body := map[string]any{"title": "Test", "column": "backlog"}
-	createResp := api.request("POST", "/api/v1/boards/main/cards", body)
-	created := createCardFromResponse(t, createResp)
-
-	// Try to move without specifying column
-	moveBody := map[string]any{}
-	w := api.request("PATCH", "/api/v1/boards/main/cards/"+created.ID+"/move", moveBody)
+	card := createCardFromResponse(t, api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "Fresh"}))
 
-	if w.Code != http.StatusBadRequest {
-		t.Errorf("Expected status 400, got %d", w.Code)
+	w := api.request("GET", "/api/v1/boards/main/cards/"+card.Alias+"/suggest-column", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
 	}
-
-	var resp map[string]string
+	var resp SuggestColumnResponse
 	decodeJSON(t, w, &resp)
-	if resp["error"] != "column is required" {
-		t.Errorf("Expected 'column is required' error, got %q", resp["error"])
+	if resp.Column != "" || resp.Reason != "no suggestion" {
+		t.Errorf("Expected no suggestion, got %+v", resp)
+	}
+
+	if w := api.request("GET", "/api/v1/boards/main/cards/nope/suggest-column", nil); w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 for missing card, got %d", w.Code)
 	}
 }
 
-func TestHandler_MoveCard_NotFound(t *testing.T) {
+func TestHandler_SuggestTags(t *testing.T) {
 	api := setupTestAPI(t)
 	api.createBoard(t, "main")
 
-	moveBody := map[string]any{"column": "done"}
-	w := api.request("PATCH", "/api/v1/boards/main/cards/nonexistent/move", moveBody)
+	w := api.request("GET", "/api/v1/boards/main/cards/suggest-tags?title=Review+blocked+deploy", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	var resp SuggestTagsResponse
+	decodeJSON(t, w, &resp)
+	if want := []string{"blocked", "needs-review"}; !reflect.DeepEqual(resp.Suggestions, want) {
+		t.Errorf("Suggestions = %v, want %v", resp.Suggestions, want)
+	}
 
-	if w.Code != http.StatusNotFound {
-		t.Errorf("Expected status 404, got %d", w.Code)
+	w = api.request("GET", "/api/v1/boards/main/cards/suggest-tags?title=Unrelated", nil)
+	if w.Code != http.StatusOK || strings.TrimSpace(w.Body.String()) != `{"suggestions":[]}` {
+		t.Errorf("Expected an empty list, got %d %s", w.Code, w.Body.String())
+	}
+
+	if w := api.request("GET", "/api/v1/boards/nope/cards/suggest-tags?title=x", nil); w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 for missing board, got %d", w.Code)
 	}
 }
 
@@ -751,12 +2643,12 @@ func TestHandler_ListCards_WithColumnFilter(t *testing.T) {
 		t.Errorf("Expected status 200, got %d", w.Code)
 	}
 
-	var resp map[string][]CardResponse
+	var resp PaginatedCardList
 	decodeJSON(t, w, &resp)
-	if len(resp["cards"]) != 2 {
-		t.Errorf("Expected 2 cards in backlog, got %d", len(resp["cards"]))
+	if len(resp.Cards) != 2 {
+		t.Errorf("Expected 2 cards in backlog, got %d", len(resp.Cards))
 	}
-	for _, card := range resp["cards"] {
+	for _, card := range resp.Cards {
 		if card.Column != "backlog" {
 			t.Errorf("Expected column 'backlog', got %q", card.Column)
 		}
@@ -850,19 +2742,24 @@ func setupCrossProjectAPI(t *testing.T, globalCfg *model.GlobalConfig) (*testAPI
 	cardStore := store.NewCardStore(paths)
 	boardStore := store.NewBoardStore(paths)
 	projectStore := store.NewProjectStore(paths)
-	aliasService := service.NewAliasService(cardStore)
+	auditStore := store.NewAuditStore(paths)
+	aliasService := service.NewAliasService(cardStore, boardStore)
 	cardService := service.NewCardService(cardStore, boardStore, aliasService)
+	cardService.SetAuditStore(auditStore, func() string { return "test-user" })
 	boardService := service.NewBoardService(boardStore, cardStore)
+	searchService := service.NewSearchService(cardStore, boardStore)
 
 	ctx := &ProjectContext{
-		Paths:        paths,
-		BoardStore:   boardStore,
-		CardStore:    cardStore,
-		ProjectStore: projectStore,
-		CardService:  cardService,
-		BoardService: boardService,
-		Creator:      "test-user",
-		ProjectRoot:  tempDir,
+		Paths:         paths,
+		BoardStore:    boardStore,
+		CardStore:     cardStore,
+		ProjectStore:  projectStore,
+		AuditStore:    auditStore,
+		CardService:   cardService,
+		BoardService:  boardService,
+		SearchService: searchService,
+		Creator:       "test-user",
+		ProjectRoot:   tempDir,
 	}
 
 	gs := &mockGlobalStore{cfg: globalCfg}
@@ -1186,3 +3083,199 @@ func TestHandler_SwitchProject_MissingBody(t *testing.T) {
 		t.Errorf("Expected 400, got %d: %s", w.Code, w.Body.String())
 	}
 }
+
+func TestHandler_SearchAllBoards(t *testing.T) {
+	projADir := createProjectDir(t, "main")
+	projBDir := createProjectDir(t, "dev")
+
+	for dir, title := range map[string]string{projADir: "Fix login bug", projBDir: "Login page redesign"} {
+		cardStore := store.NewCardStore(config.NewPaths(dir, ""))
+		boardName := "main"
+		if dir == projBDir {
+			boardName = "dev"
+		}
+		card := &model.Card{ID: "card-" + boardName, Alias: boardName + "-card", Title: title, Column: "todo", Position: "a"}
+		if err := cardStore.Create(boardName, card); err != nil {
+			t.Fatalf("Failed to create card: %v", err)
+		}
+	}
+
+	globalCfg := &model.GlobalConfig{
+		Projects: map[string]string{
+			"project-a": projADir,
+			"project-b": projBDir,
+			"missing":   "/nonexistent/project", // no boards: contributes nothing, doesn't fail the search
+		},
+	}
+	api, _ := setupCrossProjectAPI(t, globalCfg)
+
+	w := api.request("GET", "/api/v1/all-boards/search?q=login", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp AllBoardsSearchResponse
+	decodeJSON(t, w, &resp)
+	if resp.Count != 2 || len(resp.Results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", resp.Count)
+	}
+	if resp.Results[0].ProjectPath != projADir || resp.Results[0].BoardName != "main" || resp.Results[0].Card.Title != "Fix login bug" {
+		t.Errorf("Unexpected first result: %+v", resp.Results[0])
+	}
+	if resp.Results[1].BoardName != "dev" {
+		t.Errorf("Unexpected second result: %+v", resp.Results[1])
+	}
+
+	if w := api.request("GET", "/api/v1/all-boards/search", nil); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 without q, got %d", w.Code)
+	}
+}
+
+func TestHandler_SearchAll(t *testing.T) {
+	projADir := createProjectDir(t, "main")
+	projBDir := createProjectDir(t, "dev")
+	brokenDir := createProjectDir(t, "broken")
+
+	for dir, title := range map[string]string{projADir: "Fix login bug", projBDir: "Login page redesign"} {
+		cardStore := store.NewCardStore(config.NewPaths(dir, ""))
+		boardName := "main"
+		if dir == projBDir {
+			boardName = "dev"
+		}
+		card := &model.Card{ID: "card-" + boardName, Alias: boardName + "-card", Title: title, Description: "Touches the session code", Column: "todo", Position: "a"}
+		if err := cardStore.Create(boardName, card); err != nil {
+			t.Fatalf("Failed to create card: %v", err)
+		}
+	}
+	brokenCfg := filepath.Join(config.NewPaths(brokenDir, "").BoardDir("broken"), config.ConfigFileName)
+	if err := os.WriteFile(brokenCfg, []byte("not = [valid toml"), 0644); err != nil {
+		t.Fatalf("Failed to corrupt board config: %v", err)
+	}
+
+	globalCfg := &model.GlobalConfig{
+		Projects: map[string]string{
+			"project-a": projADir,
+			"project-b": projBDir,
+			"project-c": brokenDir,
+		},
+	}
+	api, _ := setupCrossProjectAPI(t, globalCfg)
+
+	w := api.request("GET", "/api/v1/search?q=login", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp GlobalSearchResponse
+	decodeJSON(t, w, &resp)
+	if len(resp.Results) != 2 {
+		t.Fatalf("Expected 2 results, got %+v", resp.Results)
+	}
+	first := resp.Results[0]
+	if first.Project != "project-a" || first.ProjectPath != projADir || first.BoardName != "main" || first.MatchedIn != "title" {
+		t.Errorf("Unexpected first result: %+v", first)
+	}
+	if resp.Results[1].Project != "project-b" || resp.Results[1].ProjectPath != projBDir {
+		t.Errorf("Unexpected second result: %+v", resp.Results[1])
+	}
+	if len(resp.Skipped) != 1 || resp.Skipped[0].Name != "project-c" || resp.Skipped[0].Path != brokenDir {
+		t.Errorf("Expected the broken project to be skipped, got %+v", resp.Skipped)
+	}
+
+	// fields narrows what's searched
+	w = api.request("GET", "/api/v1/search?q=session&fields=title", nil)
+	decodeJSON(t, w, &resp)
+	if len(resp.Results) != 0 {
+		t.Errorf("Expected no title matches for 'session', got %+v", resp.Results)
+	}
+	w = api.request("GET", "/api/v1/search?q=session&fields=title,description", nil)
+	decodeJSON(t, w, &resp)
+	if len(resp.Results) != 2 || resp.Results[0].MatchedIn != "description" {
+		t.Errorf("Expected 2 description matches for 'session', got %+v", resp.Results)
+	}
+
+	if w := api.request("GET", "/api/v1/search?q=login&fields=alias", nil); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for unknown field, got %d", w.Code)
+	}
+	if w := api.request("GET", "/api/v1/search", nil); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 without q, got %d", w.Code)
+	}
+}
+
+func TestHandler_ListComments(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+	card := createCardFromResponse(t, api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "Thread"}))
+
+	for _, body := range []string{"first", "second", "third"} {
+		if w := api.request("POST", "/api/v1/boards/main/cards/"+card.ID+"/comments", map[string]any{"body": body}); w.Code != http.StatusCreated {
+			t.Fatalf("Expected status 201, got %d. Body: %s", w.Code, w.Body.String())
+		}
+	}
+
+	w := api.request("GET", "/api/v1/boards/main/cards/"+card.ID+"/comments", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	var resp CommentsResponse
+	decodeJSON(t, w, &resp)
+	if len(resp.Comments) != 3 {
+		t.Fatalf("Expected 3 comments, got %d", len(resp.Comments))
+	}
+	for i, want := range []string{"first", "second", "third"} {
+		if resp.Comments[i].Body != want {
+			t.Errorf("comment %d: expected %q, got %q", i, want, resp.Comments[i].Body)
+		}
+	}
+
+	if w := api.request("GET", "/api/v1/boards/main/cards/missing/comments", nil); w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404 for unknown card, got %d", w.Code)
+	}
+}
+
+func TestHandler_CreateComment_Mentions(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+	if err := api.handler.ctx().BoardService.AddCollaborator("main", "alice"); err != nil {
+		t.Fatalf("AddCollaborator failed: %v", err)
+	}
+	mentioned := createCardFromResponse(t, api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "Thread"}))
+	createCardFromResponse(t, api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "Quiet"}))
+
+	w := api.request("POST", "/api/v1/boards/main/cards/"+mentioned.ID+"/comments", map[string]any{"body": "@alice and @mallory, thoughts?"})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	var comment CommentResponse
+	decodeJSON(t, w, &comment)
+	if !reflect.DeepEqual(comment.MentionedIn, []string{"alice", "mallory"}) {
+		t.Errorf("MentionedIn = %v, want [alice mallory]", comment.MentionedIn)
+	}
+	if len(comment.Warnings) != 1 || !strings.Contains(comment.Warnings[0], "mallory") {
+		t.Errorf("Expected one warning about mallory, got %v", comment.Warnings)
+	}
+
+	w = api.request("GET", "/api/v1/boards/main/cards?mentioned=alice", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	var listResult PaginatedCardList
+	decodeJSON(t, w, &listResult)
+	if len(listResult.Cards) != 1 || listResult.Cards[0].ID != mentioned.ID {
+		t.Errorf("Expected only %s, got %v", mentioned.ID, listResult.Cards)
+	}
+}
+
+func TestHandler_CreateComment_TooLong(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+	card := createCardFromResponse(t, api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "Thread"}))
+
+	body := make([]byte, service.MaxCommentBodyBytes+1)
+	for i := range body {
+		body[i] = 'x'
+	}
+	w := api.request("POST", "/api/v1/boards/main/cards/"+card.ID+"/comments", map[string]any{"body": string(body)})
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", w.Code)
+	}
+}
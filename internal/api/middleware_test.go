@@ -0,0 +1,220 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	kanerr "github.com/amterp/kan/internal/errors"
+)
+
+func TestLoggingMiddleware_CardCreation(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+
+	var buf bytes.Buffer
+	logged := LoggingMiddleware(slog.New(slog.NewJSONHandler(&buf, nil)), api.mux)
+
+	req := httptest.NewRequest("POST", "/api/v1/boards/main/cards", strings.NewReader(`{"title": "Logged"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	logged.ServeHTTP(w, req)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Create failed with status %d", w.Code)
+	}
+
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("Expected one JSON log record, got %q: %v", buf.String(), err)
+	}
+	if record["method"] != "POST" || record["path"] != "/api/v1/boards/main/cards" {
+		t.Errorf("Unexpected method/path: %v %v", record["method"], record["path"])
+	}
+	if record["status"] != float64(http.StatusCreated) {
+		t.Errorf("status = %v, want %d", record["status"], http.StatusCreated)
+	}
+	if record["board"] != "main" {
+		t.Errorf("board = %v, want main", record["board"])
+	}
+	if d, ok := record["duration_ms"].(float64); !ok || d <= 0 {
+		t.Errorf("duration_ms = %v, want > 0", record["duration_ms"])
+	}
+	if record["bytes_written"] != float64(w.Body.Len()) {
+		t.Errorf("bytes_written = %v, want %d", record["bytes_written"], w.Body.Len())
+	}
+}
+
+func TestRequestBoard(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/api/v1/boards/main", "main"},
+		{"/api/v1/boards/main/columns", "main"},
+		{"/api/v1/boards", ""},
+		{"/api/v1/project", ""},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest("GET", tt.path, nil)
+		api.mux.ServeHTTP(httptest.NewRecorder(), req)
+		if got := requestBoard(req); got != tt.want {
+			t.Errorf("requestBoard(%s) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestRequestValidationMiddleware(t *testing.T) {
+	const limit = 16
+	echo := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("Handler failed to read body: %v", err)
+		}
+		w.Write(body)
+	})
+	validated := RequestValidationMiddleware(limit)(echo)
+
+	tests := []struct {
+		name        string
+		method      string
+		body        string
+		contentType string
+		wantStatus  int
+	}{
+		{"body at limit", "POST", strings.Repeat("a", limit), "application/json", http.StatusOK},
+		{"body over limit", "POST", strings.Repeat("a", limit+1), "application/json", http.StatusRequestEntityTooLarge},
+		{"json with charset", "PATCH", "{}", "application/json; charset=utf-8", http.StatusOK},
+		{"missing content type", "POST", "{}", "", http.StatusUnsupportedMediaType},
+		{"wrong content type", "PUT", "{}", "text/plain", http.StatusUnsupportedMediaType},
+		{"write without body", "POST", "", "", http.StatusOK},
+		{"get without content type", "GET", "", "", http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/", strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			w := httptest.NewRecorder()
+			validated.ServeHTTP(w, req)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d. Body: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if w.Code == http.StatusOK && w.Body.String() != tt.body {
+				t.Errorf("Expected the handler to see body %q, got %q", tt.body, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestUploadValidationMiddleware(t *testing.T) {
+	const limit = 16
+	var readErr error
+	echo := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if readErr = err; err != nil {
+			bodyReadError(w, err, limit)
+			return
+		}
+		w.Write(body)
+	})
+	validated := UploadValidationMiddleware(limit)(echo)
+
+	tests := []struct {
+		name        string
+		body        string
+		contentType string
+		chunked     bool
+		wantStatus  int
+	}{
+		{"body at limit", strings.Repeat("a", limit), "application/json", false, http.StatusOK},
+		{"declared length over limit", strings.Repeat("a", limit+1), "application/json", false, http.StatusRequestEntityTooLarge},
+		{"streamed body over limit", strings.Repeat("a", limit+1), "application/json", true, http.StatusRequestEntityTooLarge},
+		{"multipart", "--x--", "multipart/form-data; boundary=x", false, http.StatusOK},
+		{"ndjson", "{}\n", "application/x-ndjson", false, http.StatusOK},
+		{"wrong content type", "{}", "text/plain", false, http.StatusUnsupportedMediaType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/", strings.NewReader(tt.body))
+			if tt.chunked {
+				req.ContentLength = -1
+			}
+			req.Header.Set("Content-Type", tt.contentType)
+			w := httptest.NewRecorder()
+			validated.ServeHTTP(w, req)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d. Body: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.chunked && readErr == nil {
+				t.Error("Expected the handler's read to hit the limit")
+			}
+		})
+	}
+}
+
+func TestRequestValidationMiddleware_ErrorShape(t *testing.T) {
+	validated := RequestValidationMiddleware(4)(http.NotFoundHandler())
+	req := httptest.NewRequest("POST", "/", strings.NewReader(`{"a": 1}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	validated.ServeHTTP(w, req)
+
+	var resp map[string]string
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Expected a JSON error body, got %q", w.Body.String())
+	}
+	if w.Code != http.StatusRequestEntityTooLarge || resp["error"] != kanerr.RequestTooLarge(4).Error() {
+		t.Errorf("Expected the KanError message with 413, got %d %v", w.Code, resp)
+	}
+}
+
+func TestRequestValidationMiddleware_ImportTakesLargeBodies(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+	api.request("POST", "/api/v1/boards/main/cards", map[string]any{"title": "First"})
+
+	w := api.request("GET", "/api/v1/boards/main/export", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Export failed with status %d: %s", w.Code, w.Body.String())
+	}
+	// Pad the export past the default limit with JSON whitespace.
+	export := append(w.Body.Bytes(), bytes.Repeat([]byte(" "), int(DefaultMaxRequestBodyBytes))...)
+
+	req := httptest.NewRequest("POST", "/api/v1/boards/import?name=copy", bytes.NewReader(export))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	api.mux.ServeHTTP(w, req)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected an export over %d bytes to import, got %d: %s", DefaultMaxRequestBodyBytes, w.Code, w.Body.String())
+	}
+
+	// Other routes keep the default limit.
+	req = httptest.NewRequest("POST", "/api/v1/boards/main/cards", bytes.NewReader(export))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	api.mux.ServeHTTP(w, req)
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("Expected status 413 for a card over the limit, got %d", w.Code)
+	}
+}
+
+func TestRequestValidationMiddleware_AppliedToRoutes(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+
+	req := httptest.NewRequest("POST", "/api/v1/boards/main/cards", strings.NewReader(`{"title": "Untyped"}`))
+	w := httptest.NewRecorder()
+	api.mux.ServeHTTP(w, req)
+	if w.Code != http.StatusUnsupportedMediaType {
+		t.Errorf("Expected status 415, got %d. Body: %s", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,489 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/amterp/kan/internal/model"
+)
+
+// OpenAPISpec is an OpenAPI 3.0 document describing the /api/v1 routes.
+type OpenAPISpec struct {
+	OpenAPI    string                                  `json:"openapi"`
+	Info       OpenAPIInfo                             `json:"info"`
+	Paths      map[string]map[string]*OpenAPIOperation `json:"paths"`
+	Components OpenAPIComponents                       `json:"components"`
+}
+
+// OpenAPIInfo is the spec's info object.
+type OpenAPIInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// OpenAPIComponents holds the reusable schemas referenced by operations.
+type OpenAPIComponents struct {
+	Schemas map[string]*OpenAPISchema `json:"schemas"`
+}
+
+// OpenAPIOperation describes one method on one path.
+type OpenAPIOperation struct {
+	OperationID string                      `json:"operationId,omitempty"`
+	Summary     string                      `json:"summary,omitempty"`
+	Tags        []string                    `json:"tags,omitempty"`
+	Parameters  []OpenAPIParameter          `json:"parameters,omitempty"`
+	RequestBody *OpenAPIRequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*OpenAPIResponse `json:"responses"`
+}
+
+// OpenAPIParameter is a path or query parameter.
+type OpenAPIParameter struct {
+	Name        string         `json:"name"`
+	In          string         `json:"in"`
+	Required    bool           `json:"required"`
+	Description string         `json:"description,omitempty"`
+	Schema      *OpenAPISchema `json:"schema"`
+}
+
+// OpenAPIRequestBody describes an operation's request body.
+type OpenAPIRequestBody struct {
+	Required bool                        `json:"required"`
+	Content  map[string]OpenAPIMediaType `json:"content"`
+}
+
+// OpenAPIResponse describes one response status.
+type OpenAPIResponse struct {
+	Description string                      `json:"description"`
+	Content     map[string]OpenAPIMediaType `json:"content,omitempty"`
+}
+
+// OpenAPIMediaType pairs a content type with its schema.
+type OpenAPIMediaType struct {
+	Schema *OpenAPISchema `json:"schema"`
+}
+
+// OpenAPISchema is the subset of JSON Schema used by the spec.
+type OpenAPISchema struct {
+	Ref                  string                    `json:"$ref,omitempty"`
+	Type                 string                    `json:"type,omitempty"`
+	Format               string                    `json:"format,omitempty"`
+	Items                *OpenAPISchema            `json:"items,omitempty"`
+	Properties           map[string]*OpenAPISchema `json:"properties,omitempty"`
+	AdditionalProperties any                       `json:"additionalProperties,omitempty"`
+	Required             []string                  `json:"required,omitempty"`
+}
+
+// ErrorResponse is the JSON body of every error response.
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// routeDoc is the hand-authored part of an operation: what the router can't
+// tell us. Request and Response are zero values of the JSON types; their
+// schemas are derived by reflection.
+type routeDoc struct {
+	ID       string
+	Summary  string
+	Query    []OpenAPIParameter
+	Headers  []OpenAPIParameter
+	Request  any
+	BodyType string // Request content type; defaults to application/json
+	Status   int    // Success status; defaults to 200
+	Response any    // nil for an empty body
+	RespType string // Response content type; defaults to application/json
+}
+
+// Documentation-only shapes for responses built from maps or unexported types.
+type (
+	boardListResponse struct {
+		Boards []string `json:"boards"`
+	}
+	cardListResponse struct {
+		Cards []CardResponse `json:"cards"`
+	}
+	boardExportResponse struct {
+		Board *model.BoardConfig `json:"board"`
+		Cards []*model.Card      `json:"cards"`
+	}
+)
+
+func queryParam(name, typ, description string) OpenAPIParameter {
+	return OpenAPIParameter{Name: name, In: "query", Description: description, Schema: &OpenAPISchema{Type: typ}}
+}
+
+// ifRevisionHeader documents the optional If-Revision header accepted by
+// column changes.
+var ifRevisionHeader = []OpenAPIParameter{{
+	Name: revisionHeader, In: "header", Schema: &OpenAPISchema{Type: "integer"},
+	Description: "Board revision the change is based on; a mismatch returns 409 with current_revision",
+}}
+
+// routeDocs documents every /api/v1 route registered in RegisterRoutes,
+// keyed by its mux pattern.
+var routeDocs = map[string]routeDoc{
+	"GET /api/v1/openapi.json":         {ID: "getOpenAPISpec", Summary: "This OpenAPI document", Response: map[string]any{}},
+	"GET /api/v1/project":              {ID: "getProject", Summary: "Project metadata", Response: ProjectResponse{}},
+	"PATCH /api/v1/project":            {ID: "updateProject", Summary: "Update the project name or favicon", Request: UpdateProjectRequest{}, Response: ProjectResponse{}},
+	"GET /api/v1/migrate/snapshots":    {ID: "listMigrationSnapshots", Summary: "List pre-migration snapshots", Response: SnapshotListResponse{}},
+	"GET /api/v1/hook-tasks/{task_id}": {ID: "getHookTask", Summary: "Poll an async hook task", Response: HookTaskResponse{}},
+	"GET /api/v1/all-boards":           {ID: "listAllBoards", Summary: "List boards across all registered projects", Response: AllBoardsResponse{}},
+	"GET /api/v1/all-boards/search":    {ID: "searchAllBoards", Summary: "Search cards across all registered projects", Query: []OpenAPIParameter{queryParam("q", "string", "Search text")}, Response: AllBoardsSearchResponse{}},
+	"GET /api/v1/search":               {ID: "searchAll", Summary: "Search all projects with match snippets", Query: []OpenAPIParameter{queryParam("q", "string", "Search text"), queryParam("fields", "string", "Comma-separated fields to search")}, Response: GlobalSearchResponse{}},
+	"POST /api/v1/switch":              {ID: "switchProject", Summary: "Switch the active project", Request: SwitchProjectRequest{}, Response: SwitchProjectResponse{}},
+	"GET /api/v1/boards": {ID: "listBoards", Summary: "List boards",
+		Query: []OpenAPIParameter{queryParam("include_archived", "boolean", "Include archived boards")}, Response: boardListResponse{}},
+	"POST /api/v1/boards/{board}/archive":   {ID: "archiveBoard", Summary: "Archive a board, keeping its data", Response: model.BoardConfig{}},
+	"POST /api/v1/boards/{board}/unarchive": {ID: "unarchiveBoard", Summary: "Restore an archived board", Response: model.BoardConfig{}},
+	"GET /api/v1/boards/{name}":             {ID: "getBoard", Summary: "Get a board's configuration", Response: model.BoardConfig{}},
+	"DELETE /api/v1/boards/{name}":          {ID: "deleteBoard", Summary: "Delete a board and its cards", Response: DeleteBoardResponse{}},
+	"GET /api/v1/boards/{board}/export":     {ID: "exportBoard", Summary: "Export a board and its cards", Response: boardExportResponse{}},
+	"POST /api/v1/boards/import": {ID: "importBoard", Summary: "Import a board export",
+		Query: []OpenAPIParameter{queryParam("name", "string", "Import under this name")}, Request: boardExportResponse{}, Status: http.StatusCreated, Response: ImportBoardResponse{}},
+	"POST /api/v1/boards/import-trello": {ID: "importTrelloBoard", Summary: "Import a Trello JSON export",
+		Query: []OpenAPIParameter{queryParam("name", "string", "Board name")}, Request: map[string]any{}, Status: http.StatusCreated, Response: ImportBoardResponse{}},
+	"POST /api/v1/boards/{board}/duplicate":      {ID: "duplicateBoard", Summary: "Duplicate a board", Request: DuplicateBoardRequest{}, Status: http.StatusCreated, Response: ImportBoardResponse{}},
+	"POST /api/v1/boards/{board}/merge":          {ID: "mergeBoards", Summary: "Merge a board's cards into another board", Request: MergeBoardsRequest{}, Response: MergeBoardsResponse{}},
+	"PATCH /api/v1/boards/{board}/collaborators": {ID: "updateCollaborators", Summary: "Add and remove board collaborators", Request: UpdateCollaboratorsRequest{}, Response: UpdateCollaboratorsResponse{}},
+	"GET /api/v1/boards/{board}/audit": {ID: "getBoardAudit", Summary: "Recent audit entries, newest first",
+		Query: []OpenAPIParameter{queryParam("limit", "integer", "Maximum entries (default 50)")}, Response: AuditLogResponse{}},
+	"GET /api/v1/boards/{board}/hooks/history": {ID: "getHookHistory", Summary: "Recent hook executions, newest first",
+		Query: []OpenAPIParameter{queryParam("limit", "integer", "Maximum entries (default 100)")}, Response: HookHistoryResponse{}},
+	"POST /api/v1/boards/{board}/link-rules/preview": {ID: "previewLinkRules", Summary: "Show the URL the board's link rules would make of some text", Request: LinkRulePreviewRequest{}, Response: LinkRulePreviewResponse{}},
+	"GET /api/v1/boards/{board}/velocity": {ID: "getBoardVelocity", Summary: "Story points finished in a done column within a window",
+		Query: []OpenAPIParameter{queryParam("window_days", "integer", "Window length in days (default 14)"), queryParam("done_column", "string", "Done column (default: the board's first done column)")}, Response: VelocityResponse{}},
+	"GET /api/v1/boards/{board}/stats": {ID: "getBoardStats", Summary: "Board statistics",
+		Query: []OpenAPIParameter{queryParam("since", "integer", "Window start (epoch millis)"), queryParam("until", "integer", "Window end (epoch millis)")}, Response: BoardStatsResponse{}},
+	"GET /api/v1/boards/{board}/completion":    {ID: "getBoardCompletion", Summary: "Share of cards in done columns, per column and overall", Response: CompletionResponse{}},
+	"POST /api/v1/boards/{board}/auto-archive": {ID: "autoArchive", Summary: "Archive cards past their done column's auto-archive age", Response: AutoArchiveResponse{}},
+	"GET /api/v1/boards/{board}/events":        {ID: "streamBoardEvents", Summary: "Stream card change events", Response: BoardEvent{}, RespType: "text/event-stream"},
+	"GET /api/v1/boards/{board}/ws":            {ID: "boardSocket", Summary: "Open a WebSocket for card change events", Status: http.StatusSwitchingProtocols},
+
+	"GET /api/v1/boards/{board}/columns":                {ID: "listColumns", Summary: "Card counts and limit usage per column", Response: ColumnSummariesResponse{}},
+	"POST /api/v1/boards/{board}/columns":               {ID: "createColumn", Summary: "Add a column", Headers: ifRevisionHeader, Request: CreateColumnRequest{}, Status: http.StatusCreated, Response: CreateColumnResponse{}},
+	"GET /api/v1/boards/{board}/columns/{name}/cards":   {ID: "listColumnCards", Summary: "List the cards in one column, in order", Response: ColumnCardsResponse{}},
+	"DELETE /api/v1/boards/{board}/columns/{name}":      {ID: "deleteColumn", Summary: "Delete a column and its cards", Headers: ifRevisionHeader, Response: DeleteColumnResponse{}},
+	"PATCH /api/v1/boards/{board}/columns/{name}":       {ID: "updateColumn", Summary: "Update a column", Headers: ifRevisionHeader, Request: UpdateColumnRequest{}, Response: model.Column{}},
+	"PUT /api/v1/boards/{board}/columns/order":          {ID: "reorderColumns", Summary: "Reorder columns", Headers: ifRevisionHeader, Request: ReorderColumnsRequest{}, Response: model.BoardConfig{}},
+	"PUT /api/v1/boards/{board}/columns/{column}/order": {ID: "reorderColumnCards", Summary: "Reorder the cards in a column", Request: ReorderColumnCardsRequest{}, Response: cardListResponse{}},
+
+	"GET /api/v1/boards/{board}/sprint/active": {ID: "getActiveSprint", Summary: "Get the column running the current sprint and its cards", Response: SprintResponse{}},
+	"POST /api/v1/boards/{board}/sprint/start": {ID: "startSprint", Summary: "Start a sprint in a column", Request: StartSprintRequest{}, Response: model.Column{}},
+
+	"GET /api/v1/boards/{board}/cards": {ID: "listCards", Summary: "List cards",
+		Query: []OpenAPIParameter{
+			queryParam("column", "string", "Only cards in this column"),
+			queryParam("include_archived", "boolean", "Include archived cards"),
+			queryParam("overdue", "boolean", "Only cards past their due date"),
+			queryParam("has_incomplete_checklist", "boolean", "Only cards with an unchecked checklist item"),
+			queryParam("stale", "boolean", "Only cards not updated within the board's stale_days"),
+			queryParam("has_field", "string", "Only cards with a value for this custom field"),
+			queryParam("missing_field", "string", "Only cards without a value for this custom field"),
+			queryParam("mentioned", "string", "Only cards with a comment mentioning this user"),
+			queryParam("page", "integer", "Page number (default 1)"),
+			queryParam("per_page", "integer", "Cards per page (default 50)"),
+			queryParam("sort", "string", "Comma-separated sort fields"),
+		}, Response: PaginatedCardList{}},
+	"POST /api/v1/boards/{board}/cards": {ID: "createCard", Summary: "Create a card",
+		Query: []OpenAPIParameter{queryParam("async_hooks", "boolean", "Run pattern hooks in the background")}, Request: CreateCardRequest{}, Status: http.StatusCreated, Response: CreateCardResponse{}},
+	"GET /api/v1/boards/{board}/cards/{id}":                {ID: "getCard", Summary: "Get a card", Response: CardResponse{}},
+	"PUT /api/v1/boards/{board}/cards/{id}":                {ID: "updateCard", Summary: "Update a card", Request: UpdateCardRequest{}, Response: CardResponse{}},
+	"DELETE /api/v1/boards/{board}/cards":                  {ID: "deleteCards", Summary: "Delete several cards", Request: DeleteCardsRequest{}, Response: DeleteCardsResponse{}},
+	"DELETE /api/v1/boards/{board}/cards/{id}":             {ID: "deleteCard", Summary: "Delete a card", Query: []OpenAPIParameter{queryParam("parent_behavior", "string", "What happens to child cards: clear (default), delete, or reparent")}, Status: http.StatusNoContent},
+	"PATCH /api/v1/boards/{board}/cards/{id}/move":         {ID: "moveCard", Summary: "Move a card", Request: MoveCardRequest{}, Response: CardResponse{}},
+	"PATCH /api/v1/boards/{board}/cards/bulk-move":         {ID: "bulkMoveCards", Summary: "Move several cards", Request: BulkMoveCardsRequest{}, Response: cardListResponse{}},
+	"POST /api/v1/boards/{board}/cards/fetch":              {ID: "fetchCards", Summary: "Look up several cards by ID or alias", Request: FetchCardsRequest{}, Response: FetchCardsResponse{}},
+	"POST /api/v1/boards/{board}/cards/restore":            {ID: "restoreCard", Summary: "Restore a deleted card", Request: RestoreCardRequest{}, Status: http.StatusCreated, Response: CardResponse{}},
+	"POST /api/v1/boards/{board}/cards/import-csv":         {ID: "importCardsCSV", Summary: "Import cards from CSV", BodyType: "multipart/form-data", Response: ImportCSVResponse{}},
+	"GET /api/v1/boards/{board}/cards.jsonl":               {ID: "exportCardsJSONL", Summary: "Stream a board's cards as newline-delimited JSON", Response: model.Card{}, RespType: "application/x-ndjson"},
+	"POST /api/v1/boards/{board}/cards/import-jsonl":       {ID: "importCardsJSONL", Summary: "Create or update cards from newline-delimited JSON", BodyType: "application/x-ndjson", Response: ImportJSONLResponse{}},
+	"POST /api/v1/boards/{board}/cards/from-template":      {ID: "createCardFromTemplate", Summary: "Create a card from a card template", Request: CreateCardFromTemplateRequest{}, Status: http.StatusCreated, Response: CreateCardResponse{}},
+	"POST /api/v1/boards/{board}/cards/{id}/archive":       {ID: "archiveCard", Summary: "Archive a card", Response: CardResponse{}},
+	"POST /api/v1/boards/{board}/cards/{id}/unarchive":     {ID: "unarchiveCard", Summary: "Unarchive a card", Response: CardResponse{}},
+	"POST /api/v1/boards/{board}/cards/{id}/copy-fields":   {ID: "copyCardFields", Summary: "Copy fields from another card", Request: CopyCardFieldsRequest{}, Response: CardResponse{}},
+	"POST /api/v1/boards/{board}/cards/{id}/transfer":      {ID: "transferCard", Summary: "Move a card to another board, keeping its ID", Request: TransferCardRequest{}, Response: TransferCardResponse{}},
+	"POST /api/v1/boards/{board}/cards/{id}/clone":         {ID: "cloneCard", Summary: "Clone a card", Request: CloneCardRequest{}, Status: http.StatusCreated, Response: CardResponse{}},
+	"GET /api/v1/boards/{board}/cards/{id}/blocks":         {ID: "getCardBlocks", Summary: "Cards this card blocks", Response: cardListResponse{}},
+	"GET /api/v1/boards/{board}/cards/{id}/blocked-by":     {ID: "getCardBlockedBy", Summary: "Cards blocking this card", Response: cardListResponse{}},
+	"GET /api/v1/boards/{board}/cards/{id}/suggest-column": {ID: "suggestColumn", Summary: "Suggest a column for a card", Response: SuggestColumnResponse{}},
+	"GET /api/v1/boards/{board}/cards/suggest-tags":        {ID: "suggestTags", Summary: "Suggest labels or tags for a card title", Query: []OpenAPIParameter{queryParam("title", "string", "Card title")}, Response: SuggestTagsResponse{}},
+	"POST /api/v1/boards/{board}/cards/{id}/lock":          {ID: "lockCard", Summary: "Lock a card for editing", Response: CardLockResponse{}},
+	"DELETE /api/v1/boards/{board}/cards/{id}/lock":        {ID: "unlockCard", Summary: "Release a card lock", Status: http.StatusNoContent},
+	"POST /api/v1/boards/{board}/search":                   {ID: "searchCards", Summary: "Search a board", Request: SearchRequest{}, Response: SearchResponse{}},
+
+	"GET /api/v1/boards/{board}/cards/{id}/comments":               {ID: "listComments", Summary: "List a card's comments", Response: CommentsResponse{}},
+	"POST /api/v1/boards/{board}/cards/{id}/comments":              {ID: "createComment", Summary: "Add a comment", Request: CreateCommentRequest{}, Status: http.StatusCreated, Response: CommentResponse{}},
+	"PATCH /api/v1/boards/{board}/cards/{id}/comments/{cid}":       {ID: "editComment", Summary: "Edit a comment", Request: EditCommentRequest{}, Response: CommentResponse{}},
+	"DELETE /api/v1/boards/{board}/cards/{id}/comments/{cid}":      {ID: "deleteComment", Summary: "Delete a comment", Status: http.StatusNoContent},
+	"POST /api/v1/boards/{board}/cards/{id}/checklist":             {ID: "addChecklistItem", Summary: "Add a checklist item", Request: AddChecklistItemRequest{}, Status: http.StatusCreated, Response: model.ChecklistItem{}},
+	"PATCH /api/v1/boards/{board}/cards/{id}/checklist/{item_id}":  {ID: "toggleChecklistItem", Summary: "Check or uncheck a checklist item", Request: ToggleChecklistItemRequest{}, Response: CardResponse{}},
+	"DELETE /api/v1/boards/{board}/cards/{id}/checklist/{item_id}": {ID: "deleteChecklistItem", Summary: "Delete a checklist item", Status: http.StatusNoContent},
+}
+
+// routeRecorder registers handlers on a mux and remembers their patterns, so
+// the OpenAPI spec is built from the routes actually served.
+type routeRecorder struct {
+	mux        *http.ServeMux
+	wrap       func(http.Handler) http.Handler // Applied to every route but uploads
+	wrapUpload func(http.Handler) http.Handler // Applied to upload routes
+	patterns   []string
+}
+
+func (r *routeRecorder) Handle(pattern string, handler http.Handler) {
+	r.patterns = append(r.patterns, pattern)
+	r.mux.Handle(pattern, r.wrap(handler))
+}
+
+func (r *routeRecorder) HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request)) {
+	r.Handle(pattern, http.HandlerFunc(handler))
+}
+
+// HandleUploadFunc registers a route that takes a large request body, such
+// as an import.
+func (r *routeRecorder) HandleUploadFunc(pattern string, handler func(http.ResponseWriter, *http.Request)) {
+	r.patterns = append(r.patterns, pattern)
+	r.mux.Handle(pattern, r.wrapUpload(http.HandlerFunc(handler)))
+}
+
+// GetOpenAPISpec returns the OpenAPI spec for the registered routes.
+func (h *Handler) GetOpenAPISpec(w http.ResponseWriter, r *http.Request) {
+	JSON(w, http.StatusOK, BuildOpenAPISpec(h.routes))
+}
+
+// GenerateOpenAPISpec builds the spec without a running server, for
+// `kan serve --dump-openapi`.
+func GenerateOpenAPISpec() *OpenAPISpec {
+	h := NewHandler(nil, nil)
+	h.RegisterRoutes(http.NewServeMux())
+	return BuildOpenAPISpec(h.routes)
+}
+
+var pathParamRegex = regexp.MustCompile(`\{([^}.]+)(?:\.\.\.)?\}`)
+
+// BuildOpenAPISpec builds an OpenAPI 3.0 document from mux patterns such as
+// "GET /api/v1/boards/{board}". Only /api/ routes are included; routes with
+// no routeDoc entry still get their path parameters and a generic response.
+func BuildOpenAPISpec(patterns []string) *OpenAPISpec {
+	b := &schemaBuilder{schemas: map[string]*OpenAPISchema{}, names: map[reflect.Type]string{}}
+	spec := &OpenAPISpec{
+		OpenAPI: "3.0.3",
+		Info:    OpenAPIInfo{Title: "Kan API", Version: "v1"},
+		Paths:   map[string]map[string]*OpenAPIOperation{},
+	}
+	errorSchema := b.schemaFor(reflect.TypeOf(ErrorResponse{}))
+
+	sorted := append([]string(nil), patterns...)
+	sort.Strings(sorted)
+	for _, pattern := range sorted {
+		method, path, ok := strings.Cut(pattern, " ")
+		if !ok || !strings.HasPrefix(path, "/api/") {
+			continue
+		}
+		doc := routeDocs[pattern]
+
+		op := &OpenAPIOperation{
+			OperationID: doc.ID,
+			Summary:     doc.Summary,
+			Tags:        []string{routeTag(path)},
+			Responses:   map[string]*OpenAPIResponse{},
+		}
+		for _, m := range pathParamRegex.FindAllStringSubmatch(path, -1) {
+			op.Parameters = append(op.Parameters, OpenAPIParameter{
+				Name: m[1], In: "path", Required: true, Schema: &OpenAPISchema{Type: "string"},
+			})
+		}
+		op.Parameters = append(op.Parameters, doc.Query...)
+		op.Parameters = append(op.Parameters, doc.Headers...)
+
+		if doc.Request != nil || doc.BodyType != "" {
+			bodyType := doc.BodyType
+			if bodyType == "" {
+				bodyType = "application/json"
+			}
+			schema := &OpenAPISchema{Type: "object"}
+			if doc.Request != nil {
+				schema = b.schemaFor(reflect.TypeOf(doc.Request))
+			}
+			op.RequestBody = &OpenAPIRequestBody{
+				Required: true,
+				Content:  map[string]OpenAPIMediaType{bodyType: {Schema: schema}},
+			}
+		}
+
+		status := doc.Status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		resp := &OpenAPIResponse{Description: http.StatusText(status)}
+		if doc.Response != nil {
+			respType := doc.RespType
+			if respType == "" {
+				respType = "application/json"
+			}
+			resp.Content = map[string]OpenAPIMediaType{respType: {Schema: b.schemaFor(reflect.TypeOf(doc.Response))}}
+		}
+		op.Responses[strconv.Itoa(status)] = resp
+		op.Responses["default"] = &OpenAPIResponse{
+			Description: "Error",
+			Content:     map[string]OpenAPIMediaType{"application/json": {Schema: errorSchema}},
+		}
+
+		specPath := pathParamRegex.ReplaceAllString(path, "{$1}")
+		if spec.Paths[specPath] == nil {
+			spec.Paths[specPath] = map[string]*OpenAPIOperation{}
+		}
+		spec.Paths[specPath][strings.ToLower(method)] = op
+	}
+
+	spec.Components.Schemas = b.schemas
+	return spec
+}
+
+// routeTag groups an operation by the resource its path ends in.
+func routeTag(path string) string {
+	switch {
+	case strings.Contains(path, "/comments"), strings.Contains(path, "/checklist"):
+		return "comments"
+	case strings.Contains(path, "/cards"), strings.HasSuffix(path, "/search") && strings.Contains(path, "/boards/"):
+		return "cards"
+	case strings.Contains(path, "/columns"):
+		return "columns"
+	case strings.HasPrefix(path, "/api/v1/boards"):
+		return "boards"
+	default:
+		return "project"
+	}
+}
+
+// schemaBuilder derives JSON schemas from Go types via reflection. Named
+// structs become components referenced by $ref.
+type schemaBuilder struct {
+	schemas map[string]*OpenAPISchema
+	names   map[reflect.Type]string
+}
+
+var (
+	timeType      = reflect.TypeOf(time.Time{})
+	marshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+func (b *schemaBuilder) schemaFor(t reflect.Type) *OpenAPISchema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType:
+		return &OpenAPISchema{Type: "string", Format: "date-time"}
+	case t.Kind() == reflect.Struct && t.Name() != "":
+		return &OpenAPISchema{Ref: "#/components/schemas/" + b.component(t)}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &OpenAPISchema{Type: "string"}
+	case reflect.Bool:
+		return &OpenAPISchema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &OpenAPISchema{Type: "integer"}
+	case reflect.Int64, reflect.Uint64:
+		return &OpenAPISchema{Type: "integer", Format: "int64"}
+	case reflect.Float32, reflect.Float64:
+		return &OpenAPISchema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &OpenAPISchema{Type: "string", Format: "byte"}
+		}
+		return &OpenAPISchema{Type: "array", Items: b.schemaFor(t.Elem())}
+	case reflect.Map:
+		if t.Elem().Kind() == reflect.Interface {
+			return &OpenAPISchema{Type: "object", AdditionalProperties: true}
+		}
+		return &OpenAPISchema{Type: "object", AdditionalProperties: b.schemaFor(t.Elem())}
+	case reflect.Struct:
+		return b.structSchema(t)
+	default:
+		// Interfaces and anything else accept any JSON value.
+		return &OpenAPISchema{}
+	}
+}
+
+// component registers a named struct's schema and returns its component name.
+// The name is reserved before the fields are walked so recursive types terminate.
+func (b *schemaBuilder) component(t reflect.Type) string {
+	if name, ok := b.names[t]; ok {
+		return name
+	}
+	name := t.Name()
+	if _, taken := b.schemas[name]; taken {
+		name = pathBase(t.PkgPath()) + "." + name
+	}
+	b.names[t] = name
+	b.schemas[name] = &OpenAPISchema{}
+	*b.schemas[name] = *b.structSchema(t)
+	return name
+}
+
+func pathBase(pkgPath string) string {
+	if i := strings.LastIndex(pkgPath, "/"); i >= 0 {
+		return pkgPath[i+1:]
+	}
+	return pkgPath
+}
+
+// structSchema follows encoding/json's rules: json tags name properties,
+// "-" hides them, omitempty makes them optional, and embedded structs are
+// flattened. Types with a custom MarshalJSON (e.g. CardResponse, which
+// flattens custom fields) also allow additional properties.
+func (b *schemaBuilder) structSchema(t reflect.Type) *OpenAPISchema {
+	schema := &OpenAPISchema{Type: "object", Properties: map[string]*OpenAPISchema{}}
+	if t.Implements(marshalerType) || reflect.PointerTo(t).Implements(marshalerType) {
+		schema.AdditionalProperties = true
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() && !f.Anonymous {
+			continue
+		}
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				embedded := b.structSchema(ft)
+				for k, v := range embedded.Properties {
+					schema.Properties[k] = v
+				}
+				schema.Required = append(schema.Required, embedded.Required...)
+				continue
+			}
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+		schema.Properties[name] = b.schemaFor(f.Type)
+		if !strings.Contains(opts, "omitempty") {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+
+	// An outer field may shadow an embedded one of the same name.
+	sort.Strings(schema.Required)
+	schema.Required = slices.Compact(schema.Required)
+	return schema
+}
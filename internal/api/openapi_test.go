@@ -0,0 +1,133 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestHandler_GetOpenAPISpec(t *testing.T) {
+	api := setupTestAPI(t)
+
+	req := httptest.NewRequest("GET", "/api/v1/openapi.json", nil)
+	w := httptest.NewRecorder()
+	api.mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", w.Code, w.Body.String())
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal(w.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("response is not valid JSON: %v", err)
+	}
+	if v, _ := doc["openapi"].(string); !strings.HasPrefix(v, "3.0.") {
+		t.Errorf("openapi = %q, want 3.0.x", v)
+	}
+	info, _ := doc["info"].(map[string]any)
+	if info["title"] == "" || info["version"] == "" {
+		t.Errorf("info must have title and version, got %v", info)
+	}
+	paths, _ := doc["paths"].(map[string]any)
+	if len(paths) == 0 {
+		t.Fatal("paths is empty")
+	}
+
+	for path, method := range map[string]string{
+		"/api/v1/boards":                                   "get",
+		"/api/v1/boards/{board}/cards":                     "post",
+		"/api/v1/boards/{board}/cards/{id}":                "put",
+		"/api/v1/boards/{board}/columns/{name}":            "patch",
+		"/api/v1/boards/{board}/cards/{id}/comments/{cid}": "delete",
+	} {
+		ops, _ := paths[path].(map[string]any)
+		op, ok := ops[method].(map[string]any)
+		if !ok {
+			t.Errorf("missing %s %s", method, path)
+			continue
+		}
+		if _, ok := op["responses"].(map[string]any); !ok {
+			t.Errorf("%s %s has no responses", method, path)
+		}
+	}
+
+	// Every $ref must point at a defined component schema.
+	schemas := doc["components"].(map[string]any)["schemas"].(map[string]any)
+	for _, m := range regexp.MustCompile(`"\$ref":"#/components/schemas/([^"]+)"`).FindAllStringSubmatch(w.Body.String(), -1) {
+		if _, ok := schemas[m[1]]; !ok {
+			t.Errorf("dangling $ref to %q", m[1])
+		}
+	}
+}
+
+func TestOpenAPISpec_DescribesEveryRoute(t *testing.T) {
+	spec := GenerateOpenAPISpec()
+
+	h := NewHandler(nil, nil)
+	h.RegisterRoutes(http.NewServeMux())
+	for _, pattern := range h.routes {
+		method, path, _ := strings.Cut(pattern, " ")
+		if !strings.HasPrefix(path, "/api/") {
+			continue
+		}
+		if _, ok := routeDocs[pattern]; !ok {
+			t.Errorf("route %q has no routeDocs entry", pattern)
+		}
+		if spec.Paths[path][strings.ToLower(method)] == nil {
+			t.Errorf("route %q missing from spec", pattern)
+		}
+	}
+	for pattern := range routeDocs {
+		found := false
+		for _, registered := range h.routes {
+			found = found || registered == pattern
+		}
+		if !found {
+			t.Errorf("routeDocs entry %q matches no registered route", pattern)
+		}
+	}
+}
+
+func TestOpenAPISpec_Parameters(t *testing.T) {
+	spec := GenerateOpenAPISpec()
+
+	op := spec.Paths["/api/v1/boards/{board}/cards/{id}/checklist/{item_id}"]["patch"]
+	if op == nil {
+		t.Fatal("missing checklist toggle operation")
+	}
+	var pathParams []string
+	for _, p := range op.Parameters {
+		if p.In == "path" {
+			if !p.Required {
+				t.Errorf("path parameter %q must be required", p.Name)
+			}
+			pathParams = append(pathParams, p.Name)
+		}
+	}
+	if strings.Join(pathParams, ",") != "board,id,item_id" {
+		t.Errorf("path params = %v, want board,id,item_id", pathParams)
+	}
+	if op.RequestBody == nil || op.RequestBody.Content["application/json"].Schema == nil {
+		t.Error("expected a JSON request body")
+	}
+
+	list := spec.Paths["/api/v1/boards/{board}/cards"]["get"]
+	var hasPage bool
+	for _, p := range list.Parameters {
+		hasPage = hasPage || (p.In == "query" && p.Name == "page" && p.Schema.Type == "integer")
+	}
+	if !hasPage {
+		t.Error("list cards should document the page query parameter")
+	}
+
+	card := spec.Components.Schemas["CardResponse"]
+	if card == nil || card.Properties["title"] == nil || card.AdditionalProperties != true {
+		t.Errorf("CardResponse schema should have title and allow custom fields, got %+v", card)
+	}
+	if _, ok := card.Properties["CustomFields"]; ok {
+		t.Error(`fields tagged json:"-" should be omitted`)
+	}
+}
@@ -22,21 +22,25 @@ func Error(w http.ResponseWriter, err error) {
 	status := http.StatusInternalServerError
 	message := err.Error()
 
-	var notFound *kanerr.NotFoundError
+	var kanErr *kanerr.KanError
 	var notInit *kanerr.NotInitializedError
-	var alreadyExists *kanerr.AlreadyExistsError
-	var validation *kanerr.ValidationError
+	var bulk *kanerr.BulkOperationError
+
+	// Bulk failures carry the offending IDs so clients can point at them.
+	if errors.As(err, &bulk) {
+		JSON(w, http.StatusUnprocessableEntity, map[string]any{
+			"error":      message,
+			"failed_ids": bulk.FailedIDs,
+		})
+		return
+	}
 
 	switch {
-	case errors.As(err, &notFound):
-		status = http.StatusNotFound
+	case errors.As(err, &kanErr):
+		status = kanErr.HTTPStatus
 	case errors.As(err, &notInit):
 		status = http.StatusNotFound
 		message = "Kan is not initialized in this repository"
-	case errors.As(err, &alreadyExists):
-		status = http.StatusConflict
-	case errors.As(err, &validation):
-		status = http.StatusBadRequest
 	}
 
 	JSON(w, status, map[string]string{"error": message})
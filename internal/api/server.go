@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"log/slog"
 	"net/http"
 	"sync"
 	"time"
@@ -12,14 +13,22 @@ import (
 // Server wraps the HTTP server for the web frontend.
 type Server struct {
 	httpServer *http.Server
+	handler    *Handler
 	watcher    *FileWatcher
 	wsHub      *WebSocketHub
 	watcherMu  sync.Mutex // Protects watcher during project switches
+	stopCh     chan struct{}
+	stopOnce   sync.Once
 }
 
+// autoArchiveInterval is how often the server applies done columns'
+// auto-archive policies.
+const autoArchiveInterval = time.Hour
+
 // NewServer creates a new server with the given handler, port, and kan root.
 // kanRoot is the resolved .kan/ directory path. If empty, file watching is disabled.
-func NewServer(handler *Handler, port int, kanRoot string) *Server {
+// Requests are logged to accessLog; if nil, access logging is disabled.
+func NewServer(handler *Handler, port int, kanRoot string, accessLog *slog.Logger) *Server {
 	mux := http.NewServeMux()
 	handler.RegisterRoutes(mux)
 
@@ -37,10 +46,14 @@ func NewServer(handler *Handler, port int, kanRoot string) *Server {
 			log.Printf("Warning: failed to create file watcher: %v", err)
 		} else {
 			watcher.Subscribe(wsHub)
+			watcher.Subscribe(handler)
 		}
 	}
 
-	wrapped := Logging(Cors(mux))
+	wrapped := Cors(mux)
+	if accessLog != nil {
+		wrapped = LoggingMiddleware(accessLog, wrapped)
+	}
 
 	s := &Server{
 		httpServer: &http.Server{
@@ -49,8 +62,10 @@ func NewServer(handler *Handler, port int, kanRoot string) *Server {
 			ReadTimeout:  15 * time.Second,
 			WriteTimeout: 15 * time.Second,
 		},
+		handler: handler,
 		watcher: watcher,
 		wsHub:   wsHub,
+		stopCh:  make(chan struct{}),
 	}
 
 	// Set up callback to switch watcher when project changes
@@ -68,11 +83,16 @@ func (s *Server) Start() error {
 		}
 	}
 
+	go s.runAutoArchive()
+	go s.handler.locks.RunEviction(s.stopCh)
+
 	return s.httpServer.ListenAndServe()
 }
 
 // Shutdown gracefully stops the server.
 func (s *Server) Shutdown(ctx context.Context) error {
+	s.stopOnce.Do(func() { close(s.stopCh) })
+
 	// Stop file watcher
 	if s.watcher != nil {
 		s.watcher.Stop()
@@ -112,6 +132,7 @@ func (s *Server) switchWatcher(newKanRoot string) {
 	}
 
 	watcher.Subscribe(s.wsHub)
+	watcher.Subscribe(s.handler)
 	if err := watcher.Start(); err != nil {
 		log.Printf("Warning: failed to start file watcher: %v", err)
 		return
@@ -120,3 +141,37 @@ func (s *Server) switchWatcher(newKanRoot string) {
 	s.watcher = watcher
 	log.Printf("File watcher switched to: %s", newKanRoot)
 }
+
+// runAutoArchive applies auto-archive policies on every board of the active
+// project, once at startup and then every autoArchiveInterval, until Shutdown.
+func (s *Server) runAutoArchive() {
+	ticker := time.NewTicker(autoArchiveInterval)
+	defer ticker.Stop()
+
+	for {
+		s.autoArchiveAll()
+		select {
+		case <-ticker.C:
+		case <-s.stopCh:
+			return
+		}
+	}
+}
+
+func (s *Server) autoArchiveAll() {
+	ctx := s.handler.ctx()
+	boards, err := ctx.BoardService.List()
+	if err != nil {
+		log.Printf("Warning: auto-archive failed to list boards: %v", err)
+		return
+	}
+	for _, board := range boards {
+		archived, err := s.handler.autoArchive(board)
+		if err != nil {
+			log.Printf("Warning: auto-archive failed for board %q: %v", board, err)
+		}
+		if archived > 0 {
+			log.Printf("Auto-archived %d card(s) on board %q", archived, board)
+		}
+	}
+}
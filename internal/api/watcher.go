@@ -40,6 +40,10 @@ type FileChange struct {
 	Path      string         `json:"path"`                 // Relative path from .kan/
 }
 
+// fileChangeDebounce is how long a path must stay quiet before its change is
+// emitted. Editors often save in several writes; this coalesces them.
+const fileChangeDebounce = 200 * time.Millisecond
+
 // FileWatcherSubscriber receives file change notifications.
 type FileWatcherSubscriber interface {
 	OnFileChange(change FileChange)
@@ -191,12 +195,12 @@ func (fw *FileWatcher) handleEvent(event fsnotify.Event) {
 		}
 	}
 
-	// Debounce: wait 100ms before emitting to coalesce rapid changes
+	// Debounce: wait for the path to settle before emitting
 	fw.debounceMu.Lock()
 	if timer, exists := fw.debounce[event.Name]; exists {
 		timer.Stop()
 	}
-	fw.debounce[event.Name] = time.AfterFunc(100*time.Millisecond, func() {
+	fw.debounce[event.Name] = time.AfterFunc(fileChangeDebounce, func() {
 		fw.emitChange(event)
 		fw.debounceMu.Lock()
 		delete(fw.debounce, event.Name)
@@ -0,0 +1,197 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	boardSocketPongWait   = 60 * time.Second
+	boardSocketPingPeriod = 30 * time.Second
+	boardSocketWriteWait  = 10 * time.Second
+)
+
+// BoardWebSocketHub relays board events to WebSocket clients of that board.
+// It carries the same events as the SSE stream (see BoardEventBus), as
+// {"type": "card_created", "data": {...}} messages, and answers
+// {"type": "ping"} with {"type": "pong"}.
+type BoardWebSocketHub struct {
+	mu     sync.Mutex
+	boards map[string]map[*boardSocket]struct{}
+}
+
+// boardSocket is one client connection. send is closed (under the hub's
+// lock) when the client is unregistered, which stops its writer.
+type boardSocket struct {
+	conn *websocket.Conn
+	send chan []byte
+}
+
+// NewBoardWebSocketHub creates an empty hub.
+func NewBoardWebSocketHub() *BoardWebSocketHub {
+	return &BoardWebSocketHub{boards: make(map[string]map[*boardSocket]struct{})}
+}
+
+// Register adds an upgraded connection as a client of the board and starts
+// serving it. The client is unregistered and the connection closed when the
+// client disconnects.
+func (h *BoardWebSocketHub) Register(boardName string, conn *websocket.Conn) {
+	s := &boardSocket{conn: conn, send: make(chan []byte, 64)}
+
+	h.mu.Lock()
+	if h.boards[boardName] == nil {
+		h.boards[boardName] = make(map[*boardSocket]struct{})
+	}
+	h.boards[boardName][s] = struct{}{}
+	h.mu.Unlock()
+
+	go s.writePump()
+	go h.readPump(boardName, s)
+}
+
+// Broadcast sends msg, encoded as JSON, to every client of the board. A
+// client whose buffer is full is disconnected rather than blocking.
+func (h *BoardWebSocketHub) Broadcast(boardName string, msg any) {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		log.Printf("Failed to marshal board socket message: %v", err)
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for s := range h.boards[boardName] {
+		select {
+		case s.send <- data:
+		default:
+			h.removeLocked(boardName, s)
+		}
+	}
+}
+
+// ClientCount returns the number of connected clients for a board.
+func (h *BoardWebSocketHub) ClientCount(boardName string) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.boards[boardName])
+}
+
+// reply sends msg to a single client, if it's still registered.
+func (h *BoardWebSocketHub) reply(boardName string, s *boardSocket, msg any) {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.boards[boardName][s]; !ok {
+		return
+	}
+	select {
+	case s.send <- data:
+	default:
+		h.removeLocked(boardName, s)
+	}
+}
+
+func (h *BoardWebSocketHub) unregister(boardName string, s *boardSocket) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.removeLocked(boardName, s)
+}
+
+// removeLocked drops a client and closes its send channel. Callers hold mu.
+func (h *BoardWebSocketHub) removeLocked(boardName string, s *boardSocket) {
+	clients := h.boards[boardName]
+	if _, ok := clients[s]; !ok {
+		return
+	}
+	delete(clients, s)
+	close(s.send)
+	if len(clients) == 0 {
+		delete(h.boards, boardName)
+	}
+}
+
+// readPump handles client messages until the connection fails or closes.
+func (h *BoardWebSocketHub) readPump(boardName string, s *boardSocket) {
+	defer h.unregister(boardName, s)
+
+	s.conn.SetReadLimit(512)
+	s.conn.SetReadDeadline(time.Now().Add(boardSocketPongWait))
+	s.conn.SetPongHandler(func(string) error {
+		s.conn.SetReadDeadline(time.Now().Add(boardSocketPongWait))
+		return nil
+	})
+
+	for {
+		var msg WebSocketMessage
+		if err := s.conn.ReadJSON(&msg); err != nil {
+			var syntaxErr *json.SyntaxError
+			if errors.As(err, &syntaxErr) {
+				continue // not JSON; ignore it
+			}
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseNormalClosure, websocket.CloseAbnormalClosure) {
+				log.Printf("Board socket read error: %v", err)
+			}
+			return
+		}
+		s.conn.SetReadDeadline(time.Now().Add(boardSocketPongWait))
+		if msg.Type == "ping" {
+			h.reply(boardName, s, WebSocketMessage{Type: "pong"})
+		}
+	}
+}
+
+// writePump writes queued messages and keepalive pings, and closes the
+// connection once send is closed or a write fails.
+func (s *boardSocket) writePump() {
+	ticker := time.NewTicker(boardSocketPingPeriod)
+	defer func() {
+		ticker.Stop()
+		s.conn.Close()
+	}()
+
+	for {
+		select {
+		case message, ok := <-s.send:
+			s.conn.SetWriteDeadline(time.Now().Add(boardSocketWriteWait))
+			if !ok {
+				s.conn.WriteMessage(websocket.CloseMessage, []byte{})
+				return
+			}
+			if err := s.conn.WriteMessage(websocket.TextMessage, message); err != nil {
+				return
+			}
+		case <-ticker.C:
+			s.conn.SetWriteDeadline(time.Now().Add(boardSocketWriteWait))
+			if err := s.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// ServeBoardSocket upgrades the request to a WebSocket that receives the
+// board's events.
+func (h *Handler) ServeBoardSocket(w http.ResponseWriter, r *http.Request) {
+	boardName := r.PathValue("board")
+	if _, err := h.ctx().BoardStore.Get(boardName); err != nil {
+		Error(w, err)
+		return
+	}
+
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Printf("Board socket upgrade failed: %v", err)
+		return
+	}
+	h.sockets.Register(boardName, conn)
+}
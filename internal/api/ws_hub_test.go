@@ -0,0 +1,104 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// dialBoardSocket opens a board WebSocket and waits until the hub has
+// registered it, using a ping/pong round trip.
+func dialBoardSocket(t *testing.T, server *httptest.Server, board string) *websocket.Conn {
+	t.Helper()
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/api/v1/boards/" + board + "/ws"
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("Failed to dial board socket: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	if err := conn.WriteJSON(WebSocketMessage{Type: "ping"}); err != nil {
+		t.Fatalf("Failed to send ping: %v", err)
+	}
+	if msg := nextSocketMessage(t, conn); msg.Type != "pong" {
+		t.Fatalf("Expected pong, got %q", msg.Type)
+	}
+	return conn
+}
+
+type socketMessage struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+func nextSocketMessage(t *testing.T, conn *websocket.Conn) socketMessage {
+	t.Helper()
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	var msg socketMessage
+	if err := conn.ReadJSON(&msg); err != nil {
+		t.Fatalf("Failed to read socket message: %v", err)
+	}
+	return msg
+}
+
+func TestHandler_BoardSocket_CardCreated(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+	server := httptest.NewServer(api.mux)
+	t.Cleanup(server.Close)
+
+	conn := dialBoardSocket(t, server, "main")
+
+	resp := postJSON(t, "POST", server.URL+"/api/v1/boards/main/cards", map[string]any{"title": "Pushed"})
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("Create failed with status %d", resp.StatusCode)
+	}
+
+	msg := nextSocketMessage(t, conn)
+	if msg.Type != EventCardCreated {
+		t.Fatalf("Expected %s, got %s", EventCardCreated, msg.Type)
+	}
+	var event BoardEvent
+	if err := json.Unmarshal(msg.Data, &event); err != nil {
+		t.Fatalf("Failed to decode event: %v", err)
+	}
+	if event.CardID == "" || event.Column != "backlog" {
+		t.Errorf("Unexpected created event: %+v", event)
+	}
+}
+
+func TestHandler_BoardSocket_UnregistersOnDisconnect(t *testing.T) {
+	api := setupTestAPI(t)
+	api.createBoard(t, "main")
+	server := httptest.NewServer(api.mux)
+	t.Cleanup(server.Close)
+
+	conn := dialBoardSocket(t, server, "main")
+	if api.handler.sockets.ClientCount("main") != 1 {
+		t.Fatal("Expected client to be registered")
+	}
+
+	conn.Close()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for api.handler.sockets.ClientCount("main") != 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("Client was not removed after disconnect")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestHandler_BoardSocket_BoardNotFound(t *testing.T) {
+	api := setupTestAPI(t)
+
+	w := api.request("GET", "/api/v1/boards/missing/ws", nil)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404, got %d", w.Code)
+	}
+}
@@ -76,12 +76,24 @@ func registerAdd(parent *ra.Cmd, ctx *CommandContext) {
 		SetUsage("Set custom field (key=value, repeatable; set fields also accept comma-separated values)").
 		Register(cmd)
 
+	ctx.AddLabels, _ = ra.NewStringSlice("label").
+		SetOptional(true).
+		SetFlagOnly(true).
+		SetUsage("Add a label (repeatable); creates the board's labels field if missing").
+		Register(cmd)
+
 	ctx.AddStrict, _ = ra.NewBool("strict").
 		SetOptional(true).
 		SetFlagOnly(true).
 		SetUsage("Error if wanted fields are missing (default: warn)").
 		Register(cmd)
 
+	ctx.AddForce, _ = ra.NewBool("force").
+		SetOptional(true).
+		SetFlagOnly(true).
+		SetUsage("Add even if the target column is at its limit").
+		Register(cmd)
+
 	ctx.AddGlobal = registerGlobalFlag(cmd)
 
 	ctx.AddUsed, _ = parent.RegisterCmd(cmd)
@@ -136,7 +148,22 @@ func resolvePlacement(app *App, boardName, excludeID string, p cardPlacement) (p
 	return position, beforeID, afterID, nil
 }
 
-func runAdd(title, description, board, column string, parentCard string, placement cardPlacement, fields []string, strict, global, nonInteractive, jsonOutput bool) {
+// parseLabels returns the labels given with --label, or nil if the flag wasn't
+// used. Blank values are dropped, so --label "" means "no labels".
+func parseLabels(configured bool, raw []string) *[]string {
+	if !configured {
+		return nil
+	}
+	labels := []string{}
+	for _, l := range raw {
+		if l = strings.TrimSpace(l); l != "" {
+			labels = append(labels, l)
+		}
+	}
+	return &labels
+}
+
+func runAdd(title, description, board, column string, parentCard string, placement cardPlacement, fields []string, labels *[]string, strict, force, global, nonInteractive, jsonOutput bool) {
 	app, err := NewAppWithOptions(AppOptions{Interactive: !nonInteractive, UseGlobalBoard: global})
 	if err != nil {
 		Fatal(err)
@@ -201,9 +228,11 @@ func runAdd(title, description, board, column string, parentCard string, placeme
 		Parent:       parentCard,
 		Creator:      creatorName,
 		CustomFields: customFields,
+		Labels:       labels,
 		Position:     position,
 		BeforeCard:   beforeID,
 		AfterCard:    afterID,
+		Force:        force,
 	}
 
 	card, hookResults, err := app.CardService.Add(input)
@@ -303,7 +332,7 @@ func printMissingWantedWarnings(missing []service.MissingWantedField) {
 					mf.FieldName, mf.FieldType, strings.Join(values, ", "))
 			}
 		} else {
-			// No options (string, date, free-set, boolean)
+			// No options (string, date, free-set, boolean, integer)
 			if mf.Description != "" {
 				fmt.Fprintf(os.Stderr, "  - %s (%s): %s\n", mf.FieldName, mf.FieldType, mf.Description)
 			} else {
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 
 	"github.com/amterp/kan/internal/config"
 	"github.com/amterp/kan/internal/creator"
@@ -23,21 +24,24 @@ import (
 // App holds all the dependencies for the CLI.
 // Uses interfaces for testability.
 type App struct {
-	GitClient     *git.Client
-	GlobalStore   store.GlobalStore
-	ProjectStore  store.ProjectStore
-	Paths         *config.Paths
-	BoardStore    store.BoardStore
-	CardStore     store.CardStore
-	Prompter      prompt.Prompter
-	InitService   *service.InitService
-	BoardService  *service.BoardService
-	CardService   *service.CardService
-	AliasService  *service.AliasService
-	HookService   *service.HookService
-	BoardResolver *resolver.BoardResolver
-	CardResolver  *resolver.CardResolver
-	ProjectRoot   string
+	GitClient        *git.Client
+	GlobalStore      store.GlobalStore
+	ProjectStore     store.ProjectStore
+	Paths            *config.Paths
+	BoardStore       store.BoardStore
+	CardStore        store.CardStore
+	AuditStore       store.AuditStore
+	HookHistoryStore store.HookHistoryStore
+	Prompter         prompt.Prompter
+	InitService      *service.InitService
+	BoardService     *service.BoardService
+	CardService      *service.CardService
+	SearchService    *service.SearchService
+	AliasService     *service.AliasService
+	HookService      *service.HookService
+	BoardResolver    *resolver.BoardResolver
+	CardResolver     *resolver.CardResolver
+	ProjectRoot      string
 	// UsingGlobalBoard is true when the App was built via -g (targeting the
 	// designated global board). Handlers use it to surface the target in output.
 	UsingGlobalBoard bool
@@ -152,6 +156,8 @@ func NewAppWithOptions(opts AppOptions) (*App, error) {
 	boardStore := store.NewBoardStore(paths)
 	cardStore := store.NewCardStore(paths)
 	projectStore := store.NewProjectStore(paths)
+	auditStore := store.NewAuditStore(paths)
+	hookHistoryStore := store.NewHookHistoryStore(paths)
 
 	// In global mode, fail early with a clear message if the designation has gone
 	// stale (project moved/deleted, or board removed) rather than surfacing a
@@ -176,21 +182,34 @@ func NewAppWithOptions(opts AppOptions) (*App, error) {
 		prompter = &prompt.NoopPrompter{}
 	}
 
-	aliasService := service.NewAliasService(cardStore)
+	aliasService := service.NewAliasService(cardStore, boardStore)
 	initService := service.NewInitService(globalStore)
 	boardService := service.NewBoardService(boardStore, cardStore)
+	boardService.SetGlobalStore(globalStore)
 	cardService := service.NewCardService(cardStore, boardStore, aliasService)
+	boardService.SetCardService(cardService)
+	searchService := service.NewSearchService(cardStore, boardStore)
 	boardResolver := resolver.NewBoardResolver(boardStore, globalStore, prompter, projectRoot)
 	if opts.UseGlobalBoard {
 		boardResolver.SetPreferredBoard(globalBoardName)
 	}
+	if strategy := os.Getenv("KAN_BOARD_FALLBACK"); strategy != "" {
+		if err := boardResolver.SetFallbackStrategy(strategy); err != nil {
+			return nil, fmt.Errorf("invalid KAN_BOARD_FALLBACK: %w", err)
+		}
+	}
 	cardResolver := resolver.NewCardResolver(cardStore)
 
 	// Set up hook service if we have a project root
 	var hookService *service.HookService
 	if projectRoot != "" {
 		hookService = service.NewHookService(projectRoot)
+		hookService.SetHistoryStore(hookHistoryStore)
 		cardService.SetHookService(hookService)
+		cardService.SetAuditStore(auditStore, sync.OnceValue(func() string {
+			author, _ := creator.GetAuthor(gitClient)
+			return author
+		}))
 	}
 
 	return &App{
@@ -200,10 +219,13 @@ func NewAppWithOptions(opts AppOptions) (*App, error) {
 		Paths:            paths,
 		BoardStore:       boardStore,
 		CardStore:        cardStore,
+		AuditStore:       auditStore,
+		HookHistoryStore: hookHistoryStore,
 		Prompter:         prompter,
 		InitService:      initService,
 		BoardService:     boardService,
 		CardService:      cardService,
+		SearchService:    searchService,
 		AliasService:     aliasService,
 		HookService:      hookService,
 		BoardResolver:    boardResolver,
@@ -282,7 +304,7 @@ func autoMigrateGlobal() error {
 		return err
 	}
 
-	if err := svc.Execute(plan, false); err != nil {
+	if _, err := svc.Execute(plan, false); err != nil {
 		return fmt.Errorf("auto-migration of global config failed: %w", err)
 	}
 
@@ -308,11 +330,15 @@ func autoMigrateProject(paths *config.Paths) error {
 		return err
 	}
 
-	if err := svc.Execute(plan, false); err != nil {
+	result, err := svc.Execute(plan, false)
+	if err != nil {
 		return fmt.Errorf("auto-migration failed: %w", err)
 	}
 
 	PrintInfo("Auto-migrated project data to current schema.")
+	if result.SnapshotID != "" {
+		fmt.Fprintln(os.Stderr, RenderMuted("  Undo with: kan migrate --rollback "+result.SnapshotID))
+	}
 	fmt.Fprintln(os.Stderr, RenderMuted("  Tip: commit the migrated files separately."))
 	return nil
 }
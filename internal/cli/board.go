@@ -2,11 +2,17 @@ package cli
 
 import (
 	"fmt"
-	"sort"
+	"os"
 	"strings"
+	"time"
+	"unicode/utf8"
 
+	"github.com/amterp/kan/internal/export"
 	"github.com/amterp/kan/internal/model"
+	"github.com/amterp/kan/internal/service"
+	"github.com/amterp/kan/internal/util"
 	"github.com/amterp/ra"
+	"golang.org/x/term"
 )
 
 func registerBoard(parent *ra.Cmd, ctx *CommandContext) {
@@ -18,7 +24,14 @@ func registerBoard(parent *ra.Cmd, ctx *CommandContext) {
 	createCmd.SetDescription("Create a new board")
 
 	ctx.BoardCreateName, _ = ra.NewString("name").
-		SetUsage("Name of the board to create").
+		SetOptional(true).
+		SetUsage("Name of the board to create (prompted for with --interactive)").
+		Register(createCmd)
+
+	ctx.BoardCreateInteractive, _ = ra.NewBool("interactive").
+		SetOptional(true).
+		SetFlagOnly(true).
+		SetUsage("Pick a template and extra columns in a step-by-step wizard").
 		Register(createCmd)
 
 	ctx.BoardCreateUsed, _ = cmd.RegisterCmd(createCmd)
@@ -47,6 +60,12 @@ func registerBoard(parent *ra.Cmd, ctx *CommandContext) {
 	listCmd := ra.NewCmd("list")
 	listCmd.SetDescription("List all boards")
 
+	ctx.BoardListArchived, _ = ra.NewBool("include-archived").
+		SetOptional(true).
+		SetFlagOnly(true).
+		SetUsage("Also list archived boards").
+		Register(listCmd)
+
 	ctx.BoardListUsed, _ = cmd.RegisterCmd(listCmd)
 
 	// board delete
@@ -60,11 +79,144 @@ func registerBoard(parent *ra.Cmd, ctx *CommandContext) {
 
 	ctx.BoardDeleteUsed, _ = cmd.RegisterCmd(deleteCmd)
 
+	// board archive
+	archiveCmd := ra.NewCmd("archive")
+	archiveCmd.SetDescription("Archive a board, hiding it but keeping its cards")
+
+	ctx.BoardArchiveName, _ = ra.NewString("name").
+		SetUsage("Name of the board to archive").
+		SetCompletionFunc(completeBoards).
+		Register(archiveCmd)
+
+	ctx.BoardArchiveUsed, _ = cmd.RegisterCmd(archiveCmd)
+
+	// board unarchive
+	unarchiveCmd := ra.NewCmd("unarchive")
+	unarchiveCmd.SetDescription("Restore an archived board")
+
+	ctx.BoardUnarchiveName, _ = ra.NewString("name").
+		SetUsage("Name of the board to restore").
+		Register(unarchiveCmd)
+
+	ctx.BoardUnarchiveUsed, _ = cmd.RegisterCmd(unarchiveCmd)
+
+	// board rename
+	renameCmd := ra.NewCmd("rename")
+	renameCmd.SetDescription("Rename a board")
+
+	ctx.BoardRenameOld, _ = ra.NewString("old").
+		SetUsage("Current board name (or the new name, when renaming the --board or resolved board)").
+		SetCompletionFunc(completeBoards).
+		Register(renameCmd)
+
+	ctx.BoardRenameNew, _ = ra.NewString("new").
+		SetOptional(true).
+		SetUsage("New board name").
+		Register(renameCmd)
+
+	ctx.BoardRenameBoard, _ = ra.NewString("board").
+		SetShort("b").
+		SetOptional(true).
+		SetFlagOnly(true).
+		SetUsage("Board to rename, when only the new name is given").
+		SetCompletionFunc(completeBoards).
+		Register(renameCmd)
+
+	ctx.BoardRenameUsed, _ = cmd.RegisterCmd(renameCmd)
+
+	// board export
+	exportCmd := ra.NewCmd("export")
+	exportCmd.SetDescription("Export a board and its cards as JSON or CSV (to stdout)")
+
+	ctx.BoardExportBoard, _ = ra.NewString("board").
+		SetShort("b").
+		SetOptional(true).
+		SetFlagOnly(true).
+		SetUsage("Board to export (defaults to resolved board)").
+		SetCompletionFunc(completeBoards).
+		Register(exportCmd)
+
+	ctx.BoardExportFormat, _ = ra.NewString("format").
+		SetDefault("json").
+		SetFlagOnly(true).
+		SetEnumConstraint([]string{"json", "csv"}).
+		SetUsage("Output format: json (re-importable) or csv (for spreadsheets)").
+		Register(exportCmd)
+
+	ctx.BoardExportUsed, _ = cmd.RegisterCmd(exportCmd)
+
+	// board import
+	importCmd := ra.NewCmd("import")
+	importCmd.SetDescription("Create a board from a 'kan board export' JSON file")
+
+	ctx.BoardImportFile, _ = ra.NewString("file").
+		SetUsage("Path to the export JSON file").
+		Register(importCmd)
+
+	ctx.BoardImportName, _ = ra.NewString("name").
+		SetShort("n").
+		SetOptional(true).
+		SetFlagOnly(true).
+		SetUsage("Name for the imported board (defaults to the exported name)").
+		Register(importCmd)
+
+	ctx.BoardImportUsed, _ = cmd.RegisterCmd(importCmd)
+
+	// board import-trello
+	importTrelloCmd := ra.NewCmd("import-trello")
+	importTrelloCmd.SetDescription("Create a board from a Trello board JSON export")
+
+	ctx.BoardImportTrelloFile, _ = ra.NewString("file").
+		SetUsage("Path to the Trello export JSON file").
+		Register(importTrelloCmd)
+
+	ctx.BoardImportTrelloName, _ = ra.NewString("name").
+		SetShort("n").
+		SetOptional(true).
+		SetFlagOnly(true).
+		SetUsage("Name for the imported board (defaults to the Trello board name)").
+		Register(importTrelloCmd)
+
+	ctx.BoardImportTrelloUsed, _ = cmd.RegisterCmd(importTrelloCmd)
+
+	// board stats
+	statsCmd := ra.NewCmd("stats")
+	statsCmd.SetDescription("Show how many cards each column holds, how many are done and the throughput")
+
+	ctx.BoardStatsBoard, _ = ra.NewString("board").
+		SetShort("b").
+		SetOptional(true).
+		SetFlagOnly(true).
+		SetUsage("Target board").
+		SetCompletionFunc(completeBoards).
+		Register(statsCmd)
+
+	ctx.BoardStatsSince, _ = ra.NewString("since").
+		SetOptional(true).
+		SetFlagOnly(true).
+		SetUsage("Start of the throughput window, as YYYY-MM-DD or RFC 3339 (default: 30 days before --until)").
+		Register(statsCmd)
+
+	ctx.BoardStatsUntil, _ = ra.NewString("until").
+		SetOptional(true).
+		SetFlagOnly(true).
+		SetUsage("End of the throughput window, as YYYY-MM-DD or RFC 3339 (default: now)").
+		Register(statsCmd)
+
+	ctx.BoardStatsUsed, _ = cmd.RegisterCmd(statsCmd)
+
 	ctx.BoardUsed, _ = parent.RegisterCmd(cmd)
 }
 
-func runBoardCreate(name string) {
-	app, err := NewApp(true)
+func runBoardCreate(name string, interactive, nonInteractive bool) {
+	if interactive && (nonInteractive || !stdinIsTerminal()) {
+		Fatal(fmt.Errorf("--interactive needs a terminal"))
+	}
+	if !interactive && name == "" {
+		Fatal(fmt.Errorf("board name is required (or use --interactive)"))
+	}
+
+	app, err := NewApp(!nonInteractive)
 	if err != nil {
 		Fatal(err)
 	}
@@ -73,14 +225,40 @@ func runBoardCreate(name string) {
 		Fatal(err)
 	}
 
-	if err := app.BoardService.Create(name); err != nil {
+	if !interactive {
+		applied, err := app.BoardService.Create(name)
+		if err != nil {
+			Fatal(err)
+		}
+		PrintSuccess("Created board %q", name)
+		printAppliedDefaults(applied)
+		return
+	}
+
+	cfg, err := runBoardCreateWizard(app.Prompter, name)
+	if err != nil {
 		Fatal(err)
 	}
+	applied, err := app.BoardService.CreateWithConfig(cfg)
+	if err != nil {
+		Fatal(err)
+	}
+	PrintSuccess("Created board %q with %d columns", cfg.Name, len(cfg.Columns))
+	printAppliedDefaults(applied)
+}
 
-	PrintSuccess("Created board %q", name)
+// printAppliedDefaults summarizes what a new board took from the global
+// config's defaults (see 'kan config list-defaults').
+func printAppliedDefaults(applied *service.AppliedDefaults) {
+	if applied.Columns {
+		PrintInfo("Used the default columns from the global config")
+	}
+	if len(applied.Fields) > 0 {
+		PrintInfo("Applied default fields from the global config: %s", strings.Join(applied.Fields, ", "))
+	}
 }
 
-func runBoardList(jsonOutput bool) {
+func runBoardList(includeArchived, jsonOutput bool) {
 	app, err := NewApp(true)
 	if err != nil {
 		Fatal(err)
@@ -90,7 +268,11 @@ func runBoardList(jsonOutput bool) {
 		Fatal(err)
 	}
 
-	boards, err := app.BoardService.List()
+	list := app.BoardService.List
+	if includeArchived {
+		list = app.BoardService.ListAll
+	}
+	boards, err := list()
 	if err != nil {
 		Fatal(err)
 	}
@@ -109,7 +291,13 @@ func runBoardList(jsonOutput bool) {
 
 	fmt.Println(RenderMuted("Boards:"))
 	for _, board := range boards {
-		fmt.Printf("  %s %s\n", RenderMuted("•"), board)
+		suffix := ""
+		if includeArchived {
+			if cfg, err := app.BoardService.Get(board); err == nil && cfg.IsArchived() {
+				suffix = " " + RenderMuted("(archived)")
+			}
+		}
+		fmt.Printf("  %s %s%s\n", RenderMuted("•"), board, suffix)
 	}
 }
 
@@ -174,11 +362,19 @@ func printBoardDescribeJson(cfg *model.BoardConfig, cardCounts map[string]int) {
 			Name:          cfg.Name,
 			Schema:        cfg.KanSchema,
 			DefaultColumn: cfg.DefaultColumn,
+			DoneColumns:   cfg.DoneColumns,
 			Columns:       columns,
 			CustomFields:  cfg.CustomFields,
 			CardDisplay:   cfg.CardDisplay,
+			Alias:         cfg.Alias,
 			LinkRules:     cfg.LinkRules,
 			PatternHooks:  cfg.PatternHooks,
+			Stale:         cfg.Stale,
+			Templates:     cfg.Templates,
+			Collaborators: cfg.Collaborators,
+			Includes:      cfg.Includes,
+
+			ArchivedAtMillis: cfg.ArchivedAtMillis,
 		},
 	}
 
@@ -202,15 +398,18 @@ func printBoardDescribeHuman(cfg *model.BoardConfig, cardCounts map[string]int)
 			cardWord = "card"
 		}
 		swatch := ColorSwatch(col.Color)
-		defaultTag := ""
+		tags := ""
 		if col.Name == cfg.DefaultColumn {
-			defaultTag = ", default"
+			tags = ", default"
+		}
+		if cfg.IsDoneColumn(col.Name) {
+			tags += ", done"
 		}
 		limitStr := ""
 		if col.Limit > 0 {
 			limitStr = fmt.Sprintf("/%d", col.Limit)
 		}
-		count := RenderMuted(fmt.Sprintf("(%d%s %s%s)", n, limitStr, cardWord, defaultTag))
+		count := RenderMuted(fmt.Sprintf("(%d%s %s%s)", n, limitStr, cardWord, tags))
 		fmt.Printf("  %-17s %s %s\n", col.Name, swatch, count)
 		if col.Description != "" {
 			fmt.Printf("    %s\n", col.Description)
@@ -222,16 +421,9 @@ func printBoardDescribeHuman(cfg *model.BoardConfig, cardCounts map[string]int)
 		fmt.Println()
 		fmt.Println("Custom Fields:")
 
-		// Sort field names for stable output
-		fieldNames := make([]string, 0, len(cfg.CustomFields))
-		for name := range cfg.CustomFields {
-			fieldNames = append(fieldNames, name)
-		}
-		sort.Strings(fieldNames)
-
-		for _, name := range fieldNames {
-			schema := cfg.CustomFields[name]
-			attrs := []string{schema.Type}
+		for _, field := range cfg.OrderedCustomFields() {
+			name, schema := field.Name, field.Schema
+			attrs := []string{schema.FieldType()}
 			if schema.Wanted {
 				attrs = append(attrs, "wanted")
 			}
@@ -245,9 +437,19 @@ func printBoardDescribeHuman(cfg *model.BoardConfig, cardCounts map[string]int)
 		}
 	}
 
+	if len(cfg.Collaborators) > 0 {
+		fmt.Println()
+		fmt.Printf("Collaborators: %s\n", strings.Join(cfg.Collaborators, ", "))
+	}
+
+	if len(cfg.Includes) > 0 {
+		fmt.Println()
+		fmt.Printf("Includes: %s\n", strings.Join(cfg.Includes, ", "))
+	}
+
 	// Card Display
 	cd := cfg.CardDisplay
-	if cd.TypeIndicator != "" || cd.Tint != "" || len(cd.Badges) > 0 || len(cd.Metadata) > 0 || cd.DefaultSort != "" {
+	if cd.TypeIndicator != "" || cd.Tint != "" || len(cd.Badges) > 0 || len(cd.Tags) > 0 || len(cd.Metadata) > 0 || cd.DefaultSort != "" {
 		fmt.Println()
 		fmt.Println("Card Display:")
 		if cd.TypeIndicator != "" {
@@ -259,6 +461,9 @@ func printBoardDescribeHuman(cfg *model.BoardConfig, cardCounts map[string]int)
 		if len(cd.Badges) > 0 {
 			fmt.Printf("  Badges: %s\n", strings.Join(cd.Badges, ", "))
 		}
+		if len(cd.Tags) > 0 {
+			fmt.Printf("  Tags: %s\n", strings.Join(cd.Tags, ", "))
+		}
 		if len(cd.Metadata) > 0 {
 			fmt.Printf("  Metadata: %s\n", strings.Join(cd.Metadata, ", "))
 		}
@@ -366,3 +571,385 @@ func runBoardDelete(name string, nonInteractive bool) {
 		PrintSuccess("Deleted board %q", name)
 	}
 }
+
+func runBoardArchive(name string) {
+	app, err := NewApp(false)
+	if err != nil {
+		Fatal(err)
+	}
+
+	if err := app.RequireKan(); err != nil {
+		Fatal(err)
+	}
+
+	if err := app.BoardService.ArchiveBoard(name); err != nil {
+		Fatal(err)
+	}
+	PrintSuccess("Archived board %q", name)
+	PrintInfo("Restore it with 'kan board unarchive %s'", name)
+}
+
+func runBoardRename(oldName, newName, board string, nonInteractive bool) {
+	app, err := NewApp(!nonInteractive)
+	if err != nil {
+		Fatal(err)
+	}
+
+	if err := app.RequireKan(); err != nil {
+		Fatal(err)
+	}
+
+	// With a single name, it's the new name and the board comes from --board
+	// (or the usual resolution, prompting if several boards exist).
+	if newName == "" {
+		newName = oldName
+		oldName, err = app.BoardResolver.Resolve(board, !nonInteractive)
+		if err != nil {
+			Fatal(err)
+		}
+	} else if board != "" && board != oldName {
+		Fatal(fmt.Errorf("--board %q conflicts with board name %q", board, oldName))
+	}
+
+	if err := app.BoardService.RenameBoard(oldName, newName); err != nil {
+		Fatal(err)
+	}
+
+	// Best-effort: point default_board and the global board at the new name.
+	globalCfg, loadErr := app.GlobalStore.Load()
+	if loadErr == nil && app.ProjectRoot != "" {
+		dirty := false
+		if repoCfg := globalCfg.GetRepoConfig(app.ProjectRoot); repoCfg != nil && repoCfg.DefaultBoard == oldName {
+			repoCfg.DefaultBoard = newName
+			globalCfg.SetRepoConfig(app.ProjectRoot, *repoCfg)
+			dirty = true
+		}
+		if gb := globalCfg.GlobalBoard; gb != nil && gb.Path == app.ProjectRoot && gb.Board == oldName {
+			gb.Board = newName
+			dirty = true
+		}
+		if dirty {
+			_ = app.GlobalStore.Save(globalCfg)
+		}
+	}
+
+	PrintSuccess("Renamed board %q to %q", oldName, newName)
+}
+
+func runBoardUnarchive(name string) {
+	app, err := NewApp(false)
+	if err != nil {
+		Fatal(err)
+	}
+
+	if err := app.RequireKan(); err != nil {
+		Fatal(err)
+	}
+
+	if err := app.BoardService.UnarchiveBoard(name); err != nil {
+		Fatal(err)
+	}
+	PrintSuccess("Restored board %q", name)
+}
+
+func runBoardExport(board, format string, nonInteractive bool) {
+	app, err := NewApp(!nonInteractive)
+	if err != nil {
+		Fatal(err)
+	}
+
+	if err := app.RequireKan(); err != nil {
+		Fatal(err)
+	}
+
+	boardName, err := app.BoardResolver.Resolve(board, !nonInteractive)
+	if err != nil {
+		Fatal(err)
+	}
+
+	doc, err := app.BoardService.Export(boardName)
+	if err != nil {
+		Fatal(err)
+	}
+
+	if format == "csv" {
+		if err := export.ExportBoardCSV(doc.Board, doc.Cards, os.Stdout); err != nil {
+			Fatal(err)
+		}
+		return
+	}
+
+	if err := printJson(doc); err != nil {
+		Fatal(err)
+	}
+}
+
+// Bounds on the width, in cells, of the bars `kan board stats` draws. Within
+// them, bars stretch to fill the terminal.
+const (
+	minStatsBarWidth = 10
+	maxStatsBarWidth = 60
+)
+
+// boardStatsOutput is the --json shape for `kan board stats`.
+type boardStatsOutput struct {
+	Board         string                 `json:"board"`
+	ColumnStats   []boardStatsColumnInfo `json:"column_stats"`
+	TotalCards    int                    `json:"total_cards"`
+	TotalDone     int                    `json:"total_done"`
+	CompletionPct float64                `json:"completion_pct"`
+	SinceMillis   int64                  `json:"since_millis"`
+	UntilMillis   int64                  `json:"until_millis"`
+	// Throughput is the cards finished per day between since and until.
+	Throughput float64 `json:"throughput"`
+}
+
+type boardStatsColumnInfo struct {
+	Name          string  `json:"name"`
+	CardCount     int     `json:"card_count"`
+	DoneCount     int     `json:"done_count"`
+	CompletionPct float64 `json:"completion_pct"`
+	// Limit and LimitPct show WIP limit utilization, for columns with a limit.
+	Limit    int             `json:"limit,omitempty"`
+	LimitPct float64         `json:"limit_pct,omitempty"`
+	Oldest   *boardStatsCard `json:"oldest,omitempty"`
+	Newest   *boardStatsCard `json:"newest,omitempty"`
+}
+
+type boardStatsCard struct {
+	Alias           string `json:"alias"`
+	Title           string `json:"title"`
+	CreatedAtMillis int64  `json:"created_at_millis"`
+}
+
+func newBoardStatsCard(card *model.Card) *boardStatsCard {
+	if card == nil {
+		return nil
+	}
+	return &boardStatsCard{Alias: card.Alias, Title: card.Title, CreatedAtMillis: card.CreatedAtMillis}
+}
+
+// buildBoardStats combines a board's completion and window statistics into
+// the shape `kan board stats` prints, in the completion stats' column order.
+// The three are read separately, so columns are matched by name: one added
+// or removed in between is shown without the missing details.
+func buildBoardStats(boardCfg *model.BoardConfig, completion *service.CompletionStats, stats *service.BoardStats) boardStatsOutput {
+	out := boardStatsOutput{
+		Board:         boardCfg.Name,
+		ColumnStats:   make([]boardStatsColumnInfo, len(completion.ColumnStats)),
+		TotalCards:    completion.TotalCards,
+		TotalDone:     completion.TotalDone,
+		CompletionPct: completion.CompletionPct,
+		SinceMillis:   stats.SinceMillis,
+		UntilMillis:   stats.UntilMillis,
+		Throughput:    stats.ThroughputPerDay,
+	}
+	windowCols := make(map[string]service.ColumnStats, len(stats.Columns))
+	for _, col := range stats.Columns {
+		windowCols[col.Name] = col
+	}
+	for i, col := range completion.ColumnStats {
+		info := boardStatsColumnInfo{
+			Name:          col.Name,
+			CardCount:     col.CardCount,
+			DoneCount:     col.DoneCount,
+			CompletionPct: col.CompletionPct,
+			Oldest:        newBoardStatsCard(windowCols[col.Name].Oldest),
+			Newest:        newBoardStatsCard(windowCols[col.Name].Newest),
+		}
+		if cfgCol := boardCfg.GetColumn(col.Name); cfgCol != nil && cfgCol.Limit > 0 {
+			info.Limit = cfgCol.Limit
+			info.LimitPct = float64(col.CardCount) / float64(cfgCol.Limit) * 100
+		}
+		out.ColumnStats[i] = info
+	}
+	return out
+}
+
+func runBoardStats(board, since, until string, nonInteractive, jsonOutput bool) {
+	var sinceMillis, untilMillis int64
+	var err error
+	if since != "" {
+		if sinceMillis, err = parseFilterDate(since, time.Local); err != nil {
+			Fatal(fmt.Errorf("invalid --since: %w", err))
+		}
+	}
+	if until != "" {
+		if untilMillis, err = parseFilterDate(until, time.Local); err != nil {
+			Fatal(fmt.Errorf("invalid --until: %w", err))
+		}
+	}
+
+	app, err := NewApp(!nonInteractive)
+	if err != nil {
+		Fatal(err)
+	}
+
+	if err := app.RequireKan(); err != nil {
+		Fatal(err)
+	}
+
+	boardName, err := app.BoardResolver.Resolve(board, !nonInteractive)
+	if err != nil {
+		Fatal(err)
+	}
+
+	boardCfg, err := app.BoardService.Get(boardName)
+	if err != nil {
+		Fatal(err)
+	}
+	completion, err := app.BoardService.CompletionStats(boardName)
+	if err != nil {
+		Fatal(err)
+	}
+	stats, err := app.BoardService.Statistics(boardName, sinceMillis, untilMillis)
+	if err != nil {
+		Fatal(err)
+	}
+	out := buildBoardStats(boardCfg, completion, stats)
+
+	if jsonOutput {
+		if err := printJson(out); err != nil {
+			Fatal(err)
+		}
+		return
+	}
+
+	nameWidth := len("throughput")
+	maxCount := 0
+	labels := make([]string, len(out.ColumnStats))
+	labelWidth := 0
+	for i, col := range out.ColumnStats {
+		nameWidth = max(nameWidth, len(col.Name))
+		maxCount = max(maxCount, col.CardCount)
+
+		cardWord := "cards"
+		if col.CardCount == 1 {
+			cardWord = "card"
+		}
+		labels[i] = fmt.Sprintf("%d %s", col.CardCount, cardWord)
+		if col.Limit > 0 {
+			labels[i] += fmt.Sprintf(", %d/%d WIP (%.0f%%)", col.CardCount, col.Limit, col.LimitPct)
+		}
+		if boardCfg.IsDoneColumn(col.Name) {
+			labels[i] += ", done"
+		}
+		labelWidth = max(labelWidth, len(labels[i]))
+	}
+	barWidth := min(max(terminalWidth()-nameWidth-labelWidth-4, minStatsBarWidth), maxStatsBarWidth)
+
+	// Column bars are scaled to the fullest column; done columns are drawn
+	// in the success color.
+	for i, col := range out.ColumnStats {
+		bar := statsBar(col.CardCount, maxCount, barWidth)
+		if boardCfg.IsDoneColumn(col.Name) {
+			bar = StyleSuccess.Render(bar)
+		}
+		fmt.Printf("%-*s  %s  %s\n", nameWidth, col.Name, bar, RenderMuted(labels[i]))
+	}
+
+	fmt.Println()
+	fmt.Printf("%-*s  %s  %s\n", nameWidth, "complete",
+		StyleSuccess.Render(statsBar(out.TotalDone, out.TotalCards, barWidth)),
+		fmt.Sprintf("%d/%d (%.0f%%)", out.TotalDone, out.TotalCards, out.CompletionPct))
+	fmt.Printf("%-*s  %.2f cards/day %s\n", nameWidth, "throughput", out.Throughput,
+		RenderMuted(fmt.Sprintf("(%s to %s)", util.FormatMillis(out.SinceMillis), util.FormatMillis(out.UntilMillis))))
+
+	now := util.NowMillis()
+	printedHeader := false
+	for _, col := range out.ColumnStats {
+		if col.Oldest == nil {
+			continue
+		}
+		if !printedHeader {
+			fmt.Println()
+			printedHeader = true
+		}
+		line := fmt.Sprintf("oldest %s (%s)", RenderID(col.Oldest.Alias), util.FormatDuration(now-col.Oldest.CreatedAtMillis))
+		if col.Newest.Alias != col.Oldest.Alias {
+			line += fmt.Sprintf(", newest %s (%s)", RenderID(col.Newest.Alias), util.FormatDuration(now-col.Newest.CreatedAtMillis))
+		}
+		fmt.Printf("%-*s  %s\n", nameWidth, col.Name, line)
+	}
+}
+
+// statsBlocks are the partial blocks statsBar uses for eighths of a cell.
+var statsBlocks = []rune("▏▎▍▌▋▊▉")
+
+// statsBar draws part/whole as a bar width cells wide at eighth-of-a-cell
+// resolution, padded with spaces to the full width.
+func statsBar(part, whole, width int) string {
+	eighths := 0
+	if whole > 0 {
+		eighths = (part*width*8 + whole/2) / whole
+	}
+	bar := strings.Repeat("█", eighths/8)
+	if rem := eighths % 8; rem > 0 {
+		bar += string(statsBlocks[rem-1])
+	}
+	return bar + strings.Repeat(" ", width-utf8.RuneCountInString(bar))
+}
+
+// defaultTerminalWidth is assumed when output is piped or redirected.
+const defaultTerminalWidth = 80
+
+// terminalWidth returns stdout's width in cells, or defaultTerminalWidth
+// when stdout isn't a terminal.
+func terminalWidth() int {
+	if width, _, err := term.GetSize(int(os.Stdout.Fd())); err == nil && width > 0 {
+		return width
+	}
+	return defaultTerminalWidth
+}
+
+func runBoardImport(file, name string) {
+	app, err := NewApp(true)
+	if err != nil {
+		Fatal(err)
+	}
+
+	if err := app.RequireKan(); err != nil {
+		Fatal(err)
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		Fatal(fmt.Errorf("failed to read %s: %w", file, err))
+	}
+
+	if name == "" {
+		name = service.ExportedBoardName(data)
+	}
+
+	if err := app.BoardService.Import(data, name); err != nil {
+		Fatal(err)
+	}
+
+	PrintSuccess("Imported board %q", name)
+}
+
+func runBoardImportTrello(file, name string) {
+	app, err := NewApp(true)
+	if err != nil {
+		Fatal(err)
+	}
+
+	if err := app.RequireKan(); err != nil {
+		Fatal(err)
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		Fatal(fmt.Errorf("failed to read %s: %w", file, err))
+	}
+
+	if name == "" {
+		name = service.TrelloBoardName(data)
+	}
+
+	if err := app.BoardService.ImportFromTrello(data, name); err != nil {
+		Fatal(err)
+	}
+
+	PrintSuccess("Imported Trello board as %q", name)
+}
@@ -0,0 +1,134 @@
+package cli
+
+import (
+	"encoding/json"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/amterp/kan/internal/model"
+	"github.com/amterp/kan/internal/service"
+)
+
+func TestBoardStats_JSON(t *testing.T) {
+	root := writeProjectBoard(t, "main")
+	t.Setenv("HOME", t.TempDir())
+	t.Chdir(root)
+
+	app, err := NewApp(false)
+	if err != nil {
+		t.Fatalf("NewApp: %v", err)
+	}
+	cfg, err := app.BoardService.Get("main")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	cfg.Columns[0].Limit = 4
+	cfg.Columns[1].Done = true
+	if err := app.BoardStore.Update(cfg); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	for _, card := range []struct{ title, column string }{
+		{"Write docs", "Backlog"},
+		{"Fix login", "Backlog"},
+		{"Add search", "Backlog"},
+		{"Ship it", "Done"},
+	} {
+		if _, _, err := app.CardService.Add(service.AddCardInput{BoardName: "main", Title: card.title, Column: card.column, Creator: "alice"}); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+	}
+
+	completion, err := app.BoardService.CompletionStats("main")
+	if err != nil {
+		t.Fatalf("CompletionStats: %v", err)
+	}
+	stats, err := app.BoardService.Statistics("main", 0, 0)
+	if err != nil {
+		t.Fatalf("Statistics: %v", err)
+	}
+	data, err := json.Marshal(buildBoardStats(cfg, completion, stats))
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	var got struct {
+		ColumnStats []struct {
+			Name      string  `json:"name"`
+			CardCount int     `json:"card_count"`
+			Limit     int     `json:"limit"`
+			LimitPct  float64 `json:"limit_pct"`
+			Oldest    *struct {
+				Alias string `json:"alias"`
+			} `json:"oldest"`
+		} `json:"column_stats"`
+		TotalDone  int      `json:"total_done"`
+		Throughput *float64 `json:"throughput"`
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if len(got.ColumnStats) != 2 {
+		t.Fatalf("Expected 2 column_stats entries, got %s", data)
+	}
+	backlog := got.ColumnStats[0]
+	if backlog.Name != "Backlog" || backlog.CardCount != 3 || backlog.Limit != 4 || backlog.LimitPct != 75 {
+		t.Errorf("Unexpected Backlog stats: %+v", backlog)
+	}
+	if backlog.Oldest == nil || backlog.Oldest.Alias == "" {
+		t.Errorf("Expected the oldest Backlog card, got %s", data)
+	}
+	if got.TotalDone != 1 {
+		t.Errorf("Expected 1 done card, got %d", got.TotalDone)
+	}
+	// One card finished within the default 30-day window.
+	if got.Throughput == nil || *got.Throughput < 0.033 || *got.Throughput > 0.034 {
+		t.Errorf("Expected throughput of 1/30 per day, got %s", data)
+	}
+}
+
+func TestBuildBoardStats_ColumnsMatchedByName(t *testing.T) {
+	// A column added between the three reads is only in the config and the
+	// completion stats.
+	cfg := &model.BoardConfig{Name: "main", Columns: []model.Column{
+		{Name: "Backlog", Limit: 2}, {Name: "Review", Limit: 4}, {Name: "Done"},
+	}}
+	completion := &service.CompletionStats{ColumnStats: []service.ColumnStat{
+		{Name: "Backlog", CardCount: 1}, {Name: "Review", CardCount: 2}, {Name: "Done"},
+	}}
+	oldest := &model.Card{Alias: "first"}
+	stats := &service.BoardStats{Columns: []service.ColumnStats{
+		{Name: "Backlog", Count: 1, Oldest: oldest}, {Name: "Done"},
+	}}
+
+	out := buildBoardStats(cfg, completion, stats)
+	if len(out.ColumnStats) != 3 {
+		t.Fatalf("Expected 3 columns, got %+v", out.ColumnStats)
+	}
+	if backlog := out.ColumnStats[0]; backlog.Oldest == nil || backlog.Oldest.Alias != "first" || backlog.LimitPct != 50 {
+		t.Errorf("Unexpected Backlog stats: %+v", backlog)
+	}
+	if review := out.ColumnStats[1]; review.Oldest != nil || review.Limit != 4 || review.LimitPct != 50 {
+		t.Errorf("Unexpected Review stats: %+v", review)
+	}
+}
+
+func TestStatsBar(t *testing.T) {
+	tests := []struct {
+		part, whole, width int
+		want               string
+	}{
+		{0, 0, 4, "    "},
+		{4, 4, 4, "████"},
+		{1, 2, 4, "██  "},
+		{1, 3, 4, "█▍  "},
+		{1, 32, 4, "▏   "},
+	}
+	for _, tt := range tests {
+		if got := statsBar(tt.part, tt.whole, tt.width); got != tt.want {
+			t.Errorf("statsBar(%d, %d, %d) = %q, want %q", tt.part, tt.whole, tt.width, got, tt.want)
+		}
+	}
+	if got := statsBar(3, 7, 10); utf8.RuneCountInString(got) != 10 {
+		t.Errorf("Expected a 10-cell bar, got %q", got)
+	}
+}
@@ -0,0 +1,1013 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/amterp/kan/internal/api"
+	"github.com/amterp/kan/internal/model"
+	"github.com/amterp/kan/internal/service"
+	"github.com/amterp/kan/internal/util"
+	"github.com/amterp/ra"
+)
+
+func registerCard(parent *ra.Cmd, ctx *CommandContext) {
+	cmd := ra.NewCmd("card")
+	cmd.SetDescription("Inspect and manage cards")
+
+	// card add
+	addCmd := ra.NewCmd("add")
+	addCmd.SetDescription("Create a card from one of the board's card templates")
+
+	ctx.CardAddTemplate, _ = ra.NewString("template").
+		SetShort("t").
+		SetFlagOnly(true).
+		SetUsage("Name of the card template").
+		Register(addCmd)
+
+	ctx.CardAddVars, _ = ra.NewStringSlice("vars").
+		SetOptional(true).
+		SetVariadic(true).
+		SetUsage("Template variables as key=value").
+		Register(addCmd)
+
+	ctx.CardAddBoard, _ = ra.NewString("board").
+		SetShort("b").
+		SetOptional(true).
+		SetFlagOnly(true).
+		SetUsage("Target board").
+		SetCompletionFunc(completeBoards).
+		Register(addCmd)
+
+	ctx.CardAddUsed, _ = cmd.RegisterCmd(addCmd)
+
+	// card list
+	listCmd := ra.NewCmd("list")
+	listCmd.SetDescription("List cards matching all the given filters")
+
+	ctx.CardListBoard, _ = ra.NewString("board").
+		SetShort("b").
+		SetOptional(true).
+		SetFlagOnly(true).
+		SetUsage("Board name").
+		SetCompletionFunc(completeBoards).
+		Register(listCmd)
+
+	ctx.CardListColumn, _ = ra.NewString("column").
+		SetShort("c").
+		SetOptional(true).
+		SetFlagOnly(true).
+		SetUsage("Only cards in this column").
+		SetCompletionFunc(completeColumns).
+		Register(listCmd)
+
+	ctx.CardListLabels, _ = ra.NewStringSlice("label").
+		SetOptional(true).
+		SetFlagOnly(true).
+		SetUsage("Only cards with this label (repeatable)").
+		Register(listCmd)
+
+	ctx.CardListFields, _ = ra.NewStringSlice("field").
+		SetShort("f").
+		SetOptional(true).
+		SetFlagOnly(true).
+		SetUsage("Only cards whose custom field has this value, as key=value (repeatable)").
+		Register(listCmd)
+
+	ctx.CardListCreatedAfter, _ = ra.NewString("created-after").
+		SetOptional(true).
+		SetFlagOnly(true).
+		SetUsage("Only cards created after this date (YYYY-MM-DD or RFC 3339)").
+		Register(listCmd)
+
+	ctx.CardListUpdatedAfter, _ = ra.NewString("updated-after").
+		SetOptional(true).
+		SetFlagOnly(true).
+		SetUsage("Only cards updated after this date (YYYY-MM-DD or RFC 3339)").
+		Register(listCmd)
+
+	ctx.CardListOverdue, _ = ra.NewBool("overdue").
+		SetOptional(true).
+		SetFlagOnly(true).
+		SetUsage("Only cards past their due date").
+		Register(listCmd)
+
+	ctx.CardListStale, _ = ra.NewBool("stale").
+		SetOptional(true).
+		SetFlagOnly(true).
+		SetUsage("Only cards not updated within the board's stale_days").
+		Register(listCmd)
+
+	ctx.CardListHasParent, _ = ra.NewBool("has-parent").
+		SetOptional(true).
+		SetFlagOnly(true).
+		SetUsage("Only cards with a parent").
+		Register(listCmd)
+
+	ctx.CardListNoParent, _ = ra.NewBool("no-parent").
+		SetOptional(true).
+		SetFlagOnly(true).
+		SetUsage("Only cards without a parent").
+		Register(listCmd)
+
+	ctx.CardListSort, _ = ra.NewString("sort").
+		SetShort("s").
+		SetOptional(true).
+		SetFlagOnly(true).
+		SetUsage("Sort within each column, as field[:asc|:desc] (comma-separated; e.g. created_at:desc)").
+		Register(listCmd)
+
+	ctx.CardListUsed, _ = cmd.RegisterCmd(listCmd)
+
+	// card import
+	importCmd := ra.NewCmd("import")
+	importCmd.SetDescription("Create cards from the rows of a CSV file")
+
+	ctx.CardImportFile, _ = ra.NewString("file").
+		SetUsage("Path to the CSV file (first row is the header)").
+		Register(importCmd)
+
+	ctx.CardImportBoard, _ = ra.NewString("board").
+		SetShort("b").
+		SetOptional(true).
+		SetFlagOnly(true).
+		SetUsage("Board to import into").
+		SetCompletionFunc(completeBoards).
+		Register(importCmd)
+
+	ctx.CardImportMap, _ = ra.NewStringSlice("map").
+		SetShort("m").
+		SetOptional(true).
+		SetFlagOnly(true).
+		SetUsage("Map a CSV header to a card field (header=field, repeatable; default: headers matching title, description, column or a custom field)").
+		Register(importCmd)
+
+	ctx.CardImportUsed, _ = cmd.RegisterCmd(importCmd)
+
+	// card show
+	showCmd := ra.NewCmd("show")
+	showCmd.SetDescription("Display every detail of a card")
+
+	ctx.CardShowCard, _ = ra.NewString("card").
+		SetUsage("Card ID or alias").
+		SetCompletionFunc(completeCards).
+		Register(showCmd)
+
+	ctx.CardShowBoard, _ = ra.NewString("board").
+		SetShort("b").
+		SetOptional(true).
+		SetFlagOnly(true).
+		SetUsage("Board name").
+		SetCompletionFunc(completeBoards).
+		Register(showCmd)
+
+	ctx.CardShowUsed, _ = cmd.RegisterCmd(showCmd)
+
+	// card delete
+	deleteCmd := ra.NewCmd("delete")
+	deleteCmd.SetDescription("Delete several cards at once")
+
+	ctx.CardDeleteMany, _ = ra.NewString("many").
+		SetFlagOnly(true).
+		SetUsage("Comma-separated card IDs or aliases").
+		Register(deleteCmd)
+
+	ctx.CardDeleteBoard, _ = ra.NewString("board").
+		SetShort("b").
+		SetOptional(true).
+		SetFlagOnly(true).
+		SetUsage("Board name").
+		SetCompletionFunc(completeBoards).
+		Register(deleteCmd)
+
+	ctx.CardDeleteDryRun, _ = ra.NewBool("dry-run").
+		SetOptional(true).
+		SetFlagOnly(true).
+		SetUsage("Show which cards would be deleted without deleting them").
+		Register(deleteCmd)
+
+	ctx.CardDeleteUsed, _ = cmd.RegisterCmd(deleteCmd)
+
+	// card duplicate
+	duplicateCmd := ra.NewCmd("duplicate")
+	duplicateCmd.SetDescription("Copy a card into a new card with its own ID and alias")
+
+	ctx.CardDuplicateCard, _ = ra.NewString("card").
+		SetUsage("Card ID or alias to copy").
+		SetCompletionFunc(completeCards).
+		Register(duplicateCmd)
+
+	ctx.CardDuplicateBoard, _ = ra.NewString("board").
+		SetShort("b").
+		SetOptional(true).
+		SetFlagOnly(true).
+		SetUsage("Board name").
+		SetCompletionFunc(completeBoards).
+		Register(duplicateCmd)
+
+	ctx.CardDuplicateTitle, _ = ra.NewString("title").
+		SetShort("t").
+		SetOptional(true).
+		SetFlagOnly(true).
+		SetUsage("Title for the copy (default: the original's title prefixed with \"[COPY] \")").
+		Register(duplicateCmd)
+
+	ctx.CardDuplicateColumn, _ = ra.NewString("column").
+		SetShort("c").
+		SetOptional(true).
+		SetFlagOnly(true).
+		SetUsage("Column for the copy (default: the original's column)").
+		SetCompletionFunc(completeColumns).
+		Register(duplicateCmd)
+
+	ctx.CardDuplicateLabels, _ = ra.NewStringSlice("label").
+		SetOptional(true).
+		SetFlagOnly(true).
+		SetUsage("Label to add to the copy (repeatable)").
+		Register(duplicateCmd)
+
+	ctx.CardDuplicateOpen, _ = ra.NewBool("open").
+		SetOptional(true).
+		SetFlagOnly(true).
+		SetUsage("Open the copy in the web UI (expects kan serve on its default port)").
+		Register(duplicateCmd)
+
+	ctx.CardDuplicateUsed, _ = cmd.RegisterCmd(duplicateCmd)
+
+	// card watch
+	watchCmd := ra.NewCmd("watch")
+	watchCmd.SetDescription("Show a card and re-render it whenever it changes (needs kan serve running)")
+
+	ctx.CardWatchCard, _ = ra.NewString("card").
+		SetUsage("Card ID or alias to watch").
+		SetCompletionFunc(completeCards).
+		Register(watchCmd)
+
+	ctx.CardWatchBoard, _ = ra.NewString("board").
+		SetShort("b").
+		SetOptional(true).
+		SetFlagOnly(true).
+		SetUsage("Board name").
+		SetCompletionFunc(completeBoards).
+		Register(watchCmd)
+
+	ctx.CardWatchPort, _ = ra.NewInt("port").
+		SetShort("p").
+		SetOptional(true).
+		SetDefault(defaultServePort).
+		SetFlagOnly(true).
+		SetUsage("Port kan serve is listening on").
+		Register(watchCmd)
+
+	ctx.CardWatchUsed, _ = cmd.RegisterCmd(watchCmd)
+
+	ctx.CardUsed, _ = parent.RegisterCmd(cmd)
+}
+
+// duplicateTitlePrefix marks a duplicated card's title unless --title is given.
+const duplicateTitlePrefix = "[COPY] "
+
+// parseTemplateVars parses template variables given as key=value. A repeated
+// key takes its last value.
+func parseTemplateVars(raw []string) (map[string]string, error) {
+	vars := make(map[string]string, len(raw))
+	for _, s := range raw {
+		key, value, ok := strings.Cut(s, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid variable %q (expected key=value)", s)
+		}
+		vars[key] = value
+	}
+	return vars, nil
+}
+
+func runCardAdd(templateName string, rawVars []string, board string, nonInteractive, jsonOutput bool) {
+	app, err := NewApp(!nonInteractive)
+	if err != nil {
+		Fatal(err)
+	}
+
+	if err := app.RequireKan(); err != nil {
+		Fatal(err)
+	}
+
+	boardName, err := app.BoardResolver.Resolve(board, !nonInteractive)
+	if err != nil {
+		Fatal(err)
+	}
+
+	vars, err := parseTemplateVars(rawVars)
+	if err != nil {
+		Fatal(err)
+	}
+
+	creatorName, err := app.GetAuthor()
+	if err != nil {
+		Fatal(err)
+	}
+
+	card, hookResults, err := app.CardService.ApplyTemplateAs(boardName, templateName, creatorName, vars)
+	if err != nil {
+		Fatal(err)
+	}
+
+	if jsonOutput {
+		if err := printJson(NewAddOutput(card, hookResults)); err != nil {
+			Fatal(err)
+		}
+		return
+	}
+
+	PrintSuccess("Created card %s (%s) from template %q", RenderID(card.ID), card.Alias, templateName)
+	printHookResults(hookResults)
+
+	if boardCfg, err := app.BoardService.Get(boardName); err == nil {
+		printMissingWantedWarnings(service.CheckWantedFields(card, boardCfg))
+	}
+}
+
+func runCardList(board string, filters cardListFilters, sortSpec string, nonInteractive, jsonOutput bool) {
+	app, err := NewApp(!nonInteractive)
+	if err != nil {
+		Fatal(err)
+	}
+
+	if err := app.RequireKan(); err != nil {
+		Fatal(err)
+	}
+
+	boardName, err := app.BoardResolver.Resolve(board, !nonInteractive)
+	if err != nil {
+		Fatal(err)
+	}
+
+	boardCfg, err := app.BoardService.Get(boardName)
+	if err != nil {
+		Fatal(err)
+	}
+
+	now := time.Now()
+	chain, err := buildFilterChain(filters, boardCfg, now)
+	if err != nil {
+		Fatal(err)
+	}
+	if filters.Stale && boardCfg.Stale.StaleDays <= 0 {
+		PrintWarning("--stale matches nothing: board %q has no stale_days set", boardName)
+	}
+
+	sortBy, err := model.ParseSortFields(sortSpec)
+	if err != nil {
+		Fatal(err)
+	}
+
+	cards, err := app.CardService.ListWithOptions(boardName, filters.Column, service.ListOptions{SortBy: sortBy})
+	if err != nil {
+		Fatal(err)
+	}
+	cards = chain.Apply(cards)
+
+	if jsonOutput {
+		if err := printJson(NewListOutput(cards)); err != nil {
+			Fatal(err)
+		}
+		return
+	}
+
+	if len(cards) == 0 {
+		PrintInfo("No cards found")
+		return
+	}
+	printCardTable(cards, now.UnixMilli())
+}
+
+// printCardTable prints cards as an aligned alias/title/column/age table.
+func printCardTable(cards []*model.Card, nowMillis int64) {
+	rows := make([][]string, len(cards))
+	for i, card := range cards {
+		alias := card.Alias
+		if alias == "" {
+			alias = card.ID
+		}
+		rows[i] = []string{alias, card.Title, card.Column, util.FormatDuration(nowMillis - card.CreatedAtMillis)}
+	}
+	printTable([]string{"ALIAS", "TITLE", "COLUMN", "AGE"}, rows)
+}
+
+// CardImportOutput is the JSON output of `kan card import`.
+type CardImportOutput struct {
+	Imported int                  `json:"imported"`
+	Skipped  int                  `json:"skipped"`
+	Errors   []CardImportRowError `json:"errors"`
+}
+
+// CardImportRowError describes a CSV row that failed to import.
+type CardImportRowError struct {
+	Row   int    `json:"row"`
+	Error string `json:"error"`
+}
+
+func runCardImport(file, board string, mappings []string, nonInteractive, jsonOutput bool) {
+	app, err := NewApp(!nonInteractive)
+	if err != nil {
+		Fatal(err)
+	}
+
+	if err := app.RequireKan(); err != nil {
+		Fatal(err)
+	}
+
+	boardName, err := app.BoardResolver.Resolve(board, !nonInteractive)
+	if err != nil {
+		Fatal(err)
+	}
+
+	mapping, err := parseCustomFields(mappings)
+	if err != nil {
+		Fatal(err)
+	}
+
+	creator, err := app.GetAuthor()
+	if err != nil {
+		Fatal(err)
+	}
+
+	f, err := os.Open(file)
+	if err != nil {
+		Fatal(fmt.Errorf("failed to read %s: %w", file, err))
+	}
+	defer f.Close()
+
+	imported, skipped, err := app.CardService.ImportCSV(boardName, f, service.CSVMapping(mapping), creator)
+	var rowErrs *service.CSVImportError
+	if err != nil && !errors.As(err, &rowErrs) {
+		Fatal(err)
+	}
+
+	if jsonOutput {
+		output := CardImportOutput{Imported: len(imported), Skipped: skipped, Errors: []CardImportRowError{}}
+		if rowErrs != nil {
+			for _, row := range rowErrs.Rows {
+				output.Errors = append(output.Errors, CardImportRowError{Row: row.Row, Error: row.Err.Error()})
+			}
+		}
+		if err := printJson(output); err != nil {
+			Fatal(err)
+		}
+		return
+	}
+
+	if rowErrs != nil {
+		for _, row := range rowErrs.Rows {
+			PrintWarning("row %d: %v", row.Row, row.Err)
+		}
+	}
+	PrintSuccess("Imported %d card(s) into %q (%d skipped)", len(imported), boardName, skipped)
+}
+
+func runCardDelete(many, board string, dryRun, nonInteractive, jsonOutput bool) {
+	app, err := NewApp(!nonInteractive)
+	if err != nil {
+		Fatal(err)
+	}
+
+	if err := app.RequireKan(); err != nil {
+		Fatal(err)
+	}
+
+	boardName, err := app.BoardResolver.Resolve(board, !nonInteractive)
+	if err != nil {
+		Fatal(err)
+	}
+
+	var cardIDs []string
+	for _, id := range strings.Split(many, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			cardIDs = append(cardIDs, id)
+		}
+	}
+
+	result, err := app.CardService.DeleteMany(boardName, cardIDs, dryRun)
+	if err != nil {
+		Fatal(err)
+	}
+
+	if jsonOutput {
+		if err := printJson(api.NewDeleteCardsResponse(result, dryRun)); err != nil {
+			Fatal(err)
+		}
+		return
+	}
+
+	for _, id := range result.NotFound {
+		PrintWarning("card %q not found", id)
+	}
+	for _, f := range result.Failed {
+		PrintWarning("failed to delete %s: %s", f.CardID, f.Error)
+	}
+	if dryRun {
+		PrintInfo("Would delete %d card(s) from board %q: %s", len(result.Deleted), boardName, strings.Join(result.Deleted, ", "))
+		return
+	}
+	PrintSuccess("Deleted %d card(s) from board %q", len(result.Deleted), boardName)
+}
+
+func runCardShow(idOrAlias, board string, nonInteractive, jsonOutput bool) {
+	app, err := NewApp(!nonInteractive)
+	if err != nil {
+		Fatal(err)
+	}
+
+	if err := app.RequireKan(); err != nil {
+		Fatal(err)
+	}
+
+	card, boardCfg, err := findCardForShow(app, board, idOrAlias, !nonInteractive)
+	if err != nil {
+		Fatal(err)
+	}
+
+	if jsonOutput {
+		// Emit the API's card shape so scripts can share parsing with the web UI.
+		if err := printJson(api.NewCardResponse(card, boardCfg)); err != nil {
+			Fatal(err)
+		}
+		return
+	}
+
+	printCardDetail(card, boardCfg)
+}
+
+// findCardForShow resolves the board and then the card within it.
+func findCardForShow(app *App, board, idOrAlias string, interactive bool) (*model.Card, *model.BoardConfig, error) {
+	boardName, err := app.BoardResolver.Resolve(board, interactive)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	card, err := app.CardService.FindByIDOrAlias(boardName, idOrAlias)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	boardCfg, err := app.BoardService.Get(boardName)
+	if err != nil {
+		return nil, nil, err
+	}
+	return card, boardCfg, nil
+}
+
+// printCardDetail prints the full card: unlike `kan show`, nothing is
+// summarized, and custom fields, checklist items and dependencies are listed.
+func printCardDetail(card *model.Card, boardCfg *model.BoardConfig) {
+	writeCardDetail(os.Stdout, card, boardCfg)
+}
+
+// writeCardDetail writes the card as printCardDetail shows it.
+func writeCardDetail(w io.Writer, card *model.Card, boardCfg *model.BoardConfig) {
+	const labelWidth = 12
+
+	fmt.Fprintln(w, RenderBold(card.Title))
+	fmt.Fprintln(w)
+
+	fmt.Fprintln(w, LabelValue("ID", RenderID(card.ID), labelWidth))
+	fmt.Fprintln(w, LabelValue("Alias", card.Alias, labelWidth))
+	if card.Archived {
+		fmt.Fprintln(w, LabelValue("Column", RenderMuted("(archived)"), labelWidth))
+	} else {
+		var colColor string
+		if col := boardCfg.GetColumn(card.Column); col != nil {
+			colColor = col.Color
+		}
+		fmt.Fprintln(w, LabelValue("Column", RenderColumnColor(card.Column, colColor), labelWidth))
+	}
+	fmt.Fprintln(w, LabelValue("Creator", card.Creator, labelWidth))
+	fmt.Fprintln(w, LabelValue("Created", RenderMuted(util.FormatMillis(card.CreatedAtMillis)), labelWidth))
+	fmt.Fprintln(w, LabelValue("Updated", RenderMuted(util.FormatMillis(card.UpdatedAtMillis)), labelWidth))
+	if card.DueAtMillis != 0 {
+		due := util.FormatMillis(card.DueAtMillis)
+		if card.IsOverdue(util.NowMillis()) {
+			due = StyleError.Render(due + " (overdue)")
+		}
+		fmt.Fprintln(w, LabelValue("Due", due, labelWidth))
+	}
+
+	if card.Parent != "" || len(card.Blocks) > 0 || len(card.BlockedBy) > 0 {
+		fmt.Fprintln(w)
+		if card.Parent != "" {
+			fmt.Fprintln(w, LabelValue("Parent", RenderID(card.Parent), labelWidth))
+		}
+		if len(card.Blocks) > 0 {
+			fmt.Fprintln(w, LabelValue("Blocks", renderIDList(card.Blocks), labelWidth))
+		}
+		if len(card.BlockedBy) > 0 {
+			fmt.Fprintln(w, LabelValue("Blocked by", renderIDList(card.BlockedBy), labelWidth))
+		}
+	}
+
+	if card.Description != "" {
+		fmt.Fprintf(w, "\n%s\n", RenderMuted("Description:"))
+		fmt.Fprintf(w, "  %s\n", strings.ReplaceAll(card.Description, "\n", "\n  "))
+	}
+
+	if len(card.CustomFields) > 0 {
+		fmt.Fprintf(w, "\n%s\n", RenderMuted("Custom Fields:"))
+		names := make([]string, 0, len(card.CustomFields))
+		for name := range card.CustomFields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fieldType := "unknown"
+			if schema, ok := boardCfg.CustomFields[name]; ok {
+				fieldType = schema.FieldType()
+			}
+			fmt.Fprintf(w, "  %s %s %s\n",
+				RenderBold(name),
+				RenderTypeIndicator(fieldType, stringToColor(fieldType)),
+				renderFieldValue(boardCfg, name, fieldType, card.CustomFields[name]))
+		}
+	}
+
+	if len(card.Checklist) > 0 {
+		done := 0
+		for _, item := range card.Checklist {
+			if item.Done {
+				done++
+			}
+		}
+		fmt.Fprintf(w, "\n%s\n", RenderMuted(fmt.Sprintf("Checklist (%d/%d):", done, len(card.Checklist))))
+		for _, item := range card.Checklist {
+			if item.Done {
+				fmt.Fprintf(w, "  %s %s\n", StyleSuccess.Render("[x]"), RenderMuted(item.Text))
+			} else {
+				fmt.Fprintf(w, "  [ ] %s\n", item.Text)
+			}
+		}
+	}
+
+	if len(card.Comments) > 0 {
+		comments := make([]model.Comment, len(card.Comments))
+		copy(comments, card.Comments)
+		sort.SliceStable(comments, func(i, j int) bool {
+			return comments[i].CreatedAtMillis < comments[j].CreatedAtMillis
+		})
+		fmt.Fprintf(w, "\n%s\n", RenderMuted(fmt.Sprintf("Comments (%d):", len(comments))))
+		for _, comment := range comments {
+			timestamp := RenderMuted(fmt.Sprintf("[%s]", util.FormatMillis(comment.CreatedAtMillis)))
+			fmt.Fprintf(w, "  %s %s:\n", timestamp, RenderBold(comment.Author))
+			fmt.Fprintf(w, "    %s\n", strings.ReplaceAll(comment.Body, "\n", "\n    "))
+		}
+	}
+}
+
+// renderIDList renders card IDs in accent color, comma-separated.
+func renderIDList(ids []string) string {
+	rendered := make([]string, len(ids))
+	for i, id := range ids {
+		rendered[i] = RenderID(id)
+	}
+	return strings.Join(rendered, ", ")
+}
+
+// renderFieldValue renders a custom field value, coloring enum and set values
+// the same way `kan list` colors badges.
+func renderFieldValue(boardCfg *model.BoardConfig, fieldName, fieldType string, value any) string {
+	switch fieldType {
+	case model.FieldTypeEnum, model.FieldTypeEnumSet, model.FieldTypeFreeSet:
+		var values []string
+		switch v := value.(type) {
+		case string:
+			values = []string{v}
+		case []any:
+			for _, item := range v {
+				if s, ok := item.(string); ok {
+					values = append(values, s)
+				}
+			}
+		case []string:
+			values = v
+		}
+		parts := make([]string, 0, len(values))
+		for _, val := range values {
+			color := boardCfg.GetOptionColor(fieldName, val)
+			if color == "" {
+				color = badgeColor(fieldType, fieldName, val)
+			}
+			parts = append(parts, RenderColumnColor(val, color))
+		}
+		return strings.Join(parts, ", ")
+	case model.FieldTypeURL:
+		if s, ok := value.(string); ok {
+			return RenderURL(s)
+		}
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+func runCardDuplicate(idOrAlias, board, title, column string, labels []string, open, nonInteractive, jsonOutput bool) {
+	app, err := NewApp(!nonInteractive)
+	if err != nil {
+		Fatal(err)
+	}
+
+	if err := app.RequireKan(); err != nil {
+		Fatal(err)
+	}
+
+	boardName, err := app.BoardResolver.Resolve(board, !nonInteractive)
+	if err != nil {
+		Fatal(err)
+	}
+
+	creatorName, err := app.GetAuthor()
+	if err != nil {
+		Fatal(err)
+	}
+
+	opts := service.CloneOptions{
+		TargetColumn: column,
+		Title:        title,
+		Creator:      creatorName,
+		Labels:       labels,
+	}
+	if title == "" {
+		opts.TitlePrefix = duplicateTitlePrefix
+	}
+	card, err := app.CardService.Clone(boardName, idOrAlias, opts)
+	if err != nil {
+		Fatal(err)
+	}
+
+	if jsonOutput {
+		if err := printJson(NewAddOutput(card, nil)); err != nil {
+			Fatal(err)
+		}
+	} else {
+		PrintSuccess("Created card %s (%s) from %s", RenderID(card.ID), card.Alias, idOrAlias)
+	}
+
+	if open {
+		openBrowser(cardURL(defaultServePort, boardName, card.ID))
+	}
+}
+
+func runCardWatch(idOrAlias, board string, port int, nonInteractive bool) {
+	app, err := NewApp(!nonInteractive)
+	if err != nil {
+		Fatal(err)
+	}
+
+	if err := app.RequireKan(); err != nil {
+		Fatal(err)
+	}
+
+	card, boardCfg, err := findCardForShow(app, board, idOrAlias, !nonInteractive)
+	if err != nil {
+		Fatal(err)
+	}
+	boardName := boardCfg.Name
+	printCardDetail(card, boardCfg)
+	fmt.Println()
+	PrintInfo("Watching %s via kan serve on port %d (Ctrl+C to stop)", RenderID(card.ID), port)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	cardID := card.ID
+	watcher := &cardWatcher{
+		previous: card,
+		reload: func() (*model.Card, *model.BoardConfig, error) {
+			latest, err := app.CardService.Get(boardName, cardID)
+			if err != nil {
+				return nil, nil, err
+			}
+			latestCfg, err := app.BoardService.Get(boardName)
+			return latest, latestCfg, err
+		},
+	}
+	eventsURL := fmt.Sprintf("http://localhost:%d/api/v1/boards/%s/events", port, url.PathEscape(boardName))
+	deleted, err := watcher.watch(ctx, eventsURL, os.Stdout)
+	if err != nil {
+		Fatal(err)
+	}
+	if deleted {
+		PrintInfo("Card %s was deleted", RenderID(cardID))
+	}
+}
+
+// cardWatchEvents are the board events that re-render a watched card.
+var cardWatchEvents = map[string]bool{
+	api.EventCardUpdated:    true,
+	api.EventCardMoved:      true,
+	api.EventCommentCreated: true,
+}
+
+// cardWatcher re-renders a card as board events about it arrive.
+type cardWatcher struct {
+	previous *model.Card
+	reload   func() (*model.Card, *model.BoardConfig, error)
+}
+
+// watch follows the board's event stream at eventsURL, writing the card to
+// w, with what changed highlighted, each time it's updated, moved or
+// commented on. It returns when ctx is cancelled, or with deleted set once
+// the card is deleted.
+func (cw *cardWatcher) watch(ctx context.Context, eventsURL string, w io.Writer) (deleted bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, eventsURL, nil)
+	if err != nil {
+		return false, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return false, nil
+		}
+		return false, fmt.Errorf("cannot reach kan serve (is it running?): %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("event stream returned %s", resp.Status)
+	}
+
+	events := make(chan api.BoardEvent)
+	streamErr := make(chan error, 1)
+	go func() {
+		streamErr <- readBoardEvents(resp.Body, func(event api.BoardEvent) bool {
+			select {
+			case events <- event:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		})
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return false, nil
+		case err := <-streamErr:
+			if ctx.Err() != nil {
+				return false, nil
+			}
+			if err == nil {
+				err = errors.New("kan serve closed the event stream")
+			}
+			return false, err
+		case event := <-events:
+			if event.CardID != cw.previous.ID {
+				continue
+			}
+			if event.EventType == api.EventCardDeleted {
+				return true, nil
+			}
+			if cardWatchEvents[event.EventType] {
+				if err := cw.render(w); err != nil {
+					PrintWarning("Failed to reload card: %v", err)
+				}
+			}
+		}
+	}
+}
+
+// render reloads the card and writes what changed followed by the card. One
+// edit can publish several events (a move is also an update), so a card
+// that hasn't changed since the last render isn't written again.
+func (cw *cardWatcher) render(w io.Writer) error {
+	card, boardCfg, err := cw.reload()
+	if err != nil {
+		return err
+	}
+	if reflect.DeepEqual(card, cw.previous) {
+		return nil
+	}
+	fmt.Fprintf(w, "\n%s\n", RenderMuted(time.Now().Format(time.TimeOnly)))
+	for _, change := range cardChanges(cw.previous, card) {
+		fmt.Fprintf(w, "  %s\n", change)
+	}
+	fmt.Fprintln(w)
+	writeCardDetail(w, card, boardCfg)
+	cw.previous = card
+	return nil
+}
+
+// cardChanges describes how a card differs from an earlier version of it,
+// one highlighted line per changed field.
+func cardChanges(before, after *model.Card) []string {
+	var changes []string
+	change := func(field, from, to string) {
+		changes = append(changes, fmt.Sprintf("%s %s → %s",
+			RenderBold(field+":"), RenderMuted(orNone(from)), StyleWarning.Render(orNone(to))))
+	}
+	if before.Title != after.Title {
+		change("title", before.Title, after.Title)
+	}
+	if before.Alias != after.Alias {
+		change("alias", before.Alias, after.Alias)
+	}
+	if before.Column != after.Column {
+		change("column", before.Column, after.Column)
+	}
+	if before.Archived != after.Archived {
+		change("archived", fmt.Sprint(before.Archived), fmt.Sprint(after.Archived))
+	}
+	if before.Parent != after.Parent {
+		change("parent", before.Parent, after.Parent)
+	}
+	if before.DueAtMillis != after.DueAtMillis {
+		change("due", formatOptionalMillis(before.DueAtMillis), formatOptionalMillis(after.DueAtMillis))
+	}
+	if before.Description != after.Description {
+		changes = append(changes, fmt.Sprintf("%s %s", RenderBold("description:"), StyleWarning.Render("edited")))
+	}
+
+	names := make(map[string]bool)
+	for name := range before.CustomFields {
+		names[name] = true
+	}
+	for name := range after.CustomFields {
+		names[name] = true
+	}
+	for _, name := range slices.Sorted(maps.Keys(names)) {
+		from, to := before.CustomFields[name], after.CustomFields[name]
+		if !reflect.DeepEqual(from, to) {
+			change(name, formatOptionalValue(from), formatOptionalValue(to))
+		}
+	}
+
+	if !reflect.DeepEqual(before.Checklist, after.Checklist) {
+		changes = append(changes, fmt.Sprintf("%s %s", RenderBold("checklist:"), StyleWarning.Render("edited")))
+	}
+	if added := len(after.Comments) - len(before.Comments); added > 0 {
+		changes = append(changes, StyleWarning.Render(fmt.Sprintf("+%d comment(s)", added)))
+	}
+	return changes
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "(none)"
+	}
+	return s
+}
+
+func formatOptionalMillis(millis int64) string {
+	if millis == 0 {
+		return ""
+	}
+	return util.FormatMillis(millis)
+}
+
+func formatOptionalValue(v any) string {
+	if v == nil {
+		return ""
+	}
+	return fmt.Sprint(v)
+}
+
+// readBoardEvents parses a server-sent event stream of board events, calling
+// emit for each until the stream ends or emit returns false.
+func readBoardEvents(r io.Reader, emit func(api.BoardEvent) bool) error {
+	scanner := bufio.NewScanner(r)
+	var data strings.Builder
+	for scanner.Scan() {
+		line := scanner.Text()
+		if value, ok := strings.CutPrefix(line, "data:"); ok {
+			data.WriteString(strings.TrimPrefix(value, " "))
+			continue
+		}
+		if line != "" || data.Len() == 0 {
+			continue // event names and comments; the type is also in the data
+		}
+		var event api.BoardEvent
+		err := json.Unmarshal([]byte(data.String()), &event)
+		data.Reset()
+		if err != nil {
+			return fmt.Errorf("invalid board event: %w", err)
+		}
+		if !emit(event) {
+			return nil
+		}
+	}
+	return scanner.Err()
+}
+
+// cardURL is the web UI address of a card, shown in its board.
+func cardURL(port int, boardName, cardID string) string {
+	return fmt.Sprintf("http://localhost:%d/board/%s?card=%s", port, url.PathEscape(boardName), url.QueryEscape(cardID))
+}
@@ -0,0 +1,159 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/amterp/kan/internal/api"
+	"github.com/amterp/kan/internal/model"
+	"github.com/amterp/kan/internal/service"
+)
+
+func TestCardShow_JSONMatchesAPIResponse(t *testing.T) {
+	root := writeProjectBoard(t, "main")
+	t.Setenv("HOME", t.TempDir())
+	t.Chdir(root)
+
+	app, err := NewApp(false)
+	if err != nil {
+		t.Fatalf("NewApp: %v", err)
+	}
+	created, _, err := app.CardService.Add(service.AddCardInput{
+		BoardName:   "main",
+		Title:       "Fix login",
+		Description: "Users are logged out\nafter one minute",
+		Creator:     "alice",
+	})
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if _, err := app.CardService.AddComment("main", created.ID, "Repro'd on staging", "bob"); err != nil {
+		t.Fatalf("AddComment: %v", err)
+	}
+
+	card, boardCfg, err := findCardForShow(app, "", created.Alias, false)
+	if err != nil {
+		t.Fatalf("findCardForShow: %v", err)
+	}
+	data, err := json.Marshal(api.NewCardResponse(card, boardCfg))
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	var got struct {
+		ID          string `json:"id"`
+		Alias       string `json:"alias"`
+		Title       string `json:"title"`
+		Description string `json:"description"`
+		Column      string `json:"column"`
+		Creator     string `json:"creator"`
+		Comments    []struct {
+			Body   string `json:"body"`
+			Author string `json:"author"`
+		} `json:"comments"`
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	if got.ID != created.ID || got.Alias != created.Alias {
+		t.Errorf("id/alias = %q/%q, want %q/%q", got.ID, got.Alias, created.ID, created.Alias)
+	}
+	if got.Title != "Fix login" || got.Description != "Users are logged out\nafter one minute" {
+		t.Errorf("unexpected title/description: %q / %q", got.Title, got.Description)
+	}
+	if got.Column != "Backlog" || got.Creator != "alice" {
+		t.Errorf("column/creator = %q/%q, want Backlog/alice", got.Column, got.Creator)
+	}
+	if len(got.Comments) != 1 || got.Comments[0].Body != "Repro'd on staging" || got.Comments[0].Author != "bob" {
+		t.Errorf("unexpected comments: %+v", got.Comments)
+	}
+}
+
+func TestParseTemplateVars(t *testing.T) {
+	vars, err := parseTemplateVars([]string{"component=auth", "summary=a=b", "component=api"})
+	if err != nil {
+		t.Fatalf("parseTemplateVars: %v", err)
+	}
+	if vars["component"] != "api" || vars["summary"] != "a=b" {
+		t.Errorf("Unexpected vars %v", vars)
+	}
+
+	for _, bad := range []string{"component", "=auth"} {
+		if _, err := parseTemplateVars([]string{bad}); err == nil {
+			t.Errorf("Expected error for %q", bad)
+		}
+	}
+}
+
+func TestCardURL(t *testing.T) {
+	if got := cardURL(5260, "team board", "a_2hq"); got != "http://localhost:5260/board/team%20board?card=a_2hq" {
+		t.Errorf("cardURL() = %q", got)
+	}
+}
+
+func TestCardWatcher_RerendersOnUpdate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/boards/main/events" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, event := range []api.BoardEvent{
+			{EventType: api.EventCardUpdated, CardID: "other"},
+			{EventType: api.EventCardUpdated, CardID: "c1", Column: "backlog"},
+			{EventType: api.EventCommentCreated, CardID: "c1"},
+			{EventType: api.EventCardDeleted, CardID: "c1"},
+		} {
+			data, _ := json.Marshal(event)
+			fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.EventType, data)
+		}
+	}))
+	defer server.Close()
+
+	boardCfg := &model.BoardConfig{Name: "main", Columns: model.DefaultColumns()}
+	reloads := 0
+	watcher := &cardWatcher{
+		previous: &model.Card{ID: "c1", Title: "Fix login", Column: "backlog"},
+		reload: func() (*model.Card, *model.BoardConfig, error) {
+			reloads++
+			return &model.Card{ID: "c1", Title: "Fix signup", Column: "backlog"}, boardCfg, nil
+		},
+	}
+
+	var out bytes.Buffer
+	deleted, err := watcher.watch(context.Background(), server.URL+"/api/v1/boards/main/events", &out)
+	if err != nil {
+		t.Fatalf("watch: %v", err)
+	}
+	if !deleted {
+		t.Error("Expected watch to stop on the card's deletion")
+	}
+	if reloads != 2 {
+		t.Errorf("Expected a reload per event about the card, got %d", reloads)
+	}
+	// The second event left the card unchanged, so it's rendered once.
+	if strings.Count(out.String(), "Fix signup") != 2 || !strings.Contains(out.String(), "title:") {
+		t.Errorf("Expected the updated card once with its title change, got:\n%s", out.String())
+	}
+}
+
+func TestCardChanges(t *testing.T) {
+	before := &model.Card{Title: "A", Column: "backlog", CustomFields: map[string]any{"type": "bug"}}
+	after := &model.Card{Title: "A", Column: "done", CustomFields: map[string]any{"type": "feature"},
+		Comments: []model.Comment{{Body: "hi"}}}
+
+	changes := cardChanges(before, after)
+	if len(changes) != 3 {
+		t.Fatalf("Expected column, type and comment changes, got %q", changes)
+	}
+	if !strings.Contains(changes[0], "column:") || !strings.Contains(changes[1], "type:") || !strings.Contains(changes[2], "+1 comment") {
+		t.Errorf("Unexpected changes: %q", changes)
+	}
+}
@@ -241,10 +241,11 @@ func runColumnDelete(name, board string, nonInteractive bool) {
 		Fatal(err)
 	}
 
-	deletedCards, err := app.BoardService.DeleteColumn(boardName, name)
+	deleted, err := app.BoardService.DeleteColumn(boardName, name)
 	if err != nil {
 		Fatal(err)
 	}
+	deletedCards := len(deleted)
 
 	if deletedCards > 0 {
 		cardWord := "cards"
@@ -340,13 +341,9 @@ func runColumnList(board string, nonInteractive, jsonOutput bool) {
 		Fatal(err)
 	}
 
-	// Build card count per column
-	cards, err := app.CardService.List(boardName, "")
-	cardCounts := make(map[string]int)
-	if err == nil {
-		for _, card := range cards {
-			cardCounts[card.Column]++
-		}
+	summaries, err := app.BoardService.GetColumnSummary(boardName)
+	if err != nil {
+		Fatal(err)
 	}
 
 	if jsonOutput {
@@ -357,7 +354,8 @@ func runColumnList(board string, nonInteractive, jsonOutput bool) {
 				Color:       col.Color,
 				Description: col.Description,
 				Limit:       col.Limit,
-				CardCount:   cardCounts[col.Name],
+				CardCount:   summaries[i].CardCount,
+				AtLimit:     summaries[i].AtLimit,
 			}
 		}
 		if err := printJson(NewColumnsOutput(columns)); err != nil {
@@ -371,8 +369,8 @@ func runColumnList(board string, nonInteractive, jsonOutput bool) {
 		return
 	}
 
-	for _, col := range boardCfg.Columns {
-		n := cardCounts[col.Name]
+	for i, col := range boardCfg.Columns {
+		n := summaries[i].CardCount
 		cardWord := "cards"
 		if n == 1 {
 			cardWord = "card"
@@ -384,6 +382,9 @@ func runColumnList(board string, nonInteractive, jsonOutput bool) {
 		} else {
 			count = RenderMuted(fmt.Sprintf("(%d %s)", n, cardWord))
 		}
+		if summaries[i].AtLimit {
+			count += " " + StyleWarning.Render(IconWarning+" at limit")
+		}
 		fmt.Printf("%-15s %s %s\n", col.Name, swatch, count)
 		if col.Description != "" {
 			fmt.Printf("  %s\n", RenderMuted(col.Description))
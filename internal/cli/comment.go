@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/amterp/kan/internal/editor"
+	"github.com/amterp/kan/internal/model"
 	"github.com/amterp/ra"
 )
 
@@ -151,6 +152,19 @@ func runCommentAdd(card, body, board string, global, nonInteractive, jsonOutput
 	}
 
 	PrintSuccess("Added comment %s", RenderID(comment.ID))
+	warnUnknownMentions(app, boardName, comment)
+}
+
+// warnUnknownMentions warns about mentions of users who aren't collaborators
+// on the board. The comment is saved either way.
+func warnUnknownMentions(app *App, boardName string, comment *model.Comment) {
+	unknown, err := app.CardService.UnknownMentions(boardName, comment.Mentions)
+	if err != nil {
+		return
+	}
+	for _, name := range unknown {
+		PrintWarning("@%s is not a collaborator on board %q", name, boardName)
+	}
 }
 
 func runCommentEdit(commentID, body, board string, global, nonInteractive bool) {
@@ -219,6 +233,7 @@ func runCommentEdit(commentID, body, board string, global, nonInteractive bool)
 	}
 
 	PrintSuccess("Updated comment %s", RenderID(comment.ID))
+	warnUnknownMentions(app, boardName, comment)
 }
 
 func runCommentDelete(commentID, board string, global, nonInteractive bool) {
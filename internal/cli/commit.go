@@ -5,6 +5,8 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/amterp/kan/internal/config"
+	"github.com/amterp/kan/internal/service"
 	"github.com/amterp/ra"
 )
 
@@ -47,7 +49,21 @@ func runCommit(message string) {
 		Fatal(fmt.Errorf("failed to resolve kan path: %w", err))
 	}
 
-	status, err := app.GitClient.StatusPorcelain(app.ProjectRoot, kanRelPath)
+	// The store's lock file, alias indexes, migration snapshots, migration
+	// locks, audit log and hook history are local state, never project data.
+	// The logs change on every write and would conflict on every merge; hook
+	// history also holds raw hook output, which may include secrets.
+	pathspecs := []string{
+		kanRelPath,
+		":(exclude)" + filepath.Join(kanRelPath, config.LockFile),
+		":(exclude)" + filepath.Join(kanRelPath, service.SnapshotsDir),
+		":(exclude)" + filepath.Join(kanRelPath, config.AuditLogFile),
+		":(exclude)" + filepath.Join(kanRelPath, config.HookHistoryFile),
+		":(exclude,glob)" + filepath.ToSlash(filepath.Join(kanRelPath, config.BoardsDir, "*", config.AliasIndexFile)),
+		":(exclude,glob)" + filepath.ToSlash(filepath.Join(kanRelPath, config.BoardsDir, "*", service.MigrateLockFile)),
+	}
+
+	status, err := app.GitClient.StatusPorcelain(app.ProjectRoot, pathspecs...)
 	if err != nil {
 		Fatal(fmt.Errorf("failed to check git status: %w", err))
 	}
@@ -68,10 +84,10 @@ func runCommit(message string) {
 		message = "chore: update kan files"
 	}
 
-	if err := app.GitClient.Add(app.ProjectRoot, kanRelPath); err != nil {
+	if err := app.GitClient.Add(app.ProjectRoot, pathspecs...); err != nil {
 		Fatal(err)
 	}
-	if err := app.GitClient.Commit(app.ProjectRoot, message, kanRelPath); err != nil {
+	if err := app.GitClient.Commit(app.ProjectRoot, message, pathspecs...); err != nil {
 		Fatal(err)
 	}
 
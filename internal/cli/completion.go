@@ -2,6 +2,7 @@ package cli
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"strings"
 	"sync"
@@ -95,7 +96,7 @@ func completeCards(toComplete string) ([]string, ra.CompletionDirective) {
 		return nil, ra.CompletionDirectiveNoFileComp
 	}
 
-	cards, err := compCtx.cardStore.List(board)
+	cards, err := compCtx.cardStore.List(board, false)
 	if err != nil {
 		return nil, ra.CompletionDirectiveNoFileComp
 	}
@@ -204,7 +205,7 @@ func registerCompletion(parent *ra.Cmd, ctx *CommandContext) {
 
 	ctx.CompletionShell, _ = ra.NewString("shell").
 		SetUsage("Shell type").
-		SetEnumConstraint([]string{"bash", "zsh"}).
+		SetEnumConstraint([]string{"bash", "zsh", "fish"}).
 		Register(cmd)
 
 	ctx.CompletionUsed, _ = parent.RegisterCmd(cmd)
@@ -212,16 +213,56 @@ func registerCompletion(parent *ra.Cmd, ctx *CommandContext) {
 
 // runCompletion outputs the shell completion script to stdout.
 func runCompletion(shell string, rootCmd *ra.Cmd) {
+	if err := writeCompletion(os.Stdout, shell, rootCmd); err != nil {
+		Fatal(err)
+	}
+}
+
+// writeCompletion writes the completion script for shell. Every script
+// delegates to the hidden "kan __complete" command, so boards, columns and
+// cards are listed live by the completion functions above.
+func writeCompletion(w io.Writer, shell string, rootCmd *ra.Cmd) error {
 	var err error
 	switch shell {
 	case "bash":
-		err = rootCmd.GenBashCompletion(os.Stdout)
+		err = rootCmd.GenBashCompletion(w)
 	case "zsh":
-		err = rootCmd.GenZshCompletion(os.Stdout)
+		err = rootCmd.GenZshCompletion(w)
+	case "fish":
+		// ra has no fish generator, so kan carries its own script.
+		_, err = io.WriteString(w, fishCompletionScript)
 	default:
-		Fatal(fmt.Errorf("unsupported shell: %s (supported: bash, zsh)", shell))
+		return fmt.Errorf("unsupported shell: %s (supported: bash, zsh, fish)", shell)
 	}
 	if err != nil {
-		Fatal(fmt.Errorf("failed to generate completion script: %w", err))
+		return fmt.Errorf("failed to generate completion script: %w", err)
 	}
+	return nil
 }
+
+// fishCompletionScript mirrors ra's bash and zsh scripts: it passes the
+// words typed so far to "__complete" and reads the directive bitmask from the
+// last line of its output (1 = error, 4 = no file fallback).
+const fishCompletionScript = `# fish completion for kan
+
+function __kan_complete
+    set -l args (commandline -opc)
+    set -e args[1]
+    set -l out (kan __complete $args (commandline -ct | string collect --allow-empty) 2>/dev/null)
+    or return
+
+    set -l directive (string replace ':' '' -- $out[-1])
+    set -e out[-1]
+    if test (math "bitand($directive, 1)") -ne 0
+        return
+    end
+
+    if test (count $out) -gt 0
+        printf '%s\n' $out
+    else if test (math "bitand($directive, 4)") -eq 0
+        __fish_complete_path (commandline -ct)
+    end
+end
+
+complete -c kan -f -a '(__kan_complete)'
+`
@@ -1,6 +1,11 @@
 package cli
 
-import "testing"
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
 
 func TestBoardFromArgs_LongFlagEquals(t *testing.T) {
 	args := []string{"kan", "show", "--board=main", "card1"}
@@ -87,3 +92,32 @@ func TestBoardFromArgs_FirstFlagWins(t *testing.T) {
 		t.Errorf("Expected 'first' (first flag wins), got %q", got)
 	}
 }
+
+func TestWriteCompletion_Bash(t *testing.T) {
+	ctx := buildRootCmd()
+	var buf bytes.Buffer
+	if err := writeCompletion(&buf, "bash", ctx.RootCmd); err != nil {
+		t.Fatalf("writeCompletion failed: %v", err)
+	}
+	// Boards, columns and cards are completed live by "kan __complete".
+	if !strings.Contains(buf.String(), `kan __complete "${COMP_WORDS[@]:1}"`) {
+		t.Errorf("Expected bash script to invoke kan __complete, got:\n%s", buf.String())
+	}
+}
+
+func TestWriteCompletion_AllShells(t *testing.T) {
+	ctx := buildRootCmd()
+	for _, shell := range []string{"bash", "zsh", "fish"} {
+		var buf bytes.Buffer
+		if err := writeCompletion(&buf, shell, ctx.RootCmd); err != nil {
+			t.Errorf("%s: writeCompletion failed: %v", shell, err)
+			continue
+		}
+		if !strings.Contains(buf.String(), "kan __complete") {
+			t.Errorf("%s: expected script to invoke kan __complete", shell)
+		}
+	}
+	if err := writeCompletion(io.Discard, "powershell", ctx.RootCmd); err == nil {
+		t.Error("Expected error for unsupported shell")
+	}
+}
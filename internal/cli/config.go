@@ -0,0 +1,121 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/amterp/kan/internal/model"
+	"github.com/amterp/kan/internal/store"
+	"github.com/amterp/ra"
+)
+
+func registerConfig(parent *ra.Cmd, ctx *CommandContext) {
+	cmd := ra.NewCmd("config")
+	cmd.SetDescription("Manage global defaults for new boards")
+
+	// config set-default-field
+	setFieldCmd := ra.NewCmd("set-default-field")
+	setFieldCmd.SetDescription("Add or replace a custom field that new boards start with")
+
+	ctx.ConfigSetDefaultFieldName, _ = ra.NewString("name").
+		SetUsage("Field name").
+		Register(setFieldCmd)
+
+	ctx.ConfigSetDefaultFieldType, _ = ra.NewString("type").
+		SetEnumConstraint(model.ValidFieldTypes).
+		SetUsage("Field type").
+		Register(setFieldCmd)
+
+	ctx.ConfigSetDefaultFieldOptions, _ = ra.NewStringSlice("option").
+		SetShort("o").
+		SetOptional(true).
+		SetFlagOnly(true).
+		SetUsage("Allowed value for enum and enum-set fields (repeatable)").
+		Register(setFieldCmd)
+
+	ctx.ConfigSetDefaultFieldUsed, _ = cmd.RegisterCmd(setFieldCmd)
+
+	// config list-defaults
+	listCmd := ra.NewCmd("list-defaults")
+	listCmd.SetDescription("Show the default columns and custom fields for new boards")
+	ctx.ConfigListDefaultsUsed, _ = cmd.RegisterCmd(listCmd)
+
+	ctx.ConfigUsed, _ = parent.RegisterCmd(cmd)
+}
+
+func runConfigSetDefaultField(name, fieldType string, options []string) {
+	if err := model.ValidateCustomFieldName(name); err != nil {
+		Fatal(err)
+	}
+
+	schema := model.CustomFieldSchema{Type: fieldType}
+	hasOptions := fieldType == model.FieldTypeEnum || fieldType == model.FieldTypeEnumSet
+	if hasOptions && len(options) == 0 {
+		Fatal(fmt.Errorf("%s fields need at least one --option", fieldType))
+	}
+	if !hasOptions && len(options) > 0 {
+		Fatal(fmt.Errorf("--option only applies to enum and enum-set fields"))
+	}
+	for _, opt := range options {
+		schema.Options = append(schema.Options, model.CustomFieldOption{Value: strings.TrimSpace(opt)})
+	}
+
+	cfg, err := loadGlobalConfig()
+	if err != nil {
+		Fatal(err)
+	}
+	_, replaced := cfg.DefaultCustomFields[name]
+	cfg.SetDefaultCustomField(name, schema)
+	if err := store.NewGlobalStore().Save(cfg); err != nil {
+		Fatal(err)
+	}
+
+	if replaced {
+		PrintSuccess("Replaced default field %q (%s)", name, fieldType)
+	} else {
+		PrintSuccess("Added default field %q (%s)", name, fieldType)
+	}
+	PrintInfo("New boards will start with it; existing boards are unchanged")
+}
+
+func runConfigListDefaults() {
+	cfg, err := loadGlobalConfig()
+	if err != nil {
+		Fatal(err)
+	}
+
+	if len(cfg.DefaultColumns) == 0 && len(cfg.DefaultCustomFields) == 0 {
+		PrintInfo("No global defaults set. Add a field with 'kan config set-default-field'.")
+		return
+	}
+
+	if len(cfg.DefaultColumns) > 0 {
+		fmt.Println(RenderMuted("Default columns:"))
+		for _, col := range cfg.DefaultColumns {
+			fmt.Printf("  %s %s\n", RenderMuted("•"), col.Name)
+		}
+	}
+
+	if len(cfg.DefaultCustomFields) > 0 {
+		names := make([]string, 0, len(cfg.DefaultCustomFields))
+		for name := range cfg.DefaultCustomFields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		fmt.Println(RenderMuted("Default custom fields:"))
+		for _, name := range names {
+			schema := cfg.DefaultCustomFields[name]
+			line := fmt.Sprintf("  %s %s  %s", RenderMuted("•"), name, RenderMuted(schema.FieldType()))
+			if len(schema.Options) > 0 {
+				values := make([]string, len(schema.Options))
+				for i, opt := range schema.Options {
+					values[i] = opt.Value
+				}
+				line += RenderMuted(" (" + strings.Join(values, ", ") + ")")
+			}
+			fmt.Println(line)
+		}
+	}
+}
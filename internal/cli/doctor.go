@@ -2,8 +2,11 @@ package cli
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"os/signal"
 	"strings"
+	"time"
 
 	"github.com/amterp/kan/internal/service"
 	"github.com/amterp/ra"
@@ -33,10 +36,28 @@ func registerDoctor(parent *ra.Cmd, ctx *CommandContext) {
 		SetCompletionFunc(completeBoards).
 		Register(cmd)
 
+	ctx.DoctorIncludeArchived, _ = ra.NewBool("include-archived").
+		SetOptional(true).
+		SetFlagOnly(true).
+		SetUsage("Also check archived boards").
+		Register(cmd)
+
+	ctx.DoctorWatch, _ = ra.NewInt("watch").
+		SetOptional(true).
+		SetFlagOnly(true).
+		SetUsage("Re-check every this many seconds, printing issues that appear or get resolved, until Ctrl+C").
+		Register(cmd)
+
+	ctx.DoctorWatchExitOnError, _ = ra.NewBool("watch-exit-on-error").
+		SetOptional(true).
+		SetFlagOnly(true).
+		SetUsage("With --watch, exit 1 as soon as errors are found").
+		Register(cmd)
+
 	ctx.DoctorUsed, _ = parent.RegisterCmd(cmd)
 }
 
-func runDoctor(boardName string, fix bool, dryRun bool, jsonOutput bool) {
+func runDoctor(boardName string, fix, dryRun, includeArchived, jsonOutput bool, watch int, watchExitOnError bool) {
 	app, err := NewApp(false)
 	if err != nil {
 		Fatal(err)
@@ -50,6 +71,15 @@ func runDoctor(boardName string, fix bool, dryRun bool, jsonOutput bool) {
 	if fix && dryRun {
 		Fatal(fmt.Errorf("--fix and --dry-run cannot be used together"))
 	}
+	if watch < 0 {
+		Fatal(fmt.Errorf("--watch must be a positive number of seconds"))
+	}
+	if watch > 0 && (fix || jsonOutput) {
+		Fatal(fmt.Errorf("--watch cannot be used with --fix or --json"))
+	}
+	if watchExitOnError && watch == 0 {
+		Fatal(fmt.Errorf("--watch-exit-on-error requires --watch"))
+	}
 
 	// Validate board exists if specified
 	if boardName != "" && !app.BoardStore.Exists(boardName) {
@@ -62,6 +92,12 @@ func runDoctor(boardName string, fix bool, dryRun bool, jsonOutput bool) {
 	}
 
 	doctorService := service.NewDoctorService(app.Paths, app.CardStore)
+	doctorService.SetIncludeArchived(includeArchived)
+
+	if watch > 0 {
+		watchDoctor(doctorService, boardName, time.Duration(watch)*time.Second, dryRun, watchExitOnError)
+		return
+	}
 
 	// Run diagnosis
 	report, err := doctorService.Diagnose(boardName)
@@ -91,6 +127,104 @@ func runDoctor(boardName string, fix bool, dryRun bool, jsonOutput bool) {
 	}
 }
 
+// watchDoctor runs the doctor every interval until interrupted, printing the
+// full report once and then only the issues that appear or get resolved.
+func watchDoctor(doctorService *service.DoctorService, boardName string, interval time.Duration, dryRun, exitOnError bool) {
+	report, err := doctorService.Diagnose(boardName)
+	if err != nil {
+		Fatal(err)
+	}
+	printDoctorReport(report, false, dryRun)
+	if exitOnError && report.HasErrors() {
+		os.Exit(1)
+	}
+	fmt.Println()
+	PrintInfo("Watching for changes every %s (Ctrl+C to stop)", interval)
+
+	watcher := &doctorWatcher{diagnose: func() (*service.DiagnosticReport, error) {
+		return doctorService.Diagnose(boardName)
+	}, previous: report.Issues}
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	defer signal.Stop(interrupt)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-interrupt:
+			return
+		case <-ticker.C:
+			report, err := watcher.check(os.Stdout)
+			if err != nil {
+				PrintWarning("Check failed: %v", err)
+				continue
+			}
+			if exitOnError && report.HasErrors() {
+				os.Exit(1)
+			}
+		}
+	}
+}
+
+// doctorWatcher diffs successive doctor runs.
+type doctorWatcher struct {
+	diagnose func() (*service.DiagnosticReport, error)
+	previous []service.Issue
+}
+
+// check runs the doctor and prints the issues that appeared or were resolved
+// since the last check, if any.
+func (dw *doctorWatcher) check(w io.Writer) (*service.DiagnosticReport, error) {
+	report, err := dw.diagnose()
+	if err != nil {
+		return nil, err
+	}
+	added, resolved := diffIssues(dw.previous, report.Issues)
+	dw.previous = report.Issues
+	if len(added) == 0 && len(resolved) == 0 {
+		return report, nil
+	}
+
+	fmt.Fprintf(w, "\n%s\n", RenderMuted(time.Now().Format(time.TimeOnly)))
+	for _, issue := range added {
+		fmt.Fprintf(w, "%s %s\n", StyleError.Render("[NEW]"), formatIssueLine(issue))
+	}
+	for _, issue := range resolved {
+		fmt.Fprintf(w, "%s %s\n", StyleSuccess.Render("[RESOLVED]"), formatIssueLine(issue))
+	}
+	return report, nil
+}
+
+// issueKey identifies an issue across doctor runs.
+func issueKey(issue service.Issue) string {
+	return issue.Code + "\x00" + issue.Board + "\x00" + issue.CardID
+}
+
+// diffIssues returns the issues in curr but not prev, and those in prev but
+// not curr, each in their original order.
+func diffIssues(prev, curr []service.Issue) (added, resolved []service.Issue) {
+	prevKeys := make(map[string]bool, len(prev))
+	for _, issue := range prev {
+		prevKeys[issueKey(issue)] = true
+	}
+	currKeys := make(map[string]bool, len(curr))
+	for _, issue := range curr {
+		key := issueKey(issue)
+		currKeys[key] = true
+		if !prevKeys[key] {
+			added = append(added, issue)
+		}
+	}
+	for _, issue := range prev {
+		if !currKeys[issueKey(issue)] {
+			resolved = append(resolved, issue)
+		}
+	}
+	return added, resolved
+}
+
 func printDoctorReport(report *service.DiagnosticReport, didFix bool, dryRun bool) {
 	// Print board stats
 	for _, board := range report.Boards {
@@ -207,15 +341,7 @@ func printIssue(issue service.Issue) {
 		code = StyleWarning.Render(fmt.Sprintf("[%s]", issue.Code))
 	}
 
-	location := ""
-	if issue.Board != "" {
-		location = fmt.Sprintf(" %s", RenderMuted(issue.Board))
-		if issue.CardID != "" {
-			location += fmt.Sprintf("/%s", RenderID(issue.CardID))
-		}
-	}
-
-	fmt.Printf("%s %s%s %s\n", icon, code, location, issue.Message)
+	fmt.Printf("%s %s%s %s\n", icon, code, issueLocation(issue), issue.Message)
 
 	if issue.FixError != "" {
 		fmt.Printf("  %s Fix failed: %s\n", StyleError.Render("→"), issue.FixError)
@@ -228,6 +354,22 @@ func printIssue(issue service.Issue) {
 	}
 }
 
+// formatIssueLine renders an issue's code, location and message on one line.
+func formatIssueLine(issue service.Issue) string {
+	return fmt.Sprintf("[%s]%s %s", issue.Code, issueLocation(issue), issue.Message)
+}
+
+func issueLocation(issue service.Issue) string {
+	if issue.Board == "" {
+		return ""
+	}
+	location := fmt.Sprintf(" %s", RenderMuted(issue.Board))
+	if issue.CardID != "" {
+		location += fmt.Sprintf("/%s", RenderID(issue.CardID))
+	}
+	return location
+}
+
 func joinParts(parts []string) string {
 	result := ""
 	for i, p := range parts {
@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/amterp/kan/internal/service"
+	"github.com/amterp/kan/internal/store"
+	"github.com/amterp/kan/internal/version"
+)
+
+func TestDoctorWatcher_ReportsNewAndResolvedIssues(t *testing.T) {
+	paths, cleanup := setupAutoMigrateProject(t, version.CurrentBoardSchema(), version.CurrentCardVersion)
+	defer cleanup()
+
+	doctorService := service.NewDoctorService(paths, store.NewCardStore(paths))
+	watcher := &doctorWatcher{diagnose: func() (*service.DiagnosticReport, error) {
+		return doctorService.Diagnose("")
+	}}
+
+	var out bytes.Buffer
+	if _, err := watcher.check(&out); err != nil {
+		t.Fatalf("check failed: %v", err)
+	}
+	if out.Len() != 0 {
+		t.Fatalf("Expected no output for a healthy board, got %q", out.String())
+	}
+
+	brokenCard := paths.CardPath("main", "broken")
+	if err := os.WriteFile(brokenCard, []byte("{not json"), 0644); err != nil {
+		t.Fatalf("Failed to write card: %v", err)
+	}
+	report, err := watcher.check(&out)
+	if err != nil {
+		t.Fatalf("check failed: %v", err)
+	}
+	if !report.HasErrors() {
+		t.Fatalf("Expected the broken card to be an error, got %+v", report.Issues)
+	}
+	if !strings.Contains(out.String(), "[NEW]") || !strings.Contains(out.String(), service.CodeMalformedCard) {
+		t.Errorf("Expected a [NEW] %s line, got %q", service.CodeMalformedCard, out.String())
+	}
+
+	out.Reset()
+	if _, err := watcher.check(&out); err != nil {
+		t.Fatalf("check failed: %v", err)
+	}
+	if out.Len() != 0 {
+		t.Errorf("Expected no output when nothing changed, got %q", out.String())
+	}
+
+	if err := os.Remove(brokenCard); err != nil {
+		t.Fatalf("Failed to remove card: %v", err)
+	}
+	if _, err := watcher.check(&out); err != nil {
+		t.Fatalf("check failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "[RESOLVED]") {
+		t.Errorf("Expected a [RESOLVED] line, got %q", out.String())
+	}
+}
@@ -94,26 +94,38 @@ func registerEdit(parent *ra.Cmd, ctx *CommandContext) {
 		SetUsage("Set custom field (key=value, repeatable; set fields also accept comma-separated values)").
 		Register(cmd)
 
+	ctx.EditLabels, _ = ra.NewStringSlice("label").
+		SetOptional(true).
+		SetFlagOnly(true).
+		SetUsage("Replace the card's labels (repeatable; --label \"\" clears them)").
+		Register(cmd)
+
 	ctx.EditStrict, _ = ra.NewBool("strict").
 		SetOptional(true).
 		SetFlagOnly(true).
 		SetUsage("Error if wanted fields are missing (default: warn)").
 		Register(cmd)
 
+	ctx.EditForce, _ = ra.NewBool("force").
+		SetOptional(true).
+		SetFlagOnly(true).
+		SetUsage("Move even if the target column is at its limit or its transition rules aren't met").
+		Register(cmd)
+
 	ctx.EditGlobal = registerGlobalFlag(cmd)
 
 	ctx.EditUsed, _ = parent.RegisterCmd(cmd)
 }
 
 func runEdit(idOrAlias, board string, title, description, column string,
-	parent, alias string, placement cardPlacement, fields []string, strict, global, nonInteractive, jsonOutput bool) {
+	parent, alias string, placement cardPlacement, fields []string, labels *[]string, strict, force, global, nonInteractive, jsonOutput bool) {
 
 	// Check if any flags were provided
 	hasFlags := title != "" || description != "" || column != "" ||
-		parent != "" || alias != "" || len(fields) > 0 || placement.isSet()
+		parent != "" || alias != "" || len(fields) > 0 || labels != nil || placement.isSet()
 
 	if !hasFlags && nonInteractive {
-		Fatal(fmt.Errorf("no fields specified to edit (use -t, -d, -c, -p, -a, -f, --position, --before, or --after flags)"))
+		Fatal(fmt.Errorf("no fields specified to edit (use -t, -d, -c, -p, -a, -f, --label, --position, --before, or --after flags)"))
 	}
 
 	app, err := NewAppWithOptions(AppOptions{Interactive: !nonInteractive, UseGlobalBoard: global})
@@ -147,7 +159,7 @@ func runEdit(idOrAlias, board string, title, description, column string,
 	if hasFlags {
 		// Non-interactive path: apply flags directly
 		runEditNonInteractive(app, boardName, card, boardCfg, title, description, column,
-			parent, alias, placement, fields, strict, jsonOutput)
+			parent, alias, placement, fields, labels, strict, force, jsonOutput)
 	} else {
 		// Interactive path: existing menu-based editing
 		runEditInteractive(app, boardName, card, boardCfg)
@@ -157,7 +169,7 @@ func runEdit(idOrAlias, board string, title, description, column string,
 // runEditNonInteractive applies CLI flag changes to the card.
 func runEditNonInteractive(app *App, boardName string, card *model.Card, boardCfg *model.BoardConfig,
 	title, description, column string,
-	parent, alias string, placement cardPlacement, fields []string, strict, jsonOutput bool) {
+	parent, alias string, placement cardPlacement, fields []string, labels *[]string, strict, force, jsonOutput bool) {
 
 	// Parse custom fields early for validation
 	var parsedFields map[string]string
@@ -189,6 +201,8 @@ func runEditNonInteractive(app *App, boardName string, card *model.Card, boardCf
 		Position:      position,
 		BeforeCard:    beforeID,
 		AfterCard:     afterID,
+		Labels:        labels,
+		Force:         force,
 	}
 
 	if title != "" {
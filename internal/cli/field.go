@@ -0,0 +1,277 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/amterp/kan/internal/model"
+	"github.com/amterp/ra"
+)
+
+func registerField(parent *ra.Cmd, ctx *CommandContext) {
+	cmd := ra.NewCmd("field")
+	cmd.SetDescription("Manage a board's custom fields")
+
+	// field add
+	addCmd := ra.NewCmd("add")
+	addCmd.SetDescription("Add a custom field")
+
+	ctx.FieldAddName, _ = ra.NewString("name").
+		SetUsage("Field name").
+		Register(addCmd)
+
+	ctx.FieldAddType, _ = ra.NewString("type").
+		SetShort("t").
+		SetFlagOnly(true).
+		SetEnumConstraint(model.ValidFieldTypes).
+		SetUsage("Field type").
+		Register(addCmd)
+
+	ctx.FieldAddOptions, _ = ra.NewStringSlice("option").
+		SetShort("o").
+		SetOptional(true).
+		SetFlagOnly(true).
+		SetUsage("Allowed value for enum and enum-set fields, as value or value:color (repeatable)").
+		Register(addCmd)
+
+	ctx.FieldAddDescription, _ = ra.NewString("description").
+		SetShort("d").
+		SetOptional(true).
+		SetFlagOnly(true).
+		SetUsage("Description of the field").
+		Register(addCmd)
+
+	ctx.FieldAddBoard, _ = ra.NewString("board").
+		SetShort("b").
+		SetOptional(true).
+		SetFlagOnly(true).
+		SetUsage("Target board").
+		SetCompletionFunc(completeBoards).
+		Register(addCmd)
+
+	ctx.FieldAddUsed, _ = cmd.RegisterCmd(addCmd)
+
+	// field delete
+	deleteCmd := ra.NewCmd("delete")
+	deleteCmd.SetDescription("Delete a custom field and clear it from all cards")
+
+	ctx.FieldDeleteName, _ = ra.NewString("name").
+		SetUsage("Name of the field to delete").
+		Register(deleteCmd)
+
+	ctx.FieldDeleteBoard, _ = ra.NewString("board").
+		SetShort("b").
+		SetOptional(true).
+		SetFlagOnly(true).
+		SetUsage("Target board").
+		SetCompletionFunc(completeBoards).
+		Register(deleteCmd)
+
+	ctx.FieldDeleteUsed, _ = cmd.RegisterCmd(deleteCmd)
+
+	// field edit
+	editCmd := ra.NewCmd("edit")
+	editCmd.SetDescription("Add or remove options of an enum or enum-set field")
+
+	ctx.FieldEditName, _ = ra.NewString("name").
+		SetUsage("Name of the field to edit").
+		Register(editCmd)
+
+	ctx.FieldEditAddOptions, _ = ra.NewStringSlice("add-option").
+		SetOptional(true).
+		SetFlagOnly(true).
+		SetUsage("Option to add, as value or value:color (repeatable)").
+		Register(editCmd)
+
+	ctx.FieldEditRemoveOptions, _ = ra.NewStringSlice("remove-option").
+		SetOptional(true).
+		SetFlagOnly(true).
+		SetUsage("Option value to remove (repeatable)").
+		Register(editCmd)
+
+	ctx.FieldEditBoard, _ = ra.NewString("board").
+		SetShort("b").
+		SetOptional(true).
+		SetFlagOnly(true).
+		SetUsage("Target board").
+		SetCompletionFunc(completeBoards).
+		Register(editCmd)
+
+	ctx.FieldEditUsed, _ = cmd.RegisterCmd(editCmd)
+
+	// field list
+	listCmd := ra.NewCmd("list")
+	listCmd.SetDescription("List custom fields")
+
+	ctx.FieldListBoard, _ = ra.NewString("board").
+		SetShort("b").
+		SetOptional(true).
+		SetFlagOnly(true).
+		SetUsage("Target board").
+		SetCompletionFunc(completeBoards).
+		Register(listCmd)
+
+	ctx.FieldListUsed, _ = cmd.RegisterCmd(listCmd)
+
+	ctx.FieldUsed, _ = parent.RegisterCmd(cmd)
+}
+
+// parseFieldOption parses an option given as "value" or "value:color".
+func parseFieldOption(s string) (model.CustomFieldOption, error) {
+	value, color, _ := strings.Cut(s, ":")
+	opt := model.CustomFieldOption{Value: strings.TrimSpace(value), Color: strings.TrimSpace(color)}
+	if opt.Value == "" {
+		return opt, fmt.Errorf("invalid option %q: value can't be empty", s)
+	}
+	return opt, nil
+}
+
+func parseFieldOptions(raw []string) ([]model.CustomFieldOption, error) {
+	var options []model.CustomFieldOption
+	for _, s := range raw {
+		opt, err := parseFieldOption(s)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, opt)
+	}
+	return options, nil
+}
+
+func runFieldAdd(name, fieldType string, options []string, description, board string, nonInteractive bool) {
+	app, err := NewApp(!nonInteractive)
+	if err != nil {
+		Fatal(err)
+	}
+
+	if err := app.RequireKan(); err != nil {
+		Fatal(err)
+	}
+
+	boardName, err := app.BoardResolver.Resolve(board, !nonInteractive)
+	if err != nil {
+		Fatal(err)
+	}
+
+	parsed, err := parseFieldOptions(options)
+	if err != nil {
+		Fatal(err)
+	}
+
+	schema := model.CustomFieldSchema{Type: fieldType, Options: parsed, Description: description}
+	if err := app.BoardService.AddCustomField(boardName, name, schema); err != nil {
+		Fatal(err)
+	}
+
+	PrintSuccess("Added %s field %q to board %q", fieldType, name, boardName)
+}
+
+func runFieldDelete(name, board string, nonInteractive bool) {
+	app, err := NewApp(!nonInteractive)
+	if err != nil {
+		Fatal(err)
+	}
+
+	if err := app.RequireKan(); err != nil {
+		Fatal(err)
+	}
+
+	boardName, err := app.BoardResolver.Resolve(board, !nonInteractive)
+	if err != nil {
+		Fatal(err)
+	}
+
+	cleared, err := app.BoardService.DeleteCustomField(boardName, name)
+	if err != nil {
+		Fatal(err)
+	}
+
+	if cleared > 0 {
+		cardWord := "cards"
+		if cleared == 1 {
+			cardWord = "card"
+		}
+		PrintSuccess("Deleted field %q from board %q and cleared it from %d %s", name, boardName, cleared, cardWord)
+	} else {
+		PrintSuccess("Deleted field %q from board %q", name, boardName)
+	}
+}
+
+func runFieldEdit(name string, addOptions, removeOptions []string, board string, nonInteractive bool) {
+	app, err := NewApp(!nonInteractive)
+	if err != nil {
+		Fatal(err)
+	}
+
+	if err := app.RequireKan(); err != nil {
+		Fatal(err)
+	}
+
+	boardName, err := app.BoardResolver.Resolve(board, !nonInteractive)
+	if err != nil {
+		Fatal(err)
+	}
+
+	if len(addOptions) == 0 && len(removeOptions) == 0 {
+		Fatal(fmt.Errorf("no changes specified; use --add-option or --remove-option"))
+	}
+
+	add, err := parseFieldOptions(addOptions)
+	if err != nil {
+		Fatal(err)
+	}
+
+	if err := app.BoardService.UpdateFieldOptions(boardName, name, add, removeOptions); err != nil {
+		Fatal(err)
+	}
+
+	PrintSuccess("Updated field %q in board %q", name, boardName)
+}
+
+func runFieldList(board string, nonInteractive, jsonOutput bool) {
+	app, err := NewApp(!nonInteractive)
+	if err != nil {
+		Fatal(err)
+	}
+
+	if err := app.RequireKan(); err != nil {
+		Fatal(err)
+	}
+
+	boardName, err := app.BoardResolver.Resolve(board, !nonInteractive)
+	if err != nil {
+		Fatal(err)
+	}
+
+	boardCfg, err := app.BoardService.Get(boardName)
+	if err != nil {
+		Fatal(err)
+	}
+
+	if jsonOutput {
+		if err := printJson(NewFieldsOutput(boardCfg.CustomFields)); err != nil {
+			Fatal(err)
+		}
+		return
+	}
+
+	if len(boardCfg.CustomFields) == 0 {
+		PrintInfo("No custom fields found")
+		return
+	}
+
+	for _, field := range boardCfg.OrderedCustomFields() {
+		name, schema := field.Name, field.Schema
+		fmt.Printf("%-15s %s\n", name, RenderMuted(schema.FieldType()))
+		if schema.Description != "" {
+			fmt.Printf("  %s\n", RenderMuted(schema.Description))
+		}
+		for _, opt := range schema.Options {
+			if opt.Color != "" {
+				fmt.Printf("  %s %s\n", ColorSwatch(opt.Color), opt.Value)
+			} else {
+				fmt.Printf("  %s %s\n", RenderMuted("•"), opt.Value)
+			}
+		}
+	}
+}
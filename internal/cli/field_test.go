@@ -0,0 +1,127 @@
+package cli
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/amterp/kan/internal/model"
+	"github.com/amterp/kan/internal/service"
+)
+
+func TestParseFieldOption(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    model.CustomFieldOption
+		wantErr bool
+	}{
+		{in: "bug", want: model.CustomFieldOption{Value: "bug"}},
+		{in: "bug:#ef4444", want: model.CustomFieldOption{Value: "bug", Color: "#ef4444"}},
+		{in: " feature : #3b82f6 ", want: model.CustomFieldOption{Value: "feature", Color: "#3b82f6"}},
+		{in: ":#ef4444", wantErr: true},
+		{in: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := parseFieldOption(tt.in)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected error for %q, got %+v", tt.in, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("parseFieldOption(%q) = %+v, want %+v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+// captureStdout returns what fn writes to stdout.
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("pipe: %v", err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	done := make(chan []byte)
+	go func() {
+		data, _ := io.ReadAll(r)
+		done <- data
+	}()
+	fn()
+	w.Close()
+	return string(<-done)
+}
+
+func TestFieldCommands_AddListDelete(t *testing.T) {
+	root := writeProjectBoard(t, "main")
+	t.Setenv("HOME", t.TempDir())
+	t.Chdir(root)
+
+	runFieldAdd("severity", model.FieldTypeEnum, []string{"low:#22c55e", "high"}, "How bad it is", "main", true)
+
+	app, err := NewApp(false)
+	if err != nil {
+		t.Fatalf("NewApp: %v", err)
+	}
+	card, _, err := app.CardService.Add(service.AddCardInput{
+		BoardName:    "main",
+		Title:        "Fix login",
+		CustomFields: map[string]string{"severity": "high"},
+	})
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	var listed struct {
+		Fields map[string]model.CustomFieldSchema `json:"fields"`
+	}
+	out := captureStdout(t, func() { runFieldList("main", true, true) })
+	if err := json.Unmarshal([]byte(out), &listed); err != nil {
+		t.Fatalf("unmarshal %q: %v", out, err)
+	}
+	severity := listed.Fields["severity"]
+	wantOptions := []model.CustomFieldOption{{Value: "low", Color: "#22c55e"}, {Value: "high"}}
+	if severity.Type != model.FieldTypeEnum || severity.Description != "How bad it is" || !slices.Equal(severity.Options, wantOptions) {
+		t.Errorf("Unexpected listed field: %+v", severity)
+	}
+
+	out = captureStdout(t, func() { runFieldList("main", true, false) })
+	if !strings.Contains(out, "severity") || !strings.Contains(out, "How bad it is") || !strings.Contains(out, "low") {
+		t.Errorf("Expected the field in the human listing, got %q", out)
+	}
+
+	runFieldDelete("severity", "main", true)
+
+	boardCfg, err := app.BoardService.Get("main")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if _, ok := boardCfg.CustomFields["severity"]; ok {
+		t.Errorf("Expected the field removed from the board, got %+v", boardCfg.CustomFields)
+	}
+	card, err = app.CardService.Get("main", card.ID)
+	if err != nil {
+		t.Fatalf("Get card: %v", err)
+	}
+	if _, ok := card.CustomFields["severity"]; ok {
+		t.Errorf("Expected the field cleared from the card, got %v", card.CustomFields)
+	}
+
+	out = captureStdout(t, func() { runFieldList("main", true, true) })
+	if strings.TrimSpace(out) != "{\n  \"fields\": {}\n}" {
+		t.Errorf("Expected no fields listed, got %q", out)
+	}
+}
@@ -0,0 +1,181 @@
+package cli
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/amterp/kan/internal/model"
+)
+
+// CardFilter reports whether a card should be kept.
+type CardFilter func(card *model.Card) bool
+
+// FilterChain is a list of card filters applied in order. A card is kept only
+// if it passes every filter.
+type FilterChain []CardFilter
+
+// Apply returns the cards that pass every filter, in their original order.
+func (c FilterChain) Apply(cards []*model.Card) []*model.Card {
+	kept := make([]*model.Card, 0, len(cards))
+	for _, card := range cards {
+		if c.keep(card) {
+			kept = append(kept, card)
+		}
+	}
+	return kept
+}
+
+func (c FilterChain) keep(card *model.Card) bool {
+	for _, filter := range c {
+		if !filter(card) {
+			return false
+		}
+	}
+	return true
+}
+
+// columnFilter keeps cards in the given column.
+func columnFilter(column string) CardFilter {
+	return func(card *model.Card) bool {
+		return card.Column == column
+	}
+}
+
+// labelFilter keeps cards that have the given label.
+func labelFilter(label string) CardFilter {
+	return func(card *model.Card) bool {
+		return slices.Contains(card.GetLabels(), label)
+	}
+}
+
+// fieldFilter keeps cards whose custom field equals value. For set fields,
+// the set must contain value.
+func fieldFilter(name, value string) CardFilter {
+	return func(card *model.Card) bool {
+		raw, ok := card.CustomFields[name]
+		if !ok || raw == nil {
+			return false
+		}
+		switch raw.(type) {
+		case []any, []string:
+			return slices.Contains(getSetValues(card, name), value)
+		}
+		return fmt.Sprint(raw) == value
+	}
+}
+
+// createdAfterFilter keeps cards created after millis.
+func createdAfterFilter(millis int64) CardFilter {
+	return func(card *model.Card) bool {
+		return card.CreatedAtMillis > millis
+	}
+}
+
+// updatedAfterFilter keeps cards last updated after millis.
+func updatedAfterFilter(millis int64) CardFilter {
+	return func(card *model.Card) bool {
+		return card.UpdatedAtMillis > millis
+	}
+}
+
+// overdueFilter keeps cards whose due date is before nowMillis.
+func overdueFilter(nowMillis int64) CardFilter {
+	return func(card *model.Card) bool {
+		return card.IsOverdue(nowMillis)
+	}
+}
+
+// staleFilter keeps cards the board's stale settings flag as stale.
+func staleFilter(stale model.StaleConfig, nowMillis int64) CardFilter {
+	return func(card *model.Card) bool {
+		return stale.IsStale(card, nowMillis)
+	}
+}
+
+// parentFilter keeps cards with a parent if hasParent, or without one if not.
+func parentFilter(hasParent bool) CardFilter {
+	return func(card *model.Card) bool {
+		return (card.Parent != "") == hasParent
+	}
+}
+
+// cardListFilters holds the filter flags of `kan card list`.
+type cardListFilters struct {
+	Column       string
+	Labels       []string
+	Fields       []string // key=value
+	CreatedAfter string   // date
+	UpdatedAfter string   // date
+	Overdue      bool
+	Stale        bool
+	HasParent    bool
+	NoParent     bool
+}
+
+// buildFilterChain turns the filter flags into a FilterChain, validating
+// column and field names against the board.
+func buildFilterChain(f cardListFilters, boardCfg *model.BoardConfig, now time.Time) (FilterChain, error) {
+	var chain FilterChain
+	if f.Column != "" {
+		if !boardCfg.HasColumn(f.Column) {
+			return nil, fmt.Errorf("column %q not found in board %q", f.Column, boardCfg.Name)
+		}
+		chain = append(chain, columnFilter(f.Column))
+	}
+	for _, label := range f.Labels {
+		chain = append(chain, labelFilter(label))
+	}
+	for _, field := range f.Fields {
+		name, value, ok := strings.Cut(field, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid field filter %q (expected key=value)", field)
+		}
+		if _, exists := boardCfg.CustomFields[name]; !exists {
+			return nil, fmt.Errorf("unknown field %q; valid fields: %s", name, customFieldNames(boardCfg))
+		}
+		chain = append(chain, fieldFilter(name, strings.TrimSpace(value)))
+	}
+	if f.CreatedAfter != "" {
+		millis, err := parseFilterDate(f.CreatedAfter, now.Location())
+		if err != nil {
+			return nil, fmt.Errorf("invalid --created-after: %w", err)
+		}
+		chain = append(chain, createdAfterFilter(millis))
+	}
+	if f.UpdatedAfter != "" {
+		millis, err := parseFilterDate(f.UpdatedAfter, now.Location())
+		if err != nil {
+			return nil, fmt.Errorf("invalid --updated-after: %w", err)
+		}
+		chain = append(chain, updatedAfterFilter(millis))
+	}
+	if f.Overdue {
+		chain = append(chain, overdueFilter(now.UnixMilli()))
+	}
+	if f.Stale {
+		chain = append(chain, staleFilter(boardCfg.Stale, now.UnixMilli()))
+	}
+	if f.HasParent && f.NoParent {
+		return nil, fmt.Errorf("--has-parent and --no-parent can't be used together")
+	}
+	if f.HasParent || f.NoParent {
+		chain = append(chain, parentFilter(f.HasParent))
+	}
+	return chain, nil
+}
+
+// parseFilterDate parses a YYYY-MM-DD date (midnight in loc) or an RFC 3339
+// timestamp into Unix millis.
+func parseFilterDate(s string, loc *time.Location) (int64, error) {
+	if t, err := time.ParseInLocation("2006-01-02", s, loc); err == nil {
+		return t.UnixMilli(), nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a date (expected YYYY-MM-DD or RFC 3339)", s)
+	}
+	return t.UnixMilli(), nil
+}
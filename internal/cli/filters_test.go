@@ -0,0 +1,180 @@
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"github.com/amterp/kan/internal/model"
+	"github.com/amterp/kan/internal/service"
+)
+
+// filterIDs returns the IDs of the cards that pass filter.
+func filterIDs(filter CardFilter, cards []*model.Card) []string {
+	var ids []string
+	chain := FilterChain{filter}
+	for _, card := range chain.Apply(cards) {
+		ids = append(ids, card.ID)
+	}
+	return ids
+}
+
+func assertIDs(t *testing.T, got []string, want ...string) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+}
+
+func TestColumnFilter(t *testing.T) {
+	cards := []*model.Card{{ID: "a", Column: "todo"}, {ID: "b", Column: "done"}}
+	assertIDs(t, filterIDs(columnFilter("done"), cards), "b")
+}
+
+func TestLabelFilter(t *testing.T) {
+	a := &model.Card{ID: "a"}
+	a.SetLabels([]string{"ui", "urgent"})
+	b := &model.Card{ID: "b"}
+	b.SetLabels([]string{"backend"})
+	cards := []*model.Card{a, b, {ID: "c"}}
+
+	assertIDs(t, filterIDs(labelFilter("urgent"), cards), "a")
+	assertIDs(t, filterIDs(labelFilter("missing"), cards))
+}
+
+func TestFieldFilter(t *testing.T) {
+	cards := []*model.Card{
+		{ID: "a", CustomFields: map[string]any{"priority": "high", "topics": []any{"auth", "api"}, "points": float64(3), "blocked": true}},
+		{ID: "b", CustomFields: map[string]any{"priority": "low", "topics": []any{"ui"}}},
+		{ID: "c"},
+	}
+	assertIDs(t, filterIDs(fieldFilter("priority", "high"), cards), "a")
+	assertIDs(t, filterIDs(fieldFilter("topics", "api"), cards), "a")
+	assertIDs(t, filterIDs(fieldFilter("points", "3"), cards), "a")
+	assertIDs(t, filterIDs(fieldFilter("blocked", "true"), cards), "a")
+	assertIDs(t, filterIDs(fieldFilter("priority", "medium"), cards))
+}
+
+func TestDateFilters(t *testing.T) {
+	cards := []*model.Card{
+		{ID: "old", CreatedAtMillis: 1000, UpdatedAtMillis: 5000},
+		{ID: "new", CreatedAtMillis: 3000, UpdatedAtMillis: 3000},
+	}
+	assertIDs(t, filterIDs(createdAfterFilter(2000), cards), "new")
+	assertIDs(t, filterIDs(updatedAfterFilter(4000), cards), "old")
+}
+
+func TestOverdueFilter(t *testing.T) {
+	cards := []*model.Card{{ID: "past", DueAtMillis: 1000}, {ID: "future", DueAtMillis: 9000}, {ID: "none"}}
+	assertIDs(t, filterIDs(overdueFilter(5000), cards), "past")
+}
+
+func TestStaleFilter(t *testing.T) {
+	const day = int64(24 * time.Hour / time.Millisecond)
+	now := 100 * day
+	cards := []*model.Card{
+		{ID: "stale", Column: "todo", UpdatedAtMillis: now - 10*day},
+		{ID: "fresh", Column: "todo", UpdatedAtMillis: now - day},
+		{ID: "exempt", Column: "done", UpdatedAtMillis: now - 10*day},
+	}
+	stale := model.StaleConfig{StaleDays: 7, ExemptColumns: []string{"done"}}
+	assertIDs(t, filterIDs(staleFilter(stale, now), cards), "stale")
+	assertIDs(t, filterIDs(staleFilter(model.StaleConfig{}, now), cards))
+}
+
+func TestParentFilter(t *testing.T) {
+	cards := []*model.Card{{ID: "child", Parent: "p"}, {ID: "root"}}
+	assertIDs(t, filterIDs(parentFilter(true), cards), "child")
+	assertIDs(t, filterIDs(parentFilter(false), cards), "root")
+}
+
+func TestBuildFilterChain_Errors(t *testing.T) {
+	boardCfg := &model.BoardConfig{
+		Name:         "main",
+		Columns:      []model.Column{{Name: "todo"}},
+		CustomFields: map[string]model.CustomFieldSchema{"priority": {Type: model.FieldTypeString}},
+	}
+	tests := []struct {
+		name    string
+		filters cardListFilters
+	}{
+		{"unknown column", cardListFilters{Column: "nope"}},
+		{"unknown field", cardListFilters{Fields: []string{"nope=1"}}},
+		{"malformed field", cardListFilters{Fields: []string{"priority"}}},
+		{"bad date", cardListFilters{CreatedAfter: "last week"}},
+		{"both parent flags", cardListFilters{HasParent: true, NoParent: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := buildFilterChain(tt.filters, boardCfg, time.Now()); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+}
+
+func TestParseFilterDate(t *testing.T) {
+	got, err := parseFilterDate("2024-01-02", time.UTC)
+	if err != nil || got != time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC).UnixMilli() {
+		t.Errorf("parseFilterDate(date) = %d, %v", got, err)
+	}
+	got, err = parseFilterDate("2024-01-02T03:04:05Z", time.UTC)
+	if err != nil || got != time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC).UnixMilli() {
+		t.Errorf("parseFilterDate(RFC 3339) = %d, %v", got, err)
+	}
+}
+
+func TestCardList_CombinedFilters(t *testing.T) {
+	root := writeProjectBoard(t, "main")
+	t.Setenv("HOME", t.TempDir())
+	t.Chdir(root)
+
+	app, err := NewApp(false)
+	if err != nil {
+		t.Fatalf("NewApp: %v", err)
+	}
+	add := func(title string, labels []string, parent string, dueAtMillis int64) *model.Card {
+		t.Helper()
+		card, _, err := app.CardService.Add(service.AddCardInput{
+			BoardName:   "main",
+			Title:       title,
+			Labels:      &labels,
+			Parent:      parent,
+			DueAtMillis: dueAtMillis,
+		})
+		if err != nil {
+			t.Fatalf("Add %q: %v", title, err)
+		}
+		return card
+	}
+	past := time.Now().Add(-24 * time.Hour).UnixMilli()
+	future := time.Now().Add(24 * time.Hour).UnixMilli()
+
+	want := add("Late bug", []string{"bug"}, "", past)
+	add("Late feature", []string{"feature"}, "", past)
+	add("Future bug", []string{"bug"}, "", future)
+	add("Late sub-bug", []string{"bug"}, want.ID, past)
+
+	boardCfg, err := app.BoardService.Get("main")
+	if err != nil {
+		t.Fatalf("Get board: %v", err)
+	}
+	chain, err := buildFilterChain(cardListFilters{Labels: []string{"bug"}, Overdue: true, NoParent: true}, boardCfg, time.Now())
+	if err != nil {
+		t.Fatalf("buildFilterChain: %v", err)
+	}
+	cards, err := app.CardService.List("main", "")
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+
+	var ids []string
+	for _, card := range chain.Apply(cards) {
+		ids = append(ids, card.ID)
+	}
+	assertIDs(t, ids, want.ID)
+}
@@ -149,7 +149,7 @@ func TestResolveCardWithBoard_GlobalScopedToBoard(t *testing.T) {
 	}
 
 	// The archive card must not be reachable under -g - it's on a different board.
-	if _, err := app.ResolveCardWithBoard("", "archived-task", false); !kanerr.IsNotFound(err) {
+	if _, err := app.ResolveCardWithBoard("", "archived-task", false); !kanerr.IsCode(err, kanerr.CodeCardNotFound) {
 		t.Errorf("expected not-found for a card outside the global board, got %v", err)
 	}
 
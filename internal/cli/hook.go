@@ -0,0 +1,265 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/amterp/kan/internal/model"
+	"github.com/amterp/kan/internal/service"
+	"github.com/amterp/kan/internal/util"
+	"github.com/amterp/ra"
+)
+
+// defaultHookHistoryLimit is how many entries `kan hook history` shows when
+// --limit is unset.
+const defaultHookHistoryLimit = 20
+
+func registerHook(parent *ra.Cmd, ctx *CommandContext) {
+	cmd := ra.NewCmd("hook")
+	cmd.SetDescription("Inspect and test pattern hooks")
+
+	// hook history
+	historyCmd := ra.NewCmd("history")
+	historyCmd.SetDescription("Show recent pattern hook executions, newest first")
+
+	ctx.HookHistoryBoard, _ = ra.NewString("board").
+		SetShort("b").
+		SetOptional(true).
+		SetFlagOnly(true).
+		SetUsage("Target board").
+		SetCompletionFunc(completeBoards).
+		Register(historyCmd)
+
+	ctx.HookHistoryLimit, _ = ra.NewInt("limit").
+		SetShort("n").
+		SetDefault(defaultHookHistoryLimit).
+		SetFlagOnly(true).
+		SetUsage("Maximum entries to show").
+		Register(historyCmd)
+
+	ctx.HookHistoryUsed, _ = cmd.RegisterCmd(historyCmd)
+
+	// hook test
+	testCmd := ra.NewCmd("test")
+	testCmd.SetDescription("Run a pattern hook against a card and show its output, without changing the card")
+
+	ctx.HookTestName, _ = ra.NewString("hook-name").
+		SetUsage("Name of the pattern hook to run").
+		Register(testCmd)
+
+	ctx.HookTestCard, _ = ra.NewString("card").
+		SetShort("c").
+		SetFlagOnly(true).
+		SetUsage("Card ID or alias to run the hook for").
+		SetCompletionFunc(completeCards).
+		Register(testCmd)
+
+	ctx.HookTestBoard, _ = ra.NewString("board").
+		SetShort("b").
+		SetOptional(true).
+		SetFlagOnly(true).
+		SetUsage("Board name").
+		SetCompletionFunc(completeBoards).
+		Register(testCmd)
+
+	ctx.HookTestCaptureFields, _ = ra.NewBool("capture-fields").
+		SetOptional(true).
+		SetFlagOnly(true).
+		SetUsage("Also show the custom fields the hook's output would set").
+		Register(testCmd)
+
+	ctx.HookTestUsed, _ = cmd.RegisterCmd(testCmd)
+
+	ctx.HookUsed, _ = parent.RegisterCmd(cmd)
+}
+
+// hookHistoryOutput is the --json shape for `kan hook history`.
+type hookHistoryOutput struct {
+	Board   string                   `json:"board"`
+	Entries []model.HookHistoryEntry `json:"entries"`
+}
+
+func runHookHistory(board string, limit int, nonInteractive, jsonOutput bool) {
+	if limit < 1 {
+		Fatal(fmt.Errorf("--limit must be a positive integer"))
+	}
+
+	app, err := NewApp(!nonInteractive)
+	if err != nil {
+		Fatal(err)
+	}
+
+	if err := app.RequireKan(); err != nil {
+		Fatal(err)
+	}
+
+	boardName, err := app.BoardResolver.Resolve(board, !nonInteractive)
+	if err != nil {
+		Fatal(err)
+	}
+
+	entries, err := app.HookHistoryStore.List(boardName, limit)
+	if err != nil {
+		Fatal(err)
+	}
+
+	if jsonOutput {
+		if err := printJson(hookHistoryOutput{Board: boardName, Entries: entries}); err != nil {
+			Fatal(err)
+		}
+		return
+	}
+
+	if len(entries) == 0 {
+		PrintInfo("No hook executions recorded for board %q", boardName)
+		return
+	}
+
+	for _, e := range entries {
+		icon := StyleSuccess.Render(IconSuccess)
+		if !e.Success {
+			icon = StyleError.Render(IconError)
+		}
+		fmt.Printf("%s %s  %s  %s  %s\n",
+			icon,
+			e.HookName,
+			RenderID(e.CardID),
+			RenderMuted(util.FormatMillis(e.Timestamp)),
+			RenderMuted(fmt.Sprintf("%dms", e.DurationMs)),
+		)
+		if e.Error != "" {
+			fmt.Printf("    %s\n", StyleError.Render(e.Error))
+		}
+	}
+}
+
+// hookTestOutput is the --json shape for `kan hook test`.
+type hookTestOutput struct {
+	Hook       string            `json:"hook"`
+	Board      string            `json:"board"`
+	CardID     string            `json:"card_id"`
+	Success    bool              `json:"success"`
+	ExitCode   int               `json:"exit_code"`
+	DurationMs int64             `json:"duration_ms"`
+	Stdout     string            `json:"stdout"`
+	Stderr     string            `json:"stderr"`
+	Error      string            `json:"error,omitempty"`
+	FieldsSet  map[string]string `json:"fields_set,omitempty"` // With --capture-fields
+}
+
+// runHookTest runs one pattern hook for a card and reports what it did. Hook
+// fields are never applied and the run isn't recorded in the hook history.
+// Exits 1 if the hook fails.
+func runHookTest(hookName, cardIDOrAlias, board string, captureFields, nonInteractive, jsonOutput bool) {
+	app, err := NewApp(!nonInteractive)
+	if err != nil {
+		Fatal(err)
+	}
+
+	if err := app.RequireKan(); err != nil {
+		Fatal(err)
+	}
+
+	resolved, err := app.ResolveCardWithBoard(board, cardIDOrAlias, !nonInteractive)
+	if err != nil {
+		Fatal(err)
+	}
+	boardName, card := resolved.BoardName, resolved.Card
+
+	boardCfg, err := app.BoardService.Get(boardName)
+	if err != nil {
+		Fatal(err)
+	}
+	var hook *model.PatternHook
+	var names []string
+	for i := range boardCfg.PatternHooks {
+		names = append(names, boardCfg.PatternHooks[i].Name)
+		if boardCfg.PatternHooks[i].Name == hookName {
+			hook = &boardCfg.PatternHooks[i]
+		}
+	}
+	if hook == nil {
+		if len(names) == 0 {
+			Fatal(fmt.Errorf("hook %q not found (board %q has no pattern hooks)", hookName, boardName))
+		}
+		Fatal(fmt.Errorf("hook %q not found (available: %s)", hookName, strings.Join(names, ", ")))
+	}
+	if len(app.HookService.FindMatchingHooks([]model.PatternHook{*hook}, card.Title)) == 0 {
+		Fatal(fmt.Errorf("hook '%s' pattern '%s' does not match title '%s'", hook.Name, hook.PatternTitle, card.Title))
+	}
+
+	result := app.HookService.ExecuteHook(*hook, card, boardName)
+	var fieldsSet map[string]string
+	if captureFields {
+		fieldsSet = app.CardService.HookFieldChanges(card, boardCfg, result)
+	}
+
+	if jsonOutput {
+		out := hookTestOutput{
+			Hook:       hook.Name,
+			Board:      boardName,
+			CardID:     card.ID,
+			Success:    result.Success,
+			ExitCode:   result.ExitCode,
+			DurationMs: result.Duration.Milliseconds(),
+			Stdout:     result.Stdout,
+			Stderr:     result.Stderr,
+			FieldsSet:  fieldsSet,
+		}
+		if result.Error != nil {
+			out.Error = result.Error.Error()
+		}
+		if err := printJson(out); err != nil {
+			Fatal(err)
+		}
+	} else {
+		printHookTestResult(result, captureFields, fieldsSet)
+	}
+
+	if !result.Success {
+		os.Exit(1)
+	}
+}
+
+func printHookTestResult(result *service.HookResult, captureFields bool, fieldsSet map[string]string) {
+	if result.Success {
+		PrintSuccess("Hook %q succeeded", result.HookName)
+	} else {
+		PrintError("Hook %q failed: %v", result.HookName, result.Error)
+	}
+	fmt.Printf("  Exit code: %d\n", result.ExitCode)
+	fmt.Printf("  Duration:  %s\n", result.Duration.Round(time.Millisecond))
+
+	for _, stream := range []struct{ name, output string }{{"stdout", result.Stdout}, {"stderr", result.Stderr}} {
+		fmt.Println()
+		if stream.output == "" {
+			fmt.Printf("%s %s\n", RenderBold(stream.name+":"), RenderMuted("(empty)"))
+			continue
+		}
+		fmt.Println(RenderBold(stream.name + ":"))
+		for _, line := range strings.Split(stream.output, "\n") {
+			fmt.Printf("  %s\n", line)
+		}
+	}
+
+	if !captureFields {
+		return
+	}
+	fmt.Println()
+	if len(fieldsSet) == 0 {
+		PrintInfo("The hook would not set any fields")
+		return
+	}
+	fmt.Println(RenderBold("Would set:"))
+	keys := make([]string, 0, len(fieldsSet))
+	for key := range fieldsSet {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fmt.Printf("  %s = %s\n", key, fieldsSet[key])
+	}
+}
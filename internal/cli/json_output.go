@@ -15,39 +15,55 @@ import (
 // If you add fields to model.Card, add them here too. See TestCardJsonFieldSync.
 type cardJson struct {
 	// Note: Version (_v) is intentionally omitted - it's an internal schema version
-	ID              string               `json:"id"`
-	Alias           string               `json:"alias"`
-	AliasExplicit   bool                 `json:"alias_explicit"`
-	Title           string               `json:"title"`
-	Description     string               `json:"description,omitempty"`
-	Parent          string               `json:"parent,omitempty"`
-	Creator         string               `json:"creator"`
-	CreatedAtMillis int64                `json:"created_at_millis"`
-	UpdatedAtMillis int64                `json:"updated_at_millis"`
-	Comments        []model.Comment      `json:"comments,omitempty"`
-	History         []model.HistoryEntry `json:"history,omitempty"`
-	Column          string               `json:"column"`
-	Position        string               `json:"position"`
-	Board           string               `json:"board,omitempty"`
-	CustomFields    map[string]any       `json:"-"` // Merged at top level like model.Card
+	ID               string                `json:"id"`
+	Alias            string                `json:"alias"`
+	AliasExplicit    bool                  `json:"alias_explicit"`
+	Title            string                `json:"title"`
+	Description      string                `json:"description,omitempty"`
+	Parent           string                `json:"parent,omitempty"`
+	Creator          string                `json:"creator"`
+	CreatedAtMillis  int64                 `json:"created_at_millis"`
+	UpdatedAtMillis  int64                 `json:"updated_at_millis"`
+	Comments         []model.Comment       `json:"comments,omitempty"`
+	History          []model.HistoryEntry  `json:"history,omitempty"`
+	DueAtMillis      int64                 `json:"due_at_millis,omitempty"`
+	Blocks           []string              `json:"blocks,omitempty"`
+	BlockedBy        []string              `json:"blocked_by,omitempty"`
+	Checklist        []model.ChecklistItem `json:"checklist,omitempty"`
+	Column           string                `json:"column"`
+	Position         string                `json:"position"`
+	Archived         bool                  `json:"archived,omitempty"`
+	ArchivedAtMillis int64                 `json:"archived_at_millis,omitempty"`
+	LastColumn       string                `json:"last_column,omitempty"`
+	DoneAtMillis     int64                 `json:"done_at_millis,omitempty"`
+	Board            string                `json:"board,omitempty"`
+	CustomFields     map[string]any        `json:"-"` // Merged at top level like model.Card
 }
 
 func cardToJson(c *model.Card) cardJson {
 	return cardJson{
-		ID:              c.ID,
-		Alias:           c.Alias,
-		AliasExplicit:   c.AliasExplicit,
-		Title:           c.Title,
-		Description:     c.Description,
-		Parent:          c.Parent,
-		Creator:         c.Creator,
-		CreatedAtMillis: c.CreatedAtMillis,
-		UpdatedAtMillis: c.UpdatedAtMillis,
-		Comments:        c.Comments,
-		History:         c.History,
-		Column:          c.Column,
-		Position:        c.Position,
-		CustomFields:    c.CustomFields,
+		ID:               c.ID,
+		Alias:            c.Alias,
+		AliasExplicit:    c.AliasExplicit,
+		Title:            c.Title,
+		Description:      c.Description,
+		Parent:           c.Parent,
+		Creator:          c.Creator,
+		CreatedAtMillis:  c.CreatedAtMillis,
+		UpdatedAtMillis:  c.UpdatedAtMillis,
+		Comments:         c.Comments,
+		History:          c.History,
+		DueAtMillis:      c.DueAtMillis,
+		Blocks:           c.Blocks,
+		BlockedBy:        c.BlockedBy,
+		Checklist:        c.Checklist,
+		Column:           c.Column,
+		Position:         c.Position,
+		Archived:         c.Archived,
+		ArchivedAtMillis: c.ArchivedAtMillis,
+		LastColumn:       c.LastColumn,
+		DoneAtMillis:     c.DoneAtMillis,
+		CustomFields:     c.CustomFields,
 	}
 }
 
@@ -94,6 +110,8 @@ type hookResultJson struct {
 	ExitCode   int    `json:"exit_code,omitempty"`
 	DurationMs int64  `json:"duration_ms,omitempty"`
 	Error      string `json:"error,omitempty"`
+
+	FieldsSet map[string]string `json:"fields_set,omitempty"`
 }
 
 func hookResultToJson(r *service.HookResult) hookResultJson {
@@ -104,6 +122,7 @@ func hookResultToJson(r *service.HookResult) hookResultJson {
 		Stderr:     r.Stderr,
 		ExitCode:   r.ExitCode,
 		DurationMs: r.Duration.Milliseconds(),
+		FieldsSet:  r.FieldsSet,
 	}
 	if r.Error != nil {
 		result.Error = r.Error.Error()
@@ -165,6 +184,21 @@ type ColumnInfo struct {
 	Description string `json:"description,omitempty"`
 	Limit       int    `json:"limit,omitempty"`
 	CardCount   int    `json:"card_count"`
+	AtLimit     bool   `json:"at_limit"`
+}
+
+// FieldsOutput wraps a board's custom fields for JSON output.
+type FieldsOutput struct {
+	Fields map[string]model.CustomFieldSchema `json:"fields"`
+}
+
+// NewFieldsOutput creates a FieldsOutput from a board's custom fields.
+// Always returns an empty object (not null) when there are no fields.
+func NewFieldsOutput(fields map[string]model.CustomFieldSchema) FieldsOutput {
+	if fields == nil {
+		fields = map[string]model.CustomFieldSchema{}
+	}
+	return FieldsOutput{Fields: fields}
 }
 
 // BoardsOutput wraps a list of board names for JSON output.
@@ -197,11 +231,19 @@ type BoardDescribeInfo struct {
 	Name          string                             `json:"name"`
 	Schema        string                             `json:"schema"`
 	DefaultColumn string                             `json:"default_column"`
+	DoneColumns   []string                           `json:"done_columns,omitempty"`
 	Columns       []BoardDescribeColumnInfo          `json:"columns"`
 	CustomFields  map[string]model.CustomFieldSchema `json:"custom_fields,omitempty"`
 	CardDisplay   model.CardDisplayConfig            `json:"card_display,omitempty"`
+	Alias         model.AliasConfig                  `json:"alias,omitempty"`
 	LinkRules     []model.LinkRule                   `json:"link_rules,omitempty"`
 	PatternHooks  []model.PatternHook                `json:"pattern_hooks,omitempty"`
+	Stale         model.StaleConfig                  `json:"stale,omitempty"`
+	Templates     []model.CardTemplate               `json:"templates,omitempty"`
+	Collaborators []string                           `json:"collaborators,omitempty"`
+	Includes      []string                           `json:"include,omitempty"`
+
+	ArchivedAtMillis int64 `json:"archived_at_millis,omitempty"`
 }
 
 // BoardDescribeColumnInfo contains column data for board describe JSON output.
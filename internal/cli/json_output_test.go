@@ -175,6 +175,7 @@ func TestBoardDescribeFieldSync(t *testing.T) {
 		"KanSchema":     "Exposed as 'Schema' (renamed for cleaner output)",
 		"Columns":       "Transformed to BoardDescribeColumnInfo (adds CardCount, IsDefault)",
 		"DefaultColumn": "Surfaced as IsDefault flag on individual columns instead",
+		"Revision":      "Write counter for API If-Revision checks, not useful in describe output",
 	}
 
 	// Fields that exist in BoardDescribeInfo but not in BoardConfig
@@ -248,6 +249,11 @@ func TestEmptySlicesNotNull(t *testing.T) {
 			output: NewColumnsOutput(nil),
 			check:  `"columns":[]`,
 		},
+		{
+			name:   "empty fields",
+			output: NewFieldsOutput(nil),
+			check:  `"fields":{}`,
+		},
 		{
 			name:   "empty cards",
 			output: NewListOutput(nil),
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/amterp/kan/internal/model"
+	"github.com/amterp/kan/internal/util"
 	"github.com/amterp/ra"
 )
 
@@ -47,7 +48,7 @@ func registerList(parent *ra.Cmd, ctx *CommandContext) {
 		SetShort("s").
 		SetOptional(true).
 		SetFlagOnly(true).
-		SetUsage("Sort cards within each column by a custom field (e.g. priority; see 'kan board describe')").
+		SetUsage("Sort cards within each column by a custom field (e.g. priority; see 'kan board describe') or by due_date").
 		SetCompletionFunc(completeCustomFields).
 		Register(cmd)
 
@@ -86,8 +87,9 @@ func runList(board, column, sortField string, global, descending, jsonOutput boo
 		Fatal(err)
 	}
 
-	// Validate the sort field (if any) names a defined custom field.
-	if sortField != "" {
+	// Validate the sort field (if any) names a defined custom field or the
+	// built-in due date sort.
+	if sortField != "" && sortField != model.SortByDueDate {
 		if _, ok := boardCfg.CustomFields[sortField]; !ok {
 			Fatal(fmt.Errorf("unknown sort field %q; valid fields: %s",
 				sortField, customFieldNames(boardCfg)))
@@ -215,7 +217,7 @@ func renderBadges(card *model.Card, boardCfg *model.BoardConfig) string {
 			continue
 		}
 
-		if schema.Type == model.FieldTypeBoolean {
+		if schema.FieldType() == model.FieldTypeBoolean {
 			if b, ok := card.CustomFields[fieldName].(bool); ok && b {
 				color := badgeColor("boolean", fieldName, fieldName)
 				if rendered := RenderTypeIndicator(fieldName, color); rendered != "" {
@@ -227,7 +229,7 @@ func renderBadges(card *model.Card, boardCfg *model.BoardConfig) string {
 			for _, val := range values {
 				color := boardCfg.GetOptionColor(fieldName, val)
 				if color == "" {
-					color = badgeColor(schema.Type, fieldName, val)
+					color = badgeColor(schema.FieldType(), fieldName, val)
 				}
 				if rendered := RenderTypeIndicator(val, color); rendered != "" {
 					parts = append(parts, rendered)
@@ -241,6 +243,21 @@ func renderBadges(card *model.Card, boardCfg *model.BoardConfig) string {
 	return "  " + strings.Join(parts, " ")
 }
 
+// renderTags renders the values of the card_display.tags fields as muted
+// #value tags - quieter than badges, which suits open-ended free-set values.
+func renderTags(card *model.Card, boardCfg *model.BoardConfig) string {
+	var parts []string
+	for _, fieldName := range boardCfg.CardDisplay.Tags {
+		for _, val := range getSetValues(card, fieldName) {
+			parts = append(parts, "#"+val)
+		}
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "  " + RenderMuted(strings.Join(parts, " "))
+}
+
 // calculateColumnWidths calculates the max widths for ID and type indicator columns.
 func calculateColumnWidths(cards []*model.Card, boardCfg *model.BoardConfig) cardColumnWidths {
 	widths := cardColumnWidths{}
@@ -281,5 +298,10 @@ func printCardLine(card *model.Card, boardCfg *model.BoardConfig, widths cardCol
 		}
 	}
 	badges := renderBadges(card, boardCfg)
-	fmt.Printf("  %s  %s%s%s\n", renderedID, typeIndicator, card.Title, badges)
+	tags := renderTags(card, boardCfg)
+	overdue := ""
+	if card.IsOverdue(util.NowMillis()) {
+		overdue = "  " + StyleError.Render("[overdue]")
+	}
+	fmt.Printf("  %s  %s%s%s%s%s\n", renderedID, typeIndicator, card.Title, badges, tags, overdue)
 }
@@ -1,9 +1,14 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/BurntSushi/toml"
 	"github.com/amterp/kan/internal/config"
@@ -24,16 +29,53 @@ func registerMigrate(parent *ra.Cmd, ctx *CommandContext) {
 		SetUsage("Show what would be changed without modifying files").
 		Register(cmd)
 
+	ctx.MigrateEstimate, _ = ra.NewBool("estimate").
+		SetOptional(true).
+		SetFlagOnly(true).
+		SetUsage("Estimate how many files would change and how long it would take, without migrating").
+		Register(cmd)
+
+	ctx.MigrateDiff, _ = ra.NewBool("diff").
+		SetOptional(true).
+		SetFlagOnly(true).
+		SetUsage("Print the changes a migration would make and exit 1 if there are any (for CI)").
+		Register(cmd)
+
 	ctx.MigrateAll, _ = ra.NewBool("all").
 		SetOptional(true).
 		SetFlagOnly(true).
 		SetUsage("Migrate all projects registered in global config").
 		Register(cmd)
 
+	ctx.MigrateConcurrency, _ = ra.NewInt("concurrency").
+		SetOptional(true).
+		SetFlagOnly(true).
+		SetDefault(service.DefaultMigrateConcurrency).
+		SetUsage("Number of boards (with --all, projects) to migrate at once").
+		Register(cmd)
+
+	ctx.MigrateRollback, _ = ra.NewString("rollback").
+		SetOptional(true).
+		SetFlagOnly(true).
+		SetUsage("Restore the project's data from a pre-migration snapshot ID").
+		Register(cmd)
+
+	ctx.MigrateTarget, _ = ra.NewInt("target-version").
+		SetOptional(true).
+		SetFlagOnly(true).
+		SetUsage("Downgrade boards to this older board schema version (refused if data would be lost)").
+		Register(cmd)
+
+	ctx.MigrateBreakLock, _ = ra.NewBool("break-lock").
+		SetOptional(true).
+		SetFlagOnly(true).
+		SetUsage("Remove board migration locks left by a migration that crashed or was killed, then migrate").
+		Register(cmd)
+
 	ctx.MigrateUsed, _ = parent.RegisterCmd(cmd)
 }
 
-func runMigrate(dryRun bool) {
+func runMigrate(dryRun, breakLock bool, concurrency int) {
 	// Discover project without version validation
 	// Pass nil for global config to avoid loading it (which might fail version checks)
 	result, err := discovery.DiscoverProject(&model.GlobalConfig{})
@@ -47,6 +89,19 @@ func runMigrate(dryRun bool) {
 	paths := config.NewPaths(result.ProjectRoot, result.DataLocation)
 	migrateService := service.NewMigrateService(paths)
 
+	if breakLock {
+		broken, err := migrateService.BreakLocks()
+		if err != nil {
+			Fatal(err)
+		}
+		for _, board := range broken {
+			PrintWarning("Removed migration lock on board %q", board)
+		}
+		if len(broken) == 0 {
+			PrintInfo("No migration locks to remove")
+		}
+	}
+
 	plan, err := migrateService.Plan()
 	if err != nil {
 		Fatal(err)
@@ -67,17 +122,163 @@ func runMigrate(dryRun bool) {
 		fmt.Println()
 	}
 
-	if err := migrateService.Execute(plan, dryRun); err != nil {
+	migrateResult, err := migrateService.ExecuteParallel(plan, dryRun, concurrency, nil)
+	if err != nil {
+		if migrateResult != nil && migrateResult.SnapshotID != "" {
+			PrintInfo("Undo partial changes with: kan migrate --rollback %s", migrateResult.SnapshotID)
+		}
+		if errors.Is(err, service.ErrMigrationInProgress) {
+			PrintInfo("If no other migration is running, remove the stale lock with: kan migrate --break-lock")
+		}
 		Fatal(err)
 	}
 
 	if !dryRun {
 		fmt.Println()
 		PrintSuccess("Migration complete.")
+		printSnapshotTip(migrateResult)
 		fmt.Println(RenderMuted("Tip: Commit this migration separately. Use 'git blame --ignore-rev' to hide bulk changes."))
 	}
 }
 
+func runMigrateEstimate(all bool, dryRun bool) {
+	if all || dryRun {
+		Fatal(fmt.Errorf("--estimate cannot be combined with --all or --dry-run"))
+	}
+
+	result, err := discovery.DiscoverProject(&model.GlobalConfig{})
+	if err != nil {
+		Fatal(err)
+	}
+	if result == nil {
+		Fatal(fmt.Errorf("no .kan directory found (run 'kan init' first)"))
+	}
+
+	paths := config.NewPaths(result.ProjectRoot, result.DataLocation)
+	migrateService := service.NewMigrateService(paths)
+
+	plan, err := migrateService.Plan()
+	if err != nil {
+		Fatal(err)
+	}
+
+	if !plan.HasChanges() {
+		PrintSuccess("Everything is up to date. No migration needed.")
+		return
+	}
+
+	est := migrateService.Estimate(plan)
+	fmt.Printf("Boards to migrate: %d\n", est.BoardsToMigrate)
+	fmt.Printf("Cards to migrate:  %d\n", est.CardsToMigrate)
+	fmt.Printf("Estimated time:    %s\n", time.Duration(est.EstimatedDurationMs)*time.Millisecond)
+	if plan.GlobalConfig != nil && plan.GlobalConfig.NeedsMigration {
+		fmt.Println(RenderMuted("The global config will also be migrated."))
+	}
+}
+
+func runMigrateDiff(all, dryRun, estimate bool) {
+	if all || dryRun || estimate {
+		Fatal(fmt.Errorf("--diff cannot be combined with --all, --dry-run or --estimate"))
+	}
+
+	result, err := discovery.DiscoverProject(&model.GlobalConfig{})
+	if err != nil {
+		Fatal(err)
+	}
+	if result == nil {
+		Fatal(fmt.Errorf("no .kan directory found (run 'kan init' first)"))
+	}
+
+	paths := config.NewPaths(result.ProjectRoot, result.DataLocation)
+	migrateService := service.NewQuietMigrateService(paths)
+
+	plan, err := migrateService.Plan()
+	if err != nil {
+		Fatal(err)
+	}
+	if !plan.HasChanges() {
+		PrintSuccess("Everything is up to date. No migration needed.")
+		return
+	}
+	if err := plan.FutureVersionError(); err != nil {
+		Fatal(err)
+	}
+
+	diff, err := migrateService.Diff(plan)
+	if err != nil {
+		Fatal(err)
+	}
+	for _, line := range strings.Split(diff, "\n") {
+		switch {
+		case strings.HasPrefix(line, "---"), strings.HasPrefix(line, "+++"):
+			fmt.Println(RenderBold(line))
+		case strings.HasPrefix(line, "+"):
+			fmt.Println(StyleSuccess.Render(line))
+		case strings.HasPrefix(line, "-"):
+			fmt.Println(StyleError.Render(line))
+		}
+	}
+	if plan.GlobalConfig != nil && plan.GlobalConfig.NeedsMigration {
+		fmt.Println(RenderMuted(fmt.Sprintf("The global config would also be migrated to %s.", plan.GlobalConfig.ToSchema)))
+	}
+	fmt.Println()
+	PrintError("Migration needed. Run 'kan migrate' and commit the result.")
+	os.Exit(1)
+}
+
+// printSnapshotTip tells the user how to undo a migration, if a snapshot was taken.
+func printSnapshotTip(result *service.MigrateResult) {
+	if result == nil || result.SnapshotID == "" {
+		return
+	}
+	fmt.Println(RenderMuted(fmt.Sprintf("Snapshot saved. Undo with 'kan migrate --rollback %s'.", result.SnapshotID)))
+}
+
+func runMigrateRollback(snapshotID string) {
+	result, err := discovery.DiscoverProject(&model.GlobalConfig{})
+	if err != nil {
+		Fatal(err)
+	}
+	if result == nil {
+		Fatal(fmt.Errorf("no .kan directory found (run 'kan init' first)"))
+	}
+
+	paths := config.NewPaths(result.ProjectRoot, result.DataLocation)
+	if err := service.NewMigrateService(paths).Rollback(snapshotID); err != nil {
+		Fatal(err)
+	}
+	PrintSuccess("Restored project data from snapshot %s.", snapshotID)
+}
+
+func runMigrateDowngrade(targetVersion int, all bool, dryRun bool) {
+	if all || dryRun {
+		Fatal(fmt.Errorf("--target-version cannot be combined with --all or --dry-run"))
+	}
+
+	result, err := discovery.DiscoverProject(&model.GlobalConfig{})
+	if err != nil {
+		Fatal(err)
+	}
+	if result == nil {
+		Fatal(fmt.Errorf("no .kan directory found (run 'kan init' first)"))
+	}
+
+	paths := config.NewPaths(result.ProjectRoot, result.DataLocation)
+	migrateService := service.NewMigrateService(paths)
+
+	plan, err := migrateService.PlanBoardsOnly()
+	if err != nil {
+		Fatal(err)
+	}
+	if err := migrateService.Downgrade(plan, targetVersion); err != nil {
+		Fatal(err)
+	}
+
+	fmt.Println()
+	PrintSuccess("Downgrade to board/%d complete.", targetVersion)
+	fmt.Println(RenderMuted("Open this project only with a Kan release that uses this schema; this release will migrate it forward again."))
+}
+
 // projectEntry is a resolved project for --all iteration.
 type projectEntry struct {
 	name         string
@@ -95,7 +296,11 @@ const (
 	outcomeFailed
 )
 
-func runMigrateAll(dryRun bool, nonInteractive bool) {
+func runMigrateAll(dryRun, breakLock, nonInteractive bool, concurrency int) {
+	if breakLock {
+		Fatal(fmt.Errorf("--break-lock cannot be combined with --all; run it in the affected project"))
+	}
+
 	// Load global config via raw TOML to bypass version validation
 	// (the config might need migration itself).
 	globalConfigPath := config.GlobalConfigPath()
@@ -135,10 +340,13 @@ func runMigrateAll(dryRun bool, nonInteractive bool) {
 	// Migrate global config once upfront.
 	migrateGlobalOnce(dryRun)
 
-	// Migrate each project's boards.
+	// Plan each project's boards (prompting in turn), then migrate the
+	// confirmed ones.
+	results := migrateProjects(projects, dryRun, nonInteractive, concurrency, prompter)
+
 	var migrated, upToDate, skipped, failed int
-	for _, proj := range projects {
-		switch migrateProject(proj, dryRun, nonInteractive, prompter) {
+	for _, r := range results {
+		switch r.outcome {
 		case outcomeMigrated:
 			migrated++
 		case outcomeUpToDate:
@@ -149,6 +357,7 @@ func runMigrateAll(dryRun bool, nonInteractive bool) {
 			failed++
 		}
 	}
+	printProjectSummary(results)
 
 	// Print summary.
 	fmt.Println()
@@ -238,23 +447,84 @@ func migrateGlobalOnce(dryRun bool) {
 
 	if dryRun {
 		fmt.Println(RenderBold("Global config (dry run):"))
-		_ = svc.Execute(plan, true)
+		_, _ = svc.Execute(plan, true)
 	} else {
-		if err := svc.Execute(plan, false); err != nil {
+		if _, err := svc.Execute(plan, false); err != nil {
 			PrintWarning("Failed to migrate global config: %v", err)
 		}
 	}
 }
 
-// migrateProject plans and optionally executes migration for a
-// single project's boards. Returns the outcome for summary tracking.
-func migrateProject(proj projectEntry, dryRun bool, nonInteractive bool, prompter prompt.Prompter) migrateOutcome {
+// projectMigration tracks one project through `kan migrate --all`.
+type projectMigration struct {
+	project  projectEntry
+	outcome  migrateOutcome
+	boards   int // boards with changes
+	cards    int // cards with changes
+	err      error
+	snapshot string
+
+	paths *config.Paths
+	plan  *service.MigrationPlan
+}
+
+// migrateProjects plans every project in order, then migrates those that
+// need it, concurrency projects at a time (each project's boards run one
+// at a time). Planning prints each project's changes and, unless
+// nonInteractive, asks before migrating it, so it stays sequential. A
+// project that fails to plan or migrate is recorded and doesn't stop the
+// others.
+func migrateProjects(projects []projectEntry, dryRun bool, nonInteractive bool, concurrency int, prompter prompt.Prompter) []*projectMigration {
+	results := make([]*projectMigration, len(projects))
+	var pending []*projectMigration
+	for i, proj := range projects {
+		results[i] = planProject(proj, dryRun, nonInteractive, prompter)
+		if results[i].plan != nil {
+			pending = append(pending, results[i])
+		}
+	}
+
+	if concurrency <= 0 {
+		concurrency = service.DefaultMigrateConcurrency
+	}
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for _, r := range pending {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			// Quiet: projects run side by side, so per-board output would
+			// interleave. The summary table reports the result instead.
+			migrateResult, err := service.NewQuietMigrateService(r.paths).ExecuteParallel(r.plan, false, 1, nil)
+			if migrateResult != nil {
+				r.snapshot = migrateResult.SnapshotID
+			}
+			if err != nil {
+				r.outcome, r.err = outcomeFailed, err
+				r.boards, r.cards = 0, 0
+				return
+			}
+			r.outcome = outcomeMigrated
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// planProject plans a single project's boards and, unless this is a dry
+// run or the user declines, leaves the plan on the result for
+// migrateProjects to execute.
+func planProject(proj projectEntry, dryRun bool, nonInteractive bool, prompter prompt.Prompter) *projectMigration {
+	r := &projectMigration{project: proj}
 	header := fmt.Sprintf("Project: %s (%s)", RenderBold(proj.name), proj.path)
 
 	// Check if project path exists on disk.
 	if _, err := os.Stat(proj.path); os.IsNotExist(err) {
 		PrintWarning("Skipping %q (%s) - path not found", proj.name, proj.path)
-		return outcomeSkipped
+		r.outcome = outcomeSkipped
+		return r
 	}
 
 	paths := config.NewPaths(proj.path, proj.dataLocation)
@@ -263,27 +533,33 @@ func migrateProject(proj projectEntry, dryRun bool, nonInteractive bool, prompte
 	plan, err := svc.PlanBoardsOnly()
 	if err != nil {
 		PrintWarning("Skipping %q - failed to plan: %v", proj.name, err)
-		return outcomeFailed
+		r.outcome, r.err = outcomeFailed, err
+		return r
 	}
 
 	if !plan.HasChanges() {
 		fmt.Printf("\n%s: %s\n", header, RenderMuted("up to date"))
-		return outcomeUpToDate
+		r.outcome = outcomeUpToDate
+		return r
 	}
 
 	// Refuse to migrate data from a newer Kan version (would downgrade)
 	if err := plan.FutureVersionError(); err != nil {
 		PrintWarning("Skipping %q - %v", proj.name, err)
-		return outcomeFailed
+		r.outcome, r.err = outcomeFailed, err
+		return r
 	}
 
 	// Print what would change.
 	fmt.Printf("\n%s\n", header)
 	printBoardSummary(plan)
 
+	est := svc.Estimate(plan)
 	if dryRun {
-		// In dry-run, count projects that *would* be migrated.
-		return outcomeMigrated
+		// In dry-run, count projects and boards that *would* be migrated.
+		r.outcome = outcomeMigrated
+		r.boards, r.cards = est.BoardsToMigrate, est.CardsToMigrate
+		return r
 	}
 
 	// In interactive mode, confirm before migrating.
@@ -292,19 +568,49 @@ func migrateProject(proj projectEntry, dryRun bool, nonInteractive bool, prompte
 			fmt.Sprintf("Migrate %q?", proj.name), true)
 		if err != nil {
 			PrintWarning("Skipping %q - prompt failed: %v", proj.name, err)
-			return outcomeFailed
+			r.outcome, r.err = outcomeFailed, err
+			return r
 		}
 		if !confirmed {
 			fmt.Printf("  %s\n", RenderMuted("Skipped"))
-			return outcomeSkipped
+			r.outcome = outcomeSkipped
+			return r
 		}
 	}
 
-	if err := svc.Execute(plan, false); err != nil {
-		PrintWarning("Failed to migrate %q: %v", proj.name, err)
-		return outcomeFailed
+	r.boards, r.cards = est.BoardsToMigrate, est.CardsToMigrate
+	r.paths, r.plan = paths, plan
+	return r
+}
+
+// printProjectSummary prints a table of each project's migrated boards,
+// cards and errors, followed by the snapshot taken for each migration.
+func printProjectSummary(results []*projectMigration) {
+	if len(results) == 0 {
+		return
+	}
+	rows := make([][]string, len(results))
+	for i, r := range results {
+		errMsg := ""
+		if r.err != nil {
+			errMsg = r.err.Error()
+		}
+		rows[i] = []string{r.project.name, strconv.Itoa(r.boards), strconv.Itoa(r.cards), errMsg}
+	}
+	fmt.Println()
+	printTable([]string{"PROJECT", "BOARDS", "CARDS", "ERROR"}, rows)
+
+	var snapshots []string
+	for _, r := range results {
+		if r.snapshot != "" {
+			snapshots = append(snapshots, fmt.Sprintf("  %s: %s", r.project.name, r.snapshot))
+		}
+	}
+	if len(snapshots) > 0 {
+		fmt.Println()
+		fmt.Println(RenderMuted("Snapshots saved. Undo one with 'kan migrate --rollback ID' from its project:"))
+		fmt.Println(RenderMuted(strings.Join(snapshots, "\n")))
 	}
-	return outcomeMigrated
 }
 
 // boardMigrationNotes returns human-readable descriptions of what
@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/amterp/kan/internal/prompt"
+	"github.com/amterp/kan/internal/version"
+)
+
+func TestMigrateProjects_MigratesEveryProject(t *testing.T) {
+	first, cleanupFirst := setupAutoMigrateProject(t, "board/25", 7)
+	defer cleanupFirst()
+	second, cleanupSecond := setupAutoMigrateProject(t, "board/24", 7)
+	defer cleanupSecond()
+	broken, cleanupBroken := setupAutoMigrateProject(t, "board/25", 7)
+	defer cleanupBroken()
+	if err := os.WriteFile(broken.BoardConfigPath("main"), []byte("not = [valid"), 0644); err != nil {
+		t.Fatalf("Failed to break config: %v", err)
+	}
+
+	projects := []projectEntry{
+		{name: "broken", path: filepath.Dir(broken.KanRoot())},
+		{name: "first", path: filepath.Dir(first.KanRoot())},
+		{name: "second", path: filepath.Dir(second.KanRoot())},
+	}
+	results := migrateProjects(projects, false, true, 2, &prompt.NoopPrompter{})
+
+	if results[0].outcome != outcomeFailed || results[0].err == nil {
+		t.Errorf("Expected broken project to fail to plan, got %+v", results[0])
+	}
+	for _, r := range results[1:] {
+		if r.outcome != outcomeMigrated || r.err != nil {
+			t.Errorf("%s: expected migrated, got outcome %d, err %v", r.project.name, r.outcome, r.err)
+		}
+		if r.boards != 1 || r.cards != 1 {
+			t.Errorf("%s: expected 1 board and 1 card, got %d and %d", r.project.name, r.boards, r.cards)
+		}
+		if r.snapshot == "" {
+			t.Errorf("%s: expected a snapshot ID", r.project.name)
+		}
+	}
+
+	for _, path := range []string{first.BoardConfigPath("main"), second.BoardConfigPath("main")} {
+		var raw map[string]any
+		if _, err := toml.DecodeFile(path, &raw); err != nil {
+			t.Fatalf("Failed to parse config: %v", err)
+		}
+		if raw["kan_schema"] != version.CurrentBoardSchema() {
+			t.Errorf("%s: kan_schema = %v, want %q", path, raw["kan_schema"], version.CurrentBoardSchema())
+		}
+	}
+}
@@ -25,10 +25,12 @@ type CommandContext struct {
 	InitProjectName *string
 
 	// board command
-	BoardUsed       *bool
-	BoardCreateUsed *bool
-	BoardCreateName *string
-	BoardListUsed   *bool
+	BoardUsed              *bool
+	BoardCreateUsed        *bool
+	BoardCreateName        *string
+	BoardCreateInteractive *bool
+	BoardListUsed          *bool
+	BoardListArchived      *bool
 
 	// board describe
 	BoardDescribeUsed  *bool
@@ -45,6 +47,37 @@ type CommandContext struct {
 	BoardDeleteUsed *bool
 	BoardDeleteName *string
 
+	// board archive / unarchive
+	BoardArchiveUsed   *bool
+	BoardArchiveName   *string
+	BoardUnarchiveUsed *bool
+	BoardUnarchiveName *string
+
+	// board rename
+	BoardRenameUsed  *bool
+	BoardRenameOld   *string
+	BoardRenameNew   *string
+	BoardRenameBoard *string
+
+	// board export / import
+	BoardExportUsed   *bool
+	BoardExportBoard  *string
+	BoardExportFormat *string
+	BoardImportUsed   *bool
+	BoardImportFile   *string
+	BoardImportName   *string
+
+	// board import-trello
+	BoardImportTrelloUsed *bool
+	BoardImportTrelloFile *string
+	BoardImportTrelloName *string
+
+	// board stats
+	BoardStatsUsed  *bool
+	BoardStatsBoard *string
+	BoardStatsSince *string
+	BoardStatsUntil *string
+
 	// add command
 	AddUsed        *bool
 	AddTitle       *string
@@ -56,7 +89,9 @@ type CommandContext struct {
 	AddBefore      *string
 	AddAfter       *string
 	AddFields      *[]string
+	AddLabels      *[]string
 	AddStrict      *bool
+	AddForce       *bool
 	AddGlobal      *bool
 
 	// show command
@@ -79,6 +114,12 @@ type CommandContext struct {
 	ListSort       *string
 	ListDescending *bool
 
+	// search command
+	SearchUsed   *bool
+	SearchQuery  *string
+	SearchFields *string
+	SearchAll    *bool
+
 	// edit command
 	EditUsed        *bool
 	EditCard        *string
@@ -92,18 +133,84 @@ type CommandContext struct {
 	EditAfter       *string
 	EditAlias       *string
 	EditFields      *[]string
+	EditLabels      *[]string
 	EditStrict      *bool
+	EditForce       *bool
 	EditGlobal      *bool
 
 	// serve command
-	ServeUsed   *bool
-	ServePort   *int
-	ServeNoOpen *bool
+	ServeUsed         *bool
+	ServePort         *int
+	ServeNoOpen       *bool
+	ServeDumpOpenAPI  *bool
+	ServeLogFormat    *string
+	ServeQuiet        *bool
+	ServeMaxBodyBytes *int64
+
+	// card command
+	CardUsed             *bool
+	CardAddUsed          *bool
+	CardAddTemplate      *string
+	CardAddVars          *[]string
+	CardAddBoard         *string
+	CardListUsed         *bool
+	CardListBoard        *string
+	CardListColumn       *string
+	CardListLabels       *[]string
+	CardListFields       *[]string
+	CardListCreatedAfter *string
+	CardListUpdatedAfter *string
+	CardListOverdue      *bool
+	CardListStale        *bool
+	CardListHasParent    *bool
+	CardListNoParent     *bool
+	CardListSort         *string
+	CardImportUsed       *bool
+	CardImportFile       *string
+	CardImportBoard      *string
+	CardImportMap        *[]string
+	CardShowUsed         *bool
+	CardShowCard         *string
+	CardShowBoard        *string
+	CardDeleteUsed       *bool
+	CardDeleteMany       *string
+	CardDeleteBoard      *string
+	CardDeleteDryRun     *bool
+	CardDuplicateUsed    *bool
+	CardDuplicateCard    *string
+	CardDuplicateBoard   *string
+	CardDuplicateTitle   *string
+	CardDuplicateColumn  *string
+	CardDuplicateLabels  *[]string
+	CardDuplicateOpen    *bool
+	CardWatchUsed        *bool
+	CardWatchCard        *string
+	CardWatchBoard       *string
+	CardWatchPort        *int
 
 	// migrate command
-	MigrateUsed   *bool
-	MigrateDryRun *bool
-	MigrateAll    *bool
+	MigrateUsed        *bool
+	MigrateDryRun      *bool
+	MigrateAll         *bool
+	MigrateEstimate    *bool
+	MigrateDiff        *bool
+	MigrateRollback    *string
+	MigrateTarget      *int
+	MigrateConcurrency *int
+	MigrateBreakLock   *bool
+
+	// sprint command
+	SprintUsed *bool
+
+	// sprint start
+	SprintStartUsed   *bool
+	SprintStartColumn *string
+	SprintStartDays   *int
+	SprintStartBoard  *string
+
+	// sprint status
+	SprintStatusUsed  *bool
+	SprintStatusBoard *string
 
 	// column command
 	ColumnUsed *bool
@@ -147,6 +254,33 @@ type CommandContext struct {
 	ColumnMoveAfter    *string
 	ColumnMoveBoard    *string
 
+	// field command
+	FieldUsed *bool
+
+	// field add
+	FieldAddUsed        *bool
+	FieldAddName        *string
+	FieldAddType        *string
+	FieldAddOptions     *[]string
+	FieldAddDescription *string
+	FieldAddBoard       *string
+
+	// field delete
+	FieldDeleteUsed  *bool
+	FieldDeleteName  *string
+	FieldDeleteBoard *string
+
+	// field edit
+	FieldEditUsed          *bool
+	FieldEditName          *string
+	FieldEditAddOptions    *[]string
+	FieldEditRemoveOptions *[]string
+	FieldEditBoard         *string
+
+	// field list
+	FieldListUsed  *bool
+	FieldListBoard *string
+
 	// comment command
 	CommentUsed *bool
 
@@ -171,10 +305,13 @@ type CommandContext struct {
 	CommentDeleteGlobal *bool
 
 	// doctor command
-	DoctorUsed   *bool
-	DoctorFix    *bool
-	DoctorDryRun *bool
-	DoctorBoard  *string
+	DoctorUsed             *bool
+	DoctorFix              *bool
+	DoctorDryRun           *bool
+	DoctorBoard            *string
+	DoctorIncludeArchived  *bool
+	DoctorWatch            *int
+	DoctorWatchExitOnError *bool
 
 	// commit command
 	CommitUsed    *bool
@@ -192,6 +329,32 @@ type CommandContext struct {
 
 	// global unset
 	GlobalUnsetUsed *bool
+
+	// config command
+	ConfigUsed *bool
+
+	// config set-default-field
+	ConfigSetDefaultFieldUsed    *bool
+	ConfigSetDefaultFieldName    *string
+	ConfigSetDefaultFieldType    *string
+	ConfigSetDefaultFieldOptions *[]string
+
+	// config list-defaults
+	ConfigListDefaultsUsed *bool
+
+	// hook command
+	HookUsed *bool
+
+	// hook history
+	HookHistoryUsed  *bool
+	HookHistoryBoard *string
+	HookHistoryLimit *int
+
+	HookTestUsed          *bool
+	HookTestName          *string
+	HookTestCard          *string
+	HookTestBoard         *string
+	HookTestCaptureFields *bool
 }
 
 // Run is the main entry point for the CLI.
@@ -235,18 +398,24 @@ func buildRootCmd() *CommandContext {
 	registerInit(cmd, ctx)
 	registerBoard(cmd, ctx)
 	registerColumn(cmd, ctx)
+	registerSprint(cmd, ctx)
+	registerField(cmd, ctx)
 	registerComment(cmd, ctx)
 	registerAdd(cmd, ctx)
 	registerDelete(cmd, ctx)
 	registerShow(cmd, ctx)
 	registerHistory(cmd, ctx)
 	registerList(cmd, ctx)
+	registerSearch(cmd, ctx)
+	registerCard(cmd, ctx)
 	registerEdit(cmd, ctx)
 	registerServe(cmd, ctx)
 	registerMigrate(cmd, ctx)
 	registerDoctor(cmd, ctx)
 	registerCommit(cmd, ctx)
 	registerGlobal(cmd, ctx)
+	registerConfig(cmd, ctx)
+	registerHook(cmd, ctx)
 	registerCompletion(cmd, ctx)
 
 	return ctx
@@ -275,6 +444,12 @@ func executeCommand(ctx *CommandContext) {
 			unsupportedCommand = "column edit"
 		case *ctx.ColumnMoveUsed:
 			unsupportedCommand = "column move"
+		case *ctx.FieldAddUsed:
+			unsupportedCommand = "field add"
+		case *ctx.FieldDeleteUsed:
+			unsupportedCommand = "field delete"
+		case *ctx.FieldEditUsed:
+			unsupportedCommand = "field edit"
 		case *ctx.CommentEditUsed:
 			unsupportedCommand = "comment edit"
 		case *ctx.CommentDeleteUsed:
@@ -283,8 +458,22 @@ func executeCommand(ctx *CommandContext) {
 			unsupportedCommand = "delete"
 		case *ctx.BoardDeleteUsed:
 			unsupportedCommand = "board delete"
+		case *ctx.BoardArchiveUsed:
+			unsupportedCommand = "board archive"
+		case *ctx.BoardUnarchiveUsed:
+			unsupportedCommand = "board unarchive"
+		case *ctx.BoardRenameUsed:
+			unsupportedCommand = "board rename"
+		case *ctx.BoardImportUsed:
+			unsupportedCommand = "board import"
+		case *ctx.BoardImportTrelloUsed:
+			unsupportedCommand = "board import-trello"
 		case *ctx.CommitUsed:
 			unsupportedCommand = "commit"
+		case *ctx.ConfigSetDefaultFieldUsed:
+			unsupportedCommand = "config set-default-field"
+		case *ctx.ConfigListDefaultsUsed:
+			unsupportedCommand = "config list-defaults"
 		}
 		if unsupportedCommand != "" {
 			warnJsonNotSupported(unsupportedCommand)
@@ -296,21 +485,42 @@ func executeCommand(ctx *CommandContext) {
 		runInit(*ctx.InitLocation, *ctx.InitName, *ctx.InitColumns, *ctx.InitProjectName, *ctx.NonInteractive)
 
 	case *ctx.BoardCreateUsed:
-		runBoardCreate(*ctx.BoardCreateName)
+		runBoardCreate(*ctx.BoardCreateName, *ctx.BoardCreateInteractive, *ctx.NonInteractive)
 
 	case *ctx.BoardDeleteUsed:
 		runBoardDelete(*ctx.BoardDeleteName, *ctx.NonInteractive)
 
+	case *ctx.BoardArchiveUsed:
+		runBoardArchive(*ctx.BoardArchiveName)
+
+	case *ctx.BoardUnarchiveUsed:
+		runBoardUnarchive(*ctx.BoardUnarchiveName)
+
+	case *ctx.BoardRenameUsed:
+		runBoardRename(*ctx.BoardRenameOld, *ctx.BoardRenameNew, *ctx.BoardRenameBoard, *ctx.NonInteractive)
+
 	case *ctx.BoardDescribeUsed:
 		runBoardDescribe(*ctx.BoardDescribeName, *ctx.BoardDescribeBoard, *ctx.NonInteractive, *ctx.Json)
 
 	case *ctx.BoardListUsed:
-		runBoardList(*ctx.Json)
+		runBoardList(*ctx.BoardListArchived, *ctx.Json)
+
+	case *ctx.BoardExportUsed:
+		runBoardExport(*ctx.BoardExportBoard, *ctx.BoardExportFormat, *ctx.NonInteractive)
+
+	case *ctx.BoardImportUsed:
+		runBoardImport(*ctx.BoardImportFile, *ctx.BoardImportName)
+
+	case *ctx.BoardStatsUsed:
+		runBoardStats(*ctx.BoardStatsBoard, *ctx.BoardStatsSince, *ctx.BoardStatsUntil, *ctx.NonInteractive, *ctx.Json)
+
+	case *ctx.BoardImportTrelloUsed:
+		runBoardImportTrello(*ctx.BoardImportTrelloFile, *ctx.BoardImportTrelloName)
 
 	case *ctx.AddUsed:
 		runAdd(*ctx.AddTitle, *ctx.AddDescription, *ctx.AddBoard, *ctx.AddColumn, *ctx.AddParent,
 			cardPlacement{*ctx.AddPosition, ctx.RootCmd.Configured("position"), *ctx.AddBefore, *ctx.AddAfter},
-			*ctx.AddFields, *ctx.AddStrict, *ctx.AddGlobal, *ctx.NonInteractive, *ctx.Json)
+			*ctx.AddFields, parseLabels(ctx.RootCmd.Configured("label"), *ctx.AddLabels), *ctx.AddStrict, *ctx.AddForce, *ctx.AddGlobal, *ctx.NonInteractive, *ctx.Json)
 
 	case *ctx.DeleteUsed:
 		runDelete(*ctx.DeleteCard, *ctx.DeleteBoard, *ctx.DeleteGlobal, *ctx.NonInteractive)
@@ -324,20 +534,62 @@ func executeCommand(ctx *CommandContext) {
 	case *ctx.ListUsed:
 		runList(*ctx.ListBoard, *ctx.ListColumn, *ctx.ListSort, *ctx.ListGlobal, *ctx.ListDescending, *ctx.Json)
 
+	case *ctx.CardAddUsed:
+		runCardAdd(*ctx.CardAddTemplate, *ctx.CardAddVars, *ctx.CardAddBoard, *ctx.NonInteractive, *ctx.Json)
+
+	case *ctx.CardListUsed:
+		filters := cardListFilters{
+			Column:       *ctx.CardListColumn,
+			Labels:       *ctx.CardListLabels,
+			Fields:       *ctx.CardListFields,
+			CreatedAfter: *ctx.CardListCreatedAfter,
+			UpdatedAfter: *ctx.CardListUpdatedAfter,
+			Overdue:      *ctx.CardListOverdue,
+			Stale:        *ctx.CardListStale,
+			HasParent:    *ctx.CardListHasParent,
+			NoParent:     *ctx.CardListNoParent,
+		}
+		runCardList(*ctx.CardListBoard, filters, *ctx.CardListSort, *ctx.NonInteractive, *ctx.Json)
+
+	case *ctx.CardImportUsed:
+		runCardImport(*ctx.CardImportFile, *ctx.CardImportBoard, *ctx.CardImportMap, *ctx.NonInteractive, *ctx.Json)
+
+	case *ctx.CardShowUsed:
+		runCardShow(*ctx.CardShowCard, *ctx.CardShowBoard, *ctx.NonInteractive, *ctx.Json)
+
+	case *ctx.CardDeleteUsed:
+		runCardDelete(*ctx.CardDeleteMany, *ctx.CardDeleteBoard, *ctx.CardDeleteDryRun, *ctx.NonInteractive, *ctx.Json)
+	case *ctx.CardDuplicateUsed:
+		runCardDuplicate(*ctx.CardDuplicateCard, *ctx.CardDuplicateBoard, *ctx.CardDuplicateTitle, *ctx.CardDuplicateColumn,
+			*ctx.CardDuplicateLabels, *ctx.CardDuplicateOpen, *ctx.NonInteractive, *ctx.Json)
+	case *ctx.CardWatchUsed:
+		runCardWatch(*ctx.CardWatchCard, *ctx.CardWatchBoard, *ctx.CardWatchPort, *ctx.NonInteractive)
+
+	case *ctx.SearchUsed:
+		runSearch(*ctx.SearchQuery, *ctx.SearchFields, *ctx.SearchAll, *ctx.Json)
+
 	case *ctx.EditUsed:
 		runEdit(*ctx.EditCard, *ctx.EditBoard, *ctx.EditTitle, *ctx.EditDescription,
 			*ctx.EditColumn, *ctx.EditParent, *ctx.EditAlias,
 			cardPlacement{*ctx.EditPosition, ctx.RootCmd.Configured("position"), *ctx.EditBefore, *ctx.EditAfter},
-			*ctx.EditFields, *ctx.EditStrict, *ctx.EditGlobal, *ctx.NonInteractive, *ctx.Json)
+			*ctx.EditFields, parseLabels(ctx.RootCmd.Configured("label"), *ctx.EditLabels), *ctx.EditStrict, *ctx.EditForce, *ctx.EditGlobal, *ctx.NonInteractive, *ctx.Json)
 
 	case *ctx.ServeUsed:
-		runServe(*ctx.ServePort, ctx.RootCmd.Configured("port"), *ctx.ServeNoOpen)
+		runServe(*ctx.ServePort, ctx.RootCmd.Configured("port"), *ctx.ServeNoOpen, *ctx.ServeDumpOpenAPI, *ctx.ServeLogFormat, *ctx.ServeQuiet, *ctx.ServeMaxBodyBytes)
 
 	case *ctx.MigrateUsed:
-		if *ctx.MigrateAll {
-			runMigrateAll(*ctx.MigrateDryRun, *ctx.NonInteractive)
+		if *ctx.MigrateRollback != "" {
+			runMigrateRollback(*ctx.MigrateRollback)
+		} else if ctx.RootCmd.Configured("target-version") {
+			runMigrateDowngrade(*ctx.MigrateTarget, *ctx.MigrateAll, *ctx.MigrateDryRun)
+		} else if *ctx.MigrateDiff {
+			runMigrateDiff(*ctx.MigrateAll, *ctx.MigrateDryRun, *ctx.MigrateEstimate)
+		} else if *ctx.MigrateEstimate {
+			runMigrateEstimate(*ctx.MigrateAll, *ctx.MigrateDryRun)
+		} else if *ctx.MigrateAll {
+			runMigrateAll(*ctx.MigrateDryRun, *ctx.MigrateBreakLock, *ctx.NonInteractive, *ctx.MigrateConcurrency)
 		} else {
-			runMigrate(*ctx.MigrateDryRun)
+			runMigrate(*ctx.MigrateDryRun, *ctx.MigrateBreakLock, *ctx.MigrateConcurrency)
 		}
 
 	case *ctx.ColumnAddUsed:
@@ -358,6 +610,24 @@ func executeCommand(ctx *CommandContext) {
 	case *ctx.ColumnMoveUsed:
 		runColumnMove(*ctx.ColumnMoveName, *ctx.ColumnMoveBoard, *ctx.ColumnMovePosition, *ctx.ColumnMoveAfter, *ctx.NonInteractive)
 
+	case *ctx.SprintStartUsed:
+		runSprintStart(*ctx.SprintStartColumn, *ctx.SprintStartDays, *ctx.SprintStartBoard, *ctx.NonInteractive, *ctx.Json)
+
+	case *ctx.SprintStatusUsed:
+		runSprintStatus(*ctx.SprintStatusBoard, *ctx.NonInteractive, *ctx.Json)
+
+	case *ctx.FieldAddUsed:
+		runFieldAdd(*ctx.FieldAddName, *ctx.FieldAddType, *ctx.FieldAddOptions, *ctx.FieldAddDescription, *ctx.FieldAddBoard, *ctx.NonInteractive)
+
+	case *ctx.FieldDeleteUsed:
+		runFieldDelete(*ctx.FieldDeleteName, *ctx.FieldDeleteBoard, *ctx.NonInteractive)
+
+	case *ctx.FieldEditUsed:
+		runFieldEdit(*ctx.FieldEditName, *ctx.FieldEditAddOptions, *ctx.FieldEditRemoveOptions, *ctx.FieldEditBoard, *ctx.NonInteractive)
+
+	case *ctx.FieldListUsed:
+		runFieldList(*ctx.FieldListBoard, *ctx.NonInteractive, *ctx.Json)
+
 	case *ctx.CommentAddUsed:
 		runCommentAdd(*ctx.CommentAddCard, *ctx.CommentAddBody, *ctx.CommentAddBoard, *ctx.CommentAddGlobal, *ctx.NonInteractive, *ctx.Json)
 
@@ -368,7 +638,8 @@ func executeCommand(ctx *CommandContext) {
 		runCommentDelete(*ctx.CommentDeleteID, *ctx.CommentDeleteBoard, *ctx.CommentDeleteGlobal, *ctx.NonInteractive)
 
 	case *ctx.DoctorUsed:
-		runDoctor(*ctx.DoctorBoard, *ctx.DoctorFix, *ctx.DoctorDryRun, *ctx.Json)
+		runDoctor(*ctx.DoctorBoard, *ctx.DoctorFix, *ctx.DoctorDryRun, *ctx.DoctorIncludeArchived, *ctx.Json,
+			*ctx.DoctorWatch, *ctx.DoctorWatchExitOnError)
 
 	case *ctx.CommitUsed:
 		runCommit(*ctx.CommitMessage)
@@ -382,6 +653,17 @@ func executeCommand(ctx *CommandContext) {
 	case *ctx.GlobalUnsetUsed:
 		runGlobalUnset()
 
+	case *ctx.ConfigSetDefaultFieldUsed:
+		runConfigSetDefaultField(*ctx.ConfigSetDefaultFieldName, *ctx.ConfigSetDefaultFieldType, *ctx.ConfigSetDefaultFieldOptions)
+
+	case *ctx.ConfigListDefaultsUsed:
+		runConfigListDefaults()
+
+	case *ctx.HookHistoryUsed:
+		runHookHistory(*ctx.HookHistoryBoard, *ctx.HookHistoryLimit, *ctx.NonInteractive, *ctx.Json)
+	case *ctx.HookTestUsed:
+		runHookTest(*ctx.HookTestName, *ctx.HookTestCard, *ctx.HookTestBoard, *ctx.HookTestCaptureFields, *ctx.NonInteractive, *ctx.Json)
+
 	case *ctx.CompletionUsed:
 		runCompletion(*ctx.CompletionShell, ctx.RootCmd)
 	}
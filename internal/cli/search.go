@@ -0,0 +1,145 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/amterp/kan/internal/service"
+	"github.com/amterp/ra"
+)
+
+func registerSearch(parent *ra.Cmd, ctx *CommandContext) {
+	cmd := ra.NewCmd("search")
+	cmd.SetDescription("Search cards across every board in the project")
+
+	ctx.SearchQuery, _ = ra.NewString("query").
+		SetUsage("Text to search for (case-insensitive substring)").
+		Register(cmd)
+
+	ctx.SearchFields, _ = ra.NewString("field").
+		SetShort("f").
+		SetOptional(true).
+		SetFlagOnly(true).
+		SetUsage("Comma-separated fields to search: title, description, comments (default: title,description)").
+		Register(cmd)
+
+	ctx.SearchAll, _ = ra.NewBool("all").
+		SetShort("a").
+		SetOptional(true).
+		SetFlagOnly(true).
+		SetUsage("Search every registered project, not just the current one").
+		Register(cmd)
+
+	ctx.SearchUsed, _ = parent.RegisterCmd(cmd)
+}
+
+func runSearch(query, fields string, all, jsonOutput bool) {
+	opts := service.SearchOptions{}
+	if fields != "" {
+		for _, f := range strings.Split(fields, ",") {
+			opts.Fields = append(opts.Fields, strings.TrimSpace(f))
+		}
+	}
+
+	var hits []service.SearchHit
+	if all {
+		globalCfg, err := loadGlobalConfig()
+		if err != nil {
+			Fatal(err)
+		}
+		var skipped []service.SkippedProject
+		hits, skipped, err = service.SearchProjects(globalCfg, query, opts)
+		if err != nil {
+			Fatal(err)
+		}
+		for _, sp := range skipped {
+			PrintWarning("skipping project %q (%s): %v", sp.Name, prettyPath(sp.Path), sp.Err)
+		}
+	} else {
+		app, err := NewApp(false)
+		if err != nil {
+			Fatal(err)
+		}
+		if err := app.RequireKan(); err != nil {
+			Fatal(err)
+		}
+		hits, err = app.SearchService.SearchAllBoards(query, opts)
+		if err != nil {
+			Fatal(err)
+		}
+	}
+
+	if jsonOutput {
+		if err := printJson(hits); err != nil {
+			Fatal(err)
+		}
+		return
+	}
+
+	if len(hits) == 0 {
+		PrintInfo("No cards found")
+		return
+	}
+	printSearchHits(hits, all)
+}
+
+// printSearchHits prints hits as an aligned table. The project column is only
+// shown for cross-project searches.
+func printSearchHits(hits []service.SearchHit, showProject bool) {
+	headers := []string{"BOARD", "CARD", "TITLE", "MATCHED", "SNIPPET"}
+	if showProject {
+		headers = append([]string{"PROJECT"}, headers...)
+	}
+
+	rows := make([][]string, len(hits))
+	for i, hit := range hits {
+		card := hit.Alias
+		if card == "" {
+			card = hit.CardID
+		}
+		row := []string{hit.BoardName, card, hit.Title, hit.MatchedIn, hit.Snippet}
+		if showProject {
+			row = append([]string{hit.Project}, row...)
+		}
+		rows[i] = row
+	}
+
+	// Pad before styling so ANSI codes don't throw off the alignment. The
+	// last column is left unpadded.
+	widths := make([]int, len(headers))
+	for i, h := range headers {
+		widths[i] = len(h)
+	}
+	for _, row := range rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], len([]rune(cell)))
+		}
+	}
+	pad := func(cells []string) []string {
+		out := make([]string, len(cells))
+		for i, cell := range cells {
+			if i < len(cells)-1 {
+				cell += strings.Repeat(" ", widths[i]-len([]rune(cell)))
+			}
+			out[i] = cell
+		}
+		return out
+	}
+
+	header := pad(headers)
+	for i := range header {
+		header[i] = RenderBold(header[i])
+	}
+	fmt.Println(strings.Join(header, "  "))
+
+	cardCol := 1
+	if showProject {
+		cardCol = 2
+	}
+	for _, row := range rows {
+		cells := pad(row)
+		cells[cardCol] = RenderID(cells[cardCol])
+		cells[len(cells)-1] = RenderMuted(cells[len(cells)-1])
+		fmt.Println(strings.Join(cells, "  "))
+	}
+}
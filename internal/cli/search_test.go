@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/amterp/kan/internal/config"
+	kanerr "github.com/amterp/kan/internal/errors"
+	"github.com/amterp/kan/internal/model"
+	"github.com/amterp/kan/internal/service"
+	"github.com/amterp/kan/internal/store"
+)
+
+// writeSearchProject creates a project with boards "alpha" and "beta", each
+// holding a card that mentions "login".
+func writeSearchProject(t *testing.T) string {
+	t.Helper()
+	root := writeProjectBoard(t, "alpha")
+	paths := config.NewPaths(root, "")
+	boardStore := store.NewBoardStore(paths)
+	beta, err := boardStore.Get("alpha")
+	if err != nil {
+		t.Fatalf("get alpha: %v", err)
+	}
+	beta.Name, beta.ID = "beta", "beta-id"
+	if err := boardStore.Create(beta); err != nil {
+		t.Fatalf("create beta: %v", err)
+	}
+
+	cardStore := store.NewCardStore(paths)
+	cards := map[string]*model.Card{
+		"alpha": {ID: "a1", Alias: "fix-login", Title: "Fix login", Column: "Backlog", Position: "a"},
+		"beta":  {ID: "b1", Alias: "docs", Title: "Docs", Description: "Explain the login flow", Column: "Done", Position: "a"},
+	}
+	for board, card := range cards {
+		if err := cardStore.Create(board, card); err != nil {
+			t.Fatalf("create card on %s: %v", board, err)
+		}
+	}
+	return root
+}
+
+func TestSearchProjects(t *testing.T) {
+	root := writeSearchProject(t)
+	cfg := &model.GlobalConfig{}
+	cfg.RegisterProject("work", root)
+	cfg.RegisterProject("gone", filepath.Join(t.TempDir(), "missing"))
+
+	hits, skipped, err := service.SearchProjects(cfg, "LOGIN", service.SearchOptions{})
+	if err != nil {
+		t.Fatalf("SearchProjects: %v", err)
+	}
+	if len(skipped) != 0 {
+		t.Errorf("Expected a project without boards to contribute nothing, got skipped %+v", skipped)
+	}
+	want := []service.SearchHit{
+		{Project: "work", ProjectPath: root, BoardName: "alpha", CardID: "a1", Alias: "fix-login", Title: "Fix login", MatchedIn: "title", Snippet: "Fix login"},
+		{Project: "work", ProjectPath: root, BoardName: "beta", CardID: "b1", Alias: "docs", Title: "Docs", MatchedIn: "description", Snippet: "Explain the login flow"},
+	}
+	if !reflect.DeepEqual(hits, want) {
+		t.Errorf("hits =\n%+v\nwant\n%+v", hits, want)
+	}
+
+	hits, _, err = service.SearchProjects(cfg, "login", service.SearchOptions{Fields: []string{service.SearchFieldTitle}})
+	if err != nil {
+		t.Fatalf("SearchProjects: %v", err)
+	}
+	if len(hits) != 1 || hits[0].CardID != "a1" {
+		t.Errorf("Expected only the title match with --field title, got %+v", hits)
+	}
+
+	if _, _, err := service.SearchProjects(cfg, "login", service.SearchOptions{Fields: []string{"alias"}}); !kanerr.IsCode(err, kanerr.CodeInvalidField) {
+		t.Errorf("Expected validation error for unknown field, got %v", err)
+	}
+}
@@ -1,8 +1,11 @@
 package cli
 
 import (
+	"encoding/json"
 	"fmt"
+	"log/slog"
 	"net"
+	"os"
 	"os/exec"
 	"runtime"
 
@@ -10,13 +13,16 @@ import (
 	"github.com/amterp/ra"
 )
 
+// defaultServePort is the port kan serve tries first.
+const defaultServePort = 5260
+
 func registerServe(parent *ra.Cmd, ctx *CommandContext) {
 	cmd := ra.NewCmd("serve")
 	cmd.SetDescription("Start web interface")
 
 	ctx.ServePort, _ = ra.NewInt("port").
 		SetOptional(true).
-		SetDefault(5260).
+		SetDefault(defaultServePort).
 		SetShort("p").
 		SetFlagOnly(true).
 		SetUsage("Port to listen on (auto-increments if unspecified and in use; errors out if explicitly set and unavailable)").
@@ -28,10 +34,46 @@ func registerServe(parent *ra.Cmd, ctx *CommandContext) {
 		SetUsage("Don't open browser automatically").
 		Register(cmd)
 
+	ctx.ServeDumpOpenAPI, _ = ra.NewBool("dump-openapi").
+		SetOptional(true).
+		SetFlagOnly(true).
+		SetUsage("Print the API's OpenAPI 3.0 spec as JSON and exit").
+		Register(cmd)
+
+	ctx.ServeLogFormat, _ = ra.NewString("log-format").
+		SetDefault("text").
+		SetFlagOnly(true).
+		SetEnumConstraint([]string{"text", "json"}).
+		SetUsage("Access log format: text or json").
+		Register(cmd)
+
+	ctx.ServeQuiet, _ = ra.NewBool("quiet").
+		SetOptional(true).
+		SetShort("q").
+		SetFlagOnly(true).
+		SetUsage("Don't log requests").
+		Register(cmd)
+
+	ctx.ServeMaxBodyBytes, _ = ra.NewInt64("max-request-body-bytes").
+		SetDefault(api.DefaultMaxRequestBodyBytes).
+		SetFlagOnly(true).
+		SetMin(1, true).
+		SetUsage("Largest request body the API accepts, in bytes").
+		Register(cmd)
+
 	ctx.ServeUsed, _ = parent.RegisterCmd(cmd)
 }
 
-func runServe(port int, portExplicit bool, noOpen bool) {
+func runServe(port int, portExplicit bool, noOpen bool, dumpOpenAPI bool, logFormat string, quiet bool, maxBodyBytes int64) {
+	if dumpOpenAPI {
+		data, err := json.MarshalIndent(api.GenerateOpenAPISpec(), "", "  ")
+		if err != nil {
+			Fatal(err)
+		}
+		fmt.Println(string(data))
+		return
+	}
+
 	app, err := NewApp(false)
 	if err != nil {
 		Fatal(err)
@@ -47,17 +89,22 @@ func runServe(port int, portExplicit bool, noOpen bool) {
 	}
 
 	ctx := &api.ProjectContext{
-		Paths:        app.Paths,
-		BoardStore:   app.BoardStore,
-		CardStore:    app.CardStore,
-		ProjectStore: app.ProjectStore,
-		CardService:  app.CardService,
-		BoardService: app.BoardService,
-		Creator:      creatorName,
-		ProjectRoot:  app.ProjectRoot,
+		Paths:            app.Paths,
+		BoardStore:       app.BoardStore,
+		CardStore:        app.CardStore,
+		ProjectStore:     app.ProjectStore,
+		AuditStore:       app.AuditStore,
+		HookHistoryStore: app.HookHistoryStore,
+		CardService:      app.CardService,
+		BoardService:     app.BoardService,
+		SearchService:    app.SearchService,
+		HookService:      app.HookService,
+		Creator:          creatorName,
+		ProjectRoot:      app.ProjectRoot,
 	}
 
 	handler := api.NewHandler(app.GlobalStore, ctx)
+	handler.SetMaxRequestBodyBytes(maxBodyBytes)
 
 	// If user explicitly set --port, honor it exactly; otherwise auto-increment.
 	var actualPort int
@@ -70,7 +117,11 @@ func runServe(port int, portExplicit bool, noOpen bool) {
 		actualPort = findAvailablePort(port)
 	}
 
-	server := api.NewServer(handler, actualPort, app.Paths.KanRoot())
+	var accessLog *slog.Logger
+	if !quiet {
+		accessLog = newAccessLogger(logFormat)
+	}
+	server := api.NewServer(handler, actualPort, app.Paths.KanRoot(), accessLog)
 
 	url := fmt.Sprintf("http://localhost:%d", actualPort)
 
@@ -88,6 +139,15 @@ func runServe(port int, portExplicit bool, noOpen bool) {
 	}
 }
 
+// newAccessLogger returns a logger writing request records to stderr in the
+// given format ("json" or "text").
+func newAccessLogger(format string) *slog.Logger {
+	if format == "json" {
+		return slog.New(slog.NewJSONHandler(os.Stderr, nil))
+	}
+	return slog.New(slog.NewTextHandler(os.Stderr, nil))
+}
+
 // findAvailablePort tries ports starting from startPort until it finds one that's available.
 func findAvailablePort(startPort int) int {
 	maxAttempts := 100
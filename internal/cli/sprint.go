@@ -0,0 +1,150 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/amterp/kan/internal/api"
+	kanerr "github.com/amterp/kan/internal/errors"
+	"github.com/amterp/kan/internal/service"
+	"github.com/amterp/kan/internal/util"
+	"github.com/amterp/ra"
+)
+
+func registerSprint(parent *ra.Cmd, ctx *CommandContext) {
+	cmd := ra.NewCmd("sprint")
+	cmd.SetDescription("Run time-boxed sprints in a column")
+
+	// sprint start
+	startCmd := ra.NewCmd("start")
+	startCmd.SetDescription("Start a sprint in a column, from now")
+
+	ctx.SprintStartColumn, _ = ra.NewString("column").
+		SetUsage("Column to run the sprint in").
+		SetCompletionFunc(completeColumns).
+		Register(startCmd)
+
+	ctx.SprintStartDays, _ = ra.NewInt("days").
+		SetShort("d").
+		SetOptional(true).
+		SetFlagOnly(true).
+		SetDefault(service.DefaultSprintDays).
+		SetUsage("How many days the sprint runs").
+		Register(startCmd)
+
+	ctx.SprintStartBoard, _ = ra.NewString("board").
+		SetShort("b").
+		SetOptional(true).
+		SetFlagOnly(true).
+		SetUsage("Target board").
+		SetCompletionFunc(completeBoards).
+		Register(startCmd)
+
+	ctx.SprintStartUsed, _ = cmd.RegisterCmd(startCmd)
+
+	// sprint status
+	statusCmd := ra.NewCmd("status")
+	statusCmd.SetDescription("Show the active sprint and its cards")
+
+	ctx.SprintStatusBoard, _ = ra.NewString("board").
+		SetShort("b").
+		SetOptional(true).
+		SetFlagOnly(true).
+		SetUsage("Target board").
+		SetCompletionFunc(completeBoards).
+		Register(statusCmd)
+
+	ctx.SprintStatusUsed, _ = cmd.RegisterCmd(statusCmd)
+
+	ctx.SprintUsed, _ = parent.RegisterCmd(cmd)
+}
+
+func runSprintStart(column string, days int, board string, nonInteractive, jsonOutput bool) {
+	if days <= 0 {
+		Fatal(fmt.Errorf("--days must be a positive number of days"))
+	}
+
+	app, err := NewApp(!nonInteractive)
+	if err != nil {
+		Fatal(err)
+	}
+
+	if err := app.RequireKan(); err != nil {
+		Fatal(err)
+	}
+
+	boardName, err := app.BoardResolver.Resolve(board, !nonInteractive)
+	if err != nil {
+		Fatal(err)
+	}
+
+	col, err := app.BoardService.StartSprint(boardName, column, days)
+	if err != nil {
+		Fatal(err)
+	}
+
+	if jsonOutput {
+		if err := printJson(col); err != nil {
+			Fatal(err)
+		}
+		return
+	}
+	PrintSuccess("Started a %d-day sprint in %s, ending %s", days, RenderBold(col.Name), util.FormatMillis(col.SprintEndMillis))
+}
+
+func runSprintStatus(board string, nonInteractive, jsonOutput bool) {
+	app, err := NewApp(!nonInteractive)
+	if err != nil {
+		Fatal(err)
+	}
+
+	if err := app.RequireKan(); err != nil {
+		Fatal(err)
+	}
+
+	boardName, err := app.BoardResolver.Resolve(board, !nonInteractive)
+	if err != nil {
+		Fatal(err)
+	}
+
+	col, err := app.BoardService.GetActiveSprint(boardName)
+	if kanerr.IsCode(err, kanerr.CodeSprintNotFound) && !jsonOutput {
+		PrintInfo("No active sprint on board %q (start one with 'kan sprint start <column>')", boardName)
+		return
+	}
+	if err != nil {
+		Fatal(err)
+	}
+	boardCfg, err := app.BoardService.Get(boardName)
+	if err != nil {
+		Fatal(err)
+	}
+	cards, err := app.CardService.List(boardName, col.Name)
+	if err != nil {
+		Fatal(err)
+	}
+
+	if jsonOutput {
+		// Emit the API's sprint shape so scripts can share parsing with the web UI.
+		resp := api.SprintResponse{Column: *col, Cards: make([]api.CardResponse, len(cards))}
+		for i, card := range cards {
+			resp.Cards[i] = api.NewCardResponse(card, boardCfg)
+		}
+		if err := printJson(resp); err != nil {
+			Fatal(err)
+		}
+		return
+	}
+
+	const labelWidth = 8
+	now := util.NowMillis()
+	fmt.Println(LabelValue("Sprint", RenderColumnColor(col.Name, col.Color), labelWidth))
+	fmt.Println(LabelValue("Started", RenderMuted(util.FormatMillis(col.SprintStartMillis)), labelWidth))
+	fmt.Println(LabelValue("Ends", util.FormatMillis(col.SprintEndMillis)+" "+
+		RenderMuted("("+util.FormatDuration(col.SprintEndMillis-now)+" left)"), labelWidth))
+	fmt.Println()
+	if len(cards) == 0 {
+		PrintInfo("No cards in %s", col.Name)
+		return
+	}
+	printCardTable(cards, now)
+}
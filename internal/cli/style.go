@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/charmbracelet/lipgloss"
 )
@@ -142,6 +143,34 @@ func LabelValue(label, value string, labelWidth int) string {
 	return fmt.Sprintf("%s %s", labelStyle.Render(label+":"), value)
 }
 
+// printTable prints rows as space-aligned columns under a muted header.
+func printTable(headers []string, rows [][]string) {
+	widths := make([]int, len(headers))
+	for i, h := range headers {
+		widths[i] = len(h)
+	}
+	for _, row := range rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], utf8.RuneCountInString(cell))
+		}
+	}
+
+	pad := func(cell string, width int) string {
+		return cell + strings.Repeat(" ", width-utf8.RuneCountInString(cell))
+	}
+	line := func(cells []string) string {
+		padded := make([]string, len(cells))
+		for i, cell := range cells {
+			padded[i] = pad(cell, widths[i])
+		}
+		return strings.TrimRight(strings.Join(padded, "  "), " ")
+	}
+	fmt.Println(RenderMuted(line(headers)))
+	for _, row := range rows {
+		fmt.Println(line(row))
+	}
+}
+
 // Badge color palette - matches web/src/utils/badgeColors.ts for CLI/web parity.
 var badgeColors = []string{
 	"#2563eb", "#dc2626", "#047857", "#c2410c", "#9333ea",
@@ -0,0 +1,168 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/amterp/kan/internal/model"
+	"github.com/amterp/kan/internal/prompt"
+)
+
+// BoardTemplate is a starting layout offered by `kan board create --interactive`.
+// The Default* methods return fresh values, so callers may modify them.
+type BoardTemplate struct {
+	Name        string
+	Description string
+
+	columns      func() []model.Column
+	customFields func() map[string]model.CustomFieldSchema
+	cardDisplay  func() model.CardDisplayConfig
+}
+
+// DefaultColumns returns the template's columns, in board order.
+func (t BoardTemplate) DefaultColumns() []model.Column {
+	return t.columns()
+}
+
+// DefaultCustomFields returns the template's custom fields (nil if none).
+func (t BoardTemplate) DefaultCustomFields() map[string]model.CustomFieldSchema {
+	if t.customFields == nil {
+		return nil
+	}
+	return t.customFields()
+}
+
+// DefaultCardDisplay returns the template's card display config.
+func (t BoardTemplate) DefaultCardDisplay() model.CardDisplayConfig {
+	if t.cardDisplay == nil {
+		return model.CardDisplayConfig{}
+	}
+	return t.cardDisplay()
+}
+
+// boardTemplates lists the built-in templates; the first is the default.
+var boardTemplates = []BoardTemplate{
+	{
+		Name:         "default",
+		Description:  "backlog, next, in-progress, done with a type field",
+		columns:      model.DefaultColumns,
+		customFields: model.DefaultCustomFields,
+		cardDisplay:  model.DefaultCardDisplay,
+	},
+	{
+		Name:        "simple",
+		Description: "todo, doing, done with no custom fields",
+		columns: func() []model.Column {
+			return []model.Column{
+				{Name: "todo", Color: "#6b7280"},
+				{Name: "doing", Color: "#f59e0b"},
+				{Name: "done", Color: "#10b981"},
+			}
+		},
+	},
+	{
+		Name:        "bug-tracker",
+		Description: "triage through fixed, with type and severity fields",
+		columns: func() []model.Column {
+			return []model.Column{
+				{Name: "triage", Color: "#6b7280", Description: "Reported, not yet reproduced"},
+				{Name: "confirmed", Color: "#3b82f6", Description: "Reproduced and ready to pick up"},
+				{Name: "in-progress", Color: "#f59e0b"},
+				{Name: "fixed", Color: "#10b981"},
+			}
+		},
+		customFields: func() map[string]model.CustomFieldSchema {
+			return map[string]model.CustomFieldSchema{
+				"type": {
+					Type: model.FieldTypeEnum,
+					Options: []model.CustomFieldOption{
+						{Value: "bug", Color: "#dc2626"},
+						{Value: "regression", Color: "#c2410c"},
+						{Value: "crash", Color: "#991b1b"},
+					},
+				},
+				"severity": {
+					Type:   model.FieldTypeEnum,
+					Wanted: true,
+					Options: []model.CustomFieldOption{
+						{Value: "critical", Color: "#dc2626"},
+						{Value: "high", Color: "#f59e0b"},
+						{Value: "medium", Color: "#3b82f6"},
+						{Value: "low", Color: "#6b7280"},
+					},
+				},
+			}
+		},
+		cardDisplay: func() model.CardDisplayConfig {
+			return model.CardDisplayConfig{TypeIndicator: "type", Tint: "severity"}
+		},
+	},
+}
+
+// findBoardTemplate returns the built-in template with the given name.
+func findBoardTemplate(name string) (BoardTemplate, bool) {
+	for _, t := range boardTemplates {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return BoardTemplate{}, false
+}
+
+// runBoardCreateWizard prompts for a board name (unless given), a template and
+// any extra columns, and returns the config to create.
+func runBoardCreateWizard(p prompt.Prompter, name string) (*model.BoardConfig, error) {
+	if name == "" {
+		var err error
+		name, err = p.Input("Board name", "")
+		if err != nil {
+			return nil, err
+		}
+		name = strings.TrimSpace(name)
+	}
+
+	names := make([]string, len(boardTemplates))
+	for i, t := range boardTemplates {
+		names[i] = t.Name
+	}
+	choice, err := p.Select("Template", names)
+	if err != nil {
+		return nil, err
+	}
+	tmpl, ok := findBoardTemplate(choice)
+	if !ok {
+		return nil, fmt.Errorf("unknown template %q", choice)
+	}
+
+	cfg := &model.BoardConfig{
+		Name:         name,
+		Columns:      tmpl.DefaultColumns(),
+		CustomFields: tmpl.DefaultCustomFields(),
+		CardDisplay:  tmpl.DefaultCardDisplay(),
+	}
+
+	addMore, err := p.Confirm("Add extra columns?", false)
+	if err != nil {
+		return nil, err
+	}
+	for addMore {
+		col, err := p.Input("Column name (blank to finish)", "")
+		if err != nil {
+			return nil, err
+		}
+		col = strings.TrimSpace(col)
+		if col == "" {
+			break
+		}
+		cfg.Columns = append(cfg.Columns, model.Column{Name: col, Color: model.NextColumnColor(len(cfg.Columns))})
+	}
+
+	return cfg, nil
+}
+
+// stdinIsTerminal reports whether stdin is an interactive terminal.
+func stdinIsTerminal() bool {
+	info, err := os.Stdin.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,155 @@
+package cli
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/amterp/kan/internal/config"
+	"github.com/amterp/kan/internal/prompt"
+	"github.com/amterp/kan/internal/service"
+	"github.com/amterp/kan/internal/store"
+)
+
+// scriptedPrompter implements prompt.Prompter by replaying canned answers in
+// order, so a test can drive a whole wizard.
+type scriptedPrompter struct {
+	inputs   []string
+	selects  []string
+	confirms []bool
+}
+
+func (p *scriptedPrompter) Select(title string, options []string) (string, error) {
+	answer := p.selects[0]
+	p.selects = p.selects[1:]
+	return answer, nil
+}
+
+func (p *scriptedPrompter) Input(title string, defaultValue string) (string, error) {
+	answer := p.inputs[0]
+	p.inputs = p.inputs[1:]
+	return answer, nil
+}
+
+func (p *scriptedPrompter) Confirm(title string, defaultValue bool) (bool, error) {
+	answer := p.confirms[0]
+	p.confirms = p.confirms[1:]
+	return answer, nil
+}
+
+func (p *scriptedPrompter) MultiSelect(title string, options []string) ([]string, error) {
+	return nil, prompt.ErrNonInteractive
+}
+
+var _ prompt.Prompter = (*scriptedPrompter)(nil)
+
+func TestBoardCreateWizard_CreatesSelectedTemplate(t *testing.T) {
+	for _, tmpl := range boardTemplates {
+		t.Run(tmpl.Name, func(t *testing.T) {
+			root := t.TempDir()
+			paths := config.NewPaths(root, "")
+			boardStore := store.NewBoardStore(paths)
+			boardService := service.NewBoardService(boardStore, store.NewCardStore(paths))
+
+			p := &scriptedPrompter{
+				inputs:   []string{"work"},
+				selects:  []string{tmpl.Name},
+				confirms: []bool{false},
+			}
+			cfg, err := runBoardCreateWizard(p, "")
+			if err != nil {
+				t.Fatalf("wizard: %v", err)
+			}
+			if _, err := boardService.CreateWithConfig(cfg); err != nil {
+				t.Fatalf("CreateWithConfig: %v", err)
+			}
+
+			got, err := boardStore.Get("work")
+			if err != nil {
+				t.Fatalf("Get: %v", err)
+			}
+			if !reflect.DeepEqual(got.Columns, tmpl.DefaultColumns()) {
+				t.Errorf("Columns = %+v, want %+v", got.Columns, tmpl.DefaultColumns())
+			}
+			if len(got.CustomFields) != len(tmpl.DefaultCustomFields()) ||
+				(len(got.CustomFields) > 0 && !reflect.DeepEqual(got.CustomFields, tmpl.DefaultCustomFields())) {
+				t.Errorf("CustomFields = %+v, want %+v", got.CustomFields, tmpl.DefaultCustomFields())
+			}
+			if !reflect.DeepEqual(got.CardDisplay, tmpl.DefaultCardDisplay()) {
+				t.Errorf("CardDisplay = %+v, want %+v", got.CardDisplay, tmpl.DefaultCardDisplay())
+			}
+			if got.DefaultColumn != tmpl.DefaultColumns()[0].Name {
+				t.Errorf("DefaultColumn = %q, want the first column", got.DefaultColumn)
+			}
+			if warnings := got.ValidateCardDisplay(); len(warnings) > 0 {
+				t.Errorf("template card display is invalid: %v", warnings)
+			}
+		})
+	}
+}
+
+func TestBoardCreateWizard_ExtraColumns(t *testing.T) {
+	p := &scriptedPrompter{
+		inputs:   []string{"review", " blocked ", ""},
+		selects:  []string{"simple"},
+		confirms: []bool{true},
+	}
+
+	// A name given on the command line is not prompted for.
+	cfg, err := runBoardCreateWizard(p, "ops")
+	if err != nil {
+		t.Fatalf("wizard: %v", err)
+	}
+
+	if cfg.Name != "ops" {
+		t.Errorf("Name = %q, want ops", cfg.Name)
+	}
+	var names []string
+	for _, col := range cfg.Columns {
+		names = append(names, col.Name)
+		if col.Color == "" {
+			t.Errorf("column %q has no color", col.Name)
+		}
+	}
+	want := []string{"todo", "doing", "done", "review", "blocked"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("columns = %v, want %v", names, want)
+	}
+}
+
+func TestBoardCreateWizard_RejectsInvalidExtraColumn(t *testing.T) {
+	root := t.TempDir()
+	paths := config.NewPaths(root, "")
+	boardService := service.NewBoardService(store.NewBoardStore(paths), store.NewCardStore(paths))
+
+	p := &scriptedPrompter{
+		inputs:   []string{"In Review", ""},
+		selects:  []string{"simple"},
+		confirms: []bool{true},
+	}
+	cfg, err := runBoardCreateWizard(p, "ops")
+	if err != nil {
+		t.Fatalf("wizard: %v", err)
+	}
+	if _, err := boardService.CreateWithConfig(cfg); err == nil {
+		t.Error("expected an invalid column name to be rejected")
+	}
+	if boardService.Exists("ops") {
+		t.Error("board should not be created when validation fails")
+	}
+}
+
+func TestFindBoardTemplate(t *testing.T) {
+	if _, ok := findBoardTemplate("bug-tracker"); !ok {
+		t.Error("expected bug-tracker template")
+	}
+	if _, ok := findBoardTemplate("nope"); ok {
+		t.Error("expected unknown template to be missing")
+	}
+	// Each call returns fresh values so callers can modify them freely.
+	tmpl, _ := findBoardTemplate("default")
+	cols := tmpl.DefaultColumns()
+	cols[0].Name = "changed"
+	if tmpl.DefaultColumns()[0].Name == "changed" {
+		t.Error("DefaultColumns should return a fresh slice")
+	}
+}
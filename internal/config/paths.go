@@ -12,6 +12,10 @@ const (
 	ConfigFileName    = "config.toml"
 	GlobalConfigDir   = ".config/kan"
 	CustomFaviconFile = "favicon.svg"
+	AuditLogFile      = "audit.jsonl"
+	HookHistoryFile   = "hooks-history.jsonl"
+	LockFile          = ".lock"
+	AliasIndexFile    = ".alias-index.json"
 )
 
 // Paths provides path resolution for Kan data files.
@@ -61,6 +65,12 @@ func (p *Paths) CardPath(boardName, cardID string) string {
 	return filepath.Join(p.CardsDir(boardName), cardID+".json")
 }
 
+// AliasIndexPath returns the path to a board's alias index, a cache that
+// FileCardStore derives from the card files.
+func (p *Paths) AliasIndexPath(boardName string) string {
+	return filepath.Join(p.BoardDir(boardName), AliasIndexFile)
+}
+
 // ProjectConfigPath returns the path to the project config file.
 func (p *Paths) ProjectConfigPath() string {
 	return filepath.Join(p.KanRoot(), ConfigFileName)
@@ -71,6 +81,21 @@ func (p *Paths) CustomFaviconPath() string {
 	return filepath.Join(p.KanRoot(), CustomFaviconFile)
 }
 
+// LockPath returns the path to the lock file guarding writes to Kan data.
+func (p *Paths) LockPath() string {
+	return filepath.Join(p.KanRoot(), LockFile)
+}
+
+// AuditLogPath returns the path to the project's audit log.
+func (p *Paths) AuditLogPath() string {
+	return filepath.Join(p.KanRoot(), AuditLogFile)
+}
+
+// HookHistoryPath returns the path to the project's hook execution history.
+func (p *Paths) HookHistoryPath() string {
+	return filepath.Join(p.KanRoot(), HookHistoryFile)
+}
+
 // GlobalConfigPath returns the path to the global config file.
 func GlobalConfigPath() string {
 	home, err := os.UserHomeDir()
@@ -3,6 +3,7 @@ package errors
 import (
 	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 )
 
@@ -15,49 +16,84 @@ var (
 	ErrAmbiguous      = errors.New("ambiguous match")
 )
 
-// NotFoundError indicates a resource doesn't exist.
-type NotFoundError struct {
-	Resource string // "card", "board", "config"
-	ID       string // The identifier that wasn't found
-}
+// Error codes carried by KanError.
+const (
+	CodeCardNotFound          = "CARD_NOT_FOUND"
+	CodeBoardNotFound         = "BOARD_NOT_FOUND"
+	CodeColumnNotFound        = "COLUMN_NOT_FOUND"
+	CodeFieldNotFound         = "FIELD_NOT_FOUND"
+	CodeTemplateNotFound      = "TEMPLATE_NOT_FOUND"
+	CodeCommentNotFound       = "COMMENT_NOT_FOUND"
+	CodeChecklistItemNotFound = "CHECKLIST_ITEM_NOT_FOUND"
+	CodeSnapshotNotFound      = "SNAPSHOT_NOT_FOUND"
+	CodeSprintNotFound        = "SPRINT_NOT_FOUND"
+
+	CodeBoardAlreadyExists  = "BOARD_ALREADY_EXISTS"
+	CodeColumnAlreadyExists = "COLUMN_ALREADY_EXISTS"
+	CodeFieldAlreadyExists  = "FIELD_ALREADY_EXISTS"
+	CodeCardAlreadyExists   = "CARD_ALREADY_EXISTS"
+
+	CodeInvalidField        = "INVALID_FIELD"
+	CodeColumnAtLimit       = "COLUMN_AT_LIMIT"
+	CodeTransitionViolation = "TRANSITION_VIOLATION"
+
+	CodeRequestTooLarge      = "REQUEST_TOO_LARGE"
+	CodeUnsupportedMediaType = "UNSUPPORTED_MEDIA_TYPE"
+)
+
+// KanError is a structured error: a stable Code for callers to check, the
+// HTTP status the API answers with, and Details about what went wrong (e.g.
+// the column and limit of a full column).
+//
+// A KanError matches another KanError with the same Code under errors.Is,
+// and the sentinel of its kind (ErrNotFound, ErrAlreadyExists or
+// ErrInvalidInput), so IsNotFound and friends keep working.
+type KanError struct {
+	Code       string
+	Message    string
+	HTTPStatus int
+	Details    map[string]any
 
-func (e *NotFoundError) Error() string {
-	return fmt.Sprintf("%s not found: %s", e.Resource, e.ID)
+	kind error // sentinel the error matches under errors.Is
 }
 
-func (e *NotFoundError) Unwrap() error {
-	return ErrNotFound
+func newKanError(kind error, code string, status int, message string) *KanError {
+	return &KanError{Code: code, Message: message, HTTPStatus: status, kind: kind}
 }
 
-// AlreadyExistsError indicates a resource already exists.
-type AlreadyExistsError struct {
-	Resource string
-	ID       string
+func (e *KanError) Error() string {
+	return e.Message
 }
 
-func (e *AlreadyExistsError) Error() string {
-	return fmt.Sprintf("%s already exists: %s", e.Resource, e.ID)
+// Is reports whether target is a KanError with the same code, or the
+// sentinel of e's kind.
+func (e *KanError) Is(target error) bool {
+	if t, ok := target.(*KanError); ok {
+		return t.Code == e.Code
+	}
+	return e.kind != nil && target == e.kind
 }
 
-func (e *AlreadyExistsError) Unwrap() error {
-	return ErrAlreadyExists
+// WithDetail sets a detail on e and returns it, for chaining.
+func (e *KanError) WithDetail(key string, value any) *KanError {
+	if e.Details == nil {
+		e.Details = make(map[string]any)
+	}
+	e.Details[key] = value
+	return e
 }
 
-// ValidationError indicates invalid user input.
-type ValidationError struct {
-	Field   string
-	Message string
+// IsCode reports whether err is, or wraps, a KanError with the given code.
+func IsCode(err error, code string) bool {
+	return errors.Is(err, &KanError{Code: code})
 }
 
-func (e *ValidationError) Error() string {
-	if e.Field != "" {
-		return fmt.Sprintf("invalid %s: %s", e.Field, e.Message)
-	}
-	return e.Message
+func notFound(code, resource, id string) *KanError {
+	return newKanError(ErrNotFound, code, http.StatusNotFound, fmt.Sprintf("%s not found: %s", resource, id))
 }
 
-func (e *ValidationError) Unwrap() error {
-	return ErrInvalidInput
+func alreadyExists(code, resource, id string) *KanError {
+	return newKanError(ErrAlreadyExists, code, http.StatusConflict, fmt.Sprintf("%s already exists: %s", resource, id))
 }
 
 // AmbiguousMatch is one candidate in an AmbiguousCardError list.
@@ -104,6 +140,44 @@ func (e *AmbiguousCardError) Unwrap() error {
 	return ErrAmbiguous
 }
 
+// BulkOperationError indicates a batch operation was refused because some of its
+// items couldn't be processed. Batch operations are all-or-nothing, so nothing
+// was applied when this is returned. FailedIDs lists the offending inputs in
+// the order they were given.
+type BulkOperationError struct {
+	Operation string // e.g. "move"
+	FailedIDs []string
+	Reason    string // optional detail, e.g. "card not found"
+}
+
+func (e *BulkOperationError) Error() string {
+	msg := fmt.Sprintf("bulk %s failed for %d card(s): %s", e.Operation, len(e.FailedIDs), strings.Join(e.FailedIDs, ", "))
+	if e.Reason != "" {
+		msg += " (" + e.Reason + ")"
+	}
+	return msg
+}
+
+func (e *BulkOperationError) Unwrap() error {
+	return ErrInvalidInput
+}
+
+// DowngradeError indicates a schema downgrade was refused because the target
+// schema can't represent some of the data. Nothing was written when this is
+// returned.
+type DowngradeError struct {
+	Target string   // e.g. "board/3"
+	Losses []string // one entry per value that would be dropped
+}
+
+func (e *DowngradeError) Error() string {
+	return fmt.Sprintf("cannot downgrade to %s without losing data:\n  - %s", e.Target, strings.Join(e.Losses, "\n  - "))
+}
+
+func (e *DowngradeError) Unwrap() error {
+	return ErrInvalidInput
+}
+
 // NotInitializedError indicates Kan isn't set up in the repo.
 type NotInitializedError struct {
 	Path string
@@ -142,20 +216,45 @@ func (e *StaleGlobalBoardError) Error() string {
 
 // Helper constructors for common cases
 
-func CardNotFound(idOrAlias string) error {
-	return &NotFoundError{Resource: "card", ID: idOrAlias}
+func CardNotFound(idOrAlias string) *KanError {
+	return notFound(CodeCardNotFound, "card", idOrAlias).WithDetail("card", idOrAlias)
+}
+
+func BoardNotFound(name string) *KanError {
+	return notFound(CodeBoardNotFound, "board", name).WithDetail("board", name)
+}
+
+func ColumnNotFound(name, board string) *KanError {
+	return notFound(CodeColumnNotFound, "column", fmt.Sprintf("%s (in board %s)", name, board)).
+		WithDetail("column", name).WithDetail("board", board)
+}
+
+func FieldNotFound(name, board string) *KanError {
+	return notFound(CodeFieldNotFound, "custom field", fmt.Sprintf("%s (in board %s)", name, board)).
+		WithDetail("field", name).WithDetail("board", board)
+}
+
+func TemplateNotFound(name, board string) *KanError {
+	return notFound(CodeTemplateNotFound, "card template", fmt.Sprintf("%s (in board %s)", name, board)).
+		WithDetail("template", name).WithDetail("board", board)
+}
+
+func CommentNotFound(id string) *KanError {
+	return notFound(CodeCommentNotFound, "comment", id).WithDetail("comment", id)
 }
 
-func BoardNotFound(name string) error {
-	return &NotFoundError{Resource: "board", ID: name}
+func ChecklistItemNotFound(id string) *KanError {
+	return notFound(CodeChecklistItemNotFound, "checklist item", id).WithDetail("item", id)
 }
 
-func ColumnNotFound(name, board string) error {
-	return &NotFoundError{Resource: "column", ID: fmt.Sprintf("%s (in board %s)", name, board)}
+func SnapshotNotFound(id string) *KanError {
+	return notFound(CodeSnapshotNotFound, "snapshot", id).WithDetail("snapshot", id)
 }
 
-func CommentNotFound(id string) error {
-	return &NotFoundError{Resource: "comment", ID: id}
+// NoActiveSprint reports a board with no column whose sprint covers now.
+func NoActiveSprint(board string) *KanError {
+	return newKanError(ErrNotFound, CodeSprintNotFound, http.StatusNotFound,
+		fmt.Sprintf("no active sprint in board %s", board)).WithDetail("board", board)
 }
 
 // NewAmbiguousCardError builds an AmbiguousCardError. The full match list is
@@ -166,25 +265,66 @@ func NewAmbiguousCardError(input string, matches []AmbiguousMatch, displayLimit
 	return &AmbiguousCardError{Input: input, Matches: matches, DisplayLimit: displayLimit}
 }
 
-func BoardAlreadyExists(name string) error {
-	return &AlreadyExistsError{Resource: "board", ID: name}
+func BoardAlreadyExists(name string) *KanError {
+	return alreadyExists(CodeBoardAlreadyExists, "board", name).WithDetail("board", name)
+}
+
+func ColumnAlreadyExists(name, board string) *KanError {
+	return alreadyExists(CodeColumnAlreadyExists, "column", fmt.Sprintf("%s (in board %s)", name, board)).
+		WithDetail("column", name).WithDetail("board", board)
 }
 
-func ColumnAlreadyExists(name, board string) error {
-	return &AlreadyExistsError{Resource: "column", ID: fmt.Sprintf("%s (in board %s)", name, board)}
+func FieldAlreadyExists(name, board string) *KanError {
+	return alreadyExists(CodeFieldAlreadyExists, "custom field", fmt.Sprintf("%s (in board %s)", name, board)).
+		WithDetail("field", name).WithDetail("board", board)
 }
 
-func InvalidField(field, message string) error {
-	return &ValidationError{Field: field, Message: message}
+func CardAlreadyExists(id, board string) *KanError {
+	return alreadyExists(CodeCardAlreadyExists, "card", fmt.Sprintf("%s (in board %s)", id, board)).
+		WithDetail("card", id).WithDetail("board", board)
 }
 
-func ColumnLimitExceeded(columnName string, limit int) error {
-	return &ValidationError{
-		Message: fmt.Sprintf("column %q is full (limit: %d)", columnName, limit),
+// InvalidField reports invalid user input. field may be empty when the input
+// as a whole is invalid, in which case message is the whole error.
+func InvalidField(field, message string) *KanError {
+	if field == "" {
+		return newKanError(ErrInvalidInput, CodeInvalidField, http.StatusBadRequest, message)
 	}
+	return newKanError(ErrInvalidInput, CodeInvalidField, http.StatusBadRequest,
+		fmt.Sprintf("invalid %s: %s", field, message)).WithDetail("field", field)
+}
+
+// RequestTooLarge reports an API request body over the server's limit.
+func RequestTooLarge(limit int64) *KanError {
+	return newKanError(ErrInvalidInput, CodeRequestTooLarge, http.StatusRequestEntityTooLarge,
+		fmt.Sprintf("request body exceeds %d bytes", limit)).WithDetail("limit", limit)
+}
+
+// UnsupportedMediaType reports an API request body of a type the route
+// doesn't read.
+func UnsupportedMediaType(contentType string) *KanError {
+	return newKanError(ErrInvalidInput, CodeUnsupportedMediaType, http.StatusUnsupportedMediaType,
+		"Content-Type must be application/json").WithDetail("content_type", contentType)
+}
+
+// ColumnAtLimit reports a column at its WIP limit, so no more cards can be
+// added or moved into it.
+func ColumnAtLimit(columnName string, limit int) *KanError {
+	return newKanError(ErrInvalidInput, CodeColumnAtLimit, http.StatusConflict,
+		fmt.Sprintf("column '%s' is at its WIP limit of %d", columnName, limit)).
+		WithDetail("column", columnName).WithDetail("limit", limit)
+}
+
+// TransitionViolation reports a card that doesn't meet a column's transition
+// rules, so it can't be moved there without forcing.
+func TransitionViolation(columnName, message string) *KanError {
+	return newKanError(ErrInvalidInput, CodeTransitionViolation, http.StatusUnprocessableEntity,
+		fmt.Sprintf("cannot move card to '%s': %s", columnName, message)).
+		WithDetail("column", columnName)
 }
 
-// IsNotFound checks if an error is a not-found error.
+// IsNotFound checks if an error is a not-found error of any code. Prefer
+// IsCode when only one kind of resource is expected.
 func IsNotFound(err error) bool {
 	return errors.Is(err, ErrNotFound)
 }
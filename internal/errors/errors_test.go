@@ -0,0 +1,56 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestKanError_Is(t *testing.T) {
+	err := fmt.Errorf("loading: %w", CardNotFound("abc"))
+
+	if !IsCode(err, CodeCardNotFound) {
+		t.Errorf("expected %s through wrapping, got %v", CodeCardNotFound, err)
+	}
+	if IsCode(err, CodeBoardNotFound) {
+		t.Errorf("card not found should not match %s", CodeBoardNotFound)
+	}
+	if !IsNotFound(err) || IsValidationError(err) || IsAlreadyExists(err) {
+		t.Errorf("sentinel predicates disagree for %v", err)
+	}
+	if IsCode(errors.New("card not found: abc"), CodeCardNotFound) {
+		t.Error("plain errors should not match a code")
+	}
+}
+
+func TestKanError_Fields(t *testing.T) {
+	tests := []struct {
+		err     *KanError
+		code    string
+		status  int
+		message string
+	}{
+		{CardNotFound("abc"), CodeCardNotFound, http.StatusNotFound, "card not found: abc"},
+		{ColumnNotFound("next", "main"), CodeColumnNotFound, http.StatusNotFound, "column not found: next (in board main)"},
+		{BoardAlreadyExists("main"), CodeBoardAlreadyExists, http.StatusConflict, "board already exists: main"},
+		{InvalidField("title", "cannot be empty"), CodeInvalidField, http.StatusBadRequest, "invalid title: cannot be empty"},
+		{InvalidField("", "nothing to do"), CodeInvalidField, http.StatusBadRequest, "nothing to do"},
+		{ColumnAtLimit("doing", 3), CodeColumnAtLimit, http.StatusConflict, "column 'doing' is at its WIP limit of 3"},
+		{TransitionViolation("done", "needs review"), CodeTransitionViolation, http.StatusUnprocessableEntity, "cannot move card to 'done': needs review"},
+	}
+	for _, tt := range tests {
+		if tt.err.Code != tt.code || tt.err.HTTPStatus != tt.status || tt.err.Error() != tt.message {
+			t.Errorf("got (%s, %d, %q), want (%s, %d, %q)",
+				tt.err.Code, tt.err.HTTPStatus, tt.err.Error(), tt.code, tt.status, tt.message)
+		}
+	}
+}
+
+func TestKanError_WithDetail(t *testing.T) {
+	err := ColumnAtLimit("doing", 3).WithDetail("card", "abc")
+
+	if err.Details["column"] != "doing" || err.Details["limit"] != 3 || err.Details["card"] != "abc" {
+		t.Errorf("unexpected details: %v", err.Details)
+	}
+}
@@ -0,0 +1,90 @@
+// Package export renders boards in formats meant for other tools.
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/amterp/kan/internal/model"
+)
+
+// csvBuiltinColumns are the leading CSV columns, one per built-in card field.
+// Custom fields follow, one column each in name order.
+var csvBuiltinColumns = []string{"id", "alias", "title", "description", "column", "created_at", "updated_at", "parent"}
+
+// setValueSeparator joins the values of enum-set and free-set fields into a
+// single cell.
+const setValueSeparator = ";"
+
+// ExportBoardCSV writes one row per card to w, preceded by a header row.
+// Timestamps are RFC 3339 in UTC so spreadsheets parse them as dates. Cards
+// are written in the order given.
+func ExportBoardCSV(boardCfg *model.BoardConfig, cards []*model.Card, w io.Writer) error {
+	fieldNames := make([]string, 0, len(boardCfg.CustomFields))
+	for name := range boardCfg.CustomFields {
+		fieldNames = append(fieldNames, name)
+	}
+	sort.Strings(fieldNames)
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(append(append([]string{}, csvBuiltinColumns...), fieldNames...)); err != nil {
+		return err
+	}
+
+	for _, card := range cards {
+		row := []string{
+			card.ID,
+			card.Alias,
+			card.Title,
+			card.Description,
+			card.Column,
+			formatMillis(card.CreatedAtMillis),
+			formatMillis(card.UpdatedAtMillis),
+			card.Parent,
+		}
+		for _, name := range fieldNames {
+			row = append(row, formatFieldValue(card.CustomFields[name]))
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func formatMillis(millis int64) string {
+	if millis == 0 {
+		return ""
+	}
+	return time.UnixMilli(millis).UTC().Format(time.RFC3339)
+}
+
+// formatFieldValue renders a custom field value as a single cell. Values
+// decoded from card JSON arrive as string, bool, float64 or []any.
+func formatFieldValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case []string:
+		return strings.Join(v, setValueSeparator)
+	case []any:
+		parts := make([]string, 0, len(v))
+		for _, item := range v {
+			parts = append(parts, formatFieldValue(item))
+		}
+		return strings.Join(parts, setValueSeparator)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,102 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"reflect"
+	"testing"
+
+	"github.com/amterp/kan/internal/model"
+)
+
+// exportRows runs ExportBoardCSV and parses the output back into rows.
+func exportRows(t *testing.T, boardCfg *model.BoardConfig, cards []*model.Card) [][]string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := ExportBoardCSV(boardCfg, cards, &buf); err != nil {
+		t.Fatalf("ExportBoardCSV failed: %v", err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("output is not valid CSV: %v\n%s", err, buf.String())
+	}
+	return rows
+}
+
+func testBoard() *model.BoardConfig {
+	return &model.BoardConfig{
+		Name: "main",
+		CustomFields: map[string]model.CustomFieldSchema{
+			"type":   {Type: model.FieldTypeEnum},
+			"labels": {Type: model.FieldTypeEnumSet},
+			"points": {Type: model.FieldTypeInteger},
+		},
+	}
+}
+
+func TestExportBoardCSV_Header(t *testing.T) {
+	rows := exportRows(t, testBoard(), nil)
+
+	want := []string{"id", "alias", "title", "description", "column", "created_at", "updated_at", "parent", "labels", "points", "type"}
+	if len(rows) != 1 || !reflect.DeepEqual(rows[0], want) {
+		t.Errorf("rows = %v, want only the header %v", rows, want)
+	}
+}
+
+func TestExportBoardCSV_CardWithoutCustomFields(t *testing.T) {
+	card := &model.Card{
+		ID:              "c1",
+		Alias:           "fix-login",
+		Title:           "Fix login",
+		Column:          "backlog",
+		CreatedAtMillis: 1700000000000,
+		UpdatedAtMillis: 1700000060000,
+	}
+	rows := exportRows(t, testBoard(), []*model.Card{card})
+
+	want := []string{"c1", "fix-login", "Fix login", "", "backlog", "2023-11-14T22:13:20Z", "2023-11-14T22:14:20Z", "", "", "", ""}
+	if !reflect.DeepEqual(rows[1], want) {
+		t.Errorf("row = %v, want %v", rows[1], want)
+	}
+}
+
+func TestExportBoardCSV_EnumSetValues(t *testing.T) {
+	card := &model.Card{
+		ID:     "c1",
+		Title:  "Tagged",
+		Parent: "p1",
+		CustomFields: map[string]any{
+			"labels": []any{"frontend", "urgent"},
+			"points": float64(5),
+			"type":   "bug",
+		},
+	}
+	rows := exportRows(t, testBoard(), []*model.Card{card})
+
+	got := rows[1][len(csvBuiltinColumns):]
+	if want := []string{"frontend;urgent", "5", "bug"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("custom field cells = %v, want %v", got, want)
+	}
+	if rows[1][7] != "p1" {
+		t.Errorf("parent = %q, want p1", rows[1][7])
+	}
+}
+
+func TestExportBoardCSV_DescriptionWithCommas(t *testing.T) {
+	card := &model.Card{
+		ID:          "c1",
+		Title:       `Say "hi", then leave`,
+		Description: "First, second, third\nand a new line",
+	}
+	rows := exportRows(t, testBoard(), []*model.Card{card})
+
+	if rows[1][2] != card.Title {
+		t.Errorf("title = %q, want %q", rows[1][2], card.Title)
+	}
+	if rows[1][3] != card.Description {
+		t.Errorf("description = %q, want %q", rows[1][3], card.Description)
+	}
+	if len(rows[1]) != len(rows[0]) {
+		t.Errorf("row has %d cells, header has %d", len(rows[1]), len(rows[0]))
+	}
+}
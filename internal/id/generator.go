@@ -27,20 +27,24 @@ var validIDPattern = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
 type Entity int
 
 const (
-	Card    Entity = iota // a_
-	Board                 // b_
-	Comment               // c_
-	Project               // p_
-	// Future entities: d_, e_, ...
+	Card          Entity = iota // a_
+	Board                       // b_
+	Comment                     // c_
+	Project                     // p_
+	ChecklistItem               // d_
+	HookTask                    // e_
+	// Future entities: f_, g_, ...
 )
 
 // prefixes maps entity types to their current prefix.
 // These are purely cosmetic and may change—see package doc.
 var prefixes = map[Entity]string{
-	Card:    "a_",
-	Board:   "b_",
-	Comment: "c_",
-	Project: "p_",
+	Card:          "a_",
+	Board:         "b_",
+	Comment:       "c_",
+	Project:       "p_",
+	ChecklistItem: "d_",
+	HookTask:      "e_",
 }
 
 var generator *fid.Generator
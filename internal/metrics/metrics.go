@@ -0,0 +1,68 @@
+// Package metrics defines the Prometheus collectors kan exposes on /metrics
+// when running `kan serve`. Collectors are registered with the default
+// registry, so promhttp.Handler() serves them alongside Go runtime metrics.
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// CardCreatedTotal counts cards created, per board.
+var CardCreatedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "kan_cards_created_total",
+	Help: "Number of cards created.",
+}, []string{"board"})
+
+// CardDeletedTotal counts cards deleted, per board.
+var CardDeletedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "kan_cards_deleted_total",
+	Help: "Number of cards deleted.",
+}, []string{"board"})
+
+// CardMovedTotal counts card moves between columns, per board. Reorders
+// within a column are not counted.
+var CardMovedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "kan_cards_moved_total",
+	Help: "Number of cards moved to a different column.",
+}, []string{"board"})
+
+// HookExecutionsTotal counts pattern hook runs, per board and hook name.
+// success is "true" or "false" depending on whether the hook exited cleanly.
+var HookExecutionsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "kan_hook_executions_total",
+	Help: "Number of pattern hook executions.",
+}, []string{"board", "hook", "success"})
+
+// HookDurationSeconds observes how long each pattern hook took to run.
+var HookDurationSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "kan_hook_duration_seconds",
+	Help:    "Pattern hook execution time in seconds.",
+	Buckets: prometheus.DefBuckets,
+}, []string{"board", "hook"})
+
+// CardsTotal is the number of active cards in each column. It's refreshed
+// whenever a board's cards are listed, so it can lag behind changes made
+// outside the server (e.g. via the CLI) until the next listing.
+var CardsTotal = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "kan_cards_total",
+	Help: "Number of active cards per column, as of the last listing.",
+}, []string{"board", "column"})
+
+func init() {
+	prometheus.MustRegister(
+		CardCreatedTotal,
+		CardDeletedTotal,
+		CardMovedTotal,
+		HookExecutionsTotal,
+		HookDurationSeconds,
+		CardsTotal,
+	)
+}
+
+// SetColumnCounts replaces the board's kan_cards_total series with counts.
+// Columns missing from counts are dropped, so renamed or removed columns
+// don't linger.
+func SetColumnCounts(board string, counts map[string]int) {
+	CardsTotal.DeletePartialMatch(prometheus.Labels{"board": board})
+	for column, n := range counts {
+		CardsTotal.WithLabelValues(board, column).Set(float64(n))
+	}
+}
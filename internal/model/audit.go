@@ -0,0 +1,31 @@
+package model
+
+// Audit entity types.
+const (
+	AuditEntityCard   = "card"
+	AuditEntityBoard  = "board"
+	AuditEntityColumn = "column"
+)
+
+// Audit actions.
+const (
+	AuditActionCreated = "created"
+	AuditActionUpdated = "updated"
+	AuditActionDeleted = "deleted"
+	AuditActionMoved   = "moved"
+)
+
+// AuditEntry records a single mutation for the project's audit log.
+//
+// Delta holds the fields that changed, keyed by field name, with their new
+// values (nil for a removed field). Moves record "from" and "to" columns
+// instead. Delta is informational: it is not meant to replay changes.
+type AuditEntry struct {
+	Timestamp  int64          `json:"timestamp"`
+	Actor      string         `json:"actor,omitempty"`
+	BoardName  string         `json:"board"`
+	EntityType string         `json:"entity_type"`
+	EntityID   string         `json:"entity_id"`
+	Action     string         `json:"action"`
+	Delta      map[string]any `json:"delta,omitempty"`
+}
@@ -1,8 +1,16 @@
 package model
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/url"
 	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/amterp/kan/internal/util"
 )
 
 // Custom field type constants.
@@ -13,14 +21,28 @@ const (
 	FieldTypeFreeSet = "free-set"
 	FieldTypeDate    = "date"
 	FieldTypeBoolean = "boolean"
+	FieldTypeInteger = "integer"
+	FieldTypeURL     = "url"
+	FieldTypeUser    = "user"
+
+	// FieldTypeTags is accepted in board configs (board/18+) as a synonym for
+	// FieldTypeFreeSet. Configs keep it as written, so code compares
+	// CustomFieldSchema.FieldType rather than Type against FieldTypeFreeSet.
+	// Before board/5 "tags" meant enum-set; the v4 -> v5 migration renames
+	// those, so the two meanings never meet.
+	FieldTypeTags = "tags"
 )
 
+// DefaultTagMaxLength is the maximum length, in characters, of each value of a
+// free-set field whose max_length is unset.
+const DefaultTagMaxLength = 50
+
 // MaxSetItems is the maximum number of values allowed per set field (enum-set, free-set).
 // This prevents accidental abuse and keeps the UI manageable.
 const MaxSetItems = 10
 
 // ValidFieldTypes lists all supported custom field types.
-var ValidFieldTypes = []string{FieldTypeString, FieldTypeEnum, FieldTypeEnumSet, FieldTypeFreeSet, FieldTypeDate, FieldTypeBoolean}
+var ValidFieldTypes = []string{FieldTypeString, FieldTypeEnum, FieldTypeEnumSet, FieldTypeFreeSet, FieldTypeDate, FieldTypeBoolean, FieldTypeInteger, FieldTypeURL, FieldTypeUser}
 
 // IsValidFieldType returns true if the given type is a valid custom field type.
 func IsValidFieldType(t string) bool {
@@ -36,15 +58,50 @@ func IsValidFieldType(t string) bool {
 // Stored as config.toml in the board directory.
 // Schema changes require a version bump—see internal/version/version.go.
 type BoardConfig struct {
-	KanSchema     string                       `toml:"kan_schema" json:"kan_schema"`
-	ID            string                       `toml:"id" json:"id"`
-	Name          string                       `toml:"name" json:"name"`
-	Columns       []Column                     `toml:"columns" json:"columns"`
-	DefaultColumn string                       `toml:"default_column" json:"default_column"`
-	CustomFields  map[string]CustomFieldSchema `toml:"custom_fields,omitempty" json:"custom_fields,omitempty"`
-	CardDisplay   CardDisplayConfig            `toml:"card_display,omitempty" json:"card_display,omitempty"`
-	LinkRules     []LinkRule                   `toml:"link_rules,omitempty" json:"link_rules,omitempty"`
-	PatternHooks  []PatternHook                `toml:"pattern_hooks,omitempty" json:"pattern_hooks,omitempty"`
+	KanSchema     string   `toml:"kan_schema" json:"kan_schema"`
+	ID            string   `toml:"id" json:"id"`
+	Name          string   `toml:"name" json:"name"`
+	Columns       []Column `toml:"columns" json:"columns"`
+	DefaultColumn string   `toml:"default_column" json:"default_column"`
+	// DoneColumns names columns whose cards count as finished, in addition
+	// to columns with Done set. Unlike Done, it doesn't enable auto-archive.
+	DoneColumns  []string                     `toml:"done_columns,omitempty" json:"done_columns,omitempty"`
+	CustomFields map[string]CustomFieldSchema `toml:"custom_fields,omitempty" json:"custom_fields,omitempty"`
+	CardDisplay  CardDisplayConfig            `toml:"card_display,omitempty" json:"card_display,omitempty"`
+	Alias        AliasConfig                  `toml:"alias,omitempty" json:"alias,omitempty"`
+	Stale        StaleConfig                  `toml:"stale,omitempty" json:"stale,omitempty"`
+	LinkRules    []LinkRule                   `toml:"link_rules,omitempty" json:"link_rules,omitempty"`
+	PatternHooks []PatternHook                `toml:"pattern_hooks,omitempty" json:"pattern_hooks,omitempty"`
+
+	// ArchivedAtMillis is when the board was archived, or 0 if it's active.
+	// Archived boards keep all their data but are left out of board lists.
+	ArchivedAtMillis int64 `toml:"archived_at_millis,omitempty" json:"archived_at_millis,omitempty"`
+
+	// Templates are reusable blueprints for new cards (see CardTemplate).
+	Templates []CardTemplate `toml:"templates,omitempty" json:"templates,omitempty"`
+
+	// Collaborators are the usernames or email addresses user fields accept.
+	// When empty, user fields accept any value.
+	Collaborators []string `toml:"collaborators,omitempty" json:"collaborators,omitempty"`
+
+	// Revision counts the config's writes. BoardStore.Update increments it,
+	// so clients can detect that the config changed since they read it.
+	Revision int `toml:"revision,omitempty" json:"revision"`
+
+	// Includes are TOML files, relative to the .kan directory, whose
+	// custom_fields, link_rules and pattern_hooks are shared into this board.
+	// Definitions in the board itself win over included ones of the same name.
+	Includes []string `toml:"include,omitempty" json:"include,omitempty"`
+}
+
+// AcceptsUser reports whether a user field on this board may hold name.
+func (b *BoardConfig) AcceptsUser(name string) bool {
+	return len(b.Collaborators) == 0 || slices.Contains(b.Collaborators, name)
+}
+
+// IsArchived reports whether the board has been archived.
+func (b *BoardConfig) IsArchived() bool {
+	return b.ArchivedAtMillis > 0
 }
 
 // Column represents a kanban column.
@@ -53,6 +110,48 @@ type Column struct {
 	Color       string `toml:"color" json:"color"`
 	Description string `toml:"description,omitempty" json:"description,omitempty"`
 	Limit       int    `toml:"limit,omitempty" json:"limit,omitempty"`
+	// TransitionRules gate moves into this column on the card's custom fields.
+	TransitionRules []TransitionRule `toml:"transition_rules,omitempty" json:"transition_rules,omitempty"`
+	// Done marks a column whose cards are finished. Cards moved into it get
+	// DoneAtMillis stamped, which drives AutoArchiveAfterDays.
+	Done bool `toml:"done,omitempty" json:"done,omitempty"`
+	// AutoArchiveAfterDays archives cards that have sat in this done column for
+	// longer than this many days (0 = never).
+	AutoArchiveAfterDays int `toml:"auto_archive_after_days,omitempty" json:"auto_archive_after_days,omitempty"`
+	// SprintStartMillis and SprintEndMillis time-box the column as a sprint
+	// (both 0 = no sprint). The sprint is active while now is within them.
+	SprintStartMillis int64 `toml:"sprint_start_millis,omitempty" json:"sprint_start_millis,omitempty"`
+	SprintEndMillis   int64 `toml:"sprint_end_millis,omitempty" json:"sprint_end_millis,omitempty"`
+}
+
+// TransitionRule lists custom fields a card must (or must not) have set to be
+// moved into the column that owns the rule. A field counts as set when it
+// holds a non-empty string or set, a number, or true.
+type TransitionRule struct {
+	FromColumn     string   `toml:"from_column,omitempty" json:"from_column,omitempty"`           // Only moves from this column are checked ("" = any column)
+	RequiredFields []string `toml:"required_fields,omitempty" json:"required_fields,omitempty"`   // Fields that must be set
+	ForbidIfFields []string `toml:"forbid_if_fields,omitempty" json:"forbid_if_fields,omitempty"` // Fields that must not be set
+}
+
+// AppliesTo reports whether the rule covers a move out of fromColumn.
+func (r TransitionRule) AppliesTo(fromColumn string) bool {
+	return r.FromColumn == "" || r.FromColumn == fromColumn
+}
+
+// IsAtLimit reports whether a column holding cardCount cards has reached its
+// limit. A zero limit means unlimited.
+func (c *Column) IsAtLimit(cardCount int) bool {
+	return c.Limit > 0 && cardCount >= c.Limit
+}
+
+// HasSprint reports whether the column has a sprint set, active or not.
+func (c *Column) HasSprint() bool {
+	return c.SprintStartMillis != 0 || c.SprintEndMillis != 0
+}
+
+// IsSprintActive reports whether the column's sprint covers nowMillis.
+func (c *Column) IsSprintActive(nowMillis int64) bool {
+	return c.HasSprint() && c.SprintStartMillis <= nowMillis && nowMillis <= c.SprintEndMillis
 }
 
 // CustomFieldOption represents a single option for enum/enum-set fields.
@@ -60,14 +159,107 @@ type CustomFieldOption struct {
 	Value       string `toml:"value" json:"value"`
 	Color       string `toml:"color,omitempty" json:"color,omitempty"`
 	Description string `toml:"description,omitempty" json:"description,omitempty"`
+	Label       string `toml:"label,omitempty" json:"label,omitempty"` // Display name for an integer value (e.g. 8 = "large")
 }
 
 // CustomFieldSchema defines the schema for a custom field.
 type CustomFieldSchema struct {
-	Type        string              `toml:"type" json:"type"`                           // "string", "enum", "enum-set", "free-set", "date", "boolean"
-	Options     []CustomFieldOption `toml:"options,omitempty" json:"options,omitempty"` // For enum/enum-set types
+	Type        string              `toml:"type" json:"type"`                           // "string", "enum", "enum-set", "free-set", "date", "boolean", "integer", "url", "user"
+	Options     []CustomFieldOption `toml:"options,omitempty" json:"options,omitempty"` // For enum/enum-set types; labeled values for integer
 	Wanted      bool                `toml:"wanted,omitempty" json:"wanted,omitempty"`   // Warn if field is missing
 	Description string              `toml:"description,omitempty" json:"description,omitempty"`
+	Min         *int                `toml:"min,omitempty" json:"min,omitempty"`                 // Integer fields: inclusive lower bound (nil = unbounded)
+	Max         *int                `toml:"max,omitempty" json:"max,omitempty"`                 // Integer fields: inclusive upper bound (nil = unbounded)
+	Pattern     string              `toml:"pattern,omitempty" json:"pattern,omitempty"`         // URL fields: optional regex the URL must match
+	MaxLength   int                 `toml:"max_length,omitempty" json:"max_length,omitempty"`   // Free-set fields: max characters per value (0 = DefaultTagMaxLength)
+	FutureOnly  bool                `toml:"future_only,omitempty" json:"future_only,omitempty"` // Date fields: reject dates before today
+	PastOnly    bool                `toml:"past_only,omitempty" json:"past_only,omitempty"`     // Date fields: reject dates after today
+	Format      string              `toml:"format,omitempty" json:"format,omitempty"`           // Date fields: one of ValidDateFormats; empty = "date"
+	Order       int                 `toml:"order,omitempty" json:"order,omitempty"`             // Display position; ties (including the default 0) sort by name
+}
+
+// NamedCustomFieldSchema is a custom field schema together with its name.
+type NamedCustomFieldSchema struct {
+	Name   string
+	Schema CustomFieldSchema
+}
+
+// TagMaxLength returns the maximum length of each value of a free-set field.
+func (s CustomFieldSchema) TagMaxLength() int {
+	if s.MaxLength > 0 {
+		return s.MaxLength
+	}
+	return DefaultTagMaxLength
+}
+
+// FieldType returns the field's canonical type, resolving the "tags" synonym
+// to FieldTypeFreeSet. Type keeps the name as written in the config.
+func (s CustomFieldSchema) FieldType() string {
+	if s.Type == FieldTypeTags {
+		return FieldTypeFreeSet
+	}
+	return s.Type
+}
+
+// MarshalJSON writes the canonical type, so API clients only ever see
+// free-set.
+func (s CustomFieldSchema) MarshalJSON() ([]byte, error) {
+	type plain CustomFieldSchema
+	p := plain(s)
+	p.Type = s.FieldType()
+	return json.Marshal(p)
+}
+
+// DateFormat returns the storage format of a date field, falling back to
+// DateFormatDate when unset or unknown.
+func (s CustomFieldSchema) DateFormat() string {
+	if !slices.Contains(ValidDateFormats, s.Format) {
+		return DateFormatDate
+	}
+	return s.Format
+}
+
+// Storage formats for date fields (CustomFieldSchema.Format).
+const (
+	DateFormatDate     = "date"     // Stored as YYYY-MM-DD (the default)
+	DateFormatDateTime = "datetime" // Stored as an RFC 3339 timestamp
+)
+
+// ValidDateFormats lists all supported date field storage formats.
+var ValidDateFormats = []string{DateFormatDate, DateFormatDateTime}
+
+// Alias styles for AliasConfig.Style.
+const (
+	AliasStyleSlugify  = "slugify"  // Hyphenated title words, e.g. "fix-login-bug" (the default)
+	AliasStyleInitials = "initials" // First letter of each title word, e.g. "flb"
+	AliasStyleNumeric  = "numeric"  // "card-<N>", ignoring the title
+)
+
+// ValidAliasStyles lists all supported alias styles.
+var ValidAliasStyles = []string{AliasStyleSlugify, AliasStyleInitials, AliasStyleNumeric}
+
+// AliasConfig controls how aliases are generated for new cards. The zero
+// value is the default slugify strategy.
+type AliasConfig struct {
+	Style     string `toml:"style,omitempty" json:"style,omitempty"`           // One of ValidAliasStyles; empty = slugify
+	MaxLength int    `toml:"max_length,omitempty" json:"max_length,omitempty"` // Length budget for the title-derived part; 0 = default
+	Prefix    string `toml:"prefix,omitempty" json:"prefix,omitempty"`         // Prepended to every generated alias
+}
+
+// StaleConfig flags cards that haven't been updated in a while. The zero
+// value disables stale detection.
+type StaleConfig struct {
+	StaleDays     int      `toml:"stale_days,omitempty" json:"stale_days,omitempty"`         // Days without an update before a card is stale; 0 = disabled
+	ExemptColumns []string `toml:"exempt_columns,omitempty" json:"exempt_columns,omitempty"` // Columns whose cards are never stale (e.g. "done")
+}
+
+// IsStale reports whether the card was last updated more than StaleDays days
+// before nowMillis and sits in a column that isn't exempt.
+func (c StaleConfig) IsStale(card *Card, nowMillis int64) bool {
+	if c.StaleDays <= 0 || slices.Contains(c.ExemptColumns, card.Column) {
+		return false
+	}
+	return nowMillis-card.UpdatedAtMillis > int64(c.StaleDays)*util.MillisPerDay
 }
 
 // CardDisplayConfig controls how custom fields render on cards in the board view.
@@ -76,6 +268,7 @@ type CardDisplayConfig struct {
 	Tint          string   `toml:"tint,omitempty" json:"tint,omitempty"`                     // enum field → card background color
 	Badges        []string `toml:"badges,omitempty" json:"badges,omitempty"`                 // set/boolean fields shown as chips
 	Metadata      []string `toml:"metadata,omitempty" json:"metadata,omitempty"`             // fields shown as small text
+	Tags          []string `toml:"tags,omitempty" json:"tags,omitempty"`                     // free-set fields shown as plain #tags
 
 	// DefaultSort names a custom field the board view is sorted by on load (the
 	// web Sort control still overrides it). Empty means manual (position) order.
@@ -93,28 +286,139 @@ type LinkRule struct {
 }
 
 // PatternHook defines a hook that runs when cards are created with matching titles.
-// The command receives the card ID and board name as arguments.
+// The command receives the card ID and board name as arguments. A hook with a
+// Webhook URL and no Command POSTs the card as JSON to that URL instead.
 type PatternHook struct {
-	Name         string `toml:"name" json:"name"`                           // Human-readable name for the hook
-	PatternTitle string `toml:"pattern_title" json:"pattern_title"`         // Regex pattern to match card titles
-	Command      string `toml:"command" json:"command"`                     // Command to execute (~ expanded)
-	Timeout      int    `toml:"timeout,omitempty" json:"timeout,omitempty"` // Timeout in seconds (default: 30)
+	Name           string            `toml:"name" json:"name"`                                           // Human-readable name for the hook
+	PatternTitle   string            `toml:"pattern_title" json:"pattern_title"`                         // Regex pattern to match card titles
+	Command        string            `toml:"command,omitempty" json:"command,omitempty"`                 // Command to execute (~ expanded)
+	CommandArgs    []string          `toml:"command_args,omitempty" json:"command_args,omitempty"`       // Arguments replacing the default card ID and board name
+	Webhook        string            `toml:"webhook,omitempty" json:"webhook,omitempty"`                 // URL to POST to (used when Command is empty)
+	WebhookHeaders map[string]string `toml:"webhook_headers,omitempty" json:"webhook_headers,omitempty"` // Extra request headers, e.g. Authorization
+	Timeout        int               `toml:"timeout,omitempty" json:"timeout,omitempty"`                 // Timeout in seconds (default: 30)
 }
 
-// ValidateLinkRules validates that all link rules have valid regex patterns.
-// Returns a list of warning messages for invalid patterns (non-fatal).
+// CardTemplate is a blueprint for new cards. TitlePattern and
+// DefaultDescription are Go text/template strings expanded with the variables
+// given when the template is applied, e.g. "[{{.component}}] {{.summary}}".
+type CardTemplate struct {
+	Name                string            `toml:"name" json:"name"`
+	TitlePattern        string            `toml:"title_pattern" json:"title_pattern"`
+	DefaultColumn       string            `toml:"default_column,omitempty" json:"default_column,omitempty"`
+	DefaultCustomFields map[string]string `toml:"default_custom_fields,omitempty" json:"default_custom_fields,omitempty"`
+	DefaultDescription  string            `toml:"default_description,omitempty" json:"default_description,omitempty"`
+}
+
+// GetTemplate returns the card template with the given name, or nil.
+func (b *BoardConfig) GetTemplate(name string) *CardTemplate {
+	for i := range b.Templates {
+		if b.Templates[i].Name == name {
+			return &b.Templates[i]
+		}
+	}
+	return nil
+}
+
+// hookGroupRef matches a {N} capture group placeholder in a hook command or
+// its arguments.
+var hookGroupRef = regexp.MustCompile(`\{(\d+)\}`)
+
+// ExpandGroupRefs replaces each {N} in s with groups[N], where groups is a
+// regexp submatch slice ({0} is the full match). References past the end of
+// groups are left as-is.
+func ExpandGroupRefs(s string, groups []string) string {
+	return hookGroupRef.ReplaceAllStringFunc(s, func(ref string) string {
+		n, err := strconv.Atoi(ref[1 : len(ref)-1])
+		if err != nil || n >= len(groups) {
+			return ref
+		}
+		return groups[n]
+	})
+}
+
+// HasGroupRefs reports whether s contains a {N} capture group placeholder.
+func HasGroupRefs(s string) bool {
+	return hookGroupRef.MatchString(s)
+}
+
+// maxGroupRef returns the highest {N} referenced by the hook's command and
+// arguments, or -1 if there are none.
+func (h PatternHook) maxGroupRef() int {
+	highest := -1
+	for _, s := range append([]string{h.Command}, h.CommandArgs...) {
+		for _, m := range hookGroupRef.FindAllStringSubmatch(s, -1) {
+			if n, err := strconv.Atoi(m[1]); err == nil && n > highest {
+				highest = n
+			}
+		}
+	}
+	return highest
+}
+
+// linkGroupRef matches a {N} or {N!raw} capture group placeholder in a link
+// rule's URL template.
+var linkGroupRef = regexp.MustCompile(`\{(\d+)(!raw)?\}`)
+
+// Preview applies the rule to text and returns the URL its first match links
+// to, the way the web UI builds it: {N} is replaced with capture group N
+// (URL-encoded), {N!raw} with the group as-is, and {0} is the full match.
+// Reports false if the pattern is invalid or doesn't match.
+func (r LinkRule) Preview(text string) (string, bool) {
+	re, err := regexp.Compile(r.Pattern)
+	if err != nil {
+		return "", false
+	}
+	groups := re.FindStringSubmatch(text)
+	if groups == nil {
+		return "", false
+	}
+	return linkGroupRef.ReplaceAllStringFunc(r.URL, func(ref string) string {
+		m := linkGroupRef.FindStringSubmatch(ref)
+		n, err := strconv.Atoi(m[1])
+		if err != nil || n >= len(groups) {
+			return ref
+		}
+		if m[2] != "" {
+			return groups[n]
+		}
+		// Like encodeURIComponent: spaces become %20, not +.
+		return strings.ReplaceAll(url.QueryEscape(groups[n]), "+", "%20")
+	}), true
+}
+
+// ValidateURL checks that the rule's URL template only references capture
+// groups its pattern defines. The pattern must compile.
+func (r LinkRule) ValidateURL() error {
+	re, err := regexp.Compile(r.Pattern)
+	if err != nil {
+		return err
+	}
+	for _, m := range linkGroupRef.FindAllStringSubmatch(r.URL, -1) {
+		if n, err := strconv.Atoi(m[1]); err != nil || n > re.NumSubexp() {
+			return fmt.Errorf("url references {%s} but pattern has %d capture group(s)", m[1], re.NumSubexp())
+		}
+	}
+	return nil
+}
+
+// ValidateLinkRules validates that all link rules have valid regex patterns
+// and URL templates. Returns a list of warning messages for invalid rules (non-fatal).
 func ValidateLinkRules(rules []LinkRule) []string {
 	var warnings []string
 	for _, rule := range rules {
 		if _, err := regexp.Compile(rule.Pattern); err != nil {
 			warnings = append(warnings, fmt.Sprintf(
 				"link_rules: invalid regex in '%s': %s", rule.Name, err.Error()))
+		} else if err := rule.ValidateURL(); err != nil {
+			warnings = append(warnings, fmt.Sprintf(
+				"link_rules: rule '%s' %s", rule.Name, err.Error()))
 		}
 	}
 	return warnings
 }
 
-// ValidatePatternHooks validates that all pattern hooks have valid regex patterns.
+// ValidatePatternHooks validates that all pattern hooks have valid regex patterns
+// and only reference capture groups their pattern defines.
 // Returns a list of warning messages for invalid patterns (non-fatal).
 func ValidatePatternHooks(hooks []PatternHook) []string {
 	var warnings []string
@@ -126,13 +430,25 @@ func ValidatePatternHooks(hooks []PatternHook) []string {
 		if hook.PatternTitle == "" {
 			warnings = append(warnings, fmt.Sprintf(
 				"pattern_hooks: hook '%s' missing required 'pattern_title' field", hook.Name))
-		} else if _, err := regexp.Compile(hook.PatternTitle); err != nil {
+		} else if re, err := regexp.Compile(hook.PatternTitle); err != nil {
 			warnings = append(warnings, fmt.Sprintf(
 				"pattern_hooks: invalid regex in '%s': %s", hook.Name, err.Error()))
+		} else if ref := hook.maxGroupRef(); ref > re.NumSubexp() {
+			warnings = append(warnings, fmt.Sprintf(
+				"pattern_hooks: hook '%s' references {%d} but pattern_title has %d capture group(s)", hook.Name, ref, re.NumSubexp()))
 		}
-		if hook.Command == "" {
+		switch {
+		case hook.Command == "" && hook.Webhook == "":
+			warnings = append(warnings, fmt.Sprintf(
+				"pattern_hooks: hook '%s' needs a 'command' or 'webhook' field", hook.Name))
+		case hook.Command != "" && hook.Webhook != "":
 			warnings = append(warnings, fmt.Sprintf(
-				"pattern_hooks: hook '%s' missing required 'command' field", hook.Name))
+				"pattern_hooks: hook '%s' sets both 'command' and 'webhook'; the webhook is ignored", hook.Name))
+		case hook.Webhook != "":
+			if u, err := url.Parse(hook.Webhook); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				warnings = append(warnings, fmt.Sprintf(
+					"pattern_hooks: hook '%s' has invalid webhook URL %q (must be http or https)", hook.Name, hook.Webhook))
+			}
 		}
 	}
 	return warnings
@@ -212,6 +528,20 @@ func (b *BoardConfig) GetColumn(name string) *Column {
 	return nil
 }
 
+// IsAtCapacity reports whether the named column, currently holding cardCount
+// cards, has reached its limit. Unknown columns are never at capacity.
+func (b *BoardConfig) IsAtCapacity(columnName string, cardCount int) bool {
+	col := b.GetColumn(columnName)
+	return col != nil && col.IsAtLimit(cardCount)
+}
+
+// IsDoneColumn reports whether the named column is marked done, either by its
+// done flag or by being listed in done_columns.
+func (b *BoardConfig) IsDoneColumn(name string) bool {
+	col := b.GetColumn(name)
+	return col != nil && (col.Done || slices.Contains(b.DoneColumns, name))
+}
+
 // AddColumn adds a new column at the specified position.
 // If position is -1 or >= len(columns), appends to end.
 // Returns false if a column with the same name already exists.
@@ -243,9 +573,44 @@ func (b *BoardConfig) RemoveColumn(name string) bool {
 	}
 
 	b.Columns = append(b.Columns[:idx], b.Columns[idx+1:]...)
+	b.DoneColumns = removeName(b.DoneColumns, name)
+	b.Stale.ExemptColumns = removeName(b.Stale.ExemptColumns, name)
+
+	// Rules scoped to moves out of the removed column can never apply again.
+	for i := range b.Columns {
+		rules := b.Columns[i].TransitionRules[:0]
+		for _, rule := range b.Columns[i].TransitionRules {
+			if rule.FromColumn != name {
+				rules = append(rules, rule)
+			}
+		}
+		if len(rules) == 0 {
+			rules = nil
+		}
+		b.Columns[i].TransitionRules = rules
+	}
 	return true
 }
 
+// removeName drops every occurrence of name from a list of column or field
+// names, returning nil if nothing is left.
+func removeName(names []string, name string) []string {
+	names = slices.DeleteFunc(names, func(n string) bool { return n == name })
+	if len(names) == 0 {
+		return nil
+	}
+	return names
+}
+
+// renameName replaces oldName with newName in a list of column names.
+func renameName(names []string, oldName, newName string) {
+	for i, n := range names {
+		if n == oldName {
+			names[i] = newName
+		}
+	}
+}
+
 // RenameColumn renames a column.
 // Returns false if the old column doesn't exist or new name already exists.
 func (b *BoardConfig) RenameColumn(oldName, newName string) bool {
@@ -268,6 +633,18 @@ func (b *BoardConfig) RenameColumn(oldName, newName string) bool {
 		b.DefaultColumn = newName
 	}
 
+	renameName(b.DoneColumns, oldName, newName)
+	renameName(b.Stale.ExemptColumns, oldName, newName)
+
+	// Keep transition rules scoped to the old name pointing at the column.
+	for i := range b.Columns {
+		for j := range b.Columns[i].TransitionRules {
+			if b.Columns[i].TransitionRules[j].FromColumn == oldName {
+				b.Columns[i].TransitionRules[j].FromColumn = newName
+			}
+		}
+	}
+
 	return true
 }
 
@@ -293,6 +670,18 @@ func (b *BoardConfig) SetColumnLimit(name string, limit int) bool {
 	return true
 }
 
+// SetColumnSprint sets a column's sprint window.
+// Returns false if the column doesn't exist.
+func (b *BoardConfig) SetColumnSprint(name string, startMillis, endMillis int64) bool {
+	col := b.GetColumn(name)
+	if col == nil {
+		return false
+	}
+	col.SprintStartMillis = startMillis
+	col.SprintEndMillis = endMillis
+	return true
+}
+
 // SetColumnDescription updates a column's description.
 // Returns false if the column doesn't exist.
 func (b *BoardConfig) SetColumnDescription(name, description string) bool {
@@ -330,6 +719,53 @@ func (b *BoardConfig) MoveColumn(name string, newPosition int) bool {
 	return true
 }
 
+// RemoveCustomField removes a custom field and any card_display or
+// transition rule references to it. Returns false if the field doesn't exist.
+func (b *BoardConfig) RemoveCustomField(name string) bool {
+	if _, ok := b.CustomFields[name]; !ok {
+		return false
+	}
+	delete(b.CustomFields, name)
+
+	display := &b.CardDisplay
+	for _, slot := range []*string{&display.TypeIndicator, &display.Tint, &display.DefaultSort} {
+		if *slot == name {
+			*slot = ""
+		}
+	}
+	if display.DefaultSort == "" {
+		display.DefaultSortDesc = false
+	}
+	display.Badges = removeName(display.Badges, name)
+	display.Metadata = removeName(display.Metadata, name)
+	display.Tags = removeName(display.Tags, name)
+
+	for i := range b.Columns {
+		for j := range b.Columns[i].TransitionRules {
+			rule := &b.Columns[i].TransitionRules[j]
+			rule.RequiredFields = removeName(rule.RequiredFields, name)
+			rule.ForbidIfFields = removeName(rule.ForbidIfFields, name)
+		}
+	}
+	return true
+}
+
+// OrderedCustomFields returns the board's custom fields sorted by Order,
+// then by name.
+func (b *BoardConfig) OrderedCustomFields() []NamedCustomFieldSchema {
+	fields := make([]NamedCustomFieldSchema, 0, len(b.CustomFields))
+	for name, schema := range b.CustomFields {
+		fields = append(fields, NamedCustomFieldSchema{Name: name, Schema: schema})
+	}
+	slices.SortFunc(fields, func(a, b NamedCustomFieldSchema) int {
+		if a.Schema.Order != b.Schema.Order {
+			return a.Schema.Order - b.Schema.Order
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	return fields
+}
+
 // GetOptionColor returns the color for an enum option value, or empty string if not found.
 func (b *BoardConfig) GetOptionColor(fieldName, value string) string {
 	schema, exists := b.CustomFields[fieldName]
@@ -344,13 +780,156 @@ func (b *BoardConfig) GetOptionColor(fieldName, value string) string {
 	return ""
 }
 
+// ValidateTransitionRules validates that transition rules reference existing
+// columns and custom fields.
+// Returns a list of warning messages for invalid references (non-fatal).
+func (b *BoardConfig) ValidateTransitionRules() []string {
+	var warnings []string
+	for _, col := range b.Columns {
+		for _, rule := range col.TransitionRules {
+			if rule.FromColumn != "" && !b.HasColumn(rule.FromColumn) {
+				warnings = append(warnings, fmt.Sprintf(
+					"columns.%s.transition_rules: from_column references non-existent column: %s", col.Name, rule.FromColumn))
+			}
+			for _, fieldName := range append(append([]string(nil), rule.RequiredFields...), rule.ForbidIfFields...) {
+				if _, exists := b.CustomFields[fieldName]; !exists {
+					warnings = append(warnings, fmt.Sprintf(
+						"columns.%s.transition_rules references non-existent field: %s", col.Name, fieldName))
+				}
+			}
+		}
+	}
+	return warnings
+}
+
+// ValidateDoneColumns validates the columns' done and auto-archive settings.
+// Returns a list of warning messages for invalid settings (non-fatal).
+func (b *BoardConfig) ValidateDoneColumns() []string {
+	var warnings []string
+	for _, col := range b.Columns {
+		if col.AutoArchiveAfterDays < 0 {
+			warnings = append(warnings, fmt.Sprintf(
+				"columns.%s.auto_archive_after_days: must not be negative; auto-archive is off", col.Name))
+		} else if col.AutoArchiveAfterDays > 0 && !col.Done {
+			warnings = append(warnings, fmt.Sprintf(
+				"columns.%s.auto_archive_after_days: only applies to done columns; set done = true", col.Name))
+		}
+	}
+	for _, name := range b.DoneColumns {
+		if !b.HasColumn(name) {
+			warnings = append(warnings, fmt.Sprintf("done_columns references non-existent column: %s", name))
+		}
+	}
+	return warnings
+}
+
+// ValidateStaleConfig validates the stale card settings.
+// Returns a list of warning messages for invalid settings (non-fatal).
+func (b *BoardConfig) ValidateStaleConfig() []string {
+	var warnings []string
+	if b.Stale.StaleDays < 0 {
+		warnings = append(warnings, "stale.stale_days: must not be negative; stale detection is off")
+	}
+	for _, name := range b.Stale.ExemptColumns {
+		if !b.HasColumn(name) {
+			warnings = append(warnings, fmt.Sprintf("stale.exempt_columns references non-existent column: %s", name))
+		}
+	}
+	return warnings
+}
+
+// ValidateTemplates validates the card templates' names, patterns and
+// column references. Returns a list of warning messages (non-fatal).
+func (b *BoardConfig) ValidateTemplates() []string {
+	var warnings []string
+	seen := make(map[string]bool)
+	for _, tmpl := range b.Templates {
+		if tmpl.Name == "" {
+			warnings = append(warnings, "templates: template missing required 'name' field")
+			continue
+		}
+		if seen[tmpl.Name] {
+			warnings = append(warnings, fmt.Sprintf("templates: duplicate template name '%s'; only the first is used", tmpl.Name))
+		}
+		seen[tmpl.Name] = true
+		if tmpl.TitlePattern == "" {
+			warnings = append(warnings, fmt.Sprintf("templates: template '%s' missing required 'title_pattern' field", tmpl.Name))
+		} else if _, err := template.New("title").Parse(tmpl.TitlePattern); err != nil {
+			warnings = append(warnings, fmt.Sprintf("templates: invalid title_pattern in '%s': %s", tmpl.Name, err.Error()))
+		}
+		if _, err := template.New("description").Parse(tmpl.DefaultDescription); err != nil {
+			warnings = append(warnings, fmt.Sprintf("templates: invalid default_description in '%s': %s", tmpl.Name, err.Error()))
+		}
+		if tmpl.DefaultColumn != "" && !b.HasColumn(tmpl.DefaultColumn) {
+			warnings = append(warnings, fmt.Sprintf("templates: '%s' references non-existent column: %s", tmpl.Name, tmpl.DefaultColumn))
+		}
+	}
+	return warnings
+}
+
+// ValidateAliasConfig validates the alias generation settings.
+// Returns a list of warning messages for invalid settings (non-fatal).
+func (b *BoardConfig) ValidateAliasConfig() []string {
+	var warnings []string
+	if b.Alias.Style != "" && !slices.Contains(ValidAliasStyles, b.Alias.Style) {
+		warnings = append(warnings, fmt.Sprintf(
+			"alias.style: unknown style %q (must be one of %s); using %q",
+			b.Alias.Style, strings.Join(ValidAliasStyles, ", "), AliasStyleSlugify))
+	}
+	if b.Alias.MaxLength < 0 {
+		warnings = append(warnings, "alias.max_length: must not be negative; using the default")
+	}
+	if b.Alias.Prefix != "" && !aliasPrefixPattern.MatchString(b.Alias.Prefix) {
+		warnings = append(warnings, fmt.Sprintf(
+			"alias.prefix: %q should contain only lowercase letters, digits, and hyphens", b.Alias.Prefix))
+	}
+	return warnings
+}
+
+// aliasPrefixPattern matches prefixes that keep generated aliases slug-like.
+var aliasPrefixPattern = regexp.MustCompile(`^[a-z0-9-]+$`)
+
+// ValidateFieldPatterns validates that URL field patterns are valid regexes.
+// Returns a list of warning messages for invalid patterns (non-fatal).
+func (b *BoardConfig) ValidateFieldPatterns() []string {
+	var warnings []string
+	for name, schema := range b.CustomFields {
+		if schema.Pattern == "" {
+			continue
+		}
+		if _, err := regexp.Compile(schema.Pattern); err != nil {
+			warnings = append(warnings, fmt.Sprintf(
+				"custom_fields.%s: invalid regex in pattern: %s", name, err.Error()))
+		}
+	}
+	return warnings
+}
+
+// ValidateDateFields validates the format and range settings of date fields.
+// Returns a list of warning messages for invalid settings (non-fatal).
+func (b *BoardConfig) ValidateDateFields() []string {
+	var warnings []string
+	for name, schema := range b.CustomFields {
+		if schema.Format != "" && !slices.Contains(ValidDateFormats, schema.Format) {
+			warnings = append(warnings, fmt.Sprintf(
+				"custom_fields.%s.format: unknown format %q (must be one of %s); using %q",
+				name, schema.Format, strings.Join(ValidDateFormats, ", "), DateFormatDate))
+		}
+		if schema.FutureOnly && schema.PastOnly {
+			warnings = append(warnings, fmt.Sprintf(
+				"custom_fields.%s: future_only and past_only together only allow today", name))
+		}
+	}
+	return warnings
+}
+
 // ValidateCardDisplay validates that CardDisplayConfig references valid custom fields.
 // Returns a list of warning messages for invalid references (non-fatal).
 func (b *BoardConfig) ValidateCardDisplay() []string {
 	var warnings []string
 
 	cd := b.CardDisplay
-	if cd.TypeIndicator == "" && cd.Tint == "" && len(cd.Badges) == 0 && len(cd.Metadata) == 0 && cd.DefaultSort == "" && !cd.DefaultSortDesc {
+	if cd.TypeIndicator == "" && cd.Tint == "" && len(cd.Badges) == 0 && len(cd.Metadata) == 0 && len(cd.Tags) == 0 && cd.DefaultSort == "" && !cd.DefaultSortDesc {
 		return nil // Empty config, nothing to validate
 	}
 
@@ -359,7 +938,7 @@ func (b *BoardConfig) ValidateCardDisplay() []string {
 		schema, exists := b.CustomFields[cd.TypeIndicator]
 		if !exists {
 			warnings = append(warnings, "card_display.type_indicator references non-existent field: "+cd.TypeIndicator)
-		} else if schema.Type != FieldTypeEnum {
+		} else if schema.FieldType() != FieldTypeEnum {
 			warnings = append(warnings, "card_display.type_indicator should reference an enum field, but '"+cd.TypeIndicator+"' is type '"+schema.Type+"'")
 		}
 	}
@@ -369,7 +948,7 @@ func (b *BoardConfig) ValidateCardDisplay() []string {
 		schema, exists := b.CustomFields[cd.Tint]
 		if !exists {
 			warnings = append(warnings, "card_display.tint references non-existent field: "+cd.Tint)
-		} else if schema.Type != FieldTypeEnum {
+		} else if schema.FieldType() != FieldTypeEnum {
 			warnings = append(warnings, "card_display.tint should reference an enum field, but '"+cd.Tint+"' is type '"+schema.Type+"'")
 		}
 	}
@@ -379,11 +958,21 @@ func (b *BoardConfig) ValidateCardDisplay() []string {
 		schema, exists := b.CustomFields[fieldName]
 		if !exists {
 			warnings = append(warnings, "card_display.badges references non-existent field: "+fieldName)
-		} else if schema.Type != FieldTypeEnumSet && schema.Type != FieldTypeFreeSet && schema.Type != FieldTypeBoolean {
+		} else if t := schema.FieldType(); t != FieldTypeEnumSet && t != FieldTypeFreeSet && t != FieldTypeBoolean {
 			warnings = append(warnings, "card_display.badges should reference set or boolean fields (enum-set, free-set, or boolean), but '"+fieldName+"' is type '"+schema.Type+"'")
 		}
 	}
 
+	// Validate tags reference free-set fields
+	for _, fieldName := range cd.Tags {
+		schema, exists := b.CustomFields[fieldName]
+		if !exists {
+			warnings = append(warnings, "card_display.tags references non-existent field: "+fieldName)
+		} else if schema.FieldType() != FieldTypeFreeSet {
+			warnings = append(warnings, "card_display.tags should reference free-set fields, but '"+fieldName+"' is type '"+schema.Type+"'")
+		}
+	}
+
 	// Validate metadata references existing fields
 	for _, fieldName := range cd.Metadata {
 		if _, exists := b.CustomFields[fieldName]; !exists {
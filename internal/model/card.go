@@ -3,6 +3,7 @@ package model
 import (
 	"bytes"
 	"encoding/json"
+	"slices"
 	"strings"
 )
 
@@ -21,6 +22,18 @@ type Card struct {
 	UpdatedAtMillis int64     `json:"updated_at_millis"`
 	Comments        []Comment `json:"comments,omitempty"`
 
+	// DueAtMillis is an optional deadline in Unix millis; zero means unset.
+	DueAtMillis int64 `json:"due_at_millis,omitempty"`
+
+	// Blocks and BlockedBy record dependencies on other cards of the same
+	// board, by card ID. CardService keeps them symmetric: if A is blocked by
+	// B, then B blocks A. BlockedBy is the side users edit.
+	Blocks    []string `json:"blocks,omitempty"`
+	BlockedBy []string `json:"blocked_by,omitempty"`
+
+	// Checklist is an ordered list of subtasks, in the order they were added.
+	Checklist []ChecklistItem `json:"checklist,omitempty"`
+
 	// History is an append-only, chronological log of tracked field changes.
 	// Today only column transitions are recorded; the structure is general so
 	// other fields can be tracked later without a schema migration. See
@@ -36,11 +49,54 @@ type Card struct {
 	// Cards are sorted by this field lexicographically.
 	Position string `json:"position"`
 
+	// Archived marks a soft-deleted card. An archived card belongs to no column
+	// (Column is empty) and is hidden from listings unless explicitly requested.
+	// LastColumn remembers where it lived so unarchiving can put it back.
+	Archived         bool   `json:"archived,omitempty"`
+	ArchivedAtMillis int64  `json:"archived_at_millis,omitempty"`
+	LastColumn       string `json:"last_column,omitempty"`
+
+	// DoneAtMillis is when the card entered a done column (see Column.Done);
+	// zero while it's in any other column.
+	DoneAtMillis int64 `json:"done_at_millis,omitempty"`
+
 	// CustomFields holds board-defined custom fields (including labels, type, etc.).
 	// These are serialized at the top level of the JSON, not nested.
 	CustomFields map[string]any `json:"-"`
 }
 
+// LabelsField is the custom field that holds a card's labels. Boards define it
+// as a tags (free-set) field.
+const LabelsField = "labels"
+
+// GetLabels returns the card's labels, or nil if it has none.
+func (c *Card) GetLabels() []string {
+	return c.GetSetValues(LabelsField)
+}
+
+// GetSetValues returns the values of a set field (enum-set or free-set), or
+// nil if the card doesn't have it set.
+func (c *Card) GetSetValues(fieldName string) []string {
+	return slices.Clone(asStringSlice(c.CustomFields[fieldName]))
+}
+
+// SetLabels replaces the card's labels. An empty list removes the field.
+func (c *Card) SetLabels(labels []string) {
+	if len(labels) == 0 {
+		delete(c.CustomFields, LabelsField)
+		return
+	}
+	if c.CustomFields == nil {
+		c.CustomFields = make(map[string]any)
+	}
+	c.CustomFields[LabelsField] = slices.Clone(labels)
+}
+
+// IsOverdue reports whether the card has a due date earlier than nowMillis.
+func (c *Card) IsOverdue(nowMillis int64) bool {
+	return c.DueAtMillis != 0 && c.DueAtMillis < nowMillis
+}
+
 // HistoryEntry records a single tracked field change on a card.
 //
 // Field is the changed field name ("column" for now); Value is the NEW value
@@ -64,6 +120,28 @@ type Comment struct {
 	Author          string `json:"author"`
 	CreatedAtMillis int64  `json:"created_at_millis"`
 	UpdatedAtMillis int64  `json:"updated_at_millis,omitempty"`
+
+	// Mentions lists the users @mentioned in Body, in order of first
+	// appearance. Derived from Body whenever the comment is written.
+	Mentions []string `json:"mentions,omitempty"`
+}
+
+// ChecklistItem is one subtask in a card's checklist.
+type ChecklistItem struct {
+	ID              string `json:"id"`
+	Text            string `json:"text"`
+	Done            bool   `json:"done"`
+	CreatedAtMillis int64  `json:"created_at_millis"`
+}
+
+// HasIncompleteChecklist reports whether any checklist item is not done.
+func (c *Card) HasIncompleteChecklist() bool {
+	for _, item := range c.Checklist {
+		if !item.Done {
+			return true
+		}
+	}
+	return false
 }
 
 // MarshalJSON implements custom JSON marshaling to merge custom fields
@@ -198,8 +276,11 @@ var reservedCardFieldNames = map[string]bool{
 	"title": true, "description": true,
 	"parent": true, "creator": true,
 	"created_at_millis": true, "updated_at_millis": true,
-	"comments": true, "history": true,
+	"comments": true, "history": true, "due_at_millis": true,
+	"blocks": true, "blocked_by": true, "checklist": true,
 	"column": true, "position": true,
+	"archived": true, "archived_at_millis": true, "last_column": true,
+	"done_at_millis": true,
 	// Computed/API-only fields that may appear in JSON from external sources
 	// (e.g. the restore endpoint) but aren't custom fields.
 	"missing_wanted_fields": true,
@@ -3,6 +3,8 @@ package model
 import (
 	"encoding/json"
 	"reflect"
+	"slices"
+	"sort"
 	"strings"
 	"testing"
 )
@@ -132,6 +134,29 @@ func TestCardJSON_EmptyCustomFields(t *testing.T) {
 	}
 }
 
+func TestCard_Labels(t *testing.T) {
+	var card Card
+	if labels := card.GetLabels(); labels != nil {
+		t.Errorf("Expected no labels, got %v", labels)
+	}
+
+	card.SetLabels([]string{"ui", "urgent"})
+	if labels := card.GetLabels(); !reflect.DeepEqual(labels, []string{"ui", "urgent"}) {
+		t.Errorf("Expected [ui urgent], got %v", labels)
+	}
+
+	// Set values loaded from JSON arrive as []any.
+	card.CustomFields[LabelsField] = []any{"backend"}
+	if labels := card.GetLabels(); !reflect.DeepEqual(labels, []string{"backend"}) {
+		t.Errorf("Expected [backend], got %v", labels)
+	}
+
+	card.SetLabels(nil)
+	if _, ok := card.CustomFields[LabelsField]; ok {
+		t.Errorf("Expected labels field removed, got %v", card.CustomFields)
+	}
+}
+
 func TestBoardConfig_HasColumn(t *testing.T) {
 	cfg := &BoardConfig{
 		Columns: []Column{
@@ -311,6 +336,43 @@ func TestBoardConfig_ValidateCardDisplay(t *testing.T) {
 			},
 			wantWarnings: 0,
 		},
+		{
+			name: "badges accept tags fields",
+			cfg: &BoardConfig{
+				CustomFields: map[string]CustomFieldSchema{
+					"components": {Type: "tags"},
+				},
+				CardDisplay: CardDisplayConfig{
+					Badges: []string{"components"},
+				},
+			},
+			wantWarnings: 0,
+		},
+		{
+			name: "valid tags config",
+			cfg: &BoardConfig{
+				CustomFields: map[string]CustomFieldSchema{
+					"topics":     {Type: "free-set"},
+					"components": {Type: "tags"},
+				},
+				CardDisplay: CardDisplayConfig{
+					Tags: []string{"topics", "components"},
+				},
+			},
+			wantWarnings: 0,
+		},
+		{
+			name: "tags references non-free-set field",
+			cfg: &BoardConfig{
+				CustomFields: map[string]CustomFieldSchema{
+					"labels": {Type: "enum-set"},
+				},
+				CardDisplay: CardDisplayConfig{
+					Tags: []string{"labels", "missing"},
+				},
+			},
+			wantWarnings: 2,
+		},
 		{
 			name: "metadata references non-existent field",
 			cfg: &BoardConfig{
@@ -380,6 +442,209 @@ func TestBoardConfig_ValidateCardDisplay(t *testing.T) {
 	}
 }
 
+func TestCustomFieldSchema_FieldType(t *testing.T) {
+	if got := (CustomFieldSchema{Type: "tags"}).FieldType(); got != FieldTypeFreeSet {
+		t.Errorf("FieldType() = %q, want free-set for the tags synonym", got)
+	}
+	if got := (CustomFieldSchema{Type: FieldTypeEnumSet}).FieldType(); got != FieldTypeEnumSet {
+		t.Errorf("FieldType() = %q, want enum-set untouched", got)
+	}
+
+	data, err := json.Marshal(CustomFieldSchema{Type: "tags", MaxLength: 20})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if string(data) != `{"type":"free-set","max_length":20}` {
+		t.Errorf("JSON = %s, want the canonical type", data)
+	}
+}
+
+func TestCustomFieldSchema_TagMaxLength(t *testing.T) {
+	if got := (CustomFieldSchema{}).TagMaxLength(); got != DefaultTagMaxLength {
+		t.Errorf("TagMaxLength() = %d, want default %d", got, DefaultTagMaxLength)
+	}
+	if got := (CustomFieldSchema{MaxLength: 8}).TagMaxLength(); got != 8 {
+		t.Errorf("TagMaxLength() = %d, want 8", got)
+	}
+}
+
+func TestBoardConfig_ValidateFieldPatterns(t *testing.T) {
+	cfg := &BoardConfig{
+		CustomFields: map[string]CustomFieldSchema{
+			"link": {Type: "url"},
+			"pr":   {Type: "url", Pattern: `^https://github\.com/`},
+			"bad":  {Type: "url", Pattern: `(unclosed`},
+		},
+	}
+	warnings := cfg.ValidateFieldPatterns()
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "custom_fields.bad") {
+		t.Errorf("ValidateFieldPatterns() = %v, want one warning for 'bad'", warnings)
+	}
+}
+
+func TestBoardConfig_ValidateDateFields(t *testing.T) {
+	cfg := &BoardConfig{
+		CustomFields: map[string]CustomFieldSchema{
+			"due":     {Type: "date", FutureOnly: true},
+			"stamped": {Type: "date", Format: DateFormatDateTime},
+			"odd":     {Type: "date", Format: "epoch"},
+			"both":    {Type: "date", FutureOnly: true, PastOnly: true},
+		},
+	}
+	warnings := cfg.ValidateDateFields()
+	sort.Strings(warnings)
+	if len(warnings) != 2 || !strings.Contains(warnings[0], "custom_fields.both") || !strings.Contains(warnings[1], "custom_fields.odd.format") {
+		t.Errorf("ValidateDateFields() = %v, want warnings for 'both' and 'odd'", warnings)
+	}
+	if got := cfg.CustomFields["odd"].DateFormat(); got != DateFormatDate {
+		t.Errorf("DateFormat() = %q, want fallback %q", got, DateFormatDate)
+	}
+}
+
+func TestBoardConfig_DoneColumns(t *testing.T) {
+	cfg := &BoardConfig{
+		Columns:     []Column{{Name: "doing"}, {Name: "done", Done: true}, {Name: "closed"}},
+		DoneColumns: []string{"closed", "gone"},
+	}
+	for name, want := range map[string]bool{"doing": false, "done": true, "closed": true, "gone": false} {
+		if got := cfg.IsDoneColumn(name); got != want {
+			t.Errorf("IsDoneColumn(%q) = %v, want %v", name, got, want)
+		}
+	}
+	if warnings := cfg.ValidateDoneColumns(); len(warnings) != 1 || !strings.Contains(warnings[0], "gone") {
+		t.Errorf("ValidateDoneColumns() = %v, want a warning for 'gone'", warnings)
+	}
+
+	cfg.RenameColumn("closed", "shipped")
+	if !cfg.IsDoneColumn("shipped") {
+		t.Errorf("Expected renamed column to stay done, got DoneColumns %v", cfg.DoneColumns)
+	}
+	cfg.RemoveColumn("shipped")
+	if !reflect.DeepEqual(cfg.DoneColumns, []string{"gone"}) {
+		t.Errorf("Expected removed column dropped from DoneColumns, got %v", cfg.DoneColumns)
+	}
+}
+
+func TestStaleConfig_IsStale(t *testing.T) {
+	const day = int64(24 * 60 * 60 * 1000)
+	now := int64(1_700_000_000_000)
+	cfg := StaleConfig{StaleDays: 30, ExemptColumns: []string{"done"}}
+
+	tests := []struct {
+		name string
+		card Card
+		want bool
+	}{
+		{"updated 31 days ago", Card{Column: "doing", UpdatedAtMillis: now - 31*day}, true},
+		{"updated 30 days ago", Card{Column: "doing", UpdatedAtMillis: now - 30*day}, false},
+		{"exempt column", Card{Column: "done", UpdatedAtMillis: now - 90*day}, false},
+	}
+	for _, tt := range tests {
+		if got := cfg.IsStale(&tt.card, now); got != tt.want {
+			t.Errorf("%s: IsStale() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	old := Card{Column: "doing", UpdatedAtMillis: now - 90*day}
+	if (StaleConfig{}).IsStale(&old, now) {
+		t.Error("Expected nothing to be stale with stale_days unset")
+	}
+}
+
+func TestBoardConfig_StaleExemptColumns(t *testing.T) {
+	cfg := &BoardConfig{
+		Columns: []Column{{Name: "doing"}, {Name: "done"}},
+		Stale:   StaleConfig{StaleDays: -1, ExemptColumns: []string{"done", "gone"}},
+	}
+	warnings := cfg.ValidateStaleConfig()
+	if len(warnings) != 2 || !strings.HasPrefix(warnings[0], "stale.stale_days") || !strings.Contains(warnings[1], "gone") {
+		t.Errorf("ValidateStaleConfig() = %v, want stale_days and 'gone' warnings", warnings)
+	}
+
+	cfg.RenameColumn("done", "shipped")
+	if !reflect.DeepEqual(cfg.Stale.ExemptColumns, []string{"shipped", "gone"}) {
+		t.Errorf("Expected renamed exempt column, got %v", cfg.Stale.ExemptColumns)
+	}
+	cfg.RemoveColumn("shipped")
+	if !reflect.DeepEqual(cfg.Stale.ExemptColumns, []string{"gone"}) {
+		t.Errorf("Expected removed column dropped from exempt_columns, got %v", cfg.Stale.ExemptColumns)
+	}
+}
+
+func TestValidatePatternHooks_GroupRefs(t *testing.T) {
+	tests := []struct {
+		name string
+		hook PatternHook
+		want string
+	}{
+		{"group in range", PatternHook{Name: "h", PatternTitle: `JIRA-(\d+)`, Command: "sync", CommandArgs: []string{"{1}"}}, ""},
+		{"full match only", PatternHook{Name: "h", PatternTitle: `^URGENT`, Command: "notify {0}"}, ""},
+		{"missing group", PatternHook{Name: "h", PatternTitle: `^URGENT`, Command: "notify", CommandArgs: []string{"{0}", "{1}"}},
+			"pattern_hooks: hook 'h' references {1} but pattern_title has 0 capture group(s)"},
+		{"missing group in command", PatternHook{Name: "h", PatternTitle: `(a)(b)`, Command: "run-{3}"},
+			"pattern_hooks: hook 'h' references {3} but pattern_title has 2 capture group(s)"},
+	}
+	for _, tt := range tests {
+		warnings := ValidatePatternHooks([]PatternHook{tt.hook})
+		switch {
+		case tt.want == "" && len(warnings) != 0:
+			t.Errorf("%s: expected no warnings, got %v", tt.name, warnings)
+		case tt.want != "" && (len(warnings) != 1 || warnings[0] != tt.want):
+			t.Errorf("%s: warnings = %v, want [%s]", tt.name, warnings, tt.want)
+		}
+	}
+}
+
+func TestExpandGroupRefs(t *testing.T) {
+	groups := []string{"JIRA-42", "JIRA", "42"}
+	if got := ExpandGroupRefs("{1}/{2} ({0}) {3}", groups); got != "JIRA/42 (JIRA-42) {3}" {
+		t.Errorf("ExpandGroupRefs() = %q", got)
+	}
+	if got := ExpandGroupRefs("{0}", nil); got != "{0}" {
+		t.Errorf("ExpandGroupRefs() with no match = %q, want {0} untouched", got)
+	}
+	if !HasGroupRefs("~/hooks/{1}.sh") || HasGroupRefs("~/hooks/sync.sh") {
+		t.Error("HasGroupRefs() misreported a placeholder")
+	}
+}
+
+func TestBoardConfig_ValidateAliasConfig(t *testing.T) {
+	valid := &BoardConfig{Alias: AliasConfig{Style: AliasStyleNumeric, MaxLength: 10, Prefix: "be-"}}
+	if warnings := valid.ValidateAliasConfig(); len(warnings) != 0 {
+		t.Errorf("ValidateAliasConfig() = %v, want no warnings", warnings)
+	}
+
+	invalid := &BoardConfig{Alias: AliasConfig{Style: "emoji", MaxLength: -1, Prefix: "BE_"}}
+	warnings := invalid.ValidateAliasConfig()
+	if len(warnings) != 3 {
+		t.Fatalf("ValidateAliasConfig() = %v, want 3 warnings", warnings)
+	}
+	for i, key := range []string{"alias.style", "alias.max_length", "alias.prefix"} {
+		if !strings.HasPrefix(warnings[i], key) {
+			t.Errorf("warning %d = %q, want prefix %q", i, warnings[i], key)
+		}
+	}
+}
+
+func TestBoardConfig_OrderedCustomFields(t *testing.T) {
+	cfg := &BoardConfig{CustomFields: map[string]CustomFieldSchema{
+		"zeta":     {Type: FieldTypeString},
+		"alpha":    {Type: FieldTypeString},
+		"priority": {Type: FieldTypeEnum, Order: 2},
+		"type":     {Type: FieldTypeEnum, Order: 1},
+		"estimate": {Type: FieldTypeInteger, Order: 2},
+	}}
+
+	var names []string
+	for _, f := range cfg.OrderedCustomFields() {
+		names = append(names, f.Name)
+	}
+	want := []string{"alpha", "zeta", "type", "estimate", "priority"}
+	if !slices.Equal(names, want) {
+		t.Errorf("OrderedCustomFields() = %v, want %v", names, want)
+	}
+}
+
 func TestGlobalConfig_GetRepoConfig(t *testing.T) {
 	cfg := &GlobalConfig{
 		Repos: map[string]RepoConfig{
@@ -652,3 +917,55 @@ func TestCurrentColumnSinceMillis(t *testing.T) {
 		t.Errorf("got %d, want 500", got)
 	}
 }
+
+func TestLinkRule_Preview(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    LinkRule
+		text    string
+		want    string
+		matched bool
+	}{
+		{"full match", LinkRule{Pattern: `JIRA-\d+`, URL: "https://jira.example.com/browse/{0}"}, "JIRA-123", "https://jira.example.com/browse/JIRA-123", true},
+		{"first match in text", LinkRule{Pattern: `#(\d+)`, URL: "https://github.com/o/r/issues/{1}"}, "see #12 and #34", "https://github.com/o/r/issues/12", true},
+		{"encoded group", LinkRule{Pattern: `doc:(\S+)`, URL: "https://docs.example.com/?q={1}"}, "doc:a/b", "https://docs.example.com/?q=a%2Fb", true},
+		{"encoded query", LinkRule{Pattern: `q:(.+)`, URL: "https://search.example.com/?q={1}"}, "q:a b&c", "https://search.example.com/?q=a%20b%26c", true},
+		{"raw group", LinkRule{Pattern: `doc:(\S+)`, URL: "https://docs.example.com/{1!raw}"}, "doc:a/b", "https://docs.example.com/a/b", true},
+		{"missing group left as-is", LinkRule{Pattern: `JIRA-\d+`, URL: "https://x/{1}"}, "JIRA-1", "https://x/{1}", true},
+		{"no match", LinkRule{Pattern: `JIRA-\d+`, URL: "https://x/{0}"}, "Fix login bug", "", false},
+		{"invalid pattern", LinkRule{Pattern: `(`, URL: "https://x/{0}"}, "(", "", false},
+	}
+	for _, tt := range tests {
+		got, matched := tt.rule.Preview(tt.text)
+		if got != tt.want || matched != tt.matched {
+			t.Errorf("%s: Preview(%q) = %q, %v; want %q, %v", tt.name, tt.text, got, matched, tt.want, tt.matched)
+		}
+	}
+}
+
+func TestValidateLinkRules_URL(t *testing.T) {
+	tests := []struct {
+		name string
+		rule LinkRule
+		want string
+	}{
+		{"full match only", LinkRule{Name: "r", Pattern: `JIRA-\d+`, URL: "https://x/{0}"}, ""},
+		{"groups in range", LinkRule{Name: "r", Pattern: `(\w+)/(\w+)#(\d+)`, URL: "https://github.com/{1}/{2}/issues/{3!raw}"}, ""},
+		{"no placeholders", LinkRule{Name: "r", Pattern: `x`, URL: "https://x"}, ""},
+		{"missing group", LinkRule{Name: "r", Pattern: `#(\d+)`, URL: "https://x/{1}/{2}"},
+			"link_rules: rule 'r' url references {2} but pattern has 1 capture group(s)"},
+		{"missing raw group", LinkRule{Name: "r", Pattern: `x`, URL: "https://x/{1!raw}"},
+			"link_rules: rule 'r' url references {1} but pattern has 0 capture group(s)"},
+		{"invalid regex", LinkRule{Name: "r", Pattern: `(`, URL: "https://x/{9}"},
+			"link_rules: invalid regex in 'r': error parsing regexp: missing closing ): `(`"},
+	}
+	for _, tt := range tests {
+		warnings := ValidateLinkRules([]LinkRule{tt.rule})
+		switch {
+		case tt.want == "" && len(warnings) != 0:
+			t.Errorf("%s: expected no warnings, got %v", tt.name, warnings)
+		case tt.want != "" && (len(warnings) != 1 || warnings[0] != tt.want):
+			t.Errorf("%s: warnings = %v, want [%s]", tt.name, warnings, tt.want)
+		}
+	}
+}
@@ -9,6 +9,10 @@ type GlobalConfig struct {
 	Projects    map[string]string     `toml:"projects,omitempty"`     // name -> path
 	Repos       map[string]RepoConfig `toml:"repos,omitempty"`        // path -> config
 	GlobalBoard *GlobalBoardRef       `toml:"global_board,omitempty"` // board reachable from anywhere via -g
+
+	// Defaults for boards created with `kan board create`.
+	DefaultColumns      []Column                     `toml:"default_columns,omitempty"`       // replace the built-in columns
+	DefaultCustomFields map[string]CustomFieldSchema `toml:"default_custom_fields,omitempty"` // added to every new board
 }
 
 // RepoConfig holds per-repository settings.
@@ -62,6 +66,14 @@ func (g *GlobalConfig) ClearGlobalBoard() {
 	g.GlobalBoard = nil
 }
 
+// SetDefaultCustomField adds or replaces a custom field applied to new boards.
+func (g *GlobalConfig) SetDefaultCustomField(name string, schema CustomFieldSchema) {
+	if g.DefaultCustomFields == nil {
+		g.DefaultCustomFields = make(map[string]CustomFieldSchema)
+	}
+	g.DefaultCustomFields[name] = schema
+}
+
 // RemoveRepoConfig removes a repo config and any project entries pointing to that path.
 // Used to clean up stale entries when re-initializing a project.
 func (g *GlobalConfig) RemoveRepoConfig(path string) {
@@ -0,0 +1,14 @@
+package model
+
+// HookHistoryEntry records one pattern hook execution for the project's hook
+// history. Stdout is truncated (see store.MaxHookHistoryStdout).
+type HookHistoryEntry struct {
+	Timestamp  int64  `json:"timestamp"`
+	BoardName  string `json:"board"`
+	HookName   string `json:"hook_name"`
+	CardID     string `json:"card_id"`
+	Success    bool   `json:"success"`
+	Stdout     string `json:"stdout,omitempty"`
+	Error      string `json:"error,omitempty"`
+	DurationMs int64  `json:"duration_ms"`
+}
@@ -1,6 +1,8 @@
 package model
 
 import (
+	"fmt"
+	"html"
 	"strings"
 )
 
@@ -29,6 +31,40 @@ type FaviconConfig struct {
 	Emoji      string `toml:"emoji" json:"emoji"`           // Unicode emoji (if icon_type="emoji")
 }
 
+// ToSVG renders the favicon as a 32x32 SVG: the letter in white, or the
+// emoji, on a rounded square of the background color. The output depends only
+// on the config, so it can be cached or inlined as a data URI.
+func (f FaviconConfig) ToSVG() string {
+	bg := f.Background
+	if bg == "" {
+		bg = FaviconColors[0]
+	}
+
+	var content string
+	if f.IconType == IconTypeEmoji && f.Emoji != "" {
+		// Emoji variant - larger font, centered
+		content = fmt.Sprintf(
+			`<text x="50%%" y="50%%" dominant-baseline="central" text-anchor="middle" font-size="20">%s</text>`,
+			html.EscapeString(f.Emoji),
+		)
+	} else {
+		// Letter variant (default)
+		letter := f.Letter
+		if letter == "" {
+			letter = "K"
+		}
+		content = fmt.Sprintf(
+			`<text x="50%%" y="50%%" dominant-baseline="central" text-anchor="middle" fill="white" font-family="system-ui, -apple-system, sans-serif" font-weight="600" font-size="20">%s</text>`,
+			html.EscapeString(letter),
+		)
+	}
+
+	return fmt.Sprintf(
+		`<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 32 32"><rect width="32" height="32" rx="6" fill="%s"/>%s</svg>`,
+		html.EscapeString(bg), content,
+	)
+}
+
 // FaviconColors is a palette of vibrant, distinct colors for favicon backgrounds.
 // Designed to be easily distinguishable in browser tabs.
 var FaviconColors = []string{
@@ -0,0 +1,67 @@
+package model
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+)
+
+// assertValidXML fails the test if svg isn't well-formed XML.
+func assertValidXML(t *testing.T, svg string) {
+	t.Helper()
+	dec := xml.NewDecoder(strings.NewReader(svg))
+	for {
+		if _, err := dec.Token(); err == io.EOF {
+			return
+		} else if err != nil {
+			t.Fatalf("SVG is not valid XML: %v\n%s", err, svg)
+		}
+	}
+}
+
+func TestFaviconConfig_ToSVG_Letter(t *testing.T) {
+	cfg := FaviconConfig{Background: "#10b981", IconType: IconTypeLetter, Letter: "M"}
+
+	svg := cfg.ToSVG()
+	assertValidXML(t, svg)
+	if !strings.Contains(svg, ">M</text>") {
+		t.Errorf("Expected the letter in the SVG, got %s", svg)
+	}
+	if !strings.Contains(svg, `fill="#10b981"`) {
+		t.Errorf("Expected the background color in the SVG, got %s", svg)
+	}
+	if !strings.Contains(svg, `fill="white"`) {
+		t.Errorf("Expected white text, got %s", svg)
+	}
+	if again := cfg.ToSVG(); again != svg {
+		t.Errorf("Expected the same SVG on each call, got\n%s\nthen\n%s", svg, again)
+	}
+}
+
+func TestFaviconConfig_ToSVG_Emoji(t *testing.T) {
+	svg := FaviconConfig{Background: "#f59e0b", IconType: IconTypeEmoji, Emoji: "🚀"}.ToSVG()
+
+	assertValidXML(t, svg)
+	if !strings.Contains(svg, "🚀") {
+		t.Errorf("Expected the emoji in the SVG, got %s", svg)
+	}
+}
+
+func TestFaviconConfig_ToSVG_Escapes(t *testing.T) {
+	svg := FaviconConfig{Background: `"/><script/>`, IconType: IconTypeLetter, Letter: "<"}.ToSVG()
+
+	assertValidXML(t, svg)
+	if strings.Contains(svg, "<script") {
+		t.Errorf("Expected the background to be escaped, got %s", svg)
+	}
+}
+
+func TestFaviconConfig_ToSVG_Defaults(t *testing.T) {
+	svg := FaviconConfig{}.ToSVG()
+
+	assertValidXML(t, svg)
+	if !strings.Contains(svg, ">K</text>") || !strings.Contains(svg, `fill="`+FaviconColors[0]+`"`) {
+		t.Errorf("Expected the default letter and color, got %s", svg)
+	}
+}
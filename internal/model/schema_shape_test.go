@@ -32,29 +32,55 @@ import (
 // Card.CustomFields (tagged json:"-"), which is flattened into the card JSON by
 // hand, so changes to that custom (un)marshaling won't trip this guard.
 var expectedPersistedFields = map[string][]string{
-	"board/12": {
+	"board/30": {
+		"alias",
+		"alias.max_length",
+		"alias.prefix",
+		"alias.style",
+		"archived_at_millis",
 		"card_display",
 		"card_display.badges",
 		"card_display.default_sort",
 		"card_display.default_sort_desc",
 		"card_display.metadata",
+		"card_display.tags",
 		"card_display.tint",
 		"card_display.type_indicator",
+		"collaborators",
 		"columns",
+		"columns.auto_archive_after_days",
 		"columns.color",
 		"columns.description",
+		"columns.done",
 		"columns.limit",
 		"columns.name",
+		"columns.sprint_end_millis",
+		"columns.sprint_start_millis",
+		"columns.transition_rules",
+		"columns.transition_rules.forbid_if_fields",
+		"columns.transition_rules.from_column",
+		"columns.transition_rules.required_fields",
 		"custom_fields",
 		"custom_fields.description",
+		"custom_fields.format",
+		"custom_fields.future_only",
+		"custom_fields.max",
+		"custom_fields.max_length",
+		"custom_fields.min",
 		"custom_fields.options",
 		"custom_fields.options.color",
 		"custom_fields.options.description",
+		"custom_fields.options.label",
 		"custom_fields.options.value",
+		"custom_fields.order",
+		"custom_fields.past_only",
+		"custom_fields.pattern",
 		"custom_fields.type",
 		"custom_fields.wanted",
 		"default_column",
+		"done_columns",
 		"id",
+		"include",
 		"kan_schema",
 		"link_rules",
 		"link_rules.name",
@@ -63,35 +89,91 @@ var expectedPersistedFields = map[string][]string{
 		"name",
 		"pattern_hooks",
 		"pattern_hooks.command",
+		"pattern_hooks.command_args",
 		"pattern_hooks.name",
 		"pattern_hooks.pattern_title",
 		"pattern_hooks.timeout",
+		"pattern_hooks.webhook",
+		"pattern_hooks.webhook_headers",
+		"revision",
+		"stale",
+		"stale.exempt_columns",
+		"stale.stale_days",
+		"templates",
+		"templates.default_column",
+		"templates.default_custom_fields",
+		"templates.default_description",
+		"templates.name",
+		"templates.title_pattern",
 	},
-	"card/3": {
+	"card/9": {
 		"_v",
 		"alias",
 		"alias_explicit",
+		"archived",
+		"archived_at_millis",
+		"blocked_by",
+		"blocks",
+		"checklist",
+		"checklist.created_at_millis",
+		"checklist.done",
+		"checklist.id",
+		"checklist.text",
 		"column",
 		"comments",
 		"comments.author",
 		"comments.body",
 		"comments.created_at_millis",
 		"comments.id",
+		"comments.mentions",
 		"comments.updated_at_millis",
 		"created_at_millis",
 		"creator",
 		"description",
+		"done_at_millis",
+		"due_at_millis",
 		"history",
 		"history.at",
 		"history.field",
 		"history.value",
 		"id",
+		"last_column",
 		"parent",
 		"position",
 		"title",
 		"updated_at_millis",
 	},
-	"global/2": {
+	"global/5": {
+		"default_columns",
+		"default_columns.auto_archive_after_days",
+		"default_columns.color",
+		"default_columns.description",
+		"default_columns.done",
+		"default_columns.limit",
+		"default_columns.name",
+		"default_columns.sprint_end_millis",
+		"default_columns.sprint_start_millis",
+		"default_columns.transition_rules",
+		"default_columns.transition_rules.forbid_if_fields",
+		"default_columns.transition_rules.from_column",
+		"default_columns.transition_rules.required_fields",
+		"default_custom_fields",
+		"default_custom_fields.description",
+		"default_custom_fields.format",
+		"default_custom_fields.future_only",
+		"default_custom_fields.max",
+		"default_custom_fields.max_length",
+		"default_custom_fields.min",
+		"default_custom_fields.options",
+		"default_custom_fields.options.color",
+		"default_custom_fields.options.description",
+		"default_custom_fields.options.label",
+		"default_custom_fields.options.value",
+		"default_custom_fields.order",
+		"default_custom_fields.past_only",
+		"default_custom_fields.pattern",
+		"default_custom_fields.type",
+		"default_custom_fields.wanted",
 		"editor",
 		"global_board",
 		"global_board.board",
@@ -1,10 +1,67 @@
 package model
 
 import (
+	"fmt"
 	"sort"
 	"strings"
 )
 
+// SortByDueDate is the built-in sort key for ordering cards by DueAtMillis
+// rather than by a custom field. Earliest due dates come first; cards without
+// one sort to the end, like unset custom fields.
+const SortByDueDate = "due_date"
+
+// Built-in sort keys for card metadata, accepted anywhere a sort field is.
+// SortByDueAt is an alias for SortByDueDate.
+const (
+	SortByCreatedAt = "created_at"
+	SortByUpdatedAt = "updated_at"
+	SortByTitle     = "title"
+	SortByDueAt     = "due_at"
+)
+
+// SortField is one key of a multi-field sort.
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+// IsBuiltinSortField reports whether field names card metadata rather than a
+// custom field.
+func IsBuiltinSortField(field string) bool {
+	switch field {
+	case SortByDueDate, SortByDueAt, SortByCreatedAt, SortByUpdatedAt, SortByTitle:
+		return true
+	}
+	return false
+}
+
+// ParseSortFields parses a comma-separated sort spec such as
+// "due_at:asc,title:desc". Each term is a field name with an optional ":asc"
+// or ":desc" direction (ascending by default). An empty spec yields no keys.
+func ParseSortFields(spec string) ([]SortField, error) {
+	if strings.TrimSpace(spec) == "" {
+		return nil, nil
+	}
+	var keys []SortField
+	for _, term := range strings.Split(spec, ",") {
+		field, dir, _ := strings.Cut(strings.TrimSpace(term), ":")
+		if field == "" {
+			return nil, fmt.Errorf("empty sort field in %q", spec)
+		}
+		key := SortField{Field: field}
+		switch strings.ToLower(dir) {
+		case "", "asc":
+		case "desc":
+			key.Desc = true
+		default:
+			return nil, fmt.Errorf("invalid sort direction %q for %s (expected asc or desc)", dir, field)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
 // SortCardsByField sorts cards in place by the named custom field, using the
 // board schema to interpret values. It is a non-destructive view sort: it never
 // touches the cards' Position, only their order in the slice.
@@ -20,6 +77,7 @@ import (
 //   - string / date: case-insensitive lexicographic, then case-sensitive as a
 //     tiebreak. ISO-8601 dates (YYYY-MM-DD) sort chronologically as strings.
 //   - boolean: false before true.
+//   - integer: numerically.
 //
 // Cards with no value for the field always sort to the end, regardless of
 // direction—"unset" stays out of the way whether ascending or descending. Cards
@@ -33,33 +91,33 @@ func SortCardsByField(cards []*Card, board *BoardConfig, field string, descendin
 	if field == "" {
 		return
 	}
+	SortCardsByFields(cards, board, []SortField{{Field: field, Desc: descending}})
+}
 
-	var schema CustomFieldSchema
-	if board != nil {
-		schema = board.CustomFields[field] // zero value (empty Type) if undefined
+// SortCardsByFields sorts cards in place by several keys, each breaking ties
+// left by the previous one. Keys may name custom fields (ordered as in
+// SortCardsByField) or built-in metadata: created_at, updated_at, title and
+// due_at/due_date. A custom field defined on the board takes precedence over
+// a built-in of the same name. Unset values sort last per key, whatever the
+// direction, and cards equal on every key keep their manual order.
+func SortCardsByFields(cards []*Card, board *BoardConfig, keys []SortField) {
+	if len(keys) == 0 {
+		return
+	}
+	cmps := make([]func(a, b *Card) int, len(keys))
+	for i, key := range keys {
+		cmps[i] = cardKeyComparator(board, key)
 	}
-	cmp := fieldValueComparator(schema)
 
 	sort.SliceStable(cards, func(i, j int) bool {
 		ci, cj := cards[i], cards[j]
-		vi, hasI := fieldSortValue(ci, field)
-		vj, hasJ := fieldSortValue(cj, field)
-
-		// Present values always sort before missing ones, in both directions.
-		if hasI != hasJ {
-			return hasI
-		}
-
-		if hasI && hasJ {
-			if c := cmp(vi, vj); c != 0 {
-				if descending {
-					return c > 0
-				}
+		for _, cmp := range cmps {
+			if c := cmp(ci, cj); c != 0 {
 				return c < 0
 			}
 		}
 
-		// Equal values (or both unset): preserve manual order.
+		// Equal on every key: preserve manual order.
 		if ci.Position != cj.Position {
 			return ci.Position < cj.Position
 		}
@@ -67,6 +125,79 @@ func SortCardsByField(cards []*Card, board *BoardConfig, field string, descendin
 	})
 }
 
+// cardKeyComparator returns a 3-way comparison of two cards on a single sort
+// key, with direction and unset-last already applied.
+func cardKeyComparator(board *BoardConfig, key SortField) func(a, b *Card) int {
+	value, cmp := sortKeyAccessors(board, key.Field)
+	return func(a, b *Card) int {
+		va, hasA := value(a)
+		vb, hasB := value(b)
+
+		// Present values always sort before missing ones, in both directions.
+		if hasA != hasB {
+			if hasA {
+				return -1
+			}
+			return 1
+		}
+		if !hasA {
+			return 0
+		}
+
+		c := cmp(va, vb)
+		if key.Desc {
+			c = -c
+		}
+		return c
+	}
+}
+
+// sortKeyAccessors resolves a sort key to a value getter and a comparator for
+// the values it yields.
+func sortKeyAccessors(board *BoardConfig, field string) (func(*Card) (any, bool), func(a, b any) int) {
+	var schema CustomFieldSchema
+	var defined bool
+	if board != nil {
+		schema, defined = board.CustomFields[field] // zero value (empty Type) if undefined
+	}
+
+	millis := func(get func(*Card) int64) func(*Card) (any, bool) {
+		return func(c *Card) (any, bool) {
+			v := get(c)
+			return v, v != 0
+		}
+	}
+	compareMillis := func(a, b any) int {
+		ma, mb := a.(int64), b.(int64)
+		switch {
+		case ma < mb:
+			return -1
+		case ma > mb:
+			return 1
+		default:
+			return 0
+		}
+	}
+
+	switch {
+	case field == SortByDueDate:
+		return millis(func(c *Card) int64 { return c.DueAtMillis }), compareMillis
+	case defined:
+		// Fall through to the custom field below.
+	case field == SortByDueAt:
+		return millis(func(c *Card) int64 { return c.DueAtMillis }), compareMillis
+	case field == SortByCreatedAt:
+		return millis(func(c *Card) int64 { return c.CreatedAtMillis }), compareMillis
+	case field == SortByUpdatedAt:
+		return millis(func(c *Card) int64 { return c.UpdatedAtMillis }), compareMillis
+	case field == SortByTitle:
+		return func(c *Card) (any, bool) { return c.Title, c.Title != "" },
+			func(a, b any) int { return compareStrings(asString(a), asString(b)) }
+	}
+
+	return func(c *Card) (any, bool) { return fieldSortValue(c, field) }, fieldValueComparator(schema)
+}
+
 // fieldSortValue returns a card's raw value for a field and whether it counts as
 // "set". Empty strings and empty sets are treated as unset; a boolean is set
 // whenever present (false is a meaningful value).
@@ -90,7 +221,7 @@ func fieldSortValue(card *Card, field string) (any, bool) {
 // fieldValueComparator returns a 3-way comparison function for two set values of
 // the given field. Both inputs are assumed non-empty (see fieldSortValue).
 func fieldValueComparator(schema CustomFieldSchema) func(a, b any) int {
-	switch schema.Type {
+	switch schema.FieldType() {
 	case FieldTypeEnum:
 		order := optionRanks(schema)
 		return func(a, b any) int {
@@ -122,6 +253,18 @@ func fieldValueComparator(schema CustomFieldSchema) func(a, b any) int {
 		return func(a, b any) int {
 			return compareStrings(minSetValue(a), minSetValue(b))
 		}
+	case FieldTypeInteger:
+		return func(a, b any) int {
+			na, nb := asNumber(a), asNumber(b)
+			switch {
+			case na < nb:
+				return -1
+			case na > nb:
+				return 1
+			default:
+				return 0
+			}
+		}
 	default: // string, date, or unknown
 		return func(a, b any) int {
 			return compareStrings(asString(a), asString(b))
@@ -213,6 +356,21 @@ func asBool(v any) bool {
 	return b
 }
 
+// asNumber coerces an integer field value to float64. Values set in memory are
+// int; values decoded from card JSON are float64.
+func asNumber(v any) float64 {
+	switch n := v.(type) {
+	case int:
+		return float64(n)
+	case int64:
+		return float64(n)
+	case float64:
+		return n
+	default:
+		return 0
+	}
+}
+
 // asStringSlice coerces a set value (stored as []any from JSON, or []string in
 // memory) to a []string, dropping non-string elements.
 func asStringSlice(v any) []string {
@@ -31,6 +31,7 @@ func sortTestBoard() *BoardConfig {
 			"due":      {Type: FieldTypeDate},
 			"blocked":  {Type: FieldTypeBoolean},
 			"topics":   {Type: FieldTypeFreeSet},
+			"points":   {Type: FieldTypeInteger},
 		},
 	}
 }
@@ -168,6 +169,21 @@ func TestSortCardsByField_Boolean(t *testing.T) {
 	}
 }
 
+func TestSortCardsByField_IntegerNumeric(t *testing.T) {
+	cards := []*Card{
+		mkCard("ten", "A", map[string]any{"points": float64(10)}), // as decoded from JSON
+		mkCard("two", "B", map[string]any{"points": 2}),           // as set in memory
+		mkCard("unset", "C", nil),
+		mkCard("zero", "D", map[string]any{"points": float64(0)}),
+	}
+	SortCardsByField(cards, sortTestBoard(), "points", false)
+	// Numeric, not lexicographic ("10" < "2"); zero is a real value.
+	want := []string{"zero", "two", "ten", "unset"}
+	if got := cardIDs(cards); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
 func TestSortCardsByField_StringCaseInsensitive(t *testing.T) {
 	cards := []*Card{
 		mkCard("bob", "A", map[string]any{"assignee": "bob"}),
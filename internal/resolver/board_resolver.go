@@ -2,24 +2,38 @@ package resolver
 
 import (
 	"fmt"
+	"slices"
 
 	"github.com/amterp/kan/internal/model"
 	"github.com/amterp/kan/internal/prompt"
+	"github.com/amterp/kan/internal/service"
 	"github.com/amterp/kan/internal/store"
 )
 
-// BoardResolver handles board selection logic.
+// BoardResolver handles board selection logic. The fallback chain itself is
+// BoardService.GetOrDefaultFunc; the resolver adds the preferred board and
+// the fallback strategy, prompting if need be.
 type BoardResolver struct {
 	boardStore  store.BoardStore
-	globalStore store.GlobalStore
+	boards      *service.BoardService
 	prompter    prompt.Prompter
 	projectPath string
 	// preferredBoard, when set, is used ahead of default_board and the
 	// interactive picker. It is how `-g` pins resolution to the designated
 	// global board while still letting an explicit -b override.
 	preferredBoard string
+	// fallback decides what happens when several boards exist and none was
+	// given or inferred. See the Fallback* constants.
+	fallback string
 }
 
+// Fallback strategies for when no board is given and none can be inferred.
+const (
+	FallbackFirst  = "first"  // The first board by name
+	FallbackRecent = "recent" // The board whose config was written last
+	FallbackPrompt = "prompt" // Ask when interactive, fail otherwise (the default)
+)
+
 // NewBoardResolver creates a new board resolver.
 func NewBoardResolver(
 	boardStore store.BoardStore,
@@ -27,11 +41,14 @@ func NewBoardResolver(
 	prompter prompt.Prompter,
 	projectPath string,
 ) *BoardResolver {
+	boards := service.NewBoardService(boardStore, nil)
+	boards.SetGlobalStore(globalStore)
 	return &BoardResolver{
 		boardStore:  boardStore,
-		globalStore: globalStore,
+		boards:      boards,
 		prompter:    prompter,
 		projectPath: projectPath,
+		fallback:    FallbackPrompt,
 	}
 }
 
@@ -41,6 +58,19 @@ func (r *BoardResolver) SetPreferredBoard(board string) {
 	r.preferredBoard = board
 }
 
+// SetFallbackStrategy sets how Resolve picks a board when several exist and
+// none was given or inferred: FallbackFirst, FallbackRecent or FallbackPrompt.
+func (r *BoardResolver) SetFallbackStrategy(strategy string) error {
+	switch strategy {
+	case FallbackFirst, FallbackRecent, FallbackPrompt:
+		r.fallback = strategy
+		return nil
+	default:
+		return fmt.Errorf("unknown board fallback strategy %q (expected %s, %s or %s)",
+			strategy, FallbackFirst, FallbackRecent, FallbackPrompt)
+	}
+}
+
 // InferBoard resolves which board to use without user interaction.
 // It checks: single-board auto-detect, then default_board from global config.
 // Returns "" if no board can be inferred. Used by both BoardResolver and
@@ -66,52 +96,35 @@ func InferBoard(boardStore store.BoardStore, globalCfg *model.GlobalConfig, proj
 	return ""
 }
 
-// Resolve determines which board to use based on the spec's rules:
+// Resolve determines which board to use:
 // 1. If explicit board provided, use it
-// 2. If only one board exists, use it
-// 3. If default_board configured, use it
-// 4. If interactive, prompt user
-// 5. Otherwise, fail with error
+// 2. If a preferred board is set (see SetPreferredBoard), use it
+// 3. If only one board exists, use it
+// 4. If default_board configured, use it
+// 5. Otherwise apply the fallback strategy (see SetFallbackStrategy)
+//
+// Steps 1, 3 and 4 are BoardService.GetOrDefaultFunc's.
 func (r *BoardResolver) Resolve(explicitBoard string, interactive bool) (string, error) {
-	// 1. Explicit board
-	if explicitBoard != "" {
-		if !r.boardStore.Exists(explicitBoard) {
-			return "", fmt.Errorf("board %q not found", explicitBoard)
-		}
-		return explicitBoard, nil
-	}
-
-	// 2. Get all boards - check for empty first
-	boards, err := r.boardStore.List()
-	if err != nil {
-		return "", err
-	}
-	if len(boards) == 0 {
-		return "", fmt.Errorf("no boards found; run 'kan init' first")
-	}
-
-	// 2.5. Preferred board (e.g. the designated global board under -g) wins over
-	// default_board and the picker, but yields to an explicit -b (handled above).
-	if r.preferredBoard != "" && r.boardStore.Exists(r.preferredBoard) {
+	// The preferred board (e.g. the designated global board under -g) wins
+	// over default_board and the picker, but yields to an explicit -b.
+	if explicitBoard == "" && r.preferredBoard != "" && r.boardStore.Exists(r.preferredBoard) {
 		return r.preferredBoard, nil
 	}
 
-	// 3-4. Try non-interactive inference (single board or default)
-	globalCfg, _ := r.globalStore.Load()
-	if board := InferBoard(r.boardStore, globalCfg, r.projectPath); board != "" {
-		// Validate the inferred board still exists (default_board might be stale)
-		if r.boardStore.Exists(board) {
-			return board, nil
+	return r.boards.GetOrDefaultFunc(explicitBoard, r.projectPath, func(boards []string) (string, error) {
+		switch r.fallback {
+		case FallbackFirst:
+			return slices.Min(boards), nil
+		case FallbackRecent:
+			if board := store.MostRecentBoard(r.boardStore, boards); board != "" {
+				return board, nil
+			}
 		}
-	}
-
-	// 5. Multiple boards, no usable default
-	if !interactive {
-		return "", fmt.Errorf("multiple boards exist; specify with -b or set default_board in config")
-	}
-
-	// 6. Prompt user
-	return r.prompter.Select("Select board", boards)
+		if !interactive {
+			return "", fmt.Errorf("multiple boards exist; specify with -b or set default_board in config")
+		}
+		return r.prompter.Select("Select board", boards)
+	})
 }
 
 // GetBoardConfig returns the board configuration.
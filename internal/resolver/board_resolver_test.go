@@ -2,6 +2,7 @@ package resolver
 
 import (
 	"testing"
+	"time"
 
 	kanerr "github.com/amterp/kan/internal/errors"
 	"github.com/amterp/kan/internal/model"
@@ -11,7 +12,8 @@ import (
 
 // mockBoardStore implements store.BoardStore for testing.
 type mockBoardStore struct {
-	boards map[string]*model.BoardConfig
+	boards   map[string]*model.BoardConfig
+	modTimes map[string]time.Time // Unset boards report the zero time
 }
 
 func newMockBoardStore() *mockBoardStore {
@@ -43,6 +45,16 @@ func (m *mockBoardStore) Update(config *model.BoardConfig) error {
 }
 
 func (m *mockBoardStore) List() ([]string, error) {
+	var names []string
+	for name, cfg := range m.boards {
+		if !cfg.IsArchived() {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+func (m *mockBoardStore) ListAll() ([]string, error) {
 	var names []string
 	for name := range m.boards {
 		names = append(names, name)
@@ -50,6 +62,25 @@ func (m *mockBoardStore) List() ([]string, error) {
 	return names, nil
 }
 
+func (m *mockBoardStore) ModTime(boardName string) (time.Time, error) {
+	if _, ok := m.boards[boardName]; !ok {
+		return time.Time{}, kanerr.BoardNotFound(boardName)
+	}
+	return m.modTimes[boardName], nil
+}
+
+func (m *mockBoardStore) Archive(boardName string) error {
+	return nil
+}
+
+func (m *mockBoardStore) Unarchive(boardName string) error {
+	return nil
+}
+
+func (m *mockBoardStore) Rename(oldName, newName string) error {
+	return nil
+}
+
 func (m *mockBoardStore) Delete(boardName string) error {
 	if _, ok := m.boards[boardName]; !ok {
 		return kanerr.BoardNotFound(boardName)
@@ -152,12 +183,8 @@ func TestBoardResolver_Resolve_ExplicitBoard_NotFound(t *testing.T) {
 	resolver := NewBoardResolver(boardStore, newMockGlobalStore(), &prompt.NoopPrompter{}, "/repo")
 
 	_, err := resolver.Resolve("nonexistent", false)
-	if err == nil {
-		t.Fatal("Expected error for nonexistent board")
-	}
-	expected := `board "nonexistent" not found`
-	if err.Error() != expected {
-		t.Errorf("Expected error %q, got %q", expected, err.Error())
+	if !kanerr.IsCode(err, kanerr.CodeBoardNotFound) {
+		t.Errorf("Expected board not found, got %v", err)
 	}
 }
 
@@ -332,6 +359,69 @@ func TestBoardResolver_Resolve_Interactive_PromptError(t *testing.T) {
 	}
 }
 
+func TestBoardResolver_Resolve_FallbackStrategies(t *testing.T) {
+	boardStore := newMockBoardStore()
+	boardStore.addBoard("main")
+	boardStore.addBoard("feature")
+	boardStore.modTimes = map[string]time.Time{
+		"main":    time.Unix(2000, 0),
+		"feature": time.Unix(1000, 0),
+	}
+
+	tests := []struct {
+		strategy string
+		want     string
+	}{
+		{FallbackFirst, "feature"},
+		{FallbackRecent, "main"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.strategy, func(t *testing.T) {
+			resolver := NewBoardResolver(boardStore, newMockGlobalStore(), &prompt.NoopPrompter{}, "/repo")
+			if err := resolver.SetFallbackStrategy(tt.strategy); err != nil {
+				t.Fatalf("SetFallbackStrategy failed: %v", err)
+			}
+			board, err := resolver.Resolve("", false)
+			if err != nil {
+				t.Fatalf("Resolve failed: %v", err)
+			}
+			if board != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, board)
+			}
+		})
+	}
+}
+
+func TestBoardResolver_Resolve_FallbackDefersToDefault(t *testing.T) {
+	boardStore := newMockBoardStore()
+	boardStore.addBoard("main")
+	boardStore.addBoard("feature")
+
+	globalStore := newMockGlobalStore()
+	globalStore.setDefaultBoard("/repo", "main")
+
+	resolver := NewBoardResolver(boardStore, globalStore, &prompt.NoopPrompter{}, "/repo")
+	if err := resolver.SetFallbackStrategy(FallbackFirst); err != nil {
+		t.Fatalf("SetFallbackStrategy failed: %v", err)
+	}
+
+	board, err := resolver.Resolve("", false)
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	if board != "main" {
+		t.Errorf("Expected default_board main ahead of the fallback, got %q", board)
+	}
+}
+
+func TestBoardResolver_SetFallbackStrategy_Unknown(t *testing.T) {
+	resolver := NewBoardResolver(newMockBoardStore(), newMockGlobalStore(), &prompt.NoopPrompter{}, "/repo")
+
+	if err := resolver.SetFallbackStrategy("random"); err == nil {
+		t.Error("Expected error for unknown strategy")
+	}
+}
+
 func TestBoardResolver_Resolve_DifferentRepoPaths(t *testing.T) {
 	boardStore := newMockBoardStore()
 	boardStore.addBoard("main")
@@ -50,8 +50,9 @@ func (r *CardResolver) Resolve(boardName, idOrAlias string) (*model.Card, error)
 		return card, nil
 	}
 
-	// 2. Load the board once for alias-exact + fuzzy.
-	cards, err := r.cardStore.List(boardName)
+	// 2. Load the board once for alias-exact + fuzzy. Archived cards are
+	// included so they can still be shown or unarchived by alias.
+	cards, err := r.cardStore.List(boardName, true)
 	if err != nil {
 		return nil, err
 	}
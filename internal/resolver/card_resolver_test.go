@@ -49,7 +49,7 @@ func (m *mockCardStore) Delete(boardName, cardID string) error {
 	return nil
 }
 
-func (m *mockCardStore) List(boardName string) ([]*model.Card, error) {
+func (m *mockCardStore) List(boardName string, includeArchived bool) ([]*model.Card, error) {
 	var cards []*model.Card
 	if board, ok := m.cards[boardName]; ok {
 		for _, card := range board {
@@ -70,6 +70,13 @@ func (m *mockCardStore) FindByAlias(boardName, alias string) (*model.Card, error
 	return nil, kanerr.CardNotFound(alias)
 }
 
+func (m *mockCardStore) RebuildIndex(boardName string) error { return nil }
+
+func (m *mockCardStore) Stream(boardName string) (<-chan store.CardOrError, error) {
+	cards, _ := m.List(boardName, true)
+	return store.StreamSlice(cards), nil
+}
+
 var _ store.CardStore = (*mockCardStore)(nil)
 
 // ============================================================================
@@ -149,7 +156,7 @@ func TestCardResolver_Resolve_NotFound(t *testing.T) {
 	if err == nil {
 		t.Fatal("Expected error for nonexistent card")
 	}
-	if !kanerr.IsNotFound(err) {
+	if !kanerr.IsCode(err, kanerr.CodeCardNotFound) {
 		t.Errorf("Expected NotFound error, got %v", err)
 	}
 }
@@ -169,7 +176,7 @@ func TestCardResolver_Resolve_WrongBoard(t *testing.T) {
 	if err == nil {
 		t.Fatal("Expected error for card in wrong board")
 	}
-	if !kanerr.IsNotFound(err) {
+	if !kanerr.IsCode(err, kanerr.CodeCardNotFound) {
 		t.Errorf("Expected NotFound error, got %v", err)
 	}
 }
@@ -431,7 +438,7 @@ func TestCardResolver_Resolve_FuzzyBelowMinQueryLen(t *testing.T) {
 	if err == nil {
 		t.Fatal("Expected not-found error for short query")
 	}
-	if !kanerr.IsNotFound(err) {
+	if !kanerr.IsCode(err, kanerr.CodeCardNotFound) {
 		t.Errorf("Expected NotFound for short query, got %v", err)
 	}
 }
@@ -445,7 +452,7 @@ func TestCardResolver_Resolve_FuzzyNoMatch(t *testing.T) {
 	if err == nil {
 		t.Fatal("Expected not-found error")
 	}
-	if !kanerr.IsNotFound(err) {
+	if !kanerr.IsCode(err, kanerr.CodeCardNotFound) {
 		t.Errorf("Expected NotFound, got %v", err)
 	}
 }
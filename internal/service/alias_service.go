@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	kanerr "github.com/amterp/kan/internal/errors"
+	"github.com/amterp/kan/internal/model"
 	"github.com/amterp/kan/internal/store"
 	"github.com/amterp/kan/internal/util"
 )
@@ -12,34 +13,64 @@ import (
 const (
 	// slugThreshold is the max character length we aim for when building the
 	// initial slug. We'll include at least minSlugWords, then keep adding
-	// words while the joined result stays within this budget.
+	// words while the joined result stays within this budget. Boards can
+	// override it with alias.max_length.
 	slugThreshold = 20
 	minSlugWords  = 2
 )
 
 // AliasService handles alias generation and collision detection.
 type AliasService struct {
-	cardStore store.CardStore
+	cardStore  store.CardStore
+	boardStore store.BoardStore
 }
 
-// NewAliasService creates a new alias service.
-func NewAliasService(cardStore store.CardStore) *AliasService {
-	return &AliasService{cardStore: cardStore}
+// NewAliasService creates a new alias service. boardStore supplies each
+// board's alias settings; if nil, every board uses the default strategy.
+func NewAliasService(cardStore store.CardStore, boardStore store.BoardStore) *AliasService {
+	return &AliasService{cardStore: cardStore, boardStore: boardStore}
 }
 
-// GenerateAlias creates a unique alias from a title.
-// It builds a short slug progressively: start with a threshold-limited set of
-// words, then on collision add more title words before falling back to -N.
+// GenerateAlias creates a unique alias for a card, using the board's
+// configured alias style (see model.AliasConfig).
 // excludeCardID allows excluding a specific card from collision detection,
 // useful when regenerating alias for an existing card.
 func (s *AliasService) GenerateAlias(boardName, title, excludeCardID string) (string, error) {
+	cfg := model.AliasConfig{}
+	if s.boardStore != nil {
+		boardCfg, err := s.boardStore.Get(boardName)
+		if err != nil {
+			return "", err
+		}
+		cfg = boardCfg.Alias
+	}
+
+	maxLength := cfg.MaxLength
+	if maxLength <= 0 {
+		maxLength = slugThreshold
+	}
+
+	switch cfg.Style {
+	case model.AliasStyleInitials:
+		return s.generateInitials(boardName, title, cfg.Prefix, maxLength, excludeCardID)
+	case model.AliasStyleNumeric:
+		return s.generateNumeric(boardName, cfg.Prefix, excludeCardID)
+	default:
+		return s.generateSlug(boardName, title, cfg.Prefix, maxLength, excludeCardID)
+	}
+}
+
+// generateSlug builds a short slug progressively: start with a
+// threshold-limited set of words, then on collision add more title words
+// before falling back to -N.
+func (s *AliasService) generateSlug(boardName, title, prefix string, threshold int, excludeCardID string) (string, error) {
 	words := util.SlugWords(title)
 	if len(words) == 0 {
 		words = []string{"card"}
 	}
 
-	initialCount := wordsForThreshold(words)
-	base := strings.Join(words[:initialCount], "-")
+	initialCount := wordsForThreshold(words, threshold)
+	base := prefix + strings.Join(words[:initialCount], "-")
 
 	if s.IsAliasAvailable(boardName, base, excludeCardID) {
 		return base, nil
@@ -47,13 +78,55 @@ func (s *AliasService) GenerateAlias(boardName, title, excludeCardID string) (st
 
 	// Collision: try adding one more title word at a time
 	for i := initialCount; i < len(words); i++ {
-		candidate := strings.Join(words[:i+1], "-")
+		candidate := prefix + strings.Join(words[:i+1], "-")
 		if s.IsAliasAvailable(boardName, candidate, excludeCardID) {
 			return candidate, nil
 		}
 	}
 
-	// All title words exhausted: fall back to numeric suffix on the base slug
+	return s.withNumericSuffix(boardName, base, title, excludeCardID)
+}
+
+// generateInitials joins the first letter of each title word ("Fix login
+// bug" -> "flb"), truncated to maxLength, falling back to -N on collision.
+func (s *AliasService) generateInitials(boardName, title, prefix string, maxLength int, excludeCardID string) (string, error) {
+	var initials strings.Builder
+	for _, word := range util.SlugWords(title) {
+		initials.WriteByte(word[0])
+	}
+	base := initials.String()
+	if base == "" {
+		base = "card"
+	}
+	if len(base) > maxLength {
+		base = base[:maxLength]
+	}
+	base = prefix + base
+
+	if s.IsAliasAvailable(boardName, base, excludeCardID) {
+		return base, nil
+	}
+	return s.withNumericSuffix(boardName, base, title, excludeCardID)
+}
+
+// generateNumeric returns "card-<N>", where N starts at the board's card
+// count (archived cards included) plus one and increments until unique.
+func (s *AliasService) generateNumeric(boardName, prefix, excludeCardID string) (string, error) {
+	cards, err := s.cardStore.List(boardName, true)
+	if err != nil {
+		return "", err
+	}
+	for n := len(cards) + 1; n <= len(cards)+1000; n++ {
+		candidate := fmt.Sprintf("%scard-%d", prefix, n)
+		if s.IsAliasAvailable(boardName, candidate, excludeCardID) {
+			return candidate, nil
+		}
+	}
+	return "", fmt.Errorf("could not generate unique numeric alias on board %q", boardName)
+}
+
+// withNumericSuffix tries base-2, base-3, ... until one is free.
+func (s *AliasService) withNumericSuffix(boardName, base, title, excludeCardID string) (string, error) {
 	for i := 2; i <= 1000; i++ {
 		candidate := fmt.Sprintf("%s-%d", base, i)
 		if s.IsAliasAvailable(boardName, candidate, excludeCardID) {
@@ -66,15 +139,15 @@ func (s *AliasService) GenerateAlias(boardName, title, excludeCardID string) (st
 
 // wordsForThreshold returns how many words to include in the initial slug.
 // Always includes at least minSlugWords (if available), then adds words while
-// the joined length stays within slugThreshold.
-func wordsForThreshold(words []string) int {
+// the joined length stays within threshold.
+func wordsForThreshold(words []string, threshold int) int {
 	count := min(minSlugWords, len(words))
 
 	for i := count; i < len(words); i++ {
 		// length of joined slug if we include words[i]:
 		// current joined length + hyphen + next word
 		candidateLen := joinedLen(words[:i+1])
-		if candidateLen > slugThreshold {
+		if candidateLen > threshold {
 			break
 		}
 		count = i + 1
@@ -42,7 +42,7 @@ func (m *mockCardStore) Delete(boardName, cardID string) error {
 	return nil
 }
 
-func (m *mockCardStore) List(boardName string) ([]*model.Card, error) {
+func (m *mockCardStore) List(boardName string, includeArchived bool) ([]*model.Card, error) {
 	var cards []*model.Card
 	for _, card := range m.cards[boardName] {
 		cards = append(cards, card)
@@ -59,12 +59,19 @@ func (m *mockCardStore) FindByAlias(boardName, alias string) (*model.Card, error
 	return nil, kanerr.CardNotFound(alias)
 }
 
+func (m *mockCardStore) RebuildIndex(boardName string) error { return nil }
+
+func (m *mockCardStore) Stream(boardName string) (<-chan store.CardOrError, error) {
+	cards, _ := m.List(boardName, true)
+	return store.StreamSlice(cards), nil
+}
+
 // Ensure mockCardStore implements the interface
 var _ store.CardStore = (*mockCardStore)(nil)
 
 func TestAliasService_GenerateAlias_Basic(t *testing.T) {
 	mockStore := newMockCardStore()
-	service := NewAliasService(mockStore)
+	service := NewAliasService(mockStore, nil)
 
 	alias, err := service.GenerateAlias("main", "Fix login bug", "")
 	if err != nil {
@@ -82,7 +89,7 @@ func TestAliasService_GenerateAlias_Collision(t *testing.T) {
 	// Add existing card with alias
 	mockStore.addCard("main", &model.Card{ID: "existing", Alias: "fix-bug"})
 
-	service := NewAliasService(mockStore)
+	service := NewAliasService(mockStore, nil)
 
 	alias, err := service.GenerateAlias("main", "Fix Bug", "")
 	if err != nil {
@@ -103,7 +110,7 @@ func TestAliasService_GenerateAlias_MultipleCollisions(t *testing.T) {
 	mockStore.addCard("main", &model.Card{ID: "2", Alias: "fix-bug-2"})
 	mockStore.addCard("main", &model.Card{ID: "3", Alias: "fix-bug-3"})
 
-	service := NewAliasService(mockStore)
+	service := NewAliasService(mockStore, nil)
 
 	alias, err := service.GenerateAlias("main", "Fix Bug", "")
 	if err != nil {
@@ -117,7 +124,7 @@ func TestAliasService_GenerateAlias_MultipleCollisions(t *testing.T) {
 
 func TestAliasService_GenerateAlias_EmptyTitle(t *testing.T) {
 	mockStore := newMockCardStore()
-	service := NewAliasService(mockStore)
+	service := NewAliasService(mockStore, nil)
 
 	alias, err := service.GenerateAlias("main", "", "")
 	if err != nil {
@@ -132,7 +139,7 @@ func TestAliasService_GenerateAlias_EmptyTitle(t *testing.T) {
 
 func TestAliasService_GenerateAlias_SpecialChars(t *testing.T) {
 	mockStore := newMockCardStore()
-	service := NewAliasService(mockStore)
+	service := NewAliasService(mockStore, nil)
 
 	tests := []struct {
 		title    string
@@ -159,7 +166,7 @@ func TestAliasService_IsAliasAvailable(t *testing.T) {
 	mockStore := newMockCardStore()
 	mockStore.addCard("main", &model.Card{ID: "card-1", Alias: "taken-alias"})
 
-	service := NewAliasService(mockStore)
+	service := NewAliasService(mockStore, nil)
 
 	// Without exclusion, alias should not be available
 	if service.IsAliasAvailable("main", "taken-alias", "") {
@@ -188,7 +195,7 @@ func TestAliasService_DifferentBoards(t *testing.T) {
 	// Same alias in different boards should be fine
 	mockStore.addCard("board1", &model.Card{ID: "1", Alias: "fix-bug"})
 
-	service := NewAliasService(mockStore)
+	service := NewAliasService(mockStore, nil)
 
 	// Should be available in board2
 	alias, err := service.GenerateAlias("board2", "Fix Bug", "")
@@ -208,7 +215,7 @@ func TestAliasService_GenerateAlias_ProgressiveExpansion(t *testing.T) {
 	// because minSlugWords=2 is always enforced. Collide with it to test expansion.
 	mockStore.addCard("main", &model.Card{ID: "1", Alias: "update-authentication"})
 
-	service := NewAliasService(mockStore)
+	service := NewAliasService(mockStore, nil)
 
 	// Should expand to include the next word rather than going to -2
 	alias, err := service.GenerateAlias("main", "Update authentication middleware", "")
@@ -228,7 +235,7 @@ func TestAliasService_GenerateAlias_ProgressiveExpansionMultipleSteps(t *testing
 	mockStore.addCard("main", &model.Card{ID: "1", Alias: "update-authentication"})
 	mockStore.addCard("main", &model.Card{ID: "2", Alias: "update-authentication-middleware"})
 
-	service := NewAliasService(mockStore)
+	service := NewAliasService(mockStore, nil)
 
 	alias, err := service.GenerateAlias("main", "Update authentication middleware layer", "")
 	if err != nil {
@@ -247,7 +254,7 @@ func TestAliasService_GenerateAlias_WordExhaustion(t *testing.T) {
 	mockStore.addCard("main", &model.Card{ID: "1", Alias: "update-authentication"})
 	mockStore.addCard("main", &model.Card{ID: "2", Alias: "update-authentication-middleware"})
 
-	service := NewAliasService(mockStore)
+	service := NewAliasService(mockStore, nil)
 
 	// Title only has 3 words, all expansions collide, should fall back to -2
 	alias, err := service.GenerateAlias("main", "Update authentication middleware", "")
@@ -262,7 +269,7 @@ func TestAliasService_GenerateAlias_WordExhaustion(t *testing.T) {
 
 func TestAliasService_GenerateAlias_LongTitleTruncated(t *testing.T) {
 	mockStore := newMockCardStore()
-	service := NewAliasService(mockStore)
+	service := NewAliasService(mockStore, nil)
 
 	// Long title should be truncated to threshold
 	alias, err := service.GenerateAlias("main", "This is a very long title that exceeds the limit", "")
@@ -280,7 +287,7 @@ func TestAliasService_GenerateAlias_LongTitleCollisionExpands(t *testing.T) {
 	mockStore := newMockCardStore()
 	mockStore.addCard("main", &model.Card{ID: "1", Alias: "this-is-a-very-long"})
 
-	service := NewAliasService(mockStore)
+	service := NewAliasService(mockStore, nil)
 
 	alias, err := service.GenerateAlias("main", "This is a very long title that exceeds the limit", "")
 	if err != nil {
@@ -295,7 +302,7 @@ func TestAliasService_GenerateAlias_LongTitleCollisionExpands(t *testing.T) {
 
 func TestAliasService_GenerateAlias_SingleWord(t *testing.T) {
 	mockStore := newMockCardStore()
-	service := NewAliasService(mockStore)
+	service := NewAliasService(mockStore, nil)
 
 	alias, err := service.GenerateAlias("main", "Bug", "")
 	if err != nil {
@@ -323,7 +330,7 @@ func TestWordsForThreshold(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := wordsForThreshold(tt.words)
+			result := wordsForThreshold(tt.words, slugThreshold)
 			if result != tt.expected {
 				t.Errorf("wordsForThreshold(%v) = %d, want %d", tt.words, result, tt.expected)
 			}
@@ -335,7 +342,7 @@ func TestAliasService_GenerateAlias_ExcludesCurrentCard(t *testing.T) {
 	mockStore := newMockCardStore()
 	mockStore.addCard("main", &model.Card{ID: "card-1", Alias: "fix-bug"})
 
-	service := NewAliasService(mockStore)
+	service := NewAliasService(mockStore, nil)
 
 	// When regenerating alias for the same card, it should get the same alias back
 	alias, err := service.GenerateAlias("main", "Fix Bug", "card-1")
@@ -352,7 +359,7 @@ func TestAliasService_GenerateAlias_DoesNotExcludeOtherCards(t *testing.T) {
 	mockStore := newMockCardStore()
 	mockStore.addCard("main", &model.Card{ID: "card-1", Alias: "fix-bug"})
 
-	service := NewAliasService(mockStore)
+	service := NewAliasService(mockStore, nil)
 
 	// When generating alias without exclusion, should get suffixed version
 	alias, err := service.GenerateAlias("main", "Fix Bug", "")
@@ -374,3 +381,55 @@ func TestAliasService_GenerateAlias_DoesNotExcludeOtherCards(t *testing.T) {
 		t.Errorf("Expected 'fix-bug-2' (excluding different card), got %q", alias)
 	}
 }
+
+func aliasServiceWithConfig(cards *mockCardStore, cfg model.AliasConfig) *AliasService {
+	boardStore := newTestBoardStore()
+	boardCfg := testBoardConfig("main")
+	boardCfg.Alias = cfg
+	boardStore.addBoard(boardCfg)
+	return NewAliasService(cards, boardStore)
+}
+
+func TestAliasService_GenerateAlias_Styles(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      model.AliasConfig
+		title    string
+		existing []string
+		expected string
+	}{
+		{"slugify default", model.AliasConfig{}, "Fix login bug", nil, "fix-login-bug"},
+		{"slugify max length", model.AliasConfig{MaxLength: 8}, "Refactor authentication module", nil, "refactor-authentication"},
+		{"slugify prefix", model.AliasConfig{Prefix: "be-"}, "Fix login bug", nil, "be-fix-login-bug"},
+		{"initials", model.AliasConfig{Style: model.AliasStyleInitials}, "fix-login-bug", nil, "flb"},
+		{"initials truncated", model.AliasConfig{Style: model.AliasStyleInitials, MaxLength: 2}, "Fix login bug", nil, "fl"},
+		{"initials collision", model.AliasConfig{Style: model.AliasStyleInitials}, "Fix login bug", []string{"flb"}, "flb-2"},
+		{"initials prefix", model.AliasConfig{Style: model.AliasStyleInitials, Prefix: "x-"}, "Fix login bug", nil, "x-flb"},
+		{"numeric", model.AliasConfig{Style: model.AliasStyleNumeric}, "Fix login bug", []string{"a", "b"}, "card-3"},
+		{"numeric collision", model.AliasConfig{Style: model.AliasStyleNumeric}, "Fix login bug", []string{"card-2", "card-3"}, "card-4"},
+		{"numeric prefix", model.AliasConfig{Style: model.AliasStyleNumeric, Prefix: "ops-"}, "Anything", nil, "ops-card-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cards := newMockCardStore()
+			for i, alias := range tt.existing {
+				cards.addCard("main", &model.Card{ID: "existing-" + string(rune('a'+i)), Alias: alias})
+			}
+			alias, err := aliasServiceWithConfig(cards, tt.cfg).GenerateAlias("main", tt.title, "")
+			if err != nil {
+				t.Fatalf("GenerateAlias failed: %v", err)
+			}
+			if alias != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, alias)
+			}
+		})
+	}
+}
+
+func TestAliasService_GenerateAlias_UnknownBoard(t *testing.T) {
+	svc := aliasServiceWithConfig(newMockCardStore(), model.AliasConfig{})
+	if _, err := svc.GenerateAlias("missing", "Fix login bug", ""); err == nil {
+		t.Error("Expected error for unknown board")
+	}
+}
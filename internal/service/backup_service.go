@@ -0,0 +1,247 @@
+package service
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/amterp/kan/internal/config"
+	kanerr "github.com/amterp/kan/internal/errors"
+)
+
+// SnapshotsDir is the directory inside the Kan data directory that holds
+// pre-migration snapshots. It is never itself included in a snapshot.
+const SnapshotsDir = ".snapshots"
+
+// SnapshotRetention is how many snapshots a project keeps. Taking a snapshot
+// prunes the oldest beyond this, so repeated (auto-)migrations don't grow
+// .snapshots without bound.
+const SnapshotRetention = 5
+
+// snapshotIDFormat names snapshots by their UTC creation time, so IDs sort
+// chronologically.
+const snapshotIDFormat = "20060102T150405.000Z"
+
+// SnapshotInfo describes a stored snapshot.
+type SnapshotInfo struct {
+	ID              string
+	CreatedAtMillis int64
+	// BoardSchemas maps each board in the snapshot to the kan_schema its
+	// config had when the snapshot was taken ("" for unversioned boards).
+	BoardSchemas map[string]string
+}
+
+// BackupService copies a project's Kan data directory aside so a migration
+// can be undone. Like MigrateService it works on raw files, since snapshots
+// may hold data in any schema version.
+type BackupService struct{}
+
+// NewBackupService creates a new backup service.
+func NewBackupService() *BackupService {
+	return &BackupService{}
+}
+
+// Snapshot copies everything in the Kan data directory (except existing
+// snapshots and the lock file) to .snapshots/<id>/ and returns the new snapshot's ID.
+// Snapshots beyond SnapshotRetention are then pruned, oldest first.
+func (s *BackupService) Snapshot(paths *config.Paths) (string, error) {
+	root := paths.KanRoot()
+	snapshotsRoot := filepath.Join(root, SnapshotsDir)
+
+	now := time.Now().UTC()
+	id := now.Format(snapshotIDFormat)
+	// Two snapshots within the same millisecond would collide; disambiguate.
+	for n := 2; ; n++ {
+		if _, err := os.Stat(filepath.Join(snapshotsRoot, id)); os.IsNotExist(err) {
+			break
+		}
+		id = fmt.Sprintf("%s-%d", now.Format(snapshotIDFormat), n)
+	}
+	dst := filepath.Join(snapshotsRoot, id)
+
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", root, err)
+	}
+	if err := os.MkdirAll(dst, 0755); err != nil {
+		return "", fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+	for _, entry := range entries {
+		if entry.Name() == SnapshotsDir || entry.Name() == config.LockFile {
+			continue
+		}
+		if err := copyDir(filepath.Join(root, entry.Name()), filepath.Join(dst, entry.Name())); err != nil {
+			_ = os.RemoveAll(dst)
+			return "", fmt.Errorf("failed to snapshot %s: %w", entry.Name(), err)
+		}
+	}
+	if _, err := s.Prune(paths, SnapshotRetention); err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// Prune removes all but the newest keep snapshots and returns the IDs it
+// removed, oldest first.
+func (s *BackupService) Prune(paths *config.Paths, keep int) ([]string, error) {
+	snapshots, err := s.List(paths)
+	if err != nil {
+		return nil, err
+	}
+	var removed []string
+	for len(snapshots) > keep {
+		id := snapshots[0].ID
+		if err := os.RemoveAll(filepath.Join(paths.KanRoot(), SnapshotsDir, id)); err != nil {
+			return removed, fmt.Errorf("failed to prune snapshot %s: %w", id, err)
+		}
+		removed = append(removed, id)
+		snapshots = snapshots[1:]
+	}
+	return removed, nil
+}
+
+// Restore replaces the contents of the Kan data directory with the given
+// snapshot. The snapshot itself is kept, so a restore can be repeated.
+func (s *BackupService) Restore(paths *config.Paths, snapshotID string) error {
+	src, err := s.snapshotDir(paths, snapshotID)
+	if err != nil {
+		return err
+	}
+
+	root := paths.KanRoot()
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", root, err)
+	}
+	for _, entry := range entries {
+		if entry.Name() == SnapshotsDir || entry.Name() == config.LockFile {
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(root, entry.Name())); err != nil {
+			return fmt.Errorf("failed to remove %s: %w", entry.Name(), err)
+		}
+	}
+
+	snapshotEntries, err := os.ReadDir(src)
+	if err != nil {
+		return fmt.Errorf("failed to read snapshot %q: %w", snapshotID, err)
+	}
+	for _, entry := range snapshotEntries {
+		if err := copyDir(filepath.Join(src, entry.Name()), filepath.Join(root, entry.Name())); err != nil {
+			return fmt.Errorf("failed to restore %s: %w", entry.Name(), err)
+		}
+	}
+	return nil
+}
+
+// List returns the project's snapshots, oldest first.
+func (s *BackupService) List(paths *config.Paths) ([]SnapshotInfo, error) {
+	snapshotsRoot := filepath.Join(paths.KanRoot(), SnapshotsDir)
+	entries, err := os.ReadDir(snapshotsRoot)
+	if os.IsNotExist(err) {
+		return []SnapshotInfo{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshots: %w", err)
+	}
+
+	snapshots := []SnapshotInfo{}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		info := SnapshotInfo{
+			ID:           entry.Name(),
+			BoardSchemas: snapshotBoardSchemas(filepath.Join(snapshotsRoot, entry.Name())),
+		}
+		// Disambiguated IDs carry a "-N" suffix after the timestamp.
+		if len(info.ID) >= len(snapshotIDFormat) {
+			if t, err := time.Parse(snapshotIDFormat, info.ID[:len(snapshotIDFormat)]); err == nil {
+				info.CreatedAtMillis = t.UnixMilli()
+			}
+		}
+		snapshots = append(snapshots, info)
+	}
+	sort.Slice(snapshots, func(i, j int) bool { return snapshots[i].ID < snapshots[j].ID })
+	return snapshots, nil
+}
+
+// snapshotDir resolves a snapshot ID to its directory, rejecting IDs that
+// don't name an existing snapshot (including any path tricks).
+func (s *BackupService) snapshotDir(paths *config.Paths, snapshotID string) (string, error) {
+	if snapshotID == "" || snapshotID != filepath.Base(snapshotID) || snapshotID == "." || snapshotID == ".." {
+		return "", kanerr.InvalidField("snapshot", fmt.Sprintf("invalid snapshot ID %q", snapshotID))
+	}
+	dir := filepath.Join(paths.KanRoot(), SnapshotsDir, snapshotID)
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return "", kanerr.SnapshotNotFound(snapshotID)
+	}
+	return dir, nil
+}
+
+// snapshotBoardSchemas reads the kan_schema of each board config in a
+// snapshot. Unreadable configs are skipped.
+func snapshotBoardSchemas(snapshotDir string) map[string]string {
+	schemas := make(map[string]string)
+	boardsDir := filepath.Join(snapshotDir, config.BoardsDir)
+	entries, err := os.ReadDir(boardsDir)
+	if err != nil {
+		return schemas
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		var cfg struct {
+			KanSchema string `toml:"kan_schema"`
+		}
+		if _, err := toml.DecodeFile(filepath.Join(boardsDir, entry.Name(), config.ConfigFileName), &cfg); err != nil {
+			continue
+		}
+		schemas[entry.Name()] = cfg.KanSchema
+	}
+	return schemas
+}
+
+// copyDir recursively copies a directory tree.
+func copyDir(src, dst string) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relPath, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		dstPath := filepath.Join(dst, relPath)
+
+		if info.IsDir() {
+			return os.MkdirAll(dstPath, info.Mode())
+		}
+		// A migration lock belongs to the running migration, not the data:
+		// restoring one would leave the board locked.
+		if info.Name() == MigrateLockFile {
+			return nil
+		}
+
+		srcFile, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer srcFile.Close()
+
+		dstFile, err := os.Create(dstPath)
+		if err != nil {
+			return err
+		}
+		defer dstFile.Close()
+
+		_, err = io.Copy(dstFile, srcFile)
+		return err
+	})
+}
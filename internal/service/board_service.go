@@ -1,12 +1,19 @@
 package service
 
 import (
+	"encoding/json"
+	"fmt"
 	"regexp"
+	"slices"
+	"sort"
+	"strings"
 
 	kanerr "github.com/amterp/kan/internal/errors"
 	"github.com/amterp/kan/internal/id"
 	"github.com/amterp/kan/internal/model"
 	"github.com/amterp/kan/internal/store"
+	"github.com/amterp/kan/internal/util"
+	"github.com/amterp/kan/internal/version"
 )
 
 // columnNameRegex validates column names: lowercase alphanumeric and hyphens.
@@ -14,8 +21,10 @@ var columnNameRegex = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
 
 // BoardService handles board operations.
 type BoardService struct {
-	boardStore store.BoardStore
-	cardStore  store.CardStore
+	boardStore  store.BoardStore
+	cardStore   store.CardStore
+	cardService *CardService
+	globalStore store.GlobalStore
 }
 
 // NewBoardService creates a new board service.
@@ -26,10 +35,29 @@ func NewBoardService(boardStore store.BoardStore, cardStore store.CardStore) *Bo
 	}
 }
 
-// Create creates a new board with default columns.
-func (s *BoardService) Create(name string) error {
+// SetCardService sets the card service used to archive cards and move them
+// between boards. Required by RunAutoArchive and MoveCardAcrossBoards.
+func (s *BoardService) SetCardService(cardService *CardService) {
+	s.cardService = cardService
+}
+
+// SetGlobalStore sets the store new boards read the global default columns
+// and custom fields from. Without it, boards only get the built-in defaults.
+func (s *BoardService) SetGlobalStore(globalStore store.GlobalStore) {
+	s.globalStore = globalStore
+}
+
+// AppliedDefaults reports what a new board took from the global config.
+type AppliedDefaults struct {
+	Columns bool     // The board's columns are the global default_columns
+	Fields  []string // Custom fields taken from default_custom_fields, sorted
+}
+
+// Create creates a new board with default columns. Global default columns
+// replace the built-in ones, and global default custom fields are added on
+// top of the built-in fields, replacing any of the same name.
+func (s *BoardService) Create(name string) (*AppliedDefaults, error) {
 	cfg := &model.BoardConfig{
-		ID:            id.Generate(id.Board),
 		Name:          name,
 		Columns:       model.DefaultColumns(),
 		DefaultColumn: "backlog",
@@ -37,15 +65,159 @@ func (s *BoardService) Create(name string) error {
 		CardDisplay:   model.DefaultCardDisplay(),
 	}
 
+	defaults, err := s.globalDefaults()
+	if err != nil {
+		return nil, err
+	}
+	applied := &AppliedDefaults{}
+	if len(defaults.DefaultColumns) > 0 {
+		cfg.Columns = slices.Clone(defaults.DefaultColumns)
+		cfg.DefaultColumn = ""
+		applied.Columns = true
+	}
+	for fieldName, schema := range defaults.DefaultCustomFields {
+		cfg.CustomFields[fieldName] = schema
+		applied.Fields = append(applied.Fields, fieldName)
+	}
+	sort.Strings(applied.Fields)
+
+	// A global "type" field may not be an enum, which type_indicator requires.
+	if cfg.CustomFields[cfg.CardDisplay.TypeIndicator].FieldType() != model.FieldTypeEnum {
+		cfg.CardDisplay.TypeIndicator = ""
+	}
+
+	return applied, s.create(cfg)
+}
+
+// CreateWithConfig creates a board from a caller-built config, such as a CLI
+// template. The ID is always generated, and the default column falls back to
+// the first column when unset. Global default custom fields are added unless
+// cfg defines a field of the same name, and global default columns are used
+// only when cfg has no columns.
+func (s *BoardService) CreateWithConfig(cfg *model.BoardConfig) (*AppliedDefaults, error) {
+	defaults, err := s.globalDefaults()
+	if err != nil {
+		return nil, err
+	}
+	applied := &AppliedDefaults{}
+	if len(cfg.Columns) == 0 && len(defaults.DefaultColumns) > 0 {
+		cfg.Columns = slices.Clone(defaults.DefaultColumns)
+		applied.Columns = true
+	}
+	for fieldName, schema := range defaults.DefaultCustomFields {
+		if _, exists := cfg.CustomFields[fieldName]; exists {
+			continue
+		}
+		if cfg.CustomFields == nil {
+			cfg.CustomFields = make(map[string]model.CustomFieldSchema)
+		}
+		cfg.CustomFields[fieldName] = schema
+		applied.Fields = append(applied.Fields, fieldName)
+	}
+	sort.Strings(applied.Fields)
+
+	return applied, s.create(cfg)
+}
+
+// globalDefaults loads the global config holding the default columns and
+// custom fields for new boards.
+func (s *BoardService) globalDefaults() (*model.GlobalConfig, error) {
+	if s.globalStore == nil {
+		return &model.GlobalConfig{}, nil
+	}
+	return s.globalStore.Load()
+}
+
+// create validates a new board's config, generates its ID and saves it.
+func (s *BoardService) create(cfg *model.BoardConfig) error {
+	if cfg.Name == "" {
+		return kanerr.InvalidField("name", "cannot be empty")
+	}
+	if len(cfg.Columns) == 0 {
+		return kanerr.InvalidField("columns", "a board needs at least one column")
+	}
+	seen := make(map[string]bool, len(cfg.Columns))
+	for _, col := range cfg.Columns {
+		if !columnNameRegex.MatchString(col.Name) {
+			return kanerr.InvalidField("column name", fmt.Sprintf("%q must be lowercase alphanumeric with hyphens (e.g., 'in-progress')", col.Name))
+		}
+		if seen[col.Name] {
+			return kanerr.ColumnAlreadyExists(col.Name, cfg.Name)
+		}
+		seen[col.Name] = true
+	}
+	if cfg.DefaultColumn == "" {
+		cfg.DefaultColumn = cfg.Columns[0].Name
+	} else if !cfg.HasColumn(cfg.DefaultColumn) {
+		return kanerr.ColumnNotFound(cfg.DefaultColumn, cfg.Name)
+	}
+
+	cfg.ID = id.Generate(id.Board)
 	// Store.Create handles existence check and returns proper error
 	return s.boardStore.Create(cfg)
 }
 
-// List returns the names of all boards.
+// List returns the names of all active (non-archived) boards.
 func (s *BoardService) List() ([]string, error) {
 	return s.boardStore.List()
 }
 
+// ListAll returns the names of all boards, archived ones included.
+func (s *BoardService) ListAll() ([]string, error) {
+	return s.boardStore.ListAll()
+}
+
+// GetOrDefault returns the board to act on when boardName may be empty. It
+// tries, in order: boardName itself, the repo's default_board, then the most
+// recently modified board. An explicit board that doesn't exist is an error
+// rather than a reason to fall back; a stale default_board is skipped.
+func (s *BoardService) GetOrDefault(boardName, projectRoot string) (string, error) {
+	return s.GetOrDefaultFunc(boardName, projectRoot, func(boards []string) (string, error) {
+		if board := store.MostRecentBoard(s.boardStore, boards); board != "" {
+			return board, nil
+		}
+		return "", fmt.Errorf("no readable boards found")
+	})
+}
+
+// GetOrDefaultFunc is GetOrDefault with pick choosing among the active boards,
+// in place of the most recently modified one, when neither boardName nor a
+// usable default_board names a board. A single board is used without asking
+// pick.
+func (s *BoardService) GetOrDefaultFunc(boardName, projectRoot string, pick func(boards []string) (string, error)) (string, error) {
+	if boardName != "" {
+		if !s.boardStore.Exists(boardName) {
+			return "", kanerr.BoardNotFound(boardName)
+		}
+		return boardName, nil
+	}
+
+	boards, err := s.boardStore.List()
+	if err != nil {
+		return "", err
+	}
+	if len(boards) == 0 {
+		return "", fmt.Errorf("no boards found; run 'kan init' first")
+	}
+	if len(boards) == 1 {
+		return boards[0], nil
+	}
+
+	if s.globalStore != nil && projectRoot != "" {
+		globalCfg, err := s.globalStore.Load()
+		if err != nil {
+			return "", err
+		}
+		if repoCfg := globalCfg.GetRepoConfig(projectRoot); repoCfg != nil && repoCfg.DefaultBoard != "" {
+			if s.boardStore.Exists(repoCfg.DefaultBoard) {
+				return repoCfg.DefaultBoard, nil
+			}
+		}
+	}
+
+	return pick(boards)
+}
+
 // Get returns the board configuration.
 func (s *BoardService) Get(name string) (*model.BoardConfig, error) {
 	return s.boardStore.Get(name)
@@ -56,23 +228,418 @@ func (s *BoardService) Exists(name string) bool {
 	return s.boardStore.Exists(name)
 }
 
+// ArchiveBoard archives a board. Unlike DeleteBoard this is reversible: the
+// board's config and cards are kept, and UnarchiveBoard restores it.
+func (s *BoardService) ArchiveBoard(boardName string) error {
+	if err := s.requireOtherActiveBoard(boardName, "cannot archive the last active board"); err != nil {
+		return err
+	}
+	return s.boardStore.Archive(boardName)
+}
+
+// UnarchiveBoard restores an archived board.
+func (s *BoardService) UnarchiveBoard(boardName string) error {
+	return s.boardStore.Unarchive(boardName)
+}
+
+// RenameBoard renames a board. Cards and column names are unaffected; only the
+// board's directory and config name change.
+func (s *BoardService) RenameBoard(oldName, newName string) error {
+	if newName == "" {
+		return kanerr.InvalidField("name", "cannot be empty")
+	}
+	if strings.ContainsAny(newName, `/\`) || newName == "." || newName == ".." {
+		return kanerr.InvalidField("name", fmt.Sprintf("%q is not a valid board name", newName))
+	}
+	if newName == oldName {
+		return kanerr.InvalidField("name", fmt.Sprintf("board is already named %q", oldName))
+	}
+	return s.boardStore.Rename(oldName, newName)
+}
+
+// CardTransferResult describes a card moved by MoveCardAcrossBoards.
+type CardTransferResult struct {
+	Card *model.Card
+	// DroppedFields lists, sorted, the custom fields cleared because the
+	// destination board doesn't define them or rejects their value.
+	DroppedFields []string
+	// AliasChanged is set when the card's alias was taken on the destination
+	// board and a new one was generated.
+	AliasChanged bool
+	// Unlinked holds the source-board cards that referenced the moved card,
+	// as rewritten without the reference.
+	Unlinked []*model.Card
+}
+
+// MoveCardAcrossBoards moves a card to dstColumn on another board, keeping its
+// ID. Card references are board-local, so the card's parent and blockers are
+// cleared, as are references to it from cards left on the source board. An
+// empty dstColumn means the destination's default column.
+func (s *BoardService) MoveCardAcrossBoards(srcBoard, dstBoard, cardID, dstColumn string) (*CardTransferResult, error) {
+	if s.cardService == nil {
+		return nil, fmt.Errorf("moving cards between boards needs a card service")
+	}
+	if srcBoard == dstBoard {
+		return nil, kanerr.InvalidField("target_board", "card is already on this board")
+	}
+	if _, err := s.boardStore.Get(srcBoard); err != nil {
+		return nil, err
+	}
+	dstCfg, err := s.boardStore.Get(dstBoard)
+	if err != nil {
+		return nil, err
+	}
+	card, err := s.cardStore.Get(srcBoard, cardID)
+	if err != nil {
+		return nil, err
+	}
+	if card.Archived {
+		return nil, kanerr.InvalidField("card", "card is archived; unarchive it first")
+	}
+	if _, err := s.cardStore.Get(dstBoard, card.ID); err == nil {
+		return nil, kanerr.CardAlreadyExists(card.ID, dstBoard)
+	}
+
+	if dstColumn == "" {
+		dstColumn = dstCfg.DefaultColumn
+	}
+	if !dstCfg.HasColumn(dstColumn) {
+		return nil, kanerr.ColumnNotFound(dstColumn, dstBoard)
+	}
+	dstCards, err := s.cardStore.List(dstBoard, false)
+	if err != nil {
+		return nil, err
+	}
+	colCards := cardsInColumnExcluding(dstCards, dstColumn, card.ID)
+	if dstCfg.IsAtCapacity(dstColumn, len(colCards)) {
+		return nil, kanerr.ColumnAtLimit(dstColumn, dstCfg.GetColumn(dstColumn).Limit)
+	}
+
+	result := &CardTransferResult{Card: card, DroppedFields: s.reapplyFields(card, dstCfg)}
+
+	if card.Alias != "" && !s.cardService.aliasService.IsAliasAvailable(dstBoard, card.Alias, card.ID) {
+		alias, err := s.cardService.aliasService.GenerateAlias(dstBoard, card.Title, card.ID)
+		if err != nil {
+			return nil, err
+		}
+		card.Alias = alias
+		card.AliasExplicit = false
+		result.AliasChanged = true
+	}
+
+	card.Parent = ""
+	card.Blocks = nil
+	card.BlockedBy = nil
+	card.Column = dstColumn
+	card.Position = computePosition(colCards, len(colCards))
+	card.UpdatedAtMillis = util.NowMillis()
+	card.History = append(card.History, model.HistoryEntry{
+		Field: "column", Value: dstColumn, At: card.UpdatedAtMillis,
+	})
+	stampDone(card, dstCfg, card.UpdatedAtMillis)
+
+	// Write the destination copy first, so a failure part-way leaves the card
+	// on both boards rather than on neither.
+	if err := s.cardStore.Create(dstBoard, card); err != nil {
+		return nil, err
+	}
+	if err := s.cardStore.Delete(srcBoard, card.ID); err != nil {
+		return nil, err
+	}
+	if result.Unlinked, err = s.unlinkCards(srcBoard, map[string]bool{card.ID: true}); err != nil {
+		return nil, err
+	}
+	s.cardService.recordAudit(srcBoard, card.ID, model.AuditActionDeleted, map[string]any{"to_board": dstBoard})
+	s.cardService.recordAudit(dstBoard, card.ID, model.AuditActionCreated, map[string]any{"from_board": srcBoard})
+	return result, nil
+}
+
+// reapplyFields re-applies card's custom fields through dstCfg's schema, one
+// at a time, so a field it rejects is dropped rather than failing a move
+// between boards. It returns the dropped field names, sorted.
+func (s *BoardService) reapplyFields(card *model.Card, dstCfg *model.BoardConfig) []string {
+	var dropped []string
+	kept := &model.Card{}
+	for name, value := range card.CustomFields {
+		if _, defined := dstCfg.CustomFields[name]; defined && value != nil {
+			err := s.cardService.validateAndApplyCustomFields(kept, dstCfg, map[string]string{name: formatCustomFieldValue(value)})
+			if err == nil {
+				continue
+			}
+			delete(kept.CustomFields, name)
+		}
+		dropped = append(dropped, name)
+	}
+	sort.Strings(dropped)
+	card.CustomFields = kept.CustomFields
+	return dropped
+}
+
+// Alias conflict strategies for MergeOptions.ConflictAlias.
+const (
+	MergeAliasRegenerate = "regenerate"
+	MergeAliasSkip       = "skip"
+)
+
+// MergeOptions controls how MergeBoards moves cards.
+type MergeOptions struct {
+	// ColumnMapping maps source column names to destination column names.
+	// Cards in a source column it leaves out go to the destination column of
+	// the same name.
+	ColumnMapping map[string]string
+	// ConflictAlias is what happens to a card whose alias is taken on the
+	// destination: MergeAliasRegenerate (the default) gives it a new alias,
+	// MergeAliasSkip leaves it on the source board.
+	ConflictAlias string
+	// DeleteSource deletes the source board once all its cards have moved.
+	// It's ignored when cards were skipped.
+	DeleteSource bool
+}
+
+// MergeResult describes a MergeBoards run.
+type MergeResult struct {
+	Moved            int
+	Skipped          int
+	AliasRegenerated int
+	// DroppedFields lists, sorted, the custom fields cleared on some card
+	// because the destination board doesn't define them or rejects the value.
+	DroppedFields []string
+	SourceDeleted bool
+	// MovedCards holds the moved cards as written to the destination board.
+	MovedCards []*model.Card
+	// Unlinked holds the skipped cards that referenced a moved card, as
+	// rewritten on the source board without the reference.
+	Unlinked []*model.Card
+}
+
+// MergeBoards moves every card, archived ones included, from srcBoard to
+// dstBoard, keeping their IDs and their order within each column. Parent and
+// blocker references between merged cards are kept; references to cards that
+// stay behind are cleared. Everything that can be checked up front (columns,
+// ID collisions, WIP limits) is, so a refused merge moves nothing.
+func (s *BoardService) MergeBoards(srcBoard, dstBoard string, opts MergeOptions) (*MergeResult, error) {
+	if s.cardService == nil {
+		return nil, fmt.Errorf("merging boards needs a card service")
+	}
+	if srcBoard == dstBoard {
+		return nil, kanerr.InvalidField("target", "cannot merge a board into itself")
+	}
+	switch opts.ConflictAlias {
+	case "":
+		opts.ConflictAlias = MergeAliasRegenerate
+	case MergeAliasRegenerate, MergeAliasSkip:
+	default:
+		return nil, kanerr.InvalidField("conflict_alias",
+			fmt.Sprintf("must be %q or %q, got %q", MergeAliasRegenerate, MergeAliasSkip, opts.ConflictAlias))
+	}
+
+	srcCfg, err := s.boardStore.Get(srcBoard)
+	if err != nil {
+		return nil, err
+	}
+	dstCfg, err := s.boardStore.Get(dstBoard)
+	if err != nil {
+		return nil, err
+	}
+
+	targets := make(map[string]string, len(srcCfg.Columns))
+	for from, to := range opts.ColumnMapping {
+		if !srcCfg.HasColumn(from) {
+			return nil, kanerr.ColumnNotFound(from, srcBoard)
+		}
+		if !dstCfg.HasColumn(to) {
+			return nil, kanerr.ColumnNotFound(to, dstBoard)
+		}
+		targets[from] = to
+	}
+
+	cards, err := s.cardStore.List(srcBoard, true)
+	if err != nil {
+		return nil, err
+	}
+	dstCards, err := s.cardStore.List(dstBoard, true)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &MergeResult{}
+	moving := make(map[string]bool, len(cards))
+	added := make(map[string]int)
+	for _, card := range cards {
+		if _, err := s.cardStore.Get(dstBoard, card.ID); err == nil {
+			return nil, kanerr.CardAlreadyExists(card.ID, dstBoard)
+		}
+		if opts.ConflictAlias == MergeAliasSkip && card.Alias != "" &&
+			!s.cardService.aliasService.IsAliasAvailable(dstBoard, card.Alias, card.ID) {
+			result.Skipped++
+			continue
+		}
+		if _, mapped := targets[card.Column]; !mapped {
+			if !dstCfg.HasColumn(card.Column) {
+				return nil, kanerr.InvalidField("column_mapping",
+					fmt.Sprintf("column %q has no mapping and board %s has no column of that name", card.Column, dstBoard))
+			}
+			targets[card.Column] = card.Column
+		}
+		moving[card.ID] = true
+		if !card.Archived {
+			added[targets[card.Column]]++
+		}
+	}
+	for column, n := range added {
+		if dstCfg.IsAtCapacity(column, countActiveInColumn(dstCards, column)+n-1) {
+			return nil, kanerr.ColumnAtLimit(column, dstCfg.GetColumn(column).Limit)
+		}
+	}
+
+	// Keep each column's cards in order, and source columns in board order.
+	sort.SliceStable(cards, func(i, j int) bool {
+		ci, cj := srcCfg.GetColumnIndex(cards[i].Column), srcCfg.GetColumnIndex(cards[j].Column)
+		if ci != cj {
+			return ci < cj
+		}
+		if cards[i].Position != cards[j].Position {
+			return cards[i].Position < cards[j].Position
+		}
+		return cards[i].ID < cards[j].ID
+	})
+
+	columns := make(map[string][]*model.Card)
+	dropped := make(map[string]bool)
+	for _, card := range cards {
+		if !moving[card.ID] {
+			continue
+		}
+		column := targets[card.Column]
+		if _, ok := columns[column]; !ok {
+			columns[column] = cardsInColumnExcluding(dstCards, column, "")
+		}
+
+		for _, name := range s.reapplyFields(card, dstCfg) {
+			dropped[name] = true
+		}
+		if card.Alias != "" && !s.cardService.aliasService.IsAliasAvailable(dstBoard, card.Alias, card.ID) {
+			alias, err := s.cardService.aliasService.GenerateAlias(dstBoard, card.Title, card.ID)
+			if err != nil {
+				return nil, err
+			}
+			card.Alias = alias
+			card.AliasExplicit = false
+			result.AliasRegenerated++
+		}
+
+		if !moving[card.Parent] {
+			card.Parent = ""
+		}
+		card.Blocks = slices.DeleteFunc(card.Blocks, func(id string) bool { return !moving[id] })
+		card.BlockedBy = slices.DeleteFunc(card.BlockedBy, func(id string) bool { return !moving[id] })
+		card.Column = column
+		card.Position = computePosition(columns[column], len(columns[column]))
+		columns[column] = append(columns[column], card)
+		card.UpdatedAtMillis = util.NowMillis()
+		card.History = append(card.History, model.HistoryEntry{
+			Field: "column", Value: column, At: card.UpdatedAtMillis,
+		})
+		stampDone(card, dstCfg, card.UpdatedAtMillis)
+
+		if err := s.cardStore.Create(dstBoard, card); err != nil {
+			return nil, err
+		}
+		if err := s.cardStore.Delete(srcBoard, card.ID); err != nil {
+			return nil, err
+		}
+		s.cardService.recordAudit(srcBoard, card.ID, model.AuditActionDeleted, map[string]any{"to_board": dstBoard})
+		s.cardService.recordAudit(dstBoard, card.ID, model.AuditActionCreated, map[string]any{"from_board": srcBoard})
+		result.Moved++
+		result.MovedCards = append(result.MovedCards, card)
+	}
+	for name := range dropped {
+		result.DroppedFields = append(result.DroppedFields, name)
+	}
+	sort.Strings(result.DroppedFields)
+
+	if result.Skipped > 0 {
+		result.Unlinked, err = s.unlinkCards(srcBoard, moving)
+		return result, err
+	}
+	if opts.DeleteSource {
+		if _, err := s.DeleteBoard(srcBoard); err != nil {
+			return result, err
+		}
+		result.SourceDeleted = true
+	}
+	return result, nil
+}
+
+// countActiveInColumn counts the unarchived cards in a column.
+func countActiveInColumn(cards []*model.Card, column string) int {
+	n := 0
+	for _, c := range cards {
+		if c.Column == column && !c.Archived {
+			n++
+		}
+	}
+	return n
+}
+
+// unlinkCards removes references to the given cards (as parent or blocker)
+// from the cards on a board, returning the cards it changed.
+func (s *BoardService) unlinkCards(boardName string, ids map[string]bool) ([]*model.Card, error) {
+	cards, err := s.cardStore.List(boardName, true)
+	if err != nil {
+		return nil, err
+	}
+	var unlinked []*model.Card
+	for _, c := range cards {
+		changed := false
+		if ids[c.Parent] {
+			c.Parent = ""
+			changed = true
+		}
+		isUnlinked := func(id string) bool {
+			if ids[id] {
+				changed = true
+			}
+			return ids[id]
+		}
+		c.Blocks = slices.DeleteFunc(c.Blocks, isUnlinked)
+		c.BlockedBy = slices.DeleteFunc(c.BlockedBy, isUnlinked)
+		if changed {
+			if err := s.cardStore.Update(boardName, c); err != nil {
+				return nil, err
+			}
+			unlinked = append(unlinked, c)
+		}
+	}
+	return unlinked, nil
+}
+
+// requireOtherActiveBoard returns a validation error with msg if boardName is
+// the only active board, so a project always keeps one board to work in.
+func (s *BoardService) requireOtherActiveBoard(boardName, msg string) error {
+	boards, err := s.boardStore.List()
+	if err != nil {
+		return err
+	}
+	if len(boards) <= 1 && slices.Contains(boards, boardName) {
+		return kanerr.InvalidField("board", msg)
+	}
+	return nil
+}
+
 // DeleteBoard deletes a board and all its cards.
 // Returns the total number of cards that were in the board (best-effort count;
 // if the board config can't be read, e.g. due to an outdated schema, deletion
 // proceeds and the count is reported as 0).
 func (s *BoardService) DeleteBoard(boardName string) (int, error) {
-	// Prevent deleting the last board
-	boards, err := s.boardStore.List()
-	if err != nil {
+	// Prevent deleting the last active board (archived boards don't count)
+	if err := s.requireOtherActiveBoard(boardName, "cannot delete the last remaining board"); err != nil {
 		return 0, err
 	}
-	if len(boards) <= 1 {
-		return 0, kanerr.InvalidField("board", "cannot delete the last remaining board")
-	}
 
 	// Count cards best-effort; errors shouldn't block deletion
 	totalCards := 0
-	cards, err := s.cardStore.List(boardName)
+	cards, err := s.cardStore.List(boardName, true)
 	if err == nil {
 		totalCards = len(cards)
 	}
@@ -115,39 +682,40 @@ func (s *BoardService) AddColumn(boardName, columnName, color, description strin
 }
 
 // DeleteColumn removes a column and all its cards.
-// Returns the number of cards deleted.
-func (s *BoardService) DeleteColumn(boardName, columnName string) (int, error) {
+// Returns the cards that were deleted.
+func (s *BoardService) DeleteColumn(boardName, columnName string) ([]*model.Card, error) {
 	cfg, err := s.boardStore.Get(boardName)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
 	// Cannot delete the last column
 	if len(cfg.Columns) <= 1 {
-		return 0, kanerr.InvalidField("column", "cannot delete the last remaining column")
+		return nil, kanerr.InvalidField("column", "cannot delete the last remaining column")
 	}
 
 	// Cannot delete the default column
 	if cfg.DefaultColumn == columnName {
-		return 0, kanerr.InvalidField("column", "cannot delete the default column; change default_column first")
+		return nil, kanerr.InvalidField("column", "cannot delete the default column; change default_column first")
 	}
 
 	// Check column exists
 	if !cfg.HasColumn(columnName) {
-		return 0, kanerr.ColumnNotFound(columnName, boardName)
+		return nil, kanerr.ColumnNotFound(columnName, boardName)
 	}
 
 	// Find and delete all cards in the column
-	allCards, err := s.cardStore.List(boardName)
+	allCards, err := s.cardStore.List(boardName, false)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
-	deletedCount := 0
+	var deleted []*model.Card
 	for _, card := range allCards {
 		if card.Column == columnName {
-			_ = s.cardStore.Delete(boardName, card.ID)
-			deletedCount++
+			if err := s.cardStore.Delete(boardName, card.ID); err == nil {
+				deleted = append(deleted, card)
+			}
 		}
 	}
 
@@ -155,10 +723,10 @@ func (s *BoardService) DeleteColumn(boardName, columnName string) (int, error) {
 	cfg.RemoveColumn(columnName)
 
 	if err := s.boardStore.Update(cfg); err != nil {
-		return deletedCount, err
+		return deleted, err
 	}
 
-	return deletedCount, nil
+	return deleted, nil
 }
 
 // RenameColumn renames a column.
@@ -185,16 +753,24 @@ func (s *BoardService) RenameColumn(boardName, oldName, newName string) error {
 
 	// Update card files first - the old column name is still valid in the
 	// board config at this point, so a failure here leaves data consistent.
-	cards, err := s.cardStore.List(boardName)
+	// Archived cards are included so their LastColumn keeps pointing at a real
+	// column for a later unarchive.
+	cards, err := s.cardStore.List(boardName, true)
 	if err != nil {
 		return err
 	}
 	for _, card := range cards {
+		if card.Column != oldName && card.LastColumn != oldName {
+			continue
+		}
 		if card.Column == oldName {
 			card.Column = newName
-			if err := s.cardStore.Update(boardName, card); err != nil {
-				return err
-			}
+		}
+		if card.LastColumn == oldName {
+			card.LastColumn = newName
+		}
+		if err := s.cardStore.Update(boardName, card); err != nil {
+			return err
 		}
 	}
 
@@ -250,6 +826,71 @@ func (s *BoardService) UpdateColumnLimit(boardName, columnName string, limit int
 	return s.boardStore.Update(cfg)
 }
 
+// DefaultSprintDays is how long a sprint runs when no duration is given.
+const DefaultSprintDays = 14
+
+// SetSprint time-boxes a column as a sprint running from startMillis to
+// endMillis. Passing 0 for both clears the column's sprint.
+func (s *BoardService) SetSprint(boardName, columnName string, startMillis, endMillis int64) error {
+	if startMillis != 0 || endMillis != 0 {
+		if startMillis <= 0 || endMillis <= startMillis {
+			return kanerr.InvalidField("sprint", "end must be after start")
+		}
+	}
+
+	cfg, err := s.boardStore.Get(boardName)
+	if err != nil {
+		return err
+	}
+
+	if !cfg.SetColumnSprint(columnName, startMillis, endMillis) {
+		return kanerr.ColumnNotFound(columnName, boardName)
+	}
+
+	return s.boardStore.Update(cfg)
+}
+
+// StartSprint starts a sprint in the column now, running for durationDays
+// (DefaultSprintDays if 0), and returns the updated column.
+func (s *BoardService) StartSprint(boardName, columnName string, durationDays int) (*model.Column, error) {
+	if durationDays < 0 {
+		return nil, kanerr.InvalidField("duration_days", "must be a positive number of days")
+	}
+	if durationDays == 0 {
+		durationDays = DefaultSprintDays
+	}
+
+	start := util.NowMillis()
+	end := start + int64(durationDays)*util.MillisPerDay
+	if err := s.SetSprint(boardName, columnName, start, end); err != nil {
+		return nil, err
+	}
+
+	cfg, err := s.boardStore.Get(boardName)
+	if err != nil {
+		return nil, err
+	}
+	return cfg.GetColumn(columnName), nil
+}
+
+// GetActiveSprint returns the column whose sprint covers now, the first in
+// board order if several do. Returns a SPRINT_NOT_FOUND error if none does.
+func (s *BoardService) GetActiveSprint(boardName string) (*model.Column, error) {
+	cfg, err := s.boardStore.Get(boardName)
+	if err != nil {
+		return nil, err
+	}
+
+	now := util.NowMillis()
+	for i := range cfg.Columns {
+		if cfg.Columns[i].IsSprintActive(now) {
+			col := cfg.Columns[i]
+			return &col, nil
+		}
+	}
+	return nil, kanerr.NoActiveSprint(boardName)
+}
+
 // ReorderColumn moves a column to a new position (0-indexed).
 func (s *BoardService) ReorderColumn(boardName, columnName string, newPosition int) error {
 	cfg, err := s.boardStore.Get(boardName)
@@ -309,8 +950,191 @@ func (s *BoardService) ReorderColumns(boardName string, columnNames []string) er
 	return s.boardStore.Update(cfg)
 }
 
-// GetColumnCardCount returns the number of cards in a column.
-func (s *BoardService) GetColumnCardCount(boardName, columnName string) (int, error) {
+// AddCustomField adds a custom field to a board. Enum and enum-set fields
+// need at least one option.
+func (s *BoardService) AddCustomField(boardName, fieldName string, schema model.CustomFieldSchema) error {
+	if err := model.ValidateCustomFieldName(fieldName); err != nil {
+		return err
+	}
+	if !model.IsValidFieldType(schema.Type) {
+		return kanerr.InvalidField("field type", fmt.Sprintf("unknown type %q", schema.Type))
+	}
+	if err := validateFieldOptions(schema); err != nil {
+		return err
+	}
+
+	cfg, err := s.boardStore.Get(boardName)
+	if err != nil {
+		return err
+	}
+	if _, exists := cfg.CustomFields[fieldName]; exists {
+		return kanerr.FieldAlreadyExists(fieldName, boardName)
+	}
+
+	if cfg.CustomFields == nil {
+		cfg.CustomFields = make(map[string]model.CustomFieldSchema)
+	}
+	cfg.CustomFields[fieldName] = schema
+	return s.boardStore.Update(cfg)
+}
+
+// UpdateFieldOptions adds and removes options of an enum or enum-set field.
+// Removals run first. Cards that use a removed option keep their value.
+func (s *BoardService) UpdateFieldOptions(boardName, fieldName string, add []model.CustomFieldOption, remove []string) error {
+	cfg, err := s.boardStore.Get(boardName)
+	if err != nil {
+		return err
+	}
+	schema, exists := cfg.CustomFields[fieldName]
+	if !exists {
+		return kanerr.FieldNotFound(fieldName, boardName)
+	}
+	if !fieldHasOptions(schema.FieldType()) {
+		return kanerr.InvalidField("field", fmt.Sprintf("%q is a %s field; only enum and enum-set fields have options", fieldName, schema.FieldType()))
+	}
+
+	for _, value := range remove {
+		i := slices.IndexFunc(schema.Options, func(o model.CustomFieldOption) bool { return o.Value == value })
+		if i < 0 {
+			return kanerr.InvalidField("option", fmt.Sprintf("%q is not an option of %q", value, fieldName))
+		}
+		schema.Options = slices.Delete(schema.Options, i, i+1)
+	}
+	for _, opt := range add {
+		if slices.ContainsFunc(schema.Options, func(o model.CustomFieldOption) bool { return o.Value == opt.Value }) {
+			return kanerr.InvalidField("option", fmt.Sprintf("%q is already an option of %q", opt.Value, fieldName))
+		}
+		schema.Options = append(schema.Options, opt)
+	}
+	if err := validateFieldOptions(schema); err != nil {
+		return err
+	}
+
+	cfg.CustomFields[fieldName] = schema
+	return s.boardStore.Update(cfg)
+}
+
+// DeleteCustomField removes a custom field from a board and clears its value
+// from every card, archived ones included. References from card_display and
+// transition rules are dropped too. Returns the number of cards cleared.
+func (s *BoardService) DeleteCustomField(boardName, fieldName string) (int, error) {
+	cfg, err := s.boardStore.Get(boardName)
+	if err != nil {
+		return 0, err
+	}
+	if !cfg.RemoveCustomField(fieldName) {
+		return 0, kanerr.FieldNotFound(fieldName, boardName)
+	}
+
+	// Clear cards first: a value for a field that is still defined is
+	// harmless if the config update below fails.
+	cards, err := s.cardStore.List(boardName, true)
+	if err != nil {
+		return 0, err
+	}
+	cleared := 0
+	for _, card := range cards {
+		if _, ok := card.CustomFields[fieldName]; !ok {
+			continue
+		}
+		delete(card.CustomFields, fieldName)
+		if err := s.cardStore.Update(boardName, card); err != nil {
+			return cleared, err
+		}
+		cleared++
+	}
+
+	return cleared, s.boardStore.Update(cfg)
+}
+
+// UpdateCollaborators removes and then adds collaborators, the values user
+// fields accept, in one config write. Removing a collaborator that cards still
+// name in a user field is allowed; the returned map counts those cards, archived
+// ones included, for each such collaborator.
+func (s *BoardService) UpdateCollaborators(boardName string, add, remove []string) (map[string]int, error) {
+	cfg, err := s.boardStore.Get(boardName)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range remove {
+		i := slices.Index(cfg.Collaborators, name)
+		if i < 0 {
+			return nil, kanerr.InvalidField("collaborator", fmt.Sprintf("%q is not a collaborator on board %q", name, boardName))
+		}
+		cfg.Collaborators = slices.Delete(cfg.Collaborators, i, i+1)
+	}
+	for _, name := range add {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return nil, kanerr.InvalidField("collaborator", "cannot be empty")
+		}
+		if slices.Contains(cfg.Collaborators, name) {
+			return nil, kanerr.InvalidField("collaborator", fmt.Sprintf("%q is already a collaborator on board %q", name, boardName))
+		}
+		cfg.Collaborators = append(cfg.Collaborators, name)
+	}
+
+	stillAssigned := make(map[string]int)
+	if len(remove) > 0 {
+		cards, err := s.cardStore.List(boardName, true)
+		if err != nil {
+			return nil, err
+		}
+		for _, card := range cards {
+			for name, schema := range cfg.CustomFields {
+				if schema.FieldType() != model.FieldTypeUser {
+					continue
+				}
+				if value, ok := card.CustomFields[name].(string); ok && slices.Contains(remove, value) {
+					stillAssigned[value]++
+				}
+			}
+		}
+	}
+
+	return stillAssigned, s.boardStore.Update(cfg)
+}
+
+// AddCollaborator adds a collaborator to a board.
+func (s *BoardService) AddCollaborator(boardName, username string) error {
+	_, err := s.UpdateCollaborators(boardName, []string{username}, nil)
+	return err
+}
+
+// RemoveCollaborator removes a collaborator from a board. Cards that name
+// them in a user field keep the value; the number of such cards is returned
+// so callers can warn about it.
+func (s *BoardService) RemoveCollaborator(boardName, username string) (int, error) {
+	stillAssigned, err := s.UpdateCollaborators(boardName, nil, []string{username})
+	return stillAssigned[username], err
+}
+
+// fieldHasOptions reports whether a field type takes a fixed list of options.
+func fieldHasOptions(fieldType string) bool {
+	return fieldType == model.FieldTypeEnum || fieldType == model.FieldTypeEnumSet
+}
+
+// validateFieldOptions checks that enum and enum-set fields have at least one
+// option, and that other types have none.
+func validateFieldOptions(schema model.CustomFieldSchema) error {
+	hasOptions := fieldHasOptions(schema.Type)
+	if hasOptions && len(schema.Options) == 0 {
+		return kanerr.InvalidField("options", fmt.Sprintf("%s fields need at least one option", schema.Type))
+	}
+	if !hasOptions && len(schema.Options) > 0 && schema.Type != model.FieldTypeInteger {
+		return kanerr.InvalidField("options", fmt.Sprintf("%s fields don't take options", schema.Type))
+	}
+	for _, opt := range schema.Options {
+		if opt.Value == "" {
+			return kanerr.InvalidField("options", "option values can't be empty")
+		}
+	}
+	return nil
+}
+
+// GetCardCount returns the number of active cards in a column.
+func (s *BoardService) GetCardCount(boardName, columnName string) (int, error) {
 	cfg, err := s.boardStore.Get(boardName)
 	if err != nil {
 		return 0, err
@@ -320,7 +1144,7 @@ func (s *BoardService) GetColumnCardCount(boardName, columnName string) (int, er
 		return 0, kanerr.ColumnNotFound(columnName, boardName)
 	}
 
-	cards, err := s.cardStore.List(boardName)
+	cards, err := s.cardStore.List(boardName, false)
 	if err != nil {
 		return 0, err
 	}
@@ -333,3 +1157,555 @@ func (s *BoardService) GetColumnCardCount(boardName, columnName string) (int, er
 	}
 	return count, nil
 }
+
+// ColumnSummary describes how full a column is.
+type ColumnSummary struct {
+	Name      string
+	CardCount int
+	Limit     int // 0 = no limit
+	// PercentFull is CardCount as a percentage of Limit (it can exceed 100
+	// for columns filled past their limit), or 0 for a column with no limit.
+	PercentFull float64
+	AtLimit     bool
+}
+
+// GetColumnSummary returns a summary of each column, in board column order.
+// Archived cards don't count.
+func (s *BoardService) GetColumnSummary(boardName string) ([]ColumnSummary, error) {
+	cfg, err := s.boardStore.Get(boardName)
+	if err != nil {
+		return nil, err
+	}
+
+	cards, err := s.cardStore.List(boardName, false)
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[string]int)
+	for _, card := range cards {
+		counts[card.Column]++
+	}
+
+	summaries := make([]ColumnSummary, len(cfg.Columns))
+	for i, col := range cfg.Columns {
+		summary := ColumnSummary{
+			Name:      col.Name,
+			CardCount: counts[col.Name],
+			Limit:     col.Limit,
+			AtLimit:   col.IsAtLimit(counts[col.Name]),
+		}
+		if col.Limit > 0 {
+			summary.PercentFull = float64(summary.CardCount) / float64(col.Limit) * 100
+		}
+		summaries[i] = summary
+	}
+	return summaries, nil
+}
+
+// defaultStatsWindowMillis is the stats window used when no start is given.
+const defaultStatsWindowMillis = 30 * util.MillisPerDay
+
+// ColumnStats summarizes the active cards in one column.
+type ColumnStats struct {
+	Name  string
+	Count int
+	// AvgAgeMillis is the mean of UpdatedAtMillis - CreatedAtMillis over
+	// the column's cards, or 0 for an empty column.
+	AvgAgeMillis int64
+	// Oldest and Newest are the column's earliest and latest created cards,
+	// nil for an empty column.
+	Oldest *model.Card
+	Newest *model.Card
+}
+
+// BoardStats summarizes a board's active cards. A card is done if its column
+// is a done column (see BoardConfig.IsDoneColumn). Column transitions aren't
+// recorded, so a card's cycle time is approximated by the time between its
+// creation and its last update. Throughput also counts archived cards that
+// were archived from a done column.
+type BoardStats struct {
+	Columns    []ColumnStats // in board column order
+	TotalCards int
+	DoneCount  int
+
+	SinceMillis int64
+	UntilMillis int64
+	// CreatedInWindow counts cards created within [SinceMillis, UntilMillis].
+	CreatedInWindow int
+	// CompletedInWindow counts done cards, archived ones included, that got to
+	// their done column within the window (or, when that isn't recorded, were
+	// last updated within it).
+	CompletedInWindow int
+	// AvgCycleTimeMillis is the mean approximate cycle time of done cards.
+	AvgCycleTimeMillis int64
+	// ThroughputPerDay is CompletedInWindow divided by the window length in days.
+	ThroughputPerDay float64
+}
+
+// Statistics computes card counts, cycle time and throughput for a board.
+// A zero until means now; a zero since means 30 days before until.
+func (s *BoardService) Statistics(boardName string, since, until int64) (*BoardStats, error) {
+	if until == 0 {
+		until = util.NowMillis()
+	}
+	if since == 0 {
+		since = until - defaultStatsWindowMillis
+	}
+	if since > until {
+		return nil, kanerr.InvalidField("since", "must not be after until")
+	}
+
+	cfg, err := s.boardStore.Get(boardName)
+	if err != nil {
+		return nil, err
+	}
+	cards, err := s.cardStore.List(boardName, true)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &BoardStats{
+		Columns:     make([]ColumnStats, len(cfg.Columns)),
+		SinceMillis: since,
+		UntilMillis: until,
+	}
+	index := make(map[string]int, len(cfg.Columns))
+	ageSums := make([]int64, len(cfg.Columns))
+	for i, col := range cfg.Columns {
+		stats.Columns[i].Name = col.Name
+		index[col.Name] = i
+	}
+
+	completedInWindow := func(card *model.Card) bool {
+		at := doneSince(card)
+		if at == 0 {
+			at = card.UpdatedAtMillis
+		}
+		return at >= since && at <= until
+	}
+
+	var cycleSum int64
+	for _, card := range cards {
+		if card.Archived {
+			// Done cards auto-archived within the window were still finished
+			// within it.
+			if cfg.IsDoneColumn(card.LastColumn) && completedInWindow(card) {
+				stats.CompletedInWindow++
+			}
+			continue
+		}
+		stats.TotalCards++
+
+		age := max(card.UpdatedAtMillis-card.CreatedAtMillis, 0)
+		if i, ok := index[card.Column]; ok {
+			col := &stats.Columns[i]
+			col.Count++
+			ageSums[i] += age
+			if col.Oldest == nil || card.CreatedAtMillis < col.Oldest.CreatedAtMillis {
+				col.Oldest = card
+			}
+			if col.Newest == nil || card.CreatedAtMillis > col.Newest.CreatedAtMillis {
+				col.Newest = card
+			}
+		}
+		if card.CreatedAtMillis >= since && card.CreatedAtMillis <= until {
+			stats.CreatedInWindow++
+		}
+		if cfg.IsDoneColumn(card.Column) {
+			stats.DoneCount++
+			cycleSum += age
+			if completedInWindow(card) {
+				stats.CompletedInWindow++
+			}
+		}
+	}
+
+	for i := range stats.Columns {
+		if n := stats.Columns[i].Count; n > 0 {
+			stats.Columns[i].AvgAgeMillis = ageSums[i] / int64(n)
+		}
+	}
+	if stats.DoneCount > 0 {
+		stats.AvgCycleTimeMillis = cycleSum / int64(stats.DoneCount)
+	}
+	if window := until - since; window > 0 {
+		stats.ThroughputPerDay = float64(stats.CompletedInWindow) / (float64(window) / float64(util.MillisPerDay))
+	}
+	return stats, nil
+}
+
+// ColumnStat is one column's entry in CompletionStats.
+type ColumnStat struct {
+	Name      string
+	CardCount int
+	DoneCount int
+	// CompletionPct is DoneCount as a percentage of CardCount (0 for an
+	// empty column).
+	CompletionPct float64
+}
+
+// CompletionStats reports how many of a board's active cards are done. A
+// card is done if its column is a done column (see BoardConfig.IsDoneColumn).
+type CompletionStats struct {
+	ColumnStats   []ColumnStat // in board column order
+	TotalCards    int
+	TotalDone     int
+	CompletionPct float64
+}
+
+// CompletionStats computes per-column and overall completion for a board's
+// active cards. Cards in columns the board doesn't define are ignored.
+func (s *BoardService) CompletionStats(boardName string) (*CompletionStats, error) {
+	cfg, err := s.boardStore.Get(boardName)
+	if err != nil {
+		return nil, err
+	}
+	cards, err := s.cardStore.List(boardName, false)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &CompletionStats{ColumnStats: make([]ColumnStat, len(cfg.Columns))}
+	index := make(map[string]int, len(cfg.Columns))
+	for i, col := range cfg.Columns {
+		stats.ColumnStats[i].Name = col.Name
+		index[col.Name] = i
+	}
+
+	for _, card := range cards {
+		i, ok := index[card.Column]
+		if !ok {
+			continue
+		}
+		stats.ColumnStats[i].CardCount++
+		stats.TotalCards++
+		if cfg.IsDoneColumn(card.Column) {
+			stats.ColumnStats[i].DoneCount++
+			stats.TotalDone++
+		}
+	}
+
+	for i := range stats.ColumnStats {
+		col := &stats.ColumnStats[i]
+		col.CompletionPct = percent(col.DoneCount, col.CardCount)
+	}
+	stats.CompletionPct = percent(stats.TotalDone, stats.TotalCards)
+	return stats, nil
+}
+
+// StoryPointsField is the integer custom field SprintVelocity sums.
+const StoryPointsField = "story_points"
+
+// SprintVelocity sums the story points of the cards in doneColumn that got
+// there within the last windowDays days. A card's time is its DoneAtMillis,
+// falling back to UpdatedAtMillis for cards that never recorded one. Cards
+// without story points count as zero.
+func (s *BoardService) SprintVelocity(boardName string, doneColumn string, windowDays int) (float64, error) {
+	cfg, err := s.boardStore.Get(boardName)
+	if err != nil {
+		return 0, err
+	}
+	if !cfg.HasColumn(doneColumn) {
+		return 0, kanerr.ColumnNotFound(doneColumn, boardName)
+	}
+	if windowDays < 1 {
+		return 0, kanerr.InvalidField("window_days", "must be at least 1")
+	}
+	schema, ok := cfg.CustomFields[StoryPointsField]
+	if !ok {
+		return 0, kanerr.FieldNotFound(StoryPointsField, boardName)
+	}
+	if schema.FieldType() != model.FieldTypeInteger {
+		return 0, kanerr.InvalidField(StoryPointsField, fmt.Sprintf("must be an integer field, not %s", schema.FieldType()))
+	}
+
+	cards, err := s.cardStore.List(boardName, false)
+	if err != nil {
+		return 0, err
+	}
+
+	since := util.NowMillis() - int64(windowDays)*util.MillisPerDay
+	velocity := 0.0
+	for _, card := range cards {
+		if card.Column != doneColumn {
+			continue
+		}
+		at := card.DoneAtMillis
+		if at == 0 {
+			at = card.UpdatedAtMillis
+		}
+		if at < since {
+			continue
+		}
+		switch points := card.CustomFields[StoryPointsField].(type) {
+		case float64:
+			velocity += points
+		case int:
+			velocity += float64(points)
+		case int64:
+			velocity += float64(points)
+		}
+	}
+	return velocity, nil
+}
+
+// percent returns part as a percentage of whole, or 0 if whole is 0.
+func percent(part, whole int) float64 {
+	if whole == 0 {
+		return 0
+	}
+	return float64(part) / float64(whole) * 100
+}
+
+// BoardExport is a self-contained snapshot of a board: its config and all of
+// its cards, archived ones included. The JSON form is what Import consumes.
+type BoardExport struct {
+	Board *model.BoardConfig
+	Cards []*model.Card
+}
+
+// boardExportDocument is the JSON layout of a BoardExport.
+type boardExportDocument struct {
+	Board *model.BoardConfig `json:"board"`
+	Cards []*model.Card      `json:"cards"`
+}
+
+// MarshalJSON renders the export as a single JSON document. Cards keep their
+// on-disk shape (custom fields flattened, `_v` stamped), and an empty board
+// still yields `"cards": []`.
+func (e *BoardExport) MarshalJSON() ([]byte, error) {
+	cards := e.Cards
+	if cards == nil {
+		cards = []*model.Card{}
+	}
+	return json.Marshal(boardExportDocument{Board: e.Board, Cards: cards})
+}
+
+// ExportedBoardName returns the board name recorded in an export document,
+// or "" if the document can't be read. Callers use it to report which board
+// Import created when no target name was given.
+func ExportedBoardName(data []byte) string {
+	var doc boardExportDocument
+	if err := json.Unmarshal(data, &doc); err != nil || doc.Board == nil {
+		return ""
+	}
+	return doc.Board.Name
+}
+
+// Export returns the board's config and every card on it.
+func (s *BoardService) Export(boardName string) (*BoardExport, error) {
+	cfg, err := s.boardStore.Get(boardName)
+	if err != nil {
+		return nil, err
+	}
+	cards, err := s.cardStore.List(boardName, true)
+	if err != nil {
+		return nil, err
+	}
+	return &BoardExport{Board: cfg, Cards: cards}, nil
+}
+
+// Import creates a board from an exported JSON document. If targetName is
+// empty the exported board's name is used. The board gets a fresh ID, while
+// cards keep their IDs so parent and dependency references stay intact.
+// The document is validated in full before anything is written.
+func (s *BoardService) Import(data []byte, targetName string) error {
+	var doc boardExportDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return kanerr.InvalidField("import", fmt.Sprintf("invalid export document: %v", err))
+	}
+	if doc.Board == nil {
+		return kanerr.InvalidField("import", "export document has no board")
+	}
+
+	cfg := doc.Board
+	// Exports carry no migration path of their own, so only same-schema data
+	// is accepted; migrate the source project and re-export otherwise.
+	if cfg.KanSchema != version.CurrentBoardSchema() {
+		return kanerr.InvalidField("import", fmt.Sprintf("board schema %q is not %q; migrate the source and re-export",
+			cfg.KanSchema, version.CurrentBoardSchema()))
+	}
+
+	if targetName == "" {
+		targetName = cfg.Name
+	}
+	if targetName == "" {
+		return kanerr.InvalidField("name", "cannot be empty")
+	}
+	if s.boardStore.Exists(targetName) {
+		return kanerr.BoardAlreadyExists(targetName)
+	}
+	cfg.Name = targetName
+	cfg.ID = id.Generate(id.Board)
+
+	for _, card := range doc.Cards {
+		if card.ID == "" {
+			return kanerr.InvalidField("import", "card without an id")
+		}
+		if !id.IsValidID(card.ID) {
+			return kanerr.InvalidField("import", fmt.Sprintf("invalid card id %q", card.ID))
+		}
+		if card.Version != version.CurrentCardVersion {
+			return kanerr.InvalidField("import", fmt.Sprintf("card %s has schema card/%d, expected card/%d",
+				card.ID, card.Version, version.CurrentCardVersion))
+		}
+		if card.Column != "" && !cfg.HasColumn(card.Column) {
+			return kanerr.InvalidField("import", fmt.Sprintf("card %s is in unknown column %q", card.ID, card.Column))
+		}
+		if err := model.ValidateCustomFields(card.CustomFields); err != nil {
+			return err
+		}
+	}
+
+	if err := s.boardStore.Create(cfg); err != nil {
+		return err
+	}
+	for _, card := range doc.Cards {
+		if err := s.cardStore.Create(targetName, card); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Duplicate copies a board's config and all of its cards (archived ones
+// included) into a new board named targetName. Unlike Import, the copy gets
+// fresh card, comment and checklist item IDs and newly generated aliases, with
+// parent and dependency references remapped to the new cards, so the two
+// boards share no identities. Columns, positions and history carry over.
+func (s *BoardService) Duplicate(sourceName, targetName string) error {
+	if targetName == "" {
+		return kanerr.InvalidField("name", "cannot be empty")
+	}
+	src, err := s.Export(sourceName)
+	if err != nil {
+		return err
+	}
+	if s.boardStore.Exists(targetName) {
+		return kanerr.BoardAlreadyExists(targetName)
+	}
+
+	// Round-trip through the export document for a deep copy, so nothing is
+	// shared with the source's in-memory config or cards.
+	data, err := json.Marshal(src)
+	if err != nil {
+		return fmt.Errorf("failed to copy board: %w", err)
+	}
+	var doc boardExportDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("failed to copy board: %w", err)
+	}
+
+	cfg := doc.Board
+	cfg.ID = id.Generate(id.Board)
+	cfg.Name = targetName
+
+	newIDs := make(map[string]string, len(doc.Cards))
+	for _, card := range doc.Cards {
+		newIDs[card.ID] = id.Generate(id.Card)
+	}
+	remap := func(ids []string) []string {
+		for i, old := range ids {
+			if newID, ok := newIDs[old]; ok {
+				ids[i] = newID
+			}
+		}
+		return ids
+	}
+	for _, card := range doc.Cards {
+		card.ID = newIDs[card.ID]
+		if newID, ok := newIDs[card.Parent]; ok {
+			card.Parent = newID
+		}
+		card.Blocks = remap(card.Blocks)
+		card.BlockedBy = remap(card.BlockedBy)
+		for i := range card.Comments {
+			card.Comments[i].ID = id.Generate(id.Comment)
+		}
+		for i := range card.Checklist {
+			card.Checklist[i].ID = id.Generate(id.ChecklistItem)
+		}
+	}
+
+	if err := s.boardStore.Create(cfg); err != nil {
+		return err
+	}
+	// Aliases are generated as each card is created, so later cards see the
+	// earlier ones when checking for collisions.
+	aliases := NewAliasService(s.cardStore, s.boardStore)
+	for _, card := range doc.Cards {
+		alias, err := aliases.GenerateAlias(targetName, card.Title, "")
+		if err != nil {
+			return err
+		}
+		card.Alias = alias
+		if err := s.cardStore.Create(targetName, card); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RunAutoArchive archives cards that have sat in a done column for longer than
+// the column's AutoArchiveAfterDays, and returns how many it archived.
+// Columns without a policy are left alone.
+func (s *BoardService) RunAutoArchive(boardName string) (archived int, err error) {
+	ids, err := s.RunAutoArchiveWithIDs(boardName)
+	return len(ids), err
+}
+
+// RunAutoArchiveWithIDs is RunAutoArchive, returning the IDs of the archived
+// cards instead of a count. On error, the cards archived so far are returned.
+func (s *BoardService) RunAutoArchiveWithIDs(boardName string) ([]string, error) {
+	if s.cardService == nil {
+		return nil, fmt.Errorf("auto-archive needs a card service")
+	}
+
+	cfg, err := s.boardStore.Get(boardName)
+	if err != nil {
+		return nil, err
+	}
+	cards, err := s.cardStore.List(boardName, false)
+	if err != nil {
+		return nil, err
+	}
+
+	now := util.NowMillis()
+	var archived []string
+	for _, card := range cards {
+		col := cfg.GetColumn(card.Column)
+		if col == nil || !col.Done || col.AutoArchiveAfterDays <= 0 {
+			continue
+		}
+		doneAt := doneSince(card)
+		if doneAt == 0 || now-doneAt <= int64(col.AutoArchiveAfterDays)*util.MillisPerDay {
+			continue
+		}
+		if err := s.cardService.Archive(boardName, card.ID); err != nil {
+			return archived, fmt.Errorf("failed to archive card %s: %w", card.ID, err)
+		}
+		archived = append(archived, card.ID)
+	}
+	return archived, nil
+}
+
+// doneSince returns when the card entered its (done) column, or for an
+// archived card the column it was archived from. Cards that were already
+// there before the column was marked done have no DoneAtMillis, nor do
+// archived ones, so fall back to the last history entry for the column. Zero
+// means unknown.
+func doneSince(card *model.Card) int64 {
+	if card.DoneAtMillis != 0 {
+		return card.DoneAtMillis
+	}
+	column := card.Column
+	if card.Archived {
+		column = card.LastColumn
+	}
+	for i := len(card.History) - 1; i >= 0; i-- {
+		if entry := card.History[i]; entry.Field == "column" && entry.Value == column {
+			return entry.At
+		}
+	}
+	return 0
+}
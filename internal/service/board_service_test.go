@@ -1,11 +1,17 @@
 package service
 
 import (
+	"encoding/json"
+	"fmt"
+	"reflect"
 	"testing"
+	"time"
 
+	"github.com/amterp/kan/internal/config"
 	kanerr "github.com/amterp/kan/internal/errors"
 	"github.com/amterp/kan/internal/model"
 	"github.com/amterp/kan/internal/store"
+	"github.com/amterp/kan/internal/util"
 )
 
 // We reuse testBoardStore and testCardStore from card_service_test.go
@@ -21,7 +27,7 @@ func TestBoardService_Create_Basic(t *testing.T) {
 	cardStore := newTestCardStore()
 	service := NewBoardService(boardStore, cardStore)
 
-	if err := service.Create("main"); err != nil {
+	if _, err := service.Create("main"); err != nil {
 		t.Fatalf("Create failed: %v", err)
 	}
 
@@ -50,16 +56,16 @@ func TestBoardService_Create_AlreadyExists(t *testing.T) {
 	service := NewBoardService(boardStore, cardStore)
 
 	// Create first time
-	if err := service.Create("main"); err != nil {
+	if _, err := service.Create("main"); err != nil {
 		t.Fatalf("First create failed: %v", err)
 	}
 
 	// Create second time - should fail
-	err := service.Create("main")
+	_, err := service.Create("main")
 	if err == nil {
 		t.Fatal("Expected error for duplicate board")
 	}
-	if !kanerr.IsAlreadyExists(err) {
+	if !kanerr.IsCode(err, kanerr.CodeBoardAlreadyExists) {
 		t.Errorf("Expected AlreadyExists error, got %v", err)
 	}
 }
@@ -121,7 +127,7 @@ func TestBoardService_Get_NotFound(t *testing.T) {
 	if err == nil {
 		t.Fatal("Expected error for nonexistent board")
 	}
-	if !kanerr.IsNotFound(err) {
+	if !kanerr.IsCode(err, kanerr.CodeBoardNotFound) {
 		t.Errorf("Expected NotFound error, got %v", err)
 	}
 }
@@ -169,16 +175,190 @@ func TestBoardService_Create_DefaultColumns(t *testing.T) {
 	}
 }
 
+// testGlobalStore is an in-memory GlobalStore.
+type testGlobalStore struct {
+	cfg *model.GlobalConfig
+}
+
+func (s *testGlobalStore) Load() (*model.GlobalConfig, error) { return s.cfg, nil }
+func (s *testGlobalStore) Save(cfg *model.GlobalConfig) error { s.cfg = cfg; return nil }
+func (s *testGlobalStore) EnsureExists() error                { return nil }
+
+// setupGetOrDefault creates boards main and feature, with feature written
+// last, and a global config with no repo settings.
+func setupGetOrDefault(t *testing.T) (*BoardService, *testBoardStore, *testGlobalStore) {
+	t.Helper()
+	boardStore := newTestBoardStore()
+	boardStore.boards["main"] = &model.BoardConfig{ID: "b1", Name: "main"}
+	boardStore.boards["feature"] = &model.BoardConfig{ID: "b2", Name: "feature"}
+	boardStore.modTimes = map[string]time.Time{
+		"main":    time.Unix(1000, 0),
+		"feature": time.Unix(2000, 0),
+	}
+	globalStore := &testGlobalStore{cfg: &model.GlobalConfig{Repos: map[string]model.RepoConfig{}}}
+	svc := NewBoardService(boardStore, newTestCardStore())
+	svc.SetGlobalStore(globalStore)
+	return svc, boardStore, globalStore
+}
+
+func TestBoardService_GetOrDefault_Explicit(t *testing.T) {
+	svc, _, _ := setupGetOrDefault(t)
+
+	board, err := svc.GetOrDefault("main", "/repo")
+	if err != nil {
+		t.Fatalf("GetOrDefault failed: %v", err)
+	}
+	if board != "main" {
+		t.Errorf("Expected explicit board main, got %q", board)
+	}
+
+	if _, err := svc.GetOrDefault("missing", "/repo"); !kanerr.IsCode(err, kanerr.CodeBoardNotFound) {
+		t.Errorf("Expected board not found for a missing explicit board, got %v", err)
+	}
+}
+
+func TestBoardService_GetOrDefault_RepoDefault(t *testing.T) {
+	svc, _, globalStore := setupGetOrDefault(t)
+	globalStore.cfg.Repos["/repo"] = model.RepoConfig{DefaultBoard: "main"}
+
+	board, err := svc.GetOrDefault("", "/repo")
+	if err != nil {
+		t.Fatalf("GetOrDefault failed: %v", err)
+	}
+	if board != "main" {
+		t.Errorf("Expected repo default main over the more recent feature, got %q", board)
+	}
+
+	// A stale default falls through to the most recent board.
+	globalStore.cfg.Repos["/repo"] = model.RepoConfig{DefaultBoard: "gone"}
+	if board, _ := svc.GetOrDefault("", "/repo"); board != "feature" {
+		t.Errorf("Expected stale default to fall back to feature, got %q", board)
+	}
+}
+
+func TestBoardService_GetOrDefault_MostRecent(t *testing.T) {
+	svc, boardStore, _ := setupGetOrDefault(t)
+
+	board, err := svc.GetOrDefault("", "/repo")
+	if err != nil {
+		t.Fatalf("GetOrDefault failed: %v", err)
+	}
+	if board != "feature" {
+		t.Errorf("Expected most recently modified feature, got %q", board)
+	}
+
+	boardStore.modTimes["main"] = time.Unix(3000, 0)
+	if board, _ := svc.GetOrDefault("", "/repo"); board != "main" {
+		t.Errorf("Expected main after it was modified, got %q", board)
+	}
+}
+
+func TestBoardService_GetOrDefault_NoBoards(t *testing.T) {
+	svc := NewBoardService(newTestBoardStore(), newTestCardStore())
+
+	if _, err := svc.GetOrDefault("", "/repo"); err == nil {
+		t.Error("Expected error when no boards exist")
+	}
+}
+
+func TestBoardService_Create_NoGlobalDefaults(t *testing.T) {
+	boardStore := newTestBoardStore()
+	svc := NewBoardService(boardStore, newTestCardStore())
+	svc.SetGlobalStore(&testGlobalStore{cfg: &model.GlobalConfig{}})
+
+	applied, err := svc.Create("main")
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if applied.Columns || len(applied.Fields) != 0 {
+		t.Errorf("Expected nothing applied, got %+v", applied)
+	}
+
+	cfg, _ := svc.Get("main")
+	if !reflect.DeepEqual(cfg.Columns, model.DefaultColumns()) || !reflect.DeepEqual(cfg.CustomFields, model.DefaultCustomFields()) {
+		t.Errorf("Expected built-in defaults, got columns %+v and fields %+v", cfg.Columns, cfg.CustomFields)
+	}
+}
+
+func TestBoardService_Create_GlobalDefaults(t *testing.T) {
+	boardStore := newTestBoardStore()
+	svc := NewBoardService(boardStore, newTestCardStore())
+	svc.SetGlobalStore(&testGlobalStore{cfg: &model.GlobalConfig{
+		DefaultColumns: []model.Column{{Name: "todo", Color: "#6b7280"}, {Name: "doing", Color: "#f59e0b"}},
+		DefaultCustomFields: map[string]model.CustomFieldSchema{
+			"estimate": {Type: model.FieldTypeInteger},
+			"type":     {Type: model.FieldTypeString},
+		},
+	}})
+
+	applied, err := svc.Create("main")
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if !applied.Columns || !reflect.DeepEqual(applied.Fields, []string{"estimate", "type"}) {
+		t.Errorf("Expected global columns and both fields applied, got %+v", applied)
+	}
+
+	cfg, _ := svc.Get("main")
+	if len(cfg.Columns) != 2 || cfg.DefaultColumn != "todo" {
+		t.Errorf("Expected global columns with todo as default, got %+v (default %q)", cfg.Columns, cfg.DefaultColumn)
+	}
+	if cfg.CustomFields["estimate"].Type != model.FieldTypeInteger || cfg.CustomFields["type"].Type != model.FieldTypeString {
+		t.Errorf("Expected global fields to replace built-in ones, got %+v", cfg.CustomFields)
+	}
+	// The built-in type_indicator needs an enum, so it's dropped.
+	if cfg.CardDisplay.TypeIndicator != "" {
+		t.Errorf("Expected no type_indicator, got %q", cfg.CardDisplay.TypeIndicator)
+	}
+}
+
+func TestBoardService_CreateWithConfig_BoardFieldsOverrideGlobal(t *testing.T) {
+	boardStore := newTestBoardStore()
+	svc := NewBoardService(boardStore, newTestCardStore())
+	svc.SetGlobalStore(&testGlobalStore{cfg: &model.GlobalConfig{
+		DefaultColumns: []model.Column{{Name: "todo", Color: "#6b7280"}},
+		DefaultCustomFields: map[string]model.CustomFieldSchema{
+			"priority": {Type: model.FieldTypeEnum, Options: []model.CustomFieldOption{{Value: "low"}, {Value: "high"}}},
+			"estimate": {Type: model.FieldTypeInteger},
+		},
+	}})
+
+	applied, err := svc.CreateWithConfig(&model.BoardConfig{
+		Name:    "main",
+		Columns: []model.Column{{Name: "backlog", Color: "#6b7280"}, {Name: "done", Color: "#10b981"}},
+		CustomFields: map[string]model.CustomFieldSchema{
+			"priority": {Type: model.FieldTypeEnum, Options: []model.CustomFieldOption{{Value: "p0"}, {Value: "p1"}}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateWithConfig failed: %v", err)
+	}
+	if applied.Columns || !reflect.DeepEqual(applied.Fields, []string{"estimate"}) {
+		t.Errorf("Expected only estimate applied, got %+v", applied)
+	}
+
+	cfg, _ := svc.Get("main")
+	if len(cfg.Columns) != 2 || cfg.Columns[0].Name != "backlog" {
+		t.Errorf("Expected the board's own columns, got %+v", cfg.Columns)
+	}
+	if opts := cfg.CustomFields["priority"].Options; len(opts) != 2 || opts[0].Value != "p0" {
+		t.Errorf("Expected board-specific priority options, got %+v", opts)
+	}
+	if cfg.CustomFields["estimate"].Type != model.FieldTypeInteger {
+		t.Errorf("Expected global estimate field, got %+v", cfg.CustomFields["estimate"])
+	}
+}
+
 func TestBoardService_DeleteBoard(t *testing.T) {
 	boardStore := newTestBoardStore()
 	cardStore := newTestCardStore()
 	svc := NewBoardService(boardStore, cardStore)
 
 	// Need at least two boards so we're not deleting the last one
-	if err := svc.Create("main"); err != nil {
+	if _, err := svc.Create("main"); err != nil {
 		t.Fatalf("Create main failed: %v", err)
 	}
-	if err := svc.Create("other"); err != nil {
+	if _, err := svc.Create("other"); err != nil {
 		t.Fatalf("Create other failed: %v", err)
 	}
 
@@ -196,6 +376,41 @@ func TestBoardService_DeleteBoard(t *testing.T) {
 	}
 }
 
+func TestBoardService_ArchiveBoard(t *testing.T) {
+	boardStore := newTestBoardStore()
+	svc := NewBoardService(boardStore, newTestCardStore())
+	svc.Create("main")
+	svc.Create("old")
+
+	if err := svc.ArchiveBoard("old"); err != nil {
+		t.Fatalf("ArchiveBoard failed: %v", err)
+	}
+	if boards, _ := svc.List(); !reflect.DeepEqual(boards, []string{"main"}) {
+		t.Errorf("List() = %v, want only main", boards)
+	}
+
+	// The last active board can be neither archived nor deleted, but an
+	// archived board can still be deleted.
+	if err := svc.ArchiveBoard("main"); !kanerr.IsCode(err, kanerr.CodeInvalidField) {
+		t.Errorf("Expected validation error archiving the last active board, got %v", err)
+	}
+	if _, err := svc.DeleteBoard("main"); !kanerr.IsCode(err, kanerr.CodeInvalidField) {
+		t.Errorf("Expected validation error deleting the last active board, got %v", err)
+	}
+
+	if err := svc.UnarchiveBoard("old"); err != nil {
+		t.Fatalf("UnarchiveBoard failed: %v", err)
+	}
+	if boards, _ := svc.List(); len(boards) != 2 {
+		t.Errorf("List() = %v, want both boards after unarchive", boards)
+	}
+
+	svc.ArchiveBoard("old")
+	if _, err := svc.DeleteBoard("old"); err != nil {
+		t.Errorf("Expected archived board to be deletable, got %v", err)
+	}
+}
+
 func TestBoardService_DeleteBoard_WithCards(t *testing.T) {
 	boardStore := newTestBoardStore()
 	cardStore := newTestCardStore()
@@ -236,7 +451,7 @@ func TestBoardService_DeleteBoard_NotFound(t *testing.T) {
 	if err == nil {
 		t.Fatal("Expected error for nonexistent board")
 	}
-	if !kanerr.IsNotFound(err) {
+	if !kanerr.IsCode(err, kanerr.CodeBoardNotFound) {
 		t.Errorf("Expected NotFound error, got %v", err)
 	}
 }
@@ -246,7 +461,7 @@ func TestBoardService_DeleteBoard_LastBoard(t *testing.T) {
 	cardStore := newTestCardStore()
 	svc := NewBoardService(boardStore, cardStore)
 
-	if err := svc.Create("main"); err != nil {
+	if _, err := svc.Create("main"); err != nil {
 		t.Fatalf("Create failed: %v", err)
 	}
 
@@ -254,7 +469,7 @@ func TestBoardService_DeleteBoard_LastBoard(t *testing.T) {
 	if err == nil {
 		t.Fatal("Expected error when deleting the last board")
 	}
-	if !kanerr.IsValidationError(err) {
+	if !kanerr.IsCode(err, kanerr.CodeInvalidField) {
 		t.Errorf("Expected validation error, got %v", err)
 	}
 
@@ -263,3 +478,1156 @@ func TestBoardService_DeleteBoard_LastBoard(t *testing.T) {
 		t.Error("Board should still exist after failed deletion")
 	}
 }
+
+// ============================================================================
+// Export / Import Tests
+// ============================================================================
+
+// setupExportTest builds board and card services over a real on-disk project,
+// so exports go through the same JSON (un)marshaling as stored cards.
+func setupExportTest(t *testing.T) (*BoardService, *CardService) {
+	t.Helper()
+	paths := config.NewPaths(t.TempDir(), "")
+	cardStore := store.NewCardStore(paths)
+	boardStore := store.NewBoardStore(paths)
+	if err := boardStore.Create(testBoardConfig("main")); err != nil {
+		t.Fatalf("Failed to create board: %v", err)
+	}
+	return NewBoardService(boardStore, cardStore), NewCardService(cardStore, boardStore, NewAliasService(cardStore, boardStore))
+}
+
+func TestBoardService_RenameBoard(t *testing.T) {
+	boardService, cardService := setupExportTest(t)
+	card := mustAdd(t, cardService, AddCardInput{BoardName: "main", Title: "Moves along", Column: "in-progress"})
+	before, err := boardService.Get("main")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+
+	if err := boardService.RenameBoard("main", "roadmap"); err != nil {
+		t.Fatalf("RenameBoard failed: %v", err)
+	}
+
+	if _, err := boardService.Get("main"); !kanerr.IsCode(err, kanerr.CodeBoardNotFound) {
+		t.Errorf("Expected old name to be gone, got %v", err)
+	}
+	after, err := boardService.Get("roadmap")
+	if err != nil {
+		t.Fatalf("Get after rename failed: %v", err)
+	}
+	if after.Name != "roadmap" || after.ID != before.ID {
+		t.Errorf("Expected same board renamed to roadmap, got %q (%s)", after.Name, after.ID)
+	}
+	// Column names don't depend on the board name, so only Name changes.
+	if !reflect.DeepEqual(after.Columns, before.Columns) || after.DefaultColumn != before.DefaultColumn {
+		t.Errorf("Columns changed: %+v -> %+v", before.Columns, after.Columns)
+	}
+
+	moved, err := cardService.FindByIDOrAlias("roadmap", card.Alias)
+	if err != nil {
+		t.Fatalf("Card not found by alias after rename: %v", err)
+	}
+	if moved.ID != card.ID || moved.Column != "in-progress" {
+		t.Errorf("Unexpected card after rename: %+v", moved)
+	}
+}
+
+func TestBoardService_RenameBoard_Errors(t *testing.T) {
+	boardService, _ := setupExportTest(t)
+	if _, err := boardService.Create("other"); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	if err := boardService.RenameBoard("main", "other"); !kanerr.IsCode(err, kanerr.CodeBoardAlreadyExists) {
+		t.Errorf("Expected already-exists error, got %v", err)
+	}
+	if err := boardService.RenameBoard("missing", "new"); !kanerr.IsCode(err, kanerr.CodeBoardNotFound) {
+		t.Errorf("Expected not-found error, got %v", err)
+	}
+	for _, name := range []string{"", "main", "a/b"} {
+		if err := boardService.RenameBoard("main", name); !kanerr.IsCode(err, kanerr.CodeInvalidField) {
+			t.Errorf("RenameBoard(main, %q): expected validation error, got %v", name, err)
+		}
+	}
+	if _, err := boardService.Get("main"); err != nil {
+		t.Errorf("Failed renames should leave the board in place: %v", err)
+	}
+}
+
+// setupTransferTest returns services over a real project with boards "main"
+// and "other", where "other" lacks main's "labels" field.
+func setupTransferTest(t *testing.T) (*BoardService, *CardService) {
+	t.Helper()
+	boardService, cardService := setupExportTest(t)
+	boardService.SetCardService(cardService)
+	other := testBoardConfig("other")
+	delete(other.CustomFields, "labels")
+	if _, err := boardService.CreateWithConfig(other); err != nil {
+		t.Fatalf("Failed to create board: %v", err)
+	}
+	return boardService, cardService
+}
+
+func TestBoardService_MoveCardAcrossBoards(t *testing.T) {
+	boardService, cardService := setupTransferTest(t)
+	card := mustAdd(t, cardService, AddCardInput{
+		BoardName:    "main",
+		Title:        "Travelling card",
+		CustomFields: map[string]string{"type": "bug", "labels": "blocked"},
+	})
+	child := mustAdd(t, cardService, AddCardInput{BoardName: "main", Title: "Child", Parent: card.ID})
+	blocked := mustAdd(t, cardService, AddCardInput{BoardName: "main", Title: "Waits", BlockedBy: &[]string{card.ID}})
+
+	result, err := boardService.MoveCardAcrossBoards("main", "other", card.ID, "in-progress")
+	if err != nil {
+		t.Fatalf("MoveCardAcrossBoards failed: %v", err)
+	}
+	if !reflect.DeepEqual(result.DroppedFields, []string{"labels"}) {
+		t.Errorf("DroppedFields = %v, want [labels]", result.DroppedFields)
+	}
+	if result.AliasChanged {
+		t.Error("Alias should be kept when it's free on the destination")
+	}
+
+	moved, err := cardService.Get("other", card.ID)
+	if err != nil {
+		t.Fatalf("Card not on destination board: %v", err)
+	}
+	if moved.Column != "in-progress" || moved.Alias != card.Alias {
+		t.Errorf("Unexpected moved card: column %q, alias %q", moved.Column, moved.Alias)
+	}
+	if moved.CustomFields["type"] != "bug" || moved.CustomFields["labels"] != nil {
+		t.Errorf("Unexpected custom fields: %v", moved.CustomFields)
+	}
+
+	if _, err := cardService.Get("main", card.ID); !kanerr.IsCode(err, kanerr.CodeCardNotFound) {
+		t.Errorf("Expected card removed from source, got %v", err)
+	}
+	if c, _ := cardService.Get("main", child.ID); c.Parent != "" {
+		t.Errorf("Child parent = %q, want cleared", c.Parent)
+	}
+	if c, _ := cardService.Get("main", blocked.ID); len(c.BlockedBy) != 0 {
+		t.Errorf("BlockedBy = %v, want cleared", c.BlockedBy)
+	}
+}
+
+func TestBoardService_MoveCardAcrossBoards_NegativeInteger(t *testing.T) {
+	boardService, cardService := setupTransferTest(t)
+	for _, name := range []string{"main", "other"} {
+		cfg, err := boardService.Get(name)
+		if err != nil {
+			t.Fatalf("Get failed: %v", err)
+		}
+		cfg.CustomFields["balance"] = model.CustomFieldSchema{Type: model.FieldTypeInteger, Min: intPtr(-10)}
+		if err := boardService.boardStore.Update(cfg); err != nil {
+			t.Fatalf("Update failed: %v", err)
+		}
+	}
+	card := mustAdd(t, cardService, AddCardInput{BoardName: "main", Title: "In debt", CustomFields: map[string]string{"balance": "-3"}})
+
+	result, err := boardService.MoveCardAcrossBoards("main", "other", card.ID, "")
+	if err != nil {
+		t.Fatalf("MoveCardAcrossBoards failed: %v", err)
+	}
+	if len(result.DroppedFields) != 0 {
+		t.Errorf("Expected no dropped fields, got %v", result.DroppedFields)
+	}
+	moved, err := cardService.Get("other", card.ID)
+	if err != nil {
+		t.Fatalf("Card not on destination board: %v", err)
+	}
+	// Read back from the card file, so the value is a JSON number.
+	if moved.CustomFields["balance"] != float64(-3) {
+		t.Errorf("Expected balance -3 after the move, got %v (%T)", moved.CustomFields["balance"], moved.CustomFields["balance"])
+	}
+}
+
+// addBoardField adds a custom field to each of the named boards.
+func addBoardField(t *testing.T, boardService *BoardService, field string, schema model.CustomFieldSchema, boards ...string) {
+	t.Helper()
+	for _, name := range boards {
+		cfg, err := boardService.Get(name)
+		if err != nil {
+			t.Fatalf("Get failed: %v", err)
+		}
+		cfg.CustomFields[field] = schema
+		if err := boardService.boardStore.Update(cfg); err != nil {
+			t.Fatalf("Update failed: %v", err)
+		}
+	}
+}
+
+func TestBoardService_MoveCardAcrossBoards_LargeInteger(t *testing.T) {
+	boardService, cardService := setupTransferTest(t)
+	addBoardField(t, boardService, "points", model.CustomFieldSchema{Type: model.FieldTypeInteger}, "main", "other")
+	card := mustAdd(t, cardService, AddCardInput{BoardName: "main", Title: "Big", CustomFields: map[string]string{"points": "2500000"}})
+
+	// The card is read back from its file, so the value arrives as a float64.
+	result, err := boardService.MoveCardAcrossBoards("main", "other", card.ID, "")
+	if err != nil {
+		t.Fatalf("MoveCardAcrossBoards failed: %v", err)
+	}
+	if len(result.DroppedFields) != 0 {
+		t.Errorf("Expected no dropped fields, got %v", result.DroppedFields)
+	}
+	moved, err := cardService.Get("other", card.ID)
+	if err != nil {
+		t.Fatalf("Card not on destination board: %v", err)
+	}
+	if moved.CustomFields["points"] != float64(2500000) {
+		t.Errorf("Expected points 2500000 after the move, got %v", moved.CustomFields["points"])
+	}
+}
+
+func TestBoardService_MoveCardAcrossBoards_AliasCollision(t *testing.T) {
+	boardService, cardService := setupTransferTest(t)
+	existing := mustAdd(t, cardService, AddCardInput{BoardName: "other", Title: "Same title"})
+	card := mustAdd(t, cardService, AddCardInput{BoardName: "main", Title: "Same title"})
+	if card.Alias != existing.Alias {
+		t.Fatalf("Test setup: expected matching aliases, got %q and %q", card.Alias, existing.Alias)
+	}
+
+	result, err := boardService.MoveCardAcrossBoards("main", "other", card.ID, "")
+	if err != nil {
+		t.Fatalf("MoveCardAcrossBoards failed: %v", err)
+	}
+	if !result.AliasChanged || result.Card.Alias == existing.Alias {
+		t.Errorf("Expected a new alias, got %q (changed=%v)", result.Card.Alias, result.AliasChanged)
+	}
+	if result.Card.ID != card.ID || result.Card.Column != "backlog" {
+		t.Errorf("Unexpected moved card: %+v", result.Card)
+	}
+
+	for alias, want := range map[string]string{existing.Alias: existing.ID, result.Card.Alias: card.ID} {
+		found, err := cardService.FindByIDOrAlias("other", alias)
+		if err != nil || found.ID != want {
+			t.Errorf("Alias %q resolved to %v (%v), want %s", alias, found, err, want)
+		}
+	}
+}
+
+func TestBoardService_MoveCardAcrossBoards_Errors(t *testing.T) {
+	boardService, cardService := setupTransferTest(t)
+	card := mustAdd(t, cardService, AddCardInput{BoardName: "main", Title: "Stays put"})
+
+	if _, err := boardService.MoveCardAcrossBoards("main", "other", card.ID, "missing"); !kanerr.IsCode(err, kanerr.CodeColumnNotFound) {
+		t.Errorf("Expected column not found, got %v", err)
+	}
+	if _, err := boardService.MoveCardAcrossBoards("main", "missing", card.ID, ""); !kanerr.IsCode(err, kanerr.CodeBoardNotFound) {
+		t.Errorf("Expected board not found, got %v", err)
+	}
+	if _, err := boardService.MoveCardAcrossBoards("main", "main", card.ID, ""); !kanerr.IsCode(err, kanerr.CodeInvalidField) {
+		t.Errorf("Expected validation error for same board, got %v", err)
+	}
+	if _, err := cardService.Get("main", card.ID); err != nil {
+		t.Errorf("Failed moves should leave the card in place: %v", err)
+	}
+}
+
+func TestBoardService_MergeBoards(t *testing.T) {
+	boardService, cardService := setupTransferTest(t)
+	if err := boardService.AddColumn("main", "review", "", "", -1); err != nil {
+		t.Fatalf("AddColumn failed: %v", err)
+	}
+	mustAdd(t, cardService, AddCardInput{BoardName: "other", Title: "Already there", Column: "in-progress"})
+	first := mustAdd(t, cardService, AddCardInput{BoardName: "main", Title: "First", Column: "review"})
+	second := mustAdd(t, cardService, AddCardInput{
+		BoardName:    "main",
+		Title:        "Second",
+		Column:       "review",
+		BlockedBy:    &[]string{first.ID},
+		CustomFields: map[string]string{"labels": "blocked"},
+	})
+	done := mustAdd(t, cardService, AddCardInput{BoardName: "main", Title: "Finished", Column: "done"})
+
+	result, err := boardService.MergeBoards("main", "other", MergeOptions{
+		ColumnMapping: map[string]string{"review": "in-progress"},
+		DeleteSource:  true,
+	})
+	if err != nil {
+		t.Fatalf("MergeBoards failed: %v", err)
+	}
+	if result.Moved != 3 || result.Skipped != 0 || result.AliasRegenerated != 0 || !result.SourceDeleted {
+		t.Errorf("Unexpected result: %+v", result)
+	}
+	if !reflect.DeepEqual(result.DroppedFields, []string{"labels"}) {
+		t.Errorf("DroppedFields = %v, want [labels]", result.DroppedFields)
+	}
+	if boardService.Exists("main") {
+		t.Error("Expected the source board to be deleted")
+	}
+
+	cards, err := cardService.List("other", "in-progress")
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if got := serviceCardIDs(cards); len(got) != 3 || got[1] != first.ID || got[2] != second.ID {
+		t.Errorf("Expected merged cards appended in order, got %v", got)
+	}
+	moved, err := cardService.Get("other", second.ID)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if !reflect.DeepEqual(moved.BlockedBy, []string{first.ID}) {
+		t.Errorf("Links between merged cards should be kept, got %v", moved.BlockedBy)
+	}
+	if got, err := cardService.Get("other", done.ID); err != nil || got.Column != "done" {
+		t.Errorf("Expected unmapped column to keep its name, got %v (%v)", got, err)
+	}
+}
+
+func TestBoardService_MergeBoards_LargeInteger(t *testing.T) {
+	boardService, cardService := setupTransferTest(t)
+	addBoardField(t, boardService, "points", model.CustomFieldSchema{Type: model.FieldTypeInteger}, "main", "other")
+	card := mustAdd(t, cardService, AddCardInput{BoardName: "main", Title: "Big", CustomFields: map[string]string{"points": "2500000"}})
+
+	result, err := boardService.MergeBoards("main", "other", MergeOptions{})
+	if err != nil {
+		t.Fatalf("MergeBoards failed: %v", err)
+	}
+	if result.Moved != 1 || len(result.DroppedFields) != 0 {
+		t.Errorf("Expected 1 card moved with no dropped fields, got %+v", result)
+	}
+	moved, err := cardService.Get("other", card.ID)
+	if err != nil {
+		t.Fatalf("Card not on destination board: %v", err)
+	}
+	if moved.CustomFields["points"] != float64(2500000) {
+		t.Errorf("Expected points 2500000 after the merge, got %v", moved.CustomFields["points"])
+	}
+}
+
+func TestBoardService_MergeBoards_AliasCollision(t *testing.T) {
+	for _, tt := range []struct {
+		conflict    string
+		wantMoved   int
+		wantSkipped int
+	}{
+		{MergeAliasRegenerate, 3, 0},
+		{MergeAliasSkip, 2, 1},
+	} {
+		t.Run(tt.conflict, func(t *testing.T) {
+			boardService, cardService := setupTransferTest(t)
+			existing := mustAdd(t, cardService, AddCardInput{BoardName: "other", Title: "Same title"})
+			clash := mustAdd(t, cardService, AddCardInput{BoardName: "main", Title: "Same title"})
+			mustAdd(t, cardService, AddCardInput{BoardName: "main", Title: "Parent", Column: "done"})
+			child := mustAdd(t, cardService, AddCardInput{BoardName: "main", Title: "Child", Parent: clash.ID})
+
+			result, err := boardService.MergeBoards("main", "other", MergeOptions{ConflictAlias: tt.conflict, DeleteSource: true})
+			if err != nil {
+				t.Fatalf("MergeBoards failed: %v", err)
+			}
+			if result.Moved != tt.wantMoved || result.Skipped != tt.wantSkipped {
+				t.Errorf("Moved %d, skipped %d; want %d, %d", result.Moved, result.Skipped, tt.wantMoved, tt.wantSkipped)
+			}
+
+			found, err := cardService.FindByIDOrAlias("other", existing.Alias)
+			if err != nil || found.ID != existing.ID {
+				t.Errorf("Existing alias should still resolve to %s, got %v (%v)", existing.ID, found, err)
+			}
+			movedChild, err := cardService.Get("other", child.ID)
+			if err != nil {
+				t.Fatalf("Get failed: %v", err)
+			}
+
+			if tt.conflict == MergeAliasRegenerate {
+				if result.AliasRegenerated != 1 {
+					t.Errorf("AliasRegenerated = %d, want 1", result.AliasRegenerated)
+				}
+				moved, err := cardService.Get("other", clash.ID)
+				if err != nil || moved.Alias == existing.Alias {
+					t.Errorf("Expected a regenerated alias, got %v (%v)", moved, err)
+				}
+				if movedChild.Parent != clash.ID {
+					t.Errorf("Expected parent link kept, got %q", movedChild.Parent)
+				}
+				return
+			}
+
+			if result.SourceDeleted {
+				t.Error("Source board must be kept while it still has skipped cards")
+			}
+			if _, err := cardService.Get("main", clash.ID); err != nil {
+				t.Errorf("Skipped card should stay on the source board: %v", err)
+			}
+			if movedChild.Parent != "" {
+				t.Errorf("Expected link to the skipped card cleared, got %q", movedChild.Parent)
+			}
+		})
+	}
+}
+
+func TestBoardService_MergeBoards_Errors(t *testing.T) {
+	boardService, cardService := setupTransferTest(t)
+	if err := boardService.AddColumn("main", "review", "", "", -1); err != nil {
+		t.Fatalf("AddColumn failed: %v", err)
+	}
+	card := mustAdd(t, cardService, AddCardInput{BoardName: "main", Title: "Stays put", Column: "review"})
+
+	if _, err := boardService.MergeBoards("main", "other", MergeOptions{}); !kanerr.IsCode(err, kanerr.CodeInvalidField) {
+		t.Errorf("Expected error for unmapped column, got %v", err)
+	}
+	opts := MergeOptions{ColumnMapping: map[string]string{"review": "missing"}}
+	if _, err := boardService.MergeBoards("main", "other", opts); !kanerr.IsCode(err, kanerr.CodeColumnNotFound) {
+		t.Errorf("Expected column not found for mapping target, got %v", err)
+	}
+	opts = MergeOptions{ColumnMapping: map[string]string{"review": "done"}, ConflictAlias: "rename"}
+	if _, err := boardService.MergeBoards("main", "other", opts); !kanerr.IsCode(err, kanerr.CodeInvalidField) {
+		t.Errorf("Expected error for unknown conflict strategy, got %v", err)
+	}
+	if _, err := boardService.MergeBoards("main", "main", MergeOptions{}); !kanerr.IsCode(err, kanerr.CodeInvalidField) {
+		t.Errorf("Expected error for merging into itself, got %v", err)
+	}
+	if _, err := boardService.MergeBoards("main", "missing", MergeOptions{}); !kanerr.IsCode(err, kanerr.CodeBoardNotFound) {
+		t.Errorf("Expected board not found, got %v", err)
+	}
+	if _, err := cardService.Get("main", card.ID); err != nil {
+		t.Errorf("Refused merges should leave cards in place: %v", err)
+	}
+}
+
+func TestBoardService_ExportImport_RoundTrip(t *testing.T) {
+	boardService, cardService := setupExportTest(t)
+
+	bug := mustAdd(t, cardService, AddCardInput{
+		BoardName:    "main",
+		Title:        "Fix crash",
+		Column:       "in-progress",
+		CustomFields: map[string]string{"type": "bug", "labels": "blocked,needs-review"},
+	})
+	mustAdd(t, cardService, AddCardInput{BoardName: "main", Title: "Write docs", BlockedBy: &[]string{bug.ID}})
+	archived := mustAdd(t, cardService, AddCardInput{BoardName: "main", Title: "Old idea"})
+	if err := cardService.Archive("main", archived.ID); err != nil {
+		t.Fatalf("Archive failed: %v", err)
+	}
+
+	export, err := boardService.Export("main")
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	data, err := json.Marshal(export)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	if err := boardService.Import(data, "copy"); err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+
+	original, _ := boardService.Get("main")
+	imported, err := boardService.Get("copy")
+	if err != nil {
+		t.Fatalf("Imported board not found: %v", err)
+	}
+	if imported.ID == original.ID {
+		t.Error("Imported board should get a fresh ID")
+	}
+	if len(imported.Columns) != len(original.Columns) || len(imported.CustomFields) != len(original.CustomFields) {
+		t.Errorf("Imported config differs: %d columns / %d fields, want %d / %d",
+			len(imported.Columns), len(imported.CustomFields), len(original.Columns), len(original.CustomFields))
+	}
+
+	copied, err := cardService.ListIncludingArchived("copy", "")
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(copied) != 3 {
+		t.Fatalf("Expected 3 imported cards, got %d", len(copied))
+	}
+
+	got, err := cardService.Get("copy", bug.ID)
+	if err != nil {
+		t.Fatalf("Imported card not found: %v", err)
+	}
+	if got.Column != "in-progress" || got.CustomFields["type"] != "bug" {
+		t.Errorf("Expected bug in in-progress, got type=%v column=%q", got.CustomFields["type"], got.Column)
+	}
+	if !reflect.DeepEqual(got.CustomFields["labels"], []any{"blocked", "needs-review"}) {
+		t.Errorf("Expected labels [blocked needs-review], got %v", got.CustomFields["labels"])
+	}
+	if len(got.Blocks) != 1 {
+		t.Errorf("Expected dependency to survive import, got blocks=%v", got.Blocks)
+	}
+}
+
+func TestBoardService_Duplicate(t *testing.T) {
+	boardService, cardService := setupExportTest(t)
+
+	parent := mustAdd(t, cardService, AddCardInput{BoardName: "main", Title: "Epic", CustomFields: map[string]string{"type": "feature"}})
+	child := mustAdd(t, cardService, AddCardInput{BoardName: "main", Title: "Task", Column: "in-progress", Parent: parent.ID, BlockedBy: &[]string{parent.ID}})
+	if _, err := cardService.AddComment("main", child.ID, "first!", "alice"); err != nil {
+		t.Fatalf("AddComment failed: %v", err)
+	}
+	if _, err := cardService.AddChecklistItem("main", child.ID, "write tests"); err != nil {
+		t.Fatalf("AddChecklistItem failed: %v", err)
+	}
+	alias := "hand-picked"
+	if _, err := cardService.Edit(EditCardInput{BoardName: "main", CardIDOrAlias: child.ID, Alias: &alias}); err != nil {
+		t.Fatalf("Edit failed: %v", err)
+	}
+
+	if err := boardService.Duplicate("main", "copy"); err != nil {
+		t.Fatalf("Duplicate failed: %v", err)
+	}
+
+	original, _ := boardService.Get("main")
+	dup, err := boardService.Get("copy")
+	if err != nil {
+		t.Fatalf("Duplicated board not found: %v", err)
+	}
+	if dup.ID == original.ID || len(dup.Columns) != len(original.Columns) {
+		t.Errorf("Expected fresh ID and same columns, got id=%q columns=%d", dup.ID, len(dup.Columns))
+	}
+
+	copied, err := cardService.ListIncludingArchived("copy", "")
+	if err != nil || len(copied) != 2 {
+		t.Fatalf("Expected 2 copied cards, got %d (err=%v)", len(copied), err)
+	}
+	byTitle := make(map[string]*model.Card)
+	for _, c := range copied {
+		byTitle[c.Title] = c
+	}
+	epic, task := byTitle["Epic"], byTitle["Task"]
+	if epic == nil || task == nil {
+		t.Fatalf("Expected titles Epic and Task, got %v", byTitle)
+	}
+	if epic.ID == parent.ID || task.ID == child.ID {
+		t.Error("Copied cards should get fresh IDs")
+	}
+	if task.Column != "in-progress" || epic.CustomFields["type"] != "feature" {
+		t.Errorf("Card content not copied: column=%q type=%v", task.Column, epic.CustomFields["type"])
+	}
+	if task.Alias != "task" || epic.Alias != "epic" {
+		t.Errorf("Expected aliases generated from titles, got %q and %q", task.Alias, epic.Alias)
+	}
+	if task.Parent != epic.ID || !reflect.DeepEqual(task.BlockedBy, []string{epic.ID}) || !reflect.DeepEqual(epic.Blocks, []string{task.ID}) {
+		t.Errorf("References should point at the copies: parent=%q blocked_by=%v blocks=%v", task.Parent, task.BlockedBy, epic.Blocks)
+	}
+	srcTask, _ := cardService.Get("main", child.ID)
+	if len(task.Comments) != 1 || task.Comments[0].ID == srcTask.Comments[0].ID {
+		t.Errorf("Expected the comment copied with a fresh ID, got %+v", task.Comments)
+	}
+	if len(task.Checklist) != 1 || task.Checklist[0].ID == srcTask.Checklist[0].ID || task.Checklist[0].Text != "write tests" {
+		t.Errorf("Expected the checklist item copied with a fresh ID, got %+v", task.Checklist)
+	}
+	if srcTask.Parent != parent.ID {
+		t.Errorf("Source card must be untouched, parent=%q", srcTask.Parent)
+	}
+
+	if err := boardService.Duplicate("main", "copy"); !kanerr.IsCode(err, kanerr.CodeBoardAlreadyExists) {
+		t.Errorf("Expected already-exists error, got %v", err)
+	}
+	if err := boardService.Duplicate("missing", "other"); !kanerr.IsCode(err, kanerr.CodeBoardNotFound) {
+		t.Errorf("Expected not-found error, got %v", err)
+	}
+}
+
+func TestBoardService_Import_Errors(t *testing.T) {
+	boardService, _ := setupExportTest(t)
+
+	export, err := boardService.Export("main")
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	data, _ := json.Marshal(export)
+
+	if err := boardService.Import(data, "main"); !kanerr.IsCode(err, kanerr.CodeBoardAlreadyExists) {
+		t.Errorf("Expected already-exists error for name collision, got %v", err)
+	}
+
+	cases := []struct {
+		name string
+		data string
+	}{
+		{name: "malformed JSON", data: `{"board":`},
+		{name: "missing board", data: `{"cards": []}`},
+		{name: "outdated schema", data: `{"board": {"kan_schema": "board/1", "name": "old"}, "cards": []}`},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := boardService.Import([]byte(tc.data), "other"); !kanerr.IsCode(err, kanerr.CodeInvalidField) {
+				t.Errorf("Expected validation error, got %v", err)
+			}
+		})
+	}
+	if boardService.Exists("other") {
+		t.Error("Failed import should not create the board")
+	}
+}
+
+func TestBoardService_Import_RejectsPathTraversal(t *testing.T) {
+	boardService, cardService := setupExportTest(t)
+	mustAdd(t, cardService, AddCardInput{BoardName: "main", Title: "Safe"})
+	mustAdd(t, cardService, AddCardInput{BoardName: "main", Title: "Escapes"})
+
+	export, err := boardService.Export("main")
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	export.Cards[1].ID = "../../../x"
+	data, _ := json.Marshal(export)
+
+	if err := boardService.Import(data, "other"); !kanerr.IsCode(err, kanerr.CodeInvalidField) {
+		t.Errorf("Expected validation error for traversal id, got %v", err)
+	}
+	if boardService.Exists("other") {
+		t.Error("Rejected import should not create the board")
+	}
+}
+
+func TestBoardService_Statistics(t *testing.T) {
+	boardStore := newTestBoardStore()
+	cardStore := newTestCardStore()
+	svc := NewBoardService(boardStore, cardStore)
+	cfg := testBoardConfig("main") // backlog, in-progress, done
+	cfg.Columns[2].Done = true
+	boardStore.addBoard(cfg)
+
+	day := util.MillisPerDay
+	seed := []*model.Card{
+		{ID: "old", Column: "backlog", CreatedAtMillis: 1 * day, UpdatedAtMillis: 3 * day},
+		{ID: "new", Column: "backlog", CreatedAtMillis: 9 * day, UpdatedAtMillis: 9 * day},
+		{ID: "done-early", Column: "done", CreatedAtMillis: 1 * day, UpdatedAtMillis: 2 * day},
+		{ID: "done-late", Column: "done", CreatedAtMillis: 5 * day, UpdatedAtMillis: 8 * day},
+	}
+	for _, c := range seed {
+		if err := cardStore.Create("main", c); err != nil {
+			t.Fatalf("seed Create failed: %v", err)
+		}
+	}
+
+	stats, err := svc.Statistics("main", 4*day, 10*day)
+	if err != nil {
+		t.Fatalf("Statistics failed: %v", err)
+	}
+
+	want := []ColumnStats{
+		{Name: "backlog", Count: 2, AvgAgeMillis: day, Oldest: seed[0], Newest: seed[1]},
+		{Name: "in-progress"},
+		{Name: "done", Count: 2, AvgAgeMillis: 2 * day, Oldest: seed[2], Newest: seed[3]},
+	}
+	if !reflect.DeepEqual(stats.Columns, want) {
+		t.Errorf("Columns = %+v, want %+v", stats.Columns, want)
+	}
+	if stats.TotalCards != 4 || stats.DoneCount != 2 {
+		t.Errorf("Expected 4 cards, 2 done; got %d, %d", stats.TotalCards, stats.DoneCount)
+	}
+	// Only "new" and "done-late" were created in the window, and only
+	// "done-late" was completed in it.
+	if stats.CreatedInWindow != 2 || stats.CompletedInWindow != 1 {
+		t.Errorf("Expected 2 created, 1 completed in window; got %d, %d", stats.CreatedInWindow, stats.CompletedInWindow)
+	}
+	if stats.AvgCycleTimeMillis != 2*day {
+		t.Errorf("Expected avg cycle time of 2 days, got %dms", stats.AvgCycleTimeMillis)
+	}
+	if got := stats.ThroughputPerDay; got < 0.166 || got > 0.167 {
+		t.Errorf("Expected throughput of 1/6 per day, got %v", got)
+	}
+
+	if _, err := svc.Statistics("main", 10*day, 4*day); !kanerr.IsCode(err, kanerr.CodeInvalidField) {
+		t.Errorf("Expected validation error for since > until, got %v", err)
+	}
+	if _, err := svc.Statistics("missing", 0, 0); !kanerr.IsCode(err, kanerr.CodeBoardNotFound) {
+		t.Errorf("Expected not-found error for missing board, got %v", err)
+	}
+
+	// A done card archived within the window still counts toward throughput.
+	archived := &model.Card{ID: "archived", Archived: true, LastColumn: "done", CreatedAtMillis: 5 * day, UpdatedAtMillis: 12 * day,
+		History: []model.HistoryEntry{{Field: "column", Value: "done", At: 7 * day}}}
+	if err := cardStore.Create("main", archived); err != nil {
+		t.Fatalf("seed Create failed: %v", err)
+	}
+	stats, err = svc.Statistics("main", 4*day, 10*day)
+	if err != nil {
+		t.Fatalf("Statistics failed: %v", err)
+	}
+	if stats.TotalCards != 4 || stats.DoneCount != 2 || stats.CompletedInWindow != 2 {
+		t.Errorf("Expected 4 cards, 2 done, 2 completed in window; got %d, %d, %d", stats.TotalCards, stats.DoneCount, stats.CompletedInWindow)
+	}
+	if err := cardStore.Delete("main", archived.ID); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+
+	// Done means the board's done columns, not its last column.
+	cfg.Columns[2].Done = false
+	cfg.DoneColumns = []string{"backlog"}
+	stats, err = svc.Statistics("main", 4*day, 10*day)
+	if err != nil {
+		t.Fatalf("Statistics failed: %v", err)
+	}
+	if stats.DoneCount != 2 || stats.CompletedInWindow != 1 {
+		t.Errorf("Expected the 2 backlog cards done, 1 in the window; got %d, %d", stats.DoneCount, stats.CompletedInWindow)
+	}
+}
+
+func TestBoardService_GetColumnSummary(t *testing.T) {
+	boardStore := newTestBoardStore()
+	cardStore := newTestCardStore()
+	svc := NewBoardService(boardStore, cardStore)
+	cfg := testBoardConfig("main") // backlog, in-progress, done
+	cfg.Columns[0].Limit = 4
+	cfg.Columns[1].Limit = 1
+	boardStore.addBoard(cfg)
+
+	add := func(id, column string) {
+		t.Helper()
+		if err := cardStore.Create("main", &model.Card{ID: id, Column: column}); err != nil {
+			t.Fatalf("seed Create failed: %v", err)
+		}
+	}
+	add("a", "backlog")
+	add("b", "in-progress")
+
+	summaries, err := svc.GetColumnSummary("main")
+	if err != nil {
+		t.Fatalf("GetColumnSummary failed: %v", err)
+	}
+	want := []ColumnSummary{
+		{Name: "backlog", CardCount: 1, Limit: 4, PercentFull: 25},
+		{Name: "in-progress", CardCount: 1, Limit: 1, PercentFull: 100, AtLimit: true},
+		{Name: "done"},
+	}
+	if !reflect.DeepEqual(summaries, want) {
+		t.Errorf("GetColumnSummary = %+v, want %+v", summaries, want)
+	}
+
+	add("c", "backlog")
+	count, err := svc.GetCardCount("main", "backlog")
+	if err != nil {
+		t.Fatalf("GetCardCount failed: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("Expected 2 cards in backlog after adding one, got %d", count)
+	}
+
+	if _, err := svc.GetCardCount("main", "missing"); !kanerr.IsCode(err, kanerr.CodeColumnNotFound) {
+		t.Errorf("Expected not-found error for missing column, got %v", err)
+	}
+	if _, err := svc.GetColumnSummary("missing"); !kanerr.IsCode(err, kanerr.CodeBoardNotFound) {
+		t.Errorf("Expected not-found error for missing board, got %v", err)
+	}
+}
+
+func TestBoardService_CompletionStats(t *testing.T) {
+	seed := []*model.Card{
+		{ID: "a", Column: "backlog"},
+		{ID: "b", Column: "in-progress"},
+		{ID: "c", Column: "done"},
+		{ID: "d", Column: "done"},
+	}
+
+	cases := []struct {
+		name      string
+		configure func(cfg *model.BoardConfig)
+		wantDone  int
+		wantPct   float64
+		wantCols  []float64 // per-column CompletionPct
+	}{
+		{
+			name:      "no done column",
+			configure: func(cfg *model.BoardConfig) {},
+			wantDone:  0, wantPct: 0, wantCols: []float64{0, 0, 0},
+		},
+		{
+			name:      "done flag",
+			configure: func(cfg *model.BoardConfig) { cfg.Columns[2].Done = true },
+			wantDone:  2, wantPct: 50, wantCols: []float64{0, 0, 100},
+		},
+		{
+			name: "done_columns",
+			configure: func(cfg *model.BoardConfig) {
+				cfg.Columns = append(cfg.Columns, model.Column{Name: "closed"})
+				cfg.DoneColumns = []string{"done", "closed"}
+			},
+			wantDone: 2, wantPct: 50, wantCols: []float64{0, 0, 100, 0},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			boardStore := newTestBoardStore()
+			cardStore := newTestCardStore()
+			svc := NewBoardService(boardStore, cardStore)
+			cfg := testBoardConfig("main")
+			tc.configure(cfg)
+			boardStore.addBoard(cfg)
+			for _, c := range seed {
+				card := *c
+				if err := cardStore.Create("main", &card); err != nil {
+					t.Fatalf("seed Create failed: %v", err)
+				}
+			}
+
+			stats, err := svc.CompletionStats("main")
+			if err != nil {
+				t.Fatalf("CompletionStats failed: %v", err)
+			}
+			if stats.TotalCards != 4 || stats.TotalDone != tc.wantDone || stats.CompletionPct != tc.wantPct {
+				t.Errorf("Expected 4 cards, %d done (%v%%); got %d, %d (%v%%)",
+					tc.wantDone, tc.wantPct, stats.TotalCards, stats.TotalDone, stats.CompletionPct)
+			}
+			var gotCols []float64
+			for _, col := range stats.ColumnStats {
+				gotCols = append(gotCols, col.CompletionPct)
+			}
+			if !reflect.DeepEqual(gotCols, tc.wantCols) {
+				t.Errorf("Column CompletionPct = %v, want %v", gotCols, tc.wantCols)
+			}
+		})
+	}
+
+	svc := NewBoardService(newTestBoardStore(), newTestCardStore())
+	if _, err := svc.CompletionStats("missing"); !kanerr.IsCode(err, kanerr.CodeBoardNotFound) {
+		t.Errorf("Expected not found for missing board, got %v", err)
+	}
+}
+
+func TestBoardService_RunAutoArchive(t *testing.T) {
+	cardService, cardStore, boardStore := setupCardService()
+	svc := NewBoardService(boardStore, cardStore)
+	svc.SetCardService(cardService)
+	cfg := testBoardConfig("main")
+	cfg.Columns[2].Done = true
+	cfg.Columns[2].AutoArchiveAfterDays = 7
+	boardStore.addBoard(cfg)
+
+	now := util.NowMillis()
+	seed := []*model.Card{
+		{ID: "fresh", Column: "done", DoneAtMillis: now},
+		{ID: "stale", Column: "done", DoneAtMillis: now - 8*util.MillisPerDay},
+		{ID: "old-backlog", Column: "backlog", CreatedAtMillis: now - 30*util.MillisPerDay},
+	}
+	for _, c := range seed {
+		if err := cardStore.Create("main", c); err != nil {
+			t.Fatalf("seed Create failed: %v", err)
+		}
+	}
+
+	archived, err := svc.RunAutoArchiveWithIDs("main")
+	if err != nil {
+		t.Fatalf("RunAutoArchiveWithIDs failed: %v", err)
+	}
+	if !reflect.DeepEqual(archived, []string{"stale"}) {
+		t.Errorf("Expected only 'stale' archived, got %v", archived)
+	}
+	for id, wantArchived := range map[string]bool{"fresh": false, "stale": true, "old-backlog": false} {
+		card, _ := cardStore.Get("main", id)
+		if card.Archived != wantArchived {
+			t.Errorf("%s: Archived = %v, want %v", id, card.Archived, wantArchived)
+		}
+	}
+
+	// RunAutoArchive reports a count; cards archived earlier aren't counted again.
+	if err := cardStore.Create("main", &model.Card{ID: "stale-too", Column: "done", DoneAtMillis: now - 9*util.MillisPerDay}); err != nil {
+		t.Fatalf("seed Create failed: %v", err)
+	}
+	if n, err := svc.RunAutoArchive("main"); err != nil || n != 1 {
+		t.Errorf("RunAutoArchive = %d, %v; want 1, nil", n, err)
+	}
+}
+
+func TestBoardService_GetActiveSprint(t *testing.T) {
+	_, cardStore, boardStore := setupCardService()
+	svc := NewBoardService(boardStore, cardStore)
+	cfg := testBoardConfig("main")
+	now := util.NowMillis()
+	cfg.Columns[0].SprintStartMillis = now + util.MillisPerDay // not started yet
+	cfg.Columns[0].SprintEndMillis = now + 2*util.MillisPerDay
+	cfg.Columns[2].SprintStartMillis = now - 20*util.MillisPerDay // already ended
+	cfg.Columns[2].SprintEndMillis = now - 6*util.MillisPerDay
+	boardStore.addBoard(cfg)
+
+	if _, err := svc.GetActiveSprint("main"); !kanerr.IsCode(err, kanerr.CodeSprintNotFound) {
+		t.Fatalf("Expected no active sprint, got %v", err)
+	}
+
+	started, err := svc.StartSprint("main", "in-progress", 7)
+	if err != nil {
+		t.Fatalf("StartSprint failed: %v", err)
+	}
+	if got := started.SprintEndMillis - started.SprintStartMillis; got != 7*util.MillisPerDay {
+		t.Errorf("Expected a 7-day sprint, got %dms", got)
+	}
+
+	active, err := svc.GetActiveSprint("main")
+	if err != nil {
+		t.Fatalf("GetActiveSprint failed: %v", err)
+	}
+	if active.Name != "in-progress" {
+		t.Errorf("Expected the in-progress sprint, got %q", active.Name)
+	}
+}
+
+func TestBoardService_SetSprint(t *testing.T) {
+	_, cardStore, boardStore := setupCardService()
+	svc := NewBoardService(boardStore, cardStore)
+	boardStore.addBoard(testBoardConfig("main"))
+
+	if err := svc.SetSprint("main", "backlog", 2000, 1000); !kanerr.IsCode(err, kanerr.CodeInvalidField) {
+		t.Errorf("Expected invalid field for end before start, got %v", err)
+	}
+	if err := svc.SetSprint("main", "review", 1000, 2000); !kanerr.IsCode(err, kanerr.CodeColumnNotFound) {
+		t.Errorf("Expected column not found, got %v", err)
+	}
+	if _, err := svc.StartSprint("main", "backlog", -1); !kanerr.IsCode(err, kanerr.CodeInvalidField) {
+		t.Errorf("Expected invalid field for negative duration, got %v", err)
+	}
+
+	started, err := svc.StartSprint("main", "backlog", 0)
+	if err != nil {
+		t.Fatalf("StartSprint failed: %v", err)
+	}
+	if got := started.SprintEndMillis - started.SprintStartMillis; got != DefaultSprintDays*util.MillisPerDay {
+		t.Errorf("Expected the default sprint length, got %dms", got)
+	}
+
+	if err := svc.SetSprint("main", "backlog", 0, 0); err != nil {
+		t.Fatalf("Clearing the sprint failed: %v", err)
+	}
+	cfg, _ := boardStore.Get("main")
+	if cfg.GetColumn("backlog").HasSprint() {
+		t.Error("Expected the sprint to be cleared")
+	}
+}
+
+func TestBoardService_ColumnChanges_UpdateTransitionRules(t *testing.T) {
+	boardStore := newTestBoardStore()
+	svc := NewBoardService(boardStore, newTestCardStore())
+	cfg := testBoardConfig("main")
+	cfg.Columns[2].TransitionRules = []model.TransitionRule{
+		{FromColumn: "in-progress", RequiredFields: []string{"type"}},
+		{ForbidIfFields: []string{"labels"}},
+	}
+	boardStore.addBoard(cfg)
+
+	if err := svc.RenameColumn("main", "in-progress", "doing"); err != nil {
+		t.Fatalf("RenameColumn failed: %v", err)
+	}
+	got, _ := svc.Get("main")
+	if rules := got.GetColumn("done").TransitionRules; len(rules) != 2 || rules[0].FromColumn != "doing" {
+		t.Errorf("Expected from_column renamed to doing, got %+v", rules)
+	}
+
+	if _, err := svc.DeleteColumn("main", "doing"); err != nil {
+		t.Fatalf("DeleteColumn failed: %v", err)
+	}
+	got, _ = svc.Get("main")
+	want := []model.TransitionRule{{ForbidIfFields: []string{"labels"}}}
+	if rules := got.GetColumn("done").TransitionRules; !reflect.DeepEqual(rules, want) {
+		t.Errorf("Expected only the unscoped rule to remain, got %+v", rules)
+	}
+}
+
+func TestBoardService_AddCustomField(t *testing.T) {
+	boardService, _ := setupExportTest(t)
+
+	schema := model.CustomFieldSchema{
+		Type:    model.FieldTypeEnum,
+		Options: []model.CustomFieldOption{{Value: "low"}, {Value: "high", Color: "#ef4444"}},
+	}
+	if err := boardService.AddCustomField("main", "priority", schema); err != nil {
+		t.Fatalf("AddCustomField failed: %v", err)
+	}
+	cfg, err := boardService.Get("main")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if got := cfg.CustomFields["priority"]; !reflect.DeepEqual(got, schema) {
+		t.Errorf("Expected stored schema %+v, got %+v", schema, got)
+	}
+
+	tests := []struct {
+		name   string
+		field  string
+		schema model.CustomFieldSchema
+	}{
+		{"collision", "type", model.CustomFieldSchema{Type: model.FieldTypeString}},
+		{"reserved name", "title", model.CustomFieldSchema{Type: model.FieldTypeString}},
+		{"unknown type", "size", model.CustomFieldSchema{Type: "number"}},
+		{"enum without options", "size", model.CustomFieldSchema{Type: model.FieldTypeEnum}},
+		{"options on string", "size", model.CustomFieldSchema{Type: model.FieldTypeString, Options: []model.CustomFieldOption{{Value: "s"}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := boardService.AddCustomField("main", tt.field, tt.schema); err == nil {
+				t.Error("Expected error")
+			}
+		})
+	}
+	if err := boardService.AddCustomField("main", "type", model.CustomFieldSchema{Type: model.FieldTypeString}); !kanerr.IsCode(err, kanerr.CodeFieldAlreadyExists) {
+		t.Errorf("Expected AlreadyExists error for collision, got %v", err)
+	}
+}
+
+func TestBoardService_UpdateFieldOptions(t *testing.T) {
+	boardService, _ := setupExportTest(t)
+
+	add := []model.CustomFieldOption{{Value: "chore", Color: "#6b7280"}}
+	if err := boardService.UpdateFieldOptions("main", "type", add, []string{"bug"}); err != nil {
+		t.Fatalf("UpdateFieldOptions failed: %v", err)
+	}
+	cfg, _ := boardService.Get("main")
+	var values []string
+	for _, opt := range cfg.CustomFields["type"].Options {
+		values = append(values, opt.Value)
+	}
+	if want := []string{"feature", "task", "chore"}; !reflect.DeepEqual(values, want) {
+		t.Errorf("Expected options %v, got %v", want, values)
+	}
+
+	if err := boardService.UpdateFieldOptions("main", "type", add, nil); err == nil {
+		t.Error("Expected error adding a duplicate option")
+	}
+	if err := boardService.UpdateFieldOptions("main", "type", nil, []string{"bug"}); err == nil {
+		t.Error("Expected error removing a missing option")
+	}
+	if err := boardService.UpdateFieldOptions("main", "type", nil, []string{"feature", "task", "chore"}); err == nil {
+		t.Error("Expected error removing every option")
+	}
+	if err := boardService.UpdateFieldOptions("main", "missing", add, nil); !kanerr.IsCode(err, kanerr.CodeFieldNotFound) {
+		t.Errorf("Expected NotFound error for missing field, got %v", err)
+	}
+}
+
+func TestBoardService_UpdateCollaborators(t *testing.T) {
+	boardService, cardService := setupExportTest(t)
+
+	cfg, _ := boardService.Get("main")
+	cfg.CustomFields["owner"] = model.CustomFieldSchema{Type: model.FieldTypeUser}
+	if err := boardService.boardStore.Update(cfg); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	for _, name := range []string{"alice", "bob"} {
+		if err := boardService.AddCollaborator("main", name); err != nil {
+			t.Fatalf("AddCollaborator(%s) failed: %v", name, err)
+		}
+	}
+	if err := boardService.AddCollaborator("main", "alice"); !kanerr.IsCode(err, kanerr.CodeInvalidField) {
+		t.Errorf("Expected validation error adding a duplicate, got %v", err)
+	}
+	mustAdd(t, cardService, AddCardInput{BoardName: "main", Title: "Owned", CustomFields: map[string]string{"owner": "bob"}})
+
+	// Removing a collaborator who still owns a card succeeds; the caller is
+	// told how many cards still name them.
+	stillAssigned, err := boardService.RemoveCollaborator("main", "bob")
+	if err != nil {
+		t.Fatalf("RemoveCollaborator failed: %v", err)
+	}
+	if stillAssigned != 1 {
+		t.Errorf("Expected 1 card still assigned to bob, got %d", stillAssigned)
+	}
+	cfg, _ = boardService.Get("main")
+	if !reflect.DeepEqual(cfg.Collaborators, []string{"alice"}) {
+		t.Errorf("Collaborators = %v, want [alice]", cfg.Collaborators)
+	}
+
+	if _, err := boardService.RemoveCollaborator("main", "bob"); !kanerr.IsCode(err, kanerr.CodeInvalidField) {
+		t.Errorf("Expected validation error removing a non-collaborator, got %v", err)
+	}
+}
+
+func TestBoardService_SprintVelocity(t *testing.T) {
+	boardService, cardService := setupExportTest(t)
+
+	cfg, _ := boardService.Get("main")
+	cfg.CustomFields[StoryPointsField] = model.CustomFieldSchema{Type: model.FieldTypeInteger}
+	if err := boardService.boardStore.Update(cfg); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	now := util.NowMillis()
+	cards := []struct {
+		points         string
+		column         string
+		doneDaysAgo    int64 // 0: no DoneAtMillis, so UpdatedAtMillis counts
+		updatedDaysAgo int64
+	}{
+		{points: "3", column: "done", doneDaysAgo: 1},
+		{points: "5", column: "done", doneDaysAgo: 6},
+		{points: "8", column: "done", doneDaysAgo: 10},   // outside the window
+		{points: "13", column: "backlog"},                // not done
+		{points: "2", column: "done", updatedDaysAgo: 2}, // no done time recorded
+	}
+	for i, c := range cards {
+		card := mustAdd(t, cardService, AddCardInput{
+			BoardName:    "main",
+			Title:        fmt.Sprintf("Card %d", i),
+			Column:       c.column,
+			CustomFields: map[string]string{StoryPointsField: c.points},
+		})
+		card.DoneAtMillis = 0
+		if c.doneDaysAgo > 0 {
+			card.DoneAtMillis = now - c.doneDaysAgo*util.MillisPerDay
+		}
+		card.UpdatedAtMillis = now - c.updatedDaysAgo*util.MillisPerDay
+		if err := boardService.cardStore.Update("main", card); err != nil {
+			t.Fatalf("Update card failed: %v", err)
+		}
+	}
+
+	velocity, err := boardService.SprintVelocity("main", "done", 7)
+	if err != nil {
+		t.Fatalf("SprintVelocity failed: %v", err)
+	}
+	if velocity != 10 {
+		t.Errorf("Expected velocity 10 (3 + 5 + 2), got %v", velocity)
+	}
+
+	if _, err := boardService.SprintVelocity("main", "missing", 7); !kanerr.IsCode(err, kanerr.CodeColumnNotFound) {
+		t.Errorf("Expected NotFound for missing column, got %v", err)
+	}
+	if _, err := boardService.SprintVelocity("main", "done", 0); !kanerr.IsCode(err, kanerr.CodeInvalidField) {
+		t.Errorf("Expected validation error for a zero window, got %v", err)
+	}
+}
+
+func TestBoardService_DeleteCustomField(t *testing.T) {
+	boardService, cardService := setupExportTest(t)
+
+	cfg, _ := boardService.Get("main")
+	cfg.CardDisplay = model.CardDisplayConfig{TypeIndicator: "type", Badges: []string{"labels", "type"}}
+	cfg.Columns[2].TransitionRules = []model.TransitionRule{{RequiredFields: []string{"type"}}}
+	if err := boardService.boardStore.Update(cfg); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	bug := mustAdd(t, cardService, AddCardInput{BoardName: "main", Title: "Fix crash", CustomFields: map[string]string{"type": "bug"}})
+	mustAdd(t, cardService, AddCardInput{BoardName: "main", Title: "Plain"})
+	archived := mustAdd(t, cardService, AddCardInput{BoardName: "main", Title: "Old", CustomFields: map[string]string{"type": "task"}})
+	if err := cardService.Archive("main", archived.ID); err != nil {
+		t.Fatalf("Archive failed: %v", err)
+	}
+
+	cleared, err := boardService.DeleteCustomField("main", "type")
+	if err != nil {
+		t.Fatalf("DeleteCustomField failed: %v", err)
+	}
+	if cleared != 2 {
+		t.Errorf("Expected 2 cards cleared, got %d", cleared)
+	}
+
+	for _, id := range []string{bug.ID, archived.ID} {
+		card, err := cardService.Get("main", id)
+		if err != nil {
+			t.Fatalf("Get failed: %v", err)
+		}
+		if _, ok := card.CustomFields["type"]; ok {
+			t.Errorf("Expected type cleared from card %s, got %v", id, card.CustomFields)
+		}
+	}
+
+	cfg, _ = boardService.Get("main")
+	if _, ok := cfg.CustomFields["type"]; ok {
+		t.Error("Expected field removed from board")
+	}
+	if cfg.CardDisplay.TypeIndicator != "" || !reflect.DeepEqual(cfg.CardDisplay.Badges, []string{"labels"}) {
+		t.Errorf("Expected card_display references dropped, got %+v", cfg.CardDisplay)
+	}
+	if rule := cfg.Columns[2].TransitionRules[0]; len(rule.RequiredFields) != 0 {
+		t.Errorf("Expected transition rule reference dropped, got %+v", rule)
+	}
+
+	if _, err := boardService.DeleteCustomField("main", "type"); !kanerr.IsCode(err, kanerr.CodeFieldNotFound) {
+		t.Errorf("Expected NotFound error deleting again, got %v", err)
+	}
+}
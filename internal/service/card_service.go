@@ -1,16 +1,31 @@
 package service
 
 import (
+	"encoding/csv"
+	"errors"
 	"fmt"
+	"io"
+	"maps"
+	"net/url"
+	"os"
+	"regexp"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"text/template"
+	"time"
+	"unicode/utf8"
 
 	"github.com/amterp/kan/internal/id"
 
 	kanerr "github.com/amterp/kan/internal/errors"
+	"github.com/amterp/kan/internal/metrics"
 	"github.com/amterp/kan/internal/model"
 	"github.com/amterp/kan/internal/store"
 	"github.com/amterp/kan/internal/util"
+	"golang.org/x/sync/errgroup"
 )
 
 // CardService handles card operations.
@@ -19,6 +34,9 @@ type CardService struct {
 	boardStore   store.BoardStore
 	aliasService *AliasService
 	hookService  *HookService
+	auditStore   store.AuditStore
+	auditActor   func() string
+	cardLocks    sync.Map // board name + "/" + card ID -> *sync.Mutex
 }
 
 // NewCardService creates a new card service.
@@ -36,6 +54,37 @@ func (s *CardService) SetHookService(hookService *HookService) {
 	s.hookService = hookService
 }
 
+// SetAuditStore enables the audit log. When set, Add, Update, Delete, and card
+// moves append an entry attributed to actor. actor is called only when an
+// entry is written, so resolving it (e.g. from git config) costs nothing on
+// read-only paths.
+func (s *CardService) SetAuditStore(auditStore store.AuditStore, actor func() string) {
+	s.auditStore = auditStore
+	s.auditActor = actor
+}
+
+// recordAudit appends a card entry to the audit log, if enabled. The mutation
+// has already been persisted, so a failed append is reported but not returned.
+func (s *CardService) recordAudit(boardName, cardID, action string, delta map[string]any) {
+	if s.auditStore == nil {
+		return
+	}
+	entry := model.AuditEntry{
+		Timestamp:  util.NowMillis(),
+		BoardName:  boardName,
+		EntityType: model.AuditEntityCard,
+		EntityID:   cardID,
+		Action:     action,
+		Delta:      delta,
+	}
+	if s.auditActor != nil {
+		entry.Actor = s.auditActor()
+	}
+	if err := s.auditStore.Append(entry); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to write audit log: %v\n", err)
+	}
+}
+
 // AddCardInput contains the input for adding a card.
 type AddCardInput struct {
 	BoardName    string
@@ -44,13 +93,18 @@ type AddCardInput struct {
 	Column       string
 	Parent       string
 	Creator      string
+	DueAtMillis  int64             // optional deadline (0 = unset)
+	BlockedBy    *[]string         // IDs or aliases of cards blocking this one (nil = none)
 	CustomFields map[string]string // custom fields to set (parsed from key=value)
+	Labels       *[]string         // labels to set (nil = none); overrides CustomFields["labels"]
 
 	// Placement within the target column. At most one should be set; when none
 	// is set the card is appended to the end (the historical default).
 	Position   *int   // explicit index (0 = top, -1 = end, negatives count from end)
 	BeforeCard string // canonical ID of the card to insert before
 	AfterCard  string // canonical ID of the card to insert after
+
+	Force bool // add even if the target column is at its WIP limit
 }
 
 // EditCardInput contains the input for editing a card.
@@ -63,13 +117,18 @@ type EditCardInput struct {
 	Column        *string           // nil = no change
 	Parent        *string           // nil = no change, empty string = clear parent
 	Alias         *string           // nil = no change
+	DueAtMillis   *int64            // nil = no change, 0 = clear due date
+	BlockedBy     *[]string         // nil = no change, empty = clear; IDs or aliases
 	CustomFields  map[string]string // fields to set/update (parsed from key=value)
+	Labels        *[]string         // nil = no change, empty = clear; overrides CustomFields["labels"]
 
 	// Placement within a column. Any of these triggers a move (which may be an
 	// in-place reorder when Column is nil). At most one should be set.
 	Position   *int   // explicit index (0 = top, -1 = end, negatives count from end)
 	BeforeCard string // canonical ID of the card to insert before
 	AfterCard  string // canonical ID of the card to insert after
+
+	Force bool // move even if the target column is at its WIP limit
 }
 
 // Add creates a new card.
@@ -77,15 +136,158 @@ type EditCardInput struct {
 // Hooks are executed after the card is fully persisted. Hook failures are non-fatal
 // and reported in the results rather than as errors.
 func (s *CardService) Add(input AddCardInput) (*model.Card, []*HookResult, error) {
+	card, boardCfg, hooks, err := s.create(input)
+	if err != nil || len(hooks) == 0 {
+		return card, nil, err
+	}
+
+	hookResults := s.hookService.ExecuteHooks(hooks, card, input.BoardName)
+
+	// Re-fetch card after hooks to capture any modifications they made.
+	// Hooks can modify cards via commands like `kan edit`, so we need
+	// to return the card's state AFTER hook execution, not before.
+	if updatedCard, err := s.cardStore.Get(input.BoardName, card.ID); err == nil {
+		card = updatedCard
+		s.applyHookFields(input.BoardName, card, boardCfg, hookResults)
+	}
+	// If re-fetch fails, we still return the original card (non-fatal)
+
+	return card, hookResults, nil
+}
+
+// AddWithAsyncHooks creates a card like Add but doesn't wait for pattern
+// hooks: they run in the background and the returned task ID can be polled
+// via HookService.PollTask. The task ID is empty when no hooks matched. The
+// returned card is its state before any hook ran.
+//
+// Fields set by hook output are applied to the card's latest state under its
+// card lock, so edits made while the hooks ran aren't lost. onUpdate, if
+// non-nil, is then called with the updated card.
+func (s *CardService) AddWithAsyncHooks(input AddCardInput, onUpdate func(*model.Card)) (*model.Card, string, error) {
+	card, boardCfg, hooks, err := s.create(input)
+	if err != nil || len(hooks) == 0 {
+		return card, "", err
+	}
+
+	taskID := s.hookService.ExecuteHooksAsync(hooks, card, input.BoardName, func(results []*HookResult) {
+		defer s.lockCard(input.BoardName, card.ID)()
+		updatedCard, err := s.cardStore.Get(input.BoardName, card.ID)
+		if err != nil {
+			return
+		}
+		if s.applyHookFields(input.BoardName, updatedCard, boardCfg, results) && onUpdate != nil {
+			onUpdate(updatedCard)
+		}
+	})
+	return card, taskID, nil
+}
+
+// lockCard serializes read-modify-write cycles on a card within this process
+// and returns the function that releases it. Holders must not call other
+// methods that lock the same card.
+func (s *CardService) lockCard(boardName, cardID string) (unlock func()) {
+	value, _ := s.cardLocks.LoadOrStore(boardName+"/"+cardID, &sync.Mutex{})
+	mu := value.(*sync.Mutex)
+	mu.Lock()
+	return mu.Unlock
+}
+
+// ApplyTemplate creates a card from one of the board's card templates. The
+// template's title pattern and default description are expanded with vars;
+// referencing a variable that isn't in vars is an error. The card goes into
+// the template's default column (or the board's) with the template's default
+// custom fields. The card has no creator; see ApplyTemplateAs.
+func (s *CardService) ApplyTemplate(boardName, templateName string, vars map[string]string) (*model.Card, error) {
+	card, _, err := s.ApplyTemplateAs(boardName, templateName, "", vars)
+	return card, err
+}
+
+// ApplyTemplateAs is ApplyTemplate with the card's creator set. It also returns
+// the results of the pattern hooks the new card's title matched, as Add does.
+func (s *CardService) ApplyTemplateAs(boardName, templateName, creator string, vars map[string]string) (*model.Card, []*HookResult, error) {
+	input, err := s.expandTemplate(boardName, templateName, vars)
+	if err != nil {
+		return nil, nil, err
+	}
+	input.Creator = creator
+	return s.Add(input)
+}
+
+// expandTemplate builds the AddCardInput for a template application.
+func (s *CardService) expandTemplate(boardName, templateName string, vars map[string]string) (AddCardInput, error) {
+	boardCfg, err := s.boardStore.Get(boardName)
+	if err != nil {
+		return AddCardInput{}, err
+	}
+	tmpl := boardCfg.GetTemplate(templateName)
+	if tmpl == nil {
+		return AddCardInput{}, kanerr.TemplateNotFound(templateName, boardName)
+	}
+
+	title, err := executeCardTemplate(tmpl.Name, tmpl.TitlePattern, vars)
+	if err != nil {
+		return AddCardInput{}, err
+	}
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return AddCardInput{}, kanerr.InvalidField("title", fmt.Sprintf("template %q expanded to an empty title", tmpl.Name))
+	}
+	description, err := executeCardTemplate(tmpl.Name, tmpl.DefaultDescription, vars)
+	if err != nil {
+		return AddCardInput{}, err
+	}
+
+	return AddCardInput{
+		BoardName:    boardName,
+		Title:        title,
+		Description:  description,
+		Column:       tmpl.DefaultColumn,
+		CustomFields: maps.Clone(tmpl.DefaultCustomFields),
+	}, nil
+}
+
+// executeCardTemplate expands one text/template string of a card template.
+// Missing variables are reported rather than rendered as "<no value>".
+func executeCardTemplate(name, text string, vars map[string]string) (string, error) {
+	t, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", kanerr.InvalidField("template", fmt.Sprintf("template %q is invalid: %v", name, err))
+	}
+	if vars == nil {
+		vars = map[string]string{}
+	}
+	var buf strings.Builder
+	if err := t.Execute(&buf, vars); err != nil {
+		if m := missingTemplateVar.FindStringSubmatch(err.Error()); m != nil {
+			return "", kanerr.InvalidField("vars", fmt.Sprintf("template %q needs variable %q", name, m[1]))
+		}
+		return "", kanerr.InvalidField("vars", fmt.Sprintf("template %q: %v", name, err))
+	}
+	return buf.String(), nil
+}
+
+// missingTemplateVar extracts the variable name from text/template's
+// missingkey=error failure.
+var missingTemplateVar = regexp.MustCompile(`map has no entry for key "([^"]*)"`)
+
+// create validates and persists a new card, returning it along with the board
+// config and the pattern hooks that match its title (nil without a hook
+// service). Shared by Add and AddWithAsyncHooks, which differ only in how the
+// hooks are run.
+func (s *CardService) create(input AddCardInput) (*model.Card, *model.BoardConfig, []model.PatternHook, error) {
+	if input.DueAtMillis < 0 {
+		return nil, nil, nil, kanerr.InvalidField("due_at_millis", "cannot be negative")
+	}
+
 	// Get board config
 	boardCfg, err := s.boardStore.Get(input.BoardName)
 	if err != nil {
-		return nil, nil, err // Already wrapped with proper error type by store
+		return nil, nil, nil, err // Already wrapped with proper error type by store
 	}
 
-	allCards, err := s.cardStore.List(input.BoardName)
+	allCards, err := s.cardStore.List(input.BoardName, false)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	// Determine column: explicit wins, otherwise infer from any anchor, else the
@@ -93,23 +295,22 @@ func (s *CardService) Add(input AddCardInput) (*model.Card, []*HookResult, error
 	column, err := resolveTargetColumn(allCards, input.Column, boardCfg.GetDefaultColumn(),
 		input.BeforeCard, input.AfterCard)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 	if !boardCfg.HasColumn(column) {
-		return nil, nil, kanerr.ColumnNotFound(column, input.BoardName)
+		return nil, nil, nil, kanerr.ColumnNotFound(column, input.BoardName)
 	}
 
 	// Check column limit by counting existing cards in the column
-	col := boardCfg.GetColumn(column)
 	colCards := cardsInColumn(allCards, column)
-	if col.Limit > 0 && len(colCards) >= col.Limit {
-		return nil, nil, kanerr.ColumnLimitExceeded(column, col.Limit)
+	if !input.Force && boardCfg.IsAtCapacity(column, len(colCards)) {
+		return nil, nil, nil, kanerr.ColumnAtLimit(column, boardCfg.GetColumn(column).Limit)
 	}
 
 	// Compute position from the requested placement (defaults to end).
 	idx, err := resolveInsertIndex(colCards, input.Position, input.BeforeCard, input.AfterCard)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 	position := computePosition(colCards, idx)
 
@@ -117,7 +318,7 @@ func (s *CardService) Add(input AddCardInput) (*model.Card, []*HookResult, error
 	cardID := id.Generate(id.Card)
 	alias, err := s.aliasService.GenerateAlias(input.BoardName, input.Title, "")
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	now := util.NowMillis()
@@ -129,6 +330,7 @@ func (s *CardService) Add(input AddCardInput) (*model.Card, []*HookResult, error
 		Description:     input.Description,
 		Parent:          input.Parent,
 		Creator:         input.Creator,
+		DueAtMillis:     input.DueAtMillis,
 		CreatedAtMillis: now,
 		UpdatedAtMillis: now,
 		Column:          column,
@@ -137,36 +339,193 @@ func (s *CardService) Add(input AddCardInput) (*model.Card, []*HookResult, error
 			{Field: "column", Value: column, At: now},
 		},
 	}
+	stampDone(card, boardCfg, now)
 
-	// Apply custom fields if provided
-	if len(input.CustomFields) > 0 {
-		if err := s.validateAndApplyCustomFields(card, boardCfg, input.CustomFields); err != nil {
-			return nil, nil, err
+	// Labels work on any board: add the field if the board lacks it. The
+	// config is saved only once the card is known to be valid.
+	addedLabelsField := input.Labels != nil && len(*input.Labels) > 0 && addLabelsField(boardCfg)
+
+	if err := s.applyFieldEdits(card, boardCfg, input.CustomFields, input.Labels); err != nil {
+		return nil, nil, nil, err
+	}
+
+	if input.BlockedBy != nil {
+		blockers, err := s.resolveBlockers(input.BoardName, cardID, *input.BlockedBy)
+		if err != nil {
+			return nil, nil, nil, err
 		}
+		card.BlockedBy = blockers
 	}
 
+	if addedLabelsField {
+		if err := s.boardStore.Update(boardCfg); err != nil {
+			return nil, nil, nil, err
+		}
+	}
 	if err := s.cardStore.Create(input.BoardName, card); err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
+	}
+	if err := s.syncBlocks(input.BoardName, cardID, nil, card.BlockedBy); err != nil {
+		return nil, nil, nil, err
 	}
+	s.recordAudit(input.BoardName, cardID, model.AuditActionCreated,
+		map[string]any{"title": card.Title, "column": card.Column})
+	metrics.CardCreatedTotal.WithLabelValues(input.BoardName).Inc()
 
-	// Execute pattern hooks if configured
-	var hookResults []*HookResult
+	var matchingHooks []model.PatternHook
 	if s.hookService != nil && len(boardCfg.PatternHooks) > 0 {
-		matchingHooks := s.hookService.FindMatchingHooks(boardCfg.PatternHooks, input.Title)
-		if len(matchingHooks) > 0 {
-			hookResults = s.hookService.ExecuteHooks(matchingHooks, cardID, input.BoardName)
-
-			// Re-fetch card after hooks to capture any modifications they made.
-			// Hooks can modify cards via commands like `kan edit`, so we need
-			// to return the card's state AFTER hook execution, not before.
-			if updatedCard, err := s.cardStore.Get(input.BoardName, cardID); err == nil {
-				card = updatedCard
+		matchingHooks = s.hookService.FindMatchingHooks(boardCfg.PatternHooks, input.Title)
+	}
+
+	return card, boardCfg, matchingHooks, nil
+}
+
+// applyHookFields sets custom fields from key=value lines in the output of
+// successful hooks, in hook order, and saves the card once if anything
+// changed. Like hook failures, invalid values are non-fatal: they're skipped
+// and left out of the result's FieldsSet. Reports whether the card was saved.
+func (s *CardService) applyHookFields(boardName string, card *model.Card, boardCfg *model.BoardConfig, results []*HookResult) bool {
+	updated := false
+	for _, result := range results {
+		fields := s.HookFieldChanges(card, boardCfg, result)
+		if len(fields) == 0 {
+			continue
+		}
+		if err := s.validateAndApplyCustomFields(card, boardCfg, fields); err != nil {
+			continue
+		}
+		result.FieldsSet = fields
+		result.CardUpdated = true
+		updated = true
+	}
+	if !updated {
+		return false
+	}
+	// Non-fatal, like the re-fetch above: the card was already created.
+	return s.Update(boardName, card) == nil
+}
+
+// HookFieldChanges returns the custom fields a hook result's output would set
+// on card: the key=value lines naming a board field whose value the field
+// accepts. Failed hooks set nothing. The card isn't changed.
+func (s *CardService) HookFieldChanges(card *model.Card, boardCfg *model.BoardConfig, result *HookResult) map[string]string {
+	if !result.Success {
+		return nil
+	}
+	scratch := &model.Card{CustomFields: maps.Clone(card.CustomFields)}
+	fields := make(map[string]string)
+	for key, value := range parseHookFields(result.Stdout, boardCfg.CustomFields) {
+		if err := s.validateAndApplyCustomFields(scratch, boardCfg, map[string]string{key: value}); err == nil {
+			fields[key] = value
+		}
+	}
+	return fields
+}
+
+// CloneOptions controls how CardService.Clone copies a card.
+type CloneOptions struct {
+	TargetColumn string   // column for the clone (empty = same column as the source)
+	Title        string   // title for the clone (empty = the source's title)
+	TitlePrefix  string   // prepended to the title, e.g. "[COPY] "
+	Creator      string   // creator of the clone (empty = keep the source's creator)
+	Labels       []string // labels to add to those copied from the source
+}
+
+// Clone copies a card into a new card with a fresh ID and alias. Description,
+// parent, due date, and custom fields are copied; comments and history are not,
+// and timestamps are reset. The clone is appended to the end of its column.
+// Pattern hooks are not run, since the clone is not a newly authored card.
+func (s *CardService) Clone(boardName, sourceIDOrAlias string, opts CloneOptions) (*model.Card, error) {
+	source, err := s.FindByIDOrAlias(boardName, sourceIDOrAlias)
+	if err != nil {
+		return nil, err
+	}
+
+	boardCfg, err := s.boardStore.Get(boardName)
+	if err != nil {
+		return nil, err
+	}
+
+	// An archived source has no column; fall back to where it came from.
+	column := firstNonEmpty(opts.TargetColumn, source.Column, source.LastColumn)
+	if !boardCfg.HasColumn(column) && opts.TargetColumn == "" {
+		column = boardCfg.GetDefaultColumn()
+	}
+	if !boardCfg.HasColumn(column) {
+		return nil, kanerr.ColumnNotFound(column, boardName)
+	}
+
+	allCards, err := s.cardStore.List(boardName, false)
+	if err != nil {
+		return nil, err
+	}
+	colCards := cardsInColumn(allCards, column)
+	if boardCfg.IsAtCapacity(column, len(colCards)) {
+		return nil, kanerr.ColumnAtLimit(column, boardCfg.GetColumn(column).Limit)
+	}
+
+	title := opts.TitlePrefix + firstNonEmpty(opts.Title, source.Title)
+	cardID := id.Generate(id.Card)
+	alias, err := s.aliasService.GenerateAlias(boardName, title, "")
+	if err != nil {
+		return nil, err
+	}
+
+	now := util.NowMillis()
+	clone := &model.Card{
+		ID:              cardID,
+		Alias:           alias,
+		Title:           title,
+		Description:     source.Description,
+		Parent:          source.Parent,
+		Creator:         firstNonEmpty(opts.Creator, source.Creator),
+		DueAtMillis:     source.DueAtMillis,
+		CreatedAtMillis: now,
+		UpdatedAtMillis: now,
+		Column:          column,
+		Position:        computePosition(colCards, -1),
+		History: []model.HistoryEntry{
+			{Field: "column", Value: column, At: now},
+		},
+		CustomFields: copyCustomFields(source.CustomFields),
+	}
+
+	if len(opts.Labels) > 0 {
+		addedLabelsField := addLabelsField(boardCfg)
+		if err := applyLabels(clone, boardCfg, append(clone.GetLabels(), opts.Labels...)); err != nil {
+			return nil, err
+		}
+		if addedLabelsField {
+			if err := s.boardStore.Update(boardCfg); err != nil {
+				return nil, err
 			}
-			// If re-fetch fails, we still return the original card (non-fatal)
 		}
 	}
 
-	return card, hookResults, nil
+	if err := s.cardStore.Create(boardName, clone); err != nil {
+		return nil, err
+	}
+	return clone, nil
+}
+
+// copyCustomFields returns a copy of a card's custom fields that shares no
+// set slices with the original.
+func copyCustomFields(fields map[string]any) map[string]any {
+	if len(fields) == 0 {
+		return nil
+	}
+	copied := make(map[string]any, len(fields))
+	for k, v := range fields {
+		switch val := v.(type) {
+		case []any:
+			copied[k] = append([]any(nil), val...)
+		case []string:
+			copied[k] = append([]string(nil), val...)
+		default:
+			copied[k] = v
+		}
+	}
+	return copied
 }
 
 // Get retrieves a card by ID.
@@ -181,28 +540,143 @@ func (s *CardService) Update(boardName string, card *model.Card) error {
 		return err
 	}
 
+	var prev *model.Card
+	if s.auditStore != nil {
+		prev, _ = s.cardStore.Get(boardName, card.ID)
+	}
+
 	card.UpdatedAtMillis = util.NowMillis()
-	return s.cardStore.Update(boardName, card)
+	if err := s.cardStore.Update(boardName, card); err != nil {
+		return err
+	}
+	if prev != nil {
+		if delta := cardDelta(prev, card); len(delta) > 0 {
+			s.recordAudit(boardName, card.ID, model.AuditActionUpdated, delta)
+		}
+	}
+	return nil
+}
+
+// cardDelta returns the user-editable fields that differ between two versions
+// of a card, mapped to their new values (nil when a custom field was removed).
+// Column and position changes are audited as moves instead.
+func cardDelta(prev, next *model.Card) map[string]any {
+	delta := make(map[string]any)
+	if prev.Title != next.Title {
+		delta["title"] = next.Title
+	}
+	if prev.Description != next.Description {
+		delta["description"] = next.Description
+	}
+	if prev.Alias != next.Alias {
+		delta["alias"] = next.Alias
+	}
+	if prev.Parent != next.Parent {
+		delta["parent"] = next.Parent
+	}
+	if prev.DueAtMillis != next.DueAtMillis {
+		delta["due_at_millis"] = next.DueAtMillis
+	}
+	if !slices.Equal(prev.BlockedBy, next.BlockedBy) {
+		delta["blocked_by"] = next.BlockedBy
+	}
+	for k, v := range next.CustomFields {
+		if old, ok := prev.CustomFields[k]; !ok || formatCustomFieldValue(old) != formatCustomFieldValue(v) {
+			delta[k] = v
+		}
+	}
+	for k := range prev.CustomFields {
+		if _, ok := next.CustomFields[k]; !ok {
+			delta[k] = nil
+		}
+	}
+	return delta
 }
 
 // List returns all cards for a board, optionally filtered by column.
 // Cards are returned in column order (as defined in board config), sorted
 // by position within each column.
 func (s *CardService) List(boardName string, columnFilter string) ([]*model.Card, error) {
-	return s.listSorted(boardName, columnFilter, "", false)
+	return s.listSorted(boardName, columnFilter, ListOptions{})
+}
+
+// ListIncludingArchived is like List, but also returns archived cards. Archived
+// cards belong to no column, so they only appear when columnFilter is empty,
+// after the cards in board columns.
+func (s *CardService) ListIncludingArchived(boardName string, columnFilter string) ([]*model.Card, error) {
+	return s.listSorted(boardName, columnFilter, ListOptions{IncludeArchived: true})
 }
 
 // ListSorted is like List, but within each column the cards are ordered by the
 // given custom field instead of by their manual position. This is a
 // non-destructive view sort—card positions on disk are left untouched. An empty
-// sortField behaves exactly like List. See model.SortCardsByField for the
-// ordering rules (enum option order, unset-last, etc.).
+// sortField behaves exactly like List, and model.SortByDueDate ("due_date")
+// orders by due date. See model.SortCardsByField for the ordering rules (enum
+// option order, unset-last, etc.).
 func (s *CardService) ListSorted(boardName, columnFilter, sortField string, descending bool) ([]*model.Card, error) {
-	return s.listSorted(boardName, columnFilter, sortField, descending)
+	var keys []model.SortField
+	if sortField != "" {
+		keys = []model.SortField{{Field: sortField, Desc: descending}}
+	}
+	return s.listSorted(boardName, columnFilter, ListOptions{SortBy: keys})
+}
+
+// ListOptions controls how ListWithOptions orders and filters cards.
+type ListOptions struct {
+	// SortBy orders cards within each column by these keys, in priority order.
+	// Empty means manual position order. See model.SortCardsByFields.
+	SortBy          []model.SortField
+	IncludeArchived bool
+	// Streaming reads card files one at a time via CardStore.Stream and keeps
+	// only the cards that pass the column filter, instead of loading the
+	// whole board first. Worth it for filtered listings of very large boards.
+	Streaming bool
+}
+
+// ListWithOptions is like List, but sorts within each column by opts.SortBy.
+// Each sort field must be a built-in key (created_at, updated_at, title,
+// due_at) or a custom field defined on the board.
+func (s *CardService) ListWithOptions(boardName, columnFilter string, opts ListOptions) ([]*model.Card, error) {
+	if len(opts.SortBy) > 0 {
+		boardCfg, err := s.boardStore.Get(boardName)
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range opts.SortBy {
+			if _, ok := boardCfg.CustomFields[key.Field]; !ok && !model.IsBuiltinSortField(key.Field) {
+				return nil, kanerr.InvalidField("sort", fmt.Sprintf("unknown sort field %q", key.Field))
+			}
+		}
+	}
+	return s.listSorted(boardName, columnFilter, opts)
+}
+
+// ListPaginated returns one page of the cards List would return, along with
+// the total number of matching cards across all pages. Pages are 1-indexed.
+// Card order lives in the card files themselves (column + position), so every
+// card on the board is read to establish the ordering before slicing.
+func (s *CardService) ListPaginated(boardName, columnFilter string, page, perPage int) ([]*model.Card, int, error) {
+	cards, err := s.List(boardName, columnFilter)
+	if err != nil {
+		return nil, 0, err
+	}
+	return PaginateCards(cards, page, perPage)
 }
 
-func (s *CardService) listSorted(boardName, columnFilter, sortField string, descending bool) ([]*model.Card, error) {
-	cards, err := s.cardStore.List(boardName)
+func (s *CardService) listSorted(boardName, columnFilter string, opts ListOptions) ([]*model.Card, error) {
+	sortBy := opts.SortBy
+	var cards []*model.Card
+	var err error
+	if opts.Streaming {
+		cards, err = streamCards(s.cardStore, boardName, func(card *model.Card) bool {
+			if card.Archived && !opts.IncludeArchived {
+				return false
+			}
+			return columnFilter == "" || card.Column == columnFilter
+		})
+	} else {
+		cards, err = s.cardStore.List(boardName, opts.IncludeArchived)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -226,12 +700,12 @@ func (s *CardService) listSorted(boardName, columnFilter, sortField string, desc
 		grouped[card.Column] = append(grouped[card.Column], card)
 	}
 
-	// Order each group: by the requested custom field if one was given,
+	// Order each group: by the requested sort keys if any were given,
 	// otherwise by manual position (the default).
 	for col := range grouped {
 		grp := grouped[col]
-		if sortField != "" {
-			model.SortCardsByField(grp, boardCfg, sortField, descending)
+		if len(sortBy) > 0 {
+			model.SortCardsByFields(grp, boardCfg, sortBy)
 		} else {
 			sort.Slice(grp, func(i, j int) bool {
 				if grp[i].Position == grp[j].Position {
@@ -268,6 +742,27 @@ func (s *CardService) listSorted(boardName, columnFilter, sortField string, desc
 	return result, nil
 }
 
+// streamCards reads a board's cards through CardStore.Stream, keeping only
+// those keep accepts, so discarded cards never accumulate in memory. Like
+// CardStore.List, unreadable card files are skipped with a warning.
+func streamCards(cardStore store.CardStore, boardName string, keep func(*model.Card) bool) ([]*model.Card, error) {
+	stream, err := cardStore.Stream(boardName)
+	if err != nil {
+		return nil, err
+	}
+	cards := []*model.Card{}
+	for item := range stream {
+		if item.Err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: skipping %v\n", item.Err)
+			continue
+		}
+		if keep(item.Card) {
+			cards = append(cards, item.Card)
+		}
+	}
+	return cards, nil
+}
+
 // MoveCard moves a card to a different column at the bottom.
 func (s *CardService) MoveCard(boardName, cardID, targetColumn string) error {
 	return s.MoveCardWithPlacement(boardName, cardID, targetColumn, nil, "", "")
@@ -279,6 +774,69 @@ func (s *CardService) MoveCardAt(boardName, cardID, targetColumn string, positio
 	return s.MoveCardWithPlacement(boardName, cardID, targetColumn, &position, "", "")
 }
 
+// ReorderCards sets the order of the cards in a column. orderedIDs must list
+// exactly the column's current (unarchived) cards by ID, each once. Column
+// membership lives on the cards, so the column's existing positions are
+// handed out in the new order and only cards whose position changes are
+// rewritten.
+func (s *CardService) ReorderCards(boardName, columnName string, orderedIDs []string) error {
+	boardCfg, err := s.boardStore.Get(boardName)
+	if err != nil {
+		return err
+	}
+	if !boardCfg.HasColumn(columnName) {
+		return kanerr.ColumnNotFound(columnName, boardName)
+	}
+
+	allCards, err := s.cardStore.List(boardName, false)
+	if err != nil {
+		return err
+	}
+	colCards := cardsInColumn(allCards, columnName)
+
+	byID := make(map[string]*model.Card, len(colCards))
+	for _, card := range colCards {
+		byID[card.ID] = card
+	}
+	seen := make(map[string]bool, len(orderedIDs))
+	for _, cardID := range orderedIDs {
+		if byID[cardID] == nil || seen[cardID] {
+			return kanerr.InvalidField("card_ids", "reorder IDs must be a permutation of existing column cards")
+		}
+		seen[cardID] = true
+	}
+	if len(orderedIDs) != len(colCards) {
+		return kanerr.InvalidField("card_ids", "reorder IDs must be a permutation of existing column cards")
+	}
+
+	// Reuse the column's positions (already in ascending order) when they are
+	// distinct; ties can't express a strict order, so respace instead.
+	positions := make([]string, len(colCards))
+	for i, card := range colCards {
+		positions[i] = card.Position
+		if i > 0 && positions[i] == positions[i-1] {
+			positions = util.PositionInitial(len(colCards))
+			break
+		}
+	}
+
+	now := util.NowMillis()
+	for i, cardID := range orderedIDs {
+		card := byID[cardID]
+		if card.Position == positions[i] {
+			continue
+		}
+		card.Position = positions[i]
+		card.UpdatedAtMillis = now
+		if err := s.cardStore.Update(boardName, card); err != nil {
+			return err
+		}
+		s.recordAudit(boardName, card.ID, model.AuditActionMoved,
+			map[string]any{"from": columnName, "to": columnName})
+	}
+	return nil
+}
+
 // MoveCardWithPlacement moves a card to a target column at a placement determined
 // by exactly one of: an explicit index (position, non-nil), or an anchor card
 // (beforeID/afterID, by canonical ID). When none is given, the card is appended
@@ -287,6 +845,23 @@ func (s *CardService) MoveCardAt(boardName, cardID, targetColumn string, positio
 // in-place reorder).
 func (s *CardService) MoveCardWithPlacement(boardName, cardID, targetColumn string,
 	position *int, beforeID, afterID string) error {
+	return s.moveCardWithPlacement(boardName, cardID, targetColumn, position, beforeID, afterID, moveOptions{})
+}
+
+// moveOptions adjusts the checks moveCardWithPlacement makes.
+type moveOptions struct {
+	// force skips the target column's WIP limit and transition rules.
+	force bool
+	// pendingFields and pendingLabels are custom field and label edits that
+	// will be applied along with the move; transition rules are checked as if
+	// they already were.
+	pendingFields map[string]string
+	pendingLabels *[]string
+}
+
+// moveCardWithPlacement implements MoveCardWithPlacement.
+func (s *CardService) moveCardWithPlacement(boardName, cardID, targetColumn string,
+	position *int, beforeID, afterID string, opts moveOptions) error {
 
 	boardCfg, err := s.boardStore.Get(boardName)
 	if err != nil {
@@ -307,8 +882,13 @@ func (s *CardService) MoveCardWithPlacement(boardName, cardID, targetColumn stri
 		return fmt.Errorf("cannot move a card relative to itself")
 	}
 
+	// Archived cards have no column; moving one would leave it half-restored.
+	if card.Archived {
+		return kanerr.InvalidField("card", "card is archived; unarchive it first")
+	}
+
 	// Load all cards to determine positions and check limits
-	allCards, err := s.cardStore.List(boardName)
+	allCards, err := s.cardStore.List(boardName, false)
 	if err != nil {
 		return err
 	}
@@ -328,11 +908,24 @@ func (s *CardService) MoveCardWithPlacement(boardName, cardID, targetColumn stri
 	// Get sorted cards in target column (excluding the card being moved)
 	colCards := cardsInColumnExcluding(allCards, targetColumn, cardID)
 
-	// Check column limit for cross-column moves
-	if card.Column != targetColumn {
-		col := boardCfg.GetColumn(targetColumn)
-		if col.Limit > 0 && len(colCards) >= col.Limit {
-			return kanerr.ColumnLimitExceeded(targetColumn, col.Limit)
+	// Check column limit for cross-column moves (reordering within a full
+	// column is always allowed)
+	if !opts.force && card.Column != targetColumn && boardCfg.IsAtCapacity(targetColumn, len(colCards)) {
+		return kanerr.ColumnAtLimit(targetColumn, boardCfg.GetColumn(targetColumn).Limit)
+	}
+
+	if !opts.force {
+		candidate := card
+		if len(opts.pendingFields) > 0 || opts.pendingLabels != nil {
+			preview := *card
+			preview.CustomFields = maps.Clone(card.CustomFields)
+			if err := s.applyFieldEdits(&preview, boardCfg, opts.pendingFields, opts.pendingLabels); err != nil {
+				return err
+			}
+			candidate = &preview
+		}
+		if err := validateTransition(candidate, boardCfg, card.Column, targetColumn); err != nil {
+			return err
 		}
 	}
 
@@ -355,38 +948,243 @@ func (s *CardService) MoveCardWithPlacement(boardName, cardID, targetColumn stri
 		card.History = append(card.History, model.HistoryEntry{
 			Field: "column", Value: targetColumn, At: card.UpdatedAtMillis,
 		})
+		stampDone(card, boardCfg, card.UpdatedAtMillis)
 	}
 
-	return s.cardStore.Update(boardName, card)
+	if err := s.cardStore.Update(boardName, card); err != nil {
+		return err
+	}
+	s.recordAudit(boardName, card.ID, model.AuditActionMoved,
+		map[string]any{"from": prevColumn, "to": targetColumn})
+	if prevColumn != targetColumn {
+		metrics.CardMovedTotal.WithLabelValues(boardName).Inc()
+	}
+	return nil
 }
 
-// firstNonEmpty returns the first non-empty string, or "" if all are empty.
-func firstNonEmpty(vals ...string) string {
-	for _, v := range vals {
-		if v != "" {
-			return v
-		}
+// stampDone records when a card that just changed column entered a done
+// column, or clears the stamp if its new column isn't done.
+func stampDone(card *model.Card, boardCfg *model.BoardConfig, now int64) {
+	if boardCfg.IsDoneColumn(card.Column) {
+		card.DoneAtMillis = now
+	} else {
+		card.DoneAtMillis = 0
 	}
-	return ""
 }
 
-// resolveTargetColumn determines the destination column for a placement and
-// validates that an anchor card (if any) lives there. An empty explicitColumn
-// means "infer": from the anchor's column when an anchor is given, otherwise the
-// supplied fallbackColumn (e.g. the card's current column, or the board default).
-// This keeps column inference identical for both Add and MoveCardWithPlacement.
-func resolveTargetColumn(allCards []*model.Card, explicitColumn, fallbackColumn, beforeID, afterID string) (string, error) {
-	anchorID := firstNonEmpty(beforeID, afterID)
-	if anchorID == "" {
-		if explicitColumn != "" {
-			return explicitColumn, nil
-		}
-		return fallbackColumn, nil
+// validateTransition checks a move from fromColumn into toColumn against the
+// target column's transition rules. Moves within a column are never checked.
+func validateTransition(card *model.Card, boardCfg *model.BoardConfig, fromColumn, toColumn string) error {
+	if fromColumn == toColumn {
+		return nil
 	}
-
-	idx := indexOfCard(allCards, anchorID)
-	if idx < 0 {
-		return "", kanerr.CardNotFound(anchorID)
+	col := boardCfg.GetColumn(toColumn)
+	if col == nil {
+		return nil
+	}
+	for _, rule := range col.TransitionRules {
+		if !rule.AppliesTo(fromColumn) {
+			continue
+		}
+		var missing, forbidden []string
+		for _, field := range rule.RequiredFields {
+			if !transitionFieldSet(card, field) {
+				missing = append(missing, field)
+			}
+		}
+		for _, field := range rule.ForbidIfFields {
+			if transitionFieldSet(card, field) {
+				forbidden = append(forbidden, field)
+			}
+		}
+		if len(missing) > 0 {
+			return kanerr.TransitionViolation(toColumn, "missing required field(s): "+strings.Join(missing, ", "))
+		}
+		if len(forbidden) > 0 {
+			return kanerr.TransitionViolation(toColumn, "field(s) must be unset: "+strings.Join(forbidden, ", "))
+		}
+	}
+	return nil
+}
+
+// transitionFieldSet reports whether a card has a meaningful value for a
+// custom field: a non-empty string or set, any number, or true.
+func transitionFieldSet(card *model.Card, field string) bool {
+	switch v := card.CustomFields[field].(type) {
+	case nil:
+		return false
+	case string:
+		return v != ""
+	case bool:
+		return v
+	case []string:
+		return len(v) > 0
+	case []any:
+		return len(v) > 0
+	default:
+		return true
+	}
+}
+
+// BulkMoveCardInput contains the input for moving several cards at once.
+type BulkMoveCardInput struct {
+	BoardName string
+	CardIDs   []string // card IDs or aliases, in the order they should land
+	Column    string
+	Position  *int // index of the first card in the target column (nil = end)
+}
+
+// BulkMove moves several cards into one column, keeping them contiguous and in
+// the given order starting at Position. The board config and card list are
+// read once for the whole batch.
+//
+// The operation is all-or-nothing: every card is resolved and the column limit
+// checked before anything is written, and a BulkOperationError listing the
+// unresolvable IDs is returned if any are invalid. If a write fails partway,
+// cards already written are restored to their previous state.
+func (s *CardService) BulkMove(input BulkMoveCardInput) ([]*model.Card, error) {
+	if len(input.CardIDs) == 0 {
+		return nil, kanerr.InvalidField("card_ids", "at least one card is required")
+	}
+
+	boardCfg, err := s.boardStore.Get(input.BoardName)
+	if err != nil {
+		return nil, err
+	}
+	if !boardCfg.HasColumn(input.Column) {
+		return nil, kanerr.ColumnNotFound(input.Column, input.BoardName)
+	}
+
+	allCards, err := s.cardStore.List(input.BoardName, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// Resolve every card up front so a bad ID can't leave a half-applied batch.
+	var moving []*model.Card
+	var failed []string
+	seen := make(map[string]bool)
+	for _, idOrAlias := range input.CardIDs {
+		card := findCardByIDOrAlias(allCards, idOrAlias)
+		if card == nil {
+			failed = append(failed, idOrAlias)
+			continue
+		}
+		if seen[card.ID] {
+			continue
+		}
+		seen[card.ID] = true
+		moving = append(moving, card)
+	}
+	if len(failed) > 0 {
+		return nil, &kanerr.BulkOperationError{Operation: "move", FailedIDs: failed, Reason: "card not found"}
+	}
+
+	// Cards staying put in the target column, in order, bracket the insert.
+	var colCards []*model.Card
+	for _, c := range cardsInColumn(allCards, input.Column) {
+		if !seen[c.ID] {
+			colCards = append(colCards, c)
+		}
+	}
+
+	// The whole batch must fit, not just the first card.
+	if col := boardCfg.GetColumn(input.Column); col.Limit > 0 && len(colCards)+len(moving) > col.Limit {
+		return nil, kanerr.ColumnAtLimit(input.Column, col.Limit)
+	}
+
+	// Every card must satisfy the target column's transition rules.
+	for _, card := range moving {
+		if validateTransition(card, boardCfg, card.Column, input.Column) != nil {
+			failed = append(failed, card.ID)
+		}
+	}
+	if len(failed) > 0 {
+		return nil, &kanerr.BulkOperationError{Operation: "move", FailedIDs: failed, Reason: "transition rules not met"}
+	}
+
+	idx := -1
+	if input.Position != nil {
+		idx = *input.Position
+	}
+	positions := computePositions(colCards, idx, len(moving))
+
+	// Snapshot originals so a mid-batch write failure can be undone.
+	originals := make([]model.Card, len(moving))
+	for i, card := range moving {
+		originals[i] = *card
+	}
+
+	now := util.NowMillis()
+	for i, card := range moving {
+		prevColumn := card.Column
+		card.Column = input.Column
+		card.Position = positions[i]
+		card.UpdatedAtMillis = now
+		if prevColumn != input.Column {
+			card.History = append(card.History, model.HistoryEntry{
+				Field: "column", Value: input.Column, At: now,
+			})
+			stampDone(card, boardCfg, now)
+		}
+
+		if err := s.cardStore.Update(input.BoardName, card); err != nil {
+			for j := 0; j < i; j++ {
+				_ = s.cardStore.Update(input.BoardName, &originals[j])
+			}
+			return nil, err
+		}
+	}
+
+	for _, orig := range originals {
+		if orig.Column != input.Column {
+			metrics.CardMovedTotal.WithLabelValues(input.BoardName).Inc()
+		}
+	}
+	return moving, nil
+}
+
+// findCardByIDOrAlias returns the card matching idOrAlias by ID, falling back
+// to alias, or nil if none matches.
+func findCardByIDOrAlias(cards []*model.Card, idOrAlias string) *model.Card {
+	if idx := indexOfCard(cards, idOrAlias); idx >= 0 {
+		return cards[idx]
+	}
+	for _, c := range cards {
+		if c.Alias == idOrAlias {
+			return c
+		}
+	}
+	return nil
+}
+
+// firstNonEmpty returns the first non-empty string, or "" if all are empty.
+func firstNonEmpty(vals ...string) string {
+	for _, v := range vals {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// resolveTargetColumn determines the destination column for a placement and
+// validates that an anchor card (if any) lives there. An empty explicitColumn
+// means "infer": from the anchor's column when an anchor is given, otherwise the
+// supplied fallbackColumn (e.g. the card's current column, or the board default).
+// This keeps column inference identical for both Add and MoveCardWithPlacement.
+func resolveTargetColumn(allCards []*model.Card, explicitColumn, fallbackColumn, beforeID, afterID string) (string, error) {
+	anchorID := firstNonEmpty(beforeID, afterID)
+	if anchorID == "" {
+		if explicitColumn != "" {
+			return explicitColumn, nil
+		}
+		return fallbackColumn, nil
+	}
+
+	idx := indexOfCard(allCards, anchorID)
+	if idx < 0 {
+		return "", kanerr.CardNotFound(anchorID)
 	}
 	anchor := allCards[idx]
 	if explicitColumn == "" {
@@ -410,6 +1208,55 @@ func (s *CardService) FindByIDOrAlias(boardName, idOrAlias string) (*model.Card,
 	return s.cardStore.FindByAlias(boardName, idOrAlias)
 }
 
+// maxFetchConcurrency caps how many cards FetchByIDsOrAliases looks up at once.
+const maxFetchConcurrency = 8
+
+// FetchByIDsOrAliases looks up several cards by ID or alias at once. Found
+// cards are returned in request order, each once even if it was named twice;
+// references that match no card are returned in notFound rather than failing
+// the lookup.
+func (s *CardService) FetchByIDsOrAliases(boardName string, idsOrAliases []string) (cards []*model.Card, notFound []string, err error) {
+	if !s.boardStore.Exists(boardName) {
+		return nil, nil, kanerr.BoardNotFound(boardName)
+	}
+
+	// Each lookup writes only its own slot, so results keep request order
+	// without locking.
+	found := make([]*model.Card, len(idsOrAliases))
+	var g errgroup.Group
+	g.SetLimit(maxFetchConcurrency)
+	for i, idOrAlias := range idsOrAliases {
+		g.Go(func() error {
+			card, err := s.FindByIDOrAlias(boardName, idOrAlias)
+			if err != nil {
+				if kanerr.IsNotFound(err) {
+					return nil
+				}
+				return err
+			}
+			found[i] = card
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, nil, err
+	}
+
+	cards = []*model.Card{}
+	notFound = []string{}
+	seen := make(map[string]bool)
+	for i, card := range found {
+		switch {
+		case card == nil:
+			notFound = append(notFound, idsOrAliases[i])
+		case !seen[card.ID]:
+			seen[card.ID] = true
+			cards = append(cards, card)
+		}
+	}
+	return cards, notFound, nil
+}
+
 // UpdateTitle updates the card title and regenerates alias if not explicit.
 func (s *CardService) UpdateTitle(boardName string, card *model.Card, newTitle string) error {
 	card.Title = newTitle
@@ -432,6 +1279,12 @@ func (s *CardService) Edit(input EditCardInput) (*model.Card, error) {
 	if err != nil {
 		return nil, err
 	}
+	// Re-read under the card lock so a background hook result can't be
+	// overwritten by, or overwrite, this edit.
+	defer s.lockCard(input.BoardName, card.ID)()
+	if card, err = s.cardStore.Get(input.BoardName, card.ID); err != nil {
+		return nil, err
+	}
 
 	// Get board config for validation
 	boardCfg, err := s.boardStore.Get(input.BoardName)
@@ -466,8 +1319,9 @@ func (s *CardService) Edit(input EditCardInput) (*model.Card, error) {
 			}
 			targetColumn = *input.Column
 		}
-		if err := s.MoveCardWithPlacement(input.BoardName, card.ID, targetColumn,
-			input.Position, input.BeforeCard, input.AfterCard); err != nil {
+		opts := moveOptions{force: input.Force, pendingFields: input.CustomFields, pendingLabels: input.Labels}
+		if err := s.moveCardWithPlacement(input.BoardName, card.ID, targetColumn,
+			input.Position, input.BeforeCard, input.AfterCard, opts); err != nil {
 			return nil, err
 		}
 		// Re-fetch card after move (column and position changed on disk)
@@ -511,11 +1365,32 @@ func (s *CardService) Edit(input EditCardInput) (*model.Card, error) {
 		needsUpdate = true
 	}
 
-	// Handle custom fields
-	if len(input.CustomFields) > 0 {
-		if err := s.validateAndApplyCustomFields(card, boardCfg, input.CustomFields); err != nil {
+	// Handle due date change
+	if input.DueAtMillis != nil {
+		if *input.DueAtMillis < 0 {
+			return nil, kanerr.InvalidField("due_at_millis", "cannot be negative")
+		}
+		card.DueAtMillis = *input.DueAtMillis
+		needsUpdate = true
+	}
+
+	// Handle custom fields and labels
+	if len(input.CustomFields) > 0 || input.Labels != nil {
+		if err := s.applyFieldEdits(card, boardCfg, input.CustomFields, input.Labels); err != nil {
+			return nil, err
+		}
+		needsUpdate = true
+	}
+
+	// Handle dependency change (validated before anything is written)
+	var oldBlockers []string
+	if input.BlockedBy != nil {
+		blockers, err := s.resolveBlockers(input.BoardName, card.ID, *input.BlockedBy)
+		if err != nil {
 			return nil, err
 		}
+		oldBlockers = card.BlockedBy
+		card.BlockedBy = blockers
 		needsUpdate = true
 	}
 
@@ -525,9 +1400,131 @@ func (s *CardService) Edit(input EditCardInput) (*model.Card, error) {
 		}
 	}
 
+	if input.BlockedBy != nil {
+		if err := s.syncBlocks(input.BoardName, card.ID, oldBlockers, card.BlockedBy); err != nil {
+			return nil, err
+		}
+	}
+
 	return card, nil
 }
 
+// resolveBlockers resolves BlockedBy references (IDs or aliases) to canonical
+// card IDs, dropping duplicates. A card cannot block itself.
+func (s *CardService) resolveBlockers(boardName, cardID string, refs []string) ([]string, error) {
+	var ids []string
+	for _, ref := range refs {
+		blocker, err := s.FindByIDOrAlias(boardName, ref)
+		if err != nil {
+			return nil, kanerr.InvalidField("blocked_by", fmt.Sprintf("card not found: %s", ref))
+		}
+		if blocker.ID == cardID {
+			return nil, kanerr.InvalidField("blocked_by", "a card cannot block itself")
+		}
+		if !slices.Contains(ids, blocker.ID) {
+			ids = append(ids, blocker.ID)
+		}
+	}
+	return ids, nil
+}
+
+// syncBlocks mirrors a change to cardID's BlockedBy (from oldBlockers to
+// newBlockers) onto the Blocks lists of the affected cards. Those cards are
+// written without touching UpdatedAtMillis: only the reverse link changed.
+func (s *CardService) syncBlocks(boardName, cardID string, oldBlockers, newBlockers []string) error {
+	for _, blockerID := range newBlockers {
+		if slices.Contains(oldBlockers, blockerID) {
+			continue
+		}
+		blocker, err := s.cardStore.Get(boardName, blockerID)
+		if err != nil {
+			return err
+		}
+		if !slices.Contains(blocker.Blocks, cardID) {
+			blocker.Blocks = append(blocker.Blocks, cardID)
+			if err := s.cardStore.Update(boardName, blocker); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, blockerID := range oldBlockers {
+		if slices.Contains(newBlockers, blockerID) {
+			continue
+		}
+		blocker, err := s.cardStore.Get(boardName, blockerID)
+		if kanerr.IsNotFound(err) {
+			continue // Dangling reference; nothing to unlink
+		}
+		if err != nil {
+			return err
+		}
+		if slices.Contains(blocker.Blocks, cardID) {
+			blocker.Blocks = slices.DeleteFunc(blocker.Blocks, func(id string) bool { return id == cardID })
+			if err := s.cardStore.Update(boardName, blocker); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// GetBlockingChain returns every card that transitively blocks the given card
+// (its blockers, their blockers, and so on) in breadth-first order. Cycles and
+// dangling references are tolerated: each card appears at most once and
+// missing IDs are skipped.
+func (s *CardService) GetBlockingChain(boardName, cardID string) ([]*model.Card, error) {
+	start, err := s.FindByIDOrAlias(boardName, cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	visited := map[string]bool{start.ID: true}
+	queue := append([]string(nil), start.BlockedBy...)
+	var chain []*model.Card
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if visited[id] {
+			continue
+		}
+		visited[id] = true
+
+		blocker, err := s.cardStore.Get(boardName, id)
+		if kanerr.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		chain = append(chain, blocker)
+		queue = append(queue, blocker.BlockedBy...)
+	}
+	return chain, nil
+}
+
+// GetBlockedCards returns the cards the given card directly blocks.
+// Dangling references are skipped.
+func (s *CardService) GetBlockedCards(boardName, cardID string) ([]*model.Card, error) {
+	card, err := s.FindByIDOrAlias(boardName, cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	var blocked []*model.Card
+	for _, id := range card.Blocks {
+		c, err := s.cardStore.Get(boardName, id)
+		if kanerr.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		blocked = append(blocked, c)
+	}
+	return blocked, nil
+}
+
 // validateAndApplyCustomFields validates and applies custom field changes.
 func (s *CardService) validateAndApplyCustomFields(card *model.Card, boardCfg *model.BoardConfig, fields map[string]string) error {
 	if card.CustomFields == nil {
@@ -547,7 +1544,7 @@ func (s *CardService) validateAndApplyCustomFields(card *model.Card, boardCfg *m
 		}
 
 		// Validate value against schema
-		switch schema.Type {
+		switch schema.FieldType() {
 		case model.FieldTypeEnum:
 			if value == "" {
 				delete(card.CustomFields, key)
@@ -557,98 +1554,567 @@ func (s *CardService) validateAndApplyCustomFields(card *model.Card, boardCfg *m
 				card.CustomFields[key] = value
 			}
 
-		case model.FieldTypeEnumSet:
-			// Parse comma-separated values, validate against options
-			vals := parseSetValues(value)
-			vals = dedup(vals)
+		case model.FieldTypeEnumSet, model.FieldTypeFreeSet:
+			// Parse comma-separated values
+			vals, err := validateSetValues(key, parseSetValues(value), schema)
+			if err != nil {
+				return err
+			}
 			if len(vals) == 0 {
 				delete(card.CustomFields, key)
 			} else {
-				if len(vals) > model.MaxSetItems {
-					return kanerr.InvalidField(key, fmt.Sprintf("too many values (max %d)", model.MaxSetItems))
+				card.CustomFields[key] = vals
+			}
+
+		case model.FieldTypeBoolean:
+			if value == "" {
+				delete(card.CustomFields, key)
+			} else {
+				boolVal, err := parseBoolValue(value)
+				if err != nil {
+					return kanerr.InvalidField(key, err.Error())
 				}
-				for _, v := range vals {
-					if !isValidOption(schema.Options, v) {
-						return kanerr.InvalidField(key, fmt.Sprintf("%q is not a valid option; must be one of: %s", v, formatOptions(schema.Options)))
-					}
+				card.CustomFields[key] = boolVal
+			}
+
+		case model.FieldTypeInteger:
+			if value == "" {
+				delete(card.CustomFields, key)
+			} else if isIntegerExpression(value, card.CustomFields[key]) {
+				intVal, err := applyIntegerDelta(card.CustomFields[key], value, schema)
+				if err != nil {
+					return kanerr.InvalidField(key, err.Error())
 				}
-				card.CustomFields[key] = vals
+				card.CustomFields[key] = intVal
+			} else {
+				intVal, err := parseIntegerValue(value, schema)
+				if err != nil {
+					return kanerr.InvalidField(key, err.Error())
+				}
+				card.CustomFields[key] = intVal
 			}
 
-		case model.FieldTypeFreeSet:
-			// Parse comma-separated values, no option validation
-			vals := parseSetValues(value)
-			vals = dedup(vals)
-			if len(vals) == 0 {
+		case model.FieldTypeURL:
+			if value == "" {
 				delete(card.CustomFields, key)
 			} else {
-				if len(vals) > model.MaxSetItems {
-					return kanerr.InvalidField(key, fmt.Sprintf("too many values (max %d)", model.MaxSetItems))
+				urlVal, err := parseURLValue(value, schema)
+				if err != nil {
+					return kanerr.InvalidField(key, err.Error())
 				}
-				card.CustomFields[key] = vals
+				card.CustomFields[key] = urlVal
 			}
 
-		case model.FieldTypeBoolean:
+		case model.FieldTypeDate:
 			if value == "" {
 				delete(card.CustomFields, key)
 			} else {
-				boolVal, err := parseBoolValue(value)
+				dateVal, err := parseDateValue(value, schema, time.Now())
 				if err != nil {
 					return kanerr.InvalidField(key, err.Error())
 				}
-				card.CustomFields[key] = boolVal
+				card.CustomFields[key] = dateVal
+			}
+
+		case model.FieldTypeString:
+			if value == "" {
+				delete(card.CustomFields, key)
+			} else {
+				card.CustomFields[key] = value
 			}
 
-		case model.FieldTypeString, model.FieldTypeDate:
+		case model.FieldTypeUser:
 			if value == "" {
 				delete(card.CustomFields, key)
+			} else if !boardCfg.AcceptsUser(value) {
+				return kanerr.InvalidField(key, fmt.Sprintf("%q is not a collaborator; must be one of: %s", value, strings.Join(boardCfg.Collaborators, ", ")))
 			} else {
 				card.CustomFields[key] = value
 			}
 
 		default:
-			return kanerr.InvalidField(key, fmt.Sprintf("unknown field type %q", schema.Type))
+			return kanerr.InvalidField(key, fmt.Sprintf("unknown field type %q", schema.FieldType()))
 		}
 	}
 
 	return nil
 }
 
-// Delete removes a card from the board.
-func (s *CardService) Delete(boardName, cardID string) error {
-	return s.cardStore.Delete(boardName, cardID)
+// validateSetValues dedups the values of an enum-set or free-set field and
+// checks them against its schema: enum-set values must be options, free-set
+// values must fit the field's max length.
+func validateSetValues(key string, vals []string, schema model.CustomFieldSchema) ([]string, error) {
+	vals = dedup(vals)
+	if len(vals) > model.MaxSetItems {
+		return nil, kanerr.InvalidField(key, fmt.Sprintf("too many values (max %d)", model.MaxSetItems))
+	}
+	for _, v := range vals {
+		switch schema.FieldType() {
+		case model.FieldTypeEnumSet:
+			if !isValidOption(schema.Options, v) {
+				return nil, kanerr.InvalidField(key, fmt.Sprintf("%q is not a valid option; must be one of: %s", v, formatOptions(schema.Options)))
+			}
+		case model.FieldTypeFreeSet:
+			if maxLen := schema.TagMaxLength(); utf8.RuneCountInString(v) > maxLen {
+				return nil, kanerr.InvalidField(key, fmt.Sprintf("%q is too long (max %d characters)", v, maxLen))
+			}
+		}
+	}
+	return vals, nil
 }
 
-// Restore re-creates a previously deleted card from a full snapshot.
-// The card is written to disk as-is (preserving ID, alias, timestamps, etc.)
-// and inserted into the specified column at the given position.
-// If the original alias is taken, a new alias is generated.
-func (s *CardService) Restore(boardName string, card *model.Card, column string, position int) error {
-	// Validate card ID looks reasonable (prevent path traversal)
-	if !id.IsValidID(card.ID) {
-		return fmt.Errorf("invalid card ID: %s", card.ID)
+// applyFieldEdits applies custom field edits and then labels, which replace
+// any labels value in fields. nil labels leaves the card's labels alone.
+func (s *CardService) applyFieldEdits(card *model.Card, boardCfg *model.BoardConfig, fields map[string]string, labels *[]string) error {
+	if labels != nil {
+		fields = maps.Clone(fields)
+		delete(fields, model.LabelsField)
+	}
+	if len(fields) > 0 {
+		if err := s.validateAndApplyCustomFields(card, boardCfg, fields); err != nil {
+			return err
+		}
+	}
+	if labels == nil {
+		return nil
 	}
+	return applyLabels(card, boardCfg, *labels)
+}
 
-	// Validate board exists
-	boardCfg, err := s.boardStore.Get(boardName)
+// applyLabels validates and sets a card's labels. They're taken as a list
+// rather than a comma-separated value, so a label may contain a comma. An
+// empty list clears them.
+func applyLabels(card *model.Card, boardCfg *model.BoardConfig, labels []string) error {
+	schema, exists := boardCfg.CustomFields[model.LabelsField]
+	if !exists {
+		return kanerr.InvalidField("field", fmt.Sprintf("%q is not defined in board config", model.LabelsField))
+	}
+	if t := schema.FieldType(); t != model.FieldTypeEnumSet && t != model.FieldTypeFreeSet {
+		return kanerr.InvalidField(model.LabelsField, fmt.Sprintf("is a %s field; labels need a set field", t))
+	}
+	vals, err := validateSetValues(model.LabelsField, cleanSetValues(labels), schema)
 	if err != nil {
 		return err
 	}
+	card.SetLabels(vals)
+	return nil
+}
 
-	// Validate column exists
-	if !boardCfg.HasColumn(column) {
+// addLabelsField adds a free-set field for labels to a board config that
+// doesn't define one, and reports whether it did. The caller saves the config.
+func addLabelsField(boardCfg *model.BoardConfig) bool {
+	if _, exists := boardCfg.CustomFields[model.LabelsField]; exists {
+		return false
+	}
+	if boardCfg.CustomFields == nil {
+		boardCfg.CustomFields = make(map[string]model.CustomFieldSchema)
+	}
+	boardCfg.CustomFields[model.LabelsField] = model.CustomFieldSchema{Type: model.FieldTypeFreeSet}
+	return true
+}
+
+// CopyCustomFieldsFrom copies custom field values from a source card onto a
+// target card in the same board and saves the target. See
+// CopyCustomFieldsAcrossBoards for the copying rules.
+func (s *CardService) CopyCustomFieldsFrom(boardName, targetCardID, sourceCardID string, fieldNames []string) (*model.Card, error) {
+	return s.CopyCustomFieldsAcrossBoards(boardName, targetCardID, boardName, sourceCardID, fieldNames)
+}
+
+// CopyCustomFieldsAcrossBoards copies custom field values from a source card
+// (possibly on another board) onto a target card and saves the target.
+//
+// When fieldNames is empty, every field defined in both the source and target
+// board schemas is copied. Fields not defined in the target board's schema are
+// skipped rather than rejected, since boards routinely have different schemas.
+// Fields the source card has no value for are left untouched on the target.
+// Copied values are validated against the target schema (e.g. an enum value
+// must be one of the target board's options).
+func (s *CardService) CopyCustomFieldsAcrossBoards(targetBoard, targetCardID, sourceBoard, sourceCardID string, fieldNames []string) (*model.Card, error) {
+	targetCfg, err := s.boardStore.Get(targetBoard)
+	if err != nil {
+		return nil, err
+	}
+	sourceCfg, err := s.boardStore.Get(sourceBoard)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := s.cardStore.Get(targetBoard, targetCardID)
+	if err != nil {
+		return nil, err
+	}
+	source, err := s.cardStore.Get(sourceBoard, sourceCardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(fieldNames) == 0 {
+		for name := range sourceCfg.CustomFields {
+			fieldNames = append(fieldNames, name)
+		}
+	}
+
+	fields := make(map[string]string)
+	for _, name := range fieldNames {
+		if _, inTarget := targetCfg.CustomFields[name]; !inTarget {
+			continue
+		}
+		value, ok := source.CustomFields[name]
+		if !ok || value == nil {
+			continue
+		}
+		fields[name] = formatCustomFieldValue(value)
+	}
+
+	if len(fields) == 0 {
+		return target, nil
+	}
+
+	// Validate onto an empty card, so a negative value is copied as it is
+	// rather than applied as a change to the target's value.
+	copied := &model.Card{}
+	if err := s.validateAndApplyCustomFields(copied, targetCfg, fields); err != nil {
+		return nil, err
+	}
+	if target.CustomFields == nil {
+		target.CustomFields = make(map[string]any)
+	}
+	maps.Copy(target.CustomFields, copied.CustomFields)
+	if err := s.Update(targetBoard, target); err != nil {
+		return nil, err
+	}
+	return target, nil
+}
+
+// formatCustomFieldValue renders a stored custom field value in the string
+// form accepted by validateAndApplyCustomFields (sets become comma-separated).
+func formatCustomFieldValue(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []string:
+		return strings.Join(v, ",")
+	case []any:
+		parts := make([]string, 0, len(v))
+		for _, item := range v {
+			parts = append(parts, formatCustomFieldValue(item))
+		}
+		return strings.Join(parts, ",")
+	case float64:
+		// JSON numbers decode as float64; %v would print large integers in
+		// exponent form (1e+06), which the integer parser rejects.
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// Delete removes a card from the board. Its child cards are kept, with their
+// parent cleared (see DeleteWithOptions).
+func (s *CardService) Delete(boardName, cardID string) error {
+	_, err := s.DeleteWithOptions(boardName, cardID, DeleteOptions{})
+	return err
+}
+
+// What DeleteWithOptions does with the children of a deleted card.
+const (
+	ParentBehaviorClear    = "clear"    // keep children, with no parent
+	ParentBehaviorDelete   = "delete"   // delete children and their descendants
+	ParentBehaviorReparent = "reparent" // move children up to the deleted card's parent
+)
+
+// ValidParentBehaviors lists the accepted DeleteOptions.ParentBehavior values.
+var ValidParentBehaviors = []string{ParentBehaviorClear, ParentBehaviorDelete, ParentBehaviorReparent}
+
+// DeleteOptions controls DeleteWithOptions.
+type DeleteOptions struct {
+	// ParentBehavior is one of the ParentBehavior* constants. Empty means
+	// ParentBehaviorClear.
+	ParentBehavior string
+}
+
+// DeleteWithOptions removes a card from the board and deals with the cards
+// whose parent it is according to opts, so no card is left pointing at a
+// deleted parent. It returns the IDs of the deleted cards, the given card
+// first. Archived children count as children.
+func (s *CardService) DeleteWithOptions(boardName, cardID string, opts DeleteOptions) ([]string, error) {
+	result, err := s.DeleteWithResult(boardName, cardID, opts)
+	if err != nil {
+		return nil, err
+	}
+	return result.DeletedIDs, nil
+}
+
+// DeleteResult describes the cards a DeleteWithResult call changed.
+type DeleteResult struct {
+	DeletedIDs []string      // The given card first
+	Updated    []*model.Card // Children kept with a new (or no) parent
+}
+
+// DeleteWithResult is DeleteWithOptions, also returning the children it kept
+// and re-parented, for callers that report changed cards.
+func (s *CardService) DeleteWithResult(boardName, cardID string, opts DeleteOptions) (*DeleteResult, error) {
+	behavior := opts.ParentBehavior
+	if behavior == "" {
+		behavior = ParentBehaviorClear
+	}
+	if !slices.Contains(ValidParentBehaviors, behavior) {
+		return nil, kanerr.InvalidField("parent_behavior", fmt.Sprintf(
+			"unknown behavior %q (must be one of %s)", behavior, strings.Join(ValidParentBehaviors, ", ")))
+	}
+
+	card, err := s.cardStore.Get(boardName, cardID)
+	if err != nil {
+		return nil, err
+	}
+	allCards, err := s.cardStore.List(boardName, true)
+	if err != nil {
+		return nil, err
+	}
+	return s.deleteWithChildren(boardName, card, allCards, behavior)
+}
+
+// deleteWithChildren deletes card and applies behavior to its children,
+// found among allCards.
+func (s *CardService) deleteWithChildren(boardName string, card *model.Card, allCards []*model.Card, behavior string) (*DeleteResult, error) {
+	children := make(map[string][]*model.Card)
+	for _, c := range allCards {
+		if c.Parent != "" {
+			children[c.Parent] = append(children[c.Parent], c)
+		}
+	}
+
+	if behavior == ParentBehaviorDelete {
+		// Collect descendants breadth-first. The visited set guards against
+		// parent cycles, which the store doesn't prevent.
+		deleted := []string{card.ID}
+		visited := map[string]bool{card.ID: true}
+		for i := 0; i < len(deleted); i++ {
+			for _, child := range children[deleted[i]] {
+				if !visited[child.ID] {
+					visited[child.ID] = true
+					deleted = append(deleted, child.ID)
+				}
+			}
+		}
+		for _, id := range deleted {
+			if err := s.deleteCard(boardName, id); err != nil {
+				return nil, err
+			}
+		}
+		return &DeleteResult{DeletedIDs: deleted}, nil
+	}
+
+	newParent := ""
+	if behavior == ParentBehaviorReparent {
+		newParent = card.Parent
+	}
+	result := &DeleteResult{DeletedIDs: []string{card.ID}}
+	for _, child := range children[card.ID] {
+		if child.ID == card.ID {
+			continue // a card that is its own parent goes away with the delete
+		}
+		child.Parent = newParent
+		if child.Parent == child.ID {
+			child.Parent = "" // two-card parent cycle: don't make it self-parented
+		}
+		if err := s.Update(boardName, child); err != nil {
+			return nil, err
+		}
+		result.Updated = append(result.Updated, child)
+	}
+	if err := s.deleteCard(boardName, card.ID); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// deleteCard removes a single card file, recording the deletion.
+func (s *CardService) deleteCard(boardName, cardID string) error {
+	if err := s.cardStore.Delete(boardName, cardID); err != nil {
+		return err
+	}
+	s.recordAudit(boardName, cardID, model.AuditActionDeleted, nil)
+	metrics.CardDeletedTotal.WithLabelValues(boardName).Inc()
+	return nil
+}
+
+// FailedDelete records a card that DeleteMany found but couldn't delete.
+type FailedDelete struct {
+	CardID string
+	Error  string
+}
+
+// BulkDeleteResult summarizes a DeleteMany call. Deleted holds resolved card
+// IDs (the cards that would be deleted, for a dry run); NotFound holds the
+// inputs that matched no card. Updated holds the surviving children whose
+// parent was cleared.
+type BulkDeleteResult struct {
+	Deleted  []string
+	NotFound []string
+	Failed   []FailedDelete
+	Updated  []*model.Card
+}
+
+// DeleteMany deletes several cards by ID or alias. Unlike BulkMove it is not
+// all-or-nothing: unknown IDs are reported in NotFound and the rest are still
+// deleted. With dryRun, IDs are only resolved and nothing is deleted.
+//
+// The card list is read once for the whole batch. Column membership lives in
+// the card files, so deleting them leaves the board config untouched. Children
+// of deleted cards keep existing with their parent cleared, as with Delete.
+func (s *CardService) DeleteMany(boardName string, cardIDs []string, dryRun bool) (*BulkDeleteResult, error) {
+	if len(cardIDs) == 0 {
+		return nil, kanerr.InvalidField("card_ids", "at least one card is required")
+	}
+	if _, err := s.boardStore.Get(boardName); err != nil {
+		return nil, err
+	}
+
+	allCards, err := s.cardStore.List(boardName, true)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &BulkDeleteResult{}
+	live := slices.Clone(allCards)
+	seen := make(map[string]bool)
+	for _, idOrAlias := range cardIDs {
+		card := findCardByIDOrAlias(allCards, idOrAlias)
+		if card == nil {
+			result.NotFound = append(result.NotFound, idOrAlias)
+			continue
+		}
+		if seen[card.ID] {
+			continue
+		}
+		seen[card.ID] = true
+
+		if dryRun {
+			result.Deleted = append(result.Deleted, card.ID)
+			continue
+		}
+		deleted, err := s.deleteWithChildren(boardName, card, live, ParentBehaviorClear)
+		if err != nil {
+			result.Failed = append(result.Failed, FailedDelete{CardID: card.ID, Error: err.Error()})
+			continue
+		}
+		result.Deleted = append(result.Deleted, card.ID)
+		result.Updated = append(result.Updated, deleted.Updated...)
+		// Drop the card so a later parent in the batch doesn't rewrite it.
+		live = slices.DeleteFunc(live, func(c *model.Card) bool { return c.ID == card.ID })
+	}
+
+	// A child cleared early in the batch may itself be deleted later on.
+	result.Updated = slices.DeleteFunc(result.Updated, func(c *model.Card) bool {
+		return slices.Contains(result.Deleted, c.ID)
+	})
+	return result, nil
+}
+
+// Archive soft-deletes a card. The card leaves its column (remembered in
+// LastColumn) and is hidden from listings, but its file, alias, and comments
+// are kept so it can be brought back with Unarchive.
+func (s *CardService) Archive(boardName, cardID string) error {
+	card, err := s.cardStore.Get(boardName, cardID)
+	if err != nil {
+		return err
+	}
+	if card.Archived {
+		return kanerr.InvalidField("card", "card is already archived")
+	}
+
+	card.Archived = true
+	card.ArchivedAtMillis = util.NowMillis()
+	card.LastColumn = card.Column
+	card.Column = ""
+	card.Position = ""
+	card.DoneAtMillis = 0
+	card.UpdatedAtMillis = card.ArchivedAtMillis
+
+	return s.cardStore.Update(boardName, card)
+}
+
+// Unarchive returns an archived card to the bottom of the column it was
+// archived from. If that column no longer exists, the board's default column
+// is used instead.
+func (s *CardService) Unarchive(boardName, cardID string) (*model.Card, error) {
+	card, err := s.cardStore.Get(boardName, cardID)
+	if err != nil {
+		return nil, err
+	}
+	if !card.Archived {
+		return nil, kanerr.InvalidField("card", "card is not archived")
+	}
+
+	boardCfg, err := s.boardStore.Get(boardName)
+	if err != nil {
+		return nil, err
+	}
+
+	column := card.LastColumn
+	if !boardCfg.HasColumn(column) {
+		column = boardCfg.GetDefaultColumn()
+	}
+	if !boardCfg.HasColumn(column) {
+		return nil, kanerr.ColumnNotFound(column, boardName)
+	}
+
+	allCards, err := s.cardStore.List(boardName, false)
+	if err != nil {
+		return nil, err
+	}
+	colCards := cardsInColumn(allCards, column)
+	if boardCfg.IsAtCapacity(column, len(colCards)) {
+		return nil, kanerr.ColumnAtLimit(column, boardCfg.GetColumn(column).Limit)
+	}
+
+	card.Archived = false
+	card.ArchivedAtMillis = 0
+	card.LastColumn = ""
+	card.Column = column
+	card.Position = computePosition(colCards, -1)
+	card.UpdatedAtMillis = util.NowMillis()
+	card.History = append(card.History, model.HistoryEntry{
+		Field: "column", Value: column, At: card.UpdatedAtMillis,
+	})
+	stampDone(card, boardCfg, card.UpdatedAtMillis)
+
+	if err := s.cardStore.Update(boardName, card); err != nil {
+		return nil, err
+	}
+	return card, nil
+}
+
+// Restore re-creates a previously deleted card from a full snapshot.
+// The card is written to disk as-is (preserving ID, alias, timestamps, etc.)
+// and inserted into the specified column at the given position.
+// If the original alias is taken, a new alias is generated.
+func (s *CardService) Restore(boardName string, card *model.Card, column string, position int) error {
+	// Validate card ID looks reasonable (prevent path traversal)
+	if !id.IsValidID(card.ID) {
+		return fmt.Errorf("invalid card ID: %s", card.ID)
+	}
+
+	// Validate board exists
+	boardCfg, err := s.boardStore.Get(boardName)
+	if err != nil {
+		return err
+	}
+
+	// Validate column exists
+	if !boardCfg.HasColumn(column) {
 		return kanerr.ColumnNotFound(column, boardName)
 	}
 
 	// Check column limit by counting existing cards in the column
-	col := boardCfg.GetColumn(column)
-	allCards, err := s.cardStore.List(boardName)
+	allCards, err := s.cardStore.List(boardName, false)
 	if err != nil {
 		return err
 	}
 	colCards := cardsInColumn(allCards, column)
-	if col.Limit > 0 && len(colCards) >= col.Limit {
-		return kanerr.ColumnLimitExceeded(column, col.Limit)
+	if boardCfg.IsAtCapacity(column, len(colCards)) {
+		return kanerr.ColumnAtLimit(column, boardCfg.GetColumn(column).Limit)
 	}
 
 	// Check that no card with this ID already exists
@@ -666,9 +2132,13 @@ func (s *CardService) Restore(boardName string, card *model.Card, column string,
 		card.AliasExplicit = false
 	}
 
-	// Set column and position on the card itself
+	// Set column and position on the card itself. A restored card is live
+	// again even if the snapshot was taken while it was archived.
 	card.Column = column
 	card.Position = computePosition(colCards, position)
+	card.Archived = false
+	card.ArchivedAtMillis = 0
+	card.LastColumn = ""
 
 	// Write card file
 	return s.cardStore.Create(boardName, card)
@@ -699,17 +2169,79 @@ func parseSetValues(value string) []string {
 	if value == "" {
 		return []string{}
 	}
-	parts := strings.Split(value, ",")
-	result := make([]string, 0, len(parts))
-	for _, p := range parts {
-		p = strings.TrimSpace(p)
-		if p != "" {
-			result = append(result, p)
+	return cleanSetValues(strings.Split(value, ","))
+}
+
+// cleanSetValues trims each value and drops empty ones.
+func cleanSetValues(vals []string) []string {
+	result := make([]string, 0, len(vals))
+	for _, v := range vals {
+		v = strings.TrimSpace(v)
+		if v != "" {
+			result = append(result, v)
 		}
 	}
 	return result
 }
 
+// parseURLValue checks that a string is an absolute http(s) URL and, if the
+// schema sets a pattern, that the URL matches it.
+func parseURLValue(s string, schema model.CustomFieldSchema) (string, error) {
+	s = strings.TrimSpace(s)
+	u, err := url.Parse(s)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", fmt.Errorf("must be an http or https URL, got %q", s)
+	}
+	if schema.Pattern != "" {
+		re, err := regexp.Compile(schema.Pattern)
+		if err != nil {
+			return "", fmt.Errorf("invalid pattern %q in board config: %v", schema.Pattern, err)
+		}
+		if !re.MatchString(s) {
+			return "", fmt.Errorf("%q does not match pattern %q", s, schema.Pattern)
+		}
+	}
+	return s, nil
+}
+
+// dateLayout is the storage layout of date fields in the default format.
+const dateLayout = "2006-01-02"
+
+// parseDateValue parses a date field value, either YYYY-MM-DD or an RFC 3339
+// timestamp, and renders it in the schema's storage format. A timestamp keeps
+// the calendar date it was written with, whatever its offset. FutureOnly and
+// PastOnly compare against now: by calendar day for dates, and to the instant
+// for datetimes.
+func parseDateValue(s string, schema model.CustomFieldSchema, now time.Time) (string, error) {
+	s = strings.TrimSpace(s)
+	t, err := time.Parse(dateLayout, s)
+	if err != nil {
+		t, err = time.Parse(time.RFC3339, s)
+		if err != nil {
+			return "", fmt.Errorf("must be in YYYY-MM-DD format")
+		}
+	}
+
+	var stored string
+	var future, past bool
+	if schema.DateFormat() == model.DateFormatDateTime {
+		stored = t.Format(time.RFC3339)
+		future, past = t.After(now), t.Before(now)
+	} else {
+		stored = t.Format(dateLayout)
+		today := now.Format(dateLayout)
+		future, past = stored > today, stored < today
+	}
+
+	if schema.FutureOnly && past {
+		return "", fmt.Errorf("must not be in the past, got %s", stored)
+	}
+	if schema.PastOnly && future {
+		return "", fmt.Errorf("must not be in the future, got %s", stored)
+	}
+	return stored, nil
+}
+
 // parseBoolValue parses a string as a boolean value.
 // Accepts true/false, yes/no, 1/0 (case-insensitive).
 func parseBoolValue(s string) (bool, error) {
@@ -723,6 +2255,64 @@ func parseBoolValue(s string) (bool, error) {
 	}
 }
 
+// parseIntegerValue parses a string as an integer and checks it against the
+// schema's optional min/max bounds (both inclusive).
+func parseIntegerValue(s string, schema model.CustomFieldSchema) (int, error) {
+	n, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil {
+		return 0, fmt.Errorf("must be an integer, got %q", s)
+	}
+	if schema.Min != nil && n < *schema.Min {
+		return 0, fmt.Errorf("must be at least %d, got %d", *schema.Min, n)
+	}
+	if schema.Max != nil && n > *schema.Max {
+		return 0, fmt.Errorf("must be at most %d, got %d", *schema.Max, n)
+	}
+	return n, nil
+}
+
+// isIntegerExpression reports whether an integer field's value is an update
+// expression like "+2" or "-1" rather than a plain number: it has a leading
+// sign and the field already holds a value to change. A "-1" for an unset
+// field is the number -1, but a "+1" is still an expression, one that fails.
+func isIntegerExpression(value string, current any) bool {
+	value = strings.TrimSpace(value)
+	if strings.HasPrefix(value, "+") {
+		return true
+	}
+	return strings.HasPrefix(value, "-") && current != nil
+}
+
+// applyIntegerDelta applies an update expression like "+2" or "-1" to an
+// integer field's current value, clamping the result to the field's bounds.
+// Values set in memory are int; values decoded from card JSON are float64.
+func applyIntegerDelta(current any, expr string, schema model.CustomFieldSchema) (int, error) {
+	expr = strings.TrimSpace(expr)
+	delta, err := strconv.Atoi(expr)
+	if err != nil {
+		return 0, fmt.Errorf("must be an integer or a change like +2 or -1, got %q", expr)
+	}
+	var n int
+	switch v := current.(type) {
+	case int:
+		n = v
+	case int64:
+		n = int(v)
+	case float64:
+		n = int(v)
+	default:
+		return 0, fmt.Errorf("cannot apply %s: field has no numeric value", expr)
+	}
+	n += delta
+	if schema.Min != nil {
+		n = max(n, *schema.Min)
+	}
+	if schema.Max != nil {
+		n = min(n, *schema.Max)
+	}
+	return n, nil
+}
+
 // dedup removes duplicate strings, preserving order.
 func dedup(vals []string) []string {
 	seen := make(map[string]bool, len(vals))
@@ -772,7 +2362,7 @@ func CheckWantedFieldsForProposal(existingFields map[string]any, proposedFields
 			continue // Unknown field, skip (validation happens elsewhere)
 		}
 
-		switch schema.Type {
+		switch schema.FieldType() {
 		case model.FieldTypeEnumSet, model.FieldTypeFreeSet:
 			// Parse comma-separated values
 			merged[fieldName] = parseSetValues(value)
@@ -783,8 +2373,15 @@ func CheckWantedFieldsForProposal(existingFields map[string]any, proposedFields
 			} else {
 				merged[fieldName] = value // let validation catch it
 			}
+		case model.FieldTypeInteger:
+			intVal, err := parseIntegerValue(value, schema)
+			if err == nil {
+				merged[fieldName] = intVal
+			} else {
+				merged[fieldName] = value // let validation catch it
+			}
 		default:
-			// String, enum, date - store as string
+			// String, enum, date, url - store as string
 			merged[fieldName] = value
 		}
 	}
@@ -807,13 +2404,13 @@ func CheckWantedFields(card *model.Card, boardCfg *model.BoardConfig) []MissingW
 		}
 
 		value, exists := card.CustomFields[name]
-		if !exists || isEmpty(value, schema.Type) {
+		if !exists || isEmpty(value, schema.FieldType()) {
 			mf := MissingWantedField{
 				FieldName:   name,
-				FieldType:   schema.Type,
+				FieldType:   schema.FieldType(),
 				Description: schema.Description,
 			}
-			if schema.Type == model.FieldTypeEnum || schema.Type == model.FieldTypeEnumSet {
+			if schema.FieldType() == model.FieldTypeEnum || schema.FieldType() == model.FieldTypeEnumSet {
 				mf.Options = make([]MissingWantedFieldOption, len(schema.Options))
 				for i, opt := range schema.Options {
 					mf.Options[i] = MissingWantedFieldOption{
@@ -829,6 +2426,260 @@ func CheckWantedFields(card *model.Card, boardCfg *model.BoardConfig) []MissingW
 	return missing
 }
 
+// CheckOverdueCards returns the cards whose due date has passed, in their
+// original order. Cards without a due date are never overdue.
+func CheckOverdueCards(cards []*model.Card) []*model.Card {
+	now := util.NowMillis()
+	var overdue []*model.Card
+	for _, card := range cards {
+		if card.IsOverdue(now) {
+			overdue = append(overdue, card)
+		}
+	}
+	return overdue
+}
+
+// FilterStaleCards returns the cards the board's stale settings flag as stale
+// (see model.StaleConfig), in their original order. A nil config or a zero
+// StaleDays flags nothing.
+func FilterStaleCards(cards []*model.Card, boardCfg *model.BoardConfig) []*model.Card {
+	if boardCfg == nil {
+		return nil
+	}
+	now := util.NowMillis()
+	var stale []*model.Card
+	for _, card := range cards {
+		if boardCfg.Stale.IsStale(card, now) {
+			stale = append(stale, card)
+		}
+	}
+	return stale
+}
+
+// GetStaleCards returns the board's active cards that haven't been updated in
+// more than stale.stale_days days, skipping exempt columns. It returns no
+// cards when stale detection is off.
+func (s *CardService) GetStaleCards(boardName string) ([]*model.Card, error) {
+	boardCfg, err := s.boardStore.Get(boardName)
+	if err != nil {
+		return nil, err
+	}
+	if boardCfg.Stale.StaleDays <= 0 {
+		return nil, nil
+	}
+	cards, err := s.cardStore.List(boardName, false)
+	if err != nil {
+		return nil, err
+	}
+	return FilterStaleCards(cards, boardCfg), nil
+}
+
+// NoColumnSuggestion is the reason SuggestColumn gives when it has nothing to
+// suggest.
+const NoColumnSuggestion = "no suggestion"
+
+// SuggestColumn suggests where a card should go next, with a reason. It reads
+// only; the card isn't moved. The first rule that applies wins:
+//
+//   - every checklist item is done: the board's first done column
+//   - a wanted field is missing: the card's current column, as the fields
+//     should be set before it moves on
+//   - the card has sat in its column for more than stale.stale_days days
+//     (exempt columns aside): the next column
+//
+// Otherwise the suggestion is empty, with reason NoColumnSuggestion.
+func (s *CardService) SuggestColumn(boardName, cardID string) (string, string, error) {
+	boardCfg, err := s.boardStore.Get(boardName)
+	if err != nil {
+		return "", "", err
+	}
+	card, err := s.cardStore.Get(boardName, cardID)
+	if err != nil {
+		return "", "", err
+	}
+	if card.Archived {
+		return "", NoColumnSuggestion, nil
+	}
+
+	if len(card.Checklist) > 0 && !card.HasIncompleteChecklist() && !boardCfg.IsDoneColumn(card.Column) {
+		for _, col := range boardCfg.Columns {
+			if boardCfg.IsDoneColumn(col.Name) {
+				return col.Name, "all checklist items are done", nil
+			}
+		}
+	}
+
+	if missing := CheckWantedFields(card, boardCfg); len(missing) > 0 {
+		names := make([]string, len(missing))
+		for i, m := range missing {
+			names[i] = m.FieldName
+		}
+		sort.Strings(names)
+		return card.Column, "set required fields first: " + strings.Join(names, ", "), nil
+	}
+
+	stale := boardCfg.Stale
+	if stale.StaleDays > 0 && !slices.Contains(stale.ExemptColumns, card.Column) &&
+		util.NowMillis()-card.CurrentColumnSinceMillis() > int64(stale.StaleDays)*util.MillisPerDay {
+		if idx := boardCfg.GetColumnIndex(card.Column); idx >= 0 && idx+1 < len(boardCfg.Columns) {
+			return boardCfg.Columns[idx+1].Name,
+				fmt.Sprintf("in %q for more than %d days", card.Column, stale.StaleDays), nil
+		}
+	}
+
+	return "", NoColumnSuggestion, nil
+}
+
+// maxTagSuggestions caps how many values SuggestTags returns.
+const maxTagSuggestions = 3
+
+// tagSuggestionFields are the custom fields SuggestTags suggests values for.
+var tagSuggestionFields = []string{model.LabelsField, "tags"}
+
+// SuggestTags suggests up to three labels or tags for a card with the given
+// title, best first. Candidates are the fields' options and the values
+// already used on the board's cards. It's a simple word-overlap scorer: a
+// candidate scores a point for each title word it contains (case-insensitive),
+// and another for each card whose alias shares a word with the title and
+// already has it. Candidates that score nothing aren't suggested, so the list
+// is empty when the board has neither field.
+func (s *CardService) SuggestTags(boardName, title string) ([]string, error) {
+	boardCfg, err := s.boardStore.Get(boardName)
+	if err != nil {
+		return nil, err
+	}
+	var fields []string
+	scores := make(map[string]int)
+	for _, name := range tagSuggestionFields {
+		schema, ok := boardCfg.CustomFields[name]
+		if !ok {
+			continue
+		}
+		fields = append(fields, name)
+		for _, opt := range schema.Options {
+			scores[opt.Value] = 0
+		}
+	}
+	suggestions := make([]string, 0, maxTagSuggestions)
+	titleWords := slices.Compact(slices.Sorted(slices.Values(util.SlugWords(title))))
+	if len(fields) == 0 || len(titleWords) == 0 {
+		return suggestions, nil
+	}
+
+	cards, err := s.cardStore.List(boardName, false)
+	if err != nil {
+		return nil, err
+	}
+	for _, card := range cards {
+		aliasMatches := slices.ContainsFunc(util.SlugWords(card.Alias), func(w string) bool {
+			return slices.Contains(titleWords, w)
+		})
+		for _, field := range fields {
+			for _, value := range card.GetSetValues(field) {
+				if aliasMatches {
+					scores[value]++
+				} else if _, ok := scores[value]; !ok {
+					scores[value] = 0
+				}
+			}
+		}
+	}
+
+	for value := range scores {
+		scores[value] += tagWordOverlap(titleWords, value)
+	}
+	for value, score := range scores {
+		if score > 0 {
+			suggestions = append(suggestions, value)
+		}
+	}
+	sort.Slice(suggestions, func(i, j int) bool {
+		if scores[suggestions[i]] != scores[suggestions[j]] {
+			return scores[suggestions[i]] > scores[suggestions[j]]
+		}
+		return suggestions[i] < suggestions[j]
+	})
+	if len(suggestions) > maxTagSuggestions {
+		suggestions = suggestions[:maxTagSuggestions]
+	}
+	return suggestions, nil
+}
+
+// tagWordOverlap counts the title words found in value. Words shorter than
+// three letters only count as a whole word of the value, so "ui" matches
+// "ui" but "to" doesn't match "tooling".
+func tagWordOverlap(titleWords []string, value string) int {
+	lower := strings.ToLower(value)
+	valueWords := util.SlugWords(value)
+	overlap := 0
+	for _, word := range titleWords {
+		if slices.Contains(valueWords, word) || (len(word) >= 3 && strings.Contains(lower, word)) {
+			overlap++
+		}
+	}
+	return overlap
+}
+
+// FilterIncompleteChecklist returns the cards with at least one checklist item
+// not yet done, in their original order.
+func FilterIncompleteChecklist(cards []*model.Card) []*model.Card {
+	var incomplete []*model.Card
+	for _, card := range cards {
+		if card.HasIncompleteChecklist() {
+			incomplete = append(incomplete, card)
+		}
+	}
+	return incomplete
+}
+
+// FilterByField returns the cards that have (set true) or lack (set false) a
+// value for the custom field, in their original order.
+func FilterByField(cards []*model.Card, fieldName string, set bool) []*model.Card {
+	var kept []*model.Card
+	for _, card := range cards {
+		value, ok := card.CustomFields[fieldName]
+		if (ok && value != nil) == set {
+			kept = append(kept, card)
+		}
+	}
+	return kept
+}
+
+// FilterByMention returns the cards with at least one comment mentioning the
+// user, in their original order.
+func FilterByMention(cards []*model.Card, user string) []*model.Card {
+	var kept []*model.Card
+	for _, card := range cards {
+		for _, comment := range card.Comments {
+			if slices.Contains(comment.Mentions, user) {
+				kept = append(kept, card)
+				break
+			}
+		}
+	}
+	return kept
+}
+
+// PaginateCards returns the 1-indexed page of cards and the total card count.
+// A page past the end yields no cards rather than an error, so clients can
+// still read the total.
+func PaginateCards(cards []*model.Card, page, perPage int) ([]*model.Card, int, error) {
+	if page < 1 {
+		return nil, 0, kanerr.InvalidField("page", "must be at least 1")
+	}
+	if perPage < 1 {
+		return nil, 0, kanerr.InvalidField("per_page", "must be at least 1")
+	}
+
+	total := len(cards)
+	start := (page - 1) * perPage
+	if start >= total {
+		return []*model.Card{}, total, nil
+	}
+	end := min(start+perPage, total)
+	return cards[start:end], total, nil
+}
+
 // isEmpty checks if a custom field value is empty for its type.
 func isEmpty(value any, fieldType string) bool {
 	if value == nil {
@@ -836,7 +2687,7 @@ func isEmpty(value any, fieldType string) bool {
 	}
 
 	switch fieldType {
-	case model.FieldTypeString, model.FieldTypeEnum, model.FieldTypeDate:
+	case model.FieldTypeString, model.FieldTypeEnum, model.FieldTypeDate, model.FieldTypeURL, model.FieldTypeUser:
 		s, ok := value.(string)
 		return !ok || s == ""
 	case model.FieldTypeEnumSet, model.FieldTypeFreeSet:
@@ -851,19 +2702,42 @@ func isEmpty(value any, fieldType string) bool {
 	case model.FieldTypeBoolean:
 		_, ok := value.(bool)
 		return !ok
+	case model.FieldTypeInteger:
+		// Freshly set values are int; values read back from card JSON are float64.
+		switch value.(type) {
+		case int, int64, float64:
+			return false
+		default:
+			return true
+		}
 	default:
 		return true
 	}
 }
 
+// Comment limits. Comments live inline in the card file, so unbounded bodies
+// or counts would make every card read and write progressively slower.
+const (
+	MaxCommentBodyBytes = 65536
+	MaxCommentsPerCard  = 500
+)
+
 // AddComment adds a new comment to a card.
 func (s *CardService) AddComment(boardName, cardIDOrAlias, body, author string) (*model.Comment, error) {
+	if len(body) > MaxCommentBodyBytes {
+		return nil, kanerr.InvalidField("body", "comment body too long")
+	}
+
 	// Resolve card
 	card, err := s.FindByIDOrAlias(boardName, cardIDOrAlias)
 	if err != nil {
 		return nil, err
 	}
 
+	if len(card.Comments) >= MaxCommentsPerCard {
+		return nil, kanerr.InvalidField("comments", "too many comments")
+	}
+
 	// Create comment
 	now := util.NowMillis()
 	comment := model.Comment{
@@ -871,6 +2745,7 @@ func (s *CardService) AddComment(boardName, cardIDOrAlias, body, author string)
 		Body:            body,
 		Author:          author,
 		CreatedAtMillis: now,
+		Mentions:        ExtractMentions(body),
 	}
 
 	// Add to card's comments
@@ -884,8 +2759,60 @@ func (s *CardService) AddComment(boardName, cardIDOrAlias, body, author string)
 	return &comment, nil
 }
 
+// mentionPattern matches an @mention in a comment body.
+var mentionPattern = regexp.MustCompile(`@([A-Za-z0-9_-]+)`)
+
+// ExtractMentions returns the users @mentioned in a comment body, in order of
+// first appearance and without duplicates.
+func ExtractMentions(body string) []string {
+	var mentions []string
+	for _, match := range mentionPattern.FindAllStringSubmatch(body, -1) {
+		if !slices.Contains(mentions, match[1]) {
+			mentions = append(mentions, match[1])
+		}
+	}
+	return mentions
+}
+
+// UnknownMentions returns the mentions that aren't collaborators on the board.
+// A board without collaborators knows every user, so nothing is returned.
+func (s *CardService) UnknownMentions(boardName string, mentions []string) ([]string, error) {
+	cfg, err := s.boardStore.Get(boardName)
+	if err != nil {
+		return nil, err
+	}
+	var unknown []string
+	for _, name := range mentions {
+		if !cfg.AcceptsUser(name) {
+			unknown = append(unknown, name)
+		}
+	}
+	return unknown, nil
+}
+
+// ListComments returns a card's comments, oldest first.
+func (s *CardService) ListComments(boardName, cardIDOrAlias string) ([]model.Comment, error) {
+	card, err := s.FindByIDOrAlias(boardName, cardIDOrAlias)
+	if err != nil {
+		return nil, err
+	}
+
+	comments := slices.Clone(card.Comments)
+	if comments == nil {
+		comments = []model.Comment{}
+	}
+	sort.SliceStable(comments, func(i, j int) bool {
+		return comments[i].CreatedAtMillis < comments[j].CreatedAtMillis
+	})
+	return comments, nil
+}
+
 // EditComment updates an existing comment's body.
 func (s *CardService) EditComment(boardName, commentID, body string) (*model.Comment, error) {
+	if len(body) > MaxCommentBodyBytes {
+		return nil, kanerr.InvalidField("body", "comment body too long")
+	}
+
 	// Find card containing this comment
 	card, err := s.FindCommentCard(boardName, commentID)
 	if err != nil {
@@ -896,6 +2823,7 @@ func (s *CardService) EditComment(boardName, commentID, body string) (*model.Com
 	for i := range card.Comments {
 		if card.Comments[i].ID == commentID {
 			card.Comments[i].Body = body
+			card.Comments[i].Mentions = ExtractMentions(body)
 			card.Comments[i].UpdatedAtMillis = util.NowMillis()
 
 			// Save card
@@ -931,9 +2859,69 @@ func (s *CardService) DeleteComment(boardName, commentID string) error {
 	return kanerr.CommentNotFound(commentID)
 }
 
+// AddChecklistItem appends a new, not-done item to a card's checklist.
+func (s *CardService) AddChecklistItem(boardName, cardIDOrAlias, text string) (*model.ChecklistItem, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, kanerr.InvalidField("text", "must not be empty")
+	}
+
+	card, err := s.FindByIDOrAlias(boardName, cardIDOrAlias)
+	if err != nil {
+		return nil, err
+	}
+
+	item := model.ChecklistItem{
+		ID:              id.Generate(id.ChecklistItem),
+		Text:            text,
+		CreatedAtMillis: util.NowMillis(),
+	}
+	card.Checklist = append(card.Checklist, item)
+
+	if err := s.Update(boardName, card); err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// ToggleChecklistItem marks a checklist item done or not done.
+func (s *CardService) ToggleChecklistItem(boardName, cardIDOrAlias, itemID string, done bool) error {
+	card, err := s.FindByIDOrAlias(boardName, cardIDOrAlias)
+	if err != nil {
+		return err
+	}
+
+	for i := range card.Checklist {
+		if card.Checklist[i].ID == itemID {
+			if card.Checklist[i].Done == done {
+				return nil
+			}
+			card.Checklist[i].Done = done
+			return s.Update(boardName, card)
+		}
+	}
+	return kanerr.ChecklistItemNotFound(itemID)
+}
+
+// DeleteChecklistItem removes an item from a card's checklist.
+func (s *CardService) DeleteChecklistItem(boardName, cardIDOrAlias, itemID string) error {
+	card, err := s.FindByIDOrAlias(boardName, cardIDOrAlias)
+	if err != nil {
+		return err
+	}
+
+	for i, item := range card.Checklist {
+		if item.ID == itemID {
+			card.Checklist = append(card.Checklist[:i], card.Checklist[i+1:]...)
+			return s.Update(boardName, card)
+		}
+	}
+	return kanerr.ChecklistItemNotFound(itemID)
+}
+
 // FindCommentCard finds the card containing a comment with the given ID.
 func (s *CardService) FindCommentCard(boardName, commentID string) (*model.Card, error) {
-	cards, err := s.cardStore.List(boardName)
+	cards, err := s.cardStore.List(boardName, true)
 	if err != nil {
 		return nil, err
 	}
@@ -949,6 +2937,230 @@ func (s *CardService) FindCommentCard(boardName, commentID string) (*model.Card,
 	return nil, kanerr.CommentNotFound(commentID)
 }
 
+// CSVMapping maps CSV header names to card fields: "title", "description",
+// "column", or the name of one of the board's custom fields. An empty mapping
+// maps every header that names one of those fields to itself and ignores the
+// rest.
+type CSVMapping map[string]string
+
+// CSVRowError describes a CSV row that could not be imported. Row is the
+// 1-based line number in the file, counting the header as line 1.
+type CSVRowError struct {
+	Row int
+	Err error
+}
+
+// CSVImportError is returned by ImportCSV when some rows failed. The other
+// rows were still imported.
+type CSVImportError struct {
+	Rows []CSVRowError
+}
+
+func (e *CSVImportError) Error() string {
+	msgs := make([]string, len(e.Rows))
+	for i, row := range e.Rows {
+		msgs[i] = fmt.Sprintf("row %d: %v", row.Row, row.Err)
+	}
+	return fmt.Sprintf("%d row(s) failed to import: %s", len(e.Rows), strings.Join(msgs, "; "))
+}
+
+// csvBuiltinFields are the card fields a CSV column can map to besides the
+// board's custom fields.
+var csvBuiltinFields = []string{"title", "description", "column"}
+
+// ImportCSV creates one card per CSV row via Add, so placement, column
+// limits, custom field validation and pattern hooks all apply as usual. The
+// first row is the header. Rows with an empty title are skipped. A row that
+// fails to import is skipped too and reported in a *CSVImportError, but the
+// remaining rows are still imported. Rows without a column use the board's
+// default column, and empty custom field cells leave the field unset. The
+// imported cards are returned in row order.
+func (s *CardService) ImportCSV(boardName string, r io.Reader, mapping CSVMapping, creator string) (imported []*model.Card, skipped int, err error) {
+	boardCfg, err := s.boardStore.Get(boardName)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, 0, kanerr.InvalidField("csv", "file is empty")
+	}
+	if err != nil {
+		return nil, 0, kanerr.InvalidField("csv", err.Error())
+	}
+
+	isField := func(name string) bool {
+		return slices.Contains(csvBuiltinFields, name) || boardCfg.CustomFields[name].Type != ""
+	}
+	// fieldAt maps each CSV column index to the card field it fills.
+	fieldAt := make(map[int]string)
+	for i, name := range header {
+		name = strings.TrimSpace(name)
+		if len(mapping) == 0 {
+			if isField(name) {
+				fieldAt[i] = name
+			}
+		} else if field, ok := mapping[name]; ok {
+			fieldAt[i] = field
+		}
+	}
+	for name, field := range mapping {
+		if !isField(field) {
+			return nil, 0, kanerr.InvalidField("mapping", fmt.Sprintf("%q is not a card field or custom field on board %q", field, boardName))
+		}
+		if !slices.ContainsFunc(header, func(h string) bool { return strings.TrimSpace(h) == name }) {
+			return nil, 0, kanerr.InvalidField("mapping", fmt.Sprintf("CSV has no %q column", name))
+		}
+	}
+	if !slices.Contains(slices.Collect(maps.Values(fieldAt)), "title") {
+		return nil, 0, kanerr.InvalidField("mapping", "no CSV column maps to title")
+	}
+
+	var rowErrs []CSVRowError
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			// A malformed row (e.g. a stray quote) doesn't stop the import,
+			// but a failure to read does: it would repeat on every row.
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				return imported, skipped, fmt.Errorf("failed to read CSV: %w", err)
+			}
+			rowErrs = append(rowErrs, CSVRowError{Row: parseErr.StartLine, Err: err})
+			skipped++
+			continue
+		}
+		line, _ := reader.FieldPos(0)
+
+		input := AddCardInput{BoardName: boardName, Creator: creator}
+		for i, value := range record {
+			field, ok := fieldAt[i]
+			if !ok {
+				continue
+			}
+			value = strings.TrimSpace(value)
+			switch field {
+			case "title":
+				input.Title = value
+			case "description":
+				input.Description = value
+			case "column":
+				input.Column = value
+			default:
+				if value == "" {
+					continue
+				}
+				if input.CustomFields == nil {
+					input.CustomFields = make(map[string]string)
+				}
+				input.CustomFields[field] = value
+			}
+		}
+
+		if input.Title == "" {
+			skipped++
+			continue
+		}
+		card, _, err := s.Add(input)
+		if err != nil {
+			rowErrs = append(rowErrs, CSVRowError{Row: line, Err: err})
+			skipped++
+			continue
+		}
+		imported = append(imported, card)
+	}
+
+	if len(rowErrs) > 0 {
+		return imported, skipped, &CSVImportError{Rows: rowErrs}
+	}
+	return imported, skipped, nil
+}
+
+// ImportJSONL reads newline-delimited JSON cards, as written by
+// FileCardStore.ExportJSONL, into a board, keeping their IDs: a card already
+// on the board is updated, any other is created. Each card is checked as an
+// added or edited one would be: its column must exist, its custom fields must
+// match the board's schema, and its alias must not belong to another card.
+// Cards are otherwise written as they are, timestamps included, so importing
+// the same export twice leaves the board unchanged.
+//
+// Cards are written as they're read. On a bad card the cards before it stay
+// imported, and are returned alongside the error.
+func (s *CardService) ImportJSONL(boardName string, r io.Reader) (created, updated []*model.Card, err error) {
+	boardCfg, err := s.boardStore.Get(boardName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = store.ReadJSONL(r, func(_ int, card *model.Card) error {
+		if !boardCfg.HasColumn(card.Column) {
+			return kanerr.InvalidField("jsonl", fmt.Sprintf("card %s is in unknown column %q", card.ID, card.Column))
+		}
+		if err := s.checkImportedFields(card, boardCfg); err != nil {
+			return kanerr.InvalidField("jsonl", fmt.Sprintf("card %s: %v", card.ID, err))
+		}
+		if card.Alias != "" {
+			if existing, err := s.cardStore.FindByAlias(boardName, card.Alias); err == nil && existing.ID != card.ID {
+				return kanerr.InvalidField("jsonl", fmt.Sprintf("card %s: alias %q already in use by card %s", card.ID, card.Alias, existing.ID))
+			}
+		}
+
+		prev, err := s.cardStore.Get(boardName, card.ID)
+		if err == nil {
+			if err := s.cardStore.Update(boardName, card); err != nil {
+				return err
+			}
+			if delta := cardDelta(prev, card); len(delta) > 0 {
+				s.recordAudit(boardName, card.ID, model.AuditActionUpdated, delta)
+			}
+			updated = append(updated, card)
+			return nil
+		}
+		if !kanerr.IsNotFound(err) {
+			return err
+		}
+		if err := s.cardStore.Create(boardName, card); err != nil {
+			return err
+		}
+		s.recordAudit(boardName, card.ID, model.AuditActionCreated,
+			map[string]any{"title": card.Title, "column": card.Column})
+		metrics.CardCreatedTotal.WithLabelValues(boardName).Inc()
+		created = append(created, card)
+		return nil
+	})
+	return created, updated, err
+}
+
+// checkImportedFields validates an imported card's custom fields against the
+// board's schema, the same way edits are validated, and stores them in their
+// normalized form.
+func (s *CardService) checkImportedFields(card *model.Card, boardCfg *model.BoardConfig) error {
+	if err := model.ValidateCustomFields(card.CustomFields); err != nil {
+		return err
+	}
+	fields := make(map[string]string, len(card.CustomFields))
+	for name, value := range card.CustomFields {
+		if value != nil {
+			fields[name] = formatCustomFieldValue(value)
+		}
+	}
+	checked := &model.Card{}
+	if err := s.validateAndApplyCustomFields(checked, boardCfg, fields); err != nil {
+		return err
+	}
+	card.CustomFields = checked.CustomFields
+	if len(card.CustomFields) == 0 {
+		card.CustomFields = nil
+	}
+	return nil
+}
+
 // cardsInColumn returns cards belonging to the given column, sorted by position.
 func cardsInColumn(cards []*model.Card, column string) []*model.Card {
 	var result []*model.Card
@@ -1010,6 +3222,37 @@ func computePosition(sortedCards []*model.Card, index int) string {
 	return util.PositionBetween(sortedCards[index-1].Position, sortedCards[index].Position)
 }
 
+// computePositions generates count ascending positions that together slot in at
+// the given index of sortedCards (same index semantics as computePosition), so
+// a batch of cards lands contiguously and in order.
+func computePositions(sortedCards []*model.Card, index, count int) []string {
+	n := len(sortedCards)
+	if index < 0 {
+		index = n + 1 + index
+		if index < 0 {
+			index = 0
+		}
+	}
+	if index > n {
+		index = n
+	}
+
+	lower, upper := "", ""
+	if index > 0 {
+		lower = sortedCards[index-1].Position
+	}
+	if index < n {
+		upper = sortedCards[index].Position
+	}
+
+	positions := make([]string, count)
+	for i := range positions {
+		positions[i] = util.PositionBetween(lower, upper)
+		lower = positions[i]
+	}
+	return positions
+}
+
 // resolveInsertIndex returns the insertion index within colCards for a placement.
 // colCards must be the destination column, sorted, excluding the card being moved.
 // An explicit position (non-nil) takes precedence; otherwise beforeID/afterID
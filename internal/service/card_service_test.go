@@ -1,13 +1,23 @@
 package service
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"reflect"
+	"slices"
 	"strings"
 	"testing"
+	"testing/iotest"
+	"time"
 
+	"github.com/amterp/kan/internal/config"
 	kanerr "github.com/amterp/kan/internal/errors"
 	"github.com/amterp/kan/internal/model"
 	"github.com/amterp/kan/internal/store"
+	"github.com/amterp/kan/internal/util"
+	"github.com/amterp/kan/internal/version"
 )
 
 // testCardStore implements store.CardStore for CardService testing.
@@ -60,10 +70,13 @@ func (m *testCardStore) Delete(boardName, cardID string) error {
 	return kanerr.CardNotFound(cardID)
 }
 
-func (m *testCardStore) List(boardName string) ([]*model.Card, error) {
+func (m *testCardStore) List(boardName string, includeArchived bool) ([]*model.Card, error) {
 	var cards []*model.Card
 	if board, ok := m.cards[boardName]; ok {
 		for _, card := range board {
+			if card.Archived && !includeArchived {
+				continue
+			}
 			cards = append(cards, card)
 		}
 	}
@@ -81,11 +94,19 @@ func (m *testCardStore) FindByAlias(boardName, alias string) (*model.Card, error
 	return nil, kanerr.CardNotFound(alias)
 }
 
+func (m *testCardStore) RebuildIndex(boardName string) error { return nil }
+
+func (m *testCardStore) Stream(boardName string) (<-chan store.CardOrError, error) {
+	cards, _ := m.List(boardName, true)
+	return store.StreamSlice(cards), nil
+}
+
 var _ store.CardStore = (*testCardStore)(nil)
 
 // testBoardStore implements store.BoardStore for testing.
 type testBoardStore struct {
-	boards map[string]*model.BoardConfig
+	boards   map[string]*model.BoardConfig
+	modTimes map[string]time.Time // Unset boards report the zero time
 }
 
 func newTestBoardStore() *testBoardStore {
@@ -122,6 +143,16 @@ func (m *testBoardStore) Update(config *model.BoardConfig) error {
 }
 
 func (m *testBoardStore) List() ([]string, error) {
+	var names []string
+	for name, cfg := range m.boards {
+		if !cfg.IsArchived() {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+func (m *testBoardStore) ListAll() ([]string, error) {
 	var names []string
 	for name := range m.boards {
 		names = append(names, name)
@@ -129,6 +160,45 @@ func (m *testBoardStore) List() ([]string, error) {
 	return names, nil
 }
 
+func (m *testBoardStore) ModTime(boardName string) (time.Time, error) {
+	if _, ok := m.boards[boardName]; !ok {
+		return time.Time{}, kanerr.BoardNotFound(boardName)
+	}
+	return m.modTimes[boardName], nil
+}
+
+func (m *testBoardStore) Archive(boardName string) error {
+	cfg, ok := m.boards[boardName]
+	if !ok {
+		return kanerr.BoardNotFound(boardName)
+	}
+	cfg.ArchivedAtMillis = util.NowMillis()
+	return nil
+}
+
+func (m *testBoardStore) Unarchive(boardName string) error {
+	cfg, ok := m.boards[boardName]
+	if !ok {
+		return kanerr.BoardNotFound(boardName)
+	}
+	cfg.ArchivedAtMillis = 0
+	return nil
+}
+
+func (m *testBoardStore) Rename(oldName, newName string) error {
+	cfg, ok := m.boards[oldName]
+	if !ok {
+		return kanerr.BoardNotFound(oldName)
+	}
+	if _, exists := m.boards[newName]; exists {
+		return kanerr.BoardAlreadyExists(newName)
+	}
+	delete(m.boards, oldName)
+	cfg.Name = newName
+	m.boards[newName] = cfg
+	return nil
+}
+
 func (m *testBoardStore) Delete(boardName string) error {
 	if _, ok := m.boards[boardName]; !ok {
 		return kanerr.BoardNotFound(boardName)
@@ -179,7 +249,7 @@ func testBoardConfig(name string) *model.BoardConfig {
 func setupCardService() (*CardService, *testCardStore, *testBoardStore) {
 	cardStore := newTestCardStore()
 	boardStore := newTestBoardStore()
-	aliasService := NewAliasService(cardStore)
+	aliasService := NewAliasService(cardStore, boardStore)
 	service := NewCardService(cardStore, boardStore, aliasService)
 	return service, cardStore, boardStore
 }
@@ -273,7 +343,7 @@ func TestCardService_Add_InvalidColumn(t *testing.T) {
 	if err == nil {
 		t.Fatal("Expected error for invalid column")
 	}
-	if !kanerr.IsNotFound(err) {
+	if !kanerr.IsCode(err, kanerr.CodeColumnNotFound) {
 		t.Errorf("Expected NotFound error, got %v", err)
 	}
 }
@@ -291,7 +361,7 @@ func TestCardService_Add_InvalidCustomFieldValue(t *testing.T) {
 	if err == nil {
 		t.Fatal("Expected error for invalid custom field value")
 	}
-	if !kanerr.IsValidationError(err) {
+	if !kanerr.IsCode(err, kanerr.CodeInvalidField) {
 		t.Errorf("Expected ValidationError, got %v", err)
 	}
 }
@@ -308,7 +378,7 @@ func TestCardService_Add_BoardNotFound(t *testing.T) {
 	if err == nil {
 		t.Fatal("Expected error for nonexistent board")
 	}
-	if !kanerr.IsNotFound(err) {
+	if !kanerr.IsCode(err, kanerr.CodeBoardNotFound) {
 		t.Errorf("Expected NotFound error, got %v", err)
 	}
 }
@@ -370,7 +440,7 @@ func TestCardService_Get_NotFound(t *testing.T) {
 	if err == nil {
 		t.Fatal("Expected error for nonexistent card")
 	}
-	if !kanerr.IsNotFound(err) {
+	if !kanerr.IsCode(err, kanerr.CodeCardNotFound) {
 		t.Errorf("Expected NotFound error, got %v", err)
 	}
 }
@@ -472,6 +542,85 @@ func TestCardService_List_WithColumnFilter(t *testing.T) {
 	}
 }
 
+func TestCardService_ListWithOptions_Streaming(t *testing.T) {
+	service, _, boardStore := setupCardService()
+	boardStore.addBoard(testBoardConfig("main"))
+
+	mustAdd(t, service, AddCardInput{BoardName: "main", Title: "backlog 1", Column: "backlog"})
+	mustAdd(t, service, AddCardInput{BoardName: "main", Title: "in-progress 1", Column: "in-progress"})
+	mustAdd(t, service, AddCardInput{BoardName: "main", Title: "backlog 2", Column: "backlog"})
+	archived := mustAdd(t, service, AddCardInput{BoardName: "main", Title: "backlog 3", Column: "backlog"})
+	if err := service.Archive("main", archived.ID); err != nil {
+		t.Fatalf("Archive failed: %v", err)
+	}
+
+	for _, column := range []string{"", "backlog"} {
+		batch, err := service.ListWithOptions("main", column, ListOptions{})
+		if err != nil {
+			t.Fatalf("ListWithOptions failed: %v", err)
+		}
+		streamed, err := service.ListWithOptions("main", column, ListOptions{Streaming: true})
+		if err != nil {
+			t.Fatalf("ListWithOptions (streaming) failed: %v", err)
+		}
+		if got, want := serviceCardIDs(streamed), serviceCardIDs(batch); !slices.Equal(got, want) {
+			t.Errorf("column %q: streaming listed %v, batch listed %v", column, got, want)
+		}
+	}
+}
+
+func TestCardService_ReorderCards(t *testing.T) {
+	service, _, boardStore := setupCardService()
+	boardStore.addBoard(testBoardConfig("main"))
+
+	a := mustAdd(t, service, AddCardInput{BoardName: "main", Title: "A", Column: "backlog"})
+	b := mustAdd(t, service, AddCardInput{BoardName: "main", Title: "B", Column: "backlog"})
+	c := mustAdd(t, service, AddCardInput{BoardName: "main", Title: "C", Column: "backlog"})
+	other := mustAdd(t, service, AddCardInput{BoardName: "main", Title: "Elsewhere", Column: "done"})
+
+	t.Run("valid permutation", func(t *testing.T) {
+		if err := service.ReorderCards("main", "backlog", []string{c.ID, a.ID, b.ID}); err != nil {
+			t.Fatalf("ReorderCards failed: %v", err)
+		}
+		cards, _ := service.List("main", "backlog")
+		if got, want := serviceCardIDs(cards), []string{c.ID, a.ID, b.ID}; !slices.Equal(got, want) {
+			t.Errorf("Order = %v, want %v", got, want)
+		}
+		for _, card := range cards {
+			if card.Column != "backlog" {
+				t.Errorf("Card %s changed column to %q", card.ID, card.Column)
+			}
+		}
+	})
+
+	invalid := map[string][]string{
+		"missing ID":     {c.ID, a.ID},
+		"extra ID":       {c.ID, a.ID, b.ID, other.ID},
+		"unknown ID":     {c.ID, a.ID, "nope"},
+		"duplicated ID":  {c.ID, a.ID, a.ID},
+		"nothing listed": nil,
+	}
+	for name, ids := range invalid {
+		t.Run(name, func(t *testing.T) {
+			if err := service.ReorderCards("main", "backlog", ids); !kanerr.IsCode(err, kanerr.CodeInvalidField) {
+				t.Errorf("Expected validation error, got %v", err)
+			}
+		})
+	}
+
+	t.Run("empty column", func(t *testing.T) {
+		if err := service.ReorderCards("main", "in-progress", []string{}); err != nil {
+			t.Errorf("Expected empty reorder of an empty column to succeed, got %v", err)
+		}
+	})
+
+	t.Run("unknown column", func(t *testing.T) {
+		if err := service.ReorderCards("main", "nope", []string{}); !kanerr.IsCode(err, kanerr.CodeColumnNotFound) {
+			t.Errorf("Expected not-found error, got %v", err)
+		}
+	})
+}
+
 func TestCardService_List_OrderedByBoardConfig(t *testing.T) {
 	service, _, boardStore := setupCardService()
 	boardStore.addBoard(testBoardConfig("main"))
@@ -501,6 +650,64 @@ func TestCardService_List_OrderedByBoardConfig(t *testing.T) {
 	}
 }
 
+func TestCardService_ListPaginated(t *testing.T) {
+	service, _, boardStore := setupCardService()
+	boardStore.addBoard(testBoardConfig("main"))
+
+	var backlog []*model.Card
+	for _, title := range []string{"B1", "B2", "B3", "B4", "B5"} {
+		backlog = append(backlog, mustAdd(t, service, AddCardInput{BoardName: "main", Title: title, Column: "backlog"}))
+	}
+	d1 := mustAdd(t, service, AddCardInput{BoardName: "main", Title: "D1", Column: "done"})
+	mustAdd(t, service, AddCardInput{BoardName: "main", Title: "D2", Column: "done"})
+
+	cases := []struct {
+		name      string
+		column    string
+		page      int
+		perPage   int
+		wantIDs   []string
+		wantTotal int
+	}{
+		{name: "first page", page: 1, perPage: 3, wantIDs: []string{backlog[0].ID, backlog[1].ID, backlog[2].ID}, wantTotal: 7},
+		{name: "page spans columns", page: 2, perPage: 3, wantIDs: []string{backlog[3].ID, backlog[4].ID, d1.ID}, wantTotal: 7},
+		{name: "past the end", page: 4, perPage: 3, wantIDs: nil, wantTotal: 7},
+		{name: "column filter total", column: "backlog", page: 2, perPage: 2, wantIDs: []string{backlog[2].ID, backlog[3].ID}, wantTotal: 5},
+		{name: "column filter last partial page", column: "backlog", page: 3, perPage: 2, wantIDs: []string{backlog[4].ID}, wantTotal: 5},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cards, total, err := service.ListPaginated("main", tc.column, tc.page, tc.perPage)
+			if err != nil {
+				t.Fatalf("ListPaginated failed: %v", err)
+			}
+			if total != tc.wantTotal {
+				t.Errorf("Expected total %d, got %d", tc.wantTotal, total)
+			}
+			if len(cards) != len(tc.wantIDs) {
+				t.Fatalf("Expected %d cards, got %d", len(tc.wantIDs), len(cards))
+			}
+			for i, id := range tc.wantIDs {
+				if cards[i].ID != id {
+					t.Errorf("cards[%d]: expected %s, got %s (%s)", i, id, cards[i].ID, cards[i].Title)
+				}
+			}
+		})
+	}
+}
+
+func TestCardService_ListPaginated_InvalidArgs(t *testing.T) {
+	service, _, boardStore := setupCardService()
+	boardStore.addBoard(testBoardConfig("main"))
+
+	if _, _, err := service.ListPaginated("main", "", 0, 10); !kanerr.IsCode(err, kanerr.CodeInvalidField) {
+		t.Errorf("Expected validation error for page 0, got %v", err)
+	}
+	if _, _, err := service.ListPaginated("main", "", 1, 0); !kanerr.IsCode(err, kanerr.CodeInvalidField) {
+		t.Errorf("Expected validation error for per_page 0, got %v", err)
+	}
+}
+
 func TestCardService_ListSorted_ByCustomField(t *testing.T) {
 	service, cardStore, boardStore := setupCardService()
 	boardStore.addBoard(testBoardConfig("main"))
@@ -647,7 +854,7 @@ func TestCardService_MoveCard_InvalidColumn(t *testing.T) {
 	if err == nil {
 		t.Fatal("Expected error for invalid column")
 	}
-	if !kanerr.IsNotFound(err) {
+	if !kanerr.IsCode(err, kanerr.CodeColumnNotFound) {
 		t.Errorf("Expected NotFound error, got %v", err)
 	}
 }
@@ -660,7 +867,7 @@ func TestCardService_MoveCard_CardNotFound(t *testing.T) {
 	if err == nil {
 		t.Fatal("Expected error for nonexistent card")
 	}
-	if !kanerr.IsNotFound(err) {
+	if !kanerr.IsCode(err, kanerr.CodeCardNotFound) {
 		t.Errorf("Expected NotFound error, got %v", err)
 	}
 }
@@ -728,11 +935,54 @@ func TestCardService_FindByIDOrAlias_NotFound(t *testing.T) {
 	if err == nil {
 		t.Fatal("Expected error for nonexistent card")
 	}
-	if !kanerr.IsNotFound(err) {
+	if !kanerr.IsCode(err, kanerr.CodeCardNotFound) {
 		t.Errorf("Expected NotFound error, got %v", err)
 	}
 }
 
+func TestCardService_FetchByIDsOrAliases(t *testing.T) {
+	service, _, boardStore := setupCardService()
+	boardStore.addBoard(testBoardConfig("main"))
+
+	login := mustAdd(t, service, AddCardInput{BoardName: "main", Title: "Fix login bug"})
+	docs := mustAdd(t, service, AddCardInput{BoardName: "main", Title: "Write docs"})
+
+	tests := []struct {
+		name         string
+		input        []string
+		wantIDs      []string
+		wantNotFound []string
+	}{
+		{"all found by ID", []string{docs.ID, login.ID}, []string{docs.ID, login.ID}, []string{}},
+		{"some not found", []string{login.ID, "card-xyz", "gone"}, []string{login.ID}, []string{"card-xyz", "gone"}},
+		{"aliases mixed with IDs", []string{"write-docs", login.ID}, []string{docs.ID, login.ID}, []string{}},
+		{"same card twice", []string{login.ID, "fix-login-bug"}, []string{login.ID}, []string{}},
+		{"empty input", nil, []string{}, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cards, notFound, err := service.FetchByIDsOrAliases("main", tt.input)
+			if err != nil {
+				t.Fatalf("FetchByIDsOrAliases failed: %v", err)
+			}
+			ids := []string{}
+			for _, card := range cards {
+				ids = append(ids, card.ID)
+			}
+			if !reflect.DeepEqual(ids, tt.wantIDs) {
+				t.Errorf("Cards = %v, want %v", ids, tt.wantIDs)
+			}
+			if !reflect.DeepEqual(notFound, tt.wantNotFound) {
+				t.Errorf("NotFound = %v, want %v", notFound, tt.wantNotFound)
+			}
+		})
+	}
+
+	if _, _, err := service.FetchByIDsOrAliases("missing", []string{login.ID}); !kanerr.IsCode(err, kanerr.CodeBoardNotFound) {
+		t.Errorf("Expected board not found, got %v", err)
+	}
+}
+
 // ============================================================================
 // UpdateTitle() Tests
 // ============================================================================
@@ -800,7 +1050,7 @@ func TestCardService_Delete_RemovesFromCardStore(t *testing.T) {
 
 	// Verify removed from card store
 	_, err := cardStore.Get("main", card.ID)
-	if !kanerr.IsNotFound(err) {
+	if !kanerr.IsCode(err, kanerr.CodeCardNotFound) {
 		t.Error("Card should be removed from card store")
 	}
 }
@@ -813,15 +1063,306 @@ func TestCardService_Delete_CardNotFound(t *testing.T) {
 	if err == nil {
 		t.Fatal("Expected error for nonexistent card")
 	}
-	if !kanerr.IsNotFound(err) {
+	if !kanerr.IsCode(err, kanerr.CodeCardNotFound) {
 		t.Errorf("Expected NotFound error, got %v", err)
 	}
 }
 
+// addFamily creates grandparent -> parent -> child, plus an unrelated card.
+func addFamily(t *testing.T, s *CardService) (grandparent, parent, child, other *model.Card) {
+	t.Helper()
+	grandparent = mustAdd(t, s, AddCardInput{BoardName: "main", Title: "Grandparent"})
+	parent = mustAdd(t, s, AddCardInput{BoardName: "main", Title: "Parent", Parent: grandparent.ID})
+	child = mustAdd(t, s, AddCardInput{BoardName: "main", Title: "Child", Parent: parent.ID})
+	other = mustAdd(t, s, AddCardInput{BoardName: "main", Title: "Other"})
+	return grandparent, parent, child, other
+}
+
+func TestCardService_DeleteWithOptions_Clear(t *testing.T) {
+	s, cardStore, boardStore := setupCardService()
+	boardStore.addBoard(testBoardConfig("main"))
+	_, parent, child, _ := addFamily(t, s)
+
+	// The default behavior (also used by Delete) is clear.
+	deleted, err := s.DeleteWithOptions("main", parent.ID, DeleteOptions{})
+	if err != nil {
+		t.Fatalf("DeleteWithOptions failed: %v", err)
+	}
+	if !reflect.DeepEqual(deleted, []string{parent.ID}) {
+		t.Errorf("deleted = %v, want [%s]", deleted, parent.ID)
+	}
+	got, err := cardStore.Get("main", child.ID)
+	if err != nil {
+		t.Fatalf("Expected child to survive: %v", err)
+	}
+	if got.Parent != "" {
+		t.Errorf("Expected child's parent to be cleared, got %q", got.Parent)
+	}
+}
+
+func TestCardService_DeleteWithOptions_Reparent(t *testing.T) {
+	s, cardStore, boardStore := setupCardService()
+	boardStore.addBoard(testBoardConfig("main"))
+	grandparent, parent, child, _ := addFamily(t, s)
+
+	if _, err := s.DeleteWithOptions("main", parent.ID, DeleteOptions{ParentBehavior: ParentBehaviorReparent}); err != nil {
+		t.Fatalf("DeleteWithOptions failed: %v", err)
+	}
+	got, err := cardStore.Get("main", child.ID)
+	if err != nil {
+		t.Fatalf("Expected child to survive: %v", err)
+	}
+	if got.Parent != grandparent.ID {
+		t.Errorf("Expected child to move up to %s, got parent %q", grandparent.ID, got.Parent)
+	}
+}
+
+func TestCardService_DeleteWithOptions_Delete(t *testing.T) {
+	s, cardStore, boardStore := setupCardService()
+	boardStore.addBoard(testBoardConfig("main"))
+	grandparent, parent, child, other := addFamily(t, s)
+
+	deleted, err := s.DeleteWithOptions("main", grandparent.ID, DeleteOptions{ParentBehavior: ParentBehaviorDelete})
+	if err != nil {
+		t.Fatalf("DeleteWithOptions failed: %v", err)
+	}
+	want := []string{grandparent.ID, parent.ID, child.ID}
+	if !reflect.DeepEqual(deleted, want) {
+		t.Errorf("deleted = %v, want %v", deleted, want)
+	}
+	if remaining := cardStore.cards["main"]; len(remaining) != 1 || remaining[other.ID] == nil {
+		t.Errorf("Expected only %q to remain, got %v", other.Title, remaining)
+	}
+}
+
+func TestCardService_DeleteWithOptions_DeleteCycle(t *testing.T) {
+	s, cardStore, boardStore := setupCardService()
+	boardStore.addBoard(testBoardConfig("main"))
+	a := mustAdd(t, s, AddCardInput{BoardName: "main", Title: "A"})
+	b := mustAdd(t, s, AddCardInput{BoardName: "main", Title: "B", Parent: a.ID})
+	// Close the loop behind the service's back, as a bad merge might.
+	a.Parent = b.ID
+	if err := cardStore.Update("main", a); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	deleted, err := s.DeleteWithOptions("main", a.ID, DeleteOptions{ParentBehavior: ParentBehaviorDelete})
+	if err != nil {
+		t.Fatalf("DeleteWithOptions failed: %v", err)
+	}
+	if !reflect.DeepEqual(deleted, []string{a.ID, b.ID}) {
+		t.Errorf("deleted = %v, want [%s %s]", deleted, a.ID, b.ID)
+	}
+}
+
+func TestCardService_DeleteWithOptions_InvalidBehavior(t *testing.T) {
+	s, _, boardStore := setupCardService()
+	boardStore.addBoard(testBoardConfig("main"))
+	card := mustAdd(t, s, AddCardInput{BoardName: "main", Title: "A"})
+
+	if _, err := s.DeleteWithOptions("main", card.ID, DeleteOptions{ParentBehavior: "orphan"}); !kanerr.IsCode(err, kanerr.CodeInvalidField) {
+		t.Errorf("Expected validation error, got %v", err)
+	}
+}
+
 // ============================================================================
 // Edit() Tests
 // ============================================================================
 
+// labelsBoardConfig returns a test board whose labels field is a tags field.
+func labelsBoardConfig() *model.BoardConfig {
+	cfg := testBoardConfig("main")
+	cfg.CustomFields[model.LabelsField] = model.CustomFieldSchema{Type: model.FieldTypeFreeSet}
+	return cfg
+}
+
+func TestCardService_Add_Labels(t *testing.T) {
+	service, _, boardStore := setupCardService()
+	boardStore.addBoard(labelsBoardConfig())
+
+	labels := []string{"ui", "urgent"}
+	card, _, err := service.Add(AddCardInput{BoardName: "main", Title: "Labelled", Labels: &labels})
+	if err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if got := card.GetLabels(); !reflect.DeepEqual(got, labels) {
+		t.Errorf("Expected labels %v, got %v", labels, got)
+	}
+}
+
+func TestCardService_Add_Labels_CreatesField(t *testing.T) {
+	service, _, boardStore := setupCardService()
+	cfg := testBoardConfig("main")
+	delete(cfg.CustomFields, model.LabelsField)
+	boardStore.addBoard(cfg)
+
+	labels := []string{"ui"}
+	card, _, err := service.Add(AddCardInput{BoardName: "main", Title: "Labelled", Labels: &labels})
+	if err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if got := card.GetLabels(); !reflect.DeepEqual(got, labels) {
+		t.Errorf("Expected labels %v, got %v", labels, got)
+	}
+
+	saved, _ := boardStore.Get("main")
+	if schema, ok := saved.CustomFields[model.LabelsField]; !ok || schema.Type != model.FieldTypeFreeSet {
+		t.Errorf("Expected a tags labels field to be created, got %+v", saved.CustomFields)
+	}
+}
+
+func TestCardService_Add_Labels_InvalidLeavesConfig(t *testing.T) {
+	paths := config.NewPaths(t.TempDir(), "")
+	cardStore, boardStore := store.NewCardStore(paths), store.NewBoardStore(paths)
+	cfg := testBoardConfig("main")
+	delete(cfg.CustomFields, model.LabelsField)
+	if err := boardStore.Create(cfg); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	service := NewCardService(cardStore, boardStore, NewAliasService(cardStore, boardStore))
+
+	labels := []string{strings.Repeat("x", model.DefaultTagMaxLength+1)}
+	if _, _, err := service.Add(AddCardInput{BoardName: "main", Title: "Labelled", Labels: &labels}); !kanerr.IsValidationError(err) {
+		t.Fatalf("Expected ValidationError for an overlong label, got %v", err)
+	}
+	saved, err := boardStore.Get("main")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if _, ok := saved.CustomFields[model.LabelsField]; ok {
+		t.Errorf("Expected no labels field saved for a rejected card, got %+v", saved.CustomFields)
+	}
+}
+
+func TestCardService_Labels_KeepCommas(t *testing.T) {
+	service, _, boardStore := setupCardService()
+	boardStore.addBoard(labelsBoardConfig())
+
+	labels := []string{"needs review, urgently", "ui"}
+	card, _, err := service.Add(AddCardInput{BoardName: "main", Title: "Labelled", Labels: &labels})
+	if err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if got := card.GetLabels(); !reflect.DeepEqual(got, labels) {
+		t.Errorf("Expected labels %v, got %v", labels, got)
+	}
+
+	dup, err := service.Clone("main", card.ID, CloneOptions{Labels: []string{"a,b"}})
+	if err != nil {
+		t.Fatalf("Clone failed: %v", err)
+	}
+	if got, want := dup.GetLabels(), append(labels, "a,b"); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected labels %v, got %v", want, got)
+	}
+}
+
+// templateBoardConfig returns a test board with a bug-report card template.
+func templateBoardConfig() *model.BoardConfig {
+	cfg := testBoardConfig("main")
+	cfg.Templates = []model.CardTemplate{{
+		Name:                "bug-report",
+		TitlePattern:        "[{{.component}}] {{.severity}} bug",
+		DefaultColumn:       "in-progress",
+		DefaultCustomFields: map[string]string{"type": "bug"},
+		DefaultDescription:  "Component: {{.component}}",
+	}}
+	return cfg
+}
+
+func TestCardService_ApplyTemplate(t *testing.T) {
+	service, _, boardStore := setupCardService()
+	boardStore.addBoard(templateBoardConfig())
+
+	vars := map[string]string{"component": "auth", "severity": "high"}
+	card, err := service.ApplyTemplate("main", "bug-report", vars)
+	if err != nil {
+		t.Fatalf("ApplyTemplate failed: %v", err)
+	}
+	if card.Title != "[auth] high bug" {
+		t.Errorf("Title = %q, want %q", card.Title, "[auth] high bug")
+	}
+	if card.Description != "Component: auth" {
+		t.Errorf("Description = %q, want %q", card.Description, "Component: auth")
+	}
+	if card.Column != "in-progress" {
+		t.Errorf("Column = %q, want in-progress", card.Column)
+	}
+	if card.CustomFields["type"] != "bug" {
+		t.Errorf("Expected type=bug from template defaults, got %v", card.CustomFields)
+	}
+	// Applying the template must not share its default fields map.
+	cfg, _ := boardStore.Get("main")
+	card.CustomFields["type"] = "task"
+	if cfg.Templates[0].DefaultCustomFields["type"] != "bug" {
+		t.Error("Expected template defaults to be unaffected by the card")
+	}
+
+	card, _, err = service.ApplyTemplateAs("main", "bug-report", "alice", vars)
+	if err != nil {
+		t.Fatalf("ApplyTemplateAs failed: %v", err)
+	}
+	if card.Creator != "alice" {
+		t.Errorf("Creator = %q, want alice", card.Creator)
+	}
+}
+
+func TestCardService_ApplyTemplate_MissingVar(t *testing.T) {
+	service, cardStore, boardStore := setupCardService()
+	boardStore.addBoard(templateBoardConfig())
+
+	_, err := service.ApplyTemplate("main", "bug-report", map[string]string{"component": "auth"})
+	if !kanerr.IsCode(err, kanerr.CodeInvalidField) {
+		t.Fatalf("Expected validation error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), `"severity"`) {
+		t.Errorf("Expected error to name the missing variable, got %v", err)
+	}
+	if cards, _ := cardStore.List("main", true); len(cards) != 0 {
+		t.Errorf("Expected no card to be created, got %d", len(cards))
+	}
+}
+
+func TestCardService_ApplyTemplate_NotFound(t *testing.T) {
+	service, _, boardStore := setupCardService()
+	boardStore.addBoard(templateBoardConfig())
+
+	_, err := service.ApplyTemplate("main", "feature-request", nil)
+	if !kanerr.IsCode(err, kanerr.CodeTemplateNotFound) {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+}
+
+func TestCardService_Edit_Labels(t *testing.T) {
+	service, _, boardStore := setupCardService()
+	boardStore.addBoard(labelsBoardConfig())
+
+	initial := []string{"ui", "urgent"}
+	card, _, _ := service.Add(AddCardInput{BoardName: "main", Title: "Labelled", Labels: &initial})
+
+	replacement := []string{"backend"}
+	updated, err := service.Edit(EditCardInput{BoardName: "main", CardIDOrAlias: card.ID, Labels: &replacement})
+	if err != nil {
+		t.Fatalf("Edit failed: %v", err)
+	}
+	if got := updated.GetLabels(); !reflect.DeepEqual(got, replacement) {
+		t.Errorf("Expected labels replaced with %v, got %v", replacement, got)
+	}
+
+	// Labels win over a labels value passed as a custom field.
+	updated, err = service.Edit(EditCardInput{
+		BoardName:     "main",
+		CardIDOrAlias: card.ID,
+		CustomFields:  map[string]string{model.LabelsField: "ignored"},
+		Labels:        &[]string{},
+	})
+	if err != nil {
+		t.Fatalf("Edit failed: %v", err)
+	}
+	if _, ok := updated.CustomFields[model.LabelsField]; ok {
+		t.Errorf("Expected labels cleared, got %v", updated.CustomFields)
+	}
+}
+
 func TestCardService_Edit_Title(t *testing.T) {
 	service, _, boardStore := setupCardService()
 	boardStore.addBoard(testBoardConfig("main"))
@@ -862,7 +1403,7 @@ func TestCardService_Edit_Title_EmptyError(t *testing.T) {
 	if err == nil {
 		t.Fatal("Expected error for empty title")
 	}
-	if !kanerr.IsValidationError(err) {
+	if !kanerr.IsCode(err, kanerr.CodeInvalidField) {
 		t.Errorf("Expected validation error, got %v", err)
 	}
 }
@@ -924,7 +1465,7 @@ func TestCardService_Edit_Column_Invalid(t *testing.T) {
 	if err == nil {
 		t.Fatal("Expected error for invalid column")
 	}
-	if !kanerr.IsNotFound(err) {
+	if !kanerr.IsCode(err, kanerr.CodeColumnNotFound) {
 		t.Errorf("Expected NotFound error, got %v", err)
 	}
 }
@@ -996,7 +1537,7 @@ func TestCardService_Edit_CustomFields_EnumSet_Invalid(t *testing.T) {
 	if err == nil {
 		t.Fatal("Expected error for invalid tag value")
 	}
-	if !kanerr.IsValidationError(err) {
+	if !kanerr.IsCode(err, kanerr.CodeInvalidField) {
 		t.Errorf("Expected ValidationError, got %v", err)
 	}
 }
@@ -1096,23 +1637,87 @@ func TestCardService_Edit_FreeSet_EnforcesMax(t *testing.T) {
 	if err == nil {
 		t.Fatal("Expected error for too many values")
 	}
-	if !kanerr.IsValidationError(err) {
+	if !kanerr.IsCode(err, kanerr.CodeInvalidField) {
 		t.Errorf("Expected ValidationError, got %v", err)
 	}
 }
 
-func TestCardService_Edit_EnumSet_Deduplicates(t *testing.T) {
+func TestCardService_Edit_FreeSet_EnforcesMaxLength(t *testing.T) {
 	service, _, boardStore := setupCardService()
-	boardStore.addBoard(testBoardConfig("main"))
+	cfg := testBoardConfigWithFreeSet("main")
+	// Written with the synonym, as configs keep it.
+	cfg.CustomFields["components"] = model.CustomFieldSchema{Type: model.FieldTypeTags, MaxLength: 5}
+	boardStore.addBoard(cfg)
 
 	card, _, _ := service.Add(AddCardInput{BoardName: "main", Title: "Test", Column: "backlog"})
 
-	updated, err := service.Edit(EditCardInput{
+	// Explicit max_length applies to each value, not the whole set.
+	if _, err := service.Edit(EditCardInput{
 		BoardName:     "main",
 		CardIDOrAlias: card.ID,
-		CustomFields:  map[string]string{"labels": "blocked,needs-review,blocked"},
-	})
-	if err != nil {
+		CustomFields:  map[string]string{"components": "api,store,cli"},
+	}); err != nil {
+		t.Fatalf("Edit with values within max_length failed: %v", err)
+	}
+	_, err := service.Edit(EditCardInput{
+		BoardName:     "main",
+		CardIDOrAlias: card.ID,
+		CustomFields:  map[string]string{"components": "api,service"},
+	})
+	if !kanerr.IsCode(err, kanerr.CodeInvalidField) {
+		t.Errorf("Expected ValidationError for a value over max_length, got %v", err)
+	}
+
+	// Without max_length the default applies.
+	_, err = service.Edit(EditCardInput{
+		BoardName:     "main",
+		CardIDOrAlias: card.ID,
+		CustomFields:  map[string]string{"topics": strings.Repeat("x", model.DefaultTagMaxLength+1)},
+	})
+	if !kanerr.IsCode(err, kanerr.CodeInvalidField) {
+		t.Errorf("Expected ValidationError for a value over the default max length, got %v", err)
+	}
+}
+
+func TestCardService_FreeSet_SerializedAsJSONArray(t *testing.T) {
+	service, _, boardStore := setupCardService()
+	boardStore.addBoard(testBoardConfigWithFreeSet("main"))
+
+	card, _, err := service.Add(AddCardInput{
+		BoardName:    "main",
+		Title:        "Test",
+		Column:       "backlog",
+		CustomFields: map[string]string{"topics": "backend,auth"},
+	})
+	if err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+
+	data, err := json.Marshal(card)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if got := string(raw["topics"]); got != `["backend","auth"]` {
+		t.Errorf("topics JSON = %s, want [\"backend\",\"auth\"]", got)
+	}
+}
+
+func TestCardService_Edit_EnumSet_Deduplicates(t *testing.T) {
+	service, _, boardStore := setupCardService()
+	boardStore.addBoard(testBoardConfig("main"))
+
+	card, _, _ := service.Add(AddCardInput{BoardName: "main", Title: "Test", Column: "backlog"})
+
+	updated, err := service.Edit(EditCardInput{
+		BoardName:     "main",
+		CardIDOrAlias: card.ID,
+		CustomFields:  map[string]string{"labels": "blocked,needs-review,blocked"},
+	})
+	if err != nil {
 		t.Fatalf("Edit failed: %v", err)
 	}
 
@@ -1280,7 +1885,7 @@ func TestCardService_Add_WithBooleanInvalidValue(t *testing.T) {
 	if err == nil {
 		t.Fatal("Expected error for invalid boolean value")
 	}
-	if !kanerr.IsValidationError(err) {
+	if !kanerr.IsCode(err, kanerr.CodeInvalidField) {
 		t.Errorf("Expected ValidationError, got %v", err)
 	}
 }
@@ -1386,6 +1991,347 @@ func TestCheckWantedFields_BooleanUnset_IsMissing(t *testing.T) {
 	}
 }
 
+// ============================================================================
+// Integer Tests
+// ============================================================================
+
+func testBoardConfigWithInteger(name string) *model.BoardConfig {
+	cfg := testBoardConfig(name)
+	cfg.CustomFields["story_points"] = model.CustomFieldSchema{Type: "integer", Min: intPtr(0), Max: intPtr(144)}
+	return cfg
+}
+
+func TestCardService_Add_WithInteger(t *testing.T) {
+	cases := []struct {
+		name    string
+		input   string
+		want    int
+		wantErr string
+	}{
+		{name: "valid", input: "8", want: 8},
+		{name: "zero at min boundary", input: "0", want: 0},
+		{name: "max boundary", input: "144", want: 144},
+		{name: "surrounding whitespace", input: " 13 ", want: 13},
+		{name: "non-numeric", input: "lots", wantErr: "must be an integer"},
+		{name: "decimal", input: "2.5", wantErr: "must be an integer"},
+		{name: "below min", input: "-1", wantErr: "must be at least 0"},
+		{name: "above max", input: "145", wantErr: "must be at most 144"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			service, _, boardStore := setupCardService()
+			boardStore.addBoard(testBoardConfigWithInteger("main"))
+
+			card, _, err := service.Add(AddCardInput{
+				BoardName:    "main",
+				Title:        "Test card",
+				CustomFields: map[string]string{"story_points": tc.input},
+			})
+			if tc.wantErr != "" {
+				if !kanerr.IsCode(err, kanerr.CodeInvalidField) {
+					t.Fatalf("Expected validation error, got %v", err)
+				}
+				if !strings.Contains(err.Error(), tc.wantErr) {
+					t.Errorf("Expected error containing %q, got %q", tc.wantErr, err.Error())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Add failed: %v", err)
+			}
+			if card.CustomFields["story_points"] != tc.want {
+				t.Errorf("Expected story_points %d (int), got %v (%T)", tc.want, card.CustomFields["story_points"], card.CustomFields["story_points"])
+			}
+		})
+	}
+}
+
+func TestCardService_Edit_Integer(t *testing.T) {
+	service, _, boardStore := setupCardService()
+	boardStore.addBoard(testBoardConfigWithInteger("main"))
+
+	card := mustAdd(t, service, AddCardInput{BoardName: "main", Title: "Test card", CustomFields: map[string]string{"story_points": "3"}})
+
+	updated, err := service.Edit(EditCardInput{BoardName: "main", CardIDOrAlias: card.ID, CustomFields: map[string]string{"story_points": "5"}})
+	if err != nil {
+		t.Fatalf("Edit failed: %v", err)
+	}
+	if updated.CustomFields["story_points"] != 5 {
+		t.Errorf("Expected story_points 5, got %v", updated.CustomFields["story_points"])
+	}
+
+	if _, err := service.Edit(EditCardInput{BoardName: "main", CardIDOrAlias: card.ID, CustomFields: map[string]string{"story_points": "200"}}); !kanerr.IsCode(err, kanerr.CodeInvalidField) {
+		t.Errorf("Expected validation error for out-of-range edit, got %v", err)
+	}
+
+	// Empty value unsets the field
+	cleared, err := service.Edit(EditCardInput{BoardName: "main", CardIDOrAlias: card.ID, CustomFields: map[string]string{"story_points": ""}})
+	if err != nil {
+		t.Fatalf("Edit failed: %v", err)
+	}
+	if _, ok := cleared.CustomFields["story_points"]; ok {
+		t.Error("Expected story_points to be unset")
+	}
+}
+
+func TestCardService_Edit_IntegerExpression(t *testing.T) {
+	cases := []struct {
+		name    string
+		current string // "" = unset
+		expr    string
+		want    int
+		wantErr string
+	}{
+		{name: "increment", current: "3", expr: "+2", want: 5},
+		{name: "decrement", current: "3", expr: "-1", want: 2},
+		{name: "clamped to min", current: "3", expr: "-5", want: 0},
+		{name: "clamped to max", current: "140", expr: "+10", want: 144},
+		{name: "plain value", current: "3", expr: "8", want: 8},
+		{name: "unset field", expr: "+1", wantErr: "field has no numeric value"},
+		{name: "negative value for unset field", expr: "-1", wantErr: "must be at least 0"},
+		{name: "not a number", current: "3", expr: "+two", wantErr: "must be an integer or a change"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			service, _, boardStore := setupCardService()
+			boardStore.addBoard(testBoardConfigWithInteger("main"))
+			fields := map[string]string{}
+			if tc.current != "" {
+				fields["story_points"] = tc.current
+			}
+			card := mustAdd(t, service, AddCardInput{BoardName: "main", Title: "Test card", CustomFields: fields})
+
+			updated, err := service.Edit(EditCardInput{BoardName: "main", CardIDOrAlias: card.ID, CustomFields: map[string]string{"story_points": tc.expr}})
+			if tc.wantErr != "" {
+				if !kanerr.IsCode(err, kanerr.CodeInvalidField) || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Errorf("Expected validation error containing %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Edit failed: %v", err)
+			}
+			if updated.CustomFields["story_points"] != tc.want {
+				t.Errorf("Expected story_points %d, got %v", tc.want, updated.CustomFields["story_points"])
+			}
+		})
+	}
+}
+
+func TestCardService_Edit_IntegerExpression_StoredValue(t *testing.T) {
+	// Values read back from card JSON are float64.
+	service, cardStore, boardStore := setupCardService()
+	boardStore.addBoard(testBoardConfigWithInteger("main"))
+	card := mustAdd(t, service, AddCardInput{BoardName: "main", Title: "Test card"})
+	card.CustomFields = map[string]any{"story_points": float64(3)}
+	if err := cardStore.Update("main", card); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	updated, err := service.Edit(EditCardInput{BoardName: "main", CardIDOrAlias: card.ID, CustomFields: map[string]string{"story_points": "+2"}})
+	if err != nil {
+		t.Fatalf("Edit failed: %v", err)
+	}
+	if updated.CustomFields["story_points"] != 5 {
+		t.Errorf("Expected story_points 5, got %v", updated.CustomFields["story_points"])
+	}
+}
+
+func TestCardService_Edit_ExpressionOnNonIntegerField(t *testing.T) {
+	service, _, boardStore := setupCardService()
+	boardStore.addBoard(testBoardConfig("main"))
+	card := mustAdd(t, service, AddCardInput{BoardName: "main", Title: "Test card", CustomFields: map[string]string{"type": "bug"}})
+
+	_, err := service.Edit(EditCardInput{BoardName: "main", CardIDOrAlias: card.ID, CustomFields: map[string]string{"type": "+1"}})
+	if !kanerr.IsCode(err, kanerr.CodeInvalidField) {
+		t.Errorf("Expected validation error for an expression on an enum field, got %v", err)
+	}
+}
+
+func TestCheckWantedFields_IntegerZero_IsNotEmpty(t *testing.T) {
+	cfg := testBoardConfigWithInteger("main")
+	schema := cfg.CustomFields["story_points"]
+	schema.Wanted = true
+	cfg.CustomFields["story_points"] = schema
+
+	// Values read back from card JSON are float64.
+	card := &model.Card{CustomFields: map[string]any{"story_points": float64(0)}}
+	for _, mf := range CheckWantedFields(card, cfg) {
+		if mf.FieldName == "story_points" {
+			t.Error("Integer 0 should satisfy a wanted field")
+		}
+	}
+}
+
+// ============================================================================
+// URL Tests
+// ============================================================================
+
+func testBoardConfigWithURL(name string) *model.BoardConfig {
+	cfg := testBoardConfig(name)
+	cfg.CustomFields["link"] = model.CustomFieldSchema{Type: "url"}
+	cfg.CustomFields["pr"] = model.CustomFieldSchema{Type: "url", Pattern: `^https://github\.com/`}
+	return cfg
+}
+
+func TestCardService_Add_WithURL(t *testing.T) {
+	cases := []struct {
+		name    string
+		field   string
+		input   string
+		want    string
+		wantErr string
+	}{
+		{name: "valid https", field: "link", input: "https://example.com/docs", want: "https://example.com/docs"},
+		{name: "valid http", field: "link", input: "http://localhost:8080", want: "http://localhost:8080"},
+		{name: "surrounding whitespace", field: "link", input: " https://example.com ", want: "https://example.com"},
+		{name: "bare domain", field: "link", input: "example.com", wantErr: "must be an http or https URL"},
+		{name: "other scheme", field: "link", input: "ftp://example.com", wantErr: "must be an http or https URL"},
+		{name: "pattern match", field: "pr", input: "https://github.com/amterp/kan/pull/1", want: "https://github.com/amterp/kan/pull/1"},
+		{name: "pattern mismatch", field: "pr", input: "https://gitlab.com/amterp/kan", wantErr: "does not match pattern"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			service, _, boardStore := setupCardService()
+			boardStore.addBoard(testBoardConfigWithURL("main"))
+
+			card, _, err := service.Add(AddCardInput{
+				BoardName:    "main",
+				Title:        "Test card",
+				CustomFields: map[string]string{tc.field: tc.input},
+			})
+			if tc.wantErr != "" {
+				if !kanerr.IsCode(err, kanerr.CodeInvalidField) {
+					t.Fatalf("Expected validation error, got %v", err)
+				}
+				if !strings.Contains(err.Error(), tc.wantErr) {
+					t.Errorf("Expected error containing %q, got %q", tc.wantErr, err.Error())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Add failed: %v", err)
+			}
+			if card.CustomFields[tc.field] != tc.want {
+				t.Errorf("Expected %s %q, got %v", tc.field, tc.want, card.CustomFields[tc.field])
+			}
+		})
+	}
+}
+
+func TestCardService_Edit_URLUnset(t *testing.T) {
+	service, _, boardStore := setupCardService()
+	boardStore.addBoard(testBoardConfigWithURL("main"))
+
+	card := mustAdd(t, service, AddCardInput{BoardName: "main", Title: "Test card", CustomFields: map[string]string{"link": "https://example.com"}})
+
+	cleared, err := service.Edit(EditCardInput{BoardName: "main", CardIDOrAlias: card.ID, CustomFields: map[string]string{"link": ""}})
+	if err != nil {
+		t.Fatalf("Edit failed: %v", err)
+	}
+	if _, ok := cleared.CustomFields["link"]; ok {
+		t.Error("Expected link to be unset")
+	}
+}
+
+func TestCardService_Add_WithUser(t *testing.T) {
+	cases := []struct {
+		name          string
+		collaborators []string
+		input         string
+		wantErr       bool
+	}{
+		{name: "collaborator", collaborators: []string{"alice", "bob"}, input: "alice"},
+		{name: "unknown user", collaborators: []string{"alice", "bob"}, input: "mallory", wantErr: true},
+		{name: "no collaborators", input: "anyone"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			service, _, boardStore := setupCardService()
+			cfg := testBoardConfig("main")
+			cfg.CustomFields["owner"] = model.CustomFieldSchema{Type: model.FieldTypeUser}
+			cfg.Collaborators = tc.collaborators
+			boardStore.addBoard(cfg)
+
+			card, _, err := service.Add(AddCardInput{
+				BoardName:    "main",
+				Title:        "Test card",
+				CustomFields: map[string]string{"owner": tc.input},
+			})
+			if tc.wantErr {
+				if !kanerr.IsCode(err, kanerr.CodeInvalidField) {
+					t.Fatalf("Expected validation error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Add failed: %v", err)
+			}
+			if card.CustomFields["owner"] != tc.input {
+				t.Errorf("Expected owner %q, got %v", tc.input, card.CustomFields["owner"])
+			}
+		})
+	}
+}
+
+func testBoardConfigWithDates(name string) *model.BoardConfig {
+	cfg := testBoardConfig(name)
+	cfg.CustomFields["due"] = model.CustomFieldSchema{Type: model.FieldTypeDate}
+	cfg.CustomFields["deadline"] = model.CustomFieldSchema{Type: model.FieldTypeDate, FutureOnly: true}
+	cfg.CustomFields["shipped"] = model.CustomFieldSchema{Type: model.FieldTypeDate, PastOnly: true}
+	cfg.CustomFields["reviewed_at"] = model.CustomFieldSchema{Type: model.FieldTypeDate, Format: model.DateFormatDateTime}
+	return cfg
+}
+
+func TestCardService_Add_WithDate(t *testing.T) {
+	cases := []struct {
+		name    string
+		field   string
+		input   string
+		want    string
+		wantErr string
+	}{
+		{name: "valid date", field: "due", input: "2024-03-15", want: "2024-03-15"},
+		{name: "surrounding whitespace", field: "due", input: " 2024-03-15 ", want: "2024-03-15"},
+		{name: "datetime normalized to date", field: "due", input: "2024-03-15T23:30:00-05:00", want: "2024-03-15"},
+		{name: "invalid format", field: "due", input: "15/03/2024", wantErr: "must be in YYYY-MM-DD format"},
+		{name: "impossible date", field: "due", input: "2024-02-30", wantErr: "must be in YYYY-MM-DD format"},
+		{name: "future only accepts future", field: "deadline", input: "2999-01-01", want: "2999-01-01"},
+		{name: "future only rejects past", field: "deadline", input: "2000-01-01", wantErr: "must not be in the past"},
+		{name: "past only accepts past", field: "shipped", input: "2000-01-01", want: "2000-01-01"},
+		{name: "past only rejects future", field: "shipped", input: "2999-01-01", wantErr: "must not be in the future"},
+		{name: "datetime format keeps time", field: "reviewed_at", input: "2024-03-15T09:00:00+02:00", want: "2024-03-15T09:00:00+02:00"},
+		{name: "datetime format from date", field: "reviewed_at", input: "2024-03-15", want: "2024-03-15T00:00:00Z"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			service, _, boardStore := setupCardService()
+			boardStore.addBoard(testBoardConfigWithDates("main"))
+
+			card, _, err := service.Add(AddCardInput{
+				BoardName:    "main",
+				Title:        "Test card",
+				CustomFields: map[string]string{tc.field: tc.input},
+			})
+			if tc.wantErr != "" {
+				if !kanerr.IsCode(err, kanerr.CodeInvalidField) {
+					t.Fatalf("Expected validation error, got %v", err)
+				}
+				if !strings.Contains(err.Error(), tc.wantErr) {
+					t.Errorf("Expected error containing %q, got %q", tc.wantErr, err.Error())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Add failed: %v", err)
+			}
+			if card.CustomFields[tc.field] != tc.want {
+				t.Errorf("Expected %s %q, got %v", tc.field, tc.want, card.CustomFields[tc.field])
+			}
+		})
+	}
+}
+
 func TestCardService_Edit_Parent(t *testing.T) {
 	service, _, boardStore := setupCardService()
 	boardStore.addBoard(testBoardConfig("main"))
@@ -1485,7 +2431,7 @@ func TestCardService_Edit_Alias_Empty(t *testing.T) {
 	if err == nil {
 		t.Fatal("Expected error for empty alias")
 	}
-	if !kanerr.IsValidationError(err) {
+	if !kanerr.IsCode(err, kanerr.CodeInvalidField) {
 		t.Errorf("Expected validation error, got %v", err)
 	}
 }
@@ -1507,7 +2453,7 @@ func TestCardService_Edit_Alias_Collision(t *testing.T) {
 	if err == nil {
 		t.Fatal("Expected error for alias collision")
 	}
-	if !kanerr.IsValidationError(err) {
+	if !kanerr.IsCode(err, kanerr.CodeInvalidField) {
 		t.Errorf("Expected validation error, got %v", err)
 	}
 }
@@ -1659,7 +2605,7 @@ func TestCardService_Edit_CardNotFound(t *testing.T) {
 	if err == nil {
 		t.Fatal("Expected error for nonexistent card")
 	}
-	if !kanerr.IsNotFound(err) {
+	if !kanerr.IsCode(err, kanerr.CodeCardNotFound) {
 		t.Errorf("Expected NotFound error, got %v", err)
 	}
 }
@@ -1713,7 +2659,7 @@ func TestCardService_Edit_CustomFields_InvalidEnum(t *testing.T) {
 	if err == nil {
 		t.Fatal("Expected error for invalid enum value")
 	}
-	if !kanerr.IsValidationError(err) {
+	if !kanerr.IsCode(err, kanerr.CodeInvalidField) {
 		t.Errorf("Expected validation error, got %v", err)
 	}
 }
@@ -1732,7 +2678,7 @@ func TestCardService_Edit_CustomFields_UndefinedField(t *testing.T) {
 	if err == nil {
 		t.Fatal("Expected error for undefined custom field")
 	}
-	if !kanerr.IsValidationError(err) {
+	if !kanerr.IsCode(err, kanerr.CodeInvalidField) {
 		t.Errorf("Expected validation error, got %v", err)
 	}
 }
@@ -1840,6 +2786,123 @@ func TestCardService_UpdateTitle_AvoidsSelfCollision(t *testing.T) {
 	}
 }
 
+// ============================================================================
+// Dependency (Blocks / BlockedBy) Tests
+// ============================================================================
+
+func TestCardService_Add_BlockedBy(t *testing.T) {
+	service, cardStore, boardStore := setupCardService()
+	boardStore.addBoard(testBoardConfig("main"))
+
+	blocker := mustAdd(t, service, AddCardInput{BoardName: "main", Title: "Design schema"})
+	card := mustAdd(t, service, AddCardInput{
+		BoardName: "main",
+		Title:     "Write migration",
+		BlockedBy: &[]string{"design-schema", blocker.ID}, // alias and ID dedupe
+	})
+
+	if !reflect.DeepEqual(card.BlockedBy, []string{blocker.ID}) {
+		t.Errorf("BlockedBy = %v, want [%s]", card.BlockedBy, blocker.ID)
+	}
+	stored, _ := cardStore.Get("main", blocker.ID)
+	if !reflect.DeepEqual(stored.Blocks, []string{card.ID}) {
+		t.Errorf("Blocker's Blocks = %v, want [%s]", stored.Blocks, card.ID)
+	}
+}
+
+func TestCardService_Edit_BlockedBy(t *testing.T) {
+	service, cardStore, boardStore := setupCardService()
+	boardStore.addBoard(testBoardConfig("main"))
+
+	a := mustAdd(t, service, AddCardInput{BoardName: "main", Title: "A"})
+	b := mustAdd(t, service, AddCardInput{BoardName: "main", Title: "B"})
+	card := mustAdd(t, service, AddCardInput{BoardName: "main", Title: "C", BlockedBy: &[]string{a.ID}})
+
+	// Swap A for B: A no longer blocks C, B now does.
+	if _, err := service.Edit(EditCardInput{BoardName: "main", CardIDOrAlias: card.ID, BlockedBy: &[]string{b.ID}}); err != nil {
+		t.Fatalf("Edit failed: %v", err)
+	}
+	storedA, _ := cardStore.Get("main", a.ID)
+	storedB, _ := cardStore.Get("main", b.ID)
+	if len(storedA.Blocks) != 0 {
+		t.Errorf("A.Blocks = %v, want empty", storedA.Blocks)
+	}
+	if !reflect.DeepEqual(storedB.Blocks, []string{card.ID}) {
+		t.Errorf("B.Blocks = %v, want [%s]", storedB.Blocks, card.ID)
+	}
+
+	// Clearing removes both sides.
+	updated, err := service.Edit(EditCardInput{BoardName: "main", CardIDOrAlias: card.ID, BlockedBy: &[]string{}})
+	if err != nil {
+		t.Fatalf("Edit failed: %v", err)
+	}
+	storedB, _ = cardStore.Get("main", b.ID)
+	if len(updated.BlockedBy) != 0 || len(storedB.Blocks) != 0 {
+		t.Errorf("Expected dependency cleared, got BlockedBy=%v B.Blocks=%v", updated.BlockedBy, storedB.Blocks)
+	}
+}
+
+func TestCardService_Edit_BlockedBy_Invalid(t *testing.T) {
+	service, _, boardStore := setupCardService()
+	boardStore.addBoard(testBoardConfig("main"))
+
+	card := mustAdd(t, service, AddCardInput{BoardName: "main", Title: "Card"})
+
+	cases := []struct {
+		name string
+		refs []string
+	}{
+		{name: "unknown card", refs: []string{"nonexistent"}},
+		{name: "self reference", refs: []string{card.ID}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := service.Edit(EditCardInput{BoardName: "main", CardIDOrAlias: card.ID, BlockedBy: &tc.refs})
+			if !kanerr.IsCode(err, kanerr.CodeInvalidField) {
+				t.Errorf("Expected validation error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestCardService_GetBlockingChain(t *testing.T) {
+	service, cardStore, boardStore := setupCardService()
+	boardStore.addBoard(testBoardConfig("main"))
+
+	// D is blocked by B and C; B and C are both blocked by A; A is blocked by D (cycle).
+	a := mustAdd(t, service, AddCardInput{BoardName: "main", Title: "A"})
+	b := mustAdd(t, service, AddCardInput{BoardName: "main", Title: "B", BlockedBy: &[]string{a.ID}})
+	c := mustAdd(t, service, AddCardInput{BoardName: "main", Title: "C", BlockedBy: &[]string{a.ID}})
+	d := mustAdd(t, service, AddCardInput{BoardName: "main", Title: "D", BlockedBy: &[]string{b.ID, c.ID}})
+	if _, err := service.Edit(EditCardInput{BoardName: "main", CardIDOrAlias: a.ID, BlockedBy: &[]string{d.ID}}); err != nil {
+		t.Fatalf("Edit failed: %v", err)
+	}
+
+	// A dangling reference is skipped rather than failing the walk.
+	stored, _ := cardStore.Get("main", c.ID)
+	stored.BlockedBy = append(stored.BlockedBy, "card-deleted")
+
+	chain, err := service.GetBlockingChain("main", d.ID)
+	if err != nil {
+		t.Fatalf("GetBlockingChain failed: %v", err)
+	}
+	titles := make([]string, len(chain))
+	for i, card := range chain {
+		titles[i] = card.Title
+	}
+	if !reflect.DeepEqual(titles, []string{"B", "C", "A"}) {
+		t.Errorf("Chain = %v, want [B C A]", titles)
+	}
+
+	blocked, err := service.GetBlockedCards("main", a.ID)
+	if err != nil {
+		t.Fatalf("GetBlockedCards failed: %v", err)
+	}
+	if len(blocked) != 2 || blocked[0].ID != b.ID || blocked[1].ID != c.ID {
+		t.Errorf("GetBlockedCards = %v, want [B C]", blocked)
+	}
+}
+
 // ============================================================================
 // Placement: computePosition / resolveInsertIndex / MoveCardWithPlacement
 // ============================================================================
@@ -1847,7 +2910,7 @@ func TestCardService_UpdateTitle_AvoidsSelfCollision(t *testing.T) {
 // orderedColumn returns the card titles in a column, in position order.
 func orderedColumn(t *testing.T, s *CardService, board, column string) []string {
 	t.Helper()
-	all, err := s.cardStore.List(board)
+	all, err := s.cardStore.List(board, false)
 	if err != nil {
 		t.Fatalf("List failed: %v", err)
 	}
@@ -1893,7 +2956,7 @@ func TestComputePosition_NegativeIndexing(t *testing.T) {
 			t.Fatalf("Add failed: %v", err)
 		}
 	}
-	all, _ := s.cardStore.List("main")
+	all, _ := s.cardStore.List("main", false)
 	col := cardsInColumn(all, "in-progress") // [A, B, C]
 
 	// -1 = end: sorts after C.
@@ -2056,3 +3119,1453 @@ func TestCardService_MoveCardWithPlacement_SelfAnchor(t *testing.T) {
 		t.Fatal("expected error when a card anchors to itself")
 	}
 }
+
+// ============================================================================
+// Clone() Tests
+// ============================================================================
+
+func TestCardService_Clone_CopiesContentWithFreshIdentity(t *testing.T) {
+	s, _, boardStore := setupCardService()
+	boardStore.addBoard(testBoardConfig("main"))
+
+	parent := mustAdd(t, s, AddCardInput{BoardName: "main", Title: "Epic"})
+	source := mustAdd(t, s, AddCardInput{
+		BoardName:    "main",
+		Title:        "Fix login",
+		Description:  "Steps to reproduce",
+		Column:       "in-progress",
+		Parent:       parent.ID,
+		CustomFields: map[string]string{"type": "bug", "labels": "blocked"},
+	})
+	if _, err := s.AddComment("main", source.ID, "note", "alice"); err != nil {
+		t.Fatalf("AddComment failed: %v", err)
+	}
+
+	// Same title as the source: the alias must still be unique.
+	clone, err := s.Clone("main", source.Alias, CloneOptions{})
+	if err != nil {
+		t.Fatalf("Clone failed: %v", err)
+	}
+
+	if clone.ID == source.ID {
+		t.Error("clone should have a new ID")
+	}
+	if clone.Alias == source.Alias {
+		t.Errorf("clone alias %q should differ from source alias", clone.Alias)
+	}
+	if clone.Title != "Fix login" || clone.Description != "Steps to reproduce" || clone.Parent != parent.ID {
+		t.Errorf("content not copied: %+v", clone)
+	}
+	if clone.CustomFields["type"] != "bug" {
+		t.Errorf("custom fields not copied: %v", clone.CustomFields)
+	}
+	if len(clone.Comments) != 0 {
+		t.Errorf("comments should not be copied, got %d", len(clone.Comments))
+	}
+	if clone.Column != "in-progress" {
+		t.Errorf("expected clone in source column, got %q", clone.Column)
+	}
+
+	// The source card is left untouched.
+	stored, _ := s.cardStore.Get("main", source.ID)
+	if stored.Alias != source.Alias || len(stored.Comments) != 1 {
+		t.Errorf("source card changed: %+v", stored)
+	}
+}
+
+func TestCardService_Clone_TargetColumnAndPrefix(t *testing.T) {
+	s, _, boardStore := setupCardService()
+	boardStore.addBoard(testBoardConfig("main"))
+
+	source := mustAdd(t, s, AddCardInput{BoardName: "main", Title: "Template", Column: "backlog"})
+
+	clone, err := s.Clone("main", source.ID, CloneOptions{TargetColumn: "done", TitlePrefix: "[COPY] "})
+	if err != nil {
+		t.Fatalf("Clone failed: %v", err)
+	}
+	if clone.Title != "[COPY] Template" {
+		t.Errorf("expected prefixed title, got %q", clone.Title)
+	}
+	if clone.Column != "done" {
+		t.Errorf("expected clone in done, got %q", clone.Column)
+	}
+	assertOrder(t, orderedColumn(t, s, "main", "backlog"), []string{"Template"})
+
+	if _, err := s.Clone("main", source.ID, CloneOptions{TargetColumn: "nope"}); err == nil {
+		t.Error("expected error for unknown target column")
+	}
+}
+
+func TestCardService_Clone_TitleAndLabels(t *testing.T) {
+	s, _, boardStore := setupCardService()
+	boardStore.addBoard(testBoardConfig("main"))
+
+	source := mustAdd(t, s, AddCardInput{BoardName: "main", Title: "Template", Labels: &[]string{"blocked"}})
+
+	clone, err := s.Clone("main", source.ID, CloneOptions{Title: "Fresh start", Labels: []string{"needs-review", "blocked"}})
+	if err != nil {
+		t.Fatalf("Clone failed: %v", err)
+	}
+	if clone.Title != "Fresh start" || clone.Alias != "fresh-start" {
+		t.Errorf("expected title override with matching alias, got %q (%s)", clone.Title, clone.Alias)
+	}
+	if got := clone.GetLabels(); !reflect.DeepEqual(got, []string{"blocked", "needs-review"}) {
+		t.Errorf("expected source labels plus the new one, got %v", got)
+	}
+	if got := source.GetLabels(); !reflect.DeepEqual(got, []string{"blocked"}) {
+		t.Errorf("source labels changed: %v", got)
+	}
+
+	if _, err := s.Clone("main", source.ID, CloneOptions{Labels: []string{"nope"}}); !kanerr.IsCode(err, kanerr.CodeInvalidField) {
+		t.Errorf("expected validation error for a label the board doesn't allow, got %v", err)
+	}
+}
+
+// ============================================================================
+// Due Date Tests
+// ============================================================================
+
+func TestCardService_ListSorted_DueDate(t *testing.T) {
+	s, _, boardStore := setupCardService()
+	boardStore.addBoard(testBoardConfig("main"))
+
+	mustAdd(t, s, AddCardInput{BoardName: "main", Title: "None", Column: "backlog"})
+	mustAdd(t, s, AddCardInput{BoardName: "main", Title: "Later", Column: "backlog", DueAtMillis: 3000})
+	mustAdd(t, s, AddCardInput{BoardName: "main", Title: "Soon", Column: "backlog", DueAtMillis: 1000})
+
+	cards, err := s.ListSorted("main", "backlog", model.SortByDueDate, false)
+	if err != nil {
+		t.Fatalf("ListSorted failed: %v", err)
+	}
+	got := make([]string, len(cards))
+	for i, c := range cards {
+		got[i] = c.Title
+	}
+	assertOrder(t, got, []string{"Soon", "Later", "None"})
+
+	// Unset due dates stay last when descending too.
+	cards, _ = s.ListSorted("main", "backlog", model.SortByDueDate, true)
+	got = got[:0]
+	for _, c := range cards {
+		got = append(got, c.Title)
+	}
+	assertOrder(t, got, []string{"Later", "Soon", "None"})
+}
+
+func TestCardService_ListWithOptions_SortBy(t *testing.T) {
+	s, _, boardStore := setupCardService()
+	boardStore.addBoard(testBoardConfigWithInteger("main"))
+
+	// Five backlog cards whose manual order (insertion) matches none of the
+	// sorts below. story_points deliberately sorts differently as a number
+	// than as a string ("13" < "5" < "8").
+	seed := []struct {
+		title   string
+		updated int64
+		points  string
+		due     int64
+		column  string
+	}{
+		{title: "Delta", updated: 3000, points: "8", due: 2000},
+		{title: "alpha", updated: 5000, points: "13"},
+		{title: "Echo", updated: 1000, points: "5", due: 1000},
+		{title: "Bravo", updated: 4000, points: "8", due: 3000},
+		{title: "Charlie", updated: 2000, due: 1000},
+	}
+	for _, c := range seed {
+		in := AddCardInput{BoardName: "main", Title: c.title, Column: "backlog", DueAtMillis: c.due}
+		if c.points != "" {
+			in.CustomFields = map[string]string{"story_points": c.points}
+		}
+		card := mustAdd(t, s, in)
+		card.UpdatedAtMillis = c.updated // test store holds the pointer
+	}
+	mustAdd(t, s, AddCardInput{BoardName: "main", Title: "Done", Column: "done"})
+
+	cases := []struct {
+		name   string
+		column string
+		sortBy []model.SortField
+		want   []string
+	}{
+		{
+			name: "no keys keeps manual order",
+			want: []string{"Delta", "alpha", "Echo", "Bravo", "Charlie", "Done"},
+		},
+		{
+			name:   "updated_at ascending",
+			column: "backlog",
+			sortBy: []model.SortField{{Field: model.SortByUpdatedAt}},
+			want:   []string{"Echo", "Charlie", "Delta", "Bravo", "alpha"},
+		},
+		{
+			name:   "updated_at descending",
+			column: "backlog",
+			sortBy: []model.SortField{{Field: model.SortByUpdatedAt, Desc: true}},
+			want:   []string{"alpha", "Bravo", "Delta", "Charlie", "Echo"},
+		},
+		{
+			name:   "title is case-insensitive",
+			column: "backlog",
+			sortBy: []model.SortField{{Field: model.SortByTitle}},
+			want:   []string{"alpha", "Bravo", "Charlie", "Delta", "Echo"},
+		},
+		{
+			name:   "due_at ties broken by title descending",
+			column: "backlog",
+			sortBy: []model.SortField{{Field: model.SortByDueAt}, {Field: model.SortByTitle, Desc: true}},
+			want:   []string{"Echo", "Charlie", "Delta", "Bravo", "alpha"},
+		},
+		{
+			name:   "integer field compares numerically, unset last",
+			column: "backlog",
+			sortBy: []model.SortField{{Field: "story_points"}, {Field: model.SortByUpdatedAt}},
+			want:   []string{"Echo", "Delta", "Bravo", "alpha", "Charlie"},
+		},
+		{
+			name:   "sorts within columns, keeps column order",
+			sortBy: []model.SortField{{Field: model.SortByUpdatedAt, Desc: true}},
+			want:   []string{"alpha", "Bravo", "Delta", "Charlie", "Echo", "Done"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cards, err := s.ListWithOptions("main", tc.column, ListOptions{SortBy: tc.sortBy})
+			if err != nil {
+				t.Fatalf("ListWithOptions failed: %v", err)
+			}
+			got := make([]string, len(cards))
+			for i, c := range cards {
+				got[i] = c.Title
+			}
+			assertOrder(t, got, tc.want)
+		})
+	}
+
+	t.Run("unknown field is rejected", func(t *testing.T) {
+		_, err := s.ListWithOptions("main", "", ListOptions{SortBy: []model.SortField{{Field: "nope"}}})
+		if !kanerr.IsCode(err, kanerr.CodeInvalidField) {
+			t.Errorf("Expected validation error, got %v", err)
+		}
+	})
+}
+
+func TestCheckOverdueCards(t *testing.T) {
+	now := util.NowMillis()
+	cards := []*model.Card{
+		{ID: "past", DueAtMillis: now - 60_000},
+		{ID: "future", DueAtMillis: now + 3_600_000},
+		{ID: "unset"},
+	}
+
+	overdue := CheckOverdueCards(cards)
+	if len(overdue) != 1 || overdue[0].ID != "past" {
+		t.Errorf("expected only 'past' to be overdue, got %v", overdue)
+	}
+}
+
+func TestCardService_GetStaleCards(t *testing.T) {
+	s, cardStore, boardStore := setupCardService()
+	cfg := testBoardConfig("main")
+	cfg.Stale = model.StaleConfig{StaleDays: 30, ExemptColumns: []string{"done"}}
+	boardStore.addBoard(cfg)
+
+	const day = int64(24 * 60 * 60 * 1000)
+	now := util.NowMillis()
+	for _, c := range []*model.Card{
+		{ID: "old", Column: "backlog", UpdatedAtMillis: now - 31*day},
+		{ID: "recent", Column: "backlog", UpdatedAtMillis: now - 29*day},
+		{ID: "finished", Column: "done", UpdatedAtMillis: now - 90*day},
+	} {
+		if err := cardStore.Create("main", c); err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+	}
+
+	stale, err := s.GetStaleCards("main")
+	if err != nil {
+		t.Fatalf("GetStaleCards failed: %v", err)
+	}
+	if len(stale) != 1 || stale[0].ID != "old" {
+		t.Errorf("Expected only 'old' to be stale, got %v", stale)
+	}
+
+	cfg.Stale.StaleDays = 0
+	stale, err = s.GetStaleCards("main")
+	if err != nil {
+		t.Fatalf("GetStaleCards failed: %v", err)
+	}
+	if len(stale) != 0 {
+		t.Errorf("Expected no stale cards with stale_days unset, got %v", stale)
+	}
+}
+
+func TestCardService_Checklist(t *testing.T) {
+	s, _, boardStore := setupCardService()
+	boardStore.addBoard(testBoardConfig("main"))
+	card := mustAdd(t, s, AddCardInput{BoardName: "main", Title: "Release"})
+
+	first, err := s.AddChecklistItem("main", card.Alias, "  Write changelog ")
+	if err != nil {
+		t.Fatalf("AddChecklistItem failed: %v", err)
+	}
+	if first.ID == "" || first.Text != "Write changelog" || first.Done || first.CreatedAtMillis == 0 {
+		t.Errorf("Unexpected item: %+v", first)
+	}
+	second, err := s.AddChecklistItem("main", card.ID, "Tag release")
+	if err != nil {
+		t.Fatalf("AddChecklistItem failed: %v", err)
+	}
+	if _, err := s.AddChecklistItem("main", card.ID, "   "); !kanerr.IsCode(err, kanerr.CodeInvalidField) {
+		t.Errorf("Expected validation error for empty text, got %v", err)
+	}
+
+	if err := s.ToggleChecklistItem("main", card.ID, first.ID, true); err != nil {
+		t.Fatalf("ToggleChecklistItem failed: %v", err)
+	}
+	got, _ := s.Get("main", card.ID)
+	if len(got.Checklist) != 2 || !got.Checklist[0].Done || got.Checklist[1].Done {
+		t.Errorf("Expected first item done and second not, got %+v", got.Checklist)
+	}
+	if !got.HasIncompleteChecklist() {
+		t.Error("Expected an incomplete checklist")
+	}
+
+	if err := s.DeleteChecklistItem("main", card.ID, second.ID); err != nil {
+		t.Fatalf("DeleteChecklistItem failed: %v", err)
+	}
+	got, _ = s.Get("main", card.ID)
+	if len(got.Checklist) != 1 || got.Checklist[0].ID != first.ID {
+		t.Errorf("Expected only the first item to remain, got %+v", got.Checklist)
+	}
+	if got.HasIncompleteChecklist() {
+		t.Error("Expected the remaining done item to complete the checklist")
+	}
+
+	if err := s.ToggleChecklistItem("main", card.ID, "missing", true); !kanerr.IsCode(err, kanerr.CodeChecklistItemNotFound) {
+		t.Errorf("Expected not-found toggling a missing item, got %v", err)
+	}
+	if err := s.DeleteChecklistItem("main", card.ID, second.ID); !kanerr.IsCode(err, kanerr.CodeChecklistItemNotFound) {
+		t.Errorf("Expected not-found deleting an item twice, got %v", err)
+	}
+}
+
+func TestFilterIncompleteChecklist(t *testing.T) {
+	cards := []*model.Card{
+		{ID: "open", Checklist: []model.ChecklistItem{{ID: "1", Done: true}, {ID: "2"}}},
+		{ID: "complete", Checklist: []model.ChecklistItem{{ID: "1", Done: true}}},
+		{ID: "none"},
+	}
+
+	got := FilterIncompleteChecklist(cards)
+	if len(got) != 1 || got[0].ID != "open" {
+		t.Errorf("Expected only 'open', got %v", got)
+	}
+}
+
+func TestCardService_Edit_DueDate(t *testing.T) {
+	s, _, boardStore := setupCardService()
+	boardStore.addBoard(testBoardConfig("main"))
+
+	card := mustAdd(t, s, AddCardInput{BoardName: "main", Title: "Task", DueAtMillis: 1000})
+
+	due := int64(5000)
+	updated, err := s.Edit(EditCardInput{BoardName: "main", CardIDOrAlias: card.ID, DueAtMillis: &due})
+	if err != nil {
+		t.Fatalf("Edit failed: %v", err)
+	}
+	if updated.DueAtMillis != 5000 {
+		t.Errorf("expected due date 5000, got %d", updated.DueAtMillis)
+	}
+
+	clear := int64(0)
+	updated, err = s.Edit(EditCardInput{BoardName: "main", CardIDOrAlias: card.ID, DueAtMillis: &clear})
+	if err != nil {
+		t.Fatalf("Edit failed: %v", err)
+	}
+	if updated.DueAtMillis != 0 {
+		t.Errorf("expected due date cleared, got %d", updated.DueAtMillis)
+	}
+}
+
+func TestCardService_Add_RejectsNegativeDueDate(t *testing.T) {
+	s, cardStore, boardStore := setupCardService()
+	boardStore.addBoard(testBoardConfig("main"))
+
+	_, _, err := s.Add(AddCardInput{BoardName: "main", Title: "Task", DueAtMillis: -1})
+	if !kanerr.IsCode(err, kanerr.CodeInvalidField) {
+		t.Fatalf("expected invalid field error, got %v", err)
+	}
+	if cards, _ := cardStore.List("main", true); len(cards) != 0 {
+		t.Errorf("expected no card created, got %d", len(cards))
+	}
+}
+
+// ============================================================================
+// Archive() / Unarchive() Tests
+// ============================================================================
+
+func TestCardService_Archive_HidesFromList(t *testing.T) {
+	s, _, boardStore := setupCardService()
+	boardStore.addBoard(testBoardConfig("main"))
+
+	card := mustAdd(t, s, AddCardInput{BoardName: "main", Title: "Old", Column: "in-progress"})
+	mustAdd(t, s, AddCardInput{BoardName: "main", Title: "Live", Column: "in-progress"})
+
+	if err := s.Archive("main", card.ID); err != nil {
+		t.Fatalf("Archive failed: %v", err)
+	}
+
+	stored, _ := s.cardStore.Get("main", card.ID)
+	if !stored.Archived || stored.ArchivedAtMillis == 0 {
+		t.Errorf("expected archived card with timestamp, got %+v", stored)
+	}
+	if stored.Column != "" || stored.LastColumn != "in-progress" {
+		t.Errorf("expected column cleared and LastColumn in-progress, got column %q last %q",
+			stored.Column, stored.LastColumn)
+	}
+
+	assertOrder(t, orderedColumn(t, s, "main", "in-progress"), []string{"Live"})
+
+	listed, _ := s.List("main", "")
+	if len(listed) != 1 {
+		t.Errorf("List should hide archived cards, got %d", len(listed))
+	}
+	all, _ := s.ListIncludingArchived("main", "")
+	if len(all) != 2 {
+		t.Errorf("ListIncludingArchived should return 2 cards, got %d", len(all))
+	}
+
+	if err := s.Archive("main", card.ID); err == nil {
+		t.Error("expected error archiving an already-archived card")
+	}
+}
+
+func TestCardService_Unarchive_RestoresToLastColumn(t *testing.T) {
+	s, _, boardStore := setupCardService()
+	boardStore.addBoard(testBoardConfig("main"))
+
+	card := mustAdd(t, s, AddCardInput{BoardName: "main", Title: "Back", Column: "in-progress"})
+	mustAdd(t, s, AddCardInput{BoardName: "main", Title: "Other", Column: "in-progress"})
+
+	if err := s.Archive("main", card.ID); err != nil {
+		t.Fatalf("Archive failed: %v", err)
+	}
+	restored, err := s.Unarchive("main", card.ID)
+	if err != nil {
+		t.Fatalf("Unarchive failed: %v", err)
+	}
+
+	if restored.Archived || restored.ArchivedAtMillis != 0 || restored.LastColumn != "" {
+		t.Errorf("expected archive fields cleared, got %+v", restored)
+	}
+	if restored.Column != "in-progress" {
+		t.Errorf("expected column in-progress, got %q", restored.Column)
+	}
+	assertOrder(t, orderedColumn(t, s, "main", "in-progress"), []string{"Other", "Back"})
+
+	if _, err := s.Unarchive("main", card.ID); err == nil {
+		t.Error("expected error unarchiving a live card")
+	}
+}
+
+func TestCardService_Unarchive_MissingColumnFallsBackToDefault(t *testing.T) {
+	s, _, boardStore := setupCardService()
+	cfg := testBoardConfig("main")
+	boardStore.addBoard(cfg)
+
+	card := mustAdd(t, s, AddCardInput{BoardName: "main", Title: "Orphan", Column: "done"})
+	if err := s.Archive("main", card.ID); err != nil {
+		t.Fatalf("Archive failed: %v", err)
+	}
+
+	cfg.Columns = cfg.Columns[:2] // drop "done"
+
+	restored, err := s.Unarchive("main", card.ID)
+	if err != nil {
+		t.Fatalf("Unarchive failed: %v", err)
+	}
+	if restored.Column != cfg.GetDefaultColumn() {
+		t.Errorf("expected default column %q, got %q", cfg.GetDefaultColumn(), restored.Column)
+	}
+}
+
+func TestCardService_MoveCard_ArchivedRejected(t *testing.T) {
+	s, _, boardStore := setupCardService()
+	boardStore.addBoard(testBoardConfig("main"))
+
+	card := mustAdd(t, s, AddCardInput{BoardName: "main", Title: "Gone", Column: "backlog"})
+	if err := s.Archive("main", card.ID); err != nil {
+		t.Fatalf("Archive failed: %v", err)
+	}
+	if err := s.MoveCard("main", card.ID, "done"); err == nil {
+		t.Error("expected error moving an archived card")
+	}
+}
+
+// ============================================================================
+// Column WIP Limit Tests
+// ============================================================================
+
+func TestCardService_ColumnLimit(t *testing.T) {
+	cases := []struct {
+		name    string
+		limit   int
+		op      string // "add", "move-in", or "move-within"
+		force   bool
+		wantErr bool
+	}{
+		{name: "no limit allows add", limit: 0, op: "add"},
+		{name: "add to full column fails", limit: 3, op: "add", wantErr: true},
+		{name: "add to full column with force", limit: 3, op: "add", force: true},
+		{name: "move into full column fails", limit: 3, op: "move-in", wantErr: true},
+		{name: "move into full column with force", limit: 3, op: "move-in", force: true},
+		{name: "reorder within full column allowed", limit: 3, op: "move-within"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, _, boardStore := setupCardService()
+			cfg := testBoardConfig("main")
+			cfg.SetColumnLimit("in-progress", tc.limit)
+			boardStore.addBoard(cfg)
+
+			// Fill in-progress with 3 cards (limits are only enforced on entry,
+			// so seed with the limit lifted).
+			var first *model.Card
+			for _, title := range []string{"A", "B", "C"} {
+				c := mustAdd(t, s, AddCardInput{BoardName: "main", Title: title, Column: "in-progress", Force: true})
+				if first == nil {
+					first = c
+				}
+			}
+			outside := mustAdd(t, s, AddCardInput{BoardName: "main", Title: "Out", Column: "backlog"})
+
+			var err error
+			switch tc.op {
+			case "add":
+				_, _, err = s.Add(AddCardInput{BoardName: "main", Title: "New", Column: "in-progress", Force: tc.force})
+			case "move-in":
+				col := "in-progress"
+				_, err = s.Edit(EditCardInput{BoardName: "main", CardIDOrAlias: outside.ID, Column: &col, Force: tc.force})
+			case "move-within":
+				err = s.MoveCardAt("main", first.ID, "in-progress", -1)
+			}
+
+			if tc.wantErr {
+				var atLimit *kanerr.KanError
+				if !errors.As(err, &atLimit) || atLimit.Code != kanerr.CodeColumnAtLimit {
+					t.Fatalf("expected column at limit error, got %v", err)
+				}
+				if atLimit.Details["column"] != "in-progress" || atLimit.Details["limit"] != tc.limit {
+					t.Errorf("unexpected error details: %+v", atLimit.Details)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+// ============================================================================
+// BulkMove() Tests
+// ============================================================================
+
+func TestCardService_BulkMove_InsertsContiguouslyInOrder(t *testing.T) {
+	s, _, boardStore := setupCardService()
+	boardStore.addBoard(testBoardConfig("main"))
+
+	mustAdd(t, s, AddCardInput{BoardName: "main", Title: "X", Column: "done"})
+	mustAdd(t, s, AddCardInput{BoardName: "main", Title: "Y", Column: "done"})
+	a := mustAdd(t, s, AddCardInput{BoardName: "main", Title: "A", Column: "backlog"})
+	b := mustAdd(t, s, AddCardInput{BoardName: "main", Title: "B", Column: "backlog"})
+
+	// B listed first (by alias), so it should land first.
+	moved, err := s.BulkMove(BulkMoveCardInput{
+		BoardName: "main",
+		CardIDs:   []string{b.Alias, a.ID},
+		Column:    "done",
+		Position:  intPtr(1),
+	})
+	if err != nil {
+		t.Fatalf("BulkMove failed: %v", err)
+	}
+	if len(moved) != 2 {
+		t.Fatalf("expected 2 moved cards, got %d", len(moved))
+	}
+	assertOrder(t, orderedColumn(t, s, "main", "done"), []string{"X", "B", "A", "Y"})
+	assertOrder(t, orderedColumn(t, s, "main", "backlog"), []string{})
+
+	stored, _ := s.cardStore.Get("main", a.ID)
+	last := stored.History[len(stored.History)-1]
+	if last.Field != "column" || last.Value != "done" {
+		t.Errorf("expected column history entry for done, got %+v", last)
+	}
+}
+
+func TestCardService_BulkMove_InvalidIDMovesNothing(t *testing.T) {
+	s, _, boardStore := setupCardService()
+	boardStore.addBoard(testBoardConfig("main"))
+
+	a := mustAdd(t, s, AddCardInput{BoardName: "main", Title: "A", Column: "backlog"})
+
+	_, err := s.BulkMove(BulkMoveCardInput{
+		BoardName: "main",
+		CardIDs:   []string{a.ID, "nope", "missing"},
+		Column:    "done",
+	})
+	var bulkErr *kanerr.BulkOperationError
+	if !errors.As(err, &bulkErr) {
+		t.Fatalf("expected BulkOperationError, got %v", err)
+	}
+	if len(bulkErr.FailedIDs) != 2 || bulkErr.FailedIDs[0] != "nope" || bulkErr.FailedIDs[1] != "missing" {
+		t.Errorf("expected failed IDs [nope missing], got %v", bulkErr.FailedIDs)
+	}
+
+	stored, _ := s.cardStore.Get("main", a.ID)
+	if stored.Column != "backlog" {
+		t.Errorf("card should not have moved, got column %q", stored.Column)
+	}
+}
+
+func TestCardService_BulkMove_ColumnLimit(t *testing.T) {
+	s, _, boardStore := setupCardService()
+	cfg := testBoardConfig("main")
+	cfg.SetColumnLimit("done", 2)
+	boardStore.addBoard(cfg)
+
+	mustAdd(t, s, AddCardInput{BoardName: "main", Title: "X", Column: "done"})
+	a := mustAdd(t, s, AddCardInput{BoardName: "main", Title: "A", Column: "backlog"})
+	b := mustAdd(t, s, AddCardInput{BoardName: "main", Title: "B", Column: "backlog"})
+
+	_, err := s.BulkMove(BulkMoveCardInput{
+		BoardName: "main",
+		CardIDs:   []string{a.ID, b.ID},
+		Column:    "done",
+	})
+	if err == nil {
+		t.Fatal("expected column limit error")
+	}
+	assertOrder(t, orderedColumn(t, s, "main", "done"), []string{"X"})
+}
+
+// ============================================================================
+// CopyCustomFieldsFrom() Tests
+// ============================================================================
+
+func TestCardService_CopyCustomFieldsFrom_EmptyFieldsCopiesAll(t *testing.T) {
+	service, _, boardStore := setupCardService()
+	boardStore.addBoard(testBoardConfig("main"))
+
+	source := mustAdd(t, service, AddCardInput{
+		BoardName:    "main",
+		Title:        "Source",
+		CustomFields: map[string]string{"type": "bug", "labels": "blocked,needs-review"},
+	})
+	target := mustAdd(t, service, AddCardInput{BoardName: "main", Title: "Target"})
+
+	updated, err := service.CopyCustomFieldsFrom("main", target.ID, source.ID, nil)
+	if err != nil {
+		t.Fatalf("CopyCustomFieldsFrom failed: %v", err)
+	}
+
+	if updated.CustomFields["type"] != "bug" {
+		t.Errorf("Expected type 'bug', got %v", updated.CustomFields["type"])
+	}
+	if !reflect.DeepEqual(updated.CustomFields["labels"], []string{"blocked", "needs-review"}) {
+		t.Errorf("Expected labels [blocked needs-review], got %v", updated.CustomFields["labels"])
+	}
+}
+
+func TestCardService_CopyCustomFieldsFrom_SelectedFields(t *testing.T) {
+	service, _, boardStore := setupCardService()
+	boardStore.addBoard(testBoardConfig("main"))
+
+	source := mustAdd(t, service, AddCardInput{
+		BoardName:    "main",
+		Title:        "Source",
+		CustomFields: map[string]string{"type": "bug", "labels": "blocked"},
+	})
+	target := mustAdd(t, service, AddCardInput{BoardName: "main", Title: "Target"})
+
+	updated, err := service.CopyCustomFieldsFrom("main", target.ID, source.ID, []string{"type"})
+	if err != nil {
+		t.Fatalf("CopyCustomFieldsFrom failed: %v", err)
+	}
+
+	if updated.CustomFields["type"] != "bug" {
+		t.Errorf("Expected type 'bug', got %v", updated.CustomFields["type"])
+	}
+	if _, ok := updated.CustomFields["labels"]; ok {
+		t.Error("labels should not be copied when not requested")
+	}
+}
+
+func TestCardService_CopyCustomFieldsAcrossBoards_DifferentSchemas(t *testing.T) {
+	service, _, boardStore := setupCardService()
+	boardStore.addBoard(testBoardConfig("main"))
+
+	// The other board knows "type" but not "labels", and adds "priority".
+	other := testBoardConfig("other")
+	delete(other.CustomFields, "labels")
+	other.CustomFields["priority"] = model.CustomFieldSchema{Type: model.FieldTypeString}
+	boardStore.addBoard(other)
+
+	source := mustAdd(t, service, AddCardInput{
+		BoardName:    "main",
+		Title:        "Source",
+		CustomFields: map[string]string{"type": "feature", "labels": "blocked"},
+	})
+	target := mustAdd(t, service, AddCardInput{BoardName: "other", Title: "Target"})
+
+	updated, err := service.CopyCustomFieldsAcrossBoards("other", target.ID, "main", source.ID, []string{"type", "labels"})
+	if err != nil {
+		t.Fatalf("CopyCustomFieldsAcrossBoards failed: %v", err)
+	}
+
+	if updated.CustomFields["type"] != "feature" {
+		t.Errorf("Expected type 'feature', got %v", updated.CustomFields["type"])
+	}
+	if _, ok := updated.CustomFields["labels"]; ok {
+		t.Error("labels is not in the target schema and should be skipped")
+	}
+}
+
+func TestCardService_CopyCustomFieldsAcrossBoards_NegativeInteger(t *testing.T) {
+	service, _, boardStore := setupCardService()
+	for _, name := range []string{"main", "other"} {
+		cfg := testBoardConfig(name)
+		cfg.CustomFields["balance"] = model.CustomFieldSchema{Type: model.FieldTypeInteger, Min: intPtr(-10)}
+		boardStore.addBoard(cfg)
+	}
+
+	// A negative value is a value, not a change to the target's value.
+	source := mustAdd(t, service, AddCardInput{BoardName: "main", Title: "Source", CustomFields: map[string]string{"balance": "-3"}})
+	target := mustAdd(t, service, AddCardInput{BoardName: "other", Title: "Target", CustomFields: map[string]string{"balance": "5"}})
+
+	updated, err := service.CopyCustomFieldsAcrossBoards("other", target.ID, "main", source.ID, []string{"balance"})
+	if err != nil {
+		t.Fatalf("CopyCustomFieldsAcrossBoards failed: %v", err)
+	}
+	if updated.CustomFields["balance"] != -3 {
+		t.Errorf("Expected balance -3, got %v", updated.CustomFields["balance"])
+	}
+}
+
+func TestCardService_CopyCustomFieldsAcrossBoards_LargeInteger(t *testing.T) {
+	service, cardStore, boardStore := setupCardService()
+	for _, name := range []string{"main", "other"} {
+		cfg := testBoardConfig(name)
+		cfg.CustomFields["points"] = model.CustomFieldSchema{Type: model.FieldTypeInteger}
+		boardStore.addBoard(cfg)
+	}
+
+	source := mustAdd(t, service, AddCardInput{BoardName: "main", Title: "Source"})
+	target := mustAdd(t, service, AddCardInput{BoardName: "other", Title: "Target"})
+	// Cards read back from disk hold JSON numbers as float64.
+	cardStore.cards["main"][source.ID].CustomFields = map[string]any{"points": float64(2500000)}
+
+	updated, err := service.CopyCustomFieldsAcrossBoards("other", target.ID, "main", source.ID, []string{"points"})
+	if err != nil {
+		t.Fatalf("CopyCustomFieldsAcrossBoards failed: %v", err)
+	}
+	if updated.CustomFields["points"] != 2500000 {
+		t.Errorf("Expected points 2500000, got %v", updated.CustomFields["points"])
+	}
+}
+
+func TestFormatCustomFieldValue(t *testing.T) {
+	cases := []struct {
+		value any
+		want  string
+	}{
+		{"text", "text"},
+		{[]string{"a", "b"}, "a,b"},
+		{[]any{"a", float64(1000000)}, "a,1000000"},
+		{float64(1000000), "1000000"},
+		{float64(-2.5), "-2.5"},
+		{7, "7"},
+		{true, "true"},
+	}
+	for _, tc := range cases {
+		if got := formatCustomFieldValue(tc.value); got != tc.want {
+			t.Errorf("formatCustomFieldValue(%#v) = %q, want %q", tc.value, got, tc.want)
+		}
+	}
+}
+
+func TestCardService_CopyCustomFieldsFrom_InvalidForTargetSchema(t *testing.T) {
+	service, _, boardStore := setupCardService()
+	boardStore.addBoard(testBoardConfig("main"))
+
+	// Same field name, but "task" isn't a valid option on the other board.
+	other := testBoardConfig("other")
+	other.CustomFields["type"] = model.CustomFieldSchema{
+		Type:    model.FieldTypeEnum,
+		Options: []model.CustomFieldOption{{Value: "bug"}},
+	}
+	boardStore.addBoard(other)
+
+	source := mustAdd(t, service, AddCardInput{
+		BoardName:    "main",
+		Title:        "Source",
+		CustomFields: map[string]string{"type": "task"},
+	})
+	target := mustAdd(t, service, AddCardInput{BoardName: "other", Title: "Target"})
+
+	_, err := service.CopyCustomFieldsAcrossBoards("other", target.ID, "main", source.ID, nil)
+	if !kanerr.IsCode(err, kanerr.CodeInvalidField) {
+		t.Errorf("Expected ValidationError, got %v", err)
+	}
+}
+
+// testBoardConfigWithTransitions gates moves into "done": any card needs a
+// type and no labels, and cards coming from in-progress also need a reviewer.
+func testBoardConfigWithTransitions(name string) *model.BoardConfig {
+	cfg := testBoardConfig(name)
+	cfg.CustomFields["reviewer"] = model.CustomFieldSchema{Type: model.FieldTypeString}
+	cfg.Columns[2].TransitionRules = []model.TransitionRule{
+		{RequiredFields: []string{"type"}, ForbidIfFields: []string{"labels"}},
+		{FromColumn: "in-progress", RequiredFields: []string{"reviewer"}},
+	}
+	return cfg
+}
+
+func TestCardService_MoveCard_StampsDoneAt(t *testing.T) {
+	service, cardStore, boardStore := setupCardService()
+	cfg := testBoardConfig("main")
+	cfg.Columns[2].Done = true
+	boardStore.addBoard(cfg)
+
+	card, _, _ := service.Add(AddCardInput{BoardName: "main", Title: "Ship it", Column: "backlog"})
+	if card.DoneAtMillis != 0 {
+		t.Errorf("Expected no done time in backlog, got %d", card.DoneAtMillis)
+	}
+
+	if err := service.MoveCard("main", card.ID, "done"); err != nil {
+		t.Fatalf("MoveCard failed: %v", err)
+	}
+	moved, _ := cardStore.Get("main", card.ID)
+	if moved.DoneAtMillis == 0 {
+		t.Error("Expected done time to be stamped on entering a done column")
+	}
+
+	if err := service.MoveCard("main", card.ID, "in-progress"); err != nil {
+		t.Fatalf("MoveCard failed: %v", err)
+	}
+	reopened, _ := cardStore.Get("main", card.ID)
+	if reopened.DoneAtMillis != 0 {
+		t.Errorf("Expected done time cleared on leaving the done column, got %d", reopened.DoneAtMillis)
+	}
+}
+
+func TestCardService_Move_TransitionRules(t *testing.T) {
+	cases := []struct {
+		name    string
+		from    string
+		fields  map[string]string
+		wantErr string // "" = move allowed
+	}{
+		{name: "satisfies rules", from: "backlog", fields: map[string]string{"type": "bug"}},
+		{name: "missing required field", from: "backlog", wantErr: "missing required field(s): type"},
+		{name: "forbidden field set", from: "backlog", fields: map[string]string{"type": "bug", "labels": "blocked"}, wantErr: "must be unset: labels"},
+		{name: "from_column rule applies", from: "in-progress", fields: map[string]string{"type": "bug"}, wantErr: "missing required field(s): reviewer"},
+		{name: "from_column rule satisfied", from: "in-progress", fields: map[string]string{"type": "bug", "reviewer": "sam"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, _, boardStore := setupCardService()
+			boardStore.addBoard(testBoardConfigWithTransitions("main"))
+			card := mustAdd(t, s, AddCardInput{BoardName: "main", Title: "Card", Column: tc.from, CustomFields: tc.fields})
+
+			err := s.MoveCard("main", card.ID, "done")
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("MoveCard failed: %v", err)
+				}
+				return
+			}
+			var violation *kanerr.KanError
+			if !errors.As(err, &violation) || violation.Code != kanerr.CodeTransitionViolation {
+				t.Fatalf("Expected transition violation, got %v", err)
+			}
+			if violation.Details["column"] != "done" || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("Expected violation on done containing %q, got %q", tc.wantErr, err.Error())
+			}
+			if got, _ := s.Get("main", card.ID); got.Column != tc.from {
+				t.Errorf("Card should stay in %s, got %s", tc.from, got.Column)
+			}
+		})
+	}
+}
+
+func TestCardService_Edit_TransitionRules(t *testing.T) {
+	s, _, boardStore := setupCardService()
+	boardStore.addBoard(testBoardConfigWithTransitions("main"))
+	done := "done"
+
+	// Fields set in the same edit count toward the rules.
+	card := mustAdd(t, s, AddCardInput{BoardName: "main", Title: "Same edit", Column: "backlog"})
+	updated, err := s.Edit(EditCardInput{BoardName: "main", CardIDOrAlias: card.ID, Column: &done, CustomFields: map[string]string{"type": "bug"}})
+	if err != nil {
+		t.Fatalf("Edit failed: %v", err)
+	}
+	if updated.Column != "done" {
+		t.Errorf("Expected card in done, got %s", updated.Column)
+	}
+
+	// --force bypasses the rules.
+	card = mustAdd(t, s, AddCardInput{BoardName: "main", Title: "Forced", Column: "backlog"})
+	if _, err := s.Edit(EditCardInput{BoardName: "main", CardIDOrAlias: card.ID, Column: &done}); !kanerr.IsCode(err, kanerr.CodeTransitionViolation) {
+		t.Fatalf("Expected transition violation without force, got %v", err)
+	}
+	if _, err := s.Edit(EditCardInput{BoardName: "main", CardIDOrAlias: card.ID, Column: &done, Force: true}); err != nil {
+		t.Fatalf("forced Edit failed: %v", err)
+	}
+
+	// Reordering within the gated column is never checked.
+	pos := 0
+	if _, err := s.Edit(EditCardInput{BoardName: "main", CardIDOrAlias: card.ID, Position: &pos}); err != nil {
+		t.Errorf("reorder within done failed: %v", err)
+	}
+}
+
+func TestCardService_BulkMove_TransitionRules(t *testing.T) {
+	s, _, boardStore := setupCardService()
+	boardStore.addBoard(testBoardConfigWithTransitions("main"))
+	ok := mustAdd(t, s, AddCardInput{BoardName: "main", Title: "Ok", CustomFields: map[string]string{"type": "bug"}})
+	bad := mustAdd(t, s, AddCardInput{BoardName: "main", Title: "Bad"})
+
+	_, err := s.BulkMove(BulkMoveCardInput{BoardName: "main", CardIDs: []string{ok.ID, bad.ID}, Column: "done"})
+	var bulk *kanerr.BulkOperationError
+	if !errors.As(err, &bulk) || len(bulk.FailedIDs) != 1 || bulk.FailedIDs[0] != bad.ID {
+		t.Fatalf("Expected bulk failure naming %s, got %v", bad.ID, err)
+	}
+	if got, _ := s.Get("main", ok.ID); got.Column != "backlog" {
+		t.Errorf("Bulk move is all-or-nothing; expected %s to stay in backlog, got %s", ok.ID, got.Column)
+	}
+}
+
+func TestCardService_AddComment_Limits(t *testing.T) {
+	s, _, boardStore := setupCardService()
+	boardStore.addBoard(testBoardConfig("main"))
+	card := mustAdd(t, s, AddCardInput{BoardName: "main", Title: "Chatty"})
+
+	if _, err := s.AddComment("main", card.ID, strings.Repeat("x", MaxCommentBodyBytes), "alice"); err != nil {
+		t.Fatalf("AddComment at the body limit failed: %v", err)
+	}
+	_, err := s.AddComment("main", card.ID, strings.Repeat("x", MaxCommentBodyBytes+1), "alice")
+	if !kanerr.IsCode(err, kanerr.CodeInvalidField) {
+		t.Errorf("Expected validation error for oversized body, got %v", err)
+	}
+
+	stored, _ := s.Get("main", card.ID)
+	for len(stored.Comments) < MaxCommentsPerCard {
+		stored.Comments = append(stored.Comments, model.Comment{ID: fmt.Sprintf("c_%d", len(stored.Comments)), Body: "filler"})
+	}
+	_, err = s.AddComment("main", card.ID, "one too many", "alice")
+	if !kanerr.IsCode(err, kanerr.CodeInvalidField) {
+		t.Errorf("Expected validation error at %d comments, got %v", MaxCommentsPerCard, err)
+	}
+}
+
+func TestCardService_AddComment_Mentions(t *testing.T) {
+	s, _, boardStore := setupCardService()
+	cfg := testBoardConfig("main")
+	cfg.Collaborators = []string{"alice", "bob"}
+	boardStore.addBoard(cfg)
+	card := mustAdd(t, s, AddCardInput{BoardName: "main", Title: "Chatty"})
+
+	comment, err := s.AddComment("main", card.ID, "@alice can you and @carol look? cc @alice", "bob")
+	if err != nil {
+		t.Fatalf("AddComment failed: %v", err)
+	}
+	if !slices.Equal(comment.Mentions, []string{"alice", "carol"}) {
+		t.Errorf("Mentions = %v, want [alice carol]", comment.Mentions)
+	}
+
+	// Unknown mentions are stored but reported.
+	unknown, err := s.UnknownMentions("main", comment.Mentions)
+	if err != nil {
+		t.Fatalf("UnknownMentions failed: %v", err)
+	}
+	if !slices.Equal(unknown, []string{"carol"}) {
+		t.Errorf("UnknownMentions = %v, want [carol]", unknown)
+	}
+
+	edited, err := s.EditComment("main", comment.ID, "never mind, @bob has it")
+	if err != nil {
+		t.Fatalf("EditComment failed: %v", err)
+	}
+	if !slices.Equal(edited.Mentions, []string{"bob"}) {
+		t.Errorf("Mentions after edit = %v, want [bob]", edited.Mentions)
+	}
+
+	other := mustAdd(t, s, AddCardInput{BoardName: "main", Title: "Quiet"})
+	cards := []*model.Card{other}
+	if stored, err := s.Get("main", card.ID); err == nil {
+		cards = append(cards, stored)
+	}
+	if got := FilterByMention(cards, "bob"); len(got) != 1 || got[0].ID != card.ID {
+		t.Errorf("FilterByMention(bob) = %v, want only %s", got, card.ID)
+	}
+	if got := FilterByMention(cards, "alice"); len(got) != 0 {
+		t.Errorf("FilterByMention(alice) should be empty after the edit, got %v", got)
+	}
+}
+
+func TestCardService_ListComments(t *testing.T) {
+	s, _, boardStore := setupCardService()
+	boardStore.addBoard(testBoardConfig("main"))
+	card := mustAdd(t, s, AddCardInput{BoardName: "main", Title: "Thread"})
+
+	stored, _ := s.Get("main", card.ID)
+	stored.Comments = []model.Comment{
+		{ID: "c_late", Body: "late", CreatedAtMillis: 3000},
+		{ID: "c_early", Body: "early", CreatedAtMillis: 1000},
+		{ID: "c_mid", Body: "mid", CreatedAtMillis: 2000},
+	}
+
+	comments, err := s.ListComments("main", card.ID)
+	if err != nil {
+		t.Fatalf("ListComments failed: %v", err)
+	}
+	var got []string
+	for _, c := range comments {
+		got = append(got, c.ID)
+	}
+	if want := []string{"c_early", "c_mid", "c_late"}; !slices.Equal(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+	if stored.Comments[0].ID != "c_late" {
+		t.Errorf("ListComments should not reorder the stored comments")
+	}
+
+	if _, err := s.ListComments("main", "missing"); !kanerr.IsCode(err, kanerr.CodeCardNotFound) {
+		t.Errorf("Expected not-found for unknown card, got %v", err)
+	}
+}
+
+func TestCardService_ImportCSV(t *testing.T) {
+	s, _, boardStore := setupCardService()
+	boardStore.addBoard(testBoardConfig("main"))
+
+	csvData := `title,description,column,type,labels
+Fix login,Session expires early,in-progress,bug,"blocked,needs-review"
+Write docs,,,,
+,orphan description,done,,
+Ship it,,nowhere,,
+Plan sprint,,done,task,
+`
+	imported, skipped, err := s.ImportCSV("main", strings.NewReader(csvData), nil, "alice")
+	var rowErrs *CSVImportError
+	if !errors.As(err, &rowErrs) {
+		t.Fatalf("Expected a CSVImportError for the invalid column, got %v", err)
+	}
+	if len(imported) != 3 || skipped != 2 {
+		t.Errorf("Expected 3 imported and 2 skipped, got %d and %d", len(imported), skipped)
+	}
+	if len(rowErrs.Rows) != 1 || rowErrs.Rows[0].Row != 5 || !kanerr.IsCode(rowErrs.Rows[0].Err, kanerr.CodeColumnNotFound) {
+		t.Errorf("Expected a column-not-found error on row 5, got %+v", rowErrs.Rows)
+	}
+
+	cards, _ := s.List("main", "")
+	byTitle := make(map[string]*model.Card)
+	for _, c := range cards {
+		byTitle[c.Title] = c
+	}
+	if len(byTitle) != 3 {
+		t.Fatalf("Expected 3 cards, got %v", byTitle)
+	}
+	fix := byTitle["Fix login"]
+	if fix == nil || fix.Column != "in-progress" || fix.Description != "Session expires early" || fix.Creator != "alice" {
+		t.Errorf("Unexpected imported card: %+v", fix)
+	}
+	if fix != nil && (fix.CustomFields["type"] != "bug" || !reflect.DeepEqual(fix.CustomFields["labels"], []string{"blocked", "needs-review"})) {
+		t.Errorf("Expected custom fields type=bug labels=[blocked needs-review], got %v", fix.CustomFields)
+	}
+	if docs := byTitle["Write docs"]; docs == nil || docs.Column != "backlog" || len(docs.CustomFields) != 0 {
+		t.Errorf("Expected 'Write docs' in the default column with no fields, got %+v", docs)
+	}
+}
+
+func TestCardService_ImportJSONL(t *testing.T) {
+	s, _, boardStore := setupCardService()
+	boardStore.addBoard(testBoardConfig("main"))
+	existing := mustAdd(t, s, AddCardInput{BoardName: "main", Title: "Existing"})
+
+	line := func(id, alias, fields string) string {
+		return fmt.Sprintf(`{"_v":%d,"id":%q,"alias":%q,"title":"Imported","column":"backlog","position":"a"%s}`+"\n",
+			version.CurrentCardVersion, id, alias, fields)
+	}
+	input := line("new1", "new-one", `,"type":"bug","labels":["blocked"]`) + line(existing.ID, existing.Alias, "")
+	created, updated, err := s.ImportJSONL("main", strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ImportJSONL failed: %v", err)
+	}
+	if len(created) != 1 || created[0].ID != "new1" || len(updated) != 1 || updated[0].ID != existing.ID {
+		t.Errorf("Expected new1 created and %s updated, got %v and %v", existing.ID, created, updated)
+	}
+	if got, _ := s.Get("main", existing.ID); got.Title != "Imported" {
+		t.Errorf("Expected the existing card updated, got %+v", got)
+	}
+
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"undefined field", line("new2", "", `,"size":"xl"`)},
+		{"invalid option", line("new2", "", `,"type":"epic"`)},
+		{"alias taken", line("new2", existing.Alias, "")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			created, updated, err := s.ImportJSONL("main", strings.NewReader(tt.input))
+			if !kanerr.IsCode(err, kanerr.CodeInvalidField) {
+				t.Errorf("Expected invalid input error, got %v", err)
+			}
+			if len(created)+len(updated) != 0 {
+				t.Errorf("Expected nothing imported, got %v and %v", created, updated)
+			}
+			if _, err := s.Get("main", "new2"); err == nil {
+				t.Error("Rejected card should not be written")
+			}
+		})
+	}
+}
+
+func TestCardService_ImportCSV_Mapping(t *testing.T) {
+	s, _, boardStore := setupCardService()
+	boardStore.addBoard(testBoardConfig("main"))
+
+	csvData := "Summary,Kind,Notes\nCrash on start,bug,ignored\n"
+	mapping := CSVMapping{"Summary": "title", "Kind": "type"}
+	imported, skipped, err := s.ImportCSV("main", strings.NewReader(csvData), mapping, "alice")
+	if err != nil || len(imported) != 1 || skipped != 0 {
+		t.Fatalf("Expected 1 imported, got %d imported, %d skipped, err=%v", len(imported), skipped, err)
+	}
+	cards, _ := s.List("main", "")
+	if len(cards) != 1 || cards[0].Title != "Crash on start" || cards[0].CustomFields["type"] != "bug" || cards[0].Description != "" {
+		t.Errorf("Unexpected card: %+v", cards[0])
+	}
+
+	for name, tc := range map[string]struct {
+		csv     string
+		mapping CSVMapping
+	}{
+		"unknown target field": {"Summary\nx\n", CSVMapping{"Summary": "nope"}},
+		"missing header":       {"Summary\nx\n", CSVMapping{"Missing": "title"}},
+		"no title column":      {"Kind\nbug\n", nil},
+		"empty file":           {"", nil},
+	} {
+		if _, _, err := s.ImportCSV("main", strings.NewReader(tc.csv), tc.mapping, "alice"); !kanerr.IsCode(err, kanerr.CodeInvalidField) {
+			t.Errorf("%s: expected validation error, got %v", name, err)
+		}
+	}
+}
+
+func TestCardService_ImportCSV_ReadError(t *testing.T) {
+	s, _, boardStore := setupCardService()
+	boardStore.addBoard(testBoardConfig("main"))
+
+	// A reader that fails for good would fail every row; the import must stop.
+	readErr := errors.New("connection reset")
+	r := io.MultiReader(strings.NewReader("title\nFirst\n"), iotest.ErrReader(readErr))
+	imported, _, err := s.ImportCSV("main", r, nil, "alice")
+	if !errors.Is(err, readErr) {
+		t.Fatalf("Expected the read error, got %v", err)
+	}
+	if len(imported) != 1 {
+		t.Errorf("Expected the row before the error imported, got %d", len(imported))
+	}
+}
+
+func TestCardService_DeleteMany_AllValid(t *testing.T) {
+	s, cardStore, boardStore := setupCardService()
+	boardStore.addBoard(testBoardConfig("main"))
+
+	a := mustAdd(t, s, AddCardInput{BoardName: "main", Title: "A"})
+	b := mustAdd(t, s, AddCardInput{BoardName: "main", Title: "B"})
+	keep := mustAdd(t, s, AddCardInput{BoardName: "main", Title: "Keep"})
+
+	// Aliases resolve, and a card listed twice is deleted once.
+	result, err := s.DeleteMany("main", []string{a.ID, b.Alias, a.Alias}, false)
+	if err != nil {
+		t.Fatalf("DeleteMany failed: %v", err)
+	}
+	if !reflect.DeepEqual(result.Deleted, []string{a.ID, b.ID}) {
+		t.Errorf("Deleted = %v, want [%s %s]", result.Deleted, a.ID, b.ID)
+	}
+	if len(result.NotFound) != 0 || len(result.Failed) != 0 {
+		t.Errorf("unexpected NotFound/Failed: %v / %v", result.NotFound, result.Failed)
+	}
+	if remaining := cardStore.cards["main"]; len(remaining) != 1 || remaining[keep.ID] == nil {
+		t.Errorf("expected only %q to remain, got %v", keep.Title, remaining)
+	}
+}
+
+func TestCardService_DeleteMany_ChildBeforeParent(t *testing.T) {
+	s, cardStore, boardStore := setupCardService()
+	boardStore.addBoard(testBoardConfig("main"))
+	_, parent, child, _ := addFamily(t, s)
+
+	// Deleting the parent after the child mustn't try to clear the deleted
+	// child's parent.
+	result, err := s.DeleteMany("main", []string{child.ID, parent.ID}, false)
+	if err != nil {
+		t.Fatalf("DeleteMany failed: %v", err)
+	}
+	if len(result.Failed) != 0 || len(result.Deleted) != 2 {
+		t.Errorf("Expected both cards deleted, got %+v", result)
+	}
+	if _, err := cardStore.Get("main", child.ID); !kanerr.IsCode(err, kanerr.CodeCardNotFound) {
+		t.Errorf("Expected child to stay deleted, got %v", err)
+	}
+}
+
+func TestCardService_DeleteMany_MixedValidAndInvalid(t *testing.T) {
+	s, cardStore, boardStore := setupCardService()
+	boardStore.addBoard(testBoardConfig("main"))
+
+	a := mustAdd(t, s, AddCardInput{BoardName: "main", Title: "A"})
+
+	result, err := s.DeleteMany("main", []string{"missing-1", a.ID, "missing-2"}, false)
+	if err != nil {
+		t.Fatalf("DeleteMany failed: %v", err)
+	}
+	if !reflect.DeepEqual(result.Deleted, []string{a.ID}) {
+		t.Errorf("Deleted = %v, want [%s]", result.Deleted, a.ID)
+	}
+	if !reflect.DeepEqual(result.NotFound, []string{"missing-1", "missing-2"}) {
+		t.Errorf("NotFound = %v", result.NotFound)
+	}
+	if len(cardStore.cards["main"]) != 0 {
+		t.Errorf("expected the valid card to be deleted")
+	}
+}
+
+func TestCardService_DeleteMany_DryRun(t *testing.T) {
+	s, cardStore, boardStore := setupCardService()
+	boardStore.addBoard(testBoardConfig("main"))
+
+	a := mustAdd(t, s, AddCardInput{BoardName: "main", Title: "A"})
+	b := mustAdd(t, s, AddCardInput{BoardName: "main", Title: "B"})
+
+	result, err := s.DeleteMany("main", []string{a.Alias, "missing", b.ID}, true)
+	if err != nil {
+		t.Fatalf("DeleteMany failed: %v", err)
+	}
+	if !reflect.DeepEqual(result.Deleted, []string{a.ID, b.ID}) {
+		t.Errorf("Deleted = %v, want [%s %s]", result.Deleted, a.ID, b.ID)
+	}
+	if !reflect.DeepEqual(result.NotFound, []string{"missing"}) {
+		t.Errorf("NotFound = %v, want [missing]", result.NotFound)
+	}
+	if len(cardStore.cards["main"]) != 2 {
+		t.Errorf("dry run should not delete, %d cards remain", len(cardStore.cards["main"]))
+	}
+}
+
+func TestCardService_DeleteMany_Errors(t *testing.T) {
+	s, _, boardStore := setupCardService()
+	boardStore.addBoard(testBoardConfig("main"))
+
+	if _, err := s.DeleteMany("main", nil, false); !kanerr.IsCode(err, kanerr.CodeInvalidField) {
+		t.Errorf("expected validation error for no IDs, got %v", err)
+	}
+	if _, err := s.DeleteMany("nope", []string{"x"}, false); !kanerr.IsCode(err, kanerr.CodeBoardNotFound) {
+		t.Errorf("expected board not found, got %v", err)
+	}
+}
+
+// ============================================================================
+// SuggestColumn() Tests
+// ============================================================================
+
+func suggestBoardConfig() *model.BoardConfig {
+	cfg := testBoardConfig("main")
+	cfg.Columns[2].Done = true
+	cfg.Stale = model.StaleConfig{StaleDays: 7, ExemptColumns: []string{"done"}}
+	return cfg
+}
+
+// addSuggestCard adds a card and applies edit to it directly in the store.
+func addSuggestCard(t *testing.T, s *CardService, cardStore *testCardStore, column string, edit func(*model.Card)) *model.Card {
+	t.Helper()
+	card := mustAdd(t, s, AddCardInput{BoardName: "main", Title: "Card", Column: column})
+	edit(card)
+	if err := cardStore.Update("main", card); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	return card
+}
+
+func TestCardService_SuggestColumn(t *testing.T) {
+	s, cardStore, boardStore := setupCardService()
+	cfg := suggestBoardConfig()
+	typeField := cfg.CustomFields["type"]
+	typeField.Wanted = true
+	cfg.CustomFields["type"] = typeField
+	boardStore.addBoard(cfg)
+
+	stale := util.NowMillis() - 10*util.MillisPerDay
+	setType := func(c *model.Card) { c.CustomFields["type"] = "task" }
+
+	tests := []struct {
+		name       string
+		column     string
+		edit       func(*model.Card)
+		wantColumn string
+		wantReason string
+	}{
+		{
+			name:   "checklist done",
+			column: "in-progress",
+			edit: func(c *model.Card) {
+				c.Checklist = []model.ChecklistItem{{ID: "a", Text: "A", Done: true}}
+			},
+			wantColumn: "done",
+			wantReason: "all checklist items are done",
+		},
+		{
+			name:       "missing wanted field",
+			column:     "backlog",
+			edit:       func(c *model.Card) {},
+			wantColumn: "backlog",
+			wantReason: "set required fields first: type",
+		},
+		{
+			name:   "stale in column",
+			column: "backlog",
+			edit: func(c *model.Card) {
+				setType(c)
+				c.History = []model.HistoryEntry{{Field: "column", Value: "backlog", At: stale}}
+			},
+			wantColumn: "in-progress",
+			wantReason: `in "backlog" for more than 7 days`,
+		},
+		{
+			name:   "stale in exempt column",
+			column: "done",
+			edit: func(c *model.Card) {
+				setType(c)
+				c.History = []model.HistoryEntry{{Field: "column", Value: "done", At: stale}}
+			},
+			wantReason: NoColumnSuggestion,
+		},
+		{
+			name:   "checklist incomplete",
+			column: "in-progress",
+			edit: func(c *model.Card) {
+				setType(c)
+				c.Checklist = []model.ChecklistItem{{ID: "a", Text: "A", Done: true}, {ID: "b", Text: "B"}}
+			},
+			wantReason: NoColumnSuggestion,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			card := addSuggestCard(t, s, cardStore, tt.column, func(c *model.Card) {
+				if c.CustomFields == nil {
+					c.CustomFields = map[string]any{}
+				}
+				tt.edit(c)
+			})
+
+			column, reason, err := s.SuggestColumn("main", card.ID)
+			if err != nil {
+				t.Fatalf("SuggestColumn failed: %v", err)
+			}
+			if column != tt.wantColumn || reason != tt.wantReason {
+				t.Errorf("SuggestColumn = (%q, %q), want (%q, %q)", column, reason, tt.wantColumn, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestCardService_SuggestColumn_DoesNotMutate(t *testing.T) {
+	s, cardStore, boardStore := setupCardService()
+	boardStore.addBoard(suggestBoardConfig())
+
+	card := addSuggestCard(t, s, cardStore, "backlog", func(c *model.Card) {
+		c.Checklist = []model.ChecklistItem{{ID: "a", Text: "A", Done: true}}
+	})
+	before := card.UpdatedAtMillis
+
+	if column, _, err := s.SuggestColumn("main", card.ID); err != nil || column != "done" {
+		t.Fatalf("SuggestColumn = %q, %v", column, err)
+	}
+	got, _ := cardStore.Get("main", card.ID)
+	if got.Column != "backlog" || got.UpdatedAtMillis != before {
+		t.Errorf("SuggestColumn changed the card: column %q, updated %d", got.Column, got.UpdatedAtMillis)
+	}
+}
+
+func TestCardService_SuggestColumn_NotFound(t *testing.T) {
+	s, _, boardStore := setupCardService()
+	boardStore.addBoard(suggestBoardConfig())
+
+	if _, _, err := s.SuggestColumn("main", "missing"); !kanerr.IsCode(err, kanerr.CodeCardNotFound) {
+		t.Errorf("expected not found, got %v", err)
+	}
+}
+
+func TestCardService_SuggestTags(t *testing.T) {
+	s, cardStore, boardStore := setupCardService()
+	cfg := testBoardConfig("main")
+	cfg.CustomFields["labels"] = model.CustomFieldSchema{Type: model.FieldTypeEnumSet, Options: []model.CustomFieldOption{
+		{Value: "auth"}, {Value: "bug"}, {Value: "backend"}, {Value: "login-flow"}, {Value: "docs"},
+	}}
+	cfg.CustomFields["tags"] = model.CustomFieldSchema{Type: model.FieldTypeFreeSet}
+	boardStore.addBoard(cfg)
+	cardStore.Create("main", &model.Card{ID: "c1", Alias: "login-timeout", Column: "backlog", CustomFields: map[string]any{
+		"labels": []any{"auth", "backend"},
+		"tags":   []any{"sessions"},
+	}})
+	cardStore.Create("main", &model.Card{ID: "c2", Alias: "write-guide", Column: "backlog", CustomFields: map[string]any{
+		"labels": []any{"docs"},
+	}})
+
+	tests := []struct {
+		title string
+		want  []string
+	}{
+		// "login" is in login-flow, "bug" is an option, and c1's alias
+		// shares "login", lending its labels and tags.
+		{"Fix login bug", []string{"auth", "backend", "bug"}},
+		{"Sessions expire", []string{"sessions"}},
+		{"Refactor the renderer", []string{}},
+		{"", []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			got, err := s.SuggestTags("main", tt.title)
+			if err != nil {
+				t.Fatalf("SuggestTags failed: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SuggestTags(%q) = %v, want %v", tt.title, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCardService_SuggestTags_NoTagFields(t *testing.T) {
+	s, _, boardStore := setupCardService()
+	cfg := testBoardConfig("main")
+	delete(cfg.CustomFields, "labels")
+	boardStore.addBoard(cfg)
+
+	got, err := s.SuggestTags("main", "Fix login bug")
+	if err != nil {
+		t.Fatalf("SuggestTags failed: %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("Expected no suggestions without a labels or tags field, got %v", got)
+	}
+	if _, err := s.SuggestTags("missing", "x"); !kanerr.IsCode(err, kanerr.CodeBoardNotFound) {
+		t.Errorf("Expected board not found, got %v", err)
+	}
+}
@@ -2,14 +2,20 @@ package service
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
+	"sort"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/BurntSushi/toml"
 	"github.com/amterp/kan/internal/config"
+	"github.com/amterp/kan/internal/id"
 	"github.com/amterp/kan/internal/model"
 	"github.com/amterp/kan/internal/store"
 	"github.com/amterp/kan/internal/util"
@@ -30,20 +36,29 @@ const (
 	CodeMalformedBoardConfig = "MALFORMED_BOARD_CONFIG"
 	CodeMalformedCard        = "MALFORMED_CARD"
 	CodeOrphanedCard         = "ORPHANED_CARD"
+	CodeDuplicateAlias       = "DUPLICATE_ALIAS"
+	CodeCircularParentRef    = "CIRCULAR_PARENT_REF"
+	CodeIDMismatch           = "ID_MISMATCH"
 
 	// Priority 2: Config issues (warnings)
-	CodeSchemaOutdated     = "SCHEMA_OUTDATED"
-	CodeInvalidDefaultCol  = "INVALID_DEFAULT_COLUMN"
-	CodeInvalidCardDisplay = "INVALID_CARD_DISPLAY"
-	CodeInvalidLinkRule    = "INVALID_LINK_RULE"
-	CodeInvalidPatternHook = "INVALID_PATTERN_HOOK"
-	CodeMissingHookFile    = "MISSING_HOOK_FILE"
+	CodeSchemaOutdated      = "SCHEMA_OUTDATED"
+	CodeInvalidDefaultCol   = "INVALID_DEFAULT_COLUMN"
+	CodeInvalidCardDisplay  = "INVALID_CARD_DISPLAY"
+	CodeInvalidLinkRule     = "INVALID_LINK_RULE"
+	CodeInvalidLinkRuleURL  = "INVALID_LINK_RULE_URL"
+	CodeInvalidPatternHook  = "INVALID_PATTERN_HOOK"
+	CodeMissingHookFile     = "MISSING_HOOK_FILE"
+	CodeDuplicateFieldOrder = "DUPLICATE_FIELD_ORDER"
+	CodeBrokenInclude       = "BROKEN_INCLUDE"
 
 	// Priority 3: Referential integrity (warnings)
 	CodeInvalidParentRef = "INVALID_PARENT_REF"
+	CodeInvalidBlockRef  = "INVALID_BLOCK_REF"
 
 	// Priority 4: Data quality (warnings)
 	CodeMissingWantedFields = "MISSING_WANTED_FIELDS"
+	CodeStaleCard           = "STALE_CARD"
+	CodeInvalidFieldValue   = "INVALID_FIELD_VALUE"
 
 	// Priority 5: Global config (warnings)
 	CodeMalformedGlobalConfig = "MALFORMED_GLOBAL_CONFIG"
@@ -93,8 +108,9 @@ func (r *DiagnosticReport) HasErrors() bool {
 
 // DoctorService validates Kan data for consistency issues.
 type DoctorService struct {
-	paths     *config.Paths
-	cardStore store.CardStore
+	paths           *config.Paths
+	cardStore       store.CardStore
+	includeArchived bool
 }
 
 // NewDoctorService creates a new diagnostic service.
@@ -102,8 +118,14 @@ func NewDoctorService(paths *config.Paths, cardStore store.CardStore) *DoctorSer
 	return &DoctorService{paths: paths, cardStore: cardStore}
 }
 
+// SetIncludeArchived makes Diagnose check archived boards too. By default
+// they are skipped unless named explicitly.
+func (s *DoctorService) SetIncludeArchived(include bool) {
+	s.includeArchived = include
+}
+
 // Diagnose analyzes all boards (or a specific board) for issues.
-// If boardName is empty, all boards are checked.
+// If boardName is empty, all active boards are checked.
 func (s *DoctorService) Diagnose(boardName string) (*DiagnosticReport, error) {
 	report := &DiagnosticReport{
 		Boards: []BoardDiagnostic{},
@@ -123,6 +145,9 @@ func (s *DoctorService) Diagnose(boardName string) (*DiagnosticReport, error) {
 		if boardName != "" && name != boardName {
 			continue
 		}
+		if boardName == "" && !s.includeArchived && store.BoardArchived(s.paths, name) {
+			continue
+		}
 		s.checkBoard(report, name)
 	}
 
@@ -161,6 +186,16 @@ func (s *DoctorService) Fix(report *DiagnosticReport) (*DiagnosticReport, error)
 			err = s.fixInvalidCardDisplay(issue.Board, issue.FixContext)
 		case CodeInvalidParentRef:
 			err = s.fixInvalidParentRef(issue.Board, issue.CardID)
+		case CodeInvalidBlockRef:
+			err = s.fixInvalidBlockRef(issue.Board, issue.CardID, issue.FixContext)
+		case CodeDuplicateAlias:
+			err = s.fixDuplicateAlias(issue.Board, issue.CardID)
+		case CodeCircularParentRef:
+			err = s.fixCircularParentRef(issue.Board, issue.CardID)
+		case CodeInvalidFieldValue:
+			err = s.fixInvalidFieldValue(issue.Board, issue.CardID, issue.FixContext)
+		case CodeIDMismatch:
+			err = s.fixIDMismatch(issue.Board, issue.CardID, issue.FixContext)
 		default:
 			remaining = append(remaining, issue)
 			continue
@@ -283,6 +318,9 @@ func (s *DoctorService) checkBoard(report *DiagnosticReport, boardName string) {
 
 	diag.Columns = len(boardConfig.Columns)
 
+	// Merge included definitions, so the checks below see what Kan sees
+	s.checkIncludes(report, boardName, &boardConfig)
+
 	// Check schema version
 	s.checkBoardSchema(report, boardName, &boardConfig)
 
@@ -298,6 +336,9 @@ func (s *DoctorService) checkBoard(report *DiagnosticReport, boardName string) {
 	// Check pattern hooks
 	s.checkPatternHooks(report, boardName, &boardConfig)
 
+	// Check custom field order
+	s.checkFieldOrder(report, boardName, &boardConfig)
+
 	// Check card files
 	cardsDir := s.paths.CardsDir(boardName)
 	entries, err := os.ReadDir(cardsDir)
@@ -327,13 +368,14 @@ func (s *DoctorService) checkBoard(report *DiagnosticReport, boardName string) {
 		diag.CardFiles++
 
 		// Check if card file is valid
-		s.checkCardFile(report, boardName, cardID)
+		s.checkCardFile(report, boardName, cardID, &boardConfig)
 	}
 
 	diag.CardsReferenced = len(cardFiles)
 
-	// Check cards for invalid column references
-	cards, err := s.cardStore.List(boardName)
+	// Check cards for invalid column references. Archived cards are skipped:
+	// they legitimately have no column or position.
+	cards, err := s.cardStore.List(boardName, false)
 	if err == nil {
 		for _, card := range cards {
 			if card.Column == "" {
@@ -371,12 +413,22 @@ func (s *DoctorService) checkBoard(report *DiagnosticReport, boardName string) {
 		}
 	}
 
+	// Check alias uniqueness
+	s.checkAliasCollisions(report, boardName, cardFiles)
+
 	// Check parent references
 	s.checkParentRefs(report, boardName, cardFiles)
+	s.checkCircularParents(report, boardName, cardFiles)
+
+	// Check dependency references
+	s.checkBlockRefs(report, boardName, cardFiles)
 
 	// Check wanted fields
 	s.checkWantedFields(report, boardName, &boardConfig, cardFiles)
 
+	// Check for stale cards
+	s.checkStaleCards(report, boardName, &boardConfig)
+
 	report.Boards = append(report.Boards, diag)
 }
 
@@ -405,6 +457,40 @@ func (s *DoctorService) checkBoardSchema(report *DiagnosticReport, boardName str
 	}
 }
 
+// checkIncludes merges a board's included files into cfg, reporting missing
+// files as warnings and include cycles (or unreadable files) as errors.
+func (s *DoctorService) checkIncludes(report *DiagnosticReport, boardName string, cfg *model.BoardConfig) {
+	err := store.ResolveIncludes(s.paths.KanRoot(), cfg)
+	if err == nil {
+		return
+	}
+	if errors.Is(err, store.ErrIncludeCycle) || !errors.Is(err, fs.ErrNotExist) {
+		report.Issues = append(report.Issues, Issue{
+			Severity: SeverityError,
+			Code:     CodeBrokenInclude,
+			Board:    boardName,
+			Message:  fmt.Sprintf("Cannot resolve includes: %v", err),
+			Fixable:  false,
+		})
+		return
+	}
+
+	missing := []error{err}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		missing = joined.Unwrap()
+	}
+	for _, m := range missing {
+		report.Issues = append(report.Issues, Issue{
+			Severity:  SeverityWarning,
+			Code:      CodeBrokenInclude,
+			Board:     boardName,
+			Message:   fmt.Sprintf("Included file not found: %v", m),
+			Fixable:   false,
+			FixAction: "Create the file or remove it from 'include'",
+		})
+	}
+}
+
 func (s *DoctorService) checkDefaultColumn(report *DiagnosticReport, boardName string, cfg *model.BoardConfig) {
 	if cfg.DefaultColumn == "" {
 		return // Will use first column as default
@@ -437,6 +523,8 @@ func (s *DoctorService) checkCardDisplay(report *DiagnosticReport, boardName str
 			fieldType = "tint"
 		} else if strings.Contains(w, "badges") {
 			fieldType = "badges"
+		} else if strings.Contains(w, "card_display.tags") {
+			fieldType = "tags"
 		} else if strings.Contains(w, "metadata") {
 			fieldType = "metadata"
 		}
@@ -466,6 +554,14 @@ func (s *DoctorService) checkLinkRules(report *DiagnosticReport, boardName strin
 				Message:  fmt.Sprintf("Link rule '%s' has invalid regex: %v", rule.Name, err),
 				Fixable:  false,
 			})
+		} else if err := rule.ValidateURL(); err != nil {
+			report.Issues = append(report.Issues, Issue{
+				Severity: SeverityWarning,
+				Code:     CodeInvalidLinkRuleURL,
+				Board:    boardName,
+				Message:  fmt.Sprintf("Link rule '%s' %v", rule.Name, err),
+				Fixable:  false,
+			})
 		}
 	}
 }
@@ -483,9 +579,10 @@ func (s *DoctorService) checkPatternHooks(report *DiagnosticReport, boardName st
 			})
 		}
 
-		// Check if command file exists (for file-based commands)
+		// Check if command file exists (for file-based commands). Paths built
+		// from capture groups are only known once a title matches.
 		cmd := hook.Command
-		if cmd == "" {
+		if cmd == "" || model.HasGroupRefs(cmd) {
 			continue
 		}
 
@@ -517,7 +614,35 @@ func (s *DoctorService) checkPatternHooks(report *DiagnosticReport, boardName st
 	}
 }
 
-func (s *DoctorService) checkCardFile(report *DiagnosticReport, boardName, cardID string) {
+// checkFieldOrder warns when custom fields share an explicit order, since
+// their relative position then falls back to name order. The default order
+// of 0 is never reported.
+func (s *DoctorService) checkFieldOrder(report *DiagnosticReport, boardName string, cfg *model.BoardConfig) {
+	byOrder := make(map[int][]string)
+	for _, field := range cfg.OrderedCustomFields() {
+		if field.Schema.Order != 0 {
+			byOrder[field.Schema.Order] = append(byOrder[field.Schema.Order], field.Name)
+		}
+	}
+	orders := make([]int, 0, len(byOrder))
+	for order, names := range byOrder {
+		if len(names) > 1 {
+			orders = append(orders, order)
+		}
+	}
+	sort.Ints(orders)
+	for _, order := range orders {
+		report.Issues = append(report.Issues, Issue{
+			Severity: SeverityWarning,
+			Code:     CodeDuplicateFieldOrder,
+			Board:    boardName,
+			Message:  fmt.Sprintf("Custom fields %s share order %d", strings.Join(byOrder[order], ", "), order),
+			Fixable:  false,
+		})
+	}
+}
+
+func (s *DoctorService) checkCardFile(report *DiagnosticReport, boardName, cardID string, cfg *model.BoardConfig) {
 	cardPath := s.paths.CardPath(boardName, cardID)
 	data, err := os.ReadFile(cardPath)
 	if err != nil {
@@ -542,6 +667,176 @@ func (s *DoctorService) checkCardFile(report *DiagnosticReport, boardName, cardI
 			Message:  fmt.Sprintf("Invalid JSON: %v", err),
 			Fixable:  false,
 		})
+		return
+	}
+
+	if card.ID != cardID {
+		s.reportIDMismatch(report, boardName, cardID, card.ID)
+	}
+
+	s.checkCustomFieldValues(report, boardName, &card, cfg)
+}
+
+// reportIDMismatch reports a card file whose name doesn't match the ID inside
+// it. Kan finds cards by file name but writes them by ID, so saving such a
+// card would leave a second copy behind. Renaming the file is only offered
+// when the ID is valid and no other card file already has that name.
+func (s *DoctorService) reportIDMismatch(report *DiagnosticReport, boardName, fileID, cardID string) {
+	issue := Issue{
+		Severity: SeverityError,
+		Code:     CodeIDMismatch,
+		Board:    boardName,
+		CardID:   fileID,
+		Message:  fmt.Sprintf("Card file %s.json contains id %q", fileID, cardID),
+	}
+	if !id.IsValidID(cardID) {
+		issue.Message += " (not a valid card ID)"
+	} else if _, err := os.Stat(s.paths.CardPath(boardName, cardID)); err == nil {
+		issue.Message += fmt.Sprintf(" (%s.json already exists)", cardID)
+	} else {
+		issue.Fixable = true
+		issue.FixAction = fmt.Sprintf("Rename to %s.json", cardID)
+		issue.FixContext = map[string]string{"id": cardID}
+	}
+	report.Issues = append(report.Issues, issue)
+}
+
+// checkCustomFieldValues reports custom field values the board's schema
+// doesn't allow: undefined fields, enum and enum-set values that aren't
+// options, user values that aren't collaborators, and free-set values over
+// the length limit. Only invalid enum values are fixable, by resetting them to
+// the first option.
+func (s *DoctorService) checkCustomFieldValues(report *DiagnosticReport, boardName string, card *model.Card, cfg *model.BoardConfig) {
+	names := make([]string, 0, len(card.CustomFields))
+	for name := range card.CustomFields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		issue := Issue{
+			Severity: SeverityWarning,
+			Code:     CodeInvalidFieldValue,
+			Board:    boardName,
+			CardID:   card.ID,
+		}
+
+		schema, exists := cfg.CustomFields[name]
+		if !exists {
+			issue.Message = fmt.Sprintf("Card has a value for undefined field %q", name)
+			report.Issues = append(report.Issues, issue)
+			continue
+		}
+
+		value := card.CustomFields[name]
+		switch schema.FieldType() {
+		case model.FieldTypeEnum:
+			if str, ok := value.(string); !ok || !isValidOption(schema.Options, str) {
+				issue.Message = fmt.Sprintf("Field %q has invalid value %v; must be one of: %s", name, value, formatOptions(schema.Options))
+				issue.Fixable = true
+				issue.FixContext = map[string]string{"field": name}
+				if len(schema.Options) > 0 {
+					issue.FixAction = fmt.Sprintf("Set %s to %q", name, schema.Options[0].Value)
+					issue.FixContext["value"] = schema.Options[0].Value
+				} else {
+					issue.FixAction = fmt.Sprintf("Clear %s", name)
+				}
+				report.Issues = append(report.Issues, issue)
+			}
+
+		case model.FieldTypeEnumSet:
+			var invalid []string
+			for _, v := range setFieldValues(value) {
+				if !isValidOption(schema.Options, v) {
+					invalid = append(invalid, v)
+				}
+			}
+			if len(invalid) > 0 {
+				issue.Message = fmt.Sprintf("Field %q has invalid values %s; must be from: %s", name, strings.Join(invalid, ", "), formatOptions(schema.Options))
+				report.Issues = append(report.Issues, issue)
+			}
+
+		case model.FieldTypeUser:
+			if str, ok := value.(string); !ok || !cfg.AcceptsUser(str) {
+				issue.Message = fmt.Sprintf("Field %q names %v, who is not a collaborator", name, value)
+				report.Issues = append(report.Issues, issue)
+			}
+
+		case model.FieldTypeFreeSet:
+			maxLen := schema.TagMaxLength()
+			var tooLong []string
+			for _, v := range setFieldValues(value) {
+				if utf8.RuneCountInString(v) > maxLen {
+					tooLong = append(tooLong, v)
+				}
+			}
+			if len(tooLong) > 0 {
+				issue.Message = fmt.Sprintf("Field %q has values over %d characters: %s", name, maxLen, strings.Join(tooLong, ", "))
+				report.Issues = append(report.Issues, issue)
+			}
+		}
+	}
+}
+
+// setFieldValues returns the string values of a set field as decoded from
+// card JSON. Non-string items are formatted with %v.
+func setFieldValues(value any) []string {
+	switch v := value.(type) {
+	case []any:
+		vals := make([]string, len(v))
+		for i, item := range v {
+			vals[i] = fmt.Sprint(item)
+		}
+		return vals
+	case []string:
+		return v
+	case string:
+		return []string{v}
+	}
+	return nil
+}
+
+// checkAliasCollisions reports cards whose alias is already used by another
+// card on the board. The oldest card (by creation time, then ID) keeps the
+// alias; every other card sharing it gets an issue.
+func (s *DoctorService) checkAliasCollisions(report *DiagnosticReport, boardName string, cardFiles map[string]bool) {
+	byAlias := make(map[string][]model.Card)
+	for cardID := range cardFiles {
+		data, err := os.ReadFile(s.paths.CardPath(boardName, cardID))
+		if err != nil {
+			continue // Already reported in checkCardFile
+		}
+
+		var card model.Card
+		if err := json.Unmarshal(data, &card); err != nil {
+			continue // Already reported in checkCardFile
+		}
+		if card.Alias != "" {
+			byAlias[card.Alias] = append(byAlias[card.Alias], card)
+		}
+	}
+
+	for alias, cards := range byAlias {
+		if len(cards) < 2 {
+			continue
+		}
+		sort.Slice(cards, func(i, j int) bool {
+			if cards[i].CreatedAtMillis != cards[j].CreatedAtMillis {
+				return cards[i].CreatedAtMillis < cards[j].CreatedAtMillis
+			}
+			return cards[i].ID < cards[j].ID
+		})
+		for _, card := range cards[1:] {
+			report.Issues = append(report.Issues, Issue{
+				Severity:  SeverityError,
+				Code:      CodeDuplicateAlias,
+				Board:     boardName,
+				CardID:    card.ID,
+				Message:   fmt.Sprintf("Alias %q is also used by card %s", alias, cards[0].ID),
+				Fixable:   true,
+				FixAction: "Generate a new alias from the card title",
+			})
+		}
 	}
 }
 
@@ -572,6 +867,106 @@ func (s *DoctorService) checkParentRefs(report *DiagnosticReport, boardName stri
 	}
 }
 
+// checkCircularParents reports parent chains that loop back on themselves.
+// Each card has at most one parent, so walking parents from every card finds
+// each cycle exactly once; the card whose parent closes the loop is the one
+// reported (and fixed).
+func (s *DoctorService) checkCircularParents(report *DiagnosticReport, boardName string, cardFiles map[string]bool) {
+	parents := make(map[string]string)
+	for cardID := range cardFiles {
+		data, err := os.ReadFile(s.paths.CardPath(boardName, cardID))
+		if err != nil {
+			continue // Already reported in checkCardFile
+		}
+		var card model.Card
+		if err := json.Unmarshal(data, &card); err != nil {
+			continue // Already reported in checkCardFile
+		}
+		if card.Parent != "" {
+			parents[cardID] = card.Parent
+		}
+	}
+
+	const (
+		unvisited = iota
+		inProgress
+		done
+	)
+	state := make(map[string]int)
+
+	// Sorted starts keep the reported chain stable between runs.
+	starts := make([]string, 0, len(parents))
+	for cardID := range parents {
+		starts = append(starts, cardID)
+	}
+	sort.Strings(starts)
+
+	for _, start := range starts {
+		var path []string
+		cardID := start
+		for cardID != "" && state[cardID] == unvisited {
+			state[cardID] = inProgress
+			path = append(path, cardID)
+			cardID = parents[cardID]
+		}
+
+		// Reaching a card on the current path is a back-edge: the chain from
+		// that card onwards is a cycle.
+		if cardID != "" && state[cardID] == inProgress {
+			cycle := path[slices.Index(path, cardID):]
+			closer := cycle[len(cycle)-1]
+			chain := strings.Join(append(append([]string(nil), cycle...), cardID), " → ")
+			report.Issues = append(report.Issues, Issue{
+				Severity:  SeverityError,
+				Code:      CodeCircularParentRef,
+				Board:     boardName,
+				CardID:    closer,
+				Message:   "circular parent chain: " + chain,
+				Fixable:   true,
+				FixAction: fmt.Sprintf("Clear parent field on '%s'", closer),
+			})
+		}
+
+		for _, visited := range path {
+			state[visited] = done
+		}
+	}
+}
+
+func (s *DoctorService) checkBlockRefs(report *DiagnosticReport, boardName string, cardFiles map[string]bool) {
+	for cardID := range cardFiles {
+		cardPath := s.paths.CardPath(boardName, cardID)
+		data, err := os.ReadFile(cardPath)
+		if err != nil {
+			continue // Already reported in checkCardFile
+		}
+
+		var card model.Card
+		if err := json.Unmarshal(data, &card); err != nil {
+			continue // Already reported in checkCardFile
+		}
+
+		var dangling []string
+		for _, ref := range append(append([]string(nil), card.Blocks...), card.BlockedBy...) {
+			if !cardFiles[ref] && !slices.Contains(dangling, ref) {
+				dangling = append(dangling, ref)
+			}
+		}
+		if len(dangling) > 0 {
+			report.Issues = append(report.Issues, Issue{
+				Severity:   SeverityWarning,
+				Code:       CodeInvalidBlockRef,
+				Board:      boardName,
+				CardID:     cardID,
+				Message:    fmt.Sprintf("Dependency references missing card(s): %s", strings.Join(dangling, ", ")),
+				Fixable:    true,
+				FixAction:  "Remove missing cards from blocks/blocked_by",
+				FixContext: map[string]string{"refs": strings.Join(dangling, ",")},
+			})
+		}
+	}
+}
+
 func (s *DoctorService) checkWantedFields(report *DiagnosticReport, boardName string, boardCfg *model.BoardConfig, cardFiles map[string]bool) {
 	// Skip if no wanted fields configured
 	hasWanted := false
@@ -615,6 +1010,29 @@ func (s *DoctorService) checkWantedFields(report *DiagnosticReport, boardName st
 	}
 }
 
+func (s *DoctorService) checkStaleCards(report *DiagnosticReport, boardName string, boardCfg *model.BoardConfig) {
+	if boardCfg.Stale.StaleDays <= 0 {
+		return
+	}
+	cards, err := s.cardStore.List(boardName, false)
+	if err != nil {
+		return // Unreadable cards are reported by checkCardFile
+	}
+
+	now := util.NowMillis()
+	for _, card := range FilterStaleCards(cards, boardCfg) {
+		report.Issues = append(report.Issues, Issue{
+			Severity: SeverityWarning,
+			Code:     CodeStaleCard,
+			Board:    boardName,
+			CardID:   card.ID,
+			Message: fmt.Sprintf("Card not updated in %s (stale after %d days)",
+				util.FormatDuration(now-card.UpdatedAtMillis), boardCfg.Stale.StaleDays),
+			Fixable: false,
+		})
+	}
+}
+
 // Fix implementations
 
 func (s *DoctorService) fixOrphanedCard(boardName, cardID string) error {
@@ -690,6 +1108,8 @@ func (s *DoctorService) fixInvalidCardDisplay(boardName string, fixContext map[s
 		cfg.CardDisplay.Badges = filterValidFields(cfg.CardDisplay.Badges, cfg.CustomFields)
 	case "metadata":
 		cfg.CardDisplay.Metadata = filterValidFields(cfg.CardDisplay.Metadata, cfg.CustomFields)
+	case "tags":
+		cfg.CardDisplay.Tags = filterValidFields(cfg.CardDisplay.Tags, cfg.CustomFields)
 	default:
 		return fmt.Errorf("unknown card_display field type: %q", fieldType)
 	}
@@ -723,3 +1143,106 @@ func (s *DoctorService) fixInvalidParentRef(boardName, cardID string) error {
 
 	return writeJSONMap(cardPath, raw)
 }
+
+// fixCircularParentRef breaks a parent cycle by clearing the parent of the
+// card that closes it.
+func (s *DoctorService) fixCircularParentRef(boardName, cardID string) error {
+	return s.fixInvalidParentRef(boardName, cardID)
+}
+
+func (s *DoctorService) fixInvalidBlockRef(boardName, cardID string, fixCtx map[string]string) error {
+	cardPath := s.paths.CardPath(boardName, cardID)
+	data, err := os.ReadFile(cardPath)
+	if err != nil {
+		return err
+	}
+
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	dangling := strings.Split(fixCtx["refs"], ",")
+	for _, key := range []string{"blocks", "blocked_by"} {
+		refs, ok := raw[key].([]any)
+		if !ok {
+			continue
+		}
+		kept := make([]any, 0, len(refs))
+		for _, ref := range refs {
+			if id, ok := ref.(string); !ok || !slices.Contains(dangling, id) {
+				kept = append(kept, ref)
+			}
+		}
+		if len(kept) == 0 {
+			delete(raw, key)
+		} else {
+			raw[key] = kept
+		}
+	}
+
+	return writeJSONMap(cardPath, raw)
+}
+
+// fixInvalidFieldValue sets an enum field to the value in the fix context,
+// or removes it when the field has no options.
+func (s *DoctorService) fixInvalidFieldValue(boardName, cardID string, fixCtx map[string]string) error {
+	cardPath := s.paths.CardPath(boardName, cardID)
+	data, err := os.ReadFile(cardPath)
+	if err != nil {
+		return err
+	}
+
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	if value, ok := fixCtx["value"]; ok {
+		raw[fixCtx["field"]] = value
+	} else {
+		delete(raw, fixCtx["field"])
+	}
+
+	return writeJSONMap(cardPath, raw)
+}
+
+func (s *DoctorService) fixIDMismatch(boardName, fileID string, fixCtx map[string]string) error {
+	cardID := fixCtx["id"]
+	if !id.IsValidID(cardID) {
+		return fmt.Errorf("invalid card ID %q", cardID)
+	}
+	target := s.paths.CardPath(boardName, cardID)
+	if _, err := os.Stat(target); err == nil {
+		return fmt.Errorf("%s.json already exists", cardID)
+	}
+	if err := os.Rename(s.paths.CardPath(boardName, fileID), target); err != nil {
+		return err
+	}
+	return s.cardStore.RebuildIndex(boardName)
+}
+
+func (s *DoctorService) fixDuplicateAlias(boardName, cardID string) error {
+	cardPath := s.paths.CardPath(boardName, cardID)
+	data, err := os.ReadFile(cardPath)
+	if err != nil {
+		return err
+	}
+
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	// Don't exclude the card itself: its current alias is the duplicate, so it
+	// must count as taken.
+	title, _ := raw["title"].(string)
+	alias, err := NewAliasService(s.cardStore, store.NewBoardStore(s.paths)).GenerateAlias(boardName, title, "")
+	if err != nil {
+		return err
+	}
+	raw["alias"] = alias
+	raw["alias_explicit"] = false
+
+	return writeJSONMap(cardPath, raw)
+}
@@ -3,6 +3,7 @@ package service
 import (
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -80,6 +81,194 @@ func TestDoctorService_Healthy(t *testing.T) {
 	}
 }
 
+func TestDoctorService_StaleCard(t *testing.T) {
+	service, tempDir, cleanup := setupDoctorTest(t, "healthy")
+	defer cleanup()
+
+	// Both fixture cards were last updated in 2023.
+	configPath := filepath.Join(tempDir, ".kan", "boards", "main", "config.toml")
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("Failed to read config: %v", err)
+	}
+	stale := "\n[stale]\nstale_days = 30\nexempt_columns = [\"done\"]\n"
+	if err := os.WriteFile(configPath, append(data, stale...), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	report, err := service.Diagnose("")
+	if err != nil {
+		t.Fatalf("Diagnose failed: %v", err)
+	}
+
+	var staleIDs []string
+	for _, issue := range report.Issues {
+		if issue.Code == CodeStaleCard {
+			staleIDs = append(staleIDs, issue.CardID)
+			if issue.Severity != SeverityWarning || issue.Fixable {
+				t.Errorf("Expected an unfixable warning, got %+v", issue)
+			}
+		}
+	}
+	// card-2 is in the exempt done column.
+	if !reflect.DeepEqual(staleIDs, []string{"card-1"}) {
+		t.Errorf("Expected STALE_CARD for card-1 only, got %v", staleIDs)
+	}
+	if report.Summary.Errors != 0 {
+		t.Errorf("Expected stale cards not to count as errors, got %d", report.Summary.Errors)
+	}
+}
+
+func TestDoctorService_InvalidLinkRuleURL(t *testing.T) {
+	service, tempDir, cleanup := setupDoctorTest(t, "healthy")
+	defer cleanup()
+
+	configPath := filepath.Join(tempDir, ".kan", "boards", "main", "config.toml")
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("Failed to read config: %v", err)
+	}
+	rules := `
+[[link_rules]]
+name = "Jira"
+pattern = "[A-Z]+-\\d+"
+url = "https://jira.example.com/browse/{0}"
+
+[[link_rules]]
+name = "GitHub"
+pattern = "#(\\d+)"
+url = "https://github.com/{1}/{2}"
+
+[[link_rules]]
+name = "Broken"
+pattern = "("
+url = "https://example.com/{5}"
+`
+	if err := os.WriteFile(configPath, append(data, rules...), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	report, err := service.Diagnose("")
+	if err != nil {
+		t.Fatalf("Diagnose failed: %v", err)
+	}
+
+	var codes []string
+	for _, issue := range report.Issues {
+		codes = append(codes, issue.Code)
+		if issue.Code == CodeInvalidLinkRuleURL && !strings.Contains(issue.Message, "GitHub") {
+			t.Errorf("Expected the GitHub rule to be reported, got %q", issue.Message)
+		}
+	}
+	// The rule with a bad regex is only reported for its regex.
+	if !reflect.DeepEqual(codes, []string{CodeInvalidLinkRule, CodeInvalidLinkRuleURL}) &&
+		!reflect.DeepEqual(codes, []string{CodeInvalidLinkRuleURL, CodeInvalidLinkRule}) {
+		t.Errorf("Expected one INVALID_LINK_RULE and one INVALID_LINK_RULE_URL, got %v", codes)
+	}
+}
+
+func TestDoctorService_DuplicateFieldOrder(t *testing.T) {
+	service, tempDir, cleanup := setupDoctorTest(t, "healthy")
+	defer cleanup()
+
+	configPath := filepath.Join(tempDir, ".kan", "boards", "main", "config.toml")
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("Failed to read config: %v", err)
+	}
+	fields := `
+[custom_fields.priority]
+type = "string"
+order = 1
+
+[custom_fields.effort]
+type = "string"
+order = 1
+
+[custom_fields.notes]
+type = "string"
+
+[custom_fields.owner]
+type = "string"
+`
+	if err := os.WriteFile(configPath, append(data, fields...), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	report, err := service.Diagnose("")
+	if err != nil {
+		t.Fatalf("Diagnose failed: %v", err)
+	}
+
+	var messages []string
+	for _, issue := range report.Issues {
+		if issue.Code == CodeDuplicateFieldOrder {
+			messages = append(messages, issue.Message)
+		}
+	}
+	// notes and owner share the default order, which isn't reported.
+	want := []string{"Custom fields effort, priority share order 1"}
+	if !reflect.DeepEqual(messages, want) {
+		t.Errorf("DUPLICATE_FIELD_ORDER messages = %v, want %v", messages, want)
+	}
+}
+
+func TestDoctorService_SkipsArchivedBoards(t *testing.T) {
+	service, tempDir, cleanup := setupDoctorTest(t, "invalid-default-column")
+	defer cleanup()
+
+	configPath := filepath.Join(tempDir, ".kan", "boards", "main", "config.toml")
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("Failed to read config: %v", err)
+	}
+	archived := strings.Replace(string(data), "\nname = ", "\narchived_at_millis = 1700000000000\nname = ", 1)
+	if err := os.WriteFile(configPath, []byte(archived), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	report, err := service.Diagnose("")
+	if err != nil {
+		t.Fatalf("Diagnose failed: %v", err)
+	}
+	if len(report.Boards) != 0 || len(report.Issues) != 0 {
+		t.Errorf("Expected archived board to be skipped, got boards %v, issues %v", report.Boards, report.Issues)
+	}
+
+	// Naming the board checks it regardless.
+	report, err = service.Diagnose("main")
+	if err != nil {
+		t.Fatalf("Diagnose failed: %v", err)
+	}
+	if report.Summary.Warnings != 1 {
+		t.Errorf("Expected 1 warning when naming an archived board, got %v", report.Issues)
+	}
+
+	service.SetIncludeArchived(true)
+	report, err = service.Diagnose("")
+	if err != nil {
+		t.Fatalf("Diagnose failed: %v", err)
+	}
+	if report.Summary.Warnings != 1 {
+		t.Errorf("Expected 1 warning with archived boards included, got %v", report.Issues)
+	}
+}
+
+func TestDoctorService_StaleCard_Disabled(t *testing.T) {
+	service, _, cleanup := setupDoctorTest(t, "healthy")
+	defer cleanup()
+
+	report, err := service.Diagnose("")
+	if err != nil {
+		t.Fatalf("Diagnose failed: %v", err)
+	}
+	for _, issue := range report.Issues {
+		if issue.Code == CodeStaleCard {
+			t.Errorf("Expected no STALE_CARD with stale_days unset, got %+v", issue)
+		}
+	}
+}
+
 func TestDoctorService_OrphanedCard(t *testing.T) {
 	service, _, cleanup := setupDoctorTest(t, "orphaned-card")
 	defer cleanup()
@@ -237,6 +426,437 @@ func TestDoctorService_InvalidParent_Fix(t *testing.T) {
 	}
 }
 
+func TestDoctorService_CircularParent(t *testing.T) {
+	service, _, cleanup := setupDoctorTest(t, "circular-parent")
+	defer cleanup()
+
+	report, err := service.Diagnose("")
+	if err != nil {
+		t.Fatalf("Diagnose failed: %v", err)
+	}
+
+	if report.Summary.Errors != 1 {
+		t.Errorf("Expected 1 error, got %d: %+v", report.Summary.Errors, report.Issues)
+	}
+
+	var issue *Issue
+	for i := range report.Issues {
+		if report.Issues[i].Code == CodeCircularParentRef {
+			issue = &report.Issues[i]
+		}
+	}
+	if issue == nil {
+		t.Fatal("Expected CIRCULAR_PARENT_REF issue")
+	}
+	if issue.Severity != SeverityError || !issue.Fixable {
+		t.Errorf("Expected a fixable error, got %+v", issue)
+	}
+	if issue.CardID != "card-c" {
+		t.Errorf("Expected the closing card 'card-c', got %q", issue.CardID)
+	}
+	want := "circular parent chain: card-a → card-b → card-c → card-a"
+	if issue.Message != want {
+		t.Errorf("Message = %q, want %q", issue.Message, want)
+	}
+}
+
+func TestDoctorService_CircularParent_Fix(t *testing.T) {
+	service, tempDir, cleanup := setupDoctorTest(t, "circular-parent")
+	defer cleanup()
+
+	report, err := service.Diagnose("")
+	if err != nil {
+		t.Fatalf("Diagnose failed: %v", err)
+	}
+
+	fixedReport, err := service.Fix(report)
+	if err != nil {
+		t.Fatalf("Fix failed: %v", err)
+	}
+	if fixedReport.Summary.Fixed != 1 {
+		t.Errorf("Expected 1 fix, got %d", fixedReport.Summary.Fixed)
+	}
+
+	// Only the closing card loses its parent
+	cardsDir := filepath.Join(tempDir, ".kan", "boards", "main", "cards")
+	for cardID, wantParent := range map[string]bool{"card-a": true, "card-b": true, "card-c": false, "card-d": true} {
+		data, err := os.ReadFile(filepath.Join(cardsDir, cardID+".json"))
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", cardID, err)
+		}
+		if got := strings.Contains(string(data), `"parent"`); got != wantParent {
+			t.Errorf("%s has parent = %v, want %v", cardID, got, wantParent)
+		}
+	}
+
+	// Re-diagnose to verify the cycle is gone
+	newReport, err := service.Diagnose("")
+	if err != nil {
+		t.Fatalf("Diagnose after fix failed: %v", err)
+	}
+	for _, issue := range newReport.Issues {
+		if issue.Code == CodeCircularParentRef {
+			t.Errorf("Cycle should be broken after fix, got %+v", issue)
+		}
+	}
+}
+
+func TestDoctorService_InvalidBlockRef(t *testing.T) {
+	service, _, cleanup := setupDoctorTest(t, "invalid-block-ref")
+	defer cleanup()
+
+	report, err := service.Diagnose("")
+	if err != nil {
+		t.Fatalf("Diagnose failed: %v", err)
+	}
+
+	if report.Summary.Warnings != 1 {
+		t.Errorf("Expected 1 warning, got %d", report.Summary.Warnings)
+	}
+
+	found := false
+	for _, issue := range report.Issues {
+		if issue.Code == CodeInvalidBlockRef && issue.CardID == "card-1" {
+			found = true
+			if !issue.Fixable {
+				t.Error("Invalid block ref issue should be fixable")
+			}
+			if !strings.Contains(issue.Message, "card-nonexistent") {
+				t.Errorf("Message should name the missing card, got %q", issue.Message)
+			}
+		}
+	}
+	if !found {
+		t.Error("Expected INVALID_BLOCK_REF issue for card-1")
+	}
+}
+
+func TestDoctorService_InvalidBlockRef_Fix(t *testing.T) {
+	service, tempDir, cleanup := setupDoctorTest(t, "invalid-block-ref")
+	defer cleanup()
+
+	report, err := service.Diagnose("")
+	if err != nil {
+		t.Fatalf("Diagnose failed: %v", err)
+	}
+
+	fixedReport, err := service.Fix(report)
+	if err != nil {
+		t.Fatalf("Fix failed: %v", err)
+	}
+	if fixedReport.Summary.Fixed != 1 {
+		t.Errorf("Expected 1 fix, got %d", fixedReport.Summary.Fixed)
+	}
+
+	// Only the dangling reference is removed; the valid blocker stays.
+	paths := config.NewPaths(tempDir, "")
+	card, err := store.NewCardStore(paths).Get("main", "card-1")
+	if err != nil {
+		t.Fatalf("Failed to read fixed card: %v", err)
+	}
+	if len(card.BlockedBy) != 1 || card.BlockedBy[0] != "card-2" {
+		t.Errorf("BlockedBy = %v, want [card-2]", card.BlockedBy)
+	}
+
+	report, err = service.Diagnose("")
+	if err != nil {
+		t.Fatalf("Second Diagnose failed: %v", err)
+	}
+	if report.Summary.Warnings != 0 {
+		t.Errorf("Expected no warnings after fix, got %d", report.Summary.Warnings)
+	}
+}
+
+func TestDoctorService_InvalidFieldValue(t *testing.T) {
+	service, _, cleanup := setupDoctorTest(t, "invalid-field-value")
+	defer cleanup()
+
+	report, err := service.Diagnose("")
+	if err != nil {
+		t.Fatalf("Diagnose failed: %v", err)
+	}
+
+	if report.Summary.Warnings != 1 {
+		t.Errorf("Expected 1 warning, got %d", report.Summary.Warnings)
+	}
+
+	found := false
+	for _, issue := range report.Issues {
+		if issue.Code == CodeInvalidFieldValue && issue.CardID == "card-1" {
+			found = true
+			if issue.Severity != SeverityWarning {
+				t.Errorf("Expected warning severity, got %s", issue.Severity)
+			}
+			if !issue.Fixable {
+				t.Error("Invalid enum value issue should be fixable")
+			}
+			if !strings.Contains(issue.Message, "priority") || !strings.Contains(issue.Message, "invalid") {
+				t.Errorf("Message should name the field and value, got %q", issue.Message)
+			}
+		}
+	}
+	if !found {
+		t.Error("Expected INVALID_FIELD_VALUE issue for card-1")
+	}
+}
+
+func TestDoctorService_InvalidFieldValue_Fix(t *testing.T) {
+	service, tempDir, cleanup := setupDoctorTest(t, "invalid-field-value")
+	defer cleanup()
+
+	report, err := service.Diagnose("")
+	if err != nil {
+		t.Fatalf("Diagnose failed: %v", err)
+	}
+
+	fixedReport, err := service.Fix(report)
+	if err != nil {
+		t.Fatalf("Fix failed: %v", err)
+	}
+	if fixedReport.Summary.Fixed != 1 {
+		t.Errorf("Expected 1 fix, got %d", fixedReport.Summary.Fixed)
+	}
+
+	paths := config.NewPaths(tempDir, "")
+	card, err := store.NewCardStore(paths).Get("main", "card-1")
+	if err != nil {
+		t.Fatalf("Failed to read fixed card: %v", err)
+	}
+	if card.CustomFields["priority"] != "low" {
+		t.Errorf("priority = %v, want low", card.CustomFields["priority"])
+	}
+
+	report, err = service.Diagnose("")
+	if err != nil {
+		t.Fatalf("Second Diagnose failed: %v", err)
+	}
+	if report.Summary.Warnings != 0 {
+		t.Errorf("Expected no warnings after fix, got %d", report.Summary.Warnings)
+	}
+}
+
+func TestDoctorService_InvalidFieldValue_SetsAndUndefined(t *testing.T) {
+	service, tempDir, cleanup := setupDoctorTest(t, "invalid-field-value")
+	defer cleanup()
+
+	configPath := filepath.Join(tempDir, ".kan", "boards", "main", "config.toml")
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("Failed to read config: %v", err)
+	}
+	fields := `
+[custom_fields.areas]
+type = "enum-set"
+options = [{ value = "ui" }, { value = "api" }]
+
+[custom_fields.topics]
+type = "free-set"
+max_length = 5
+`
+	if err := os.WriteFile(configPath, append(data, fields...), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	card := `{"_v": 9, "id": "card-2", "alias": "c2", "title": "Sets", "column": "backlog", "position": "W",
+"created_at_millis": 1700000000000, "updated_at_millis": 1700000000000,
+"areas": ["ui", "db"], "topics": ["short", "far-too-long"], "color": "red"}`
+	cardPath := filepath.Join(tempDir, ".kan", "boards", "main", "cards", "card-2.json")
+	if err := os.WriteFile(cardPath, []byte(card), 0644); err != nil {
+		t.Fatalf("Failed to write card: %v", err)
+	}
+
+	report, err := service.Diagnose("")
+	if err != nil {
+		t.Fatalf("Diagnose failed: %v", err)
+	}
+
+	var messages []string
+	for _, issue := range report.Issues {
+		if issue.Code == CodeInvalidFieldValue && issue.CardID == "card-2" {
+			if issue.Fixable {
+				t.Errorf("Only enum issues should be fixable, got fixable %q", issue.Message)
+			}
+			messages = append(messages, issue.Message)
+		}
+	}
+	if len(messages) != 3 {
+		t.Fatalf("Expected 3 issues for card-2, got %v", messages)
+	}
+	for i, want := range []string{"db", "undefined field \"color\"", "far-too-long"} {
+		if !strings.Contains(messages[i], want) {
+			t.Errorf("Message %d = %q, want it to mention %s", i, messages[i], want)
+		}
+	}
+}
+
+func TestDoctorService_IDMismatch(t *testing.T) {
+	service, _, cleanup := setupDoctorTest(t, "id-mismatch")
+	defer cleanup()
+
+	report, err := service.Diagnose("")
+	if err != nil {
+		t.Fatalf("Diagnose failed: %v", err)
+	}
+
+	if report.Summary.Errors != 1 || len(report.Issues) != 1 {
+		t.Fatalf("Expected 1 error, got %+v: %+v", report.Summary, report.Issues)
+	}
+	issue := report.Issues[0]
+	if issue.Code != CodeIDMismatch || issue.Severity != SeverityError || issue.CardID != "card-wrong" {
+		t.Errorf("Expected ID_MISMATCH error for card-wrong, got %+v", issue)
+	}
+	if !issue.Fixable || issue.FixAction != "Rename to card-correct.json" {
+		t.Errorf("Expected a rename fix, got %+v", issue)
+	}
+}
+
+func TestDoctorService_IDMismatch_Fix(t *testing.T) {
+	service, tempDir, cleanup := setupDoctorTest(t, "id-mismatch")
+	defer cleanup()
+
+	report, err := service.Diagnose("")
+	if err != nil {
+		t.Fatalf("Diagnose failed: %v", err)
+	}
+
+	fixedReport, err := service.Fix(report)
+	if err != nil {
+		t.Fatalf("Fix failed: %v", err)
+	}
+	if fixedReport.Summary.Fixed != 1 || fixedReport.Summary.Errors != 0 {
+		t.Errorf("Expected 1 fix and no errors left, got %+v", fixedReport.Summary)
+	}
+
+	cardsDir := filepath.Join(tempDir, ".kan", "boards", "main", "cards")
+	if _, err := os.Stat(filepath.Join(cardsDir, "card-wrong.json")); !os.IsNotExist(err) {
+		t.Errorf("Expected card-wrong.json to be gone, got %v", err)
+	}
+	cardStore := store.NewCardStore(config.NewPaths(tempDir, ""))
+	card, err := cardStore.FindByAlias("main", "renamed-card")
+	if err != nil || card.ID != "card-correct" {
+		t.Errorf("FindByAlias(renamed-card) = %v, %v; want card-correct", card, err)
+	}
+
+	report, err = service.Diagnose("")
+	if err != nil {
+		t.Fatalf("Diagnose failed: %v", err)
+	}
+	if len(report.Issues) != 0 {
+		t.Errorf("Expected no issues after fix, got %+v", report.Issues)
+	}
+}
+
+func TestDoctorService_IDMismatch_TargetExists(t *testing.T) {
+	service, tempDir, cleanup := setupDoctorTest(t, "id-mismatch")
+	defer cleanup()
+
+	// Another file already holds the ID, so renaming would overwrite it.
+	cardsDir := filepath.Join(tempDir, ".kan", "boards", "main", "cards")
+	data, err := os.ReadFile(filepath.Join(cardsDir, "card-wrong.json"))
+	if err != nil {
+		t.Fatalf("Failed to read card: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(cardsDir, "card-correct.json"), data, 0644); err != nil {
+		t.Fatalf("Failed to write card: %v", err)
+	}
+
+	report, err := service.Diagnose("")
+	if err != nil {
+		t.Fatalf("Diagnose failed: %v", err)
+	}
+	found := false
+	for _, issue := range report.Issues {
+		if issue.Code != CodeIDMismatch {
+			continue
+		}
+		found = true
+		if issue.Fixable || !strings.Contains(issue.Message, "already exists") {
+			t.Errorf("Expected an unfixable mismatch naming the existing file, got %+v", issue)
+		}
+	}
+	if !found {
+		t.Error("Expected ID_MISMATCH issue")
+	}
+}
+
+func TestDoctorService_DuplicateAlias(t *testing.T) {
+	service, _, cleanup := setupDoctorTest(t, "duplicate-alias")
+	defer cleanup()
+
+	report, err := service.Diagnose("")
+	if err != nil {
+		t.Fatalf("Diagnose failed: %v", err)
+	}
+
+	if report.Summary.Errors != 2 {
+		t.Errorf("Expected 2 errors, got %d", report.Summary.Errors)
+	}
+
+	// The oldest card keeps the alias; the other two are flagged.
+	flagged := map[string]bool{}
+	for _, issue := range report.Issues {
+		if issue.Code != CodeDuplicateAlias {
+			continue
+		}
+		flagged[issue.CardID] = true
+		if !issue.Fixable {
+			t.Error("Duplicate alias issue should be fixable")
+		}
+		if !strings.Contains(issue.Message, "card-1") {
+			t.Errorf("Message should name the card keeping the alias, got %q", issue.Message)
+		}
+	}
+	if len(flagged) != 2 || !flagged["card-2"] || !flagged["card-3"] {
+		t.Errorf("Expected DUPLICATE_ALIAS for card-2 and card-3, got %v", flagged)
+	}
+}
+
+func TestDoctorService_DuplicateAlias_Fix(t *testing.T) {
+	service, tempDir, cleanup := setupDoctorTest(t, "duplicate-alias")
+	defer cleanup()
+
+	report, err := service.Diagnose("")
+	if err != nil {
+		t.Fatalf("Diagnose failed: %v", err)
+	}
+
+	fixedReport, err := service.Fix(report)
+	if err != nil {
+		t.Fatalf("Fix failed: %v", err)
+	}
+	if fixedReport.Summary.Fixed != 2 {
+		t.Errorf("Expected 2 fixes, got %d", fixedReport.Summary.Fixed)
+	}
+
+	cardStore := store.NewCardStore(config.NewPaths(tempDir, ""))
+	seen := map[string]string{}
+	for _, id := range []string{"card-1", "card-2", "card-3"} {
+		card, err := cardStore.Get("main", id)
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", id, err)
+		}
+		if other, dup := seen[card.Alias]; dup {
+			t.Errorf("Alias %q still shared by %s and %s", card.Alias, other, id)
+		}
+		seen[card.Alias] = id
+
+		found, err := cardStore.FindByAlias("main", card.Alias)
+		if err != nil || found.ID != id {
+			t.Errorf("FindByAlias(%q) = %v, %v; want %s", card.Alias, found, err, id)
+		}
+	}
+	if seen["fix-login"] != "card-1" {
+		t.Errorf("Expected card-1 to keep 'fix-login', aliases: %v", seen)
+	}
+
+	report, err = service.Diagnose("")
+	if err != nil {
+		t.Fatalf("Second Diagnose failed: %v", err)
+	}
+	if report.Summary.Errors != 0 {
+		t.Errorf("Expected no errors after fix, got %d: %v", report.Summary.Errors, report.Issues)
+	}
+}
+
 func TestDoctorService_SpecificBoard(t *testing.T) {
 	service, _, cleanup := setupDoctorTest(t, "healthy")
 	defer cleanup()
@@ -289,6 +909,28 @@ func TestDoctorService_HasErrors(t *testing.T) {
 	}
 }
 
+func TestDoctorService_BrokenInclude(t *testing.T) {
+	service, _, cleanup := setupDoctorTest(t, "broken-include")
+	defer cleanup()
+
+	report, err := service.Diagnose("")
+	if err != nil {
+		t.Fatalf("Diagnose failed: %v", err)
+	}
+
+	// The readable include still applies, so card-1's team value is valid.
+	if report.Summary.Errors != 0 || report.Summary.Warnings != 1 {
+		t.Fatalf("Expected 0 errors and 1 warning, got %+v: %+v", report.Summary, report.Issues)
+	}
+	issue := report.Issues[0]
+	if issue.Code != CodeBrokenInclude || issue.Severity != SeverityWarning {
+		t.Errorf("Expected BROKEN_INCLUDE warning, got %+v", issue)
+	}
+	if !strings.Contains(issue.Message, "shared/missing.toml") {
+		t.Errorf("Expected message to name the missing file, got %q", issue.Message)
+	}
+}
+
 func TestDoctorService_InvalidDefaultColumn(t *testing.T) {
 	service, _, cleanup := setupDoctorTest(t, "invalid-default-column")
 	defer cleanup()
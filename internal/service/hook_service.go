@@ -3,15 +3,24 @@ package service
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/amterp/kan/internal/id"
+	"github.com/amterp/kan/internal/metrics"
 	"github.com/amterp/kan/internal/model"
+	"github.com/amterp/kan/internal/store"
+	"github.com/amterp/kan/internal/util"
 )
 
 // DefaultHookTimeout is the default timeout for hook execution in seconds.
@@ -26,20 +35,55 @@ type HookResult struct {
 	ExitCode int
 	Duration time.Duration
 	Error    error
+
+	// FieldsSet holds the custom fields the hook set on the card via its
+	// output (see parseHookFields). CardUpdated reports whether any were.
+	FieldsSet   map[string]string
+	CardUpdated bool
 }
 
+// hookTaskTTL is how long a finished async hook task stays pollable.
+const hookTaskTTL = 5 * time.Minute
+
 // HookService handles pattern hook execution.
 type HookService struct {
-	projectRoot string
+	projectRoot  string
+	historyStore store.HookHistoryStore
+
+	// tasks maps an async task ID to its *hookTask. Tasks live in memory
+	// only; a restarted server forgets them.
+	tasks       sync.Map
+	taskTTL     time.Duration
+	sweepEvery  time.Duration
+	janitorOnce sync.Once
+	stopJanitor chan struct{}
+	closeOnce   sync.Once
+}
+
+// hookTask tracks one ExecuteHooksAsync run.
+type hookTask struct {
+	mu         sync.Mutex
+	done       bool
+	results    []*HookResult
+	finishedAt time.Time
 }
 
 // NewHookService creates a new hook service.
 func NewHookService(projectRoot string) *HookService {
 	return &HookService{
 		projectRoot: projectRoot,
+		taskTTL:     hookTaskTTL,
+		sweepEvery:  time.Minute,
+		stopJanitor: make(chan struct{}),
 	}
 }
 
+// SetHistoryStore enables hook history. When set, ExecuteHooks records every
+// hook it runs.
+func (s *HookService) SetHistoryStore(historyStore store.HookHistoryStore) {
+	s.historyStore = historyStore
+}
+
 // FindMatchingHooks returns all hooks whose pattern matches the given card title.
 func (s *HookService) FindMatchingHooks(hooks []model.PatternHook, title string) []model.PatternHook {
 	var matching []model.PatternHook
@@ -56,28 +100,60 @@ func (s *HookService) FindMatchingHooks(hooks []model.PatternHook, title string)
 	return matching
 }
 
-// ExecuteHook runs a hook command with the card ID and board name as arguments.
-// Returns the hook result including stdout, stderr, exit code, and any error.
-func (s *HookService) ExecuteHook(hook model.PatternHook, cardID, boardName string) *HookResult {
-	result := &HookResult{
-		HookName: hook.Name,
-	}
+// maxWebhookResponse caps how much of a webhook's response body is captured.
+const maxWebhookResponse = 64 << 10
 
+// webhookPayload is the JSON body POSTed to webhook hooks.
+type webhookPayload struct {
+	CardID       string         `json:"card_id"`
+	Board        string         `json:"board"`
+	Title        string         `json:"title"`
+	CustomFields map[string]any `json:"custom_fields"`
+}
+
+// ExecuteHook runs a hook for a card. Command hooks are run with the card ID
+// and board name as arguments, or with CommandArgs if set; {N} in the command
+// and its arguments is replaced with capture group N of the title match.
+// Webhook hooks (Webhook set, Command empty) POST the card to the URL. Returns
+// the hook result including output, exit code, and any error.
+func (s *HookService) ExecuteHook(hook model.PatternHook, card *model.Card, boardName string) *HookResult {
 	// Determine timeout
 	timeout := hook.Timeout
 	if timeout <= 0 {
 		timeout = DefaultHookTimeout
 	}
 
-	// Expand ~ in command path
-	command := expandTilde(hook.Command)
+	if hook.Command == "" && hook.Webhook != "" {
+		return s.executeWebhook(hook, card, boardName, timeout)
+	}
+	return s.executeCommand(hook, card, boardName, timeout)
+}
+
+func (s *HookService) executeCommand(hook model.PatternHook, card *model.Card, boardName string, timeout int) *HookResult {
+	result := &HookResult{
+		HookName: hook.Name,
+	}
+
+	// Substitute capture groups, then expand ~ in command path
+	var groups []string
+	if re, err := regexp.Compile(hook.PatternTitle); err == nil {
+		groups = re.FindStringSubmatch(card.Title)
+	}
+	command := expandTilde(model.ExpandGroupRefs(hook.Command, groups))
+	args := []string{card.ID, boardName}
+	if len(hook.CommandArgs) > 0 {
+		args = make([]string, len(hook.CommandArgs))
+		for i, arg := range hook.CommandArgs {
+			args[i] = model.ExpandGroupRefs(arg, groups)
+		}
+	}
 
 	// Create context with timeout
 	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Second)
 	defer cancel()
 
 	// Create command
-	cmd := exec.CommandContext(ctx, command, cardID, boardName)
+	cmd := exec.CommandContext(ctx, command, args...)
 
 	// Set working directory to project root
 	cmd.Dir = s.projectRoot
@@ -116,16 +192,194 @@ func (s *HookService) ExecuteHook(hook model.PatternHook, cardID, boardName stri
 	return result
 }
 
+// executeWebhook POSTs the card to the hook's URL. The response body (up to
+// maxWebhookResponse bytes) becomes Stdout; a non-2xx status is a failure.
+// ExitCode is 0 on success and -1 otherwise, since HTTP has no exit codes.
+func (s *HookService) executeWebhook(hook model.PatternHook, card *model.Card, boardName string, timeout int) *HookResult {
+	result := &HookResult{
+		HookName: hook.Name,
+		ExitCode: -1,
+	}
+
+	customFields := card.CustomFields
+	if customFields == nil {
+		customFields = map[string]any{}
+	}
+	body, err := json.Marshal(webhookPayload{
+		CardID:       card.ID,
+		Board:        boardName,
+		Title:        card.Title,
+		CustomFields: customFields,
+	})
+	if err != nil {
+		result.Error = fmt.Errorf("failed to encode webhook payload: %w", err)
+		return result
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.Webhook, bytes.NewReader(body))
+	if err != nil {
+		result.Error = fmt.Errorf("invalid webhook request: %w", err)
+		return result
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range hook.WebhookHeaders {
+		req.Header.Set(name, value)
+	}
+
+	start := time.Now()
+	resp, err := http.DefaultClient.Do(req)
+	if err == nil {
+		var respBody []byte
+		respBody, err = io.ReadAll(io.LimitReader(resp.Body, maxWebhookResponse))
+		resp.Body.Close()
+		result.Stdout = strings.TrimSpace(string(respBody))
+	}
+	result.Duration = time.Since(start)
+
+	switch {
+	case ctx.Err() == context.DeadlineExceeded:
+		result.Error = fmt.Errorf("hook timed out after %ds", timeout)
+	case err != nil:
+		result.Error = err
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		result.Error = fmt.Errorf("webhook returned %s", resp.Status)
+	default:
+		result.Success = true
+		result.ExitCode = 0
+	}
+
+	return result
+}
+
 // ExecuteHooks runs all matching hooks sequentially and returns their results.
-func (s *HookService) ExecuteHooks(hooks []model.PatternHook, cardID, boardName string) []*HookResult {
+func (s *HookService) ExecuteHooks(hooks []model.PatternHook, card *model.Card, boardName string) []*HookResult {
 	var results []*HookResult
 	for _, hook := range hooks {
-		result := s.ExecuteHook(hook, cardID, boardName)
+		startedAt := util.NowMillis()
+		result := s.ExecuteHook(hook, card, boardName)
+		metrics.HookExecutionsTotal.WithLabelValues(boardName, hook.Name, strconv.FormatBool(result.Success)).Inc()
+		metrics.HookDurationSeconds.WithLabelValues(boardName, hook.Name).Observe(result.Duration.Seconds())
+		s.recordHistory(boardName, card.ID, startedAt, result)
 		results = append(results, result)
 	}
 	return results
 }
 
+// recordHistory appends a hook result to the hook history, if enabled. Like
+// the audit log, a failed append is reported but doesn't fail the hook.
+func (s *HookService) recordHistory(boardName, cardID string, startedAt int64, result *HookResult) {
+	if s.historyStore == nil {
+		return
+	}
+	entry := model.HookHistoryEntry{
+		Timestamp:  startedAt,
+		HookName:   result.HookName,
+		CardID:     cardID,
+		Success:    result.Success,
+		Stdout:     result.Stdout,
+		DurationMs: result.Duration.Milliseconds(),
+	}
+	if result.Error != nil {
+		entry.Error = result.Error.Error()
+	}
+	if err := s.historyStore.Append(boardName, entry); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to write hook history: %v\n", err)
+	}
+}
+
+// ExecuteHooksAsync runs hooks like ExecuteHooks, but in the background, and
+// returns a task ID immediately. Poll it with PollTask. If then is non-nil it
+// is called with the results before the task is marked done, so anything it
+// does (e.g. applying hook fields to the card) is visible to pollers that see
+// done. Finished tasks are evicted after five minutes.
+func (s *HookService) ExecuteHooksAsync(hooks []model.PatternHook, card *model.Card, boardName string, then func([]*HookResult)) string {
+	s.janitorOnce.Do(func() { go s.evictExpiredTasks() })
+
+	taskID := id.Generate(id.HookTask)
+	task := &hookTask{}
+	s.tasks.Store(taskID, task)
+
+	go func() {
+		results := s.ExecuteHooks(hooks, card, boardName)
+		if then != nil {
+			then(results)
+		}
+		task.mu.Lock()
+		task.done = true
+		task.results = results
+		task.finishedAt = time.Now()
+		task.mu.Unlock()
+	}()
+
+	return taskID
+}
+
+// PollTask reports the state of an async hook task. ok is false if the task
+// is unknown, either because it never existed or because it was evicted.
+// results is nil until done.
+func (s *HookService) PollTask(taskID string) (done bool, results []*HookResult, ok bool) {
+	value, found := s.tasks.Load(taskID)
+	if !found {
+		return false, nil, false
+	}
+	task := value.(*hookTask)
+	task.mu.Lock()
+	defer task.mu.Unlock()
+	return task.done, task.results, true
+}
+
+// Close stops the background eviction of finished tasks.
+func (s *HookService) Close() {
+	s.closeOnce.Do(func() { close(s.stopJanitor) })
+}
+
+// evictExpiredTasks periodically drops tasks that finished more than taskTTL
+// ago. It runs until Close is called.
+func (s *HookService) evictExpiredTasks() {
+	ticker := time.NewTicker(s.sweepEvery)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.stopJanitor:
+			return
+		case now := <-ticker.C:
+			s.tasks.Range(func(key, value any) bool {
+				task := value.(*hookTask)
+				task.mu.Lock()
+				expired := task.done && now.Sub(task.finishedAt) >= s.taskTTL
+				task.mu.Unlock()
+				if expired {
+					s.tasks.Delete(key)
+				}
+				return true
+			})
+		}
+	}
+}
+
+// parseHookFields extracts field assignments from hook output. Each line of
+// the form key=value whose key names a custom field in schemas is returned;
+// all other lines are ignored, so hooks can still print free-form messages.
+// Later lines win when a key repeats.
+func parseHookFields(output string, schemas map[string]model.CustomFieldSchema) map[string]string {
+	fields := make(map[string]string)
+	for _, line := range strings.Split(output, "\n") {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), "=")
+		if !ok {
+			continue
+		}
+		key = strings.TrimSpace(key)
+		if _, known := schemas[key]; !known {
+			continue
+		}
+		fields[key] = strings.TrimSpace(value)
+	}
+	return fields
+}
+
 // expandTilde expands ~ to the user's home directory.
 func expandTilde(path string) string {
 	if strings.HasPrefix(path, "~/") {
@@ -1,12 +1,20 @@
 package service
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"reflect"
 	"runtime"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/amterp/kan/internal/config"
 	"github.com/amterp/kan/internal/model"
+	"github.com/amterp/kan/internal/store"
 )
 
 func TestFindMatchingHooks(t *testing.T) {
@@ -108,7 +116,7 @@ func TestExecuteHook_Success(t *testing.T) {
 		Timeout:      5,
 	}
 
-	result := service.ExecuteHook(hook, "card-123", "main")
+	result := service.ExecuteHook(hook, &model.Card{ID: "card-123"}, "main")
 
 	if !result.Success {
 		t.Errorf("Expected success, got error: %v", result.Error)
@@ -125,6 +133,54 @@ func TestExecuteHook_Success(t *testing.T) {
 	}
 }
 
+func TestExecuteHook_CommandArgsCaptureGroup(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Skipping on Windows")
+	}
+
+	service := NewHookService(t.TempDir())
+	hook := model.PatternHook{
+		Name:         "jira",
+		PatternTitle: `JIRA-(\d+)`,
+		Command:      "echo",
+		CommandArgs:  []string{"{1}", "--key={0}"},
+		Timeout:      5,
+	}
+
+	result := service.ExecuteHook(hook, &model.Card{ID: "card-123", Title: "Fix JIRA-42 crash"}, "main")
+	if !result.Success {
+		t.Fatalf("Expected success, got error: %v", result.Error)
+	}
+	// CommandArgs replace the default card ID and board name arguments.
+	if result.Stdout != "42 --key=JIRA-42" {
+		t.Errorf("Expected stdout '42 --key=JIRA-42', got %q", result.Stdout)
+	}
+}
+
+func TestExecuteHook_CommandArgsNoGroups(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Skipping on Windows")
+	}
+
+	service := NewHookService(t.TempDir())
+	hook := model.PatternHook{
+		Name:         "urgent",
+		PatternTitle: `^URGENT`,
+		Command:      "echo",
+		CommandArgs:  []string{"{0}", "{1}"},
+		Timeout:      5,
+	}
+
+	result := service.ExecuteHook(hook, &model.Card{ID: "card-123", Title: "URGENT: outage"}, "main")
+	if !result.Success {
+		t.Fatalf("Expected success, got error: %v", result.Error)
+	}
+	// Only {0} exists; the unknown reference is passed through untouched.
+	if result.Stdout != "URGENT {1}" {
+		t.Errorf("Expected stdout 'URGENT {1}', got %q", result.Stdout)
+	}
+}
+
 func TestExecuteHook_Failure(t *testing.T) {
 	if runtime.GOOS == "windows" {
 		t.Skip("Skipping on Windows")
@@ -140,7 +196,7 @@ func TestExecuteHook_Failure(t *testing.T) {
 		Timeout:      5,
 	}
 
-	result := service.ExecuteHook(hook, "card-123", "main")
+	result := service.ExecuteHook(hook, &model.Card{ID: "card-123"}, "main")
 
 	if result.Success {
 		t.Error("Expected failure, got success")
@@ -161,7 +217,7 @@ func TestExecuteHook_NotFound(t *testing.T) {
 		Timeout:      5,
 	}
 
-	result := service.ExecuteHook(hook, "card-123", "main")
+	result := service.ExecuteHook(hook, &model.Card{ID: "card-123"}, "main")
 
 	if result.Success {
 		t.Error("Expected failure for nonexistent command")
@@ -183,7 +239,7 @@ func TestExecuteHook_DefaultTimeout(t *testing.T) {
 	}
 
 	// Just verify it runs (default timeout is 30s, echo is instant)
-	result := service.ExecuteHook(hook, "card-123", "main")
+	result := service.ExecuteHook(hook, &model.Card{ID: "card-123"}, "main")
 	if result.Error != nil && runtime.GOOS != "windows" {
 		t.Errorf("Unexpected error: %v", result.Error)
 	}
@@ -202,7 +258,7 @@ func TestExecuteHooks_Multiple(t *testing.T) {
 		{Name: "hook2", PatternTitle: ".*", Command: "echo", Timeout: 5},
 	}
 
-	results := service.ExecuteHooks(hooks, "card-123", "main")
+	results := service.ExecuteHooks(hooks, &model.Card{ID: "card-123"}, "main")
 
 	if len(results) != 2 {
 		t.Errorf("Expected 2 results, got %d", len(results))
@@ -215,16 +271,341 @@ func TestExecuteHooks_Multiple(t *testing.T) {
 	}
 }
 
+func TestExecuteHooks_RecordsHistory(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Skipping on Windows")
+	}
+
+	tmpDir := t.TempDir()
+	historyStore := store.NewHookHistoryStore(config.NewPaths(tmpDir, ""))
+	service := NewHookService(tmpDir)
+	service.SetHistoryStore(historyStore)
+
+	hooks := []model.PatternHook{
+		{Name: "ok", PatternTitle: ".*", Command: "echo", Timeout: 5},
+		{Name: "broken", PatternTitle: ".*", Command: "false", Timeout: 5},
+	}
+	service.ExecuteHooks(hooks, &model.Card{ID: "card-123"}, "main")
+
+	entries, err := historyStore.List("main", 0)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 history entries, got %d", len(entries))
+	}
+
+	byName := map[string]model.HookHistoryEntry{}
+	for _, e := range entries {
+		byName[e.HookName] = e
+		if e.CardID != "card-123" || e.BoardName != "main" || e.Timestamp == 0 {
+			t.Errorf("Unexpected entry: %+v", e)
+		}
+	}
+	if ok := byName["ok"]; !ok.Success || ok.Stdout != "card-123 main" {
+		t.Errorf("Expected successful echo entry, got %+v", ok)
+	}
+	if broken := byName["broken"]; broken.Success || broken.Error == "" {
+		t.Errorf("Expected failed entry with an error, got %+v", broken)
+	}
+}
+
 func TestExecuteHooks_Empty(t *testing.T) {
 	service := NewHookService("/tmp")
 
-	results := service.ExecuteHooks(nil, "card-123", "main")
+	results := service.ExecuteHooks(nil, &model.Card{ID: "card-123"}, "main")
 	if len(results) != 0 {
 		t.Errorf("Expected empty results for nil hooks, got %d", len(results))
 	}
 
-	results = service.ExecuteHooks([]model.PatternHook{}, "card-123", "main")
+	results = service.ExecuteHooks([]model.PatternHook{}, &model.Card{ID: "card-123"}, "main")
 	if len(results) != 0 {
 		t.Errorf("Expected empty results for empty hooks, got %d", len(results))
 	}
 }
+
+func TestExecuteHook_Webhook(t *testing.T) {
+	var gotPayload webhookPayload
+	var gotAuth, gotContentType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		gotContentType = r.Header.Get("Content-Type")
+		if err := json.NewDecoder(r.Body).Decode(&gotPayload); err != nil {
+			t.Errorf("Failed to decode webhook body: %v", err)
+		}
+		w.Write([]byte("synced\n"))
+	}))
+	defer server.Close()
+
+	service := NewHookService(t.TempDir())
+	hook := model.PatternHook{
+		Name:           "notify",
+		PatternTitle:   ".*",
+		Webhook:        server.URL,
+		WebhookHeaders: map[string]string{"Authorization": "Bearer secret"},
+		Timeout:        5,
+	}
+	card := &model.Card{ID: "card-123", Title: "PROJ-1", CustomFields: map[string]any{"type": "bug"}}
+
+	result := service.ExecuteHook(hook, card, "main")
+
+	if !result.Success {
+		t.Fatalf("Expected success, got error: %v", result.Error)
+	}
+	if result.Stdout != "synced" {
+		t.Errorf("Expected stdout 'synced', got %q", result.Stdout)
+	}
+	if gotAuth != "Bearer secret" {
+		t.Errorf("Expected Authorization header to be sent, got %q", gotAuth)
+	}
+	if gotContentType != "application/json" {
+		t.Errorf("Expected JSON content type, got %q", gotContentType)
+	}
+	want := webhookPayload{CardID: "card-123", Board: "main", Title: "PROJ-1", CustomFields: map[string]any{"type": "bug"}}
+	if !reflect.DeepEqual(gotPayload, want) {
+		t.Errorf("Payload = %+v, want %+v", gotPayload, want)
+	}
+}
+
+func TestExecuteHook_WebhookErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "nope", http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	service := NewHookService(t.TempDir())
+	hook := model.PatternHook{Name: "notify", PatternTitle: ".*", Webhook: server.URL, Timeout: 5}
+
+	result := service.ExecuteHook(hook, &model.Card{ID: "card-123"}, "main")
+
+	if result.Success {
+		t.Error("Expected failure for non-2xx response")
+	}
+	if result.Error == nil || !strings.Contains(result.Error.Error(), "401") {
+		t.Errorf("Expected error naming the status, got %v", result.Error)
+	}
+	if result.Stdout != "nope" {
+		t.Errorf("Expected response body captured, got %q", result.Stdout)
+	}
+}
+
+func TestExecuteHook_WebhookTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	service := NewHookService(t.TempDir())
+	hook := model.PatternHook{Name: "slow", PatternTitle: ".*", Webhook: server.URL, Timeout: 1}
+
+	start := time.Now()
+	result := service.ExecuteHook(hook, &model.Card{ID: "card-123"}, "main")
+
+	if result.Success {
+		t.Error("Expected timeout to fail the hook")
+	}
+	if result.Error == nil || !strings.Contains(result.Error.Error(), "timed out") {
+		t.Errorf("Expected timeout error, got %v", result.Error)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Hook took %v; timeout was not enforced", elapsed)
+	}
+}
+
+func TestCardService_Add_HookOutputSetsFields(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Skipping on Windows")
+	}
+
+	tmpDir := t.TempDir()
+	script := filepath.Join(tmpDir, "set-fields.sh")
+	// Unknown keys and free-form lines are ignored; invalid values are skipped.
+	body := "#!/bin/sh\necho 'looking up ticket'\necho priority=high\necho unknown=1\necho type=nonsense\n"
+	if err := os.WriteFile(script, []byte(body), 0755); err != nil {
+		t.Fatalf("Failed to write hook script: %v", err)
+	}
+
+	cardService, _, boardStore := setupCardService()
+	cfg := testBoardConfig("main")
+	cfg.CustomFields["priority"] = model.CustomFieldSchema{Type: "string"}
+	cfg.PatternHooks = []model.PatternHook{{Name: "lookup", PatternTitle: ".*", Command: script, Timeout: 5}}
+	boardStore.addBoard(cfg)
+	cardService.SetHookService(NewHookService(tmpDir))
+
+	card, results, err := cardService.Add(AddCardInput{BoardName: "main", Title: "PROJ-1"})
+	if err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if len(results) != 1 || !results[0].Success {
+		t.Fatalf("Expected one successful hook result, got %+v", results)
+	}
+	if card.CustomFields["priority"] != "high" {
+		t.Errorf("Expected priority 'high' on returned card, got %v", card.CustomFields["priority"])
+	}
+	if want := map[string]string{"priority": "high"}; !reflect.DeepEqual(results[0].FieldsSet, want) {
+		t.Errorf("FieldsSet = %v, want %v", results[0].FieldsSet, want)
+	}
+	if !results[0].CardUpdated {
+		t.Error("Expected CardUpdated to be true")
+	}
+
+	stored, err := cardService.Get("main", card.ID)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if stored.CustomFields["priority"] != "high" {
+		t.Errorf("Expected priority 'high' to be persisted, got %v", stored.CustomFields["priority"])
+	}
+	if _, ok := stored.CustomFields["type"]; ok {
+		t.Error("Invalid enum value from hook output should be skipped")
+	}
+}
+
+func TestCardService_HookFieldChanges(t *testing.T) {
+	cardService, _, _ := setupCardService()
+	cfg := testBoardConfig("main")
+	cfg.CustomFields["priority"] = model.CustomFieldSchema{Type: "string"}
+	card := &model.Card{ID: "card1", CustomFields: map[string]any{"priority": "low"}}
+
+	result := &HookResult{Success: true, Stdout: "checking\npriority=high\ntype=nonsense\nunknown=1"}
+	changes := cardService.HookFieldChanges(card, cfg, result)
+	if want := map[string]string{"priority": "high"}; !reflect.DeepEqual(changes, want) {
+		t.Errorf("HookFieldChanges = %v, want %v", changes, want)
+	}
+	if card.CustomFields["priority"] != "low" {
+		t.Errorf("Card was modified: priority = %v", card.CustomFields["priority"])
+	}
+
+	result.Success = false
+	if changes := cardService.HookFieldChanges(card, cfg, result); changes != nil {
+		t.Errorf("Expected no changes from a failed hook, got %v", changes)
+	}
+}
+
+func TestCardService_AddWithAsyncHooks(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.Write([]byte("type=bug"))
+	}))
+	defer server.Close()
+
+	cardService, cardStore, boardStore := setupCardService()
+	hookService := NewHookService(t.TempDir())
+	defer hookService.Close()
+	cardService.SetHookService(hookService)
+	cfg := testBoardConfig("main")
+	cfg.PatternHooks = []model.PatternHook{
+		{Name: "notify", PatternTitle: ".*", Webhook: server.URL, Timeout: 5},
+	}
+	boardStore.addBoard(cfg)
+
+	updates := make(chan *model.Card, 1)
+	card, taskID, err := cardService.AddWithAsyncHooks(AddCardInput{BoardName: "main", Title: "Slow hook"}, func(c *model.Card) {
+		updates <- c
+	})
+	if err != nil {
+		t.Fatalf("AddWithAsyncHooks failed: %v", err)
+	}
+	if taskID == "" {
+		t.Fatal("Expected a task ID when a hook matches")
+	}
+
+	// The hook is still blocked on release, yet the card already exists.
+	if _, err := cardStore.Get("main", card.ID); err != nil {
+		t.Fatalf("Expected card to be persisted before hooks finish: %v", err)
+	}
+	if done, _, ok := hookService.PollTask(taskID); !ok || done {
+		t.Fatalf("PollTask before release = done %v, ok %v; want pending", done, ok)
+	}
+
+	// Hold the card lock as an in-flight edit would: the hook's fields must
+	// wait for it and then land on top of the edit.
+	unlock := cardService.lockCard("main", card.ID)
+	close(release)
+	time.Sleep(50 * time.Millisecond)
+	if done, _, _ := hookService.PollTask(taskID); done {
+		t.Fatal("Expected hook fields to wait for the card lock")
+	}
+	stored, _ := cardStore.Get("main", card.ID)
+	edited := *stored
+	edited.Title = "Edited meanwhile"
+	if err := cardStore.Update("main", &edited); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	unlock()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		done, results, ok := hookService.PollTask(taskID)
+		if !ok {
+			t.Fatal("Task disappeared before completion")
+		}
+		if done {
+			if len(results) != 1 || !results[0].Success || !results[0].CardUpdated {
+				t.Fatalf("Unexpected results: %+v", results)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Timed out waiting for async hooks")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	stored, _ = cardStore.Get("main", card.ID)
+	if stored.Title != "Edited meanwhile" || stored.CustomFields["type"] != "bug" {
+		t.Errorf("Expected the edit and the hook's field to both survive, got title %q fields %v", stored.Title, stored.CustomFields)
+	}
+	select {
+	case updated := <-updates:
+		if updated.ID != card.ID || updated.CustomFields["type"] != "bug" {
+			t.Errorf("Unexpected card passed to onUpdate: %+v", updated)
+		}
+	default:
+		t.Error("Expected onUpdate to be called after the hook set a field")
+	}
+}
+
+func TestCardService_AddWithAsyncHooks_NoMatchingHooks(t *testing.T) {
+	cardService, _, boardStore := setupCardService()
+	cardService.SetHookService(NewHookService(t.TempDir()))
+	boardStore.addBoard(testBoardConfig("main"))
+
+	_, taskID, err := cardService.AddWithAsyncHooks(AddCardInput{BoardName: "main", Title: "No hooks"}, nil)
+	if err != nil {
+		t.Fatalf("AddWithAsyncHooks failed: %v", err)
+	}
+	if taskID != "" {
+		t.Errorf("Expected no task ID without matching hooks, got %q", taskID)
+	}
+}
+
+func TestHookService_EvictsFinishedTasks(t *testing.T) {
+	service := NewHookService(t.TempDir())
+	service.taskTTL = 20 * time.Millisecond
+	service.sweepEvery = 10 * time.Millisecond
+	defer service.Close()
+
+	if _, _, ok := service.PollTask("e_unknown"); ok {
+		t.Error("Expected unknown task to be reported as not found")
+	}
+
+	taskID := service.ExecuteHooksAsync(nil, &model.Card{ID: "card-123"}, "main", nil)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if _, _, ok := service.PollTask(taskID); !ok {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Timed out waiting for finished task to be evicted")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
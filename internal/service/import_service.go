@@ -0,0 +1,249 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	kanerr "github.com/amterp/kan/internal/errors"
+	"github.com/amterp/kan/internal/id"
+	"github.com/amterp/kan/internal/model"
+	"github.com/amterp/kan/internal/util"
+)
+
+// TrelloLabelsField is the custom field Trello label names are imported into.
+const TrelloLabelsField = "labels"
+
+// trelloCreator is recorded as the creator of every imported card.
+const trelloCreator = "trello-import"
+
+// trelloBoard is the subset of a Trello board export
+// (GET /1/boards/{id}?cards=all&lists=all) that Kan imports.
+type trelloBoard struct {
+	Name  string       `json:"name"`
+	Lists []trelloList `json:"lists"`
+	Cards []trelloCard `json:"cards"`
+}
+
+type trelloList struct {
+	ID     string  `json:"id"`
+	Name   string  `json:"name"`
+	Closed bool    `json:"closed"`
+	Pos    float64 `json:"pos"`
+}
+
+type trelloCard struct {
+	ID               string        `json:"id"`
+	Name             string        `json:"name"`
+	Desc             string        `json:"desc"`
+	IDList           string        `json:"idList"`
+	Closed           bool          `json:"closed"`
+	Pos              float64       `json:"pos"`
+	Due              string        `json:"due"`
+	DateLastActivity string        `json:"dateLastActivity"`
+	Labels           []trelloLabel `json:"labels"`
+}
+
+type trelloLabel struct {
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+// TrelloBoardName returns a Kan board name derived from a Trello export's
+// board name, or "" if the export can't be read. Callers use it when no
+// target name was given.
+func TrelloBoardName(data []byte) string {
+	var doc trelloBoard
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return ""
+	}
+	return strings.Join(util.SlugWords(doc.Name), "-")
+}
+
+// ImportFromTrello creates a new board named targetBoardName from a Trello
+// board export. Open lists become columns (in Trello order, with names
+// slugified to Kan's column format), and cards keep their order within each
+// list. Archived cards, and cards in archived lists, are imported as archived
+// cards. Label names go into a free-set "labels" custom field, which is
+// created when any card has labels; color-only labels use their color name.
+func (s *BoardService) ImportFromTrello(data []byte, targetBoardName string) error {
+	var doc trelloBoard
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return kanerr.InvalidField("import", fmt.Sprintf("invalid Trello export: %v", err))
+	}
+	if targetBoardName == "" {
+		return kanerr.InvalidField("name", "cannot be empty")
+	}
+	if s.boardStore.Exists(targetBoardName) {
+		return kanerr.BoardAlreadyExists(targetBoardName)
+	}
+
+	lists := make([]trelloList, 0, len(doc.Lists))
+	for _, list := range doc.Lists {
+		if !list.Closed {
+			lists = append(lists, list)
+		}
+	}
+	if len(lists) == 0 {
+		return kanerr.InvalidField("import", "Trello export has no open lists")
+	}
+	sort.SliceStable(lists, func(i, j int) bool { return lists[i].Pos < lists[j].Pos })
+
+	cfg := &model.BoardConfig{
+		ID:           id.Generate(id.Board),
+		Name:         targetBoardName,
+		CustomFields: model.DefaultCustomFields(),
+		CardDisplay:  model.DefaultCardDisplay(),
+	}
+	columnFor := make(map[string]string) // Trello list ID -> column name
+	for _, list := range lists {
+		name := uniqueColumnName(cfg, list.Name)
+		cfg.Columns = append(cfg.Columns, model.Column{Name: name, Color: model.NextColumnColor(len(cfg.Columns))})
+		columnFor[list.ID] = name
+	}
+	cfg.DefaultColumn = cfg.Columns[0].Name
+
+	cards := make([]trelloCard, len(doc.Cards))
+	copy(cards, doc.Cards)
+	sort.SliceStable(cards, func(i, j int) bool { return cards[i].Pos < cards[j].Pos })
+
+	for _, card := range cards {
+		if len(card.Labels) > 0 {
+			if _, ok := cfg.CustomFields[TrelloLabelsField]; !ok {
+				cfg.CustomFields[TrelloLabelsField] = model.CustomFieldSchema{
+					Type:        model.FieldTypeFreeSet,
+					Description: "Labels imported from Trello",
+				}
+			}
+			break
+		}
+	}
+
+	if err := s.boardStore.Create(cfg); err != nil {
+		return err
+	}
+
+	// Space each column's positions evenly, as migrations do for existing
+	// orderings.
+	openCounts := make(map[string]int)
+	for _, tc := range cards {
+		if column, ok := columnFor[tc.IDList]; ok && !tc.Closed && strings.TrimSpace(tc.Name) != "" {
+			openCounts[column]++
+		}
+	}
+	positions := make(map[string][]string)
+	for column, n := range openCounts {
+		positions[column] = util.PositionInitial(n)
+	}
+
+	aliases := NewAliasService(s.cardStore, s.boardStore)
+	now := util.NowMillis()
+	for _, tc := range cards {
+		if strings.TrimSpace(tc.Name) == "" {
+			continue
+		}
+		column, inOpenList := columnFor[tc.IDList]
+		if !inOpenList {
+			column = cfg.DefaultColumn
+		}
+
+		alias, err := aliases.GenerateAlias(targetBoardName, tc.Name, "")
+		if err != nil {
+			return err
+		}
+		created := trelloIDMillis(tc.ID, now)
+		updated := parseTrelloTime(tc.DateLastActivity, created)
+		card := &model.Card{
+			ID:              id.Generate(id.Card),
+			Alias:           alias,
+			Title:           strings.TrimSpace(tc.Name),
+			Description:     tc.Desc,
+			Creator:         trelloCreator,
+			DueAtMillis:     parseTrelloTime(tc.Due, 0),
+			CreatedAtMillis: created,
+			UpdatedAtMillis: updated,
+			History: []model.HistoryEntry{
+				{Field: "column", Value: column, At: created},
+			},
+		}
+		if labels := trelloLabelNames(tc.Labels); len(labels) > 0 {
+			card.CustomFields = map[string]any{TrelloLabelsField: labels}
+		}
+
+		if tc.Closed || !inOpenList {
+			card.Archived = true
+			card.ArchivedAtMillis = updated
+			card.LastColumn = column
+		} else {
+			card.Column = column
+			card.Position = positions[column][0]
+			positions[column] = positions[column][1:]
+		}
+
+		if err := s.cardStore.Create(targetBoardName, card); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// uniqueColumnName slugifies a Trello list name into a valid column name that
+// isn't already on the board, suffixing -2, -3, ... on collisions.
+func uniqueColumnName(cfg *model.BoardConfig, listName string) string {
+	base := strings.Join(util.SlugWords(listName), "-")
+	if base == "" {
+		base = "list"
+	}
+	name := base
+	for n := 2; cfg.HasColumn(name); n++ {
+		name = base + "-" + strconv.Itoa(n)
+	}
+	return name
+}
+
+// trelloLabelNames returns a card's label names, de-duplicated, falling back
+// to the color for unnamed labels.
+func trelloLabelNames(labels []trelloLabel) []string {
+	var names []string
+	for _, label := range labels {
+		name := strings.TrimSpace(label.Name)
+		if name == "" {
+			name = label.Color
+		}
+		if name != "" && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// trelloIDMillis recovers a creation time from a Trello object ID, whose first
+// 8 hex digits are a Unix timestamp in seconds. Returns fallback if the ID
+// doesn't have one.
+func trelloIDMillis(trelloID string, fallback int64) int64 {
+	if len(trelloID) < 8 {
+		return fallback
+	}
+	secs, err := strconv.ParseInt(trelloID[:8], 16, 64)
+	if err != nil || secs == 0 {
+		return fallback
+	}
+	return secs * 1000
+}
+
+// parseTrelloTime parses one of Trello's RFC 3339 timestamps into Unix
+// milliseconds, returning fallback if it is empty or malformed.
+func parseTrelloTime(s string, fallback int64) int64 {
+	if s == "" {
+		return fallback
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return fallback
+	}
+	return t.UnixMilli()
+}
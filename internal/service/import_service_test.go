@@ -0,0 +1,137 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	kanerr "github.com/amterp/kan/internal/errors"
+	"github.com/amterp/kan/internal/model"
+)
+
+func readTrelloFixture(t *testing.T) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", "trello", "board.json"))
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+	return data
+}
+
+func TestTrelloBoardName(t *testing.T) {
+	if got := TrelloBoardName(readTrelloFixture(t)); got != "website-relaunch" {
+		t.Errorf("TrelloBoardName() = %q, want 'website-relaunch'", got)
+	}
+	if got := TrelloBoardName([]byte("not json")); got != "" {
+		t.Errorf("TrelloBoardName(invalid) = %q, want empty", got)
+	}
+}
+
+func TestBoardService_ImportFromTrello(t *testing.T) {
+	boardService, cardService := setupExportTest(t)
+
+	if err := boardService.ImportFromTrello(readTrelloFixture(t), "web"); err != nil {
+		t.Fatalf("ImportFromTrello failed: %v", err)
+	}
+
+	// Open lists become columns in Trello order; the archived list is dropped
+	cfg, err := boardService.Get("web")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	var columns []string
+	for _, col := range cfg.Columns {
+		columns = append(columns, col.Name)
+	}
+	if want := []string{"to-do", "in-progress", "done"}; !slices.Equal(columns, want) {
+		t.Errorf("Columns = %v, want %v", columns, want)
+	}
+	if cfg.DefaultColumn != "to-do" {
+		t.Errorf("DefaultColumn = %q, want 'to-do'", cfg.DefaultColumn)
+	}
+	if cfg.CustomFields[TrelloLabelsField].Type != model.FieldTypeFreeSet {
+		t.Errorf("Expected a free-set labels field, got %+v", cfg.CustomFields[TrelloLabelsField])
+	}
+
+	// Cards keep their order within each list
+	todo, err := cardService.List("web", "to-do")
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if got := searchTitles(todo); !slices.Equal(got, []string{"Design new homepage", "Write copy for landing page"}) {
+		t.Errorf("to-do cards = %v", got)
+	}
+
+	design := todo[0]
+	if design.Description != "Mockups in Figma first." {
+		t.Errorf("Description = %q", design.Description)
+	}
+	if labels, _ := design.CustomFields[TrelloLabelsField].([]any); len(labels) != 2 || labels[0] != "design" || labels[1] != "red" {
+		t.Errorf("labels = %v, want [design red]", design.CustomFields[TrelloLabelsField])
+	}
+	if want := time.Date(2024, 2, 1, 17, 0, 0, 0, time.UTC).UnixMilli(); design.DueAtMillis != want {
+		t.Errorf("DueAtMillis = %d, want %d", design.DueAtMillis, want)
+	}
+	if design.CreatedAtMillis != 0x65a1b2c3*1000 {
+		t.Errorf("CreatedAtMillis = %d, want the Trello ID timestamp", design.CreatedAtMillis)
+	}
+	if design.Alias == "" || design.Creator != trelloCreator {
+		t.Errorf("Expected generated alias and import creator, got alias %q creator %q", design.Alias, design.Creator)
+	}
+	if _, ok := todo[1].CustomFields[TrelloLabelsField]; ok {
+		t.Error("Unlabeled card should not get a labels value")
+	}
+
+	// Closed cards and cards in archived lists are archived
+	all, err := cardService.ListIncludingArchived("web", "")
+	if err != nil {
+		t.Fatalf("ListIncludingArchived failed: %v", err)
+	}
+	archived := map[string]*model.Card{}
+	for _, card := range all {
+		if card.Archived {
+			archived[card.Title] = card
+		}
+	}
+	if len(all) != 5 || len(archived) != 2 {
+		t.Fatalf("Expected 5 cards with 2 archived, got %d with %d archived", len(all), len(archived))
+	}
+	font := archived["Pick a font"]
+	if font == nil || font.LastColumn != "done" || font.Column != "" {
+		t.Errorf("Closed card should be archived from 'done', got %+v", font)
+	}
+	if want := time.Date(2024, 1, 10, 8, 0, 0, 0, time.UTC).UnixMilli(); font != nil && font.ArchivedAtMillis != want {
+		t.Errorf("ArchivedAtMillis = %d, want %d", font.ArchivedAtMillis, want)
+	}
+	if mascot := archived["Animated mascot"]; mascot == nil || mascot.LastColumn != "to-do" {
+		t.Errorf("Card in archived list should be archived to the default column, got %+v", mascot)
+	}
+}
+
+func TestBoardService_ImportFromTrello_Errors(t *testing.T) {
+	boardService, _ := setupExportTest(t)
+
+	if err := boardService.ImportFromTrello(readTrelloFixture(t), "main"); !kanerr.IsCode(err, kanerr.CodeBoardAlreadyExists) {
+		t.Errorf("Expected already-exists error, got %v", err)
+	}
+
+	cases := []struct {
+		name string
+		data string
+	}{
+		{name: "malformed JSON", data: `{"lists":`},
+		{name: "no open lists", data: `{"lists": [{"id": "l1", "name": "Old", "closed": true}], "cards": []}`},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := boardService.ImportFromTrello([]byte(tc.data), "other"); !kanerr.IsCode(err, kanerr.CodeInvalidField) {
+				t.Errorf("Expected validation error, got %v", err)
+			}
+		})
+	}
+	if boardService.Exists("other") {
+		t.Error("Failed import should not create the board")
+	}
+}
@@ -64,7 +64,8 @@ func writeCardMap(path string, data map[string]any) error {
 	return os.WriteFile(path, output, 0644)
 }
 
-// listBoards returns the names of all boards in the given paths.
+// listBoards returns the names of all boards in the given paths, archived
+// ones included.
 func listBoards(paths *config.Paths) ([]string, error) {
 	boardsRoot := paths.BoardsRoot()
 
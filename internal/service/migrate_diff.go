@@ -0,0 +1,199 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/amterp/kan/internal/config"
+)
+
+// Diff previews the plan as unified-diff-style text: for each board that would
+// change, the lines added to or removed from its config.toml, then the keys
+// added to, removed from or changed on each card file. Lines start with "+"
+// or "-" and each file opens with a "--- path" / "+++ path" header, paths being
+// relative to the Kan data directory. The global config isn't included.
+//
+// The diff is exact rather than predicted: the plan's boards are migrated in a
+// scratch copy of the project config and boards, which is then compared with
+// the original. Nothing in the project is modified. An empty string means no
+// board would change.
+func (s *MigrateService) Diff(plan *MigrationPlan) (string, error) {
+	if !plan.hasBoardChanges() {
+		return "", nil
+	}
+
+	scratchRoot, err := os.MkdirTemp("", "kan-migrate-diff-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create scratch directory: %w", err)
+	}
+	defer os.RemoveAll(scratchRoot)
+
+	scratchPaths := config.NewPaths(scratchRoot, "")
+	if err := copyMigratedData(s.paths, scratchPaths); err != nil {
+		return "", fmt.Errorf("failed to copy data for diff: %w", err)
+	}
+	scratch := NewQuietMigrateService(scratchPaths)
+
+	var out strings.Builder
+	for i := range plan.Boards {
+		board := &plan.Boards[i]
+		if !board.hasChanges() {
+			continue
+		}
+
+		scratchBoard, err := scratch.planBoardMigration(board.BoardName)
+		if err != nil {
+			return "", err
+		}
+		if err := scratch.executeBoard(scratchBoard, false, io.Discard); err != nil {
+			return "", err
+		}
+
+		if err := s.diffFile(&out, board.ConfigPath, scratchPaths, diffLines); err != nil {
+			return "", err
+		}
+		for _, card := range board.Cards {
+			if err := s.diffFile(&out, card.Path, scratchPaths, diffJSONKeys); err != nil {
+				return "", err
+			}
+		}
+	}
+	return out.String(), nil
+}
+
+// copyMigratedData copies the parts of the data directory that a migration
+// changes, the project config and the boards, from src to dst. Everything
+// else (snapshots, logs) can be large and is left out.
+func copyMigratedData(src, dst *config.Paths) error {
+	if err := os.MkdirAll(dst.KanRoot(), 0755); err != nil {
+		return err
+	}
+	for _, path := range []struct{ from, to string }{
+		{src.ProjectConfigPath(), dst.ProjectConfigPath()},
+		{src.BoardsRoot(), dst.BoardsRoot()},
+	} {
+		if _, err := os.Stat(path.from); errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err := copyDir(path.from, path.to); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// diffFile compares a file with its counterpart in the scratch copy and
+// writes the changes, under a header, if there are any.
+func (s *MigrateService) diffFile(out *strings.Builder, path string, scratch *config.Paths, diff func(before, after []byte) ([]string, error)) error {
+	rel, err := filepath.Rel(s.paths.KanRoot(), path)
+	if err != nil {
+		return err
+	}
+	before, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	after, err := os.ReadFile(filepath.Join(scratch.KanRoot(), rel))
+	if err != nil {
+		return err
+	}
+
+	changes, err := diff(before, after)
+	if err != nil {
+		return fmt.Errorf("failed to diff %s: %w", rel, err)
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	rel = filepath.ToSlash(rel)
+	fmt.Fprintf(out, "--- %s\n+++ %s\n", rel, rel)
+	for _, line := range changes {
+		out.WriteString(line)
+		out.WriteByte('\n')
+	}
+	return nil
+}
+
+// diffLines returns the lines removed from before ("-") and added in after
+// ("+"), in file order, using a longest-common-subsequence alignment.
+func diffLines(before, after []byte) ([]string, error) {
+	a := strings.Split(strings.TrimRight(string(before), "\n"), "\n")
+	b := strings.Split(strings.TrimRight(string(after), "\n"), "\n")
+
+	// lcs[i][j] is the LCS length of a[i:] and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var changes []string
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			changes = append(changes, "-"+a[i])
+			i++
+		default:
+			changes = append(changes, "+"+b[j])
+			j++
+		}
+	}
+	return changes, nil
+}
+
+// diffJSONKeys returns the top-level keys removed from before ("-"), added in
+// after ("+"), or changed between them (both), in key order. Values are shown
+// as compact JSON.
+func diffJSONKeys(before, after []byte) ([]string, error) {
+	var a, b map[string]any
+	if err := json.Unmarshal(before, &a); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(after, &b); err != nil {
+		return nil, err
+	}
+
+	keys := sortedMapKeys(a)
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var changes []string
+	for _, key := range keys {
+		oldValue, hadOld := a[key]
+		newValue, hasNew := b[key]
+		oldJSON, _ := json.Marshal(oldValue)
+		newJSON, _ := json.Marshal(newValue)
+		if hadOld && hasNew && string(oldJSON) == string(newJSON) {
+			continue
+		}
+		if hadOld {
+			changes = append(changes, fmt.Sprintf("-%s: %s", key, oldJSON))
+		}
+		if hasNew {
+			changes = append(changes, fmt.Sprintf("+%s: %s", key, newJSON))
+		}
+	}
+	return changes, nil
+}
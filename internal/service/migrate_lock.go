@@ -0,0 +1,102 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/amterp/kan/internal/config"
+)
+
+// MigrateLockFile marks a board as being migrated. It lives in the board's
+// directory for the duration of a migration.
+const MigrateLockFile = ".migrating"
+
+// ErrMigrationInProgress is returned when another migration holds a board's
+// lock. A lock left behind by a crashed migration can be removed with
+// `kan migrate --break-lock`.
+var ErrMigrationInProgress = errors.New("migration already in progress")
+
+// acquireMigrateLock creates the board's migration lockfile, failing with
+// ErrMigrationInProgress if it already exists. The returned function deletes
+// it.
+func acquireMigrateLock(boardName string, paths *config.Paths) (unlock func(), err error) {
+	path := filepath.Join(paths.BoardDir(boardName), MigrateLockFile)
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	if errors.Is(err, os.ErrExist) {
+		return nil, fmt.Errorf("board %q: %w", boardName, ErrMigrationInProgress)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock board %q for migration: %w", boardName, err)
+	}
+	fmt.Fprintf(f, "%d\n", os.Getpid())
+	f.Close()
+	return func() { _ = os.Remove(path) }, nil
+}
+
+// lockBoards takes the migration lock of every board the plan changes, so
+// that two migrations never write to the same board. Dry runs take no locks.
+// If any lock is held elsewhere, the ones already taken are released.
+//
+// Once locked, each board is planned again and its entry in plan replaced:
+// another migration may have finished with the board between Plan and now,
+// and its steps must not be re-run on data that's already migrated.
+func (s *MigrateService) lockBoards(plan *MigrationPlan, dryRun bool) (unlock func(), err error) {
+	var unlocks []func()
+	unlockAll := func() {
+		for _, u := range unlocks {
+			u()
+		}
+	}
+	if dryRun {
+		return unlockAll, nil
+	}
+	for i := range plan.Boards {
+		if !plan.Boards[i].hasChanges() {
+			continue
+		}
+		u, err := acquireMigrateLock(plan.Boards[i].BoardName, s.paths)
+		if err != nil {
+			unlockAll()
+			return nil, err
+		}
+		unlocks = append(unlocks, u)
+
+		fresh, err := s.planBoardMigration(plan.Boards[i].BoardName)
+		if err != nil {
+			unlockAll()
+			return nil, fmt.Errorf("failed to plan migration for board %q: %w", plan.Boards[i].BoardName, err)
+		}
+		plan.Boards[i] = *fresh
+	}
+	return unlockAll, nil
+}
+
+// BreakLocks removes migration lockfiles left behind by migrations that
+// crashed or were killed, returning the boards that had one. Only use it
+// when no other migration is running.
+func (s *MigrateService) BreakLocks() ([]string, error) {
+	entries, err := os.ReadDir(s.paths.BoardsRoot())
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var broken []string
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		err := os.Remove(filepath.Join(s.paths.BoardDir(entry.Name()), MigrateLockFile))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return broken, fmt.Errorf("failed to remove migration lock for board %q: %w", entry.Name(), err)
+		}
+		broken = append(broken, entry.Name())
+	}
+	return broken, nil
+}
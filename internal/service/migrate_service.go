@@ -1,15 +1,22 @@
 package service
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/BurntSushi/toml"
 	"github.com/amterp/kan/internal/config"
+	kanerr "github.com/amterp/kan/internal/errors"
+	"github.com/amterp/kan/internal/model"
 	"github.com/amterp/kan/internal/util"
 	"github.com/amterp/kan/internal/version"
 )
@@ -21,18 +28,27 @@ import (
 type MigrateService struct {
 	paths  *config.Paths
 	output io.Writer
+	backup *BackupService
 }
 
 // NewMigrateService creates a new migration service that writes progress
 // to stdout.
 func NewMigrateService(paths *config.Paths) *MigrateService {
-	return &MigrateService{paths: paths, output: os.Stdout}
+	return &MigrateService{paths: paths, output: os.Stdout, backup: NewBackupService()}
 }
 
 // NewQuietMigrateService creates a migration service that suppresses
 // progress output. Used by auto-migration, which prints its own summary.
 func NewQuietMigrateService(paths *config.Paths) *MigrateService {
-	return &MigrateService{paths: paths, output: io.Discard}
+	return &MigrateService{paths: paths, output: io.Discard, backup: NewBackupService()}
+}
+
+// MigrateResult describes a completed migration.
+type MigrateResult struct {
+	// SnapshotID identifies the snapshot taken before any project files were
+	// changed; pass it to Rollback to undo the migration. Empty for dry runs
+	// and for migrations that only touch the global config.
+	SnapshotID string
 }
 
 // MigrationPlan describes what changes would be made during migration.
@@ -111,8 +127,159 @@ func (s *MigrateService) Plan() (*MigrationPlan, error) {
 }
 
 // Execute performs the migration. Progress output is written to s.output.
-func (s *MigrateService) Execute(plan *MigrationPlan, dryRun bool) error {
+// Before project files are modified, the Kan data directory is snapshotted
+// (see BackupService); the snapshot ID is returned in the result. The global
+// config lives outside the project and is not included in the snapshot. If
+// a step fails after the snapshot was taken, the result is still returned
+// alongside the error so callers can offer a rollback.
+//
+// Each board being migrated is locked first; if another migration holds a
+// lock, nothing is changed and ErrMigrationInProgress is returned.
+func (s *MigrateService) Execute(plan *MigrationPlan, dryRun bool) (*MigrateResult, error) {
+	unlock, err := s.lockBoards(plan, dryRun)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	result, err := s.prepareExecute(plan, dryRun)
+	if err != nil {
+		return result, err
+	}
+
+	for i := range plan.Boards {
+		if err := s.executeBoard(&plan.Boards[i], dryRun, s.output); err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// DefaultMigrateConcurrency is the number of boards ExecuteParallel migrates
+// at once when no concurrency is given.
+const DefaultMigrateConcurrency = 4
+
+// MigrateProgress reports on one board during ExecuteParallel. Each board
+// with changes produces a start event (Done false) followed by exactly one
+// finish event (Done true, with Error set if the board failed).
+type MigrateProgress struct {
+	BoardName string
+	Done      bool
+	Error     error
+}
+
+// ExecuteParallel performs the migration like Execute, but migrates up to
+// concurrency boards at once (DefaultMigrateConcurrency if <= 0). Each board
+// is handled by a single worker, so writes to one board never interleave.
+// The snapshot and global config migration still happen first, serially.
+//
+// Per-board output is buffered and written to s.output in plan order, so it
+// reads the same as Execute's. If progress is non-nil, events are sent on it
+// and it is closed before returning; the caller must keep draining it.
+// After the first failure no new boards are started, boards already in
+// flight finish, and the first error is returned with the result.
+func (s *MigrateService) ExecuteParallel(plan *MigrationPlan, dryRun bool, concurrency int, progress chan<- MigrateProgress) (*MigrateResult, error) {
+	if progress != nil {
+		defer close(progress)
+	}
+	if concurrency <= 0 {
+		concurrency = DefaultMigrateConcurrency
+	}
+
+	unlock, err := s.lockBoards(plan, dryRun)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	result, err := s.prepareExecute(plan, dryRun)
+	if err != nil {
+		return result, err
+	}
+
+	var pending []int
+	for i := range plan.Boards {
+		if plan.Boards[i].hasChanges() {
+			pending = append(pending, i)
+		}
+	}
+
+	var (
+		mu       sync.Mutex
+		firstErr error
+		outputs  = make([]*bytes.Buffer, len(pending))
+		finished = make([]bool, len(pending))
+		flushed  int
+	)
+	// flush writes completed boards' output in plan order. Callers hold mu.
+	flush := func() {
+		for flushed < len(pending) && finished[flushed] {
+			_, _ = s.output.Write(outputs[flushed].Bytes())
+			flushed++
+		}
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range min(concurrency, len(pending)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				board := &plan.Boards[pending[job]]
+				if progress != nil {
+					progress <- MigrateProgress{BoardName: board.BoardName}
+				}
+
+				var buf bytes.Buffer
+				err := s.executeBoard(board, dryRun, &buf)
+
+				mu.Lock()
+				outputs[job] = &buf
+				finished[job] = true
+				if err != nil && firstErr == nil {
+					firstErr = err
+				}
+				flush()
+				mu.Unlock()
+
+				if progress != nil {
+					progress <- MigrateProgress{BoardName: board.BoardName, Done: true, Error: err}
+				}
+			}
+		}()
+	}
+
+	for job := range pending {
+		mu.Lock()
+		failed := firstErr != nil
+		mu.Unlock()
+		if failed {
+			break
+		}
+		jobs <- job
+	}
+	close(jobs)
+	wg.Wait()
+
+	return result, firstErr
+}
+
+// prepareExecute takes the pre-migration snapshot (when project files will
+// change) and migrates the global config. Both must happen before any board
+// is touched.
+func (s *MigrateService) prepareExecute(plan *MigrationPlan, dryRun bool) (*MigrateResult, error) {
 	w := s.output
+	result := &MigrateResult{}
+
+	if !dryRun && plan.hasBoardChanges() {
+		snapshotID, err := s.backup.Snapshot(s.paths)
+		if err != nil {
+			return nil, fmt.Errorf("failed to snapshot before migrating: %w", err)
+		}
+		result.SnapshotID = snapshotID
+	}
 
 	// Migrate global config
 	if plan.GlobalConfig != nil && plan.GlobalConfig.NeedsMigration {
@@ -126,64 +293,73 @@ func (s *MigrateService) Execute(plan *MigrationPlan, dryRun bool) error {
 			fmt.Fprintf(w, "Would migrate global config: %s kan_schema = %q\n", verb, plan.GlobalConfig.ToSchema)
 		} else {
 			if err := s.migrateGlobalConfig(plan.GlobalConfig); err != nil {
-				return fmt.Errorf("failed to migrate global config: %w", err)
+				return result, fmt.Errorf("failed to migrate global config: %w", err)
 			}
 			fmt.Fprintf(w, "Migrated global config\n")
 		}
 	}
 
-	// Migrate boards
-	for _, board := range plan.Boards {
-		cardsToMigrate := 0
-		for _, card := range board.Cards {
-			if card.FromVersion != card.ToVersion || card.RemoveColumn {
-				cardsToMigrate++
-			}
+	return result, nil
+}
+
+// executeBoard migrates (or, for dry runs, describes) one board's config and
+// cards, writing progress to w.
+func (s *MigrateService) executeBoard(board *BoardMigration, dryRun bool, w io.Writer) error {
+	cardsToMigrate := board.cardsToMigrate()
+
+	if dryRun {
+		if board.NeedsMigration {
+			fmt.Fprintf(w, "Would migrate board %q config: add kan_schema = %q\n", board.BoardName, board.ToSchema)
 		}
+		if cardsToMigrate > 0 {
+			fmt.Fprintf(w, "Would migrate %d cards in board %q to _v=%d\n",
+				cardsToMigrate, board.BoardName, version.CurrentCardVersion)
+		}
+		return nil
+	}
 
-		if dryRun {
-			if board.NeedsMigration {
-				fmt.Fprintf(w, "Would migrate board %q config: add kan_schema = %q\n", board.BoardName, board.ToSchema)
-			}
-			if cardsToMigrate > 0 {
-				fmt.Fprintf(w, "Would migrate %d cards in board %q to _v=%d\n",
-					cardsToMigrate, board.BoardName, version.CurrentCardVersion)
-			}
-		} else {
-			if board.NeedsMigration {
-				if err := s.migrateBoardConfig(&board); err != nil {
-					return fmt.Errorf("failed to migrate board %q config: %w", board.BoardName, err)
-				}
-			}
+	if board.NeedsMigration {
+		if err := s.migrateBoardConfig(board); err != nil {
+			return fmt.Errorf("failed to migrate board %q config: %w", board.BoardName, err)
+		}
+	}
 
-			for _, card := range board.Cards {
-				if card.FromVersion == card.ToVersion && !card.RemoveColumn {
-					continue
-				}
-				if err := s.migrateCard(&card); err != nil {
-					return fmt.Errorf("failed to migrate card %q: %w", card.CardID, err)
-				}
-			}
+	for _, card := range board.Cards {
+		if card.FromVersion == card.ToVersion && !card.RemoveColumn {
+			continue
+		}
+		if err := s.migrateCard(&card); err != nil {
+			return fmt.Errorf("failed to migrate card %q: %w", card.CardID, err)
+		}
+	}
 
-			if board.NeedsMigration || cardsToMigrate > 0 {
-				fmt.Fprintf(w, "Migrated board %q", board.BoardName)
-				if board.NeedsMigration {
-					fmt.Fprintf(w, " (config")
-					if cardsToMigrate > 0 {
-						fmt.Fprintf(w, " + %d cards", cardsToMigrate)
-					}
-					fmt.Fprintf(w, ")")
-				} else if cardsToMigrate > 0 {
-					fmt.Fprintf(w, " (%d cards)", cardsToMigrate)
-				}
-				fmt.Fprintln(w)
+	if board.NeedsMigration || cardsToMigrate > 0 {
+		fmt.Fprintf(w, "Migrated board %q", board.BoardName)
+		if board.NeedsMigration {
+			fmt.Fprintf(w, " (config")
+			if cardsToMigrate > 0 {
+				fmt.Fprintf(w, " + %d cards", cardsToMigrate)
 			}
+			fmt.Fprintf(w, ")")
+		} else if cardsToMigrate > 0 {
+			fmt.Fprintf(w, " (%d cards)", cardsToMigrate)
 		}
+		fmt.Fprintln(w)
 	}
-
 	return nil
 }
 
+// Rollback restores the Kan data directory to a snapshot taken by Execute,
+// undoing the migration (and any other changes made since).
+func (s *MigrateService) Rollback(snapshotID string) error {
+	return s.backup.Restore(s.paths, snapshotID)
+}
+
+// Snapshots lists the snapshots available to Rollback, oldest first.
+func (s *MigrateService) Snapshots() ([]SnapshotInfo, error) {
+	return s.backup.List(s.paths)
+}
+
 // FutureVersionError checks if any files in the plan have a schema version
 // newer than this binary supports. Returns the first future-version error
 // found, or nil if all versions are current or older. Callers should check
@@ -212,19 +388,65 @@ func (p *MigrationPlan) HasChanges() bool {
 	if p.GlobalConfig != nil && p.GlobalConfig.NeedsMigration {
 		return true
 	}
-	for _, board := range p.Boards {
-		if board.NeedsMigration {
+	return p.hasBoardChanges()
+}
+
+// hasBoardChanges reports whether any board config or card would change,
+// i.e. whether Execute will modify files in the project's data directory.
+func (p *MigrationPlan) hasBoardChanges() bool {
+	for i := range p.Boards {
+		if p.Boards[i].hasChanges() {
 			return true
 		}
-		for _, card := range board.Cards {
-			if card.FromVersion != card.ToVersion || card.RemoveColumn {
-				return true
-			}
-		}
 	}
 	return false
 }
 
+// hasChanges reports whether the board's config or any of its cards would change.
+func (b *BoardMigration) hasChanges() bool {
+	return b.NeedsMigration || b.cardsToMigrate() > 0
+}
+
+// cardsToMigrate counts the board's cards that would change.
+func (b *BoardMigration) cardsToMigrate() int {
+	n := 0
+	for _, card := range b.Cards {
+		if card.FromVersion != card.ToVersion || card.RemoveColumn {
+			n++
+		}
+	}
+	return n
+}
+
+// Per-file costs used by Estimate, calibrated from the migration benchmarks.
+const (
+	estimateMillisPerCard  = 2
+	estimateMillisPerBoard = 5
+)
+
+// MigrateEstimate is a rough size and duration for a migration plan.
+type MigrateEstimate struct {
+	BoardsToMigrate     int
+	CardsToMigrate      int
+	EstimatedDurationMs int64
+}
+
+// Estimate sizes a migration from the plan's counts alone, without reading
+// or writing any files.
+func (s *MigrateService) Estimate(plan *MigrationPlan) MigrateEstimate {
+	var est MigrateEstimate
+	for i := range plan.Boards {
+		board := &plan.Boards[i]
+		if !board.hasChanges() {
+			continue
+		}
+		est.BoardsToMigrate++
+		est.CardsToMigrate += board.cardsToMigrate()
+	}
+	est.EstimatedDurationMs = int64(est.CardsToMigrate*estimateMillisPerCard + est.BoardsToMigrate*estimateMillisPerBoard)
+	return est
+}
+
 // PlanGlobalMigration analyzes the global config and returns a migration plan.
 // Exported for use by --all, which handles global config separately from boards.
 func (s *MigrateService) PlanGlobalMigration() (*GlobalMigration, error) {
@@ -381,10 +603,11 @@ func (s *MigrateService) planCardMigration(path string) (*CardMigration, error)
 
 func (s *MigrateService) migrateGlobalConfig(plan *GlobalMigration) error {
 	// global/1 -> global/2 and onward: bumping the schema is a no-op transform
-	// (the global_board field is purely additive). When the file already declares
-	// a schema, update it in place; prepending would create a duplicate
-	// kan_schema key and break TOML decoding. Only the pre-schema case (no
-	// FromSchema) prepends, to preserve formatting of legacy configs.
+	// (global_board, the new-board defaults, field order and column sprints
+	// are purely additive). When the file already declares a schema, update
+	// it in place; prepending would create a duplicate kan_schema key and
+	// break TOML decoding. Only the pre-schema case (no FromSchema) prepends,
+	// to preserve formatting of legacy configs.
 	if plan.FromSchema != "" {
 		return s.updateTOMLSchema(plan.Path, plan.ToSchema)
 	}
@@ -628,10 +851,11 @@ func (s *MigrateService) migrateCard(plan *CardMigration) error {
 	// history, so the version delta alone can't tell us whether a card still
 	// needs its history seeded.
 	seeded := seedCardHistory(raw)
+	seeded = seedCommentMentions(raw) || seeded
 
 	// Check if already at target version (e.g., v9->v10 board migration already
 	// bumped card files via writeCardColumnPosition). Still persist if we just
-	// seeded history.
+	// seeded history or mentions.
 	if v, ok := raw["_v"].(float64); ok && int(v) == plan.ToVersion {
 		if seeded {
 			return writeCardMap(plan.Path, raw)
@@ -683,6 +907,29 @@ func seedCardHistory(raw map[string]any) bool {
 	return true
 }
 
+// seedCommentMentions derives mentions (card/9) for comments that lack them,
+// returning true if it modified the map. Mentions are derived from the body,
+// so this is exact rather than an approximation like seedCardHistory.
+func seedCommentMentions(raw map[string]any) bool {
+	comments, _ := raw["comments"].([]any)
+	changed := false
+	for _, item := range comments {
+		comment, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		if _, has := comment["mentions"]; has {
+			continue
+		}
+		body, _ := comment["body"].(string)
+		if mentions := ExtractMentions(body); len(mentions) > 0 {
+			comment["mentions"] = mentions
+			changed = true
+		}
+	}
+	return changed
+}
+
 // migrateBoardV9ToV10 moves card-column association from board config to card files.
 // For each column's card_ids, writes column + position to each card file,
 // then strips card_ids from the board config.
@@ -769,3 +1016,579 @@ func (s *MigrateService) writeCardColumnPosition(path, column, position string)
 
 	return writeJSONMap(path, raw)
 }
+
+// Downgrade rewrites the project's boards and cards to an older board schema,
+// for handing data back to an older Kan release. Cards are downgraded to the
+// newest card version that release understands (see cardVersionForBoard).
+//
+// Each version step reverses the structural change it introduced. If any
+// board or card uses a feature the target schema can't represent, nothing is
+// written and a *kanerr.DowngradeError lists what would be lost. Otherwise the
+// data directory is snapshotted (see BackupService) before any file changes.
+//
+// Note that the current binary auto-migrates on its next run, so downgraded
+// data should only be opened with the older release.
+func (s *MigrateService) Downgrade(plan *MigrationPlan, targetVersion int) error {
+	if targetVersion < 0 {
+		return kanerr.InvalidField("target version", "cannot downgrade below board/0")
+	}
+	if targetVersion > version.CurrentBoardVersion {
+		return kanerr.InvalidField("target version", fmt.Sprintf("%s is newer than the current schema %s",
+			version.FormatBoardSchema(targetVersion), version.CurrentBoardSchema()))
+	}
+	if err := plan.FutureVersionError(); err != nil {
+		return err
+	}
+
+	var downgrades []*boardDowngrade
+	var losses []string
+	for i := range plan.Boards {
+		d, err := s.planBoardDowngrade(&plan.Boards[i], targetVersion)
+		if err != nil {
+			return fmt.Errorf("failed to plan downgrade for board %q: %w", plan.Boards[i].BoardName, err)
+		}
+		if d != nil {
+			downgrades = append(downgrades, d)
+			losses = append(losses, d.losses...)
+		}
+	}
+	if len(losses) > 0 {
+		return &kanerr.DowngradeError{Target: version.FormatBoardSchema(targetVersion), Losses: losses}
+	}
+	if len(downgrades) == 0 {
+		return nil
+	}
+
+	snapshotID, err := s.backup.Snapshot(s.paths)
+	if err != nil {
+		return fmt.Errorf("failed to snapshot before downgrading: %w", err)
+	}
+	fmt.Fprintf(s.output, "Snapshot %s taken before downgrading\n", snapshotID)
+
+	for _, d := range downgrades {
+		if err := d.write(); err != nil {
+			return fmt.Errorf("failed to downgrade board %q: %w", d.name, err)
+		}
+		fmt.Fprintf(s.output, "Downgraded board %q to %s\n", d.name, version.FormatBoardSchema(targetVersion))
+	}
+	return nil
+}
+
+// cardVersionForBoard returns the newest card version readable by the oldest
+// Kan release that supports the given board version, going by MinKanVersion.
+// Board/0 predates versioning, so its cards are card/0 too.
+func cardVersionForBoard(boardVersion int) int {
+	if boardVersion == 0 {
+		return 0
+	}
+	release := version.MinKanVersion[version.FormatBoardSchema(boardVersion)]
+	cardVersion := 1
+	for v := 2; v <= version.CurrentCardVersion; v++ {
+		if compareReleases(version.MinKanVersion[fmt.Sprintf("card/%d", v)], release) <= 0 {
+			cardVersion = v
+		}
+	}
+	return cardVersion
+}
+
+// compareReleases compares two "major.minor.patch" release strings.
+func compareReleases(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		x, _ := strconv.Atoi(as[i])
+		y, _ := strconv.Atoi(bs[i])
+		if x != y {
+			return x - y
+		}
+	}
+	return len(as) - len(bs)
+}
+
+// boardDowngrade holds a board's config and cards, decoded as raw maps and
+// rewritten in memory by the downgrade steps before anything is written.
+type boardDowngrade struct {
+	name         string
+	configPath   string
+	target       int
+	cardTarget   int
+	board        map[string]any
+	cards        []*cardDowngrade
+	losses       []string
+	movedToBoard bool // card column membership was written back to card_ids
+}
+
+// cardDowngrade is one card file within a boardDowngrade.
+type cardDowngrade struct {
+	id   string
+	path string
+	from int
+	raw  map[string]any
+}
+
+// planBoardDowngrade loads a board and its cards and applies the downgrade
+// steps in memory. Returns nil if the board and its cards are already at or
+// below the target.
+func (s *MigrateService) planBoardDowngrade(plan *BoardMigration, target int) (*boardDowngrade, error) {
+	cardTarget := cardVersionForBoard(target)
+	from := plan.FromVersion()
+
+	needed := from > target
+	for _, card := range plan.Cards {
+		needed = needed || card.FromVersion > cardTarget
+	}
+	if !needed {
+		return nil, nil
+	}
+
+	d := &boardDowngrade{
+		name:       plan.BoardName,
+		configPath: plan.ConfigPath,
+		target:     target,
+		cardTarget: cardTarget,
+	}
+	data, err := os.ReadFile(plan.ConfigPath)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := toml.Decode(string(data), &d.board); err != nil {
+		return nil, fmt.Errorf("invalid TOML: %w", err)
+	}
+	for _, card := range plan.Cards {
+		data, err := os.ReadFile(card.Path)
+		if err != nil {
+			return nil, err
+		}
+		c := &cardDowngrade{id: card.CardID, path: card.Path, from: card.FromVersion}
+		if err := json.Unmarshal(data, &c.raw); err != nil {
+			return nil, fmt.Errorf("invalid JSON in card %s: %w", card.CardID, err)
+		}
+		d.cards = append(d.cards, c)
+	}
+
+	// Board steps run first: board/10 -> board/9 reads card columns before
+	// the card steps strip them.
+	for v := from; v > target; v-- {
+		if step := boardDowngradeSteps[v]; step != nil {
+			step(d, v)
+		}
+	}
+	if target == 0 {
+		delete(d.board, "kan_schema")
+	} else {
+		d.board["kan_schema"] = version.FormatBoardSchema(target)
+	}
+
+	for _, c := range d.cards {
+		for v := c.from; v > cardTarget; v-- {
+			if step := cardDowngradeSteps[v]; step != nil {
+				step(d, c, v)
+			}
+		}
+		if cardTarget == 0 {
+			delete(c.raw, "_v")
+		} else if c.from > cardTarget {
+			c.raw["_v"] = cardTarget
+		}
+	}
+
+	return d, nil
+}
+
+// write persists the downgraded board config and any changed cards. Cards are
+// written as plain JSON rather than via writeCardMap, which would round-trip
+// them through the current model.Card.
+func (d *boardDowngrade) write() error {
+	if err := writeTOMLMap(d.configPath, d.board); err != nil {
+		return err
+	}
+	for _, c := range d.cards {
+		if c.from <= d.cardTarget {
+			continue
+		}
+		if err := writeJSONMap(c.path, c.raw); err != nil {
+			return fmt.Errorf("card %q: %w", c.id, err)
+		}
+	}
+	return nil
+}
+
+// lose records data that the target schema can't represent.
+func (d *boardDowngrade) lose(schema string, format string, args ...any) {
+	d.losses = append(d.losses, fmt.Sprintf("board %q: %s (added in %s)", d.name, fmt.Sprintf(format, args...), schema))
+}
+
+// strip removes keys from m, recording a loss for each one that holds data.
+func (d *boardDowngrade) strip(schema, owner string, m map[string]any, keys ...string) {
+	for _, key := range keys {
+		if isSetValue(m[key]) {
+			d.lose(schema, "%s sets %s", owner, key)
+		}
+		delete(m, key)
+	}
+}
+
+// customFields returns the board's custom field schemas, sorted by name.
+func (d *boardDowngrade) customFields() ([]string, map[string]map[string]any) {
+	raw, _ := d.board["custom_fields"].(map[string]any)
+	fields := make(map[string]map[string]any, len(raw))
+	for name, def := range raw {
+		if m, ok := def.(map[string]any); ok {
+			fields[name] = m
+		}
+	}
+	return sortedMapKeys(raw), fields
+}
+
+// forEachField calls fn for each custom field schema, in name order.
+func (d *boardDowngrade) forEachField(fn func(name string, field map[string]any)) {
+	names, fields := d.customFields()
+	for _, name := range names {
+		if field, ok := fields[name]; ok {
+			fn(name, field)
+		}
+	}
+}
+
+// dropFieldsOfType removes custom fields of a type the target can't represent.
+func (d *boardDowngrade) dropFieldsOfType(schema, fieldType string) {
+	d.forEachField(func(name string, field map[string]any) {
+		if field["type"] == fieldType {
+			d.lose(schema, "custom field %q has type %s", name, fieldType)
+			delete(d.board["custom_fields"].(map[string]any), name)
+		}
+	})
+}
+
+// cardDisplay returns the board's card_display table (nil if absent).
+func (d *boardDowngrade) cardDisplay() map[string]any {
+	m, _ := d.board["card_display"].(map[string]any)
+	return m
+}
+
+// boardDowngradeSteps maps board version N to the step that rewrites a
+// board/N config as board/N-1.
+var boardDowngradeSteps = map[int]func(d *boardDowngrade, v int){
+	30: func(d *boardDowngrade, v int) {
+		for _, col := range tomlTables(d.board["columns"]) {
+			d.strip(version.FormatBoardSchema(v), fmt.Sprintf("column %q", col["name"]), col, "sprint_start_millis", "sprint_end_millis")
+		}
+	},
+	29: func(d *boardDowngrade, v int) {
+		d.strip(version.FormatBoardSchema(v), "board", d.board, "include")
+	},
+	28: func(d *boardDowngrade, v int) {
+		// The revision counter is concurrency metadata, so dropping it loses
+		// nothing the user wrote.
+		delete(d.board, "revision")
+	},
+	27: func(d *boardDowngrade, v int) {
+		schema := version.FormatBoardSchema(v)
+		d.dropFieldsOfType(schema, model.FieldTypeUser)
+		d.strip(schema, "board", d.board, "collaborators")
+	},
+	26: func(d *boardDowngrade, v int) {
+		schema := version.FormatBoardSchema(v)
+		d.forEachField(func(name string, field map[string]any) {
+			d.strip(schema, fmt.Sprintf("custom field %q", name), field, "order")
+		})
+	},
+	25: func(d *boardDowngrade, v int) {
+		d.strip(version.FormatBoardSchema(v), "board", d.board, "templates")
+	},
+	24: func(d *boardDowngrade, v int) {
+		d.strip(version.FormatBoardSchema(v), "board", d.board, "archived_at_millis")
+	},
+	23: func(d *boardDowngrade, v int) {
+		for _, hook := range tomlTables(d.board["pattern_hooks"]) {
+			d.strip(version.FormatBoardSchema(v), fmt.Sprintf("pattern hook %q", hook["name"]), hook, "command_args")
+		}
+	},
+	22: func(d *boardDowngrade, v int) {
+		d.strip(version.FormatBoardSchema(v), "board", d.board, "stale")
+	},
+	21: func(d *boardDowngrade, v int) {
+		d.strip(version.FormatBoardSchema(v), "board", d.board, "done_columns")
+	},
+	20: func(d *boardDowngrade, v int) {
+		schema := version.FormatBoardSchema(v)
+		d.forEachField(func(name string, field map[string]any) {
+			d.strip(schema, fmt.Sprintf("custom field %q", name), field, "future_only", "past_only", "format")
+		})
+	},
+	19: func(d *boardDowngrade, v int) {
+		for _, col := range tomlTables(d.board["columns"]) {
+			d.strip(version.FormatBoardSchema(v), fmt.Sprintf("column %q", col["name"]), col, "done", "auto_archive_after_days")
+		}
+	},
+	18: func(d *boardDowngrade, v int) {
+		schema := version.FormatBoardSchema(v)
+		d.forEachField(func(name string, field map[string]any) {
+			if field["type"] == model.FieldTypeTags {
+				field["type"] = model.FieldTypeFreeSet
+			}
+			d.strip(schema, fmt.Sprintf("custom field %q", name), field, "max_length")
+		})
+		if display := d.cardDisplay(); display != nil {
+			d.strip(schema, "card_display", display, "tags")
+		}
+	},
+	17: func(d *boardDowngrade, v int) {
+		d.strip(version.FormatBoardSchema(v), "board", d.board, "alias")
+	},
+	16: func(d *boardDowngrade, v int) {
+		d.dropFieldsOfType(version.FormatBoardSchema(v), model.FieldTypeURL)
+	},
+	15: func(d *boardDowngrade, v int) {
+		for _, col := range tomlTables(d.board["columns"]) {
+			d.strip(version.FormatBoardSchema(v), fmt.Sprintf("column %q", col["name"]), col, "transition_rules")
+		}
+	},
+	14: func(d *boardDowngrade, v int) {
+		for _, hook := range tomlTables(d.board["pattern_hooks"]) {
+			d.strip(version.FormatBoardSchema(v), fmt.Sprintf("pattern hook %q", hook["name"]), hook, "webhook", "webhook_headers")
+		}
+	},
+	13: func(d *boardDowngrade, v int) {
+		schema := version.FormatBoardSchema(v)
+		d.dropFieldsOfType(schema, model.FieldTypeInteger)
+		d.forEachField(func(name string, field map[string]any) {
+			for _, opt := range tomlTables(field["options"]) {
+				d.strip(schema, fmt.Sprintf("option %q of custom field %q", opt["value"], name), opt, "label")
+			}
+		})
+	},
+	12: func(d *boardDowngrade, v int) {
+		if display := d.cardDisplay(); display != nil {
+			d.strip(version.FormatBoardSchema(v), "card_display", display, "default_sort", "default_sort_desc")
+		}
+	},
+	11: func(d *boardDowngrade, v int) {
+		if display := d.cardDisplay(); display != nil {
+			d.strip(version.FormatBoardSchema(v), "card_display", display, "tint")
+		}
+	},
+	10: downgradeBoardV10ToV9,
+	9: func(d *boardDowngrade, v int) {
+		d.dropFieldsOfType(version.FormatBoardSchema(v), model.FieldTypeBoolean)
+	},
+	8: func(d *boardDowngrade, v int) {
+		for _, col := range tomlTables(d.board["columns"]) {
+			d.strip(version.FormatBoardSchema(v), fmt.Sprintf("column %q", col["name"]), col, "limit")
+		}
+	},
+	7: func(d *boardDowngrade, v int) {
+		for _, col := range tomlTables(d.board["columns"]) {
+			d.strip(version.FormatBoardSchema(v), fmt.Sprintf("column %q", col["name"]), col, "description")
+		}
+	},
+	6: func(d *boardDowngrade, v int) {
+		schema := version.FormatBoardSchema(v)
+		d.forEachField(func(name string, field map[string]any) {
+			d.strip(schema, fmt.Sprintf("custom field %q", name), field, "description")
+			for _, opt := range tomlTables(field["options"]) {
+				d.strip(schema, fmt.Sprintf("option %q of custom field %q", opt["value"], name), opt, "description")
+			}
+		})
+	},
+	5: func(d *boardDowngrade, v int) {
+		d.dropFieldsOfType(version.FormatBoardSchema(v), model.FieldTypeFreeSet)
+		d.forEachField(func(name string, field map[string]any) {
+			if field["type"] == model.FieldTypeEnumSet {
+				field["type"] = "tags"
+			}
+		})
+	},
+	4: func(d *boardDowngrade, v int) {
+		schema := version.FormatBoardSchema(v)
+		d.forEachField(func(name string, field map[string]any) {
+			d.strip(schema, fmt.Sprintf("custom field %q", name), field, "wanted")
+		})
+	},
+	3: func(d *boardDowngrade, v int) {
+		d.strip(version.FormatBoardSchema(v), "board", d.board, "pattern_hooks")
+	},
+	2: downgradeBoardV2ToV1,
+	// board/1 only added kan_schema, which is dropped once all steps have run.
+	1: func(d *boardDowngrade, v int) {},
+}
+
+// downgradeBoardV10ToV9 moves card-column membership from card files back to
+// card_ids arrays on the board's columns, ordered by card position. The card
+// steps then strip column and position from the cards.
+func downgradeBoardV10ToV9(d *boardDowngrade, v int) {
+	schema := version.FormatBoardSchema(v)
+	columns := tomlTables(d.board["columns"])
+
+	byColumn := make(map[string][]*cardDowngrade)
+	for _, c := range d.cards {
+		if archived, _ := c.raw["archived"].(bool); archived {
+			continue // Reported as lost by the card/4 step.
+		}
+		column, _ := c.raw["column"].(string)
+		byColumn[column] = append(byColumn[column], c)
+	}
+
+	known := make(map[string]bool, len(columns))
+	for _, col := range columns {
+		name, _ := col["name"].(string)
+		known[name] = true
+		cards := byColumn[name]
+		sort.SliceStable(cards, func(i, j int) bool {
+			pi, _ := cards[i].raw["position"].(string)
+			pj, _ := cards[j].raw["position"].(string)
+			return pi < pj
+		})
+		ids := make([]string, len(cards))
+		for i, c := range cards {
+			ids[i] = c.id
+		}
+		col["card_ids"] = ids
+	}
+	for _, column := range sortedMapKeys(byColumn) {
+		if !known[column] {
+			for _, c := range byColumn[column] {
+				d.lose(schema, "card %q is in unknown column %q", c.id, column)
+			}
+		}
+	}
+	d.board["columns"] = columns
+	d.movedToBoard = true
+}
+
+// downgradeBoardV2ToV1 turns a "labels" tags field back into first-class
+// [[labels]] and removes card_display, which board/1 doesn't have.
+func downgradeBoardV2ToV1(d *boardDowngrade, v int) {
+	schema := version.FormatBoardSchema(v)
+	d.forEachField(func(name string, field map[string]any) {
+		if name != "labels" || field["type"] != "tags" {
+			d.lose(schema, "custom field %q", name)
+			return
+		}
+		var labels []map[string]any
+		for _, opt := range tomlTables(field["options"]) {
+			label := map[string]any{"name": opt["value"]}
+			if color, ok := opt["color"].(string); ok && color != "" {
+				label["color"] = color
+			}
+			labels = append(labels, label)
+		}
+		if len(labels) > 0 {
+			d.board["labels"] = labels
+		}
+	})
+	delete(d.board, "custom_fields")
+
+	if display := d.cardDisplay(); display != nil {
+		for _, key := range sortedMapKeys(display) {
+			if key == "badges" && reflect.DeepEqual(toStrings(display[key]), []string{"labels"}) {
+				continue // Implied by [[labels]] in board/1.
+			}
+			if isSetValue(display[key]) {
+				d.lose(schema, "card_display sets %s", key)
+			}
+		}
+		delete(d.board, "card_display")
+	}
+}
+
+// cardDowngradeSteps maps card version N to the step that rewrites a card/N
+// file as card/N-1.
+var cardDowngradeSteps = map[int]func(d *boardDowngrade, c *cardDowngrade, v int){
+	9: func(d *boardDowngrade, c *cardDowngrade, v int) {
+		// Derived from comment bodies, which are kept, so nothing is lost.
+		comments, _ := c.raw["comments"].([]any)
+		for _, item := range comments {
+			if comment, ok := item.(map[string]any); ok {
+				delete(comment, "mentions")
+			}
+		}
+	},
+	8: func(d *boardDowngrade, c *cardDowngrade, v int) {
+		// Only meaningful with a done column, which the board step reports.
+		delete(c.raw, "done_at_millis")
+	},
+	7: func(d *boardDowngrade, c *cardDowngrade, v int) {
+		d.strip(fmt.Sprintf("card/%d", v), fmt.Sprintf("card %q", c.id), c.raw, "checklist")
+	},
+	6: func(d *boardDowngrade, c *cardDowngrade, v int) {
+		d.strip(fmt.Sprintf("card/%d", v), fmt.Sprintf("card %q", c.id), c.raw, "blocks", "blocked_by")
+	},
+	5: func(d *boardDowngrade, c *cardDowngrade, v int) {
+		d.strip(fmt.Sprintf("card/%d", v), fmt.Sprintf("card %q", c.id), c.raw, "due_at_millis")
+	},
+	4: func(d *boardDowngrade, c *cardDowngrade, v int) {
+		d.strip(fmt.Sprintf("card/%d", v), fmt.Sprintf("card %q", c.id), c.raw, "archived", "archived_at_millis", "last_column")
+	},
+	3: func(d *boardDowngrade, c *cardDowngrade, v int) {
+		d.strip(fmt.Sprintf("card/%d", v), fmt.Sprintf("card %q", c.id), c.raw, "history")
+	},
+	2: func(d *boardDowngrade, c *cardDowngrade, v int) {
+		if d.movedToBoard {
+			delete(c.raw, "column")
+			delete(c.raw, "position")
+			return
+		}
+		d.strip(fmt.Sprintf("card/%d", v), fmt.Sprintf("card %q", c.id), c.raw, "column", "position")
+	},
+	// card/1 only added _v, which is dropped once all steps have run.
+	1: func(d *boardDowngrade, c *cardDowngrade, v int) {},
+}
+
+// tomlTables returns an array of TOML tables as maps, accepting both decoded
+// forms ([]map[string]any and []any).
+func tomlTables(v any) []map[string]any {
+	switch t := v.(type) {
+	case []map[string]any:
+		return t
+	case []any:
+		tables := make([]map[string]any, 0, len(t))
+		for _, item := range t {
+			if m, ok := item.(map[string]any); ok {
+				tables = append(tables, m)
+			}
+		}
+		return tables
+	}
+	return nil
+}
+
+// toStrings converts a decoded string array to []string.
+func toStrings(v any) []string {
+	var out []string
+	switch t := v.(type) {
+	case []string:
+		return t
+	case []any:
+		for _, item := range t {
+			if s, ok := item.(string); ok {
+				out = append(out, s)
+			}
+		}
+	}
+	return out
+}
+
+// isSetValue reports whether a decoded value carries data, i.e. is not
+// absent, false, zero, or empty.
+func isSetValue(v any) bool {
+	if v == nil {
+		return false
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Map, reflect.String:
+		return rv.Len() > 0
+	default:
+		return !rv.IsZero()
+	}
+}
+
+// sortedMapKeys returns a map's keys in sorted order.
+func sortedMapKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
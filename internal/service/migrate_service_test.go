@@ -2,15 +2,18 @@ package service
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 
 	"github.com/BurntSushi/toml"
 	"github.com/amterp/kan/internal/config"
+	kanerr "github.com/amterp/kan/internal/errors"
+	"github.com/amterp/kan/internal/model"
 	"github.com/amterp/kan/internal/store"
 	"github.com/amterp/kan/internal/version"
 )
@@ -36,40 +39,6 @@ import (
 //	testdata/migrations/v2/  - Schema v2 data (current, labels as custom field)
 //	etc.
 
-// copyDir recursively copies a directory tree.
-func copyDir(src, dst string) error {
-	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
-
-		relPath, err := filepath.Rel(src, path)
-		if err != nil {
-			return err
-		}
-		dstPath := filepath.Join(dst, relPath)
-
-		if info.IsDir() {
-			return os.MkdirAll(dstPath, info.Mode())
-		}
-
-		srcFile, err := os.Open(path)
-		if err != nil {
-			return err
-		}
-		defer srcFile.Close()
-
-		dstFile, err := os.Create(dstPath)
-		if err != nil {
-			return err
-		}
-		defer dstFile.Close()
-
-		_, err = io.Copy(dstFile, srcFile)
-		return err
-	})
-}
-
 // setupMigrationTest copies test fixtures to a temp directory and returns
 // the MigrateService and cleanup function.
 func setupMigrationTest(t *testing.T, fixtureName string) (*MigrateService, string, func()) {
@@ -145,7 +114,7 @@ data_location = ".kan"
 		t.Errorf("FromSchema = %q, want global/1", plan.FromSchema)
 	}
 
-	if err := svc.Execute(&MigrationPlan{GlobalConfig: plan}, false); err != nil {
+	if _, err := svc.Execute(&MigrationPlan{GlobalConfig: plan}, false); err != nil {
 		t.Fatalf("Execute: %v", err)
 	}
 
@@ -250,7 +219,7 @@ func TestMigrateService_Execute_V0(t *testing.T) {
 	}
 
 	// Execute migration (not dry run)
-	if err := service.Execute(plan, false); err != nil {
+	if _, err := service.Execute(plan, false); err != nil {
 		t.Fatalf("Execute failed: %v", err)
 	}
 
@@ -324,7 +293,7 @@ func TestMigrateService_Execute_DryRun(t *testing.T) {
 	}
 
 	// Execute with dry run
-	if err := service.Execute(plan, true); err != nil {
+	if _, err := service.Execute(plan, true); err != nil {
 		t.Fatalf("Execute (dry run) failed: %v", err)
 	}
 
@@ -363,7 +332,7 @@ func TestMigrateService_Execute_Idempotent(t *testing.T) {
 	if !plan1.HasChanges() {
 		t.Fatal("First plan should have changes")
 	}
-	if err := service.Execute(plan1, false); err != nil {
+	if _, err := service.Execute(plan1, false); err != nil {
 		t.Fatalf("First Execute failed: %v", err)
 	}
 
@@ -377,6 +346,283 @@ func TestMigrateService_Execute_Idempotent(t *testing.T) {
 	}
 }
 
+func TestMigrateService_Rollback(t *testing.T) {
+	service, tempDir, cleanup := setupMigrationTest(t, "v0")
+	defer cleanup()
+
+	plan, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	result, err := service.Execute(plan, false)
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if result.SnapshotID == "" {
+		t.Fatal("Expected a snapshot ID")
+	}
+
+	snapshots, err := service.Snapshots()
+	if err != nil {
+		t.Fatalf("Snapshots failed: %v", err)
+	}
+	if len(snapshots) != 1 || snapshots[0].ID != result.SnapshotID || snapshots[0].CreatedAtMillis == 0 {
+		t.Fatalf("Unexpected snapshots: %+v", snapshots)
+	}
+	if schema, ok := snapshots[0].BoardSchemas["main"]; !ok || schema != "" {
+		t.Errorf("Expected unversioned 'main' board in snapshot, got %v", snapshots[0].BoardSchemas)
+	}
+
+	if err := service.Rollback(result.SnapshotID); err != nil {
+		t.Fatalf("Rollback failed: %v", err)
+	}
+
+	// The card is back in its legacy form and the snapshot survives the restore.
+	cardData, err := os.ReadFile(filepath.Join(tempDir, ".kan", "boards", "main", "cards", "card-abc.json"))
+	if err != nil {
+		t.Fatalf("Failed to read card: %v", err)
+	}
+	if strings.Contains(string(cardData), `"_v"`) {
+		t.Error("Expected rolled-back card to have no _v")
+	}
+	if snapshots, _ := service.Snapshots(); len(snapshots) != 1 {
+		t.Errorf("Expected snapshot to be kept after rollback, got %d", len(snapshots))
+	}
+
+	plan, err = service.Plan()
+	if err != nil {
+		t.Fatalf("Plan after rollback failed: %v", err)
+	}
+	if !plan.HasChanges() {
+		t.Error("Plan after rollback should detect changes again")
+	}
+
+	for _, id := range []string{"missing", "../boards", ""} {
+		if err := service.Rollback(id); err == nil {
+			t.Errorf("Rollback(%q) should fail", id)
+		}
+	}
+}
+
+func TestBackupService_Snapshot_Retention(t *testing.T) {
+	service, _, cleanup := setupMigrationTest(t, "v0")
+	defer cleanup()
+
+	var ids []string
+	for range SnapshotRetention + 2 {
+		id, err := service.backup.Snapshot(service.paths)
+		if err != nil {
+			t.Fatalf("Snapshot failed: %v", err)
+		}
+		ids = append(ids, id)
+	}
+
+	snapshots, err := service.Snapshots()
+	if err != nil {
+		t.Fatalf("Snapshots failed: %v", err)
+	}
+	var kept []string
+	for _, snap := range snapshots {
+		kept = append(kept, snap.ID)
+	}
+	if want := ids[2:]; !reflect.DeepEqual(kept, want) {
+		t.Errorf("Expected the newest %d snapshots %v, got %v", SnapshotRetention, want, kept)
+	}
+}
+
+func TestMigrateService_Execute_Locked(t *testing.T) {
+	service, tempDir, cleanup := setupMigrationTest(t, "v0")
+	defer cleanup()
+
+	// Another migration holds the board's lock.
+	lockPath := filepath.Join(tempDir, ".kan", "boards", "main", MigrateLockFile)
+	if err := os.WriteFile(lockPath, nil, 0644); err != nil {
+		t.Fatalf("Failed to create lockfile: %v", err)
+	}
+
+	plan, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	if _, err := service.Execute(plan, false); !errors.Is(err, ErrMigrationInProgress) {
+		t.Fatalf("Expected ErrMigrationInProgress, got %v", err)
+	}
+	if _, err := service.ExecuteParallel(plan, false, 0, nil); !errors.Is(err, ErrMigrationInProgress) {
+		t.Fatalf("Expected ExecuteParallel to respect the lock too, got %v", err)
+	}
+	if snapshots, _ := service.Snapshots(); len(snapshots) != 0 {
+		t.Errorf("Expected no snapshot while locked, got %d", len(snapshots))
+	}
+
+	// Dry runs don't lock.
+	if _, err := service.Execute(plan, true); err != nil {
+		t.Errorf("Dry run failed: %v", err)
+	}
+
+	broken, err := service.BreakLocks()
+	if err != nil {
+		t.Fatalf("BreakLocks failed: %v", err)
+	}
+	if !reflect.DeepEqual(broken, []string{"main"}) {
+		t.Errorf("Expected main's lock to be broken, got %v", broken)
+	}
+
+	result, err := service.Execute(plan, false)
+	if err != nil {
+		t.Fatalf("Execute after breaking the lock failed: %v", err)
+	}
+	if _, err := os.Stat(lockPath); !os.IsNotExist(err) {
+		t.Errorf("Expected the lock to be released after migrating, got %v", err)
+	}
+
+	// The lock isn't part of the snapshot, so a rollback doesn't restore it.
+	if err := service.Rollback(result.SnapshotID); err != nil {
+		t.Fatalf("Rollback failed: %v", err)
+	}
+	if _, err := os.Stat(lockPath); !os.IsNotExist(err) {
+		t.Errorf("Expected no lock after rollback, got %v", err)
+	}
+}
+
+// TestMigrateService_Execute_StalePlan covers two migrations that both plan
+// before either runs, as parallel CI jobs on one checkout do: the second gets
+// the locks only after the first has finished, and must not re-run steps on
+// boards that are already migrated.
+func TestMigrateService_Execute_StalePlan(t *testing.T) {
+	for _, parallel := range []bool{false, true} {
+		t.Run(fmt.Sprintf("parallel=%v", parallel), func(t *testing.T) {
+			service, tempDir, cleanup := setupMigrationTest(t, "v1")
+			defer cleanup()
+
+			first, err := service.Plan()
+			if err != nil {
+				t.Fatalf("Plan failed: %v", err)
+			}
+			second, err := service.Plan()
+			if err != nil {
+				t.Fatalf("Plan failed: %v", err)
+			}
+			if _, err := service.Execute(first, false); err != nil {
+				t.Fatalf("First Execute failed: %v", err)
+			}
+			configPath := filepath.Join(tempDir, ".kan", "boards", "main", "config.toml")
+			migrated, err := os.ReadFile(configPath)
+			if err != nil {
+				t.Fatalf("Failed to read config: %v", err)
+			}
+
+			var result *MigrateResult
+			if parallel {
+				result, err = service.ExecuteParallel(second, false, 0, nil)
+			} else {
+				result, err = service.Execute(second, false)
+			}
+			if err != nil {
+				t.Fatalf("Second Execute failed: %v", err)
+			}
+			if result.SnapshotID != "" {
+				t.Error("Expected no snapshot when there's nothing left to migrate")
+			}
+			if second.hasBoardChanges() {
+				t.Error("Expected the stale plan to be refreshed after locking")
+			}
+			after, err := os.ReadFile(configPath)
+			if err != nil {
+				t.Fatalf("Failed to read config: %v", err)
+			}
+			if string(after) != string(migrated) {
+				t.Errorf("Second migration changed an already-migrated board:\n%s\nwant:\n%s", after, migrated)
+			}
+		})
+	}
+}
+
+func TestMigrateService_ExecuteParallel(t *testing.T) {
+	_, tempDir, cleanup := setupMigrationTest(t, "v14")
+	defer cleanup()
+
+	// Fan the fixture's board out into five boards.
+	boardsDir := filepath.Join(tempDir, ".kan", "boards")
+	boardNames := []string{"b1", "b2", "b3", "b4", "b5"}
+	for _, name := range boardNames {
+		if err := copyDir(filepath.Join(boardsDir, "main"), filepath.Join(boardsDir, name)); err != nil {
+			t.Fatalf("Failed to copy board: %v", err)
+		}
+	}
+	if err := os.RemoveAll(filepath.Join(boardsDir, "main")); err != nil {
+		t.Fatalf("Failed to remove board: %v", err)
+	}
+
+	var out strings.Builder
+	service := &MigrateService{paths: config.NewPaths(tempDir, ""), output: &out, backup: NewBackupService()}
+	plan, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+
+	progress := make(chan MigrateProgress)
+	var events []MigrateProgress
+	collected := make(chan struct{})
+	go func() {
+		for ev := range progress {
+			events = append(events, ev)
+		}
+		close(collected)
+	}()
+
+	result, err := service.ExecuteParallel(plan, false, 3, progress)
+	if err != nil {
+		t.Fatalf("ExecuteParallel failed: %v", err)
+	}
+	<-collected
+	if result.SnapshotID == "" {
+		t.Error("Expected a snapshot ID")
+	}
+
+	started := make(map[string]int)
+	finished := make(map[string]int)
+	for _, ev := range events {
+		if ev.Error != nil {
+			t.Errorf("board %q failed: %v", ev.BoardName, ev.Error)
+		}
+		if ev.Done {
+			if started[ev.BoardName] == 0 {
+				t.Errorf("board %q finished before it started", ev.BoardName)
+			}
+			finished[ev.BoardName]++
+		} else {
+			started[ev.BoardName]++
+		}
+	}
+	for _, name := range boardNames {
+		if started[name] != 1 || finished[name] != 1 {
+			t.Errorf("board %q: expected one start and one finish event, got %d and %d", name, started[name], finished[name])
+		}
+	}
+	if len(events) != 2*len(boardNames) {
+		t.Errorf("Expected %d progress events, got %d", 2*len(boardNames), len(events))
+	}
+
+	// Output is written in plan order regardless of completion order.
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != len(boardNames) {
+		t.Fatalf("Expected one output line per board, got %q", out.String())
+	}
+	for i, name := range boardNames {
+		if !strings.Contains(lines[i], fmt.Sprintf("%q", name)) {
+			t.Errorf("line %d: expected board %q, got %q", i, name, lines[i])
+		}
+	}
+
+	plan, err = service.Plan()
+	if err != nil {
+		t.Fatalf("Second Plan failed: %v", err)
+	}
+	if plan.HasChanges() {
+		t.Error("All boards should be migrated")
+	}
+}
+
 func TestMigrateService_MigratedDataReadableByStore(t *testing.T) {
 	service, tempDir, cleanup := setupMigrationTest(t, "v0")
 	defer cleanup()
@@ -386,7 +632,7 @@ func TestMigrateService_MigratedDataReadableByStore(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Plan failed: %v", err)
 	}
-	if err := service.Execute(plan, false); err != nil {
+	if _, err := service.Execute(plan, false); err != nil {
 		t.Fatalf("Execute failed: %v", err)
 	}
 
@@ -431,7 +677,7 @@ func TestMigrateService_V0ToCurrent_ConvertsLabels(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Plan failed: %v", err)
 	}
-	if err := service.Execute(plan, false); err != nil {
+	if _, err := service.Execute(plan, false); err != nil {
 		t.Fatalf("Execute failed: %v", err)
 	}
 
@@ -524,6 +770,119 @@ func TestMigrationPlan_HasChanges(t *testing.T) {
 	}
 }
 
+func TestMigrateService_Estimate(t *testing.T) {
+	plan := &MigrationPlan{}
+	for i, n := range []int{10, 15, 25} {
+		board := BoardMigration{BoardName: fmt.Sprintf("board-%d", i), NeedsMigration: true}
+		for range n {
+			board.Cards = append(board.Cards, CardMigration{FromVersion: 7, ToVersion: 8})
+		}
+		plan.Boards = append(plan.Boards, board)
+	}
+	// Up-to-date boards and cards don't count.
+	plan.Boards = append(plan.Boards, BoardMigration{BoardName: "current", Cards: []CardMigration{{FromVersion: 8, ToVersion: 8}}})
+
+	est := NewQuietMigrateService(nil).Estimate(plan)
+	if est.BoardsToMigrate != 3 || est.CardsToMigrate != 50 {
+		t.Errorf("Expected 3 boards and 50 cards, got %d and %d", est.BoardsToMigrate, est.CardsToMigrate)
+	}
+	if est.EstimatedDurationMs <= 0 {
+		t.Errorf("Expected a positive duration, got %dms", est.EstimatedDurationMs)
+	}
+}
+
+func TestMigrateService_Diff(t *testing.T) {
+	service, tempDir, cleanup := setupMigrationTest(t, "v0")
+	defer cleanup()
+
+	configPath := filepath.Join(tempDir, ".kan", "boards", "main", "config.toml")
+	configBefore, _ := os.ReadFile(configPath)
+
+	plan, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	diff, err := service.Diff(plan)
+	if err != nil {
+		t.Fatalf("Diff failed: %v", err)
+	}
+
+	for _, want := range []string{
+		"--- boards/main/config.toml",
+		"+kan_schema = \"board/",
+		"-card_ids = [\"card-abc\"]", // membership moves to the cards (board/10)
+		"--- boards/main/cards/card-abc.json",
+		"+_v: ",
+		"+position: ",
+	} {
+		if !strings.Contains(diff, want) {
+			t.Errorf("Diff missing %q:\n%s", want, diff)
+		}
+	}
+
+	// Diffing must not touch the project.
+	if configAfter, _ := os.ReadFile(configPath); string(configAfter) != string(configBefore) {
+		t.Error("Diff modified the board config")
+	}
+	if plan2, _ := service.Plan(); !plan2.HasChanges() {
+		t.Error("Diff should leave the migration pending")
+	}
+}
+
+func TestMigrateService_Diff_NoChanges(t *testing.T) {
+	service, _, cleanup := setupMigrationTest(t, "v30")
+	defer cleanup()
+
+	plan, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	if diff, err := service.Diff(plan); err != nil || diff != "" {
+		t.Errorf("Expected an empty diff, got %q (err %v)", diff, err)
+	}
+}
+
+func TestCopyMigratedData(t *testing.T) {
+	src := config.NewPaths(t.TempDir(), "")
+	for _, path := range []string{
+		src.ProjectConfigPath(),
+		src.BoardConfigPath("main"),
+		src.CardPath("main", "card-abc"),
+		src.AuditLogPath(),
+		filepath.Join(src.KanRoot(), ".snapshots", "snap.json"),
+	} {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("x"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	dst := config.NewPaths(t.TempDir(), "")
+	if err := copyMigratedData(src, dst); err != nil {
+		t.Fatalf("copyMigratedData failed: %v", err)
+	}
+	for _, path := range []string{dst.ProjectConfigPath(), dst.BoardConfigPath("main"), dst.CardPath("main", "card-abc")} {
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("Expected %s copied: %v", path, err)
+		}
+	}
+	for _, path := range []string{dst.AuditLogPath(), filepath.Join(dst.KanRoot(), ".snapshots")} {
+		if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("Expected %s left out, got %v", path, err)
+		}
+	}
+}
+
+func TestDiffLines(t *testing.T) {
+	changes, _ := diffLines([]byte("a\nb\nc\n"), []byte("a\nx\nc\nd\n"))
+	want := []string{"-b", "+x", "+d"}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("diffLines = %v, want %v", changes, want)
+	}
+}
+
 // ============================================================================
 // Fixture Completeness Test
 // ============================================================================
@@ -603,7 +962,7 @@ func TestMigrateService_V1ToCurrent_ConvertsLabels(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Plan failed: %v", err)
 	}
-	if err := service.Execute(plan, false); err != nil {
+	if _, err := service.Execute(plan, false); err != nil {
 		t.Fatalf("Execute failed: %v", err)
 	}
 
@@ -684,7 +1043,7 @@ func TestMigrateService_V1ToV2_Idempotent(t *testing.T) {
 	if !plan1.HasChanges() {
 		t.Fatal("First plan should have changes")
 	}
-	if err := service.Execute(plan1, false); err != nil {
+	if _, err := service.Execute(plan1, false); err != nil {
 		t.Fatalf("First Execute failed: %v", err)
 	}
 
@@ -741,7 +1100,7 @@ func TestMigrateService_V2ToCurrent_UpdatesSchema(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Plan failed: %v", err)
 	}
-	if err := service.Execute(plan, false); err != nil {
+	if _, err := service.Execute(plan, false); err != nil {
 		t.Fatalf("Execute failed: %v", err)
 	}
 
@@ -780,7 +1139,7 @@ func TestMigrateService_V2ToV3_Idempotent(t *testing.T) {
 	if !plan1.HasChanges() {
 		t.Fatal("First plan should have changes")
 	}
-	if err := service.Execute(plan1, false); err != nil {
+	if _, err := service.Execute(plan1, false); err != nil {
 		t.Fatalf("First Execute failed: %v", err)
 	}
 
@@ -837,7 +1196,7 @@ func TestMigrateService_V3ToV4_UpdatesSchema(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Plan failed: %v", err)
 	}
-	if err := service.Execute(plan, false); err != nil {
+	if _, err := service.Execute(plan, false); err != nil {
 		t.Fatalf("Execute failed: %v", err)
 	}
 
@@ -879,7 +1238,7 @@ func TestMigrateService_V3ToV4_Idempotent(t *testing.T) {
 	if !plan1.HasChanges() {
 		t.Fatal("First plan should have changes")
 	}
-	if err := service.Execute(plan1, false); err != nil {
+	if _, err := service.Execute(plan1, false); err != nil {
 		t.Fatalf("First Execute failed: %v", err)
 	}
 
@@ -936,7 +1295,7 @@ func TestMigrateService_V4ToV5_RenamesTagsToEnumSet(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Plan failed: %v", err)
 	}
-	if err := service.Execute(plan, false); err != nil {
+	if _, err := service.Execute(plan, false); err != nil {
 		t.Fatalf("Execute failed: %v", err)
 	}
 
@@ -1000,7 +1359,7 @@ func TestMigrateService_V4ToV5_Idempotent(t *testing.T) {
 	if !plan1.HasChanges() {
 		t.Fatal("First plan should have changes")
 	}
-	if err := service.Execute(plan1, false); err != nil {
+	if _, err := service.Execute(plan1, false); err != nil {
 		t.Fatalf("First Execute failed: %v", err)
 	}
 
@@ -1071,7 +1430,7 @@ func TestMigrateService_V5ToV6_UpdatesSchema(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Plan failed: %v", err)
 	}
-	if err := service.Execute(plan, false); err != nil {
+	if _, err := service.Execute(plan, false); err != nil {
 		t.Fatalf("Execute failed: %v", err)
 	}
 
@@ -1129,7 +1488,7 @@ func TestMigrateService_V5ToV6_Idempotent(t *testing.T) {
 	if !plan1.HasChanges() {
 		t.Fatal("First plan should have changes")
 	}
-	if err := service.Execute(plan1, false); err != nil {
+	if _, err := service.Execute(plan1, false); err != nil {
 		t.Fatalf("First Execute failed: %v", err)
 	}
 
@@ -1186,7 +1545,7 @@ func TestMigrateService_V6ToV7_UpdatesSchema(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Plan failed: %v", err)
 	}
-	if err := service.Execute(plan, false); err != nil {
+	if _, err := service.Execute(plan, false); err != nil {
 		t.Fatalf("Execute failed: %v", err)
 	}
 
@@ -1236,7 +1595,7 @@ func TestMigrateService_V6ToV7_Idempotent(t *testing.T) {
 	if !plan1.HasChanges() {
 		t.Fatal("First plan should have changes")
 	}
-	if err := service.Execute(plan1, false); err != nil {
+	if _, err := service.Execute(plan1, false); err != nil {
 		t.Fatalf("First Execute failed: %v", err)
 	}
 
@@ -1291,7 +1650,7 @@ func TestMigrateService_V7ToV8_UpdatesSchema(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Plan failed: %v", err)
 	}
-	if err := service.Execute(plan, false); err != nil {
+	if _, err := service.Execute(plan, false); err != nil {
 		t.Fatalf("Execute failed: %v", err)
 	}
 
@@ -1342,7 +1701,7 @@ func TestMigrateService_V7ToV8_Idempotent(t *testing.T) {
 	if !plan1.HasChanges() {
 		t.Fatal("First plan should have changes")
 	}
-	if err := service.Execute(plan1, false); err != nil {
+	if _, err := service.Execute(plan1, false); err != nil {
 		t.Fatalf("First Execute failed: %v", err)
 	}
 
@@ -1397,7 +1756,7 @@ func TestMigrateService_V8ToV9_UpdatesSchema(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Plan failed: %v", err)
 	}
-	if err := service.Execute(plan, false); err != nil {
+	if _, err := service.Execute(plan, false); err != nil {
 		t.Fatalf("Execute failed: %v", err)
 	}
 
@@ -1448,7 +1807,7 @@ func TestMigrateService_V8ToV9_Idempotent(t *testing.T) {
 	if !plan1.HasChanges() {
 		t.Fatal("First plan should have changes")
 	}
-	if err := service.Execute(plan1, false); err != nil {
+	if _, err := service.Execute(plan1, false); err != nil {
 		t.Fatalf("First Execute failed: %v", err)
 	}
 
@@ -1478,7 +1837,7 @@ func TestMigrateService_V10ToV11_UpdatesSchema(t *testing.T) {
 	if !plan.HasChanges() {
 		t.Fatal("v10 data should need migration to v11")
 	}
-	if err := service.Execute(plan, false); err != nil {
+	if _, err := service.Execute(plan, false); err != nil {
 		t.Fatalf("Execute failed: %v", err)
 	}
 
@@ -1520,7 +1879,7 @@ func TestMigrateService_V10ToV11_Idempotent(t *testing.T) {
 	if !plan1.HasChanges() {
 		t.Fatal("First plan should have changes")
 	}
-	if err := service.Execute(plan1, false); err != nil {
+	if _, err := service.Execute(plan1, false); err != nil {
 		t.Fatalf("First Execute failed: %v", err)
 	}
 
@@ -1550,7 +1909,7 @@ func TestMigrateService_V11ToV12_UpdatesSchema(t *testing.T) {
 	if !plan.HasChanges() {
 		t.Fatal("v11 data should need migration to v12")
 	}
-	if err := service.Execute(plan, false); err != nil {
+	if _, err := service.Execute(plan, false); err != nil {
 		t.Fatalf("Execute failed: %v", err)
 	}
 
@@ -1596,7 +1955,7 @@ func TestMigrateService_V11ToV12_Idempotent(t *testing.T) {
 	if !plan1.HasChanges() {
 		t.Fatal("First plan should have changes")
 	}
-	if err := service.Execute(plan1, false); err != nil {
+	if _, err := service.Execute(plan1, false); err != nil {
 		t.Fatalf("First Execute failed: %v", err)
 	}
 
@@ -1611,84 +1970,1214 @@ func TestMigrateService_V11ToV12_Idempotent(t *testing.T) {
 }
 
 // ============================================================================
-// V12 Tests (Current schema - no migration needed)
+// V12 Tests (board/12 -> board/13, schema-only bump for integer field type)
 // ============================================================================
 
-func TestMigrateService_Plan_V12_NoChanges(t *testing.T) {
-	service, _, cleanup := setupMigrationTest(t, "v12")
+func TestMigrateService_V12ToV13_UpdatesSchema(t *testing.T) {
+	service, tempDir, cleanup := setupMigrationTest(t, "v12")
 	defer cleanup()
 
+	// Migrate
 	plan, err := service.Plan()
 	if err != nil {
 		t.Fatalf("Plan failed: %v", err)
 	}
-	if plan.HasChanges() {
-		t.Error("Current schema (v12) data should not need migration")
+	if !plan.HasChanges() {
+		t.Fatal("v12 data should need migration to v13")
+	}
+	if _, err := service.Execute(plan, false); err != nil {
+		t.Fatalf("Execute failed: %v", err)
 	}
-}
-
-func TestMigrateService_V12_ReadableByStores(t *testing.T) {
-	_, tempDir, cleanup := setupMigrationTest(t, "v12")
-	defer cleanup()
 
-	// V12 fixtures should be directly readable by stores without migration
+	// Verify stores can read the migrated data
 	paths := config.NewPaths(tempDir, "")
-	cardStore := store.NewCardStore(paths)
 	boardStore := store.NewBoardStore(paths)
 
-	// Board store should read without error
 	boardCfg, err := boardStore.Get("main")
 	if err != nil {
-		t.Fatalf("BoardStore.Get failed on v12 fixtures: %v", err)
-	}
-	if boardCfg.Name != "main" {
-		t.Errorf("Board name = %q, want 'main'", boardCfg.Name)
+		t.Fatalf("BoardStore.Get failed after migration: %v", err)
 	}
+
+	// Verify schema was updated
 	if boardCfg.KanSchema != version.CurrentBoardSchema() {
-		t.Errorf("Board KanSchema = %q, want %q", boardCfg.KanSchema, version.CurrentBoardSchema())
+		t.Errorf("Expected KanSchema %q, got %q", version.CurrentBoardSchema(), boardCfg.KanSchema)
 	}
 
-	// Column description should be present
-	backlog := boardCfg.GetColumn("Backlog")
-	if backlog == nil {
-		t.Fatal("Expected 'Backlog' column")
+	// Existing fields should be preserved
+	if len(boardCfg.CustomFields) == 0 {
+		t.Error("CustomFields should be preserved")
 	}
-	if backlog.Description != "Cards that are planned but not yet started" {
-		t.Errorf("Expected Backlog description, got %q", backlog.Description)
+	// Default sort (added in v12) should survive the v13 bump.
+	if boardCfg.CardDisplay.DefaultSort != "type" {
+		t.Errorf("Expected CardDisplay.DefaultSort = 'type', got %q", boardCfg.CardDisplay.DefaultSort)
 	}
+}
 
-	// Column limit should be present
-	if backlog.Limit != 5 {
-		t.Errorf("Expected Backlog Limit 5, got %d", backlog.Limit)
-	}
+func TestMigrateService_V12ToV13_Idempotent(t *testing.T) {
+	service, _, cleanup := setupMigrationTest(t, "v12")
+	defer cleanup()
 
-	// Done column should have no limit
-	done := boardCfg.GetColumn("Done")
-	if done == nil {
-		t.Fatal("Expected 'Done' column")
+	// First migration
+	plan1, err := service.Plan()
+	if err != nil {
+		t.Fatalf("First Plan failed: %v", err)
 	}
-	if done.Limit != 0 {
-		t.Errorf("Expected Done Limit 0 (no limit), got %d", done.Limit)
+	if !plan1.HasChanges() {
+		t.Fatal("First plan should have changes")
 	}
-
-	// Pattern hooks should be present
-	if len(boardCfg.PatternHooks) != 1 {
-		t.Errorf("Expected 1 pattern hook, got %d", len(boardCfg.PatternHooks))
-	} else {
-		hook := boardCfg.PatternHooks[0]
-		if hook.Name != "jira-sync" {
-			t.Errorf("Expected hook name 'jira-sync', got %q", hook.Name)
-		}
-		if hook.Timeout != 60 {
-			t.Errorf("Expected hook timeout 60, got %d", hook.Timeout)
-		}
+	if _, err := service.Execute(plan1, false); err != nil {
+		t.Fatalf("First Execute failed: %v", err)
 	}
 
-	// Wanted field should be present
-	typeSchema, ok := boardCfg.CustomFields["type"]
-	if !ok {
-		t.Error("Expected 'type' custom field")
-	} else {
+	// Second migration should be no-op
+	plan2, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Second Plan failed: %v", err)
+	}
+	if plan2.HasChanges() {
+		t.Error("Second plan should have no changes (migration is idempotent)")
+	}
+}
+
+// ============================================================================
+// V13 Tests (board/13 -> board/14, schema-only bump for webhook hooks)
+// ============================================================================
+
+func TestMigrateService_V13ToV14_UpdatesSchema(t *testing.T) {
+	service, tempDir, cleanup := setupMigrationTest(t, "v13")
+	defer cleanup()
+
+	plan, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	if !plan.HasChanges() {
+		t.Fatal("v13 data should need migration to v14")
+	}
+	if _, err := service.Execute(plan, false); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	paths := config.NewPaths(tempDir, "")
+	boardCfg, err := store.NewBoardStore(paths).Get("main")
+	if err != nil {
+		t.Fatalf("BoardStore.Get failed after migration: %v", err)
+	}
+
+	if boardCfg.KanSchema != version.CurrentBoardSchema() {
+		t.Errorf("Expected KanSchema %q, got %q", version.CurrentBoardSchema(), boardCfg.KanSchema)
+	}
+
+	// The existing command hook should survive the v14 bump untouched.
+	if len(boardCfg.PatternHooks) != 1 || boardCfg.PatternHooks[0].Command != "~/.kan/hooks/jira-sync.sh" {
+		t.Errorf("Expected command hook to be preserved, got %+v", boardCfg.PatternHooks)
+	}
+	if _, ok := boardCfg.CustomFields["story_points"]; !ok {
+		t.Error("Integer field (added in v13) should be preserved")
+	}
+}
+
+func TestMigrateService_V13ToV14_Idempotent(t *testing.T) {
+	service, _, cleanup := setupMigrationTest(t, "v13")
+	defer cleanup()
+
+	plan1, err := service.Plan()
+	if err != nil {
+		t.Fatalf("First Plan failed: %v", err)
+	}
+	if !plan1.HasChanges() {
+		t.Fatal("First plan should have changes")
+	}
+	if _, err := service.Execute(plan1, false); err != nil {
+		t.Fatalf("First Execute failed: %v", err)
+	}
+
+	plan2, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Second Plan failed: %v", err)
+	}
+	if plan2.HasChanges() {
+		t.Error("Second plan should have no changes (migration is idempotent)")
+	}
+}
+
+// ============================================================================
+// V14 Tests (board/14 -> board/15, schema-only bump for transition rules)
+// ============================================================================
+
+func TestMigrateService_V14ToV15_UpdatesSchema(t *testing.T) {
+	service, tempDir, cleanup := setupMigrationTest(t, "v14")
+	defer cleanup()
+
+	plan, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	if !plan.HasChanges() {
+		t.Fatal("v14 data should need migration to v15")
+	}
+	if _, err := service.Execute(plan, false); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	paths := config.NewPaths(tempDir, "")
+	boardCfg, err := store.NewBoardStore(paths).Get("main")
+	if err != nil {
+		t.Fatalf("BoardStore.Get failed after migration: %v", err)
+	}
+
+	if boardCfg.KanSchema != version.CurrentBoardSchema() {
+		t.Errorf("Expected KanSchema %q, got %q", version.CurrentBoardSchema(), boardCfg.KanSchema)
+	}
+
+	// Columns carry over with no transition rules.
+	for _, col := range boardCfg.Columns {
+		if len(col.TransitionRules) != 0 {
+			t.Errorf("Expected no transition rules on %q, got %+v", col.Name, col.TransitionRules)
+		}
+	}
+	if len(boardCfg.PatternHooks) != 2 || boardCfg.PatternHooks[1].Webhook == "" {
+		t.Errorf("Webhook hook (added in v14) should be preserved, got %+v", boardCfg.PatternHooks)
+	}
+}
+
+func TestMigrateService_V14ToV15_Idempotent(t *testing.T) {
+	service, _, cleanup := setupMigrationTest(t, "v14")
+	defer cleanup()
+
+	plan1, err := service.Plan()
+	if err != nil {
+		t.Fatalf("First Plan failed: %v", err)
+	}
+	if !plan1.HasChanges() {
+		t.Fatal("First plan should have changes")
+	}
+	if _, err := service.Execute(plan1, false); err != nil {
+		t.Fatalf("First Execute failed: %v", err)
+	}
+
+	plan2, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Second Plan failed: %v", err)
+	}
+	if plan2.HasChanges() {
+		t.Error("Second plan should have no changes (migration is idempotent)")
+	}
+}
+
+// ============================================================================
+// V15 Tests (board/15 -> board/16, schema-only bump for url fields)
+// ============================================================================
+
+func TestMigrateService_V15ToV16_UpdatesSchema(t *testing.T) {
+	service, tempDir, cleanup := setupMigrationTest(t, "v15")
+	defer cleanup()
+
+	plan, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	if !plan.HasChanges() {
+		t.Fatal("v15 data should need migration to v16")
+	}
+	if _, err := service.Execute(plan, false); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	paths := config.NewPaths(tempDir, "")
+	boardCfg, err := store.NewBoardStore(paths).Get("main")
+	if err != nil {
+		t.Fatalf("BoardStore.Get failed after migration: %v", err)
+	}
+
+	if boardCfg.KanSchema != version.CurrentBoardSchema() {
+		t.Errorf("Expected KanSchema %q, got %q", version.CurrentBoardSchema(), boardCfg.KanSchema)
+	}
+
+	// Existing fields carry over with no patterns.
+	for name, schema := range boardCfg.CustomFields {
+		if schema.Pattern != "" {
+			t.Errorf("Expected no pattern on %q, got %q", name, schema.Pattern)
+		}
+	}
+	done := boardCfg.Columns[len(boardCfg.Columns)-1]
+	if len(done.TransitionRules) != 1 {
+		t.Errorf("Transition rules (added in v15) should be preserved, got %+v", done.TransitionRules)
+	}
+}
+
+func TestMigrateService_V15ToV16_Idempotent(t *testing.T) {
+	service, _, cleanup := setupMigrationTest(t, "v15")
+	defer cleanup()
+
+	plan1, err := service.Plan()
+	if err != nil {
+		t.Fatalf("First Plan failed: %v", err)
+	}
+	if !plan1.HasChanges() {
+		t.Fatal("First plan should have changes")
+	}
+	if _, err := service.Execute(plan1, false); err != nil {
+		t.Fatalf("First Execute failed: %v", err)
+	}
+
+	plan2, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Second Plan failed: %v", err)
+	}
+	if plan2.HasChanges() {
+		t.Error("Second plan should have no changes (migration is idempotent)")
+	}
+}
+
+// ============================================================================
+// V16 Tests (board/16 -> board/17, schema-only bump for alias config)
+// ============================================================================
+
+func TestMigrateService_V16ToV17_UpdatesSchema(t *testing.T) {
+	service, tempDir, cleanup := setupMigrationTest(t, "v16")
+	defer cleanup()
+
+	plan, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	if !plan.HasChanges() {
+		t.Fatal("v16 data should need migration to v17")
+	}
+	if _, err := service.Execute(plan, false); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	paths := config.NewPaths(tempDir, "")
+	boardCfg, err := store.NewBoardStore(paths).Get("main")
+	if err != nil {
+		t.Fatalf("BoardStore.Get failed after migration: %v", err)
+	}
+
+	if boardCfg.KanSchema != version.CurrentBoardSchema() {
+		t.Errorf("Expected KanSchema %q, got %q", version.CurrentBoardSchema(), boardCfg.KanSchema)
+	}
+
+	// Boards without an [alias] section keep the default strategy.
+	if boardCfg.Alias != (model.AliasConfig{}) {
+		t.Errorf("Expected empty alias config, got %+v", boardCfg.Alias)
+	}
+	if _, ok := boardCfg.CustomFields["pr"]; !ok {
+		t.Error("URL field (added in v16) should be preserved")
+	}
+}
+
+func TestMigrateService_V16ToV17_Idempotent(t *testing.T) {
+	service, _, cleanup := setupMigrationTest(t, "v16")
+	defer cleanup()
+
+	plan1, err := service.Plan()
+	if err != nil {
+		t.Fatalf("First Plan failed: %v", err)
+	}
+	if !plan1.HasChanges() {
+		t.Fatal("First plan should have changes")
+	}
+	if _, err := service.Execute(plan1, false); err != nil {
+		t.Fatalf("First Execute failed: %v", err)
+	}
+
+	plan2, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Second Plan failed: %v", err)
+	}
+	if plan2.HasChanges() {
+		t.Error("Second plan should have no changes (migration is idempotent)")
+	}
+}
+
+// ============================================================================
+// V17 Tests (board/17 -> board/18, schema-only bump for tags fields)
+// ============================================================================
+
+func TestMigrateService_V17ToV18_UpdatesSchema(t *testing.T) {
+	service, tempDir, cleanup := setupMigrationTest(t, "v17")
+	defer cleanup()
+
+	plan, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	if !plan.HasChanges() {
+		t.Fatal("v17 data should need migration to v18")
+	}
+	if _, err := service.Execute(plan, false); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	paths := config.NewPaths(tempDir, "")
+	boardCfg, err := store.NewBoardStore(paths).Get("main")
+	if err != nil {
+		t.Fatalf("BoardStore.Get failed after migration: %v", err)
+	}
+
+	if boardCfg.KanSchema != version.CurrentBoardSchema() {
+		t.Errorf("Expected KanSchema %q, got %q", version.CurrentBoardSchema(), boardCfg.KanSchema)
+	}
+	if topics := boardCfg.CustomFields["topics"]; topics.Type != model.FieldTypeFreeSet || topics.MaxLength != 0 {
+		t.Errorf("Expected free-set topics field to be preserved with default max length, got %+v", topics)
+	}
+	if len(boardCfg.CardDisplay.Tags) != 0 {
+		t.Errorf("Expected no card_display.tags, got %v", boardCfg.CardDisplay.Tags)
+	}
+}
+
+func TestMigrateService_V17ToV18_Idempotent(t *testing.T) {
+	service, _, cleanup := setupMigrationTest(t, "v17")
+	defer cleanup()
+
+	plan1, err := service.Plan()
+	if err != nil {
+		t.Fatalf("First Plan failed: %v", err)
+	}
+	if !plan1.HasChanges() {
+		t.Fatal("First plan should have changes")
+	}
+	if _, err := service.Execute(plan1, false); err != nil {
+		t.Fatalf("First Execute failed: %v", err)
+	}
+
+	plan2, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Second Plan failed: %v", err)
+	}
+	if plan2.HasChanges() {
+		t.Error("Second plan should have no changes (migration is idempotent)")
+	}
+}
+
+// ============================================================================
+// V18 Tests (board/18 -> board/19, schema-only bump for done columns)
+// ============================================================================
+
+func TestMigrateService_V18ToV19_UpdatesSchema(t *testing.T) {
+	service, tempDir, cleanup := setupMigrationTest(t, "v18")
+	defer cleanup()
+
+	plan, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	if !plan.HasChanges() {
+		t.Fatal("v18 data should need migration to v19")
+	}
+	if _, err := service.Execute(plan, false); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	paths := config.NewPaths(tempDir, "")
+	boardCfg, err := store.NewBoardStore(paths).Get("main")
+	if err != nil {
+		t.Fatalf("BoardStore.Get failed after migration: %v", err)
+	}
+
+	if boardCfg.KanSchema != version.CurrentBoardSchema() {
+		t.Errorf("Expected KanSchema %q, got %q", version.CurrentBoardSchema(), boardCfg.KanSchema)
+	}
+	for _, col := range boardCfg.Columns {
+		if col.Done || col.AutoArchiveAfterDays != 0 {
+			t.Errorf("Expected column %q to have no done policy, got %+v", col.Name, col)
+		}
+	}
+}
+
+func TestMigrateService_V18ToV19_Idempotent(t *testing.T) {
+	service, _, cleanup := setupMigrationTest(t, "v18")
+	defer cleanup()
+
+	plan1, err := service.Plan()
+	if err != nil {
+		t.Fatalf("First Plan failed: %v", err)
+	}
+	if !plan1.HasChanges() {
+		t.Fatal("First plan should have changes")
+	}
+	if _, err := service.Execute(plan1, false); err != nil {
+		t.Fatalf("First Execute failed: %v", err)
+	}
+
+	plan2, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Second Plan failed: %v", err)
+	}
+	if plan2.HasChanges() {
+		t.Error("Second plan should have no changes (migration is idempotent)")
+	}
+}
+
+// ============================================================================
+// V19 Tests (board/19 -> board/20, schema-only bump for date field settings)
+// ============================================================================
+
+func TestMigrateService_V19ToV20_UpdatesSchema(t *testing.T) {
+	service, tempDir, cleanup := setupMigrationTest(t, "v19")
+	defer cleanup()
+
+	plan, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	if !plan.HasChanges() {
+		t.Fatal("v19 data should need migration to v20")
+	}
+	if _, err := service.Execute(plan, false); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	paths := config.NewPaths(tempDir, "")
+	boardCfg, err := store.NewBoardStore(paths).Get("main")
+	if err != nil {
+		t.Fatalf("BoardStore.Get failed after migration: %v", err)
+	}
+
+	if boardCfg.KanSchema != version.CurrentBoardSchema() {
+		t.Errorf("Expected KanSchema %q, got %q", version.CurrentBoardSchema(), boardCfg.KanSchema)
+	}
+	if done := boardCfg.GetColumn("Done"); done == nil || !done.Done {
+		t.Errorf("Expected Done column to keep its done policy, got %+v", done)
+	}
+}
+
+func TestMigrateService_V19ToV20_Idempotent(t *testing.T) {
+	service, _, cleanup := setupMigrationTest(t, "v19")
+	defer cleanup()
+
+	plan1, err := service.Plan()
+	if err != nil {
+		t.Fatalf("First Plan failed: %v", err)
+	}
+	if !plan1.HasChanges() {
+		t.Fatal("First plan should have changes")
+	}
+	if _, err := service.Execute(plan1, false); err != nil {
+		t.Fatalf("First Execute failed: %v", err)
+	}
+
+	plan2, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Second Plan failed: %v", err)
+	}
+	if plan2.HasChanges() {
+		t.Error("Second plan should have no changes (migration is idempotent)")
+	}
+}
+
+// ============================================================================
+// V20 Tests (board/20 -> board/21, schema-only bump for done_columns)
+// ============================================================================
+
+func TestMigrateService_V20ToV21_UpdatesSchema(t *testing.T) {
+	service, tempDir, cleanup := setupMigrationTest(t, "v20")
+	defer cleanup()
+
+	plan, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	if !plan.HasChanges() {
+		t.Fatal("v20 data should need migration to v21")
+	}
+	if _, err := service.Execute(plan, false); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	paths := config.NewPaths(tempDir, "")
+	boardCfg, err := store.NewBoardStore(paths).Get("main")
+	if err != nil {
+		t.Fatalf("BoardStore.Get failed after migration: %v", err)
+	}
+
+	if boardCfg.KanSchema != version.CurrentBoardSchema() {
+		t.Errorf("Expected KanSchema %q, got %q", version.CurrentBoardSchema(), boardCfg.KanSchema)
+	}
+	if len(boardCfg.DoneColumns) != 0 {
+		t.Errorf("Expected no done_columns after migration, got %v", boardCfg.DoneColumns)
+	}
+}
+
+func TestMigrateService_V20ToV21_Idempotent(t *testing.T) {
+	service, _, cleanup := setupMigrationTest(t, "v20")
+	defer cleanup()
+
+	plan1, err := service.Plan()
+	if err != nil {
+		t.Fatalf("First Plan failed: %v", err)
+	}
+	if !plan1.HasChanges() {
+		t.Fatal("First plan should have changes")
+	}
+	if _, err := service.Execute(plan1, false); err != nil {
+		t.Fatalf("First Execute failed: %v", err)
+	}
+
+	plan2, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Second Plan failed: %v", err)
+	}
+	if plan2.HasChanges() {
+		t.Error("Second plan should have no changes (migration is idempotent)")
+	}
+}
+
+// ============================================================================
+// V21 Tests (board/21 -> board/22, schema-only bump for stale card settings)
+// ============================================================================
+
+func TestMigrateService_V21ToV22_UpdatesSchema(t *testing.T) {
+	service, tempDir, cleanup := setupMigrationTest(t, "v21")
+	defer cleanup()
+
+	plan, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	if !plan.HasChanges() {
+		t.Fatal("v21 data should need migration to v22")
+	}
+	if _, err := service.Execute(plan, false); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	paths := config.NewPaths(tempDir, "")
+	boardCfg, err := store.NewBoardStore(paths).Get("main")
+	if err != nil {
+		t.Fatalf("BoardStore.Get failed after migration: %v", err)
+	}
+
+	if boardCfg.KanSchema != version.CurrentBoardSchema() {
+		t.Errorf("Expected KanSchema %q, got %q", version.CurrentBoardSchema(), boardCfg.KanSchema)
+	}
+	if !reflect.DeepEqual(boardCfg.DoneColumns, []string{"Done"}) {
+		t.Errorf("Expected done_columns to survive migration, got %v", boardCfg.DoneColumns)
+	}
+	if boardCfg.Stale.StaleDays != 0 {
+		t.Errorf("Expected stale detection off after migration, got %+v", boardCfg.Stale)
+	}
+}
+
+func TestMigrateService_V21ToV22_Idempotent(t *testing.T) {
+	service, _, cleanup := setupMigrationTest(t, "v21")
+	defer cleanup()
+
+	plan1, err := service.Plan()
+	if err != nil {
+		t.Fatalf("First Plan failed: %v", err)
+	}
+	if !plan1.HasChanges() {
+		t.Fatal("First plan should have changes")
+	}
+	if _, err := service.Execute(plan1, false); err != nil {
+		t.Fatalf("First Execute failed: %v", err)
+	}
+
+	plan2, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Second Plan failed: %v", err)
+	}
+	if plan2.HasChanges() {
+		t.Error("Second plan should have no changes (migration is idempotent)")
+	}
+}
+
+// ============================================================================
+// V22 Tests (board/22 -> board/23, schema-only bump for hook command args)
+// ============================================================================
+
+func TestMigrateService_V22ToV23_UpdatesSchema(t *testing.T) {
+	service, tempDir, cleanup := setupMigrationTest(t, "v22")
+	defer cleanup()
+
+	plan, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	if !plan.HasChanges() {
+		t.Fatal("v22 data should need migration to v23")
+	}
+	if _, err := service.Execute(plan, false); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	paths := config.NewPaths(tempDir, "")
+	boardCfg, err := store.NewBoardStore(paths).Get("main")
+	if err != nil {
+		t.Fatalf("BoardStore.Get failed after migration: %v", err)
+	}
+
+	if boardCfg.KanSchema != version.CurrentBoardSchema() {
+		t.Errorf("Expected KanSchema %q, got %q", version.CurrentBoardSchema(), boardCfg.KanSchema)
+	}
+	if boardCfg.Stale.StaleDays != 30 {
+		t.Errorf("Expected stale settings to survive migration, got %+v", boardCfg.Stale)
+	}
+	for _, hook := range boardCfg.PatternHooks {
+		if len(hook.CommandArgs) != 0 {
+			t.Errorf("Expected no command_args after migration, got %+v", hook)
+		}
+	}
+}
+
+func TestMigrateService_V22ToV23_Idempotent(t *testing.T) {
+	service, _, cleanup := setupMigrationTest(t, "v22")
+	defer cleanup()
+
+	plan1, err := service.Plan()
+	if err != nil {
+		t.Fatalf("First Plan failed: %v", err)
+	}
+	if !plan1.HasChanges() {
+		t.Fatal("First plan should have changes")
+	}
+	if _, err := service.Execute(plan1, false); err != nil {
+		t.Fatalf("First Execute failed: %v", err)
+	}
+
+	plan2, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Second Plan failed: %v", err)
+	}
+	if plan2.HasChanges() {
+		t.Error("Second plan should have no changes (migration is idempotent)")
+	}
+}
+
+// ============================================================================
+// V23 Tests (board/23 -> board/24, schema-only bump for board archiving)
+// ============================================================================
+
+func TestMigrateService_V23ToV24_UpdatesSchema(t *testing.T) {
+	service, tempDir, cleanup := setupMigrationTest(t, "v23")
+	defer cleanup()
+
+	plan, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	if !plan.HasChanges() {
+		t.Fatal("v23 data should need migration to v24")
+	}
+	if _, err := service.Execute(plan, false); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	paths := config.NewPaths(tempDir, "")
+	boardCfg, err := store.NewBoardStore(paths).Get("main")
+	if err != nil {
+		t.Fatalf("BoardStore.Get failed after migration: %v", err)
+	}
+
+	if boardCfg.KanSchema != version.CurrentBoardSchema() {
+		t.Errorf("Expected KanSchema %q, got %q", version.CurrentBoardSchema(), boardCfg.KanSchema)
+	}
+	if len(boardCfg.PatternHooks) == 0 || len(boardCfg.PatternHooks[0].CommandArgs) != 2 {
+		t.Errorf("Expected hook command args to survive migration, got %+v", boardCfg.PatternHooks)
+	}
+	if boardCfg.IsArchived() {
+		t.Error("Expected board to stay active after migration")
+	}
+}
+
+func TestMigrateService_V23ToV24_Idempotent(t *testing.T) {
+	service, _, cleanup := setupMigrationTest(t, "v23")
+	defer cleanup()
+
+	plan1, err := service.Plan()
+	if err != nil {
+		t.Fatalf("First Plan failed: %v", err)
+	}
+	if !plan1.HasChanges() {
+		t.Fatal("First plan should have changes")
+	}
+	if _, err := service.Execute(plan1, false); err != nil {
+		t.Fatalf("First Execute failed: %v", err)
+	}
+
+	plan2, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Second Plan failed: %v", err)
+	}
+	if plan2.HasChanges() {
+		t.Error("Second plan should have no changes (migration is idempotent)")
+	}
+}
+
+// ============================================================================
+// V24 Tests (board/24 -> board/25, schema-only bump for card templates)
+// ============================================================================
+
+func TestMigrateService_V24ToV25_UpdatesSchema(t *testing.T) {
+	service, tempDir, cleanup := setupMigrationTest(t, "v24")
+	defer cleanup()
+
+	plan, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	if !plan.HasChanges() {
+		t.Fatal("v24 data should need migration to v25")
+	}
+	if _, err := service.Execute(plan, false); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	paths := config.NewPaths(tempDir, "")
+	boardCfg, err := store.NewBoardStore(paths).Get("main")
+	if err != nil {
+		t.Fatalf("BoardStore.Get failed after migration: %v", err)
+	}
+
+	if boardCfg.KanSchema != version.CurrentBoardSchema() {
+		t.Errorf("Expected KanSchema %q, got %q", version.CurrentBoardSchema(), boardCfg.KanSchema)
+	}
+	if boardCfg.ArchivedAtMillis != 1700000000000 {
+		t.Errorf("Expected archive timestamp to survive migration, got %d", boardCfg.ArchivedAtMillis)
+	}
+	if len(boardCfg.Templates) != 0 {
+		t.Errorf("Expected no templates after migration, got %+v", boardCfg.Templates)
+	}
+}
+
+func TestMigrateService_V24ToV25_Idempotent(t *testing.T) {
+	service, _, cleanup := setupMigrationTest(t, "v24")
+	defer cleanup()
+
+	plan1, err := service.Plan()
+	if err != nil {
+		t.Fatalf("First Plan failed: %v", err)
+	}
+	if !plan1.HasChanges() {
+		t.Fatal("First plan should have changes")
+	}
+	if _, err := service.Execute(plan1, false); err != nil {
+		t.Fatalf("First Execute failed: %v", err)
+	}
+
+	plan2, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Second Plan failed: %v", err)
+	}
+	if plan2.HasChanges() {
+		t.Error("Second plan should have no changes (migration is idempotent)")
+	}
+}
+
+// ============================================================================
+// V25 Tests (board/25 -> board/26, schema-only bump for custom field order)
+// ============================================================================
+
+func TestMigrateService_V25ToV26_UpdatesSchema(t *testing.T) {
+	service, tempDir, cleanup := setupMigrationTest(t, "v25")
+	defer cleanup()
+
+	plan, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	if !plan.HasChanges() {
+		t.Fatal("v25 data should need migration to v26")
+	}
+	if _, err := service.Execute(plan, false); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	paths := config.NewPaths(tempDir, "")
+	boardCfg, err := store.NewBoardStore(paths).Get("main")
+	if err != nil {
+		t.Fatalf("BoardStore.Get failed after migration: %v", err)
+	}
+
+	if boardCfg.KanSchema != version.CurrentBoardSchema() {
+		t.Errorf("Expected KanSchema %q, got %q", version.CurrentBoardSchema(), boardCfg.KanSchema)
+	}
+	if len(boardCfg.Templates) != 1 {
+		t.Errorf("Expected templates to survive migration, got %+v", boardCfg.Templates)
+	}
+	for name, schema := range boardCfg.CustomFields {
+		if schema.Order != 0 {
+			t.Errorf("Expected no order on field %q after migration, got %d", name, schema.Order)
+		}
+	}
+}
+
+func TestMigrateService_V25ToV26_Idempotent(t *testing.T) {
+	service, _, cleanup := setupMigrationTest(t, "v25")
+	defer cleanup()
+
+	plan1, err := service.Plan()
+	if err != nil {
+		t.Fatalf("First Plan failed: %v", err)
+	}
+	if !plan1.HasChanges() {
+		t.Fatal("First plan should have changes")
+	}
+	if _, err := service.Execute(plan1, false); err != nil {
+		t.Fatalf("First Execute failed: %v", err)
+	}
+
+	plan2, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Second Plan failed: %v", err)
+	}
+	if plan2.HasChanges() {
+		t.Error("Second plan should have no changes (migration is idempotent)")
+	}
+}
+
+func TestMigrateService_V26ToV27_UpdatesSchema(t *testing.T) {
+	service, tempDir, cleanup := setupMigrationTest(t, "v26")
+	defer cleanup()
+
+	plan, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	if !plan.HasChanges() {
+		t.Fatal("v26 data should need migration to v27")
+	}
+	if _, err := service.Execute(plan, false); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	paths := config.NewPaths(tempDir, "")
+	boardCfg, err := store.NewBoardStore(paths).Get("main")
+	if err != nil {
+		t.Fatalf("BoardStore.Get failed after migration: %v", err)
+	}
+
+	if boardCfg.KanSchema != version.CurrentBoardSchema() {
+		t.Errorf("Expected KanSchema %q, got %q", version.CurrentBoardSchema(), boardCfg.KanSchema)
+	}
+	if boardCfg.CustomFields["type"].Order != 1 {
+		t.Errorf("Expected field order to survive migration, got %+v", boardCfg.CustomFields["type"])
+	}
+	if len(boardCfg.Collaborators) != 0 {
+		t.Errorf("Expected no collaborators after migration, got %v", boardCfg.Collaborators)
+	}
+}
+
+func TestMigrateService_V26ToV27_Idempotent(t *testing.T) {
+	service, _, cleanup := setupMigrationTest(t, "v26")
+	defer cleanup()
+
+	plan1, err := service.Plan()
+	if err != nil {
+		t.Fatalf("First Plan failed: %v", err)
+	}
+	if !plan1.HasChanges() {
+		t.Fatal("First plan should have changes")
+	}
+	if _, err := service.Execute(plan1, false); err != nil {
+		t.Fatalf("First Execute failed: %v", err)
+	}
+
+	plan2, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Second Plan failed: %v", err)
+	}
+	if plan2.HasChanges() {
+		t.Error("Second plan should have no changes (migration is idempotent)")
+	}
+}
+
+func TestMigrateService_V27ToV28_UpdatesSchema(t *testing.T) {
+	service, tempDir, cleanup := setupMigrationTest(t, "v27")
+	defer cleanup()
+
+	plan, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	if !plan.HasChanges() {
+		t.Fatal("v27 data should need migration to v28")
+	}
+	if _, err := service.Execute(plan, false); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	paths := config.NewPaths(tempDir, "")
+	boardCfg, err := store.NewBoardStore(paths).Get("main")
+	if err != nil {
+		t.Fatalf("BoardStore.Get failed after migration: %v", err)
+	}
+
+	if boardCfg.KanSchema != version.CurrentBoardSchema() {
+		t.Errorf("Expected KanSchema %q, got %q", version.CurrentBoardSchema(), boardCfg.KanSchema)
+	}
+	if !reflect.DeepEqual(boardCfg.Collaborators, []string{"alice", "bob"}) {
+		t.Errorf("Expected collaborators to survive migration, got %v", boardCfg.Collaborators)
+	}
+	if boardCfg.Revision != 0 {
+		t.Errorf("Expected migrated board to start at revision 0, got %d", boardCfg.Revision)
+	}
+}
+
+func TestMigrateService_V27ToV28_Idempotent(t *testing.T) {
+	service, _, cleanup := setupMigrationTest(t, "v27")
+	defer cleanup()
+
+	plan1, err := service.Plan()
+	if err != nil {
+		t.Fatalf("First Plan failed: %v", err)
+	}
+	if !plan1.HasChanges() {
+		t.Fatal("First plan should have changes")
+	}
+	if _, err := service.Execute(plan1, false); err != nil {
+		t.Fatalf("First Execute failed: %v", err)
+	}
+
+	plan2, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Second Plan failed: %v", err)
+	}
+	if plan2.HasChanges() {
+		t.Error("Second plan should have no changes (migration is idempotent)")
+	}
+}
+
+func TestMigrateService_V28ToV29_UpdatesSchema(t *testing.T) {
+	service, tempDir, cleanup := setupMigrationTest(t, "v28")
+	defer cleanup()
+
+	plan, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	if !plan.HasChanges() {
+		t.Fatal("v28 data should need migration to v29")
+	}
+	if _, err := service.Execute(plan, false); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	paths := config.NewPaths(tempDir, "")
+	boardCfg, err := store.NewBoardStore(paths).Get("main")
+	if err != nil {
+		t.Fatalf("BoardStore.Get failed after migration: %v", err)
+	}
+
+	if boardCfg.KanSchema != version.CurrentBoardSchema() {
+		t.Errorf("Expected KanSchema %q, got %q", version.CurrentBoardSchema(), boardCfg.KanSchema)
+	}
+	if boardCfg.Revision != 5 {
+		t.Errorf("Expected revision to survive migration, got %d", boardCfg.Revision)
+	}
+	if len(boardCfg.Includes) != 0 {
+		t.Errorf("Expected no includes after migration, got %v", boardCfg.Includes)
+	}
+}
+
+func TestMigrateService_V28ToV29_Idempotent(t *testing.T) {
+	service, _, cleanup := setupMigrationTest(t, "v28")
+	defer cleanup()
+
+	plan1, err := service.Plan()
+	if err != nil {
+		t.Fatalf("First Plan failed: %v", err)
+	}
+	if !plan1.HasChanges() {
+		t.Fatal("First plan should have changes")
+	}
+	if _, err := service.Execute(plan1, false); err != nil {
+		t.Fatalf("First Execute failed: %v", err)
+	}
+
+	plan2, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Second Plan failed: %v", err)
+	}
+	if plan2.HasChanges() {
+		t.Error("Second plan should have no changes (migration is idempotent)")
+	}
+}
+
+func TestMigrateService_V29ToV30_UpdatesSchema(t *testing.T) {
+	service, tempDir, cleanup := setupMigrationTest(t, "v29")
+	defer cleanup()
+
+	plan, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	if !plan.HasChanges() {
+		t.Fatal("v29 data should need migration to v30")
+	}
+	if _, err := service.Execute(plan, false); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	paths := config.NewPaths(tempDir, "")
+	boardCfg, err := store.NewBoardStore(paths).Get("main")
+	if err != nil {
+		t.Fatalf("BoardStore.Get failed after migration: %v", err)
+	}
+
+	if boardCfg.KanSchema != version.CurrentBoardSchema() {
+		t.Errorf("Expected KanSchema %q, got %q", version.CurrentBoardSchema(), boardCfg.KanSchema)
+	}
+	for _, col := range boardCfg.Columns {
+		if col.HasSprint() {
+			t.Errorf("Expected no sprint on column %q after migration", col.Name)
+		}
+	}
+}
+
+func TestMigrateService_V29ToV30_Idempotent(t *testing.T) {
+	service, _, cleanup := setupMigrationTest(t, "v29")
+	defer cleanup()
+
+	plan1, err := service.Plan()
+	if err != nil {
+		t.Fatalf("First Plan failed: %v", err)
+	}
+	if !plan1.HasChanges() {
+		t.Fatal("First plan should have changes")
+	}
+	if _, err := service.Execute(plan1, false); err != nil {
+		t.Fatalf("First Execute failed: %v", err)
+	}
+
+	plan2, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Second Plan failed: %v", err)
+	}
+	if plan2.HasChanges() {
+		t.Error("Second plan should have no changes (migration is idempotent)")
+	}
+}
+
+// ============================================================================
+// V30 Tests (Current schema - no migration needed)
+// ============================================================================
+
+func TestMigrateService_Plan_V30_NoChanges(t *testing.T) {
+	service, _, cleanup := setupMigrationTest(t, "v30")
+	defer cleanup()
+
+	plan, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	if plan.HasChanges() {
+		t.Error("Current schema (v30) data should not need migration")
+	}
+}
+
+func TestMigrateService_V30_ReadableByStores(t *testing.T) {
+	_, tempDir, cleanup := setupMigrationTest(t, "v30")
+	defer cleanup()
+
+	// V30 fixtures should be directly readable by stores without migration
+	paths := config.NewPaths(tempDir, "")
+	cardStore := store.NewCardStore(paths)
+	boardStore := store.NewBoardStore(paths)
+
+	// Board store should read without error
+	boardCfg, err := boardStore.Get("main")
+	if err != nil {
+		t.Fatalf("BoardStore.Get failed on v30 fixtures: %v", err)
+	}
+	if boardCfg.Name != "main" {
+		t.Errorf("Board name = %q, want 'main'", boardCfg.Name)
+	}
+	if boardCfg.KanSchema != version.CurrentBoardSchema() {
+		t.Errorf("Board KanSchema = %q, want %q", boardCfg.KanSchema, version.CurrentBoardSchema())
+	}
+
+	// Included fields should be merged in (new in v29)
+	if !reflect.DeepEqual(boardCfg.Includes, []string{"shared/fields.toml"}) {
+		t.Errorf("Includes = %v, want [shared/fields.toml]", boardCfg.Includes)
+	}
+	if boardCfg.CustomFields["team"].Type != model.FieldTypeEnum {
+		t.Errorf("Expected included team field of type enum, got %q", boardCfg.CustomFields["team"].Type)
+	}
+
+	// Revision should be present (new in v28)
+	if boardCfg.Revision != 5 {
+		t.Errorf("Revision = %d, want 5", boardCfg.Revision)
+	}
+
+	// Collaborators and a user field should be present (new in v27)
+	if !reflect.DeepEqual(boardCfg.Collaborators, []string{"alice", "bob"}) {
+		t.Errorf("Collaborators = %v, want [alice bob]", boardCfg.Collaborators)
+	}
+	if boardCfg.CustomFields["owner"].Type != model.FieldTypeUser {
+		t.Errorf("Expected owner type 'user', got %q", boardCfg.CustomFields["owner"].Type)
+	}
+
+	// Column description should be present
+	backlog := boardCfg.GetColumn("Backlog")
+	if backlog == nil {
+		t.Fatal("Expected 'Backlog' column")
+	}
+	if backlog.Description != "Cards that are planned but not yet started" {
+		t.Errorf("Expected Backlog description, got %q", backlog.Description)
+	}
+
+	// Column limit should be present
+	if backlog.Limit != 5 {
+		t.Errorf("Expected Backlog Limit 5, got %d", backlog.Limit)
+	}
+
+	// Sprint window should be present (new in v30)
+	if backlog.SprintStartMillis != 1700000000000 || backlog.SprintEndMillis != 1701209600000 {
+		t.Errorf("Expected Backlog sprint window, got %d-%d", backlog.SprintStartMillis, backlog.SprintEndMillis)
+	}
+
+	// Done column should have no limit
+	done := boardCfg.GetColumn("Done")
+	if done == nil {
+		t.Fatal("Expected 'Done' column")
+	}
+	if done.Limit != 0 {
+		t.Errorf("Expected Done Limit 0 (no limit), got %d", done.Limit)
+	}
+
+	// Done should carry the transition rule added in v15
+	wantRules := []model.TransitionRule{{
+		FromColumn:     "Backlog",
+		RequiredFields: []string{"type"},
+		ForbidIfFields: []string{"high_priority"},
+	}}
+	if !reflect.DeepEqual(done.TransitionRules, wantRules) {
+		t.Errorf("Expected Done transition rules %+v, got %+v", wantRules, done.TransitionRules)
+	}
+
+	// Pattern hooks should be present (the second, a webhook, is new in v14)
+	if len(boardCfg.PatternHooks) != 2 {
+		t.Errorf("Expected 2 pattern hooks, got %d", len(boardCfg.PatternHooks))
+	} else {
+		hook := boardCfg.PatternHooks[0]
+		if hook.Name != "jira-sync" {
+			t.Errorf("Expected hook name 'jira-sync', got %q", hook.Name)
+		}
+		if hook.Timeout != 60 {
+			t.Errorf("Expected hook timeout 60, got %d", hook.Timeout)
+		}
+		// Command args are new in v23
+		if !reflect.DeepEqual(hook.CommandArgs, []string{"{2}", "--project={1}"}) {
+			t.Errorf("Expected jira-sync command args, got %v", hook.CommandArgs)
+		}
+		webhook := boardCfg.PatternHooks[1]
+		if webhook.Command != "" || webhook.Webhook != "https://hooks.example.com/kan" {
+			t.Errorf("Expected webhook-only hook, got %+v", webhook)
+		}
+		if webhook.WebhookHeaders["Authorization"] != "Bearer test-token" {
+			t.Errorf("Expected Authorization webhook header, got %v", webhook.WebhookHeaders)
+		}
+	}
+
+	// Wanted field should be present
+	typeSchema, ok := boardCfg.CustomFields["type"]
+	if !ok {
+		t.Error("Expected 'type' custom field")
+	} else {
 		if !typeSchema.Wanted {
 			t.Error("Expected 'type' field to have wanted=true")
 		}
@@ -1758,10 +3247,98 @@ func TestMigrateService_V12_ReadableByStores(t *testing.T) {
 		t.Error("Expected CardDisplay.DefaultSortDesc = true")
 	}
 
+	// Integer field with bounds and labeled options should be present (new in v13)
+	spSchema, ok := boardCfg.CustomFields["story_points"]
+	if !ok {
+		t.Error("Expected 'story_points' custom field")
+	} else {
+		if spSchema.Type != "integer" {
+			t.Errorf("Expected story_points type 'integer', got %q", spSchema.Type)
+		}
+		if spSchema.Min == nil || *spSchema.Min != 0 {
+			t.Errorf("Expected story_points min 0, got %v", spSchema.Min)
+		}
+		if spSchema.Max == nil || *spSchema.Max != 144 {
+			t.Errorf("Expected story_points max 144, got %v", spSchema.Max)
+		}
+		if len(spSchema.Options) != 2 || spSchema.Options[1].Label != "large" {
+			t.Errorf("Expected labeled story_points options, got %v", spSchema.Options)
+		}
+	}
+
+	// URL field should be present with its pattern (new in v16)
+	prSchema, ok := boardCfg.CustomFields["pr"]
+	if !ok {
+		t.Error("Expected 'pr' custom field")
+	} else {
+		if prSchema.Type != "url" {
+			t.Errorf("Expected pr type 'url', got %q", prSchema.Type)
+		}
+		if prSchema.Pattern != `^https://github\.com/` {
+			t.Errorf("Expected pr pattern, got %q", prSchema.Pattern)
+		}
+	}
+
+	// Alias config should be present (new in v17)
+	if boardCfg.Alias.Style != model.AliasStyleInitials || boardCfg.Alias.MaxLength != 6 || boardCfg.Alias.Prefix != "kan-" {
+		t.Errorf("Expected initials alias config, got %+v", boardCfg.Alias)
+	}
+
+	// Tags field should read as free-set with its max length (new in v18)
+	if components := boardCfg.CustomFields["components"]; components.FieldType() != model.FieldTypeFreeSet || components.MaxLength != 20 {
+		t.Errorf("Expected tags field read as free-set with max_length 20, got %+v", components)
+	}
+	if !reflect.DeepEqual(boardCfg.CardDisplay.Tags, []string{"components"}) {
+		t.Errorf("Expected card_display.tags [components], got %v", boardCfg.CardDisplay.Tags)
+	}
+
+	// Done column policy should be present (new in v19)
+	if done := boardCfg.GetColumn("Done"); done == nil || !done.Done || done.AutoArchiveAfterDays != 14 {
+		t.Errorf("Expected Done column with a 14 day auto-archive policy, got %+v", done)
+	}
+
+	// Card templates should be present (new in v25)
+	wantTemplates := []model.CardTemplate{{
+		Name:                "bug-report",
+		TitlePattern:        "[{{.component}}] {{.summary}}",
+		DefaultColumn:       "Backlog",
+		DefaultCustomFields: map[string]string{"type": "bug"},
+		DefaultDescription:  "Severity: {{.severity}}",
+	}}
+	if !reflect.DeepEqual(boardCfg.Templates, wantTemplates) {
+		t.Errorf("Templates = %+v, want %+v", boardCfg.Templates, wantTemplates)
+	}
+
+	// Custom field order should be present (new in v27)
+	if got := boardCfg.OrderedCustomFields(); len(got) < 2 || got[len(got)-2].Name != "type" || got[len(got)-1].Name != "labels" {
+		t.Errorf("Expected type and labels ordered last, got %+v", got)
+	}
+
+	// Archive timestamp should be present (new in v24)
+	if boardCfg.ArchivedAtMillis != 1700000000000 {
+		t.Errorf("ArchivedAtMillis = %d, want 1700000000000", boardCfg.ArchivedAtMillis)
+	}
+
+	// Stale card settings should be present (new in v22)
+	wantStale := model.StaleConfig{StaleDays: 30, ExemptColumns: []string{"Done"}}
+	if !reflect.DeepEqual(boardCfg.Stale, wantStale) {
+		t.Errorf("Stale = %+v, want %+v", boardCfg.Stale, wantStale)
+	}
+
+	// Done columns should be present (new in v21)
+	if !reflect.DeepEqual(boardCfg.DoneColumns, []string{"Done"}) {
+		t.Errorf("DoneColumns = %v, want [Done]", boardCfg.DoneColumns)
+	}
+
+	// Date field settings should be present (new in v20)
+	if shipped := boardCfg.CustomFields["shipped"]; shipped.Format != model.DateFormatDateTime || !shipped.PastOnly || shipped.FutureOnly {
+		t.Errorf("Expected past-only datetime field, got %+v", shipped)
+	}
+
 	// Card store should read without error
 	card, err := cardStore.Get("main", "card-abc")
 	if err != nil {
-		t.Fatalf("CardStore.Get failed on v12 fixtures: %v", err)
+		t.Fatalf("CardStore.Get failed on v27 fixtures: %v", err)
 	}
 	if card.ID != "card-abc" {
 		t.Errorf("Card ID = %q, want 'card-abc'", card.ID)
@@ -1778,15 +3355,58 @@ func TestMigrateService_V12_ReadableByStores(t *testing.T) {
 		t.Error("Card Position should not be empty")
 	}
 
+	// Due date should be present (new in card/5)
+	if card.DueAtMillis != 1704393600000 {
+		t.Errorf("Card DueAtMillis = %d, want 1704393600000", card.DueAtMillis)
+	}
+
+	// Comment mentions should be present (new in card/9)
+	if len(card.Comments) != 1 || !reflect.DeepEqual(card.Comments[0].Mentions, []string{"alice"}) {
+		t.Errorf("Expected one comment mentioning alice, got %+v", card.Comments)
+	}
+
 	// Custom fields should be present
 	if card.CustomFields["priority"] != "high" {
 		t.Errorf("Custom field 'priority' = %v, want 'high'", card.CustomFields["priority"])
 	}
-	if card.CustomFields["high_priority"] != true {
-		t.Errorf("Custom field 'high_priority' = %v, want true", card.CustomFields["high_priority"])
+	if card.CustomFields["high_priority"] != true {
+		t.Errorf("Custom field 'high_priority' = %v, want true", card.CustomFields["high_priority"])
+	}
+	if card.CustomFields["tint"] != "red" {
+		t.Errorf("Custom field 'tint' = %v, want 'red'", card.CustomFields["tint"])
+	}
+	if card.CustomFields["story_points"] != float64(8) {
+		t.Errorf("Custom field 'story_points' = %v, want 8", card.CustomFields["story_points"])
+	}
+	if card.CustomFields["pr"] != "https://github.com/amterp/kan/pull/1" {
+		t.Errorf("Custom field 'pr' = %v, want the PR URL", card.CustomFields["pr"])
+	}
+	if card.CustomFields["shipped"] != "2024-01-04T12:00:00Z" {
+		t.Errorf("Custom field 'shipped' = %v, want the RFC 3339 timestamp", card.CustomFields["shipped"])
+	}
+	if card.CustomFields["owner"] != "alice" {
+		t.Errorf("Custom field 'owner' = %v, want 'alice'", card.CustomFields["owner"])
+	}
+
+	// Checklist should be present (new in card/7)
+	wantChecklist := []model.ChecklistItem{
+		{ID: "chk-1", Text: "Reproduce", Done: true, CreatedAtMillis: 1704307200000},
+		{ID: "chk-2", Text: "Fix", Done: false, CreatedAtMillis: 1704307200000},
+	}
+	if !reflect.DeepEqual(card.Checklist, wantChecklist) {
+		t.Errorf("Card Checklist = %+v, want %+v", card.Checklist, wantChecklist)
+	}
+
+	// Done timestamp should be present (new in card/8)
+	doneCard, err := cardStore.Get("main", "card-done")
+	if err != nil {
+		t.Fatalf("CardStore.Get failed on v27 fixtures: %v", err)
 	}
-	if card.CustomFields["tint"] != "red" {
-		t.Errorf("Custom field 'tint' = %v, want 'red'", card.CustomFields["tint"])
+	if doneCard.DoneAtMillis != 1704393600000 {
+		t.Errorf("Card DoneAtMillis = %d, want 1704393600000", doneCard.DoneAtMillis)
+	}
+	if _, ok := doneCard.CustomFields["done_at_millis"]; ok {
+		t.Error("done_at_millis should not be read as a custom field")
 	}
 }
 
@@ -1805,7 +3425,7 @@ func TestMigrateService_CardV2ToV3_SeedsHistory(t *testing.T) {
 	if !plan.HasChanges() {
 		t.Fatal("card/2 data should need migration to card/3")
 	}
-	if err := service.Execute(plan, false); err != nil {
+	if _, err := service.Execute(plan, false); err != nil {
 		t.Fatalf("Execute failed: %v", err)
 	}
 
@@ -1842,27 +3462,373 @@ func TestMigrateService_CardV2ToV3_SeedsHistory(t *testing.T) {
 	if entry["value"] != "Done" {
 		t.Errorf("Seeded entry value = %v, want current column 'Done'", entry["value"])
 	}
-	// Seeded at creation time (approximation for pre-existing cards).
-	if int64(entry["at"].(float64)) != 1704307200000 {
-		t.Errorf("Seeded entry at = %v, want created_at_millis", entry["at"])
+	// Seeded at creation time (approximation for pre-existing cards).
+	if int64(entry["at"].(float64)) != 1704307200000 {
+		t.Errorf("Seeded entry at = %v, want created_at_millis", entry["at"])
+	}
+
+	// The migrated card must be readable by the (strict-version) store.
+	paths := config.NewPaths(tempDir, "")
+	cardStore := store.NewCardStore(paths)
+	got, err := cardStore.Get("main", "card-abc")
+	if err != nil {
+		t.Fatalf("CardStore.Get failed after migration: %v", err)
+	}
+	if len(got.History) != 1 || got.History[0].Value != "Done" {
+		t.Errorf("Store-read history = %+v, want single Done entry", got.History)
+	}
+}
+
+// ============================================================================
+// Card v3 -> v4 Migration Tests (archive fields)
+// ============================================================================
+
+func TestMigrateService_CardV3ToV4_StampsVersion(t *testing.T) {
+	service, tempDir, cleanup := setupMigrationTest(t, "card_v3_no_archive")
+	defer cleanup()
+
+	plan, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	if !plan.HasChanges() {
+		t.Fatal("card/3 data should need migration to card/4")
+	}
+	if _, err := service.Execute(plan, false); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	// Archive fields are optional; a card without them is simply not archived.
+	paths := config.NewPaths(tempDir, "")
+	cardStore := store.NewCardStore(paths)
+	card, err := cardStore.Get("main", "card-abc")
+	if err != nil {
+		t.Fatalf("CardStore.Get failed after migration: %v", err)
+	}
+	if card.Version != version.CurrentCardVersion {
+		t.Errorf("Card Version = %d, want %d", card.Version, version.CurrentCardVersion)
+	}
+	if card.Archived || card.ArchivedAtMillis != 0 || card.LastColumn != "" {
+		t.Errorf("Migrated card should not be archived, got %+v", card)
+	}
+	if card.Column != "Backlog" {
+		t.Errorf("Card Column = %q, want 'Backlog'", card.Column)
+	}
+	if len(card.History) != 1 {
+		t.Errorf("Existing history should be preserved, got %+v", card.History)
+	}
+
+	listed, err := cardStore.List("main", false)
+	if err != nil {
+		t.Fatalf("CardStore.List failed: %v", err)
+	}
+	if len(listed) != 1 {
+		t.Errorf("Migrated card should be listed by default, got %d cards", len(listed))
+	}
+}
+
+func TestMigrateService_CardV3ToV4_Idempotent(t *testing.T) {
+	service, _, cleanup := setupMigrationTest(t, "card_v3_no_archive")
+	defer cleanup()
+
+	plan, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	if _, err := service.Execute(plan, false); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	plan2, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Second Plan failed: %v", err)
+	}
+	if plan2.HasChanges() {
+		t.Error("Second migration should have no changes")
+	}
+}
+
+// ============================================================================
+// Card v4 -> v5 Migration Tests (due date)
+// ============================================================================
+
+func TestMigrateService_CardV4ToV5_StampsVersion(t *testing.T) {
+	service, tempDir, cleanup := setupMigrationTest(t, "card_v4_no_due_date")
+	defer cleanup()
+
+	plan, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	if !plan.HasChanges() {
+		t.Fatal("card/4 data should need migration to card/5")
+	}
+	if _, err := service.Execute(plan, false); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	// due_at_millis is optional; a card without it simply has no due date.
+	paths := config.NewPaths(tempDir, "")
+	cardStore := store.NewCardStore(paths)
+	card, err := cardStore.Get("main", "card-abc")
+	if err != nil {
+		t.Fatalf("CardStore.Get failed after migration: %v", err)
+	}
+	if card.Version != version.CurrentCardVersion {
+		t.Errorf("Card Version = %d, want %d", card.Version, version.CurrentCardVersion)
+	}
+	if card.DueAtMillis != 0 {
+		t.Errorf("Migrated card should have no due date, got %d", card.DueAtMillis)
+	}
+}
+
+func TestMigrateService_CardV4ToV5_Idempotent(t *testing.T) {
+	service, _, cleanup := setupMigrationTest(t, "card_v4_no_due_date")
+	defer cleanup()
+
+	plan, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	if _, err := service.Execute(plan, false); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	plan2, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Second Plan failed: %v", err)
+	}
+	if plan2.HasChanges() {
+		t.Error("Second migration should have no changes")
+	}
+}
+
+// ============================================================================
+// Card v5 -> v6 Migration Tests (dependencies)
+// ============================================================================
+
+func TestMigrateService_CardV5ToV6_StampsVersion(t *testing.T) {
+	service, tempDir, cleanup := setupMigrationTest(t, "card_v5_no_blocks")
+	defer cleanup()
+
+	plan, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	if !plan.HasChanges() {
+		t.Fatal("card/5 data should need migration to card/6")
+	}
+	if _, err := service.Execute(plan, false); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	// blocks/blocked_by are optional; a card without them has no dependencies.
+	paths := config.NewPaths(tempDir, "")
+	cardStore := store.NewCardStore(paths)
+	card, err := cardStore.Get("main", "card-abc")
+	if err != nil {
+		t.Fatalf("CardStore.Get failed after migration: %v", err)
+	}
+	if card.Version != version.CurrentCardVersion {
+		t.Errorf("Card Version = %d, want %d", card.Version, version.CurrentCardVersion)
+	}
+	if len(card.Blocks) != 0 || len(card.BlockedBy) != 0 {
+		t.Errorf("Migrated card should have no dependencies, got blocks=%v blocked_by=%v", card.Blocks, card.BlockedBy)
+	}
+	if card.DueAtMillis != 1704393600000 {
+		t.Errorf("Card DueAtMillis = %d, want 1704393600000", card.DueAtMillis)
+	}
+}
+
+func TestMigrateService_CardV5ToV6_Idempotent(t *testing.T) {
+	service, _, cleanup := setupMigrationTest(t, "card_v5_no_blocks")
+	defer cleanup()
+
+	plan, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	if _, err := service.Execute(plan, false); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	plan2, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Second Plan failed: %v", err)
+	}
+	if plan2.HasChanges() {
+		t.Error("Second migration should have no changes")
+	}
+}
+
+// ============================================================================
+// Card v6 -> v7 Migration Tests (checklists)
+// ============================================================================
+
+func TestMigrateService_CardV6ToV7_StampsVersion(t *testing.T) {
+	service, tempDir, cleanup := setupMigrationTest(t, "card_v6_no_checklist")
+	defer cleanup()
+
+	plan, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	if !plan.HasChanges() {
+		t.Fatal("card/6 data should need migration to card/7")
+	}
+	if _, err := service.Execute(plan, false); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	// checklist is optional; a card without one has no subtasks.
+	paths := config.NewPaths(tempDir, "")
+	card, err := store.NewCardStore(paths).Get("main", "card-abc")
+	if err != nil {
+		t.Fatalf("CardStore.Get failed after migration: %v", err)
+	}
+	if card.Version != version.CurrentCardVersion {
+		t.Errorf("Card Version = %d, want %d", card.Version, version.CurrentCardVersion)
+	}
+	if len(card.Checklist) != 0 {
+		t.Errorf("Migrated card should have no checklist, got %+v", card.Checklist)
+	}
+	if _, ok := card.CustomFields["checklist"]; ok {
+		t.Error("checklist should not be read as a custom field")
+	}
+}
+
+func TestMigrateService_CardV6ToV7_Idempotent(t *testing.T) {
+	service, _, cleanup := setupMigrationTest(t, "card_v6_no_checklist")
+	defer cleanup()
+
+	plan, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	if _, err := service.Execute(plan, false); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	plan2, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Second Plan failed: %v", err)
+	}
+	if plan2.HasChanges() {
+		t.Error("Second migration should have no changes")
+	}
+}
+
+// ============================================================================
+// Card v7 -> v8 Migration Tests (done timestamps)
+// ============================================================================
+
+func TestMigrateService_CardV7ToV8_StampsVersion(t *testing.T) {
+	service, tempDir, cleanup := setupMigrationTest(t, "card_v7_no_done_at")
+	defer cleanup()
+
+	plan, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	if !plan.HasChanges() {
+		t.Fatal("card/7 data should need migration to card/8")
+	}
+	if _, err := service.Execute(plan, false); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	// done_at_millis is optional; the card isn't in a done column.
+	paths := config.NewPaths(tempDir, "")
+	card, err := store.NewCardStore(paths).Get("main", "card-abc")
+	if err != nil {
+		t.Fatalf("CardStore.Get failed after migration: %v", err)
+	}
+	if card.Version != version.CurrentCardVersion {
+		t.Errorf("Card Version = %d, want %d", card.Version, version.CurrentCardVersion)
+	}
+	if card.DoneAtMillis != 0 {
+		t.Errorf("Migrated card should have no done timestamp, got %d", card.DoneAtMillis)
+	}
+}
+
+func TestMigrateService_CardV7ToV8_Idempotent(t *testing.T) {
+	service, _, cleanup := setupMigrationTest(t, "card_v7_no_done_at")
+	defer cleanup()
+
+	plan, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	if _, err := service.Execute(plan, false); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	plan2, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Second Plan failed: %v", err)
+	}
+	if plan2.HasChanges() {
+		t.Error("Second migration should have no changes")
+	}
+}
+
+// ============================================================================
+// Card v8 -> v9 Migration Tests (comment mentions)
+// ============================================================================
+
+func TestMigrateService_CardV8ToV9_SeedsMentions(t *testing.T) {
+	service, tempDir, cleanup := setupMigrationTest(t, "card_v8_no_mentions")
+	defer cleanup()
+
+	plan, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	if !plan.HasChanges() {
+		t.Fatal("card/8 data should need migration to card/9")
+	}
+	if _, err := service.Execute(plan, false); err != nil {
+		t.Fatalf("Execute failed: %v", err)
 	}
 
-	// The migrated card must be readable by the (strict-version) store.
 	paths := config.NewPaths(tempDir, "")
-	cardStore := store.NewCardStore(paths)
-	got, err := cardStore.Get("main", "card-abc")
+	card, err := store.NewCardStore(paths).Get("main", "card-abc")
 	if err != nil {
 		t.Fatalf("CardStore.Get failed after migration: %v", err)
 	}
-	if len(got.History) != 1 || got.History[0].Value != "Done" {
-		t.Errorf("Store-read history = %+v, want single Done entry", got.History)
+	if card.Version != version.CurrentCardVersion {
+		t.Errorf("Card Version = %d, want %d", card.Version, version.CurrentCardVersion)
+	}
+	if len(card.Comments) != 1 || !reflect.DeepEqual(card.Comments[0].Mentions, []string{"alice"}) {
+		t.Errorf("Expected mentions derived from the comment body, got %+v", card.Comments)
+	}
+}
+
+func TestMigrateService_CardV8ToV9_Idempotent(t *testing.T) {
+	service, _, cleanup := setupMigrationTest(t, "card_v8_no_mentions")
+	defer cleanup()
+
+	plan, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	if _, err := service.Execute(plan, false); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	plan2, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Second Plan failed: %v", err)
+	}
+	if plan2.HasChanges() {
+		t.Error("Second migration should have no changes")
 	}
 }
 
-func TestMigrateService_CardV3_NoOp(t *testing.T) {
-	// The v12 fixture card is already card/3 with history on a current-schema
+func TestMigrateService_CardV9_NoOp(t *testing.T) {
+	// The v30 fixture cards are already card/9 with history on a current-schema
 	// board, so nothing (card or board) should need migration.
-	service, _, cleanup := setupMigrationTest(t, "v12")
+	service, _, cleanup := setupMigrationTest(t, "v30")
 	defer cleanup()
 
 	plan, err := service.Plan()
@@ -1870,7 +3836,7 @@ func TestMigrateService_CardV3_NoOp(t *testing.T) {
 		t.Fatalf("Plan failed: %v", err)
 	}
 	if plan.HasChanges() {
-		t.Error("card/3 data with history should not need migration")
+		t.Error("card/9 data should not need migration")
 	}
 }
 
@@ -1924,3 +3890,435 @@ func TestSeedCardHistory(t *testing.T) {
 		}
 	})
 }
+
+// ============================================================================
+// Downgrade tests
+// ============================================================================
+
+// decodeTOMLFile decodes a TOML file into a raw map.
+func decodeTOMLFile(t *testing.T, path string) map[string]any {
+	t.Helper()
+	var raw map[string]any
+	if _, err := toml.DecodeFile(path, &raw); err != nil {
+		t.Fatalf("Failed to decode %s: %v", path, err)
+	}
+	return raw
+}
+
+// decodeJSONFile decodes a JSON file into a raw map.
+func decodeJSONFile(t *testing.T, path string) map[string]any {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read %s: %v", path, err)
+	}
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatalf("Failed to parse %s: %v", path, err)
+	}
+	return raw
+}
+
+// A missing step would be skipped silently, leaving that version's fields in
+// the downgraded data.
+func TestDowngradeSteps_CoverEveryVersion(t *testing.T) {
+	for v := 1; v <= version.CurrentBoardVersion; v++ {
+		if boardDowngradeSteps[v] == nil {
+			t.Errorf("boardDowngradeSteps has no step for board/%d", v)
+		}
+	}
+	for v := 1; v <= version.CurrentCardVersion; v++ {
+		if cardDowngradeSteps[v] == nil {
+			t.Errorf("cardDowngradeSteps has no step for card/%d", v)
+		}
+	}
+}
+
+func TestMigrateService_DowngradeV4ToV3(t *testing.T) {
+	service, tempDir, cleanup := setupMigrationTest(t, "v4")
+	defer cleanup()
+
+	// The fixture's "type" field is wanted, which board/3 can't express.
+	// Clear it so the downgrade is lossless.
+	configPath := filepath.Join(tempDir, ".kan", "boards", "main", "config.toml")
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("Failed to read config: %v", err)
+	}
+	if err := os.WriteFile(configPath, []byte(strings.Replace(string(data), "wanted = true\n", "", 1)), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	cardPath := filepath.Join(tempDir, ".kan", "boards", "main", "cards", "card-abc.json")
+	cardBefore, _ := os.ReadFile(cardPath)
+
+	plan, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	if err := service.Downgrade(plan, 3); err != nil {
+		t.Fatalf("Downgrade failed: %v", err)
+	}
+
+	raw := decodeTOMLFile(t, configPath)
+	if raw["kan_schema"] != "board/3" {
+		t.Errorf("kan_schema = %v, want board/3", raw["kan_schema"])
+	}
+	if hooks := tomlTables(raw["pattern_hooks"]); len(hooks) != 1 || hooks[0]["name"] != "jira-sync" {
+		t.Errorf("pattern_hooks should be kept at board/3, got %v", raw["pattern_hooks"])
+	}
+	fields := raw["custom_fields"].(map[string]any)
+	if _, ok := fields["type"].(map[string]any)["wanted"]; ok {
+		t.Error("wanted should be stripped")
+	}
+	if fields["labels"].(map[string]any)["type"] != "tags" {
+		t.Errorf("labels type = %v, want tags", fields["labels"])
+	}
+
+	// Cards are already card/1, which board/3 reads, so they're untouched.
+	if cardAfter, _ := os.ReadFile(cardPath); string(cardAfter) != string(cardBefore) {
+		t.Errorf("card changed:\n%s", cardAfter)
+	}
+
+	snapshots, err := service.Snapshots()
+	if err != nil || len(snapshots) != 1 || snapshots[0].BoardSchemas["main"] != "board/4" {
+		t.Errorf("expected a board/4 snapshot, got %+v (err %v)", snapshots, err)
+	}
+}
+
+func TestMigrateService_DowngradeV4ToV3_RefusesDataLoss(t *testing.T) {
+	service, tempDir, cleanup := setupMigrationTest(t, "v4")
+	defer cleanup()
+
+	configPath := filepath.Join(tempDir, ".kan", "boards", "main", "config.toml")
+	before, _ := os.ReadFile(configPath)
+
+	plan, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	err = service.Downgrade(plan, 3)
+
+	var downgradeErr *kanerr.DowngradeError
+	if !errors.As(err, &downgradeErr) {
+		t.Fatalf("expected DowngradeError, got %v", err)
+	}
+	if downgradeErr.Target != "board/3" || len(downgradeErr.Losses) != 1 ||
+		!strings.Contains(downgradeErr.Losses[0], `custom field "type" sets wanted`) {
+		t.Errorf("unexpected losses: %v", downgradeErr.Losses)
+	}
+
+	if after, _ := os.ReadFile(configPath); string(after) != string(before) {
+		t.Error("config should be unchanged when the downgrade is refused")
+	}
+	if snapshots, _ := service.Snapshots(); len(snapshots) != 0 {
+		t.Errorf("no snapshot should be taken, got %d", len(snapshots))
+	}
+
+	// Downgrading to board/2 also drops the pattern hook.
+	err = service.Downgrade(plan, 2)
+	if !errors.As(err, &downgradeErr) || len(downgradeErr.Losses) != 2 {
+		t.Errorf("expected wanted and pattern_hooks losses, got %v", err)
+	}
+}
+
+func TestMigrateService_DowngradeV2ToV1(t *testing.T) {
+	service, tempDir, cleanup := setupMigrationTest(t, "v2")
+	defer cleanup()
+
+	plan, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	if err := service.Downgrade(plan, 1); err != nil {
+		t.Fatalf("Downgrade failed: %v", err)
+	}
+
+	// The result matches the v1 fixture that migrates to it.
+	boardPath := filepath.Join(".kan", "boards", "main", "config.toml")
+	got := decodeTOMLFile(t, filepath.Join(tempDir, boardPath))
+	want := decodeTOMLFile(t, filepath.Join("testdata", "migrations", "v1", boardPath))
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("board config = %v\nwant %v", got, want)
+	}
+
+	cardPath := filepath.Join(".kan", "boards", "main", "cards", "card-abc.json")
+	gotCard := decodeJSONFile(t, filepath.Join(tempDir, cardPath))
+	wantCard := decodeJSONFile(t, filepath.Join("testdata", "migrations", "v1", cardPath))
+	if !reflect.DeepEqual(gotCard, wantCard) {
+		t.Errorf("card = %v\nwant %v", gotCard, wantCard)
+	}
+
+	// Migrating forward again restores the current schema.
+	plan, err = service.Plan()
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	if _, err := service.Execute(plan, false); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if raw := decodeTOMLFile(t, filepath.Join(tempDir, boardPath)); raw["kan_schema"] != version.CurrentBoardSchema() {
+		t.Errorf("kan_schema after re-migrating = %v", raw["kan_schema"])
+	}
+}
+
+func TestMigrateService_DowngradeCurrentRefusesHistoryLoss(t *testing.T) {
+	service, _, cleanup := setupMigrationTest(t, "v30")
+	defer cleanup()
+
+	plan, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	// board/9 needs card/1 cards, which have no column history.
+	var downgradeErr *kanerr.DowngradeError
+	if err := service.Downgrade(plan, 9); !errors.As(err, &downgradeErr) {
+		t.Fatalf("expected DowngradeError, got %v", err)
+	}
+	found := false
+	for _, loss := range downgradeErr.Losses {
+		found = found || strings.Contains(loss, "sets history (added in card/3)")
+	}
+	if !found {
+		t.Errorf("expected a history loss, got %v", downgradeErr.Losses)
+	}
+}
+
+func TestMigrateService_DowngradeRefusesDonePolicyLoss(t *testing.T) {
+	service, _, cleanup := setupMigrationTest(t, "v19")
+	defer cleanup()
+
+	plan, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	var downgradeErr *kanerr.DowngradeError
+	if err := service.Downgrade(plan, 18); !errors.As(err, &downgradeErr) {
+		t.Fatalf("expected DowngradeError, got %v", err)
+	}
+	want := []string{
+		`board "main": column "Done" sets done (added in board/19)`,
+		`board "main": column "Done" sets auto_archive_after_days (added in board/19)`,
+	}
+	if !reflect.DeepEqual(downgradeErr.Losses, want) {
+		t.Errorf("Losses = %v, want %v", downgradeErr.Losses, want)
+	}
+}
+
+func TestMigrateService_DowngradeRefusesDateSettingsLoss(t *testing.T) {
+	service, _, cleanup := setupMigrationTest(t, "v20")
+	defer cleanup()
+
+	plan, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	var downgradeErr *kanerr.DowngradeError
+	if err := service.Downgrade(plan, 19); !errors.As(err, &downgradeErr) {
+		t.Fatalf("expected DowngradeError, got %v", err)
+	}
+	want := []string{
+		`board "main": custom field "shipped" sets past_only (added in board/20)`,
+		`board "main": custom field "shipped" sets format (added in board/20)`,
+	}
+	if !reflect.DeepEqual(downgradeErr.Losses, want) {
+		t.Errorf("Losses = %v, want %v", downgradeErr.Losses, want)
+	}
+}
+
+func TestMigrateService_DowngradeRefusesDoneColumnsLoss(t *testing.T) {
+	service, _, cleanup := setupMigrationTest(t, "v21")
+	defer cleanup()
+
+	plan, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	var downgradeErr *kanerr.DowngradeError
+	if err := service.Downgrade(plan, 20); !errors.As(err, &downgradeErr) {
+		t.Fatalf("expected DowngradeError, got %v", err)
+	}
+	want := []string{`board "main": board sets done_columns (added in board/21)`}
+	if !reflect.DeepEqual(downgradeErr.Losses, want) {
+		t.Errorf("Losses = %v, want %v", downgradeErr.Losses, want)
+	}
+}
+
+func TestMigrateService_DowngradeRefusesStaleSettingsLoss(t *testing.T) {
+	service, _, cleanup := setupMigrationTest(t, "v22")
+	defer cleanup()
+
+	plan, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	var downgradeErr *kanerr.DowngradeError
+	if err := service.Downgrade(plan, 21); !errors.As(err, &downgradeErr) {
+		t.Fatalf("expected DowngradeError, got %v", err)
+	}
+	want := []string{`board "main": board sets stale (added in board/22)`}
+	if !reflect.DeepEqual(downgradeErr.Losses, want) {
+		t.Errorf("Losses = %v, want %v", downgradeErr.Losses, want)
+	}
+}
+
+func TestMigrateService_DowngradeRefusesCommandArgsLoss(t *testing.T) {
+	service, _, cleanup := setupMigrationTest(t, "v23")
+	defer cleanup()
+
+	plan, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	var downgradeErr *kanerr.DowngradeError
+	if err := service.Downgrade(plan, 22); !errors.As(err, &downgradeErr) {
+		t.Fatalf("expected DowngradeError, got %v", err)
+	}
+	want := []string{`board "main": pattern hook "jira-sync" sets command_args (added in board/23)`}
+	if !reflect.DeepEqual(downgradeErr.Losses, want) {
+		t.Errorf("Losses = %v, want %v", downgradeErr.Losses, want)
+	}
+}
+
+func TestMigrateService_DowngradeRefusesArchiveLoss(t *testing.T) {
+	service, _, cleanup := setupMigrationTest(t, "v24")
+	defer cleanup()
+
+	plan, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	var downgradeErr *kanerr.DowngradeError
+	if err := service.Downgrade(plan, 23); !errors.As(err, &downgradeErr) {
+		t.Fatalf("expected DowngradeError, got %v", err)
+	}
+	want := []string{`board "main": board sets archived_at_millis (added in board/24)`}
+	if !reflect.DeepEqual(downgradeErr.Losses, want) {
+		t.Errorf("Losses = %v, want %v", downgradeErr.Losses, want)
+	}
+}
+
+func TestMigrateService_DowngradeRefusesTemplateLoss(t *testing.T) {
+	service, _, cleanup := setupMigrationTest(t, "v25")
+	defer cleanup()
+
+	plan, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	var downgradeErr *kanerr.DowngradeError
+	if err := service.Downgrade(plan, 24); !errors.As(err, &downgradeErr) {
+		t.Fatalf("expected DowngradeError, got %v", err)
+	}
+	want := []string{`board "main": board sets templates (added in board/25)`}
+	if !reflect.DeepEqual(downgradeErr.Losses, want) {
+		t.Errorf("Losses = %v, want %v", downgradeErr.Losses, want)
+	}
+}
+
+func TestMigrateService_DowngradeRefusesFieldOrderLoss(t *testing.T) {
+	service, _, cleanup := setupMigrationTest(t, "v26")
+	defer cleanup()
+
+	plan, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	var downgradeErr *kanerr.DowngradeError
+	if err := service.Downgrade(plan, 25); !errors.As(err, &downgradeErr) {
+		t.Fatalf("expected DowngradeError, got %v", err)
+	}
+	want := []string{
+		`board "main": custom field "labels" sets order (added in board/26)`,
+		`board "main": custom field "type" sets order (added in board/26)`,
+	}
+	if !reflect.DeepEqual(downgradeErr.Losses, want) {
+		t.Errorf("Losses = %v, want %v", downgradeErr.Losses, want)
+	}
+}
+
+func TestMigrateService_DowngradeRefusesCollaboratorLoss(t *testing.T) {
+	service, _, cleanup := setupMigrationTest(t, "v28")
+	defer cleanup()
+
+	plan, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	var downgradeErr *kanerr.DowngradeError
+	if err := service.Downgrade(plan, 26); !errors.As(err, &downgradeErr) {
+		t.Fatalf("expected DowngradeError, got %v", err)
+	}
+	want := []string{
+		`board "main": custom field "owner" has type user (added in board/27)`,
+		`board "main": board sets collaborators (added in board/27)`,
+	}
+	if !reflect.DeepEqual(downgradeErr.Losses, want) {
+		t.Errorf("Losses = %v, want %v", downgradeErr.Losses, want)
+	}
+}
+
+func TestMigrateService_DowngradeRefusesIncludeLoss(t *testing.T) {
+	service, _, cleanup := setupMigrationTest(t, "v29")
+	defer cleanup()
+
+	plan, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	var downgradeErr *kanerr.DowngradeError
+	if err := service.Downgrade(plan, 28); !errors.As(err, &downgradeErr) {
+		t.Fatalf("expected DowngradeError, got %v", err)
+	}
+	want := []string{`board "main": board sets include (added in board/29)`}
+	if !reflect.DeepEqual(downgradeErr.Losses, want) {
+		t.Errorf("Losses = %v, want %v", downgradeErr.Losses, want)
+	}
+}
+
+func TestMigrateService_DowngradeRefusesSprintLoss(t *testing.T) {
+	service, _, cleanup := setupMigrationTest(t, "v30")
+	defer cleanup()
+
+	plan, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	var downgradeErr *kanerr.DowngradeError
+	if err := service.Downgrade(plan, 29); !errors.As(err, &downgradeErr) {
+		t.Fatalf("expected DowngradeError, got %v", err)
+	}
+	want := []string{
+		`board "main": column "Backlog" sets sprint_start_millis (added in board/30)`,
+		`board "main": column "Backlog" sets sprint_end_millis (added in board/30)`,
+	}
+	if !reflect.DeepEqual(downgradeErr.Losses, want) {
+		t.Errorf("Losses = %v, want %v", downgradeErr.Losses, want)
+	}
+}
+
+func TestMigrateService_DowngradeRejectsInvalidTarget(t *testing.T) {
+	service, _, cleanup := setupMigrationTest(t, "v2")
+	defer cleanup()
+
+	plan, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	for _, target := range []int{-1, version.CurrentBoardVersion + 1} {
+		if err := service.Downgrade(plan, target); !kanerr.IsCode(err, kanerr.CodeInvalidField) {
+			t.Errorf("Downgrade(%d) = %v, want a validation error", target, err)
+		}
+	}
+	// Downgrading to the current version (or above the data's) is a no-op.
+	if err := service.Downgrade(plan, 2); err != nil {
+		t.Errorf("Downgrade to the same version: %v", err)
+	}
+}
+
+func TestCardVersionForBoard(t *testing.T) {
+	for board, want := range map[int]int{0: 0, 1: 1, 9: 1, 10: 2, 11: 2, 12: 3, 13: 9, version.CurrentBoardVersion: version.CurrentCardVersion} {
+		if got := cardVersionForBoard(board); got != want {
+			t.Errorf("cardVersionForBoard(%d) = %d, want %d", board, got, want)
+		}
+	}
+}
@@ -0,0 +1,354 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/amterp/kan/internal/config"
+	kanerr "github.com/amterp/kan/internal/errors"
+	"github.com/amterp/kan/internal/model"
+	"github.com/amterp/kan/internal/store"
+	"golang.org/x/sync/errgroup"
+)
+
+// Searchable card fields for SearchOptions.Fields.
+const (
+	SearchFieldTitle       = "title"
+	SearchFieldDescription = "description"
+	SearchFieldComments    = "comments"
+)
+
+// defaultSearchFields is used when SearchOptions.Fields is empty.
+var defaultSearchFields = []string{SearchFieldTitle, SearchFieldDescription}
+
+// SearchOptions controls how SearchService.Search matches cards.
+type SearchOptions struct {
+	Fields        []string // Subset of title, description, comments; empty = title + description
+	CaseSensitive bool
+	UseRegex      bool // Treat the query as a Go regular expression instead of a substring
+}
+
+// SearchHit is one card matched by SearchAllBoards, with the field that
+// matched and a short excerpt around the match.
+type SearchHit struct {
+	Project     string `json:"project,omitempty"`      // Set by SearchProjects: the project's registered name
+	ProjectPath string `json:"project_path,omitempty"` // Set by SearchProjects
+	BoardName   string `json:"board"`
+	CardID      string `json:"card_id"`
+	Alias       string `json:"alias"`
+	Title       string `json:"title"`
+	MatchedIn   string `json:"matched_in"` // The first selected field that matched
+	Snippet     string `json:"snippet"`
+}
+
+// snippetContext is how many characters of text are kept on each side of a
+// match in SearchHit.Snippet.
+const snippetContext = 30
+
+// SearchService finds cards by text across their title, description, and comments.
+type SearchService struct {
+	cardStore  store.CardStore
+	boardStore store.BoardStore
+}
+
+// NewSearchService creates a new search service.
+func NewSearchService(cardStore store.CardStore, boardStore store.BoardStore) *SearchService {
+	return &SearchService{
+		cardStore:  cardStore,
+		boardStore: boardStore,
+	}
+}
+
+// Search returns the cards on a board whose searched fields match query, in
+// board order (by column, then position). Archived cards are not searched.
+// A card matches if any of the selected fields match.
+func (s *SearchService) Search(boardName, query string, opts SearchOptions) ([]*model.Card, error) {
+	if query == "" {
+		return nil, kanerr.InvalidField("query", "must not be empty")
+	}
+
+	fields := opts.Fields
+	if len(fields) == 0 {
+		fields = defaultSearchFields
+	}
+	for _, f := range fields {
+		if f != SearchFieldTitle && f != SearchFieldDescription && f != SearchFieldComments {
+			return nil, kanerr.InvalidField("fields", fmt.Sprintf("unknown search field %q (must be title, description, or comments)", f))
+		}
+	}
+
+	match, err := buildMatcher(query, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	boardCfg, err := s.boardStore.Get(boardName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Stream rather than List: only matching cards are kept in memory.
+	results, err := streamCards(s.cardStore, boardName, func(card *model.Card) bool {
+		return !card.Archived && cardMatches(card, fields, match)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sortByBoardOrder(results, boardCfg)
+	return results, nil
+}
+
+// SearchAllBoards runs Search on every board in the project, in board-name
+// order, and reports each matching card as a SearchHit.
+func (s *SearchService) SearchAllBoards(query string, opts SearchOptions) ([]SearchHit, error) {
+	if query == "" {
+		return nil, kanerr.InvalidField("query", "must not be empty")
+	}
+	locate, err := buildLocator(query, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	boardNames, err := s.boardStore.List()
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(boardNames)
+
+	fields := opts.Fields
+	if len(fields) == 0 {
+		fields = defaultSearchFields
+	}
+
+	hits := []SearchHit{}
+	for _, boardName := range boardNames {
+		cards, err := s.Search(boardName, query, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, card := range cards {
+			field, snippet := locateMatch(card, fields, locate)
+			hits = append(hits, SearchHit{
+				BoardName: boardName,
+				CardID:    card.ID,
+				Alias:     card.Alias,
+				Title:     card.Title,
+				MatchedIn: field,
+				Snippet:   snippet,
+			})
+		}
+	}
+	return hits, nil
+}
+
+// maxProjectSearchConcurrency caps how many projects SearchProjects searches
+// at once.
+const maxProjectSearchConcurrency = 8
+
+// SkippedProject is a registered project that SearchProjects couldn't search.
+type SkippedProject struct {
+	Name string
+	Path string
+	Err  error
+}
+
+// SearchProjects runs SearchAllBoards on every project registered in the
+// global config, several projects at a time. Hits are tagged with the
+// project's registered name and path and returned in project-name order.
+// Projects that can't be read are returned in skipped rather than failing
+// the search; an invalid query fails the whole search.
+func SearchProjects(globalCfg *model.GlobalConfig, query string, opts SearchOptions) ([]SearchHit, []SkippedProject, error) {
+	if query == "" {
+		return nil, nil, kanerr.InvalidField("query", "must not be empty")
+	}
+	if _, err := buildLocator(query, opts); err != nil {
+		return nil, nil, err
+	}
+
+	names := make([]string, 0, len(globalCfg.Projects))
+	for name := range globalCfg.Projects {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// Each project writes only its own slot, so results keep name order
+	// without locking.
+	projectHits := make([][]SearchHit, len(names))
+	projectErrs := make([]error, len(names))
+
+	var g errgroup.Group
+	g.SetLimit(max(1, min(len(names), maxProjectSearchConcurrency)))
+	for i, name := range names {
+		projectPath := globalCfg.Projects[name]
+		dataLocation := ""
+		if repoCfg := globalCfg.GetRepoConfig(projectPath); repoCfg != nil {
+			dataLocation = repoCfg.DataLocation
+		}
+		g.Go(func() error {
+			paths := config.NewPaths(projectPath, dataLocation)
+			search := NewSearchService(store.NewCardStore(paths), store.NewBoardStore(paths))
+			hits, err := search.SearchAllBoards(query, opts)
+			if err != nil {
+				if kanerr.IsValidationError(err) {
+					return err
+				}
+				projectErrs[i] = err
+				return nil
+			}
+			for j := range hits {
+				hits[j].Project = name
+				hits[j].ProjectPath = projectPath
+			}
+			projectHits[i] = hits
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, nil, err
+	}
+
+	hits := []SearchHit{}
+	var skipped []SkippedProject
+	for i, name := range names {
+		if projectErrs[i] != nil {
+			skipped = append(skipped, SkippedProject{Name: name, Path: globalCfg.Projects[name], Err: projectErrs[i]})
+			continue
+		}
+		hits = append(hits, projectHits[i]...)
+	}
+	return hits, skipped, nil
+}
+
+// buildMatcher compiles the query into a predicate over field text.
+func buildMatcher(query string, opts SearchOptions) (func(string) bool, error) {
+	if opts.UseRegex {
+		pattern := query
+		if !opts.CaseSensitive {
+			pattern = "(?i)" + pattern
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, kanerr.InvalidField("query", fmt.Sprintf("invalid regex: %v", err))
+		}
+		return re.MatchString, nil
+	}
+
+	if opts.CaseSensitive {
+		return func(text string) bool { return strings.Contains(text, query) }, nil
+	}
+	lowered := strings.ToLower(query)
+	return func(text string) bool { return strings.Contains(strings.ToLower(text), lowered) }, nil
+}
+
+// buildLocator is like buildMatcher, but the returned function reports where
+// the first match is, as a [start, end) byte range, or nil.
+func buildLocator(query string, opts SearchOptions) (func(string) []int, error) {
+	pattern := query
+	if !opts.UseRegex {
+		pattern = regexp.QuoteMeta(query)
+	}
+	if !opts.CaseSensitive {
+		pattern = "(?i)" + pattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, kanerr.InvalidField("query", fmt.Sprintf("invalid regex: %v", err))
+	}
+	return re.FindStringIndex, nil
+}
+
+// locateMatch returns the first of fields that matches the card, along with a
+// snippet of the matching text.
+func locateMatch(card *model.Card, fields []string, locate func(string) []int) (string, string) {
+	for _, f := range fields {
+		var texts []string
+		switch f {
+		case SearchFieldTitle:
+			texts = []string{card.Title}
+		case SearchFieldDescription:
+			texts = []string{card.Description}
+		case SearchFieldComments:
+			for _, c := range card.Comments {
+				texts = append(texts, c.Body)
+			}
+		}
+		for _, text := range texts {
+			if loc := locate(text); loc != nil {
+				return f, snippet(text, loc[0], loc[1])
+			}
+		}
+	}
+	return "", ""
+}
+
+// snippet returns text[start:end] with up to snippetContext characters either
+// side, on a single line. Ellipses mark where the text was cut.
+func snippet(text string, start, end int) string {
+	from := start
+	for n := 0; from > 0 && n < snippetContext; n++ {
+		_, size := utf8.DecodeLastRuneInString(text[:from])
+		from -= size
+	}
+	to := end
+	for n := 0; to < len(text) && n < snippetContext; n++ {
+		_, size := utf8.DecodeRuneInString(text[to:])
+		to += size
+	}
+
+	out := strings.Join(strings.Fields(text[from:to]), " ")
+	if from > 0 {
+		out = "…" + out
+	}
+	if to < len(text) {
+		out += "…"
+	}
+	return out
+}
+
+func cardMatches(card *model.Card, fields []string, match func(string) bool) bool {
+	for _, f := range fields {
+		switch f {
+		case SearchFieldTitle:
+			if match(card.Title) {
+				return true
+			}
+		case SearchFieldDescription:
+			if card.Description != "" && match(card.Description) {
+				return true
+			}
+		case SearchFieldComments:
+			for _, c := range card.Comments {
+				if match(c.Body) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// sortByBoardOrder orders cards by their column's index in the board config,
+// then by position. Cards in unknown columns sort last.
+func sortByBoardOrder(cards []*model.Card, boardCfg *model.BoardConfig) {
+	colIndex := make(map[string]int, len(boardCfg.Columns))
+	for i, col := range boardCfg.Columns {
+		colIndex[col.Name] = i
+	}
+	rank := func(column string) int {
+		if i, ok := colIndex[column]; ok {
+			return i
+		}
+		return len(boardCfg.Columns)
+	}
+	sort.SliceStable(cards, func(i, j int) bool {
+		ri, rj := rank(cards[i].Column), rank(cards[j].Column)
+		if ri != rj {
+			return ri < rj
+		}
+		return cards[i].Position < cards[j].Position
+	})
+}
@@ -0,0 +1,210 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/amterp/kan/internal/config"
+	kanerr "github.com/amterp/kan/internal/errors"
+	"github.com/amterp/kan/internal/model"
+	"github.com/amterp/kan/internal/store"
+)
+
+func setupSearchService(t *testing.T) (*SearchService, *CardService) {
+	t.Helper()
+	cardService, cardStore, boardStore := setupCardService()
+	boardStore.addBoard(testBoardConfig("main"))
+
+	mustAdd(t, cardService, AddCardInput{BoardName: "main", Title: "Fix login redirect", Column: "done"})
+	mustAdd(t, cardService, AddCardInput{BoardName: "main", Title: "Refactor auth", Description: "Split the LOGIN handler", Column: "backlog"})
+	withComment := mustAdd(t, cardService, AddCardInput{BoardName: "main", Title: "Update docs", Column: "backlog"})
+	if _, err := cardService.AddComment("main", withComment.ID, "mention login flow in the guide", "alice"); err != nil {
+		t.Fatalf("AddComment failed: %v", err)
+	}
+	archived := mustAdd(t, cardService, AddCardInput{BoardName: "main", Title: "Old login page"})
+	if err := cardService.Archive("main", archived.ID); err != nil {
+		t.Fatalf("Archive failed: %v", err)
+	}
+
+	return NewSearchService(cardStore, boardStore), cardService
+}
+
+func searchTitles(cards []*model.Card) []string {
+	titles := make([]string, len(cards))
+	for i, c := range cards {
+		titles[i] = c.Title
+	}
+	return titles
+}
+
+func TestSearchService_Search(t *testing.T) {
+	search, _ := setupSearchService(t)
+
+	cases := []struct {
+		name  string
+		query string
+		opts  SearchOptions
+		want  []string
+	}{
+		{
+			name:  "default fields, case-insensitive, board order",
+			query: "login",
+			want:  []string{"Refactor auth", "Fix login redirect"},
+		},
+		{
+			name:  "case-sensitive",
+			query: "LOGIN",
+			opts:  SearchOptions{CaseSensitive: true},
+			want:  []string{"Refactor auth"},
+		},
+		{
+			name:  "title only",
+			query: "login",
+			opts:  SearchOptions{Fields: []string{SearchFieldTitle}},
+			want:  []string{"Fix login redirect"},
+		},
+		{
+			name:  "comments",
+			query: "login flow",
+			opts:  SearchOptions{Fields: []string{SearchFieldComments}},
+			want:  []string{"Update docs"},
+		},
+		{
+			name:  "regex",
+			query: `^(fix|update)\b`,
+			opts:  SearchOptions{UseRegex: true, Fields: []string{SearchFieldTitle}},
+			want:  []string{"Update docs", "Fix login redirect"},
+		},
+		{
+			name:  "no matches",
+			query: "nonexistent",
+			want:  []string{},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cards, err := search.Search("main", tc.query, tc.opts)
+			if err != nil {
+				t.Fatalf("Search failed: %v", err)
+			}
+			got := searchTitles(cards)
+			if fmt.Sprint(got) != fmt.Sprint(tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSearchService_Search_InvalidInput(t *testing.T) {
+	search, _ := setupSearchService(t)
+
+	cases := []struct {
+		name  string
+		query string
+		opts  SearchOptions
+	}{
+		{name: "empty query", query: ""},
+		{name: "unknown field", query: "x", opts: SearchOptions{Fields: []string{"alias"}}},
+		{name: "invalid regex", query: "(", opts: SearchOptions{UseRegex: true}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := search.Search("main", tc.query, tc.opts); !kanerr.IsCode(err, kanerr.CodeInvalidField) {
+				t.Errorf("Expected validation error, got %v", err)
+			}
+		})
+	}
+
+	if _, err := search.Search("missing", "x", SearchOptions{}); !kanerr.IsCode(err, kanerr.CodeBoardNotFound) {
+		t.Errorf("Expected not-found error for missing board, got %v", err)
+	}
+}
+
+// setupSearchBenchmark writes n cards to a real on-disk board so benchmarks
+// include the cost of reading card files, which dominates in practice.
+func setupSearchBenchmark(b *testing.B, n int) *SearchService {
+	b.Helper()
+	paths := config.NewPaths(b.TempDir(), "")
+	cardStore := store.NewCardStore(paths)
+	boardStore := store.NewBoardStore(paths)
+	if err := boardStore.Create(testBoardConfig("main")); err != nil {
+		b.Fatalf("Failed to create board: %v", err)
+	}
+
+	for i := 0; i < n; i++ {
+		card := &model.Card{
+			ID:          fmt.Sprintf("card-%04d", i),
+			Alias:       fmt.Sprintf("card-%04d", i),
+			Title:       fmt.Sprintf("Card %d: implement feature %d", i, i%37),
+			Description: fmt.Sprintf("Details for card %d. Mentions the login flow every tenth card: %v", i, i%10 == 0),
+			Column:      "backlog",
+			Position:    fmt.Sprintf("a%04d", i),
+		}
+		if err := cardStore.Create("main", card); err != nil {
+			b.Fatalf("Failed to create card: %v", err)
+		}
+	}
+	return NewSearchService(cardStore, boardStore)
+}
+
+func BenchmarkSearchService_Search_Substring1000(b *testing.B) {
+	search := setupSearchBenchmark(b, 1000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := search.Search("main", "feature 12", SearchOptions{}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSearchService_Search_Regex1000(b *testing.B) {
+	search := setupSearchBenchmark(b, 1000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := search.Search("main", `feature (1|2)\d\b`, SearchOptions{UseRegex: true}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestSearchService_SearchAllBoards(t *testing.T) {
+	search, cardService := setupSearchService(t)
+	longDesc := "Some background first. Then the part that matters: the login form double-submits on slow networks."
+	mustAdd(t, cardService, AddCardInput{BoardName: "main", Title: "Form bug", Description: longDesc, Column: "done"})
+
+	hits, err := search.SearchAllBoards("login", SearchOptions{Fields: []string{SearchFieldTitle, SearchFieldDescription, SearchFieldComments}})
+	if err != nil {
+		t.Fatalf("SearchAllBoards failed: %v", err)
+	}
+
+	got := make(map[string]SearchHit)
+	for _, hit := range hits {
+		if hit.BoardName != "main" {
+			t.Errorf("Unexpected board %q", hit.BoardName)
+		}
+		got[hit.Title] = hit
+	}
+	cases := []struct {
+		title     string
+		matchedIn string
+		snippet   string
+	}{
+		{"Fix login redirect", SearchFieldTitle, "Fix login redirect"},
+		{"Refactor auth", SearchFieldDescription, "Split the LOGIN handler"},
+		{"Update docs", SearchFieldComments, "mention login flow in the guide"},
+		{"Form bug", SearchFieldDescription, "…en the part that matters: the login form double-submits on slow n…"},
+	}
+	if len(hits) != len(cases) {
+		t.Fatalf("Expected %d hits, got %d: %+v", len(cases), len(hits), hits)
+	}
+	for _, tc := range cases {
+		hit := got[tc.title]
+		if hit.MatchedIn != tc.matchedIn || hit.Snippet != tc.snippet {
+			t.Errorf("%s: got matched_in=%q snippet=%q, want %q %q", tc.title, hit.MatchedIn, hit.Snippet, tc.matchedIn, tc.snippet)
+		}
+	}
+
+	if _, err := search.SearchAllBoards("", SearchOptions{}); !kanerr.IsCode(err, kanerr.CodeInvalidField) {
+		t.Errorf("Expected validation error for empty query, got %v", err)
+	}
+}
@@ -0,0 +1,205 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// aliasIndex maps card aliases to card IDs for one board. It lets
+// FindByAlias read a single card file instead of every card on the board.
+//
+// The index is a cache, not data: FileCardStore updates it on every write and
+// rebuilds it from the card files whenever it looks stale, so deleting it is
+// always safe. `kan commit` leaves it out.
+type aliasIndex map[string]string
+
+// readAliasIndex reads a board's alias index. A missing index is returned as
+// a nil map with no error; callers tell it apart from an empty index with
+// aliasIndexStale. Must be called with the lock held.
+func (s *FileCardStore) readAliasIndex(boardName string) (aliasIndex, error) {
+	data, err := os.ReadFile(s.paths.AliasIndexPath(boardName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var index aliasIndex
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("invalid alias index: %w", err)
+	}
+	return index, nil
+}
+
+// writeAliasIndex writes a board's alias index. Must be called with the
+// exclusive lock held.
+func (s *FileCardStore) writeAliasIndex(boardName string, index aliasIndex) error {
+	data, err := json.Marshal(index)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.paths.AliasIndexPath(boardName), data, 0644)
+}
+
+// rebuildAliasIndex scans every card on the board, archived ones included,
+// and rewrites the index. Must be called with the exclusive lock held.
+func (s *FileCardStore) rebuildAliasIndex(boardName string) error {
+	cards, err := s.list(boardName, true)
+	if err != nil {
+		return err
+	}
+	index := make(aliasIndex, len(cards))
+	for _, card := range cards {
+		if card.Alias != "" {
+			index[card.Alias] = card.ID
+		}
+	}
+	return s.writeAliasIndex(boardName, index)
+}
+
+// aliasIndexStale reports whether a board's index is missing or older than
+// its cards. Without checkCards, only the cards directory's mtime is
+// compared: that catches cards added or removed behind the store's back
+// (e.g. by a VCS pull) with a single stat. With checkCards, every card
+// file's mtime is compared too, which also catches cards edited in place.
+// A board with no cards directory is never stale. Must be called with the
+// lock held.
+func (s *FileCardStore) aliasIndexStale(boardName string, checkCards bool) bool {
+	cardsDir := s.paths.CardsDir(boardName)
+	dirInfo, err := os.Stat(cardsDir)
+	if err != nil {
+		return false
+	}
+	indexInfo, err := os.Stat(s.paths.AliasIndexPath(boardName))
+	if err != nil {
+		return true
+	}
+	indexTime := indexInfo.ModTime()
+	if indexTime.Before(dirInfo.ModTime()) {
+		return true
+	}
+	if !checkCards {
+		return false
+	}
+	return newestCardModTime(cardsDir).After(indexTime)
+}
+
+// newestCardModTime returns the latest mtime of the card files in cardsDir.
+func newestCardModTime(cardsDir string) time.Time {
+	var newest time.Time
+	entries, err := os.ReadDir(cardsDir)
+	if err != nil {
+		return newest
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		if info.ModTime().After(newest) {
+			newest = info.ModTime()
+		}
+	}
+	return newest
+}
+
+// updateAliasIndex applies a change to a board's index after a card write.
+// wasStale is aliasIndexStale from before the write: a stale index is
+// rebuilt rather than patched, so that refreshing its mtime doesn't hide
+// changes it never saw. If the index can't be updated it is removed, to be
+// rebuilt on the next lookup; the card write itself has already succeeded.
+// Must be called with the exclusive lock held.
+func (s *FileCardStore) updateAliasIndex(boardName string, wasStale bool, apply func(aliasIndex)) {
+	err := func() error {
+		if wasStale {
+			return s.rebuildAliasIndex(boardName)
+		}
+		index, err := s.readAliasIndex(boardName)
+		if err != nil {
+			return err
+		}
+		if index == nil {
+			return s.rebuildAliasIndex(boardName)
+		}
+		apply(index)
+		return s.writeAliasIndex(boardName, index)
+	}()
+	if err != nil {
+		os.Remove(s.paths.AliasIndexPath(boardName))
+	}
+}
+
+// removeCard drops every alias that points at cardID.
+func (index aliasIndex) removeCard(cardID string) {
+	for alias, id := range index {
+		if id == cardID {
+			delete(index, alias)
+		}
+	}
+}
+
+// lookupAlias returns the card ID the index has for alias, or "" if it has
+// none. The index is rebuilt first if it is stale or unreadable; the first
+// lookup on each board compares it against every card file, later ones only
+// against the cards directory (see aliasIndexStale).
+func (s *FileCardStore) lookupAlias(boardName, alias string) (string, error) {
+	_, checked := s.indexChecked.Load(boardName)
+
+	var stale bool
+	var cardID string
+	err := s.lock.withSharedLock(func() error {
+		if stale = s.aliasIndexStale(boardName, !checked); stale {
+			return nil
+		}
+		index, err := s.readAliasIndex(boardName)
+		if err != nil {
+			stale = true
+			return nil
+		}
+		cardID = index[alias]
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if stale {
+		if err := s.RebuildIndex(boardName); err != nil {
+			return "", err
+		}
+		err = s.lock.withSharedLock(func() error {
+			index, err := s.readAliasIndex(boardName)
+			cardID = index[alias]
+			return err
+		})
+		if err != nil {
+			return "", err
+		}
+	}
+	s.indexChecked.Store(boardName, true)
+	return cardID, nil
+}
+
+// RebuildIndex rebuilds a board's alias index from its card files.
+func (s *FileCardStore) RebuildIndex(boardName string) error {
+	if _, err := os.Stat(filepath.Dir(s.paths.AliasIndexPath(boardName))); err != nil {
+		if os.IsNotExist(err) {
+			return nil // no board, nothing to index
+		}
+		return err
+	}
+	err := s.lock.withLock(func() error {
+		return s.rebuildAliasIndex(boardName)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to rebuild alias index for board %s: %w", boardName, err)
+	}
+	return nil
+}
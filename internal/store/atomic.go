@@ -0,0 +1,20 @@
+package store
+
+import "os"
+
+// writeFileAtomic writes data to path via a sibling temp file and a rename.
+// Rename is atomic on POSIX filesystems, so a crash mid-write leaves either
+// the old file or the new one, never a truncated mix. The temp file is named
+// path + ".tmp", which store listings skip since it lacks their suffix.
+func writeFileAtomic(path string, data []byte) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
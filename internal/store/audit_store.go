@@ -0,0 +1,70 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/amterp/kan/internal/config"
+	"github.com/amterp/kan/internal/model"
+)
+
+// MaxAuditEntries is how many entries FileAuditStore keeps. Older entries are
+// dropped on write.
+const MaxAuditEntries = 10000
+
+// FileAuditStore implements AuditStore as newline-delimited JSON in
+// .kan/audit.jsonl, oldest entry first.
+type FileAuditStore struct {
+	paths      *config.Paths
+	maxEntries int
+	lines      lineCount
+	mu         sync.Mutex // Serializes appends and trims within a process (e.g. kan serve)
+}
+
+// NewAuditStore creates a new audit store.
+func NewAuditStore(paths *config.Paths) *FileAuditStore {
+	return &FileAuditStore{paths: paths, maxEntries: MaxAuditEntries}
+}
+
+// Append adds an entry to the end of the log. The log is trimmed back to the
+// most recent maxEntries once it overshoots them (see appendJSONLine).
+func (s *FileAuditStore) Append(entry model.AuditEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit entry: %w", err)
+	}
+
+	return appendJSONLine(s.paths.AuditLogPath(), "audit log", line, s.maxEntries, &s.lines)
+}
+
+// Recent returns up to limit entries for the board, newest first. An empty
+// boardName returns entries for all boards. Malformed lines are skipped.
+func (s *FileAuditStore) Recent(boardName string, limit int) ([]model.AuditEntry, error) {
+	s.mu.Lock()
+	lines, err := readJSONLines(s.paths.AuditLogPath(), "audit log")
+	s.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	lines = lastJSONLines(lines, s.maxEntries)
+
+	entries := []model.AuditEntry{}
+	for i := len(lines) - 1; i >= 0; i-- {
+		if limit > 0 && len(entries) >= limit {
+			break
+		}
+		var entry model.AuditEntry
+		if err := json.Unmarshal(lines[i], &entry); err != nil {
+			continue
+		}
+		if boardName != "" && entry.BoardName != boardName {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
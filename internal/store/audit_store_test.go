@@ -0,0 +1,87 @@
+package store
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/amterp/kan/internal/config"
+	"github.com/amterp/kan/internal/model"
+)
+
+func appendAuditEntries(t *testing.T, s *FileAuditStore, board string, n int) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		entry := model.AuditEntry{
+			Timestamp:  int64(i),
+			BoardName:  board,
+			EntityType: model.AuditEntityCard,
+			EntityID:   fmt.Sprintf("card-%d", i),
+			Action:     model.AuditActionCreated,
+		}
+		if err := s.Append(entry); err != nil {
+			t.Fatalf("Append failed: %v", err)
+		}
+	}
+}
+
+func TestFileAuditStore_Recent_Empty(t *testing.T) {
+	s := NewAuditStore(config.NewPaths(t.TempDir(), ""))
+
+	entries, err := s.Recent("main", 10)
+	if err != nil {
+		t.Fatalf("Recent failed: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("Expected no entries, got %d", len(entries))
+	}
+}
+
+func TestFileAuditStore_Recent_NewestFirstWithLimit(t *testing.T) {
+	s := NewAuditStore(config.NewPaths(t.TempDir(), ""))
+	appendAuditEntries(t, s, "main", 5)
+	appendAuditEntries(t, s, "other", 2)
+
+	cases := []struct {
+		name  string
+		board string
+		limit int
+		want  []string
+	}{
+		{name: "limit", board: "main", limit: 3, want: []string{"card-4", "card-3", "card-2"}},
+		{name: "no limit", board: "main", limit: 0, want: []string{"card-4", "card-3", "card-2", "card-1", "card-0"}},
+		{name: "scoped to board", board: "other", limit: 10, want: []string{"card-1", "card-0"}},
+		{name: "all boards", board: "", limit: 3, want: []string{"card-1", "card-0", "card-4"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			entries, err := s.Recent(tc.board, tc.limit)
+			if err != nil {
+				t.Fatalf("Recent failed: %v", err)
+			}
+			got := make([]string, len(entries))
+			for i, e := range entries {
+				got[i] = e.EntityID
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFileAuditStore_Append_TruncatesToMax(t *testing.T) {
+	s := NewAuditStore(config.NewPaths(t.TempDir(), ""))
+	s.maxEntries = 3
+	appendAuditEntries(t, s, "main", 5)
+
+	entries, err := s.Recent("main", 0)
+	if err != nil {
+		t.Fatalf("Recent failed: %v", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("Expected 3 entries after truncation, got %d", len(entries))
+	}
+	if entries[0].EntityID != "card-4" || entries[2].EntityID != "card-2" {
+		t.Errorf("Expected the newest entries to be kept, got %v", entries)
+	}
+}
@@ -1,24 +1,30 @@
 package store
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
+	"time"
 
 	"github.com/BurntSushi/toml"
 	"github.com/amterp/kan/internal/config"
 	kanerr "github.com/amterp/kan/internal/errors"
 	"github.com/amterp/kan/internal/model"
+	"github.com/amterp/kan/internal/util"
 	"github.com/amterp/kan/internal/version"
 )
 
 // FileBoardStore implements BoardStore using the filesystem.
 type FileBoardStore struct {
 	paths *config.Paths
+	lock  *fileLock
 }
 
 // NewBoardStore creates a new board store.
 func NewBoardStore(paths *config.Paths) *FileBoardStore {
-	return &FileBoardStore{paths: paths}
+	return &FileBoardStore{paths: paths, lock: newFileLock(paths)}
 }
 
 // Create creates a new board with the given config.
@@ -40,7 +46,7 @@ func (s *FileBoardStore) Create(cfg *model.BoardConfig) error {
 	}
 
 	// Write config
-	if err := s.writeConfig(cfg); err != nil {
+	if err := s.lock.withLock(func() error { return s.writeConfig(cfg) }); err != nil {
 		return fmt.Errorf("failed to write board config: %w", err)
 	}
 	return nil
@@ -49,7 +55,12 @@ func (s *FileBoardStore) Create(cfg *model.BoardConfig) error {
 // Get reads the board config from disk.
 func (s *FileBoardStore) Get(boardName string) (*model.BoardConfig, error) {
 	path := s.paths.BoardConfigPath(boardName)
-	data, err := os.ReadFile(path)
+	var data []byte
+	err := s.lock.withSharedLock(func() error {
+		var err error
+		data, err = os.ReadFile(path)
+		return err
+	})
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil, kanerr.BoardNotFound(boardName)
@@ -70,6 +81,14 @@ func (s *FileBoardStore) Get(boardName string) (*model.BoardConfig, error) {
 		return nil, version.InvalidBoardSchema(path, cfg.KanSchema)
 	}
 
+	// Merge shared definitions from included files. A missing include only
+	// loses its definitions; kan doctor reports it as BROKEN_INCLUDE.
+	if err := ResolveIncludes(s.paths.KanRoot(), &cfg); err != nil {
+		if errors.Is(err, ErrIncludeCycle) || !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("invalid board config: %w", err)
+		}
+	}
+
 	// Validate link rules and print warnings for invalid patterns
 	if warnings := model.ValidateLinkRules(cfg.LinkRules); len(warnings) > 0 {
 		for _, w := range warnings {
@@ -84,6 +103,55 @@ func (s *FileBoardStore) Get(boardName string) (*model.BoardConfig, error) {
 		}
 	}
 
+	// Validate alias settings and print warnings for invalid values
+	if warnings := cfg.ValidateAliasConfig(); len(warnings) > 0 {
+		for _, w := range warnings {
+			fmt.Fprintln(os.Stderr, "Warning:", w)
+		}
+	}
+
+	// Validate URL field patterns and print warnings for invalid regexes
+	if warnings := cfg.ValidateFieldPatterns(); len(warnings) > 0 {
+		for _, w := range warnings {
+			fmt.Fprintln(os.Stderr, "Warning:", w)
+		}
+	}
+
+	// Validate date field settings and print warnings for ignored values
+	if warnings := cfg.ValidateDateFields(); len(warnings) > 0 {
+		for _, w := range warnings {
+			fmt.Fprintln(os.Stderr, "Warning:", w)
+		}
+	}
+
+	// Validate transition rules and print warnings for dangling references
+	if warnings := cfg.ValidateTransitionRules(); len(warnings) > 0 {
+		for _, w := range warnings {
+			fmt.Fprintln(os.Stderr, "Warning:", w)
+		}
+	}
+
+	// Validate done column settings and print warnings for ignored values
+	if warnings := cfg.ValidateDoneColumns(); len(warnings) > 0 {
+		for _, w := range warnings {
+			fmt.Fprintln(os.Stderr, "Warning:", w)
+		}
+	}
+
+	// Validate stale card settings and print warnings for ignored values
+	if warnings := cfg.ValidateStaleConfig(); len(warnings) > 0 {
+		for _, w := range warnings {
+			fmt.Fprintln(os.Stderr, "Warning:", w)
+		}
+	}
+
+	// Validate card templates and print warnings for invalid patterns
+	if warnings := cfg.ValidateTemplates(); len(warnings) > 0 {
+		for _, w := range warnings {
+			fmt.Fprintln(os.Stderr, "Warning:", w)
+		}
+	}
+
 	return &cfg, nil
 }
 
@@ -92,27 +160,133 @@ func (s *FileBoardStore) Get(boardName string) (*model.BoardConfig, error) {
 // 1. The config may have been valid before stricter validation was added
 // 2. Update is often just adding/removing card IDs, not changing card_display
 // Validation happens on Create; invalid configs produce warnings at load time.
+//
+// Each Update sets cfg.Revision to one past the revision on disk, so writers
+// holding an older copy of the config can detect that it has changed.
 func (s *FileBoardStore) Update(cfg *model.BoardConfig) error {
-	if err := s.writeConfig(cfg); err != nil {
+	err := s.lock.withLock(func() error {
+		cfg.Revision = s.diskRevision(cfg.Name) + 1
+		return s.writeConfig(cfg)
+	})
+	if err != nil {
 		return fmt.Errorf("failed to update board config: %w", err)
 	}
 	return nil
 }
 
+// diskRevision returns the revision recorded in the board's config file, or 0
+// if it can't be read. Callers must hold the store lock.
+func (s *FileBoardStore) diskRevision(boardName string) int {
+	var stored struct {
+		Revision int `toml:"revision"`
+	}
+	if _, err := toml.DecodeFile(s.paths.BoardConfigPath(boardName), &stored); err != nil {
+		return 0
+	}
+	return stored.Revision
+}
+
 // Delete removes a board and all its data (config and cards).
 func (s *FileBoardStore) Delete(boardName string) error {
 	if !s.Exists(boardName) {
 		return kanerr.BoardNotFound(boardName)
 	}
 	boardDir := s.paths.BoardDir(boardName)
-	if err := os.RemoveAll(boardDir); err != nil {
+	if err := s.lock.withLock(func() error { return os.RemoveAll(boardDir) }); err != nil {
 		return fmt.Errorf("failed to delete board directory: %w", err)
 	}
 	return nil
 }
 
-// List returns the names of all boards.
+// Archive marks a board as archived. Its config and cards are kept, but List
+// no longer returns it.
+func (s *FileBoardStore) Archive(boardName string) error {
+	cfg, err := s.Get(boardName)
+	if err != nil {
+		return err
+	}
+	if cfg.IsArchived() {
+		return kanerr.InvalidField("board", fmt.Sprintf("board %q is already archived", boardName))
+	}
+	cfg.ArchivedAtMillis = util.NowMillis()
+	return s.Update(cfg)
+}
+
+// Unarchive restores an archived board.
+func (s *FileBoardStore) Unarchive(boardName string) error {
+	cfg, err := s.Get(boardName)
+	if err != nil {
+		return err
+	}
+	if !cfg.IsArchived() {
+		return kanerr.InvalidField("board", fmt.Sprintf("board %q is not archived", boardName))
+	}
+	cfg.ArchivedAtMillis = 0
+	return s.Update(cfg)
+}
+
+// Rename moves a board's directory, cards and alias index included, to
+// newName and records the new name in its config.
+func (s *FileBoardStore) Rename(oldName, newName string) error {
+	cfg, err := s.Get(oldName)
+	if err != nil {
+		return err
+	}
+	if s.Exists(newName) {
+		return kanerr.BoardAlreadyExists(newName)
+	}
+
+	err = s.lock.withLock(func() error {
+		if err := os.Rename(s.paths.BoardDir(oldName), s.paths.BoardDir(newName)); err != nil {
+			return err
+		}
+		cfg.Name = newName
+		return s.writeConfig(cfg)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to rename board %q: %w", oldName, err)
+	}
+	return nil
+}
+
+// List returns the names of all active boards.
 func (s *FileBoardStore) List() ([]string, error) {
+	all, err := s.ListAll()
+	if err != nil {
+		return nil, err
+	}
+	boards := []string{}
+	for _, name := range all {
+		if !s.isArchived(name) {
+			boards = append(boards, name)
+		}
+	}
+	return boards, nil
+}
+
+// isArchived reports whether a board is archived, under the store's lock.
+func (s *FileBoardStore) isArchived(boardName string) bool {
+	var archived bool
+	err := s.lock.withSharedLock(func() error {
+		archived = BoardArchived(s.paths, boardName)
+		return nil
+	})
+	return err == nil && archived
+}
+
+// BoardArchived reads just the archived flag from a board's config, without
+// taking the store's lock. Only that key is decoded, so boards with an
+// outdated or broken schema (which Get refuses) read as active.
+func BoardArchived(paths *config.Paths, boardName string) bool {
+	var flag struct {
+		ArchivedAtMillis int64 `toml:"archived_at_millis"`
+	}
+	_, err := toml.DecodeFile(paths.BoardConfigPath(boardName), &flag)
+	return err == nil && flag.ArchivedAtMillis > 0
+}
+
+// ListAll returns the names of all boards, archived ones included.
+func (s *FileBoardStore) ListAll() ([]string, error) {
 	boardsRoot := s.paths.BoardsRoot()
 
 	entries, err := os.ReadDir(boardsRoot)
@@ -140,6 +314,35 @@ func (s *FileBoardStore) List() ([]string, error) {
 	return boards, nil
 }
 
+// ModTime returns when the board's config file was last written.
+func (s *FileBoardStore) ModTime(boardName string) (time.Time, error) {
+	info, err := os.Stat(s.paths.BoardConfigPath(boardName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return time.Time{}, kanerr.BoardNotFound(boardName)
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+	return info.ModTime(), nil
+}
+
+// MostRecentBoard returns whichever of boards had its config written last,
+// or "" if none of them can be read.
+func MostRecentBoard(boardStore BoardStore, boards []string) string {
+	var latest string
+	var latestTime time.Time
+	for _, name := range boards {
+		modTime, err := boardStore.ModTime(name)
+		if err != nil {
+			continue
+		}
+		if latest == "" || modTime.After(latestTime) {
+			latest, latestTime = name, modTime
+		}
+	}
+	return latest
+}
+
 // Exists returns true if the board exists.
 func (s *FileBoardStore) Exists(boardName string) bool {
 	path := s.paths.BoardConfigPath(boardName)
@@ -151,13 +354,9 @@ func (s *FileBoardStore) writeConfig(cfg *model.BoardConfig) error {
 	// Stamp current schema version
 	cfg.KanSchema = version.CurrentBoardSchema()
 
-	path := s.paths.BoardConfigPath(cfg.Name)
-
-	f, err := os.Create(path)
-	if err != nil {
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(withoutIncluded(s.paths.KanRoot(), cfg)); err != nil {
 		return err
 	}
-	defer f.Close()
-
-	return toml.NewEncoder(f).Encode(cfg)
+	return writeFileAtomic(s.paths.BoardConfigPath(cfg.Name), buf.Bytes())
 }
@@ -1,13 +1,17 @@
 package store
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/amterp/kan/internal/config"
 	kanerr "github.com/amterp/kan/internal/errors"
 	"github.com/amterp/kan/internal/model"
+	"github.com/amterp/kan/internal/version"
 )
 
 func setupTestBoardStore(t *testing.T) (*FileBoardStore, string, func()) {
@@ -89,7 +93,7 @@ func TestFileBoardStore_CreateDuplicate(t *testing.T) {
 		t.Fatal("Expected error for duplicate board")
 	}
 
-	if !kanerr.IsAlreadyExists(err) {
+	if !kanerr.IsCode(err, kanerr.CodeBoardAlreadyExists) {
 		t.Errorf("Expected AlreadyExists error, got: %v", err)
 	}
 }
@@ -103,11 +107,37 @@ func TestFileBoardStore_GetNotFound(t *testing.T) {
 		t.Fatal("Expected error for nonexistent board")
 	}
 
-	if !kanerr.IsNotFound(err) {
+	if !kanerr.IsCode(err, kanerr.CodeBoardNotFound) {
 		t.Errorf("Expected NotFound error, got: %v", err)
 	}
 }
 
+func TestFileBoardStore_Get_RejectsOtherSchemas(t *testing.T) {
+	store, dir, cleanup := setupTestBoardStore(t)
+	defer cleanup()
+
+	if err := store.Create(&model.BoardConfig{ID: "b1", Name: "main", Columns: model.DefaultColumns(), DefaultColumn: "backlog"}); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if _, err := store.Get("main"); err != nil {
+		t.Fatalf("Get failed on the current schema: %v", err)
+	}
+
+	// Anything but the current schema is refused, not loaded: older boards
+	// need kan migrate, and newer or unknown ones a Kan that understands them.
+	path := filepath.Join(dir, ".kan", "boards", "main", "config.toml")
+	for _, schema := range []string{"board/1", version.FormatBoardSchema(version.CurrentBoardVersion + 1), "board/banana", "global/1"} {
+		data := "kan_schema = \"" + schema + "\"\nid = \"b1\"\nname = \"main\"\n"
+		if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+		var schemaErr *version.SchemaVersionError
+		if _, err := store.Get("main"); !errors.As(err, &schemaErr) {
+			t.Errorf("%s: expected SchemaVersionError, got %v", schema, err)
+		}
+	}
+}
+
 func TestFileBoardStore_Update(t *testing.T) {
 	store, _, cleanup := setupTestBoardStore(t)
 	defer cleanup()
@@ -148,6 +178,86 @@ func TestFileBoardStore_Update(t *testing.T) {
 	}
 }
 
+func TestFileBoardStore_Update_IncrementsRevision(t *testing.T) {
+	store, _, cleanup := setupTestBoardStore(t)
+	defer cleanup()
+
+	cfg := &model.BoardConfig{
+		ID:            "board123",
+		Name:          "main",
+		Columns:       model.DefaultColumns(),
+		DefaultColumn: "backlog",
+	}
+	if err := store.Create(cfg); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if cfg.Revision != 0 {
+		t.Errorf("Revision after Create = %d, want 0", cfg.Revision)
+	}
+
+	// Two writers holding the same stale copy still advance the revision,
+	// since it's taken from disk rather than the caller's config.
+	stale, err := store.Get("main")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if err := store.Update(cfg); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if err := store.Update(stale); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if stale.Revision != 2 {
+		t.Errorf("Revision on updated config = %d, want 2", stale.Revision)
+	}
+
+	retrieved, err := store.Get("main")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if retrieved.Revision != 2 {
+		t.Errorf("Revision on disk = %d, want 2", retrieved.Revision)
+	}
+}
+
+func TestFileBoardStore_Update_KeepsTagsSynonym(t *testing.T) {
+	store, dir, cleanup := setupTestBoardStore(t)
+	defer cleanup()
+
+	cfg := &model.BoardConfig{
+		ID:            "board123",
+		Name:          "main",
+		Columns:       model.DefaultColumns(),
+		DefaultColumn: "backlog",
+		CustomFields: map[string]model.CustomFieldSchema{
+			"components": {Type: model.FieldTypeTags},
+		},
+	}
+	if err := store.Create(cfg); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	loaded, err := store.Get("main")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if got := loaded.CustomFields["components"].FieldType(); got != model.FieldTypeFreeSet {
+		t.Errorf("FieldType() = %q, want free-set", got)
+	}
+	loaded.DefaultColumn = "next"
+	if err := store.Update(loaded); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, ".kan", "boards", "main", "config.toml"))
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	if !strings.Contains(string(data), `type = "tags"`) {
+		t.Errorf("Expected the tags synonym kept on disk, got:\n%s", data)
+	}
+}
+
 func TestFileBoardStore_List(t *testing.T) {
 	store, _, cleanup := setupTestBoardStore(t)
 	defer cleanup()
@@ -258,7 +368,7 @@ func TestFileBoardStore_Delete_NotFound(t *testing.T) {
 	if err == nil {
 		t.Fatal("Expected error for nonexistent board")
 	}
-	if !kanerr.IsNotFound(err) {
+	if !kanerr.IsCode(err, kanerr.CodeBoardNotFound) {
 		t.Errorf("Expected NotFound error, got: %v", err)
 	}
 }
@@ -295,6 +405,59 @@ func TestFileBoardStore_Delete_WithCards(t *testing.T) {
 	}
 }
 
+func TestFileBoardStore_ArchiveAndUnarchive(t *testing.T) {
+	store, dir, cleanup := setupTestBoardStore(t)
+	defer cleanup()
+
+	for _, name := range []string{"main", "old"} {
+		cfg := &model.BoardConfig{
+			ID:            name + "-id",
+			Name:          name,
+			Columns:       model.DefaultColumns(),
+			DefaultColumn: "backlog",
+		}
+		if err := store.Create(cfg); err != nil {
+			t.Fatalf("Create %s failed: %v", name, err)
+		}
+	}
+	cardStore := NewCardStore(config.NewPaths(dir, ""))
+	if err := cardStore.Create("old", &model.Card{ID: "c1", Title: "Kept", Column: "backlog"}); err != nil {
+		t.Fatalf("Create card failed: %v", err)
+	}
+
+	if err := store.Archive("old"); err != nil {
+		t.Fatalf("Archive failed: %v", err)
+	}
+	if listed, _ := store.List(); !reflect.DeepEqual(listed, []string{"main"}) {
+		t.Errorf("List() = %v, want only the active board", listed)
+	}
+	if all, _ := store.ListAll(); len(all) != 2 {
+		t.Errorf("ListAll() = %v, want both boards", all)
+	}
+	if cfg, err := store.Get("old"); err != nil || !cfg.IsArchived() {
+		t.Errorf("Expected archived board to stay readable and marked archived, got %+v, %v", cfg, err)
+	}
+	if card, err := cardStore.Get("old", "c1"); err != nil || card.Title != "Kept" {
+		t.Errorf("Expected archived board's card to stay readable, got %+v, %v", card, err)
+	}
+	if err := store.Archive("old"); !kanerr.IsCode(err, kanerr.CodeInvalidField) {
+		t.Errorf("Expected validation error archiving twice, got %v", err)
+	}
+
+	if err := store.Unarchive("old"); err != nil {
+		t.Fatalf("Unarchive failed: %v", err)
+	}
+	if listed, _ := store.List(); len(listed) != 2 {
+		t.Errorf("List() = %v, want the restored board back", listed)
+	}
+	if err := store.Unarchive("old"); !kanerr.IsCode(err, kanerr.CodeInvalidField) {
+		t.Errorf("Expected validation error unarchiving an active board, got %v", err)
+	}
+	if err := store.Archive("missing"); !kanerr.IsCode(err, kanerr.CodeBoardNotFound) {
+		t.Errorf("Expected not found archiving a missing board, got %v", err)
+	}
+}
+
 func TestFileBoardStore_WithCustomFieldsAndCardDisplay(t *testing.T) {
 	store, _, cleanup := setupTestBoardStore(t)
 	defer cleanup()
@@ -346,3 +509,45 @@ func TestFileBoardStore_WithCustomFieldsAndCardDisplay(t *testing.T) {
 		t.Errorf("CardDisplay.Badges not preserved: got %v", retrieved.CardDisplay.Badges)
 	}
 }
+
+func TestFileBoardStore_TransitionRulesRoundTrip(t *testing.T) {
+	store, dir, cleanup := setupTestBoardStore(t)
+	defer cleanup()
+
+	columns := model.DefaultColumns()
+	columns[3].TransitionRules = []model.TransitionRule{
+		{FromColumn: "in-progress", RequiredFields: []string{"reviewer", "type"}},
+		{ForbidIfFields: []string{"blocked"}},
+	}
+	cfg := &model.BoardConfig{
+		ID:            "board123",
+		Name:          "main",
+		Columns:       columns,
+		DefaultColumn: "backlog",
+		CustomFields: map[string]model.CustomFieldSchema{
+			"reviewer": {Type: model.FieldTypeString},
+			"type":     {Type: model.FieldTypeString},
+			"blocked":  {Type: model.FieldTypeBoolean},
+		},
+	}
+	if err := store.Create(cfg); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	retrieved, err := store.Get("main")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if !reflect.DeepEqual(retrieved.Columns, columns) {
+		t.Errorf("Columns mismatch:\ngot  %+v\nwant %+v", retrieved.Columns, columns)
+	}
+
+	// Columns without rules shouldn't grow an empty transition_rules key.
+	data, err := os.ReadFile(filepath.Join(dir, ".kan", "boards", "main", "config.toml"))
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	if n := strings.Count(string(data), "[[columns.transition_rules]]"); n != 2 {
+		t.Errorf("Expected 2 transition_rules tables, got %d:\n%s", n, data)
+	}
+}
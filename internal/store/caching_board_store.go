@@ -0,0 +1,343 @@
+package store
+
+import (
+	"encoding/json"
+	"log"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/amterp/kan/internal/config"
+	"github.com/amterp/kan/internal/model"
+	"github.com/fsnotify/fsnotify"
+)
+
+// CachingBoardStore wraps a BoardStore and keeps parsed board configs in
+// memory, so a busy `kan serve` doesn't re-read and re-parse config.toml on
+// every request. Each cached board's directory is watched and its entry is
+// evicted whenever config.toml changes on disk, including edits made outside
+// the process (an editor, the CLI, a git checkout). Boards that include shared
+// files are evicted when any of those files changes too.
+//
+// Get returns a copy of the cached config, so callers can modify it freely as
+// they do with configs read from disk.
+//
+// If the file watcher can't be created, the store passes every call straight
+// through to the wrapped store rather than risk serving stale configs.
+type CachingBoardStore struct {
+	inner   BoardStore
+	paths   *config.Paths
+	watcher *fsnotify.Watcher
+
+	cache    sync.Map // board name -> *model.BoardConfig
+	locks    sync.Map // board name -> *sync.Mutex
+	watched  sync.Map // watched directory -> struct{}
+	includes sync.Map // board name -> []string of included file paths
+
+	closeOnce sync.Once
+	done      chan struct{}
+}
+
+// NewCachingBoardStore creates a caching wrapper around inner. Call Close to
+// stop the file watcher once the store is no longer needed.
+func NewCachingBoardStore(inner BoardStore, paths *config.Paths) *CachingBoardStore {
+	s := &CachingBoardStore{
+		inner: inner,
+		paths: paths,
+		done:  make(chan struct{}),
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		log.Printf("Warning: board config caching disabled: %v", err)
+		return s
+	}
+	s.watcher = watcher
+	go s.run()
+	return s
+}
+
+// Close stops the file watcher. The store keeps working afterwards, but
+// without caching.
+func (s *CachingBoardStore) Close() error {
+	var err error
+	s.closeOnce.Do(func() {
+		if s.watcher == nil {
+			return
+		}
+		close(s.done)
+		err = s.watcher.Close()
+		s.cache.Range(func(key, _ any) bool {
+			s.cache.Delete(key)
+			return true
+		})
+	})
+	return err
+}
+
+// Create creates a new board.
+func (s *CachingBoardStore) Create(cfg *model.BoardConfig) error {
+	mu := s.lockFor(cfg.Name)
+	mu.Lock()
+	defer mu.Unlock()
+
+	s.cache.Delete(cfg.Name)
+	return s.inner.Create(cfg)
+}
+
+// Get returns the board config, from the cache when possible.
+func (s *CachingBoardStore) Get(boardName string) (*model.BoardConfig, error) {
+	if cached, ok := s.cache.Load(boardName); ok {
+		return cloneBoardConfig(cached.(*model.BoardConfig))
+	}
+	if !s.caching() {
+		return s.inner.Get(boardName)
+	}
+
+	// Hold the board's lock across the read so a concurrent Update can't be
+	// overwritten in the cache by the older config read here.
+	mu := s.lockFor(boardName)
+	mu.Lock()
+	defer mu.Unlock()
+
+	if cached, ok := s.cache.Load(boardName); ok {
+		return cloneBoardConfig(cached.(*model.BoardConfig))
+	}
+
+	// Watch before reading, so a change that lands between the read and
+	// the watch being added can't leave a stale entry behind.
+	if !s.watch(boardName) {
+		return s.inner.Get(boardName)
+	}
+	cfg, err := s.inner.Get(boardName)
+	if err != nil {
+		return nil, err
+	}
+	if len(cfg.Includes) > 0 {
+		// Included files are only known once the config is read, so watch
+		// them and read again: a change that landed before the watches were
+		// added is then in the second read.
+		if !s.watchIncludes(cfg) {
+			return cfg, nil
+		}
+		if cfg, err = s.inner.Get(boardName); err != nil {
+			return nil, err
+		}
+	}
+	cached, err := cloneBoardConfig(cfg)
+	if err != nil {
+		return cfg, nil
+	}
+	s.cache.Store(boardName, cached)
+	return cfg, nil
+}
+
+// Update writes the board config to disk and refreshes its cache entry.
+func (s *CachingBoardStore) Update(cfg *model.BoardConfig) error {
+	mu := s.lockFor(cfg.Name)
+	mu.Lock()
+	defer mu.Unlock()
+
+	if err := s.inner.Update(cfg); err != nil {
+		s.cache.Delete(cfg.Name)
+		return err
+	}
+	if !s.caching() || !s.watch(cfg.Name) {
+		return nil
+	}
+	if len(cfg.Includes) > 0 && !s.watchIncludes(cfg) {
+		s.cache.Delete(cfg.Name)
+		return nil
+	}
+	if cached, err := cloneBoardConfig(cfg); err == nil {
+		s.cache.Store(cfg.Name, cached)
+	} else {
+		s.cache.Delete(cfg.Name)
+	}
+	return nil
+}
+
+// Delete removes a board and its cache entry.
+func (s *CachingBoardStore) Delete(boardName string) error {
+	mu := s.lockFor(boardName)
+	mu.Lock()
+	defer mu.Unlock()
+
+	s.cache.Delete(boardName)
+	return s.inner.Delete(boardName)
+}
+
+// Reload drops the board's cached config so the next Get re-reads it from
+// disk. The store's own watcher already does this on change; Reload lets a
+// caller that saw the change first (e.g. the server broadcasting it) be sure
+// the next read is fresh.
+func (s *CachingBoardStore) Reload(boardName string) {
+	s.cache.Delete(boardName)
+}
+
+// Archive archives a board and drops its cache entry.
+func (s *CachingBoardStore) Archive(boardName string) error {
+	mu := s.lockFor(boardName)
+	mu.Lock()
+	defer mu.Unlock()
+
+	s.cache.Delete(boardName)
+	return s.inner.Archive(boardName)
+}
+
+// Unarchive restores an archived board and drops its cache entry.
+func (s *CachingBoardStore) Unarchive(boardName string) error {
+	mu := s.lockFor(boardName)
+	mu.Lock()
+	defer mu.Unlock()
+
+	s.cache.Delete(boardName)
+	return s.inner.Unarchive(boardName)
+}
+
+// Rename renames a board and drops the cache entries for both names.
+func (s *CachingBoardStore) Rename(oldName, newName string) error {
+	mu := s.lockFor(oldName)
+	mu.Lock()
+	defer mu.Unlock()
+
+	s.cache.Delete(oldName)
+	s.cache.Delete(newName)
+	return s.inner.Rename(oldName, newName)
+}
+
+// List returns active board names. Listing is not cached.
+func (s *CachingBoardStore) List() ([]string, error) {
+	return s.inner.List()
+}
+
+// ListAll returns all board names, archived included. Listing is not cached.
+func (s *CachingBoardStore) ListAll() ([]string, error) {
+	return s.inner.ListAll()
+}
+
+// Exists checks if a board exists.
+func (s *CachingBoardStore) Exists(boardName string) bool {
+	return s.inner.Exists(boardName)
+}
+
+// ModTime returns when the board's config was last written. It is not cached.
+func (s *CachingBoardStore) ModTime(boardName string) (time.Time, error) {
+	return s.inner.ModTime(boardName)
+}
+
+// caching reports whether the watcher is running, i.e. whether it's safe to
+// serve configs from the cache.
+func (s *CachingBoardStore) caching() bool {
+	if s.watcher == nil {
+		return false
+	}
+	select {
+	case <-s.done:
+		return false
+	default:
+		return true
+	}
+}
+
+// watch makes sure the board's directory is being watched. Directories are
+// watched rather than config.toml itself because editors and atomic writes
+// replace the file, which would silently drop a watch on the file.
+func (s *CachingBoardStore) watch(boardName string) bool {
+	return s.watchDir(s.paths.BoardDir(boardName))
+}
+
+// watchIncludes watches the directories of every file cfg includes and
+// records them, so a change to any of them evicts the board. Directories are
+// watched for the same reason as in watch, which also covers an included file
+// that doesn't exist yet.
+func (s *CachingBoardStore) watchIncludes(cfg *model.BoardConfig) bool {
+	files := IncludedFiles(s.paths.KanRoot(), cfg)
+	if files == nil {
+		return false
+	}
+	for _, file := range files {
+		if !s.watchDir(filepath.Dir(file)) {
+			return false
+		}
+	}
+	s.includes.Store(cfg.Name, files)
+	return true
+}
+
+// watchDir makes sure dir is being watched.
+func (s *CachingBoardStore) watchDir(dir string) bool {
+	if _, ok := s.watched.Load(dir); ok {
+		return true
+	}
+	if err := s.watcher.Add(dir); err != nil {
+		return false
+	}
+	s.watched.Store(dir, struct{}{})
+	return true
+}
+
+// run evicts cache entries as their config files change.
+func (s *CachingBoardStore) run() {
+	for {
+		select {
+		case <-s.done:
+			return
+		case event, ok := <-s.watcher.Events:
+			if !ok {
+				return
+			}
+			s.evictIncluders(filepath.Clean(event.Name))
+			if filepath.Base(event.Name) != config.ConfigFileName {
+				continue
+			}
+			s.Reload(filepath.Base(filepath.Dir(event.Name)))
+			if event.Has(fsnotify.Remove) || event.Has(fsnotify.Rename) {
+				// A removed board directory drops its watch; re-add it on
+				// the next read.
+				s.watched.Delete(filepath.Dir(event.Name))
+			}
+		case err, ok := <-s.watcher.Errors:
+			if !ok {
+				return
+			}
+			// Events may have been dropped, so nothing cached can be trusted.
+			log.Printf("Warning: board config watcher error: %v", err)
+			s.cache.Range(func(key, _ any) bool {
+				s.cache.Delete(key)
+				return true
+			})
+		}
+	}
+}
+
+// evictIncluders drops the cached config of every board that includes path.
+func (s *CachingBoardStore) evictIncluders(path string) {
+	s.includes.Range(func(board, files any) bool {
+		if slices.Contains(files.([]string), path) {
+			s.Reload(board.(string))
+		}
+		return true
+	})
+}
+
+// lockFor returns the mutex serializing cache fills and writes for a board.
+func (s *CachingBoardStore) lockFor(boardName string) *sync.Mutex {
+	mu, _ := s.locks.LoadOrStore(boardName, &sync.Mutex{})
+	return mu.(*sync.Mutex)
+}
+
+// cloneBoardConfig deep-copies a board config via its JSON form, which
+// covers every persisted field.
+func cloneBoardConfig(cfg *model.BoardConfig) (*model.BoardConfig, error) {
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	var clone model.BoardConfig
+	if err := json.Unmarshal(data, &clone); err != nil {
+		return nil, err
+	}
+	return &clone, nil
+}
@@ -0,0 +1,244 @@
+package store
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/amterp/kan/internal/config"
+	"github.com/amterp/kan/internal/model"
+)
+
+func setupCachingBoardStore(t testing.TB) (*CachingBoardStore, *FileBoardStore, *config.Paths) {
+	t.Helper()
+
+	dir := t.TempDir()
+	paths := config.NewPaths(dir, "")
+	inner := NewBoardStore(paths)
+	cfg := &model.BoardConfig{
+		ID:            "board123",
+		Name:          "main",
+		Columns:       model.DefaultColumns(),
+		DefaultColumn: "backlog",
+	}
+	if err := inner.Create(cfg); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	s := NewCachingBoardStore(inner, paths)
+	t.Cleanup(func() { s.Close() })
+	return s, inner, paths
+}
+
+// waitFor polls cond until it holds or a second passes, since file watcher
+// events are delivered asynchronously.
+func waitFor(t *testing.T, cond func() bool) bool {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if cond() {
+			return true
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return cond()
+}
+
+func TestCachingBoardStore_GetReturnsCopies(t *testing.T) {
+	s, _, _ := setupCachingBoardStore(t)
+
+	first, err := s.Get("main")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	first.Columns[0].Name = "mutated"
+
+	second, err := s.Get("main")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if second.Columns[0].Name != "backlog" {
+		t.Errorf("Mutating a returned config changed the cache: got column %q", second.Columns[0].Name)
+	}
+}
+
+func TestCachingBoardStore_UpdateRefreshesCache(t *testing.T) {
+	s, inner, _ := setupCachingBoardStore(t)
+
+	cfg, err := s.Get("main")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	cfg.DefaultColumn = "done"
+	if err := s.Update(cfg); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	got, err := s.Get("main")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if got.DefaultColumn != "done" {
+		t.Errorf("Expected cached default column 'done', got %q", got.DefaultColumn)
+	}
+	onDisk, err := inner.Get("main")
+	if err != nil {
+		t.Fatalf("inner Get failed: %v", err)
+	}
+	if onDisk.DefaultColumn != "done" {
+		t.Errorf("Expected update on disk, got %q", onDisk.DefaultColumn)
+	}
+}
+
+func TestCachingBoardStore_ExternalEditEvicts(t *testing.T) {
+	s, _, paths := setupCachingBoardStore(t)
+
+	if _, err := s.Get("main"); err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+
+	// Edit the file behind the store's back, as a text editor or git would.
+	configPath := filepath.Join(paths.BoardDir("main"), config.ConfigFileName)
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	edited := strings.Replace(string(data), `default_column = "backlog"`, `default_column = "done"`, 1)
+	if edited == string(data) {
+		t.Fatal("Test setup: default_column line not found in config")
+	}
+	if err := os.WriteFile(configPath, []byte(edited), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	ok := waitFor(t, func() bool {
+		cfg, err := s.Get("main")
+		return err == nil && cfg.DefaultColumn == "done"
+	})
+	if !ok {
+		t.Error("Expected external edit to evict the cached config")
+	}
+}
+
+func TestCachingBoardStore_IncludeEditEvicts(t *testing.T) {
+	s, inner, paths := setupCachingBoardStore(t)
+
+	shared := filepath.Join(paths.KanRoot(), "shared", "fields.toml")
+	if err := os.MkdirAll(filepath.Dir(shared), 0755); err != nil {
+		t.Fatalf("MkdirAll failed: %v", err)
+	}
+	if err := os.WriteFile(shared, []byte("[custom_fields.team]\ntype = \"string\"\n"), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	cfg, err := inner.Get("main")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	cfg.Includes = []string{"shared/fields.toml"}
+	if err := inner.Update(cfg); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	if _, err := s.Get("main"); err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if _, ok := s.cache.Load("main"); !ok {
+		t.Fatal("Expected a board with includes to be cached")
+	}
+
+	if err := os.WriteFile(shared, []byte("[custom_fields.team]\ntype = \"date\"\n"), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	ok := waitFor(t, func() bool {
+		cfg, err := s.Get("main")
+		return err == nil && cfg.CustomFields["team"].Type == "date"
+	})
+	if !ok {
+		t.Error("Expected an edit to an included file to evict the cached config")
+	}
+}
+
+func TestCachingBoardStore_DeleteEvicts(t *testing.T) {
+	s, _, _ := setupCachingBoardStore(t)
+
+	if _, err := s.Get("main"); err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if err := s.Delete("main"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if _, err := s.Get("main"); err == nil {
+		t.Error("Expected Get to fail after Delete")
+	}
+}
+
+func TestCachingBoardStore_Reload(t *testing.T) {
+	s, _, _ := setupCachingBoardStore(t)
+
+	if _, err := s.Get("main"); err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	// Plant a stale entry, as if an eviction had been missed.
+	s.cache.Store("main", &model.BoardConfig{Name: "main", DefaultColumn: "stale"})
+
+	s.Reload("main")
+
+	got, err := s.Get("main")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if got.DefaultColumn != "backlog" {
+		t.Errorf("Expected Reload to force a fresh read, got %q", got.DefaultColumn)
+	}
+}
+
+func TestCachingBoardStore_PassThroughAfterClose(t *testing.T) {
+	s, inner, _ := setupCachingBoardStore(t)
+
+	if _, err := s.Get("main"); err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	s.Close()
+
+	cfg, _ := inner.Get("main")
+	cfg.DefaultColumn = "done"
+	if err := inner.Update(cfg); err != nil {
+		t.Fatalf("inner Update failed: %v", err)
+	}
+
+	got, err := s.Get("main")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if got.DefaultColumn != "done" {
+		t.Errorf("Expected a closed store to read through to disk, got %q", got.DefaultColumn)
+	}
+}
+
+func BenchmarkBoardStoreGet(b *testing.B) {
+	const reads = 10000
+
+	b.Run("uncached", func(b *testing.B) {
+		_, inner, _ := setupCachingBoardStore(b)
+		for b.Loop() {
+			for range reads {
+				if _, err := inner.Get("main"); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+
+	b.Run("cached", func(b *testing.B) {
+		s, _, _ := setupCachingBoardStore(b)
+		for b.Loop() {
+			for range reads {
+				if _, err := s.Get("main"); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+}
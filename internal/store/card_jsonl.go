@@ -0,0 +1,148 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sort"
+
+	"github.com/BurntSushi/toml"
+	kanerr "github.com/amterp/kan/internal/errors"
+	"github.com/amterp/kan/internal/id"
+	"github.com/amterp/kan/internal/model"
+	"github.com/amterp/kan/internal/version"
+)
+
+// boardColumns returns the names of a board's columns, in board order,
+// reading only that part of its config.
+func (s *FileCardStore) boardColumns(boardName string) ([]string, error) {
+	var stored struct {
+		Columns []struct {
+			Name string `toml:"name"`
+		} `toml:"columns"`
+	}
+	if _, err := toml.DecodeFile(s.paths.BoardConfigPath(boardName), &stored); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, kanerr.BoardNotFound(boardName)
+		}
+		return nil, fmt.Errorf("invalid board config: %w", err)
+	}
+	names := make([]string, len(stored.Columns))
+	for i, col := range stored.Columns {
+		names[i] = col.Name
+	}
+	return names, nil
+}
+
+// ExportJSONL writes every card on a board, archived ones included, to w as
+// newline-delimited JSON: one card per line, in column order and then by
+// position. Cards in a column the board no longer has come last.
+func (s *FileCardStore) ExportJSONL(boardName string, w io.Writer) error {
+	columns, err := s.boardColumns(boardName)
+	if err != nil {
+		return err
+	}
+	cards, err := s.List(boardName, true)
+	if err != nil {
+		return err
+	}
+
+	columnIndex := func(name string) int {
+		if i := slices.Index(columns, name); i >= 0 {
+			return i
+		}
+		return len(columns)
+	}
+	sort.SliceStable(cards, func(i, j int) bool {
+		ci, cj := columnIndex(cards[i].Column), columnIndex(cards[j].Column)
+		if ci != cj {
+			return ci < cj
+		}
+		if cards[i].Position != cards[j].Position {
+			return cards[i].Position < cards[j].Position
+		}
+		return cards[i].ID < cards[j].ID
+	})
+
+	enc := json.NewEncoder(w)
+	for _, card := range cards {
+		if err := enc.Encode(card); err != nil {
+			return fmt.Errorf("failed to write card %s: %w", card.ID, err)
+		}
+	}
+	return nil
+}
+
+// ReadJSONL decodes newline-delimited JSON cards, as written by ExportJSONL,
+// calling fn with each card and its 1-based line number in turn. It stops at
+// the first card that doesn't decode, has an invalid ID or an outdated
+// schema, or that fn rejects. A failure to read r is returned wrapped rather
+// than as an invalid-field error, so callers can tell the two apart.
+func ReadJSONL(r io.Reader, fn func(n int, card *model.Card) error) error {
+	dec := json.NewDecoder(r)
+	for n := 1; ; n++ {
+		var card model.Card
+		if err := dec.Decode(&card); err == io.EOF {
+			return nil
+		} else if err != nil {
+			var syntaxErr *json.SyntaxError
+			var typeErr *json.UnmarshalTypeError
+			if errors.As(err, &syntaxErr) || errors.As(err, &typeErr) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return kanerr.InvalidField("jsonl", fmt.Sprintf("card %d: %v", n, err))
+			}
+			return fmt.Errorf("failed to read card %d: %w", n, err)
+		}
+
+		if !id.IsValidID(card.ID) {
+			return kanerr.InvalidField("jsonl", fmt.Sprintf("card %d: invalid id %q", n, card.ID))
+		}
+		if card.Version != version.CurrentCardVersion {
+			return kanerr.InvalidField("jsonl", fmt.Sprintf("card %s has schema card/%d, expected card/%d",
+				card.ID, card.Version, version.CurrentCardVersion))
+		}
+		if err := fn(n, &card); err != nil {
+			return err
+		}
+	}
+}
+
+// ImportJSONL reads newline-delimited JSON cards, as written by ExportJSONL,
+// and writes them to a board as they are: a card whose ID is already on the
+// board is updated, any other is created. Cards keep their IDs, so importing
+// the same export twice leaves the board unchanged. Only the card format and
+// columns are checked; CardService.ImportJSONL also checks cards against the
+// board's custom fields and aliases.
+//
+// On a bad card the cards before it stay imported, and their count is
+// returned alongside the error.
+func (s *FileCardStore) ImportJSONL(boardName string, r io.Reader) (int, error) {
+	columns, err := s.boardColumns(boardName)
+	if err != nil {
+		return 0, err
+	}
+
+	imported := 0
+	err = ReadJSONL(r, func(_ int, card *model.Card) error {
+		if !slices.Contains(columns, card.Column) {
+			return kanerr.InvalidField("jsonl", fmt.Sprintf("card %s is in unknown column %q", card.ID, card.Column))
+		}
+		if err := model.ValidateCustomFields(card.CustomFields); err != nil {
+			return err
+		}
+
+		if _, err := s.Get(boardName, card.ID); err == nil {
+			err = s.Update(boardName, card)
+		} else if kanerr.IsNotFound(err) {
+			err = s.Create(boardName, card)
+		}
+		if err != nil {
+			return err
+		}
+		imported++
+		return nil
+	})
+	return imported, err
+}
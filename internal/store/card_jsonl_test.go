@@ -0,0 +1,144 @@
+package store
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/amterp/kan/internal/config"
+	kanerr "github.com/amterp/kan/internal/errors"
+	"github.com/amterp/kan/internal/model"
+	"github.com/amterp/kan/internal/version"
+)
+
+// setupJSONLStores creates boards with the default columns and a card store
+// over the same project.
+func setupJSONLStores(t *testing.T, boards ...string) (*FileCardStore, func()) {
+	t.Helper()
+	boardStore, dir, cleanup := setupTestBoardStore(t)
+	for _, name := range boards {
+		cfg := &model.BoardConfig{ID: name + "-id", Name: name, Columns: model.DefaultColumns(), DefaultColumn: "backlog"}
+		if err := boardStore.Create(cfg); err != nil {
+			cleanup()
+			t.Fatalf("Create failed: %v", err)
+		}
+	}
+	return NewCardStore(config.NewPaths(dir, "")), cleanup
+}
+
+func TestFileCardStore_ExportImportJSONL(t *testing.T) {
+	store, cleanup := setupJSONLStores(t, "main", "copy")
+	defer cleanup()
+
+	columns := []string{"done", "backlog", "in-progress"}
+	for i := range 10 {
+		card := &model.Card{
+			ID:              fmt.Sprintf("card%02d", i),
+			Alias:           fmt.Sprintf("card-%d", i),
+			Title:           fmt.Sprintf("Card %d", i),
+			Column:          columns[i%len(columns)],
+			Position:        fmt.Sprintf("a%d", 9-i),
+			Creator:         "tester",
+			CreatedAtMillis: int64(1000 + i),
+			UpdatedAtMillis: int64(2000 + i),
+			Archived:        i == 9,
+			CustomFields:    map[string]any{"priority": "high", "estimate": float64(i)},
+		}
+		if err := store.Create("main", card); err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := store.ExportJSONL("main", &buf); err != nil {
+		t.Fatalf("ExportJSONL failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 10 {
+		t.Fatalf("Expected 10 lines, got %d", len(lines))
+	}
+	if !strings.Contains(lines[0], `"column":"backlog"`) || !strings.Contains(lines[9], `"column":"done"`) {
+		t.Errorf("Expected cards in column order, got first %s and last %s", lines[0], lines[9])
+	}
+
+	imported, err := store.ImportJSONL("copy", bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("ImportJSONL failed: %v", err)
+	}
+	if imported != 10 {
+		t.Errorf("Expected 10 cards imported, got %d", imported)
+	}
+
+	originals, err := store.List("main", true)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	copies, err := store.List("copy", true)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(copies) != len(originals) {
+		t.Fatalf("Expected %d cards on the copy, got %d", len(originals), len(copies))
+	}
+	for _, orig := range originals {
+		got, err := store.Get("copy", orig.ID)
+		if err != nil {
+			t.Fatalf("Get failed: %v", err)
+		}
+		if !reflect.DeepEqual(got, orig) {
+			t.Errorf("Card %s differs after round trip:\n got %+v\nwant %+v", orig.ID, got, orig)
+		}
+	}
+
+	// Existing IDs are updated rather than duplicated.
+	if _, err := store.ImportJSONL("copy", bytes.NewReader(buf.Bytes())); err != nil {
+		t.Fatalf("Re-import failed: %v", err)
+	}
+	if copies, _ := store.List("copy", true); len(copies) != 10 {
+		t.Errorf("Expected re-import to keep 10 cards, got %d", len(copies))
+	}
+}
+
+func TestFileCardStore_ImportJSONL_Errors(t *testing.T) {
+	store, cleanup := setupJSONLStores(t, "main")
+	defer cleanup()
+
+	v := version.CurrentCardVersion
+	good := fmt.Sprintf(`{"_v":%d,"id":"ok1","title":"Fine","column":"backlog","position":"a"}`+"\n", v)
+	tests := []struct {
+		name         string
+		input        string
+		wantImported int
+	}{
+		{"malformed", good + "{not json\n", 1},
+		{"bad id", fmt.Sprintf(`{"_v":%d,"id":"../x","title":"Bad","column":"backlog"}`, v), 0},
+		{"unknown column", good + fmt.Sprintf(`{"_v":%d,"id":"ok2","title":"Lost","column":"review"}`, v), 1},
+		{"old schema", fmt.Sprintf(`{"_v":%d,"id":"old","title":"Old","column":"backlog"}`, v-1), 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			imported, err := store.ImportJSONL("main", strings.NewReader(tt.input))
+			if !kanerr.IsCode(err, kanerr.CodeInvalidField) {
+				t.Errorf("Expected invalid input error, got %v", err)
+			}
+			if imported != tt.wantImported {
+				t.Errorf("Expected %d imported before the error, got %d", tt.wantImported, imported)
+			}
+		})
+	}
+
+	if _, err := store.ImportJSONL("missing", strings.NewReader(good)); !kanerr.IsCode(err, kanerr.CodeBoardNotFound) {
+		t.Errorf("Expected board not found, got %v", err)
+	}
+
+	readErr := errors.New("connection reset")
+	body := io.MultiReader(strings.NewReader(good), iotest.ErrReader(readErr))
+	if _, err := store.ImportJSONL("main", body); !errors.Is(err, readErr) || kanerr.IsCode(err, kanerr.CodeInvalidField) {
+		t.Errorf("Expected the read error, not invalid input, got %v", err)
+	}
+}
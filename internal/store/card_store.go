@@ -3,9 +3,11 @@ package store
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 
 	"github.com/amterp/kan/internal/config"
 	kanerr "github.com/amterp/kan/internal/errors"
@@ -16,11 +18,16 @@ import (
 // FileCardStore implements CardStore using the filesystem.
 type FileCardStore struct {
 	paths *config.Paths
+	lock  *fileLock
+
+	// indexChecked records the boards whose alias index has been compared
+	// against every card file since the store was created.
+	indexChecked sync.Map
 }
 
 // NewCardStore creates a new card store.
 func NewCardStore(paths *config.Paths) *FileCardStore {
-	return &FileCardStore{paths: paths}
+	return &FileCardStore{paths: paths, lock: newFileLock(paths)}
 }
 
 // Create writes a new card to disk.
@@ -33,13 +40,29 @@ func (s *FileCardStore) Create(boardName string, card *model.Card) error {
 		return fmt.Errorf("failed to create cards directory: %w", err)
 	}
 
-	return s.writeCard(path, card)
+	return s.lock.withLock(func() error {
+		stale := s.aliasIndexStale(boardName, false)
+		if err := s.writeCard(path, card); err != nil {
+			return err
+		}
+		s.updateAliasIndex(boardName, stale, func(index aliasIndex) {
+			if card.Alias != "" {
+				index[card.Alias] = card.ID
+			}
+		})
+		return nil
+	})
 }
 
 // Get reads a card from disk by ID.
 func (s *FileCardStore) Get(boardName, cardID string) (*model.Card, error) {
 	path := s.paths.CardPath(boardName, cardID)
-	card, err := s.readCard(path)
+	var card *model.Card
+	err := s.lock.withSharedLock(func() error {
+		var err error
+		card, err = s.readCard(path)
+		return err
+	})
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil, kanerr.CardNotFound(cardID)
@@ -52,7 +75,22 @@ func (s *FileCardStore) Get(boardName, cardID string) (*model.Card, error) {
 // Update writes an existing card to disk.
 func (s *FileCardStore) Update(boardName string, card *model.Card) error {
 	path := s.paths.CardPath(boardName, card.ID)
-	if err := s.writeCard(path, card); err != nil {
+	err := s.lock.withLock(func() error {
+		stale := s.aliasIndexStale(boardName, false)
+		if err := s.writeCard(path, card); err != nil {
+			return err
+		}
+		// Rewrite the index even if the alias is unchanged, so it stays newer
+		// than the card file (see aliasIndexStale).
+		s.updateAliasIndex(boardName, stale, func(index aliasIndex) {
+			index.removeCard(card.ID)
+			if card.Alias != "" {
+				index[card.Alias] = card.ID
+			}
+		})
+		return nil
+	})
+	if err != nil {
 		return fmt.Errorf("failed to update card %s: %w", card.ID, err)
 	}
 	return nil
@@ -61,7 +99,17 @@ func (s *FileCardStore) Update(boardName string, card *model.Card) error {
 // Delete removes a card from disk.
 func (s *FileCardStore) Delete(boardName, cardID string) error {
 	path := s.paths.CardPath(boardName, cardID)
-	if err := os.Remove(path); err != nil {
+	err := s.lock.withLock(func() error {
+		stale := s.aliasIndexStale(boardName, false)
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+		s.updateAliasIndex(boardName, stale, func(index aliasIndex) {
+			index.removeCard(cardID)
+		})
+		return nil
+	})
+	if err != nil {
 		if os.IsNotExist(err) {
 			return kanerr.CardNotFound(cardID)
 		}
@@ -70,9 +118,74 @@ func (s *FileCardStore) Delete(boardName, cardID string) error {
 	return nil
 }
 
-// List returns all cards for a board.
-// Malformed card files are logged and skipped.
-func (s *FileCardStore) List(boardName string) ([]*model.Card, error) {
+// List returns all cards for a board. Archived cards are omitted unless
+// includeArchived is set. Malformed card files are logged and skipped.
+func (s *FileCardStore) List(boardName string, includeArchived bool) ([]*model.Card, error) {
+	var cards []*model.Card
+	err := s.lock.withSharedLock(func() error {
+		var err error
+		cards, err = s.list(boardName, includeArchived)
+		return err
+	})
+	return cards, err
+}
+
+// streamBatchSize is how many directory entries Stream reads at a time.
+const streamBatchSize = 256
+
+// Stream sends the board's cards on the returned channel as each file is read,
+// so memory use stays flat regardless of board size. Unlike List, each card
+// file is read under its own shared lock rather than one lock for the whole
+// board, so a slow consumer never blocks writers; a card deleted mid-stream
+// is skipped. Malformed card files are sent as errors.
+func (s *FileCardStore) Stream(boardName string) (<-chan CardOrError, error) {
+	cardsDir := s.paths.CardsDir(boardName)
+	dir, err := os.Open(cardsDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return StreamSlice(nil), nil
+		}
+		return nil, fmt.Errorf("failed to read cards directory: %w", err)
+	}
+
+	ch := make(chan CardOrError, streamBatchSize)
+	go func() {
+		defer close(ch)
+		defer dir.Close()
+		for {
+			entries, err := dir.ReadDir(streamBatchSize)
+			for _, entry := range entries {
+				if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+					continue
+				}
+				var card *model.Card
+				readErr := s.lock.withSharedLock(func() error {
+					var err error
+					card, err = s.readCard(filepath.Join(cardsDir, entry.Name()))
+					return err
+				})
+				if os.IsNotExist(readErr) {
+					continue
+				}
+				if readErr != nil {
+					ch <- CardOrError{Err: fmt.Errorf("card file %s: %w", entry.Name(), readErr)}
+					continue
+				}
+				ch <- CardOrError{Card: card}
+			}
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				ch <- CardOrError{Err: fmt.Errorf("failed to read cards directory: %w", err)}
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
+
+func (s *FileCardStore) list(boardName string, includeArchived bool) ([]*model.Card, error) {
 	cardsDir := s.paths.CardsDir(boardName)
 
 	entries, err := os.ReadDir(cardsDir)
@@ -96,6 +209,9 @@ func (s *FileCardStore) List(boardName string) ([]*model.Card, error) {
 			fmt.Fprintf(os.Stderr, "Warning: skipping malformed card file %s: %v\n", entry.Name(), err)
 			continue
 		}
+		if card.Archived && !includeArchived {
+			continue
+		}
 		cards = append(cards, card)
 	}
 
@@ -105,9 +221,32 @@ func (s *FileCardStore) List(boardName string) ([]*model.Card, error) {
 	return cards, nil
 }
 
-// FindByAlias searches for a card by alias.
+// FindByAlias finds a card by alias, including archived cards. It looks the
+// alias up in the board's alias index and reads only the matching card file;
+// if the index entry turns out to be wrong, the index is rebuilt and the
+// board scanned.
 func (s *FileCardStore) FindByAlias(boardName, alias string) (*model.Card, error) {
-	cards, err := s.List(boardName)
+	cardID, err := s.lookupAlias(boardName, alias)
+	if err != nil {
+		return nil, err
+	}
+	if cardID == "" {
+		return nil, kanerr.CardNotFound(alias)
+	}
+
+	card, err := s.Get(boardName, cardID)
+	if err == nil && card.Alias == alias {
+		return card, nil
+	}
+	if err := s.RebuildIndex(boardName); err != nil {
+		return nil, err
+	}
+	return s.findByAliasScan(boardName, alias)
+}
+
+// findByAliasScan finds a card by alias by reading every card on the board.
+func (s *FileCardStore) findByAliasScan(boardName, alias string) (*model.Card, error) {
+	cards, err := s.List(boardName, true)
 	if err != nil {
 		return nil, err
 	}
@@ -154,7 +293,7 @@ func (s *FileCardStore) writeCard(path string, card *model.Card) error {
 		return fmt.Errorf("failed to marshal card: %w", err)
 	}
 
-	if err := os.WriteFile(path, data, 0644); err != nil {
+	if err := writeFileAtomic(path, data); err != nil {
 		return fmt.Errorf("failed to write card file: %w", err)
 	}
 	return nil
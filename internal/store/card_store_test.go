@@ -1,9 +1,14 @@
 package store
 
 import (
+	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"runtime"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/amterp/kan/internal/config"
 	kanerr "github.com/amterp/kan/internal/errors"
@@ -80,7 +85,7 @@ func TestFileCardStore_GetNotFound(t *testing.T) {
 		t.Fatal("Expected error for nonexistent card")
 	}
 
-	if !kanerr.IsNotFound(err) {
+	if !kanerr.IsCode(err, kanerr.CodeCardNotFound) {
 		t.Errorf("Expected NotFound error, got: %v", err)
 	}
 }
@@ -123,6 +128,74 @@ func TestFileCardStore_Update(t *testing.T) {
 	}
 }
 
+// crashWriterEnv tells the test binary to act as the crash-test writer rather
+// than run tests; its value is the project directory to write into.
+const crashWriterEnv = "KAN_TEST_CRASH_WRITER_DIR"
+
+// TestFileCardStore_CrashWriter isn't a real test: when re-executed by
+// TestFileCardStore_UpdateSurvivesCrash it rewrites a large card until killed.
+func TestFileCardStore_CrashWriter(t *testing.T) {
+	dir := os.Getenv(crashWriterEnv)
+	if dir == "" {
+		t.Skip("only runs as a helper process")
+	}
+	store := NewCardStore(config.NewPaths(dir, ""))
+	card := &model.Card{ID: "big", Title: "Big", Description: strings.Repeat("x", 8<<20)}
+	for {
+		if err := store.Update("main", card); err != nil {
+			t.Fatalf("Update failed: %v", err)
+		}
+	}
+}
+
+func TestFileCardStore_UpdateSurvivesCrash(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("os.Interrupt can't be sent on Windows")
+	}
+	store, dir, cleanup := setupTestCardStore(t)
+	defer cleanup()
+
+	if err := store.Create("main", &model.Card{ID: "big", Title: "Original"}); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	cmd := exec.Command(os.Args[0], "-test.run=^TestFileCardStore_CrashWriter$")
+	cmd.Env = append(os.Environ(), crashWriterEnv+"="+dir)
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("Failed to start writer: %v", err)
+	}
+
+	// Interrupt the writer once it's partway through writing a temp file.
+	tmp := filepath.Join(dir, ".kan", "boards", "main", "cards", "big.json.tmp")
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		if info, err := os.Stat(tmp); err == nil && info.Size() > 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			cmd.Process.Kill()
+			t.Fatal("Writer never started writing")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if err := cmd.Process.Signal(os.Interrupt); err != nil {
+		t.Fatalf("Failed to interrupt writer: %v", err)
+	}
+	cmd.Wait()
+
+	card, err := store.Get("main", "big")
+	if err != nil {
+		t.Fatalf("Card corrupted by interrupted write: %v", err)
+	}
+	if card.Title != "Original" && card.Title != "Big" {
+		t.Errorf("Unexpected title %q", card.Title)
+	}
+	cards, err := store.List("main", false)
+	if err != nil || len(cards) != 1 {
+		t.Errorf("Expected the leftover temp file to be ignored, got %d cards (err %v)", len(cards), err)
+	}
+}
+
 func TestFileCardStore_Delete(t *testing.T) {
 	store, _, cleanup := setupTestCardStore(t)
 	defer cleanup()
@@ -147,7 +220,7 @@ func TestFileCardStore_Delete(t *testing.T) {
 
 	// Verify gone
 	_, err := store.Get("main", "test123")
-	if !kanerr.IsNotFound(err) {
+	if !kanerr.IsCode(err, kanerr.CodeCardNotFound) {
 		t.Errorf("Card should be deleted, got err: %v", err)
 	}
 }
@@ -157,7 +230,7 @@ func TestFileCardStore_DeleteNotFound(t *testing.T) {
 	defer cleanup()
 
 	err := store.Delete("main", "nonexistent")
-	if !kanerr.IsNotFound(err) {
+	if !kanerr.IsCode(err, kanerr.CodeCardNotFound) {
 		t.Errorf("Expected NotFound error, got: %v", err)
 	}
 }
@@ -180,7 +253,7 @@ func TestFileCardStore_List(t *testing.T) {
 	}
 
 	// List
-	listed, err := store.List("main")
+	listed, err := store.List("main", false)
 	if err != nil {
 		t.Fatalf("List failed: %v", err)
 	}
@@ -194,7 +267,7 @@ func TestFileCardStore_ListEmpty(t *testing.T) {
 	store, _, cleanup := setupTestCardStore(t)
 	defer cleanup()
 
-	listed, err := store.List("main")
+	listed, err := store.List("main", false)
 	if err != nil {
 		t.Fatalf("List failed: %v", err)
 	}
@@ -208,6 +281,138 @@ func TestFileCardStore_ListEmpty(t *testing.T) {
 	}
 }
 
+func TestFileCardStore_Stream(t *testing.T) {
+	store, dir, cleanup := setupTestCardStore(t)
+	defer cleanup()
+
+	for _, card := range []*model.Card{
+		{ID: "card1", Alias: "card-1", Title: "Card 1", Creator: "tester", CreatedAtMillis: 1, UpdatedAtMillis: 1},
+		{ID: "card2", Alias: "card-2", Title: "Card 2", Creator: "tester", CreatedAtMillis: 2, UpdatedAtMillis: 2, Archived: true},
+	} {
+		if err := store.Create("main", card); err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+	}
+	badPath := filepath.Join(dir, ".kan", "boards", "main", "cards", "bad.json")
+	if err := os.WriteFile(badPath, []byte("{not json"), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	stream, err := store.Stream("main")
+	if err != nil {
+		t.Fatalf("Stream failed: %v", err)
+	}
+	ids := map[string]bool{}
+	errs := 0
+	for item := range stream {
+		if item.Err != nil {
+			errs++
+			continue
+		}
+		ids[item.Card.ID] = true
+	}
+
+	// Archived cards are included; the malformed file is reported, not fatal
+	if len(ids) != 2 || !ids["card1"] || !ids["card2"] {
+		t.Errorf("Expected card1 and card2, got %v", ids)
+	}
+	if errs != 1 {
+		t.Errorf("Expected 1 error for the malformed file, got %d", errs)
+	}
+}
+
+func TestFileCardStore_StreamMissingBoard(t *testing.T) {
+	store, _, cleanup := setupTestCardStore(t)
+	defer cleanup()
+
+	stream, err := store.Stream("nonexistent")
+	if err != nil {
+		t.Fatalf("Stream failed: %v", err)
+	}
+	for item := range stream {
+		t.Errorf("Expected no items, got %+v", item)
+	}
+}
+
+// BenchmarkCardStoreListVsStream compares the peak live heap of loading a
+// 5,000-card board with List against counting it with Stream. Heap in use is
+// sampled in-process as a stand-in for RSS, which only ever grows within a
+// single benchmark binary and so can't be compared between sub-benchmarks.
+func BenchmarkCardStoreListVsStream(b *testing.B) {
+	const numCards = 5000
+
+	dir := b.TempDir()
+	paths := config.NewPaths(dir, "")
+	s := NewCardStore(paths)
+	description := strings.Repeat("Lorem ipsum dolor sit amet. ", 40)
+	for i := range numCards {
+		card := &model.Card{
+			ID:              fmt.Sprintf("card%05d", i),
+			Alias:           fmt.Sprintf("card-%d", i),
+			Title:           fmt.Sprintf("Card %d", i),
+			Description:     description,
+			Column:          "backlog",
+			Creator:         "tester",
+			CreatedAtMillis: int64(i),
+			UpdatedAtMillis: int64(i),
+		}
+		if err := s.Create("main", card); err != nil {
+			b.Fatalf("Create failed: %v", err)
+		}
+	}
+
+	// heapAbove returns how far the live heap has grown past base.
+	heapAbove := func(base uint64) uint64 {
+		var m runtime.MemStats
+		runtime.ReadMemStats(&m)
+		if m.HeapAlloc < base {
+			return 0
+		}
+		return m.HeapAlloc - base
+	}
+
+	b.Run("batch", func(b *testing.B) {
+		var peak uint64
+		for b.Loop() {
+			runtime.GC()
+			base := heapAbove(0)
+			cards, err := s.List("main", true)
+			if err != nil || len(cards) != numCards {
+				b.Fatalf("List returned %d cards, err %v", len(cards), err)
+			}
+			peak = max(peak, heapAbove(base))
+			runtime.KeepAlive(cards)
+		}
+		b.ReportMetric(float64(peak), "peak-heap-B")
+	})
+
+	b.Run("streaming", func(b *testing.B) {
+		var peak uint64
+		for b.Loop() {
+			runtime.GC()
+			base := heapAbove(0)
+			stream, err := s.Stream("main")
+			if err != nil {
+				b.Fatalf("Stream failed: %v", err)
+			}
+			count := 0
+			for item := range stream {
+				if item.Err != nil {
+					b.Fatal(item.Err)
+				}
+				count++
+				if count%500 == 0 {
+					peak = max(peak, heapAbove(base))
+				}
+			}
+			if count != numCards {
+				b.Fatalf("Stream returned %d cards", count)
+			}
+		}
+		b.ReportMetric(float64(peak), "peak-heap-B")
+	})
+}
+
 func TestFileCardStore_FindByAlias(t *testing.T) {
 	store, _, cleanup := setupTestCardStore(t)
 	defer cleanup()
@@ -241,11 +446,143 @@ func TestFileCardStore_FindByAliasNotFound(t *testing.T) {
 	defer cleanup()
 
 	_, err := store.FindByAlias("main", "nonexistent-alias")
-	if !kanerr.IsNotFound(err) {
+	if !kanerr.IsCode(err, kanerr.CodeCardNotFound) {
 		t.Errorf("Expected NotFound error, got: %v", err)
 	}
 }
 
+// readTestAliasIndex reads the board's alias index file directly.
+func readTestAliasIndex(t *testing.T, s *FileCardStore, boardName string) aliasIndex {
+	t.Helper()
+	index, err := s.readAliasIndex(boardName)
+	if err != nil {
+		t.Fatalf("readAliasIndex failed: %v", err)
+	}
+	return index
+}
+
+func TestFileCardStore_AliasIndexWriteThrough(t *testing.T) {
+	store, _, cleanup := setupTestCardStore(t)
+	defer cleanup()
+
+	card := &model.Card{ID: "c1", Alias: "first", Title: "First", Creator: "tester"}
+	if err := store.Create("main", card); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if index := readTestAliasIndex(t, store, "main"); index["first"] != "c1" {
+		t.Errorf("Expected index entry after Create, got %v", index)
+	}
+
+	card.Alias = "renamed"
+	if err := store.Update("main", card); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	index := readTestAliasIndex(t, store, "main")
+	if _, ok := index["first"]; ok || index["renamed"] != "c1" {
+		t.Errorf("Expected alias entry moved after Update, got %v", index)
+	}
+	if _, err := store.FindByAlias("main", "first"); !kanerr.IsCode(err, kanerr.CodeCardNotFound) {
+		t.Errorf("Expected old alias to be gone, got: %v", err)
+	}
+
+	if err := store.Delete("main", "c1"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if index := readTestAliasIndex(t, store, "main"); len(index) != 0 {
+		t.Errorf("Expected empty index after Delete, got %v", index)
+	}
+}
+
+func TestFileCardStore_AliasIndexRebuild(t *testing.T) {
+	store, dir, cleanup := setupTestCardStore(t)
+	defer cleanup()
+
+	if err := store.Create("main", &model.Card{ID: "c1", Alias: "first", Title: "First", Creator: "tester"}); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	// A card written behind the store's back (e.g. by a VCS pull) makes the
+	// index stale; a fresh store rebuilds it on first lookup.
+	cardsDir := filepath.Join(dir, ".kan", "boards", "main", "cards")
+	external := fmt.Sprintf(`{"_v": %d, "id": "c2", "alias": "second", "title": "Second", "creator": "tester", "column": "backlog", "position": "a"}`, version.CurrentCardVersion)
+	if err := os.WriteFile(filepath.Join(cardsDir, "c2.json"), []byte(external), 0644); err != nil {
+		t.Fatal(err)
+	}
+	future := time.Now().Add(time.Minute)
+	if err := os.Chtimes(filepath.Join(cardsDir, "c2.json"), future, future); err != nil {
+		t.Fatal(err)
+	}
+	store = NewCardStore(store.paths)
+	if found, err := store.FindByAlias("main", "second"); err != nil || found.ID != "c2" {
+		t.Fatalf("Expected externally added card to be found, got %v, %v", found, err)
+	}
+
+	// A corrupt index is rebuilt rather than trusted.
+	indexPath := store.paths.AliasIndexPath("main")
+	if err := os.WriteFile(indexPath, []byte("not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if found, err := store.FindByAlias("main", "first"); err != nil || found.ID != "c1" {
+		t.Errorf("Expected lookup through corrupt index to succeed, got %v, %v", found, err)
+	}
+
+	// A wrong entry is caught by checking the card it points at.
+	if err := os.WriteFile(indexPath, []byte(`{"first": "c2", "second": "c2"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if found, err := store.FindByAlias("main", "first"); err != nil || found.ID != "c1" {
+		t.Errorf("Expected wrong index entry to be corrected, got %v, %v", found, err)
+	}
+	if index := readTestAliasIndex(t, store, "main"); index["first"] != "c1" {
+		t.Errorf("Expected index rebuilt, got %v", index)
+	}
+
+	// RebuildIndex recreates a deleted index.
+	os.Remove(indexPath)
+	if err := store.RebuildIndex("main"); err != nil {
+		t.Fatalf("RebuildIndex failed: %v", err)
+	}
+	if index := readTestAliasIndex(t, store, "main"); len(index) != 2 {
+		t.Errorf("Expected 2 entries after RebuildIndex, got %v", index)
+	}
+}
+
+func BenchmarkFindByAlias(b *testing.B) {
+	const numCards = 500
+
+	dir := b.TempDir()
+	s := NewCardStore(config.NewPaths(dir, ""))
+	for i := range numCards {
+		card := &model.Card{
+			ID:      fmt.Sprintf("card%05d", i),
+			Alias:   fmt.Sprintf("card-%d", i),
+			Title:   fmt.Sprintf("Card %d", i),
+			Column:  "backlog",
+			Creator: "tester",
+		}
+		if err := s.Create("main", card); err != nil {
+			b.Fatalf("Create failed: %v", err)
+		}
+	}
+	alias := fmt.Sprintf("card-%d", numCards/2)
+
+	b.Run("index", func(b *testing.B) {
+		for b.Loop() {
+			if _, err := s.FindByAlias("main", alias); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("scan", func(b *testing.B) {
+		for b.Loop() {
+			if _, err := s.findByAliasScan("main", alias); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
 func TestFileCardStore_CustomFields(t *testing.T) {
 	store, _, cleanup := setupTestCardStore(t)
 	defer cleanup()
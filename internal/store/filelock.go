@@ -0,0 +1,59 @@
+package store
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/amterp/kan/internal/config"
+)
+
+// fileLock serializes access to a project's Kan data across processes (e.g.
+// two `kan serve` instances, or the CLI alongside a server) using an advisory
+// lock on .kan/.lock. Writers take an exclusive lock; readers take a shared
+// one so they never observe a half-written file.
+//
+// Locks are taken per operation, not across read-modify-write sequences, and
+// are not reentrant: a locked function must not call back into another locked
+// store method.
+type fileLock struct {
+	path string
+}
+
+func newFileLock(paths *config.Paths) *fileLock {
+	return &fileLock{path: paths.LockPath()}
+}
+
+// withLock runs fn while holding the exclusive lock.
+func (l *fileLock) withLock(fn func() error) error {
+	return l.run(true, fn)
+}
+
+// withSharedLock runs fn while holding a shared lock.
+func (l *fileLock) withSharedLock(fn func() error) error {
+	return l.run(false, fn)
+}
+
+func (l *fileLock) run(exclusive bool, fn func() error) error {
+	if !exclusive {
+		// Nothing to read yet; don't create the data directory as a side effect.
+		if _, err := os.Stat(filepath.Dir(l.path)); os.IsNotExist(err) {
+			return fn()
+		}
+	} else if err := os.MkdirAll(filepath.Dir(l.path), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(l.path), err)
+	}
+
+	f, err := os.OpenFile(l.path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open lock file: %w", err)
+	}
+	defer f.Close()
+
+	if err := lockFile(f, exclusive); err != nil {
+		return fmt.Errorf("failed to lock %s: %w", l.path, err)
+	}
+	defer unlockFile(f)
+
+	return fn()
+}
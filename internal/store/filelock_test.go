@@ -0,0 +1,80 @@
+package store
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/amterp/kan/internal/config"
+	"github.com/amterp/kan/internal/id"
+	"github.com/amterp/kan/internal/model"
+)
+
+func TestFileStores_ConcurrentWrites(t *testing.T) {
+	boardStore, dir, cleanup := setupTestBoardStore(t)
+	defer cleanup()
+
+	if err := boardStore.Create(&model.BoardConfig{
+		ID:            "board123",
+		Name:          "main",
+		Columns:       model.DefaultColumns(),
+		DefaultColumn: "backlog",
+	}); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	const workers = 20
+	var wg sync.WaitGroup
+	errs := make(chan error, workers)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			// Separate store instances, as separate processes would have.
+			paths := config.NewPaths(dir, "")
+			cards := NewCardStore(paths)
+			boards := NewBoardStore(paths)
+
+			card := &model.Card{ID: id.Generate(id.Card), Title: fmt.Sprintf("Card %d", i), Column: "backlog"}
+			if err := cards.Create("main", card); err != nil {
+				errs <- fmt.Errorf("create card %d: %w", i, err)
+				return
+			}
+
+			cfg, err := boards.Get("main")
+			if err != nil {
+				errs <- fmt.Errorf("read board (worker %d): %w", i, err)
+				return
+			}
+			cfg.Columns[0].Description = fmt.Sprintf("touched by worker %d", i)
+			if err := boards.Update(cfg); err != nil {
+				errs <- fmt.Errorf("update board (worker %d): %w", i, err)
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+
+	if _, err := boardStore.Get("main"); err != nil {
+		t.Fatalf("board config unreadable after concurrent writes: %v", err)
+	}
+
+	cards, err := NewCardStore(config.NewPaths(dir, "")).List("main", true)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	seen := make(map[string]bool)
+	for _, card := range cards {
+		if seen[card.ID] {
+			t.Errorf("duplicate card ID %s", card.ID)
+		}
+		seen[card.ID] = true
+	}
+	if len(seen) != workers {
+		t.Errorf("expected %d cards, got %d", workers, len(seen))
+	}
+}
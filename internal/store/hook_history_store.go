@@ -0,0 +1,97 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/amterp/kan/internal/config"
+	"github.com/amterp/kan/internal/model"
+)
+
+// MaxHookHistoryEntries is how many entries FileHookHistoryStore keeps. Older
+// entries are dropped on write. It's lower than MaxAuditEntries since each
+// entry can carry several KB of hook output.
+const MaxHookHistoryEntries = 1000
+
+// MaxHookHistoryStdout is the most hook stdout, in bytes, kept per entry.
+const MaxHookHistoryStdout = 4 * 1024
+
+// FileHookHistoryStore implements HookHistoryStore as newline-delimited JSON
+// in .kan/hooks-history.jsonl, oldest entry first.
+type FileHookHistoryStore struct {
+	paths      *config.Paths
+	maxEntries int
+	lines      lineCount
+	mu         sync.Mutex // Serializes appends and trims within a process (e.g. kan serve)
+}
+
+// NewHookHistoryStore creates a new hook history store.
+func NewHookHistoryStore(paths *config.Paths) *FileHookHistoryStore {
+	return &FileHookHistoryStore{paths: paths, maxEntries: MaxHookHistoryEntries}
+}
+
+// Append records a hook execution for the board, truncating its stdout to
+// MaxHookHistoryStdout and trimming the history to the most recent
+// maxEntries.
+func (s *FileHookHistoryStore) Append(boardName string, entry model.HookHistoryEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry.BoardName = boardName
+	entry.Stdout = truncateUTF8(entry.Stdout, MaxHookHistoryStdout)
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal hook history entry: %w", err)
+	}
+	return appendJSONLine(s.paths.HookHistoryPath(), "hook history", line, s.maxEntries, &s.lines)
+}
+
+// List returns up to limit entries for the board, newest first. An empty
+// boardName returns entries for all boards. Malformed lines are skipped.
+func (s *FileHookHistoryStore) List(boardName string, limit int) ([]model.HookHistoryEntry, error) {
+	s.mu.Lock()
+	lines, err := readJSONLines(s.paths.HookHistoryPath(), "hook history")
+	s.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	lines = lastJSONLines(lines, s.maxEntries)
+
+	entries := []model.HookHistoryEntry{}
+	for i := len(lines) - 1; i >= 0; i-- {
+		var entry model.HookHistoryEntry
+		if err := json.Unmarshal(lines[i], &entry); err != nil {
+			continue
+		}
+		if boardName != "" && entry.BoardName != boardName {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	// Hooks run concurrently (e.g. async hook tasks), so file order can lag
+	// start order slightly. Sort so callers always see newest first.
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Timestamp > entries[j].Timestamp
+	})
+	if limit > 0 && len(entries) > limit {
+		entries = entries[:limit]
+	}
+	return entries, nil
+}
+
+// truncateUTF8 cuts s to at most max bytes without splitting a multi-byte
+// character.
+func truncateUTF8(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	for max > 0 && !utf8.RuneStart(s[max]) {
+		max--
+	}
+	return s[:max]
+}
@@ -0,0 +1,142 @@
+package store
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/amterp/kan/internal/config"
+	"github.com/amterp/kan/internal/model"
+)
+
+func TestFileHookHistoryStore_List_Empty(t *testing.T) {
+	s := NewHookHistoryStore(config.NewPaths(t.TempDir(), ""))
+
+	entries, err := s.List("main", 10)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("Expected no entries, got %d", len(entries))
+	}
+}
+
+func TestFileHookHistoryStore_List_NewestFirstWithLimit(t *testing.T) {
+	s := NewHookHistoryStore(config.NewPaths(t.TempDir(), ""))
+
+	// Appended out of timestamp order, as concurrent hooks can be.
+	for _, ts := range []int64{10, 30, 20, 50, 40} {
+		entry := model.HookHistoryEntry{Timestamp: ts, HookName: fmt.Sprintf("hook-%d", ts), Success: true}
+		if err := s.Append("main", entry); err != nil {
+			t.Fatalf("Append failed: %v", err)
+		}
+	}
+	if err := s.Append("other", model.HookHistoryEntry{Timestamp: 60, HookName: "elsewhere"}); err != nil {
+		t.Fatalf("Append failed: %v", err)
+	}
+
+	cases := []struct {
+		name  string
+		board string
+		limit int
+		want  []int64
+	}{
+		{name: "limit", board: "main", limit: 3, want: []int64{50, 40, 30}},
+		{name: "no limit", board: "main", limit: 0, want: []int64{50, 40, 30, 20, 10}},
+		{name: "scoped to board", board: "other", limit: 10, want: []int64{60}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			entries, err := s.List(tc.board, tc.limit)
+			if err != nil {
+				t.Fatalf("List failed: %v", err)
+			}
+			var got []int64
+			for _, e := range entries {
+				got = append(got, e.Timestamp)
+				if e.BoardName != tc.board {
+					t.Errorf("Expected board %q, got %q", tc.board, e.BoardName)
+				}
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tc.want) {
+				t.Errorf("Expected timestamps %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestFileHookHistoryStore_TrimsToMaxEntries(t *testing.T) {
+	s := NewHookHistoryStore(config.NewPaths(t.TempDir(), ""))
+	s.maxEntries = 3
+	for i := 0; i < 5; i++ {
+		if err := s.Append("main", model.HookHistoryEntry{Timestamp: int64(i)}); err != nil {
+			t.Fatalf("Append failed: %v", err)
+		}
+	}
+
+	entries, err := s.List("main", 0)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(entries) != 3 || entries[2].Timestamp != 2 {
+		t.Errorf("Expected the 3 newest entries, got %+v", entries)
+	}
+}
+
+func TestFileHookHistoryStore_TrimsOnlyPastSlack(t *testing.T) {
+	paths := config.NewPaths(t.TempDir(), "")
+	s := NewHookHistoryStore(paths)
+	s.maxEntries = 10
+
+	fileLines := func() int {
+		data, err := os.ReadFile(paths.HookHistoryPath())
+		if err != nil {
+			t.Fatalf("ReadFile failed: %v", err)
+		}
+		return strings.Count(string(data), "\n")
+	}
+	appendN := func(from, n int) {
+		for i := from; i < from+n; i++ {
+			if err := s.Append("main", model.HookHistoryEntry{Timestamp: int64(i)}); err != nil {
+				t.Fatalf("Append failed: %v", err)
+			}
+		}
+	}
+
+	// One past the limit is within the slack, so the file is only appended to.
+	appendN(0, 11)
+	if got := fileLines(); got != 11 {
+		t.Errorf("Expected 11 lines before trimming, got %d", got)
+	}
+	entries, err := s.List("main", 0)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(entries) != 10 || entries[9].Timestamp != 1 {
+		t.Errorf("Expected List to ignore the overshoot, got %d entries", len(entries))
+	}
+
+	appendN(11, 1)
+	if got := fileLines(); got != 10 {
+		t.Errorf("Expected the file trimmed to 10 lines, got %d", got)
+	}
+}
+
+func TestFileHookHistoryStore_TruncatesStdout(t *testing.T) {
+	s := NewHookHistoryStore(config.NewPaths(t.TempDir(), ""))
+
+	// A multi-byte character straddling the limit must not be split.
+	stdout := strings.Repeat("a", MaxHookHistoryStdout-1) + "é" + strings.Repeat("b", 100)
+	if err := s.Append("main", model.HookHistoryEntry{Timestamp: 1, Stdout: stdout}); err != nil {
+		t.Fatalf("Append failed: %v", err)
+	}
+
+	entries, err := s.List("main", 0)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if got, want := entries[0].Stdout, strings.Repeat("a", MaxHookHistoryStdout-1); got != want {
+		t.Errorf("Expected stdout truncated to %d bytes, got %d", len(want), len(got))
+	}
+}
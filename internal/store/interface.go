@@ -1,6 +1,10 @@
 package store
 
-import "github.com/amterp/kan/internal/model"
+import (
+	"time"
+
+	"github.com/amterp/kan/internal/model"
+)
 
 // CardStore handles card persistence.
 type CardStore interface {
@@ -8,8 +12,33 @@ type CardStore interface {
 	Get(boardName, cardID string) (*model.Card, error)
 	Update(boardName string, card *model.Card) error
 	Delete(boardName, cardID string) error
-	List(boardName string) ([]*model.Card, error)
+	List(boardName string, includeArchived bool) ([]*model.Card, error) // Archived cards only when includeArchived
 	FindByAlias(boardName, alias string) (*model.Card, error)
+	// RebuildIndex rebuilds whatever index the store keeps for FindByAlias
+	// from the board's cards. Stores without an index do nothing.
+	RebuildIndex(boardName string) error
+	// Stream sends a board's cards (archived ones included) one at a time
+	// instead of loading them all at once. See CardOrError.
+	Stream(boardName string) (<-chan CardOrError, error)
+}
+
+// CardOrError is one item from CardStore.Stream: a card, or the error from the
+// card file that couldn't be read. The channel is closed after the last card,
+// and callers must drain it.
+type CardOrError struct {
+	Card *model.Card
+	Err  error
+}
+
+// StreamSlice streams already-loaded cards, for CardStore implementations
+// that keep cards in memory.
+func StreamSlice(cards []*model.Card) <-chan CardOrError {
+	ch := make(chan CardOrError, len(cards))
+	for _, card := range cards {
+		ch <- CardOrError{Card: card}
+	}
+	close(ch)
+	return ch
 }
 
 // BoardStore handles board persistence.
@@ -18,8 +47,13 @@ type BoardStore interface {
 	Get(boardName string) (*model.BoardConfig, error)
 	Update(config *model.BoardConfig) error
 	Delete(boardName string) error
-	List() ([]string, error) // Returns board names
+	Archive(boardName string) error
+	Unarchive(boardName string) error
+	Rename(oldName, newName string) error
+	List() ([]string, error)    // Returns active (non-archived) board names
+	ListAll() ([]string, error) // Returns all board names, archived included
 	Exists(boardName string) bool
+	ModTime(boardName string) (time.Time, error) // When the board's config was last written
 }
 
 // GlobalStore handles global config persistence.
@@ -36,3 +70,15 @@ type ProjectStore interface {
 	Exists() bool
 	EnsureInitialized(defaultName string) error
 }
+
+// AuditStore handles persistence of the mutation audit log.
+type AuditStore interface {
+	Append(entry model.AuditEntry) error
+	Recent(boardName string, limit int) ([]model.AuditEntry, error) // Newest first; limit <= 0 means all
+}
+
+// HookHistoryStore handles persistence of pattern hook execution history.
+type HookHistoryStore interface {
+	Append(boardName string, entry model.HookHistoryEntry) error
+	List(boardName string, limit int) ([]model.HookHistoryEntry, error) // Newest first; limit <= 0 means all
+}
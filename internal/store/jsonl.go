@@ -0,0 +1,133 @@
+package store
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// readJSONLines returns the non-empty lines of a newline-delimited JSON file,
+// oldest first. A missing file has no lines. what names the file in errors
+// (e.g. "audit log").
+func readJSONLines(path, what string) ([][]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", what, err)
+	}
+	defer f.Close()
+
+	var lines [][]byte
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		lines = append(lines, append([]byte(nil), scanner.Bytes()...))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", what, err)
+	}
+	return lines, nil
+}
+
+// lineCount caches a JSONL file's line count between appends, so the file is
+// only read back when it's due for trimming. Other processes may append too,
+// so the count is a trigger, not a fact; trimming always re-reads the file.
+type lineCount struct {
+	n     int
+	known bool
+}
+
+// appendJSONLine adds line to the end of a newline-delimited JSON file with a
+// single O_APPEND write. Once the file grows past maxLines by a tenth, it's
+// rewritten via a temp file and rename to keep only the most recent maxLines,
+// so the cost of trimming is spread across many appends. Readers should use
+// lastJSONLines to ignore the overshoot.
+func appendJSONLine(path, what string, line []byte, maxLines int, count *lineCount) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create %s directory: %w", what, err)
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", what, err)
+	}
+	_, err = f.Write(append(append([]byte(nil), line...), '\n'))
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", what, err)
+	}
+
+	if count.known {
+		count.n++
+	} else {
+		n, err := countLines(path, what)
+		if err != nil {
+			return err
+		}
+		count.n, count.known = n, true
+	}
+	if count.n <= maxLines+maxLines/10 {
+		return nil
+	}
+	return trimJSONLines(path, what, maxLines, count)
+}
+
+// trimJSONLines rewrites the file to hold only its most recent maxLines.
+func trimJSONLines(path, what string, maxLines int, count *lineCount) error {
+	lines, err := readJSONLines(path, what)
+	if err != nil {
+		return err
+	}
+	lines = lastJSONLines(lines, maxLines)
+
+	var buf bytes.Buffer
+	for _, l := range lines {
+		buf.Write(l)
+		buf.WriteByte('\n')
+	}
+	if err := writeFileAtomic(path, buf.Bytes()); err != nil {
+		return fmt.Errorf("failed to write %s: %w", what, err)
+	}
+	count.n = len(lines)
+	return nil
+}
+
+// lastJSONLines returns the most recent maxLines of lines.
+func lastJSONLines(lines [][]byte, maxLines int) [][]byte {
+	if len(lines) > maxLines {
+		return lines[len(lines)-maxLines:]
+	}
+	return lines
+}
+
+// countLines returns the number of newline-terminated lines in the file.
+func countLines(path, what string) (int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read %s: %w", what, err)
+	}
+	defer f.Close()
+
+	n := 0
+	buf := make([]byte, 64*1024)
+	for {
+		read, err := f.Read(buf)
+		n += bytes.Count(buf[:read], []byte{'\n'})
+		if err == io.EOF {
+			return n, nil
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to read %s: %w", what, err)
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/amterp/kan/internal/model"
+)
+
+// ErrIncludeCycle is returned when board config includes form a cycle.
+var ErrIncludeCycle = errors.New("circular include")
+
+// ErrIncludeOutsideKan is returned for an include path that is absolute or
+// leads out of the .kan directory.
+var ErrIncludeOutsideKan = errors.New("include path must stay inside .kan")
+
+// includeFile is the part of an included TOML file that is shared into a
+// board. An included file may itself include others.
+type includeFile struct {
+	Includes     []string                           `toml:"include"`
+	CustomFields map[string]model.CustomFieldSchema `toml:"custom_fields"`
+	LinkRules    []model.LinkRule                   `toml:"link_rules"`
+	PatternHooks []model.PatternHook                `toml:"pattern_hooks"`
+
+	files []string // Every file visited while loading, missing ones included
+}
+
+// ResolveIncludes merges the custom fields, link rules and pattern hooks of
+// cfg's included files into cfg. Include paths are relative to basePath, the
+// .kan directory. Definitions already in cfg win over included ones with the
+// same field or rule name, and later includes win over earlier ones.
+//
+// A cycle of includes is an error wrapping ErrIncludeCycle, and an absolute
+// path or one leading out of basePath is an error wrapping
+// ErrIncludeOutsideKan. Missing include files are skipped, and the returned
+// error then wraps fs.ErrNotExist for each of them, so callers can treat them
+// as warnings; everything else has still been merged.
+func ResolveIncludes(basePath string, cfg *model.BoardConfig) error {
+	included, err := loadIncludes(basePath, cfg.Includes)
+	if included == nil {
+		return err
+	}
+
+	for name, schema := range included.CustomFields {
+		if _, local := cfg.CustomFields[name]; local {
+			continue
+		}
+		if cfg.CustomFields == nil {
+			cfg.CustomFields = make(map[string]model.CustomFieldSchema)
+		}
+		cfg.CustomFields[name] = schema
+	}
+	cfg.LinkRules = mergeNamed(cfg.LinkRules, included.LinkRules, func(r model.LinkRule) string { return r.Name })
+	cfg.PatternHooks = mergeNamed(cfg.PatternHooks, included.PatternHooks, func(h model.PatternHook) string { return h.Name })
+	return err
+}
+
+// loadIncludes reads the given include files, and theirs, into one set of
+// definitions. It returns nil definitions on a hard error (a cycle, a path
+// outside basePath or an unreadable file); missing files are collected into
+// the error instead.
+func loadIncludes(basePath string, paths []string) (*includeFile, error) {
+	merged := &includeFile{CustomFields: make(map[string]model.CustomFieldSchema)}
+	var missing []error
+	if err := loadIncludesInto(merged, basePath, paths, nil, &missing); err != nil {
+		return nil, err
+	}
+	return merged, errors.Join(missing...)
+}
+
+func loadIncludesInto(merged *includeFile, basePath string, paths, stack []string, missing *[]error) error {
+	for _, rel := range paths {
+		if filepath.IsAbs(rel) {
+			return fmt.Errorf("%w: %s", ErrIncludeOutsideKan, rel)
+		}
+		path := filepath.Clean(filepath.Join(basePath, rel))
+		inside, err := filepath.Rel(basePath, path)
+		if err != nil || inside == ".." || strings.HasPrefix(inside, ".."+string(filepath.Separator)) {
+			return fmt.Errorf("%w: %s", ErrIncludeOutsideKan, rel)
+		}
+		for _, seen := range stack {
+			if seen == path {
+				return fmt.Errorf("%w: %s", ErrIncludeCycle, rel)
+			}
+		}
+		merged.files = append(merged.files, path)
+
+		data, err := os.ReadFile(path)
+		if os.IsNotExist(err) {
+			*missing = append(*missing, fmt.Errorf("include %s: %w", rel, err))
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("include %s: %w", rel, err)
+		}
+		var file includeFile
+		if err := toml.Unmarshal(data, &file); err != nil {
+			return fmt.Errorf("include %s: %w", rel, err)
+		}
+
+		// A file's own includes come first, so its definitions override them.
+		if err := loadIncludesInto(merged, basePath, file.Includes, append(stack, path), missing); err != nil {
+			return err
+		}
+		for name, schema := range file.CustomFields {
+			merged.CustomFields[name] = schema
+		}
+		merged.LinkRules = mergeNamed(file.LinkRules, merged.LinkRules, func(r model.LinkRule) string { return r.Name })
+		merged.PatternHooks = mergeNamed(file.PatternHooks, merged.PatternHooks, func(h model.PatternHook) string { return h.Name })
+	}
+	return nil
+}
+
+// IncludedFiles returns the paths of every file cfg includes, directly or
+// through other includes, missing files included. It returns nil when the
+// includes can't be resolved at all (see ResolveIncludes).
+func IncludedFiles(basePath string, cfg *model.BoardConfig) []string {
+	if len(cfg.Includes) == 0 {
+		return nil
+	}
+	included, _ := loadIncludes(basePath, cfg.Includes)
+	if included == nil {
+		return nil
+	}
+	return included.files
+}
+
+// mergeNamed returns local followed by the entries of shared whose name no
+// local entry uses.
+func mergeNamed[T any](local, shared []T, name func(T) string) []T {
+	names := make(map[string]bool, len(local))
+	for _, item := range local {
+		names[name(item)] = true
+	}
+	for _, item := range shared {
+		if !names[name(item)] {
+			local = append(local, item)
+		}
+	}
+	return local
+}
+
+// withoutIncluded returns a copy of cfg without the definitions that
+// ResolveIncludes merged in, so writing the config doesn't copy shared
+// definitions into the board. A definition that still matches its included
+// value is treated as included; one the board changed is kept as an override.
+func withoutIncluded(basePath string, cfg *model.BoardConfig) *model.BoardConfig {
+	if len(cfg.Includes) == 0 {
+		return cfg
+	}
+	included, _ := loadIncludes(basePath, cfg.Includes)
+	if included == nil {
+		return cfg
+	}
+
+	out := *cfg
+	out.CustomFields = make(map[string]model.CustomFieldSchema, len(cfg.CustomFields))
+	for name, schema := range cfg.CustomFields {
+		if shared, ok := included.CustomFields[name]; ok && reflect.DeepEqual(shared, schema) {
+			continue
+		}
+		out.CustomFields[name] = schema
+	}
+	out.LinkRules = withoutShared(cfg.LinkRules, included.LinkRules)
+	out.PatternHooks = withoutShared(cfg.PatternHooks, included.PatternHooks)
+	return &out
+}
+
+// withoutShared returns the entries of items that don't appear in shared.
+func withoutShared[T any](items, shared []T) []T {
+	var out []T
+	for _, item := range items {
+		isShared := false
+		for _, s := range shared {
+			if reflect.DeepEqual(item, s) {
+				isShared = true
+				break
+			}
+		}
+		if !isShared {
+			out = append(out, item)
+		}
+	}
+	return out
+}
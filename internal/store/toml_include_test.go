@@ -0,0 +1,140 @@
+package store
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/amterp/kan/internal/model"
+)
+
+// writeInclude writes a shared TOML file under the test project's .kan dir.
+func writeInclude(t *testing.T, dir, rel, content string) {
+	t.Helper()
+	path := filepath.Join(dir, ".kan", rel)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("failed to create include dir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write include: %v", err)
+	}
+}
+
+func createBoardWithIncludes(t *testing.T, store *FileBoardStore, includes ...string) *model.BoardConfig {
+	t.Helper()
+	cfg := &model.BoardConfig{
+		ID:            "board123",
+		Name:          "main",
+		Columns:       model.DefaultColumns(),
+		DefaultColumn: "backlog",
+		CustomFields: map[string]model.CustomFieldSchema{
+			"priority": {Type: model.FieldTypeString},
+		},
+		Includes: includes,
+	}
+	if err := store.Create(cfg); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	return cfg
+}
+
+func TestFileBoardStore_Get_MergesIncludes(t *testing.T) {
+	store, dir, cleanup := setupTestBoardStore(t)
+	defer cleanup()
+
+	writeInclude(t, dir, "shared/fields.toml", `
+[custom_fields.team]
+type = "enum"
+options = [{ value = "core" }, { value = "web" }]
+
+[custom_fields.priority]
+type = "enum"
+options = [{ value = "high" }]
+
+[[link_rules]]
+name = "Jira"
+pattern = "PROJ-\\d+"
+url = "https://jira.example.com/browse/{0}"
+`)
+	createBoardWithIncludes(t, store, "shared/fields.toml")
+
+	cfg, err := store.Get("main")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if cfg.CustomFields["team"].Type != model.FieldTypeEnum {
+		t.Errorf("Expected included team field, got %+v", cfg.CustomFields["team"])
+	}
+	if cfg.CustomFields["priority"].Type != model.FieldTypeString {
+		t.Errorf("Local priority should override the included one, got %+v", cfg.CustomFields["priority"])
+	}
+	if len(cfg.LinkRules) != 1 || cfg.LinkRules[0].Name != "Jira" {
+		t.Errorf("Expected included link rule, got %+v", cfg.LinkRules)
+	}
+
+	// Writing the config back mustn't copy the shared definitions into it.
+	cfg.DefaultColumn = "next"
+	if err := store.Update(cfg); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	data, err := os.ReadFile(store.paths.BoardConfigPath("main"))
+	if err != nil {
+		t.Fatalf("failed to read config: %v", err)
+	}
+	if strings.Contains(string(data), "team") || strings.Contains(string(data), "Jira") {
+		t.Errorf("Included definitions were written to the board config:\n%s", data)
+	}
+	if !strings.Contains(string(data), "[custom_fields.priority]") {
+		t.Errorf("Local field was dropped from the board config:\n%s", data)
+	}
+}
+
+func TestFileBoardStore_Get_CircularInclude(t *testing.T) {
+	store, dir, cleanup := setupTestBoardStore(t)
+	defer cleanup()
+
+	writeInclude(t, dir, "a.toml", `include = ["b.toml"]`)
+	writeInclude(t, dir, "b.toml", `include = ["a.toml"]`)
+	createBoardWithIncludes(t, store, "a.toml")
+
+	if _, err := store.Get("main"); !errors.Is(err, ErrIncludeCycle) {
+		t.Errorf("Expected circular include error, got %v", err)
+	}
+}
+
+func TestFileBoardStore_Get_MissingInclude(t *testing.T) {
+	store, dir, cleanup := setupTestBoardStore(t)
+	defer cleanup()
+
+	writeInclude(t, dir, "shared.toml", "[custom_fields.team]\ntype = \"string\"\n")
+	createBoardWithIncludes(t, store, "missing.toml", "shared.toml")
+
+	// The board stays usable, with what could be included.
+	cfg, err := store.Get("main")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if _, ok := cfg.CustomFields["team"]; !ok {
+		t.Errorf("Expected the readable include to be merged, got %v", cfg.CustomFields)
+	}
+}
+
+func TestFileBoardStore_Get_IncludeOutsideKan(t *testing.T) {
+	for _, include := range []string{"../outside.toml", "shared/../../outside.toml", "/etc/outside.toml"} {
+		t.Run(include, func(t *testing.T) {
+			store, dir, cleanup := setupTestBoardStore(t)
+			defer cleanup()
+
+			if err := os.WriteFile(filepath.Join(dir, "outside.toml"), []byte("[custom_fields.team]\ntype = \"string\"\n"), 0644); err != nil {
+				t.Fatalf("failed to write outside file: %v", err)
+			}
+			createBoardWithIncludes(t, store, include)
+
+			if _, err := store.Get("main"); !errors.Is(err, ErrIncludeOutsideKan) {
+				t.Errorf("Expected outside-kan error, got %v", err)
+			}
+		})
+	}
+}
@@ -5,6 +5,9 @@ import (
 	"time"
 )
 
+// MillisPerDay is the number of milliseconds in a day.
+const MillisPerDay = int64(24 * 60 * 60 * 1000)
+
 // NowMillis returns the current time in milliseconds since Unix epoch.
 func NowMillis() int64 {
 	return time.Now().UnixMilli()
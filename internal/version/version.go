@@ -15,9 +15,9 @@ import (
 //  4. Add migration tests in migrate_service_test.go
 //  5. Update COMPAT.md with migration details
 const (
-	CurrentCardVersion    = 3
-	CurrentBoardVersion   = 12
-	CurrentGlobalVersion  = 2
+	CurrentCardVersion    = 9
+	CurrentBoardVersion   = 30
+	CurrentGlobalVersion  = 5
 	CurrentProjectVersion = 2
 )
 
@@ -34,6 +34,12 @@ var MinKanVersion = map[string]string{
 	"card/1":    "0.1.0",
 	"card/2":    "0.21.0",
 	"card/3":    "0.25.0",
+	"card/4":    "0.29.0",
+	"card/5":    "0.29.0",
+	"card/6":    "0.29.0",
+	"card/7":    "0.29.0",
+	"card/8":    "0.29.0",
+	"card/9":    "0.29.0",
 	"board/1":   "0.1.0",
 	"board/2":   "0.2.0",
 	"board/3":   "0.4.0",
@@ -46,8 +52,29 @@ var MinKanVersion = map[string]string{
 	"board/10":  "0.21.0",
 	"board/11":  "0.22.0",
 	"board/12":  "0.28.0",
+	"board/13":  "0.29.0",
+	"board/14":  "0.29.0",
+	"board/15":  "0.29.0",
+	"board/16":  "0.29.0",
+	"board/17":  "0.29.0",
+	"board/18":  "0.29.0",
+	"board/19":  "0.29.0",
+	"board/20":  "0.29.0",
+	"board/21":  "0.29.0",
+	"board/22":  "0.29.0",
+	"board/23":  "0.29.0",
+	"board/24":  "0.29.0",
+	"board/25":  "0.29.0",
+	"board/26":  "0.29.0",
+	"board/27":  "0.29.0",
+	"board/28":  "0.29.0",
+	"board/29":  "0.29.0",
+	"board/30":  "0.29.0",
 	"global/1":  "0.1.0",
 	"global/2":  "0.26.0",
+	"global/3":  "0.29.0",
+	"global/4":  "0.29.0",
+	"global/5":  "0.29.0",
 	"project/1": "0.3.0",
 	"project/2": "0.20.0",
 }
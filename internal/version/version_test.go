@@ -29,6 +29,9 @@ func TestFormatGlobalSchema(t *testing.T) {
 	}{
 		{1, "global/1"},
 		{2, "global/2"},
+		{3, "global/3"},
+		{4, "global/4"},
+		{5, "global/5"},
 		{10, "global/10"},
 	}
 	for _, tt := range tests {
@@ -80,6 +83,9 @@ func TestParseGlobalVersion(t *testing.T) {
 	}{
 		{"global/1", 1, false},
 		{"global/2", 2, false},
+		{"global/3", 3, false},
+		{"global/4", 4, false},
+		{"global/5", 5, false},
 		{"board/1", 0, true},  // Wrong prefix
 		{"global/", 0, true},  // Missing version
 		{"global/0", 0, true}, // Version must be >= 1
@@ -103,13 +109,13 @@ func TestParseGlobalVersion(t *testing.T) {
 func TestCurrentSchemas(t *testing.T) {
 	// Verify current schema functions return expected format
 	boardSchema := CurrentBoardSchema()
-	if boardSchema != "board/12" {
-		t.Errorf("CurrentBoardSchema() = %q, want %q", boardSchema, "board/12")
+	if boardSchema != "board/30" {
+		t.Errorf("CurrentBoardSchema() = %q, want %q", boardSchema, "board/30")
 	}
 
 	globalSchema := CurrentGlobalSchema()
-	if globalSchema != "global/2" {
-		t.Errorf("CurrentGlobalSchema() = %q, want %q", globalSchema, "global/2")
+	if globalSchema != "global/5" {
+		t.Errorf("CurrentGlobalSchema() = %q, want %q", globalSchema, "global/5")
 	}
 }
 
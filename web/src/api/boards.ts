@@ -1,5 +1,5 @@
 import { api } from './client';
-import type { BoardConfig, Column, CreateColumnInput, UpdateColumnInput } from './types';
+import type { BoardConfig, Column, ColumnSummary, CreateColumnInput, UpdateColumnInput } from './types';
 
 export async function listBoards(): Promise<string[]> {
   const result = await api.get<{ boards: string[] }>('/boards');
@@ -10,8 +10,23 @@ export async function getBoard(name: string): Promise<BoardConfig> {
   return api.get<BoardConfig>(`/boards/${encodeURIComponent(name)}`);
 }
 
+export async function updateCollaborators(
+  board: string,
+  changes: { add?: string[]; remove?: string[] }
+): Promise<{ collaborators: string[]; warnings?: string[] }> {
+  return api.patch<{ collaborators: string[]; warnings?: string[] }>(
+    `/boards/${encodeURIComponent(board)}/collaborators`,
+    changes
+  );
+}
+
 // Column API functions
 
+export async function listColumns(board: string): Promise<ColumnSummary[]> {
+  const result = await api.get<{ columns: ColumnSummary[] }>(`/boards/${encodeURIComponent(board)}/columns`);
+  return result.columns;
+}
+
 export async function createColumn(
   board: string,
   input: CreateColumnInput
@@ -1,5 +1,5 @@
 import { api } from './client';
-import type { Card, Comment, CreateCardInput, CreateCardResponse, UpdateCardInput } from './types';
+import type { Card, Comment, CreateCardFromTemplateInput, CreateCardInput, CreateCardResponse, UpdateCardInput } from './types';
 
 export async function listCards(board: string, column?: string): Promise<Card[]> {
   const params = column ? `?column=${encodeURIComponent(column)}` : '';
@@ -15,6 +15,10 @@ export async function createCard(board: string, input: CreateCardInput): Promise
   return api.post<CreateCardResponse>(`/boards/${encodeURIComponent(board)}/cards`, input);
 }
 
+export async function createCardFromTemplate(board: string, input: CreateCardFromTemplateInput): Promise<CreateCardResponse> {
+  return api.post<CreateCardResponse>(`/boards/${encodeURIComponent(board)}/cards/from-template`, input);
+}
+
 export async function updateCard(board: string, id: string, input: UpdateCardInput): Promise<Card> {
   return api.put<Card>(`/boards/${encodeURIComponent(board)}/cards/${encodeURIComponent(id)}`, input);
 }
@@ -27,8 +31,11 @@ export async function moveCard(board: string, id: string, column: string, positi
   return api.patch<Card>(`/boards/${encodeURIComponent(board)}/cards/${encodeURIComponent(id)}/move`, body);
 }
 
-export async function deleteCard(board: string, id: string): Promise<void> {
-  await api.delete<void>(`/boards/${encodeURIComponent(board)}/cards/${encodeURIComponent(id)}`);
+// parentBehavior says what happens to the card's children: 'clear' (the
+// server default), 'delete', or 'reparent'.
+export async function deleteCard(board: string, id: string, parentBehavior?: 'clear' | 'delete' | 'reparent'): Promise<void> {
+  const params = parentBehavior ? `?parent_behavior=${parentBehavior}` : '';
+  await api.delete<void>(`/boards/${encodeURIComponent(board)}/cards/${encodeURIComponent(id)}${params}`);
 }
 
 export async function restoreCard(board: string, card: Card, column: string, position: number): Promise<Card> {
@@ -1,6 +1,10 @@
 import { api } from './client';
-import type { ProjectConfig } from './types';
+import type { ProjectConfig, UpdateProjectInput } from './types';
 
 export async function getProject(): Promise<ProjectConfig> {
   return api.get<ProjectConfig>('/project');
 }
+
+export async function updateProject(input: UpdateProjectInput): Promise<ProjectConfig> {
+  return api.patch<ProjectConfig>('/project', input);
+}
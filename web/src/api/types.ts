@@ -26,6 +26,7 @@ export interface Card {
   updated_at_millis: number;
   comments?: Comment[];
   history?: HistoryEntry[];
+  done_at_millis?: number;
   missing_wanted_fields?: MissingWantedField[];
   [key: string]: unknown; // custom fields
 }
@@ -36,6 +37,9 @@ export interface Comment {
   author: string;
   created_at_millis: number;
   updated_at_millis?: number;
+  mentions?: string[];
+  // Set on create/edit responses for mentions of non-collaborators.
+  warnings?: string[];
 }
 
 // HistoryEntry records one tracked field change on a card (column transitions
@@ -52,13 +56,26 @@ export interface Column {
   color: string;
   description?: string;
   limit?: number;
+  done?: boolean;
+  auto_archive_after_days?: number;
+  sprint_start_millis?: number;
+  sprint_end_millis?: number;
   card_ids?: string[];
 }
 
+export interface ColumnSummary {
+  name: string;
+  card_count: number;
+  limit: number;
+  percent_full: number;
+  at_limit: boolean;
+}
+
 export interface CustomFieldOption {
   value: string;
   color?: string;
   description?: string;
+  label?: string; // Display name for an integer value
 }
 
 // Custom field type constants - keep in sync with internal/model/board.go
@@ -68,6 +85,9 @@ export const FIELD_TYPE_ENUM_SET = 'enum-set' as const;
 export const FIELD_TYPE_FREE_SET = 'free-set' as const;
 export const FIELD_TYPE_DATE = 'date' as const;
 export const FIELD_TYPE_BOOLEAN = 'boolean' as const;
+export const FIELD_TYPE_INTEGER = 'integer' as const;
+export const FIELD_TYPE_URL = 'url' as const;
+export const FIELD_TYPE_USER = 'user' as const;
 
 export const VALID_FIELD_TYPES = [
   FIELD_TYPE_STRING,
@@ -76,6 +96,9 @@ export const VALID_FIELD_TYPES = [
   FIELD_TYPE_FREE_SET,
   FIELD_TYPE_DATE,
   FIELD_TYPE_BOOLEAN,
+  FIELD_TYPE_INTEGER,
+  FIELD_TYPE_URL,
+  FIELD_TYPE_USER,
 ] as const;
 
 export type FieldType = (typeof VALID_FIELD_TYPES)[number];
@@ -85,6 +108,14 @@ export interface CustomFieldSchema {
   options?: CustomFieldOption[];
   wanted?: boolean;
   description?: string;
+  min?: number; // integer fields: inclusive lower bound
+  max?: number; // integer fields: inclusive upper bound
+  pattern?: string; // url fields: regex the URL must match
+  max_length?: number; // free-set fields: max characters per value (default 50)
+  future_only?: boolean; // date fields: reject dates before today
+  past_only?: boolean; // date fields: reject dates after today
+  format?: 'date' | 'datetime'; // date fields: storage format (default 'date')
+  order?: number; // display position; ties sort by name
 }
 
 export interface CardDisplayConfig {
@@ -92,6 +123,7 @@ export interface CardDisplayConfig {
   tint?: string;
   badges?: string[];
   metadata?: string[];
+  tags?: string[]; // free-set fields shown as plain #tags
   // Custom field the board view sorts by on load (the Sort control overrides it).
   default_sort?: string;
   default_sort_desc?: boolean;
@@ -108,9 +140,36 @@ export interface BoardConfig {
   name: string;
   columns: Column[];
   default_column: string;
+  done_columns?: string[];
   custom_fields?: Record<string, CustomFieldSchema>;
   card_display?: CardDisplayConfig;
+  alias?: AliasConfig;
   link_rules?: LinkRule[];
+  stale?: StaleConfig;
+  archived_at_millis?: number;
+  templates?: CardTemplate[];
+  collaborators?: string[]; // values user fields accept (any, if empty)
+  revision: number; // bumped on every config write; send as If-Revision on column changes
+  include?: string[]; // shared definition files, relative to .kan
+}
+
+export interface CardTemplate {
+  name: string;
+  title_pattern: string; // Go template over the vars, e.g. "[{{.component}}] {{.summary}}"
+  default_column?: string;
+  default_custom_fields?: Record<string, string>;
+  default_description?: string;
+}
+
+export interface StaleConfig {
+  stale_days?: number;
+  exempt_columns?: string[];
+}
+
+export interface AliasConfig {
+  style?: 'slugify' | 'initials' | 'numeric';
+  max_length?: number;
+  prefix?: string;
 }
 
 export interface CreateCardInput {
@@ -121,6 +180,11 @@ export interface CreateCardInput {
   custom_fields?: Record<string, unknown>;
 }
 
+export interface CreateCardFromTemplateInput {
+  template: string;
+  vars?: Record<string, string>;
+}
+
 export interface UpdateCardInput {
   title?: string;
   description?: string;
@@ -171,6 +235,11 @@ export interface ProjectConfig {
   project_path: string;
 }
 
+export interface UpdateProjectInput {
+  name?: string;
+  favicon?: Partial<FaviconConfig>;
+}
+
 // Cross-project types
 
 export interface BoardEntry {
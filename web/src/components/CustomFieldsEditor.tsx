@@ -1,6 +1,6 @@
 import type { BoardConfig, CustomFieldSchema } from '../api/types';
 import { useState } from 'react';
-import { FIELD_TYPE_ENUM, FIELD_TYPE_ENUM_SET, FIELD_TYPE_FREE_SET, FIELD_TYPE_STRING, FIELD_TYPE_DATE, FIELD_TYPE_BOOLEAN } from '../api/types';
+import { FIELD_TYPE_ENUM, FIELD_TYPE_ENUM_SET, FIELD_TYPE_FREE_SET, FIELD_TYPE_STRING, FIELD_TYPE_DATE, FIELD_TYPE_BOOLEAN, FIELD_TYPE_INTEGER, FIELD_TYPE_URL, FIELD_TYPE_USER } from '../api/types';
 import { badgeColor } from '../utils/badgeColors';
 import FieldDescriptionTooltip from './FieldDescriptionTooltip';
 
@@ -252,6 +252,59 @@ export default function CustomFieldsEditor({
           </div>
         );
 
+      case FIELD_TYPE_USER: {
+        const collaborators = board.collaborators ?? [];
+        return (
+          <div className={marginClass} key={fieldName}>
+            <label className="flex items-center text-sm font-medium text-gray-700 dark:text-gray-300 mb-1 capitalize">
+              <span>{fieldName}</span>
+              {schema.description && <FieldDescriptionTooltip description={schema.description!} />}
+              {wantedIndicator}
+            </label>
+            {collaborators.length > 0 ? (
+              <select
+                value={(currentValue as string) || ''}
+                onChange={(e) => onChange(fieldName, e.target.value)}
+                className="w-full border border-gray-300 dark:border-gray-600 rounded-md px-3 py-2 focus:outline-none focus:ring-2 focus:ring-blue-500 bg-white dark:bg-gray-700 dark:text-white"
+              >
+                <option value="">None</option>
+                {collaborators.map((name) => (
+                  <option key={name} value={name}>
+                    {name}
+                  </option>
+                ))}
+              </select>
+            ) : (
+              <input
+                type="text"
+                value={(currentValue as string) || ''}
+                onChange={(e) => onChange(fieldName, e.target.value)}
+                className="w-full border border-gray-300 dark:border-gray-600 dark:bg-gray-700 dark:text-white rounded-md px-3 py-2 focus:outline-none focus:ring-2 focus:ring-blue-500"
+                placeholder={`Enter ${fieldName}...`}
+              />
+            )}
+          </div>
+        );
+      }
+
+      case FIELD_TYPE_URL:
+        return (
+          <div className={marginClass} key={fieldName}>
+            <label className="flex items-center text-sm font-medium text-gray-700 dark:text-gray-300 mb-1 capitalize">
+              <span>{fieldName}</span>
+              {schema.description && <FieldDescriptionTooltip description={schema.description!} />}
+              {wantedIndicator}
+            </label>
+            <input
+              type="url"
+              value={(currentValue as string) || ''}
+              onChange={(e) => onChange(fieldName, e.target.value)}
+              className="w-full border border-gray-300 dark:border-gray-600 dark:bg-gray-700 dark:text-white rounded-md px-3 py-2 focus:outline-none focus:ring-2 focus:ring-blue-500"
+              placeholder="https://..."
+            />
+          </div>
+        );
+
       case FIELD_TYPE_DATE:
         return (
           <div className={marginClass} key={fieldName}>
@@ -262,13 +315,33 @@ export default function CustomFieldsEditor({
             </label>
             <input
               type="date"
-              value={(currentValue as string) || ''}
+              value={((currentValue as string) || '').slice(0, 10)}
               onChange={(e) => onChange(fieldName, e.target.value)}
               className="w-full border border-gray-300 dark:border-gray-600 dark:bg-gray-700 dark:text-white rounded-md px-3 py-2 focus:outline-none focus:ring-2 focus:ring-blue-500"
             />
           </div>
         );
 
+      case FIELD_TYPE_INTEGER:
+        return (
+          <div className={marginClass} key={fieldName}>
+            <label className="flex items-center text-sm font-medium text-gray-700 dark:text-gray-300 mb-1 capitalize">
+              <span>{fieldName}</span>
+              {schema.description && <FieldDescriptionTooltip description={schema.description!} />}
+              {wantedIndicator}
+            </label>
+            <input
+              type="number"
+              step={1}
+              min={schema.min}
+              max={schema.max}
+              value={typeof currentValue === 'number' ? currentValue : ''}
+              onChange={(e) => onChange(fieldName, e.target.value === '' ? '' : Number(e.target.value))}
+              className="w-full border border-gray-300 dark:border-gray-600 dark:bg-gray-700 dark:text-white rounded-md px-3 py-2 focus:outline-none focus:ring-2 focus:ring-blue-500"
+            />
+          </div>
+        );
+
       case FIELD_TYPE_BOOLEAN: {
         const isOn = currentValue === true;
         return (